@@ -0,0 +1,27 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+type BackupCmd struct {
+	cmd *cobra.Command
+}
+
+func AddBackupCmd(rootCmd *cobra.Command, opts *util.GlobalOptions) {
+	backup := BackupCmd{
+		cmd: &cobra.Command{
+			Use:   "backup",
+			Short: "Back up parts of a Codesphere installation",
+			Long:  io.Long(`Back up parts of a Codesphere installation.`),
+		},
+	}
+
+	AddBackupPostgresCmd(backup.cmd, opts)
+	util.AddCmd(rootCmd, backup.cmd)
+}
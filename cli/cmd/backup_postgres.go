@@ -0,0 +1,119 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	csio "github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	intutil "github.com/codesphere-cloud/oms/internal/util"
+	"github.com/spf13/cobra"
+)
+
+type BackupPostgresCmd struct {
+	cmd  *cobra.Command
+	Opts *BackupPostgresOpts
+}
+
+type BackupPostgresOpts struct {
+	*util.GlobalOptions
+	Bucket   string
+	SSHQuiet bool
+}
+
+// PGBackupObjectName returns the bucket object name of a backup taken at t.
+func PGBackupObjectName(t time.Time) string {
+	return fmt.Sprintf("pg-backup-%s.sql.gz", t.UTC().Format("20060102-150405"))
+}
+
+// PGBackupCommand returns the shell command that dumps all databases and
+// streams the compressed dump into the backup bucket. It runs as root on the
+// postgres node, which authenticates to postgres via the local unix socket and
+// to GCS via the instance service account.
+func PGBackupCommand(bucket, object string) string {
+	return fmt.Sprintf("set -o pipefail; runuser -u postgres -- pg_dumpall --clean | gzip | gcloud storage cp - gs://%s/%s", bucket, object)
+}
+
+// ResolvePostgresNode returns the postgres node of the environment connected
+// through the jumpbox.
+func ResolvePostgresNode(infraEnv *gcp.CodesphereEnvironment) (*node.Node, error) {
+	if infraEnv.Jumpbox == nil {
+		return nil, fmt.Errorf("infra file does not contain a jumpbox")
+	}
+	if infraEnv.PostgreSQLNode == nil {
+		return nil, fmt.Errorf("no postgres node in the environment")
+	}
+	pg := infraEnv.PostgreSQLNode
+	// Nodes loaded from the infra file lost their jumpbox reference, so
+	// rebuild the node as a sub-node of the connected jumpbox.
+	return infraEnv.Jumpbox.CreateSubNode(pg.GetName(), pg.GetExternalIP(), pg.GetInternalIP()), nil
+}
+
+func (c *BackupPostgresCmd) RunE(_ *cobra.Command, _ []string) error {
+	fw := intutil.NewFilesystemWriter()
+	infraEnv, exists, err := gcp.LoadInfraFile(fw, gcp.GetInfraFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to load infra file: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("infra file not found at %s; bootstrap the environment first", gcp.GetInfraFilePath())
+	}
+	if infraEnv.Jumpbox != nil {
+		infraEnv.Jumpbox.NodeClient = node.NewSSHNodeClient(c.Opts.SSHQuiet)
+	}
+
+	target, err := ResolvePostgresNode(&infraEnv)
+	if err != nil {
+		return err
+	}
+
+	bucket := c.Opts.Bucket
+	if bucket == "" {
+		bucket = gcp.PGBackupBucketName(infraEnv.ProjectID)
+	}
+	object := PGBackupObjectName(time.Now())
+
+	log.Printf("Backing up postgres on %s to gs://%s/%s", target.GetName(), bucket, object)
+	err = target.RunSSHCommand("root", PGBackupCommand(bucket, object))
+	if err != nil {
+		return fmt.Errorf("failed to back up postgres: %w", err)
+	}
+
+	log.Printf("Backup gs://%s/%s completed", bucket, object)
+	return nil
+}
+
+func AddBackupPostgresCmd(rootCmd *cobra.Command, opts *util.GlobalOptions) {
+	backupPostgres := BackupPostgresCmd{
+		cmd: &cobra.Command{
+			Use:   "postgres",
+			Short: "Dump all PostgreSQL databases to the backup bucket",
+			Long: csio.Long(`Runs pg_dumpall on the postgres node via the jumpbox and streams the
+				compressed dump into the GCS backup bucket of the environment.
+				This is the command the scheduled backup job installed with --pg-backups
+				runs, and it can be invoked manually for an ad-hoc backup.
+				The environment is read from the local infra file created during bootstrap.`),
+			Example: util.FormatExamples("beta backup postgres", []csio.Example{
+				{Cmd: "", Desc: "Back up postgres to the environment's backup bucket"},
+				{Cmd: "--bucket my-backups", Desc: "Back up postgres to a custom bucket"},
+			}),
+			Args: cobra.NoArgs,
+		},
+		Opts: &BackupPostgresOpts{
+			GlobalOptions: opts,
+		},
+	}
+
+	flags := backupPostgres.cmd.Flags()
+	flags.StringVar(&backupPostgres.Opts.Bucket, "bucket", "", "GCS bucket to upload the backup to (default: the environment's backup bucket)")
+	flags.BoolVar(&backupPostgres.Opts.SSHQuiet, "ssh-quiet", false, "Suppress SSH command output (default: false)")
+
+	backupPostgres.cmd.RunE = backupPostgres.RunE
+	util.AddCmd(rootCmd, backupPostgres.cmd)
+}
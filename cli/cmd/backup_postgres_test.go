@@ -0,0 +1,89 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/codesphere-cloud/oms/cli/cmd"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+)
+
+var _ = Describe("BackupPostgresCmd", func() {
+	Describe("AddBackupCmd", func() {
+		It("registers the backup postgres command with its flags", func() {
+			parent := &cobra.Command{Use: "beta"}
+			cmd.AddBackupCmd(parent, &util.GlobalOptions{})
+
+			backupPostgres, _, err := parent.Find([]string{"backup", "postgres"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(backupPostgres.Use).To(Equal("postgres"))
+			Expect(backupPostgres.Flags().Lookup("bucket")).NotTo(BeNil())
+			Expect(backupPostgres.Flags().Lookup("ssh-quiet")).NotTo(BeNil())
+		})
+	})
+
+	Describe("AddRestoreCmd", func() {
+		It("registers the restore postgres command with its flags", func() {
+			parent := &cobra.Command{Use: "beta"}
+			cmd.AddRestoreCmd(parent, &util.GlobalOptions{})
+
+			restorePostgres, _, err := parent.Find([]string{"restore", "postgres"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restorePostgres.Use).To(Equal("postgres <object>"))
+			Expect(restorePostgres.Flags().Lookup("bucket")).NotTo(BeNil())
+			Expect(restorePostgres.Flags().Lookup("yes")).NotTo(BeNil())
+		})
+	})
+
+	Describe("PGBackupObjectName", func() {
+		It("names the object after the UTC backup time", func() {
+			taken := time.Date(2026, 8, 31, 2, 0, 0, 0, time.UTC)
+			Expect(cmd.PGBackupObjectName(taken)).To(Equal("pg-backup-20260831-020000.sql.gz"))
+		})
+	})
+
+	Describe("PGBackupCommand", func() {
+		It("streams a compressed dump into the bucket", func() {
+			Expect(cmd.PGBackupCommand("fake-project-pg-backups", "pg-backup-20260831-020000.sql.gz")).To(Equal(
+				"set -o pipefail; runuser -u postgres -- pg_dumpall --clean | gzip | gcloud storage cp - gs://fake-project-pg-backups/pg-backup-20260831-020000.sql.gz"))
+		})
+	})
+
+	Describe("PGRestoreCommand", func() {
+		It("streams the backup object from the bucket into psql", func() {
+			Expect(cmd.PGRestoreCommand("fake-project-pg-backups", "pg-backup-20260831-020000.sql.gz")).To(Equal(
+				"set -o pipefail; gcloud storage cat gs://fake-project-pg-backups/pg-backup-20260831-020000.sql.gz | gunzip | runuser -u postgres -- psql --set ON_ERROR_STOP=on postgres"))
+		})
+	})
+
+	Describe("ResolvePostgresNode", func() {
+		It("rebuilds the postgres node as a sub-node of the jumpbox", func() {
+			infraEnv := &gcp.CodesphereEnvironment{
+				Jumpbox:        &node.Node{Name: "jumpbox", ExternalIP: "34.0.0.1"},
+				PostgreSQLNode: &node.Node{Name: "postgres", InternalIP: "10.0.0.2"},
+			}
+
+			target, err := cmd.ResolvePostgresNode(infraEnv)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(target.GetName()).To(Equal("postgres"))
+			Expect(target.GetInternalIP()).To(Equal("10.0.0.2"))
+		})
+
+		It("fails when the environment has no postgres node", func() {
+			infraEnv := &gcp.CodesphereEnvironment{
+				Jumpbox: &node.Node{Name: "jumpbox", ExternalIP: "34.0.0.1"},
+			}
+
+			_, err := cmd.ResolvePostgresNode(infraEnv)
+			Expect(err).To(MatchError(ContainSubstring("no postgres node")))
+		})
+	})
+})
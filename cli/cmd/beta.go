@@ -29,4 +29,10 @@ func AddBetaCmd(rootCmd *cobra.Command, opts *util.GlobalOptions) {
 	AddBootstrapLocalCmd(beta.cmd)
 	AddBetaInstallCmd(beta.cmd, opts)
 	AddBetaVaultSecretCmd(beta.cmd, opts)
+	AddTunnelCmd(beta.cmd, opts)
+	AddFixCmd(beta.cmd, opts)
+	AddNodeCmd(beta.cmd, opts)
+	AddEnvCmd(beta.cmd, opts)
+	AddBackupCmd(beta.cmd, opts)
+	AddRestoreCmd(beta.cmd, opts)
 }
@@ -0,0 +1,124 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+
+	"github.com/codesphere-cloud/oms/internal/redact"
+)
+
+// BootstrapEnvPrefix is the prefix of environment variables overriding
+// bootstrap flags, e.g. OMS_BOOTSTRAP_PROJECT_NAME for --project-name.
+const BootstrapEnvPrefix = "OMS_BOOTSTRAP_"
+
+// DefaultBootstrapConfigFile is the config file read by default; it may be
+// absent unless --config points somewhere else explicitly.
+const DefaultBootstrapConfigFile = "bootstrap.yaml"
+
+// ApplyLayeredBootstrapConfig fills flags that were not set on the command
+// line from environment variables and a YAML config file, with precedence
+// flags > environment > config file > flag default. The config file maps
+// flag names to values (lists and key/value maps for the respective flag
+// types). Values applied this way satisfy required-flag validation.
+func ApplyLayeredBootstrapConfig(cmd *cobra.Command, configFile string) error {
+	fileValues, err := loadBootstrapConfigFile(cmd, configFile)
+	if err != nil {
+		return err
+	}
+
+	var applyErr error
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if applyErr != nil || flag.Changed {
+			return
+		}
+
+		if value, ok := os.LookupEnv(bootstrapEnvVar(flag.Name)); ok {
+			if err := cmd.Flags().Set(flag.Name, value); err != nil {
+				applyErr = fmt.Errorf("invalid value in %s: %w", bootstrapEnvVar(flag.Name), err)
+			}
+			return
+		}
+
+		if value, ok := fileValues[flag.Name]; ok {
+			if err := setFlagFromConfigValue(cmd.Flags(), flag.Name, value); err != nil {
+				applyErr = fmt.Errorf("invalid value for %q in %s: %w", flag.Name, configFile, err)
+			}
+		}
+	})
+	return applyErr
+}
+
+// loadBootstrapConfigFile reads the config file into a flag-name to value
+// map. A missing file is only an error when its path was set explicitly, so
+// the default bootstrap.yaml remains optional.
+func loadBootstrapConfigFile(cmd *cobra.Command, configFile string) (map[string]any, error) {
+	data, err := os.ReadFile(configFile)
+	if errors.Is(err, os.ErrNotExist) && !cmd.Flags().Changed("config") {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	values := map[string]any{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", configFile, err)
+	}
+
+	for name := range values {
+		if cmd.Flags().Lookup(name) == nil {
+			return nil, fmt.Errorf("unknown option %q in config file %s", name, configFile)
+		}
+	}
+	return values, nil
+}
+
+// setFlagFromConfigValue applies a parsed YAML value to a flag: lists and
+// maps are fed entry by entry so array and key=value flags accumulate them,
+// scalars are set directly.
+func setFlagFromConfigValue(flags *pflag.FlagSet, name string, value any) error {
+	switch typed := value.(type) {
+	case []any:
+		for _, entry := range typed {
+			if err := flags.Set(name, fmt.Sprint(entry)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]any:
+		for key, entry := range typed {
+			if err := flags.Set(name, fmt.Sprintf("%s=%v", key, entry)); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return flags.Set(name, fmt.Sprint(value))
+	}
+}
+
+func bootstrapEnvVar(flagName string) string {
+	return BootstrapEnvPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// PrintEffectiveConfig writes the resolved value of every flag after all
+// configuration layers were applied. Registered secret values are masked.
+func PrintEffectiveConfig(w io.Writer, flags *pflag.FlagSet) {
+	fmt.Fprintln(w, "Effective bootstrap configuration:")
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Name == "help" {
+			return
+		}
+		fmt.Fprintf(w, "  %s: %s\n", flag.Name, redact.Redact(flag.Value.String()))
+	})
+}
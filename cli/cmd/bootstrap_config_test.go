@@ -0,0 +1,135 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/codesphere-cloud/oms/cli/cmd"
+)
+
+var _ = Describe("ApplyLayeredBootstrapConfig", func() {
+	var (
+		testCmd    *cobra.Command
+		projectVal string
+		ttlVal     string
+		skipSteps  []string
+		labels     map[string]string
+		configFile string
+	)
+
+	safeSetEnv := func(key, value string) {
+		originalValue, exists := os.LookupEnv(key)
+		Expect(os.Setenv(key, value)).To(Succeed())
+
+		DeferCleanup(func() {
+			if exists {
+				Expect(os.Setenv(key, originalValue)).To(Succeed())
+			} else {
+				Expect(os.Unsetenv(key)).To(Succeed())
+			}
+		})
+	}
+
+	writeConfigFile := func(content string) {
+		Expect(os.WriteFile(configFile, []byte(content), 0o644)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		configFile = filepath.Join(GinkgoT().TempDir(), "bootstrap.yaml")
+
+		testCmd = &cobra.Command{Use: "bootstrap-gcp"}
+		flags := testCmd.Flags()
+		flags.StringVar(&projectVal, "project-name", "", "")
+		flags.StringVar(&ttlVal, "project-ttl", "2h", "")
+		flags.StringArrayVar(&skipSteps, "install-skip-steps", []string{}, "")
+		flags.StringToStringVar(&labels, "labels", nil, "")
+		flags.String("config", configFile, "")
+	})
+
+	It("applies config file values to unset flags", func() {
+		writeConfigFile("project-name: from-file\n")
+
+		Expect(cmd.ApplyLayeredBootstrapConfig(testCmd, configFile)).To(Succeed())
+
+		Expect(projectVal).To(Equal("from-file"))
+		Expect(ttlVal).To(Equal("2h"))
+	})
+
+	It("satisfies required-flag validation for values from the config file", func() {
+		writeConfigFile("project-name: from-file\n")
+
+		Expect(cmd.ApplyLayeredBootstrapConfig(testCmd, configFile)).To(Succeed())
+
+		Expect(testCmd.Flags().Changed("project-name")).To(BeTrue())
+	})
+
+	It("applies list and key=value map values from the config file", func() {
+		writeConfigFile("install-skip-steps: [step-a, step-b]\nlabels:\n  owner: team-a\n")
+
+		Expect(cmd.ApplyLayeredBootstrapConfig(testCmd, configFile)).To(Succeed())
+
+		Expect(skipSteps).To(Equal([]string{"step-a", "step-b"}))
+		Expect(labels).To(Equal(map[string]string{"owner": "team-a"}))
+	})
+
+	It("prefers environment variables over config file values", func() {
+		writeConfigFile("project-name: from-file\n")
+		safeSetEnv("OMS_BOOTSTRAP_PROJECT_NAME", "from-env")
+
+		Expect(cmd.ApplyLayeredBootstrapConfig(testCmd, configFile)).To(Succeed())
+
+		Expect(projectVal).To(Equal("from-env"))
+	})
+
+	It("prefers flags set on the command line over all other layers", func() {
+		writeConfigFile("project-name: from-file\n")
+		safeSetEnv("OMS_BOOTSTRAP_PROJECT_NAME", "from-env")
+		Expect(testCmd.Flags().Set("project-name", "from-flag")).To(Succeed())
+
+		Expect(cmd.ApplyLayeredBootstrapConfig(testCmd, configFile)).To(Succeed())
+
+		Expect(projectVal).To(Equal("from-flag"))
+	})
+
+	It("rejects unknown options in the config file", func() {
+		writeConfigFile("no-such-flag: value\n")
+
+		err := cmd.ApplyLayeredBootstrapConfig(testCmd, configFile)
+		Expect(err).To(MatchError(ContainSubstring(`unknown option "no-such-flag"`)))
+	})
+
+	It("ignores a missing config file at the default location", func() {
+		Expect(cmd.ApplyLayeredBootstrapConfig(testCmd, configFile)).To(Succeed())
+
+		Expect(projectVal).To(BeEmpty())
+	})
+
+	It("fails when an explicitly set config file is missing", func() {
+		Expect(testCmd.Flags().Set("config", configFile)).To(Succeed())
+
+		err := cmd.ApplyLayeredBootstrapConfig(testCmd, configFile)
+		Expect(err).To(MatchError(ContainSubstring("failed to read config file")))
+	})
+})
+
+var _ = Describe("PrintEffectiveConfig", func() {
+	It("prints the resolved flag values", func() {
+		testCmd := &cobra.Command{Use: "bootstrap-gcp"}
+		testCmd.Flags().String("project-name", "", "")
+		Expect(testCmd.Flags().Set("project-name", "my-project")).To(Succeed())
+
+		out := &bytes.Buffer{}
+		cmd.PrintEffectiveConfig(out, testCmd.Flags())
+
+		Expect(out.String()).To(ContainSubstring("Effective bootstrap configuration:"))
+		Expect(out.String()).To(ContainSubstring("project-name: my-project"))
+	})
+})
@@ -4,21 +4,32 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
 
 	"github.com/codesphere-cloud/cs-go/pkg/io"
 	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/audit"
 	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/dns"
 	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/cloudflare"
 	"github.com/codesphere-cloud/oms/internal/env"
 	"github.com/codesphere-cloud/oms/internal/github"
 	"github.com/codesphere-cloud/oms/internal/installer"
 	"github.com/codesphere-cloud/oms/internal/installer/node"
 	"github.com/codesphere-cloud/oms/internal/portal"
+	"github.com/codesphere-cloud/oms/internal/redact"
+	"github.com/codesphere-cloud/oms/internal/secretstore"
+	"github.com/codesphere-cloud/oms/internal/telemetry"
 	intutil "github.com/codesphere-cloud/oms/internal/util"
 )
 
@@ -29,12 +40,42 @@ type BootstrapGcpCmd struct {
 	CodesphereEnv     *gcp.CodesphereEnvironment
 	InputRegistryType string
 	SSHQuiet          bool
+	EstimateCost      bool
+	CompactSteps      bool
+	CIRunnerOrg       string
+	CIRunnerType      string
+	CIRunnerLabels    []string
+	Force             bool
+	InsecureHostKeys  bool
+	// MaintenanceWindowSpec backs --maintenance-window and is parsed into
+	// the environment's maintenance window.
+	MaintenanceWindowSpec string
+	// ConfigFile is a YAML file providing defaults for any bootstrap flag;
+	// environment variables and flags set on the command line override it.
+	ConfigFile           string
+	PrintEffectiveConfig bool
+	// Auth selects the GCP credential source: adc (default) or oidc for
+	// workload identity federation from a CI job.
+	Auth               string
+	AuthOIDCAudience   string
+	AuthServiceAccount string
+	// ImpersonateServiceAccount runs all GCP clients with impersonated
+	// credentials of a provisioning service account on top of the
+	// operator's own identity.
+	ImpersonateServiceAccount string
+	// SecretStoreType selects an external password manager to push
+	// generated credentials into after bootstrap.
+	SecretStoreType  string
+	SecretStoreURL   string
+	SecretStoreVault string
 	// experiments backs the deprecated --experiments flag; its values
 	// are folded into the internal bucket for backwards compatibility.
 	experiments []string
 }
 
 func (c *BootstrapGcpCmd) RunE(_ *cobra.Command, args []string) error {
+	node.SetInsecureIgnoreHostKeys(c.InsecureHostKeys)
+
 	err := c.BootstrapGcp()
 	if err != nil {
 		return fmt.Errorf("failed to bootstrap: %w", err)
@@ -59,6 +100,12 @@ func AddBootstrapGcpCmd(parent *cobra.Command, opts *util.GlobalOptions) {
 		CodesphereEnv: &gcp.CodesphereEnvironment{},
 	}
 	bootstrapGcpCmd.cmd.RunE = bootstrapGcpCmd.RunE
+	// Layered configuration (config file < environment variables < flags) is
+	// applied before cobra validates required flags, so required options may
+	// come from any layer.
+	bootstrapGcpCmd.cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		return ApplyLayeredBootstrapConfig(cmd, bootstrapGcpCmd.ConfigFile)
+	}
 
 	flags := bootstrapGcpCmd.cmd.Flags()
 	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.ProjectName, "project-name", "", "Unique GCP Project Name (required)")
@@ -84,28 +131,74 @@ func AddBootstrapGcpCmd(parent *cobra.Command, opts *util.GlobalOptions) {
 	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.SecretsDir, "secrets-dir", "/etc/codesphere/secrets", "Directory for secrets (default: /etc/codesphere/secrets)")
 	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.FolderID, "folder-id", "", "GCP Folder ID (optional)")
 	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.SSHPublicKeyPath, "ssh-public-key-path", "~/.ssh/id_rsa.pub", "SSH Public Key Path (default: ~/.ssh/id_rsa.pub)")
-	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.SSHPrivateKeyPath, "ssh-private-key-path", "~/.ssh/id_rsa", "SSH Private Key Path (default: ~/.ssh/id_rsa)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.SSHPrivateKeyPath, "ssh-private-key-path", "~/.ssh/id_rsa", "SSH Private Key Path. Not needed when the key is held by a running ssh-agent, which is also required for hardware-backed (FIDO2/PIV) keys (default: ~/.ssh/id_rsa)")
 	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.Preemptible, "preemptible", false, "Use preemptible VMs for Codesphere infrastructure. Mutually exclusive with --spot-vms (default: false)")
 	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.SpotVMs, "spot-vms", false, "Use Spot VMs for Codesphere infrastructure. Falls back to standard VMs if spot capacity unavailable. Mutually exclusive with --preemptible (default: false)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.ShieldedVMs, "shielded-vms", false, "Enable all Shielded VM features (secure boot, vTPM, integrity monitoring) on created instances. Use the individual --shielded-* flags to enable a subset (default: false)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.GenerateDiskEncryptionKey, "generate-disk-encryption-key", false, "Create a per-environment Cloud KMS key and encrypt all instance disks with it. Cleanup destroys the key for cryptographic erasure and records a destruction certificate in the teardown report. Mutually exclusive with --disk-encryption-key (default: false)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.ShieldedSecureBoot, "shielded-secure-boot", false, "Enable Shielded VM secure boot on created instances (default: false)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.ShieldedVtpm, "shielded-vtpm", false, "Enable the Shielded VM virtual TPM on created instances (default: false)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.ShieldedIntegrityMonitoring, "shielded-integrity-monitoring", false, "Enable Shielded VM integrity monitoring on created instances (default: false)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.ConfidentialVMs, "confidential-vms", false, "Enable Confidential VM encryption of memory on created instances. Requires a supported machine type (default: false)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.DiskEncryptionKey, "disk-kms-key", "", "Full resource name of a Cloud KMS key used to encrypt all VM disks (projects/.../locations/.../keyRings/.../cryptoKeys/...) (optional)")
+	flags.StringToStringVar(&bootstrapGcpCmd.CodesphereEnv.SourceImages, "source-image", nil, "Per-role boot image overrides as role=image pairs (roles: jumpbox, postgres, ceph, k0s). Accepts image or image family paths and bare image names in the bootstrap project (optional)")
+	flags.StringToStringVar(&bootstrapGcpCmd.CodesphereEnv.ServiceAccountScopes, "service-account-scopes", nil, "Per-role service account scope overrides as role=scopes pairs (roles: jumpbox, postgres, ceph, k0s), with multiple scopes separated by semicolons. Defaults to the cloud-platform scope (optional)")
+	flags.IntVar(&bootstrapGcpCmd.CodesphereEnv.NodeParallelism, "node-parallelism", bootstrap.DefaultNodeParallelism, "Maximum number of nodes that per-node configuration steps work on concurrently (default: 4)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.CleanPartialResources, "clean-partial-resources", false, "Delete labeled instances and addresses left behind by a previous interrupted run instead of adopting them (default: false)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.NodeOS, "node-os", "ubuntu", "Operating system family of the node boot images: ubuntu, rhel, or suse. Usually combined with --source-image (default: ubuntu)")
+	flags.DurationVar(&bootstrapGcpCmd.CodesphereEnv.StepTimeout, "step-timeout", 0, "Abort a bootstrap step when it runs longer than this duration, e.g. 30m. Zero disables the timeout (default: 0)")
 	flags.IntVar(&bootstrapGcpCmd.CodesphereEnv.DatacenterID, "datacenter-id", 1, "Datacenter ID (default: 1)")
 	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.DatacenterName, "datacenter-name", "dev", "Datacenter name (default: dev)")
 	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.CustomPgIP, "custom-pg-ip", "", "Custom PostgreSQL IP (optional)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.HAPostgres, "ha-postgres", false, "Provision a PostgreSQL primary and replica pair instead of a single postgres VM (default: false)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.PostgresMode, "postgres-mode", gcp.PostgresModeVM, "How PostgreSQL is provisioned: vm or cloudsql (managed Cloud SQL instance with a private IP, no postgres VM) (default: vm)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.PGBackups, "pg-backups", false, "Provision a GCS backup bucket and schedule automatic PostgreSQL backups from the jumpbox (default: false)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.PGBackupSchedule, "pg-backup-schedule", "daily", "Schedule of the PostgreSQL backup job, as a systemd OnCalendar expression (default: daily)")
+	flags.Int64Var(&bootstrapGcpCmd.CodesphereEnv.PGBackupRetentionDays, "pg-backup-retention-days", 30, "Days after which backups are deleted from the bucket by its lifecycle policy (default: 30)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.StorageBackend, "storage-backend", gcp.StorageBackendCeph, "CSI stack providing workspace storage: ceph, longhorn, or local-path. Non-Ceph backends provision no ceph VMs and are intended for small PoC environments (default: ceph)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.K8sDistribution, "k8s-distribution", "", "Kubernetes distribution deployed on the cluster nodes: k0s, k3s, or rke2 (default: k0s)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.KubeconfigPath, "kubeconfig", "", "Kubeconfig of an existing Kubernetes cluster to install Codesphere onto. Provisions no k8s VMs (optional)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.KubernetesVersion, "kubernetes-version", "", "Pin the k0s release running on the cluster nodes, e.g. v1.33.2+k0s.0 (default: version bundled by the installer)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.CCMVersion, "ccm-version", "", "cloud-provider-gcp release deployed on the cluster (default: "+gcp.DefaultCCMVersion+")")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.CCMImage, "ccm-image", "", "Cloud controller manager image (default: derived from the CCM version)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.CCMManifestURL, "ccm-manifest-url", "", "Upstream manifest with the RBAC rules of the cloud controller manager, e.g. a mirror (default: derived from the CCM version)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.StorageMode, "storage-mode", gcp.StorageModeVM, "Where workspace storage comes from: vm (Ceph cluster on dedicated VMs) or external (existing Ceph cluster, no ceph VMs) (default: vm)")
+	flags.StringArrayVar(&bootstrapGcpCmd.CodesphereEnv.CephMonHosts, "ceph-mon-hosts", []string{}, "Monitor addresses of the existing Ceph cluster (required with --storage-mode external)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.CephKeyringFile, "ceph-keyring-file", "", "File with the keyring of the existing Ceph cluster, stored in the vault (required with --storage-mode external)")
+	flags.Int64Var(&bootstrapGcpCmd.CodesphereEnv.CephDataDiskGB, "ceph-data-disk-size", gcp.DefaultCephDataDiskGB, "Size in GB of each OSD data disk on the ceph VMs (default: 100)")
+	flags.Int64Var(&bootstrapGcpCmd.CodesphereEnv.CephDBDiskGB, "ceph-db-disk-size", gcp.DefaultCephDBDiskGB, "Size in GB of each OSD DB disk on the ceph VMs. Must be smaller than half the data disk size (default: 10)")
+	flags.IntVar(&bootstrapGcpCmd.CodesphereEnv.CephOSDsPerHost, "ceph-osds-per-host", gcp.DefaultCephOSDsPerHost, "Number of OSDs (data/DB disk pairs) per ceph host (default: 1)")
+	flags.IntVar(&bootstrapGcpCmd.CodesphereEnv.CephReplicaCount, "ceph-replica-count", 0, "Number of data replicas in the Ceph pool backing workspace volumes; 0 keeps the installer default (default: 0)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.CephFailureDomain, "ceph-failure-domain", "", "Failure domain spreading the pool replicas: host or osd; empty keeps the installer default")
 	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.Region, "region", "europe-west4", "GCP Region (default: europe-west4)")
 	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.Zone, "zone", "europe-west4-a", "GCP Zone (default: europe-west4-a)")
 	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.DNSProjectID, "dns-project-id", "", "GCP Project ID for Cloud DNS (optional)")
 	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.DNSZoneName, "dns-zone-name", "oms-testing", "Cloud DNS Zone Name (optional)")
+	flags.Int64Var(&bootstrapGcpCmd.CodesphereEnv.DNSRecordTTL, "dns-record-ttl", gcp.DefaultDNSRecordTTL, "TTL in seconds for DNS records created during bootstrap (default: 300)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.DNSProvider, "dns-provider", dns.ProviderCloudDNS, "DNS provider managing the zone and records: clouddns or route53 (default: clouddns)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.DNSMode, "dns-mode", gcp.DNSModeManaged, "DNS mode: managed creates records, manual prints the required records and waits until they resolve (default: managed)")
+	flags.DurationVar(&bootstrapGcpCmd.CodesphereEnv.ManualDNSTimeout, "dns-verify-timeout", gcp.DefaultManualDNSTimeout, "How long manual DNS mode waits for the records to resolve (default: 15m)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.DNSSEC, "dnssec", false, "Enable DNSSEC signing on the managed Cloud DNS zone (default: false)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.IPv6, "ipv6", false, "Enable dual-stack networking with IPv6 gateway addresses and AAAA records (default: false)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.ApexDomainRecords, "dns-apex-records", false, "Additionally create an A record at the zone apex (the base domain itself) pointing to the gateway. Requires the base domain to be the apex of the managed zone (default: false)")
 	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.InstallLocal, "install-local", "", "Install Codesphere from local package (default: none)")
 	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.InstallVersion, "install-version", "", "Codesphere version to install (default: none)")
 	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.InstallHash, "install-hash", "", "Codesphere package hash to install (default: none)")
 	flags.StringArrayVarP(&bootstrapGcpCmd.CodesphereEnv.InstallSkipSteps, "install-skip-steps", "s", []string{}, "Installation steps to skip during Codesphere installation (optional)")
 	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.RemoteOmsBinaryPath, "remote-oms-binary", "", "Path to a local Linux amd64 OMS binary to copy to and use on the jumpbox instead of downloading a release (optional)")
+	flags.StringArrayVar(&bootstrapGcpCmd.CodesphereEnv.PrePullImages, "pre-pull-images", []string{}, "Workspace agent/base images to pre-pull onto all worker nodes after provisioning, so the first workspace start does not wait for the pull (optional)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.PreWarmDeployImages, "pre-warm-deploy-images", false, "Pre-pull the deploy config image set onto newly created worker nodes before installation, so scaled-up workers do not start with a cold image cache (default: false)")
 	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.RegistryUser, "registry-user", "", "Custom Registry username (only for GitHub registry type) (optional)")
-	flags.StringVar(&bootstrapGcpCmd.InputRegistryType, "registry-type", "local-container", "Container registry type to use (options: local-container, artifact-registry) (default: local-container)")
-	flags.StringArrayVar(&bootstrapGcpCmd.CodesphereEnv.InternalFlags, "internal-flags", gcp.DefaultInternalFlags, "Internal flags to enable in Codesphere installation (optional)")
+	flags.StringVar(&bootstrapGcpCmd.InputRegistryType, "registry-type", "local-container", "Container registry type to use (options: local-container, artifact-registry, harbor) (default: local-container)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.HarborVersion, "harbor-version", "", "Harbor release to deploy (only for harbor registry type) (default: "+gcp.DefaultHarborVersion+")")
+	flags.Int64Var(&bootstrapGcpCmd.CodesphereEnv.HarborDiskGB, "harbor-disk-size", 0, fmt.Sprintf("Disk size of the Harbor VM in GB, holding the registry data (only for harbor registry type) (default: %d)", gcp.DefaultHarborDiskGB))
+	flags.IntVar(&bootstrapGcpCmd.CodesphereEnv.RegistryKeepTagCount, "registry-keep-tag-count", 10, "Number of most recent tagged image versions the artifact registry cleanup policy keeps per image. Set to 0 together with --registry-untagged-retention-days=0 to disable cleanup (only for artifact-registry registry type)")
+	flags.IntVar(&bootstrapGcpCmd.CodesphereEnv.RegistryUntaggedRetentionDays, "registry-untagged-retention-days", 30, "Days after which the artifact registry cleanup policy deletes untagged image versions (only for artifact-registry registry type)")
+	flags.StringArrayVar(&bootstrapGcpCmd.CodesphereEnv.InternalFlags, "internal-flags", nil, "Internal flags to enable in Codesphere installation; defaults come from the installed release's metadata (optional)")
 	flags.StringArrayVar(&bootstrapGcpCmd.experiments, "experiments", []string{}, "Deprecated: use --internal-flags instead. Values are added to the internal flags.")
 	_ = flags.MarkDeprecated("experiments", "use --internal-flags instead")
-	flags.StringArrayVar(&bootstrapGcpCmd.CodesphereEnv.PreviewFlags, "preview-flags", gcp.DefaultPreviewFlags, "Preview flags to enable in Codesphere installation (optional)")
-	flags.StringArrayVar(&bootstrapGcpCmd.CodesphereEnv.FeatureFlags, "feature-flags", gcp.DefaultFeatureFlags, "Feature flags to enable in Codesphere installation (optional)")
+	flags.StringArrayVar(&bootstrapGcpCmd.CodesphereEnv.PreviewFlags, "preview-flags", nil, "Preview flags to enable in Codesphere installation; defaults come from the installed release's metadata (optional)")
+	flags.StringArrayVar(&bootstrapGcpCmd.CodesphereEnv.FeatureFlags, "feature-flags", nil, "Feature flags to enable in Codesphere installation; defaults come from the installed release's metadata (optional)")
 	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.ExternalLokiEndpoint, "external-loki-endpoint", "", "External Loki endpoint for Grafana Alloy log forwarding (optional)")
 	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.ExternalLokiSecret, "external-loki-secret", "", "External Loki password stored in the generated vault (optional)")
 	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.ExternalLokiUser, "external-loki-user", "", "External Loki username for Grafana Alloy log forwarding (optional)")
@@ -117,8 +210,52 @@ func AddBootstrapGcpCmd(parent *cobra.Command, opts *util.GlobalOptions) {
 	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.InstallConfigPath, "install-config", "config.yaml", "Path to install config file (optional)")
 	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.SecretsFilePath, "secrets-file", "prod.vault.yaml", "Path to secrets files (optional)")
 	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.WriteConfig, "write-config", true, "Write generated install config to file (default: true)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.KMSVault, "kms-vault", false, "Encrypt the secrets vault with a Cloud KMS key instead of a local age key. The key ring is created during bootstrap and the jumpbox service account is granted decrypt rights (default: false)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.SecretBackend, "secret-backend", "", "Store generated secrets in an external backend instead of the vault file, referencing them by URI: gcpsm (GCP Secret Manager) or hcvault (HashiCorp Vault, configured via VAULT_ADDR and VAULT_TOKEN) (optional)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.InstallProfile, "profile", "", "Configuration profile applied when generating a new install config (dev, production, minimal, ha-production, airgapped, gpu) or a path to a profile file (default: minimal)")
 	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.RecoverConfig, "recover-config", false, "Recover previously generated install config from the jumpbox. This will overwrite the local config! (default: false)")
+	flags.StringVar(&bootstrapGcpCmd.Auth, "auth", gcp.AuthModeADC, "GCP credential source: adc uses Application Default Credentials, oidc exchanges the CI job's OIDC token via workload identity federation (default: adc)")
+	flags.StringVar(&bootstrapGcpCmd.AuthOIDCAudience, "auth-oidc-audience", "", "Full resource name of the workload identity provider used for the OIDC token exchange (required with --auth=oidc)")
+	flags.StringVar(&bootstrapGcpCmd.AuthServiceAccount, "auth-impersonate-service-account", "", "Service account email to impersonate after the OIDC token exchange (optional, used with --auth=oidc)")
+	flags.StringVar(&bootstrapGcpCmd.ImpersonateServiceAccount, "impersonate-service-account", "", "Run all GCP operations with impersonated credentials of this provisioning service account instead of the operator's own identity. Requires the iam.serviceAccountTokenCreator role on the account (optional)")
+	flags.StringVar(&bootstrapGcpCmd.SecretStoreType, "secret-store", "", "Push generated credentials (registry, postgres admin) into this password manager after bootstrap: 1password, bitwarden or vault. Access tokens are read from OP_CONNECT_TOKEN and VAULT_TOKEN (optional)")
+	flags.StringVar(&bootstrapGcpCmd.SecretStoreURL, "secret-store-url", "", "URL of the secret store API: the 1Password Connect server, the local 'bw serve' REST API, or the Vault server address (optional, used with --secret-store)")
+	flags.StringVar(&bootstrapGcpCmd.SecretStoreVault, "secret-store-vault", "", "Scope inside the secret store: the 1Password vault ID, the Bitwarden folder ID, or the Vault KV v2 mount (optional, used with --secret-store)")
 	flags.BoolVar(&bootstrapGcpCmd.SSHQuiet, "ssh-quiet", false, "Suppress SSH command output (default: false)")
+	flags.BoolVar(&bootstrapGcpCmd.CompactSteps, "compact-steps", false, "Print one plain line per completed step instead of in-place progress. Recommended for CI logs (default: false)")
+	flags.StringVar(&bootstrapGcpCmd.ConfigFile, "config", DefaultBootstrapConfigFile, "YAML file providing defaults for any bootstrap flag as flag-name: value pairs. Environment variables (OMS_BOOTSTRAP_<FLAG_NAME>) and flags set on the command line take precedence (optional)")
+	flags.BoolVar(&bootstrapGcpCmd.PrintEffectiveConfig, "print-effective-config", false, "Print the resolved bootstrap configuration after merging config file, environment variables, and flags before execution (default: false)")
+	flags.IntVar(&bootstrapGcpCmd.CodesphereEnv.SSHPort, "ssh-port", 22, "Port sshd listens on on all provisioned nodes, applied via the sshd hardening drop-in and reflected in the SSH firewall rules (default: 22)")
+	flags.BoolVar(&bootstrapGcpCmd.InsecureHostKeys, "insecure-ignore-host-keys", false, "Skip SSH host key verification against the managed known_hosts file. Only for environments where host keys legitimately change (default: false)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.VerificationSchedule, "verification-schedule", "", "Install a scheduled job that runs 'oms verify config' against the cluster, as a systemd OnCalendar expression (e.g. hourly) (optional)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.VerificationWebhook, "verification-webhook", "", "URL the scheduled verification job posts its results to as JSON (optional, used with --verification-schedule)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.SecurityContact, "security-contact", "", "Email registered as the project's Essential Contact for security notifications (optional)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.BillingContact, "billing-contact", "", "Email registered as the project's Essential Contact for billing notifications (optional)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.TechnicalContact, "technical-contact", "", "Email registered as the project's Essential Contact for technical notifications (optional)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.ProjectOwner, "project-owner", "", "Owner recorded as the 'owner' label on the project (optional)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.CustomerID, "customer-id", "", "Customer ID recorded as the 'customer-id' label on the project (optional)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.NodeLocalDNS, "node-local-dns", false, "Deploy a node-local-dns cache on the cluster nodes and use it as the Codesphere DNS server instead of a public resolver (default: false)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.UseIAPTunnel, "iap-tunnel", false, "Provision instances without external IPs and connect through Google Identity-Aware Proxy tunnels. Requires gcloud on the operator machine (default: false)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.NoJumpbox, "no-jumpbox", false, "Do not provision a jumpbox VM; the operator machine reaches all nodes directly through IAP tunnels and jumpbox duties run on the first control plane node. Requires --iap-tunnel (default: false)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.DedicatedControlPlane, "dedicated-control-plane", false, "Taint the base k0s nodes as control planes and schedule workloads only on a dedicated worker pool (default: false)")
+	flags.IntVar(&bootstrapGcpCmd.CodesphereEnv.WorkerCount, "worker-count", 0, "Number of dedicated worker nodes to provision. Requires --dedicated-control-plane")
+	flags.StringSliceVar(&bootstrapGcpCmd.CodesphereEnv.WorkerTaints, "worker-taints", nil, "Taints applied to every dedicated worker node, in key[=value]:effect format (optional)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.ArtifactsBucket, "artifacts-bucket", "", "GCS bucket mounted via gcsfuse to hold the install package and extracted artifacts (optional)")
+	flags.Int64Var(&bootstrapGcpCmd.CodesphereEnv.ArtifactsDiskGB, "artifacts-disk-size", 0, "Size in GB of an extra jumpbox disk holding the install package and extracted artifacts (optional)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.UseCloudflare, "cloudflare", false, "Proxy DNS records through Cloudflare, provision an origin certificate, and restrict web ingress to Cloudflare IP ranges. Requires --cloudflare-zone-id and --cloudflare-api-token (default: false)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.CloudflareZoneID, "cloudflare-zone-id", "", "Cloudflare zone ID of the base domain (required with --cloudflare)")
+	flags.StringVar(&bootstrapGcpCmd.CodesphereEnv.CloudflareAPIToken, "cloudflare-api-token", "", "Cloudflare API token with DNS and origin CA permissions (required with --cloudflare)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.CloudArmorPolicy, "cloud-armor", false, "Provision a Cloud Armor security policy (rate limiting, OWASP rules) and attach it to the public gateway (default: false)")
+	flags.Int32Var(&bootstrapGcpCmd.CodesphereEnv.CloudArmorRateLimitQPM, "cloud-armor-rate-limit", 600, "Cloud Armor per-IP rate limit in requests per minute (default: 600)")
+	flags.StringArrayVar(&bootstrapGcpCmd.CodesphereEnv.CloudArmorBlockedCountries, "cloud-armor-blocked-countries", []string{}, "ISO 3166-1 alpha-2 country codes blocked by the Cloud Armor policy (optional)")
+	flags.StringToStringVar(&bootstrapGcpCmd.CodesphereEnv.ResourceLabels, "labels", nil, "Labels applied to every created resource in addition to oms-managed=true, as key=value pairs (e.g. owner=team-a,cost-center=123,environment=prod) (optional)")
+	flags.StringVar(&bootstrapGcpCmd.CIRunnerOrg, "ci-runner-org", "", "Provision a GitHub Actions runner VM and register it with this GitHub organization. Requires --github-pat with the admin:org scope (optional)")
+	flags.StringVar(&bootstrapGcpCmd.CIRunnerType, "ci-runner-machine-type", "", "Machine type for the CI runner VM (default: e2-medium)")
+	flags.StringArrayVar(&bootstrapGcpCmd.CIRunnerLabels, "ci-runner-labels", []string{}, "Additional labels for the CI runner, next to oms and the project ID (optional)")
+	flags.BoolVar(&bootstrapGcpCmd.EstimateCost, "estimate-cost", false, "Print an approximate monthly cost for the planned topology based on Cloud Billing Catalog list prices and ask for confirmation before provisioning (default: false)")
+	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.Protected, "protect", false, "Mark the environment as protected: cleanup and re-bootstrapping then require --force and typing the project ID (default: false)")
+	flags.BoolVar(&bootstrapGcpCmd.Force, "force", false, "Override the protection lock of a protected environment. Still requires typing the project ID (default: false)")
+	flags.StringVar(&bootstrapGcpCmd.MaintenanceWindowSpec, "maintenance-window", "", "Maintenance window for disruptive operations, e.g. 'Sat,Sun 22:00-04:00 Europe/Berlin'. Outside the window they are refused unless --override-window is passed (optional)")
 	flags.BoolVar(&bootstrapGcpCmd.CodesphereEnv.CreateTestUser, "create-test-user", false, "Create a test user with API token on the bootstrapped instance for smoke testing (default: false)")
 	flags.Int64Var(&bootstrapGcpCmd.CodesphereEnv.RootDiskSize, "root-disk-size", 50, "Instance root disk size in GB (default: 50)")
 
@@ -144,16 +281,80 @@ func AddBootstrapGcpCmd(parent *cobra.Command, opts *util.GlobalOptions) {
 	AddBootstrapGcpPostconfigCmd(bootstrapGcpCmd.cmd, opts)
 	AddBootstrapGcpCleanupCmd(bootstrapGcpCmd.cmd, opts)
 	AddBootstrapGcpRestartVMsCmd(bootstrapGcpCmd.cmd, opts)
+	AddBootstrapGcpScaleCmd(bootstrapGcpCmd.cmd, opts)
+	AddBootstrapGcpRegistryCleanupCmd(bootstrapGcpCmd.cmd, opts)
+	AddBootstrapGcpListCmd(bootstrapGcpCmd.cmd, opts)
+	AddBootstrapGcpTimersCmd(bootstrapGcpCmd.cmd, opts)
+	AddBootstrapGcpGraphCmd(bootstrapGcpCmd.cmd, opts)
 }
 
-func (c *BootstrapGcpCmd) BootstrapGcp() error {
-	ctx := c.cmd.Context()
+func (c *BootstrapGcpCmd) BootstrapGcp() (retErr error) {
+	// Ctrl-C cleanly aborts the current step; the infrastructure file is
+	// still written below so a re-run picks up where the bootstrap stopped.
+	ctx, stop := signal.NotifyContext(c.cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	shutdownTracing, err := telemetry.SetupTracing(ctx, "oms-bootstrap")
+	if err != nil {
+		return fmt.Errorf("failed to set up tracing: %w", err)
+	}
+	defer func() { _ = shutdownTracing(context.Background()) }()
+	// The root span spans the whole run; step and GCP API spans created from
+	// this context become its children.
+	ctx, rootSpan := telemetry.StartSpan(ctx, "bootstrap-gcp")
+	defer func() { telemetry.EndSpan(rootSpan, retErr) }()
+
+	// Secrets passed in via flags must never show up in logs or reports.
+	redact.Add(c.CodesphereEnv.GitHubPAT, c.CodesphereEnv.CloudflareAPIToken, c.CodesphereEnv.PrometheusRemoteWritePassword)
+
+	if c.PrintEffectiveConfig {
+		PrintEffectiveConfig(os.Stdout, c.cmd.Flags())
+	}
+
+	// Every mutating GCP call and SSH command of this run is recorded for
+	// later security review.
+	if err := audit.Open(c.Env.GetOmsWorkdir()); err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer func() { _ = audit.Close() }()
+
 	stlog := bootstrap.NewStepLogger(false)
+	stlog.SetCompact(c.CompactSteps)
 	icg := installer.NewInstallConfigManager()
-	gcpClient := gcp.NewGCPClient(ctx, stlog, os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"))
+
+	credentialsFile := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	switch c.Auth {
+	case gcp.AuthModeOIDC:
+		var err error
+		credentialsFile, err = gcp.SetupOIDCAuth(ctx, gcp.OIDCAuthConfig{
+			Audience:       c.AuthOIDCAudience,
+			ServiceAccount: c.AuthServiceAccount,
+			Workdir:        c.Env.GetOmsWorkdir(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set up OIDC auth: %w", err)
+		}
+	case gcp.AuthModeADC, "":
+	default:
+		return fmt.Errorf("unsupported auth mode %q (supported: adc, oidc)", c.Auth)
+	}
+
+	if c.ImpersonateServiceAccount != "" {
+		if c.Auth == gcp.AuthModeOIDC {
+			return fmt.Errorf("--impersonate-service-account cannot be combined with --auth=oidc; use --auth-impersonate-service-account instead")
+		}
+		var err error
+		credentialsFile, err = gcp.SetupImpersonatedAuth(c.ImpersonateServiceAccount, c.Env.GetOmsWorkdir())
+		if err != nil {
+			return fmt.Errorf("failed to set up impersonated auth: %w", err)
+		}
+	}
+
+	gcpClient := gcp.NewGCPClient(ctx, stlog, credentialsFile)
 	fw := intutil.NewFilesystemWriter()
 	portalClient := portal.NewPortalClient()
 	githubClient := github.NewGitHubClient(ctx, c.CodesphereEnv.GitHubPAT)
+	cloudflareClient := cloudflare.NewCloudflareClient(c.CodesphereEnv.CloudflareAPIToken)
 
 	bs, err := gcp.NewGCPBootstrapper(
 		ctx,
@@ -167,6 +368,7 @@ func (c *BootstrapGcpCmd) BootstrapGcp() error {
 		portalClient,
 		intutil.NewTime(),
 		githubClient,
+		cloudflareClient,
 	)
 	if err != nil {
 		return err
@@ -181,6 +383,30 @@ func (c *BootstrapGcpCmd) BootstrapGcp() error {
 		}
 	}
 
+	if c.CIRunnerOrg != "" {
+		c.CodesphereEnv.CIRunner = &gcp.CIRunnerConfig{
+			Org:         c.CIRunnerOrg,
+			MachineType: c.CIRunnerType,
+			Labels:      c.CIRunnerLabels,
+		}
+	}
+
+	if c.MaintenanceWindowSpec != "" {
+		window, err := gcp.ParseMaintenanceWindow(c.MaintenanceWindowSpec)
+		if err != nil {
+			return err
+		}
+		c.CodesphereEnv.MaintenanceWindow = window
+	}
+
+	if c.SecretStoreType != "" {
+		c.CodesphereEnv.SecretStore = &secretstore.Config{
+			Type:  c.SecretStoreType,
+			URL:   c.SecretStoreURL,
+			Vault: c.SecretStoreVault,
+		}
+	}
+
 	if c.cmd.Flags().Changed("experiments") {
 		if c.cmd.Flags().Changed("internal-flags") {
 			log.Printf("Warning: both --experiments and --internal-flags were set; ignoring deprecated --experiments values %v", c.experiments)
@@ -189,8 +415,81 @@ func (c *BootstrapGcpCmd) BootstrapGcp() error {
 		}
 	}
 
+	infraEnv, infraExists, err := gcp.LoadInfraFile(fw, gcp.GetInfraFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to load infra file: %w", err)
+	}
+	if infraExists && infraEnv.Protected {
+		// An earlier bootstrap marked this environment as protected; keep the
+		// lock across re-bootstraps unless --protect is explicitly changed.
+		if !c.cmd.Flags().Changed("protect") {
+			c.CodesphereEnv.Protected = true
+		}
+		if err := gcp.ConfirmProtectedOperation(infraEnv.ProjectID, "re-bootstrapping", c.Force, os.Stdin); err != nil {
+			return err
+		}
+	}
+	if infraExists && infraEnv.MaintenanceWindow != nil && !c.cmd.Flags().Changed("maintenance-window") {
+		// Keep the window an earlier bootstrap configured across re-bootstraps.
+		c.CodesphereEnv.MaintenanceWindow = infraEnv.MaintenanceWindow
+	}
+
+	// A previous bootstrap left an ops node behind: take the environment-wide
+	// operation lock so concurrent runs of other operators fail fast instead
+	// of interfering with each other.
+	if infraExists && (infraEnv.Jumpbox != nil || len(infraEnv.ControlPlaneNodes) > 0) {
+		lockEnv := infraEnv
+		lockBs, err := gcp.NewGCPBootstrapper(
+			ctx,
+			c.Env,
+			stlog,
+			&lockEnv,
+			icg,
+			gcpClient,
+			fw,
+			node.NewSSHNodeClient(c.SSHQuiet),
+			portalClient,
+			intutil.NewTime(),
+			githubClient,
+			cloudflareClient,
+		)
+		if err != nil {
+			return err
+		}
+		if err := lockBs.AcquireOperationLock("bootstrap"); err != nil {
+			return err
+		}
+		defer func() {
+			if err := lockBs.ReleaseOperationLock(); err != nil {
+				log.Printf("Warning: failed to release operation lock: %v", err)
+			}
+		}()
+	}
+
+	if c.EstimateCost {
+		estimate, err := bs.EstimateCost()
+		if err != nil {
+			return fmt.Errorf("failed to estimate cost: %w", err)
+		}
+		if err := estimate.Print(os.Stdout); err != nil {
+			return err
+		}
+		if err := confirmProvisioning(bufio.NewReader(os.Stdin)); err != nil {
+			return err
+		}
+	}
+
 	err = bs.Bootstrap()
 
+	// Print and persist the step timing summary regardless of the outcome so
+	// slow or flaky steps are visible for failed runs too.
+	if summaryErr := stlog.PrintSummary(os.Stdout); summaryErr != nil {
+		log.Printf("Warning: failed to print bootstrap summary: %v", summaryErr)
+	}
+	if reportErr := stlog.WriteReport(c.Env.GetOmsWorkdir()); reportErr != nil {
+		log.Printf("Warning: failed to write bootstrap report: %v", reportErr)
+	}
+
 	writeInfraErr := bs.WriteInfraFile()
 	if writeInfraErr != nil {
 		return fmt.Errorf("failed to write infra details: %w", writeInfraErr)
@@ -204,7 +503,32 @@ func (c *BootstrapGcpCmd) BootstrapGcp() error {
 	}
 
 	log.Println("\n🎉🎉🎉 GCP infrastructure bootstrapped successfully!")
-	log.Printf("Access the jumpbox using:\nssh-add $SSH_KEY_PATH; ssh -o StrictHostKeyChecking=no -o ForwardAgent=yes -o SendEnv=OMS_PORTAL_API_KEY -o SendEnv=OMS_PORTAL_API root@%s", bs.Env.Jumpbox.GetExternalIP())
+
+	// The quota report is informational; a failed lookup must not fail a
+	// bootstrap that already succeeded.
+	if report, quotaErr := bs.ReportQuotaUsage(); quotaErr != nil {
+		log.Printf("Warning: failed to report quota usage: %v", quotaErr)
+	} else if printErr := report.Print(os.Stdout); printErr != nil {
+		log.Printf("Warning: failed to print quota report: %v", printErr)
+	}
+
+	// Same for the cost accounting of the resources this run created.
+	if runCost, costErr := bs.ReportRunCost(); costErr != nil {
+		log.Printf("Warning: failed to report run cost: %v", costErr)
+	} else if len(runCost.Steps) > 0 {
+		if printErr := runCost.Print(os.Stdout); printErr != nil {
+			log.Printf("Warning: failed to print run cost report: %v", printErr)
+		}
+	}
+
+	if bs.Env.DNSSECDSRecord != "" {
+		log.Printf("DNSSEC is enabled. Publish this DS record for %s at your registrar:\n%s", bs.Env.BaseDomain, bs.Env.DNSSECDSRecord)
+	}
+	if bs.Env.UseIAPTunnel {
+		log.Printf("Access the jumpbox using:\ngcloud compute ssh jumpbox --tunnel-through-iap --project %s --zone %s", bs.Env.ProjectID, bs.Env.Zone)
+	} else {
+		log.Printf("Access the jumpbox using:\nssh-add $SSH_KEY_PATH; ssh -o StrictHostKeyChecking=no -o ForwardAgent=yes -o SendEnv=OMS_PORTAL_API_KEY -o SendEnv=OMS_PORTAL_API root@%s", bs.Env.Jumpbox.GetExternalIP())
+	}
 
 	if bs.Env.InstallVersion != "" {
 		log.Printf("Access Codesphere in your web browser at https://cs.%s", bs.Env.BaseDomain)
@@ -223,3 +547,20 @@ func (c *BootstrapGcpCmd) BootstrapGcp() error {
 
 	return nil
 }
+
+// confirmProvisioning asks the user to confirm provisioning after the cost
+// estimate has been printed and aborts unless the answer is yes.
+func confirmProvisioning(in *bufio.Reader) error {
+	fmt.Print("Proceed with provisioning? (y/N): ")
+
+	answer, err := in.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer != "y" && answer != "yes" {
+		return fmt.Errorf("provisioning aborted")
+	}
+	return nil
+}
@@ -10,8 +10,10 @@ import (
 
 	csio "github.com/codesphere-cloud/cs-go/pkg/io"
 	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/audit"
 	"github.com/codesphere-cloud/oms/internal/bootstrap"
 	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/env"
 	intutil "github.com/codesphere-cloud/oms/internal/util"
 	"github.com/spf13/cobra"
 )
@@ -26,6 +28,9 @@ type BootstrapGcpCleanupOpts struct {
 	ProjectID      string
 	Force          bool
 	SkipDNSCleanup bool
+	DeleteDNSZone  bool
+	DryRun         bool
+	OverrideWindow bool
 	BaseDomain     string
 	DNSZoneName    string
 	DNSProjectID   string
@@ -37,12 +42,20 @@ func (c *BootstrapGcpCleanupCmd) RunE(_ *cobra.Command, args []string) error {
 	gcpClient := gcp.NewGCPClient(ctx, stlog, os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"))
 	fw := intutil.NewFilesystemWriter()
 
+	// The teardown is recorded in the same audit log the bootstrap writes, so
+	// who deleted an environment and when stays reviewable.
+	if err := audit.Open(env.NewEnv().GetOmsWorkdir()); err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer func() { _ = audit.Close() }()
+
 	deps := &gcp.CleanupDeps{
 		GCPClient:     gcpClient,
 		FileIO:        fw,
 		StepLogger:    stlog,
 		ConfirmReader: os.Stdin,
 		InfraFilePath: gcp.GetInfraFilePath(),
+		Clock:         intutil.NewTime(),
 	}
 
 	return c.ExecuteCleanup(deps)
@@ -54,6 +67,9 @@ func (c *BootstrapGcpCleanupCmd) ExecuteCleanup(deps *gcp.CleanupDeps) error {
 		ProjectID:      c.Opts.ProjectID,
 		Force:          c.Opts.Force,
 		SkipDNSCleanup: c.Opts.SkipDNSCleanup,
+		DeleteDNSZone:  c.Opts.DeleteDNSZone,
+		DryRun:         c.Opts.DryRun,
+		OverrideWindow: c.Opts.OverrideWindow,
 		BaseDomain:     c.Opts.BaseDomain,
 		DNSZoneName:    c.Opts.DNSZoneName,
 		DNSProjectID:   c.Opts.DNSProjectID,
@@ -62,6 +78,10 @@ func (c *BootstrapGcpCleanupCmd) ExecuteCleanup(deps *gcp.CleanupDeps) error {
 		return fmt.Errorf("failed to resolve cleanup configuration: %w", err)
 	}
 
+	if c.Opts.DryRun {
+		return exec.PrintTeardownPlan()
+	}
+
 	if err := exec.VerifyAndConfirm(); err != nil {
 		return err
 	}
@@ -76,11 +96,20 @@ func (c *BootstrapGcpCleanupCmd) ExecuteCleanup(deps *gcp.CleanupDeps) error {
 		log.Printf("You may need to manually remove the serviceAccount:cloud-controller@%s.iam.gserviceaccount.com binding from project %s", exec.ProjectID, exec.DNSProjectID)
 	}
 
+	if err := deps.StepLogger.Step("Destroy disk encryption key", exec.DestroyDiskEncryptionKey); err != nil {
+		return fmt.Errorf("failed to destroy disk encryption key: %w", err)
+	}
+
+	if err := deps.StepLogger.Step("Remove deletion lien", exec.RemoveDeletionLiens); err != nil {
+		return fmt.Errorf("failed to remove deletion lien: %w", err)
+	}
+
 	if err := deps.StepLogger.Step("Delete GCP project", exec.DeleteProject); err != nil {
 		return fmt.Errorf("failed to delete project: %w", err)
 	}
 
 	exec.RemoveLocalInfraFile()
+	exec.WriteTeardownReport()
 
 	log.Println("\nGCP project cleanup completed successfully!")
 	log.Printf("Project '%s' has been scheduled for deletion.", exec.ProjectID)
@@ -99,6 +128,7 @@ func AddBootstrapGcpCleanupCmd(bootstrapGcp *cobra.Command, opts *util.GlobalOpt
 				{Desc: "Clean up using project ID from the local infra file"},
 				{Cmd: "--project-id my-project-abc123", Desc: "Clean up a specific project"},
 				{Cmd: "--project-id my-project-abc123 --force", Desc: "Force cleanup without confirmation (skips OMS-managed check)"},
+				{Cmd: "--dry-run", Desc: "Show what would be deleted without deleting anything"},
 				{Cmd: "--skip-dns-cleanup", Desc: "Skip DNS record cleanup"},
 				{Cmd: "--project-id my-project --base-domain example.com --dns-zone-name my-zone --dns-project-id dns-project", Desc: "Clean up with manual DNS settings (when infra file is not available)"},
 			}),
@@ -112,6 +142,9 @@ func AddBootstrapGcpCleanupCmd(bootstrapGcp *cobra.Command, opts *util.GlobalOpt
 	flags.StringVar(&cleanup.Opts.ProjectID, "project-id", "", "GCP Project ID to delete (optional, will use infra file if not provided)")
 	flags.BoolVar(&cleanup.Opts.Force, "force", false, "Skip confirmation prompt and OMS-managed check")
 	flags.BoolVar(&cleanup.Opts.SkipDNSCleanup, "skip-dns-cleanup", false, "Skip cleaning up DNS records")
+	flags.BoolVar(&cleanup.Opts.DeleteDNSZone, "delete-dns-zone", false, "Also delete the managed DNS zone after its records. Refused for zones not created by OMS (default: false)")
+	flags.BoolVar(&cleanup.Opts.DryRun, "dry-run", false, "Print what would be deleted without changing anything (default: false)")
+	flags.BoolVar(&cleanup.Opts.OverrideWindow, "override-window", false, "Run the teardown outside the environment's maintenance window (default: false)")
 	flags.StringVar(&cleanup.Opts.BaseDomain, "base-domain", "", "Base domain for DNS cleanup (optional, will use infra file if not provided)")
 	flags.StringVar(&cleanup.Opts.DNSZoneName, "dns-zone-name", "", "DNS zone name for DNS cleanup (optional, will use infra file if not provided)")
 	flags.StringVar(&cleanup.Opts.DNSProjectID, "dns-project-id", "", "GCP Project ID for DNS zone (optional, will use infra file if not provided)")
@@ -12,12 +12,14 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/cobra"
+	"google.golang.org/api/dns/v1"
 
 	"github.com/codesphere-cloud/oms/cli/cmd"
 	"github.com/codesphere-cloud/oms/cli/cmd/util"
 	"github.com/codesphere-cloud/oms/internal/bootstrap"
 	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
 	intutil "github.com/codesphere-cloud/oms/internal/util"
+	"github.com/stretchr/testify/mock"
 )
 
 var _ = Describe("BootstrapGcpCleanupCmd", func() {
@@ -194,6 +196,11 @@ var _ = Describe("BootstrapGcpCleanupCmd", func() {
 			}
 		})
 
+		// expectTeardownReport expects the teardown report written on success.
+		expectTeardownReport := func(projectID string) {
+			mockFileIO.EXPECT().WriteFile("/tmp/teardown-report-"+projectID+".json", mock.Anything, os.FileMode(0644)).Return(nil)
+		}
+
 		Context("when no project ID is provided and infra file doesn't exist", func() {
 			It("should return an error", func() {
 				mockFileIO.EXPECT().Exists("/tmp/test-infra.json").Return(false)
@@ -241,6 +248,7 @@ var _ = Describe("BootstrapGcpCleanupCmd", func() {
 			It("should use the provided project ID", func() {
 				cleanupCmd.Opts.ProjectID = "flag-project"
 				mockFileIO.EXPECT().Exists("/tmp/test-infra.json").Return(false)
+				mockGCPClient.EXPECT().IsProtectedProject("flag-project").Return(false, nil)
 				mockGCPClient.EXPECT().IsOMSManagedProject("flag-project").Return(false, nil)
 
 				err := cleanupCmd.ExecuteCleanup(deps)
@@ -253,6 +261,7 @@ var _ = Describe("BootstrapGcpCleanupCmd", func() {
 			It("should return the verification error", func() {
 				cleanupCmd.Opts.ProjectID = "test-project"
 				mockFileIO.EXPECT().Exists("/tmp/test-infra.json").Return(false)
+				mockGCPClient.EXPECT().IsProtectedProject("test-project").Return(false, nil)
 				mockGCPClient.EXPECT().IsOMSManagedProject("test-project").Return(false, errors.New("API error"))
 
 				err := cleanupCmd.ExecuteCleanup(deps)
@@ -266,7 +275,21 @@ var _ = Describe("BootstrapGcpCleanupCmd", func() {
 				cleanupCmd.Opts.ProjectID = "test-project"
 				cleanupCmd.Opts.Force = true
 				mockFileIO.EXPECT().Exists("/tmp/test-infra.json").Return(false)
+				mockGCPClient.EXPECT().IsProtectedProject("test-project").Return(false, nil)
 				mockGCPClient.EXPECT().DeleteProject("test-project").Return(nil)
+				expectTeardownReport("test-project")
+
+				err := cleanupCmd.ExecuteCleanup(deps)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when dry-run is set", func() {
+			It("should print the teardown plan without deleting anything", func() {
+				cleanupCmd.Opts.ProjectID = "test-project"
+				cleanupCmd.Opts.DryRun = true
+				mockFileIO.EXPECT().Exists("/tmp/test-infra.json").Return(false)
+				mockGCPClient.EXPECT().IsProtectedProject("test-project").Return(false, nil)
 
 				err := cleanupCmd.ExecuteCleanup(deps)
 				Expect(err).NotTo(HaveOccurred())
@@ -278,6 +301,7 @@ var _ = Describe("BootstrapGcpCleanupCmd", func() {
 				cleanupCmd.Opts.ProjectID = "test-project"
 				deps.ConfirmReader = bytes.NewBufferString("wrong-input\n")
 				mockFileIO.EXPECT().Exists("/tmp/test-infra.json").Return(false)
+				mockGCPClient.EXPECT().IsProtectedProject("test-project").Return(false, nil)
 				mockGCPClient.EXPECT().IsOMSManagedProject("test-project").Return(true, nil)
 
 				err := cleanupCmd.ExecuteCleanup(deps)
@@ -286,6 +310,75 @@ var _ = Describe("BootstrapGcpCleanupCmd", func() {
 			})
 		})
 
+		Context("when the infra file marks the environment as protected", func() {
+			It("should refuse deletion without force", func() {
+				protectedEnv := gcp.CodesphereEnvironment{
+					ProjectID: "prod-project",
+					Protected: true,
+				}
+				envData, _ := json.Marshal(protectedEnv)
+
+				mockFileIO.EXPECT().Exists("/tmp/test-infra.json").Return(true)
+				mockFileIO.EXPECT().ReadFile("/tmp/test-infra.json").Return(envData, nil)
+
+				err := cleanupCmd.ExecuteCleanup(deps)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("is protected"))
+			})
+
+			It("should require typing the project ID even with force", func() {
+				cleanupCmd.Opts.Force = true
+				deps.ConfirmReader = bytes.NewBufferString("wrong-project\n")
+
+				protectedEnv := gcp.CodesphereEnvironment{
+					ProjectID: "prod-project",
+					Protected: true,
+				}
+				envData, _ := json.Marshal(protectedEnv)
+
+				mockFileIO.EXPECT().Exists("/tmp/test-infra.json").Return(true)
+				mockFileIO.EXPECT().ReadFile("/tmp/test-infra.json").Return(envData, nil)
+
+				err := cleanupCmd.ExecuteCleanup(deps)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("confirmation did not match project ID"))
+			})
+
+			It("should delete the project with force and matching confirmation", func() {
+				cleanupCmd.Opts.Force = true
+				cleanupCmd.Opts.SkipDNSCleanup = true
+				deps.ConfirmReader = bytes.NewBufferString("prod-project\n")
+
+				protectedEnv := gcp.CodesphereEnvironment{
+					ProjectID: "prod-project",
+					Protected: true,
+				}
+				envData, _ := json.Marshal(protectedEnv)
+
+				mockFileIO.EXPECT().Exists("/tmp/test-infra.json").Return(true)
+				mockFileIO.EXPECT().ReadFile("/tmp/test-infra.json").Return(envData, nil)
+				mockGCPClient.EXPECT().RemoveProjectLiens("prod-project").Return(nil)
+				mockGCPClient.EXPECT().DeleteProject("prod-project").Return(nil)
+				mockFileIO.EXPECT().Remove("/tmp/test-infra.json").Return(nil)
+				expectTeardownReport("prod-project")
+
+				err := cleanupCmd.ExecuteCleanup(deps)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the project carries the protection label", func() {
+			It("should refuse deletion without force", func() {
+				cleanupCmd.Opts.ProjectID = "prod-project"
+				mockFileIO.EXPECT().Exists("/tmp/test-infra.json").Return(false)
+				mockGCPClient.EXPECT().IsProtectedProject("prod-project").Return(true, nil)
+
+				err := cleanupCmd.ExecuteCleanup(deps)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("is protected"))
+			})
+		})
+
 		Context("when infra file read fails", func() {
 			It("should return the read error", func() {
 				mockFileIO.EXPECT().Exists("/tmp/test-infra.json").Return(true)
@@ -322,9 +415,97 @@ var _ = Describe("BootstrapGcpCleanupCmd", func() {
 
 				mockFileIO.EXPECT().Exists("/tmp/test-infra.json").Return(true)
 				mockFileIO.EXPECT().ReadFile("/tmp/test-infra.json").Return(envData, nil)
+				mockGCPClient.EXPECT().GetDNSRecordSet("test-project", "test-zone", "_oms-owner.cs.example.com.", "TXT").Return(nil, nil)
 				mockGCPClient.EXPECT().DeleteDNSRecordSets("test-project", "test-zone", "example.com").Return(nil)
 				mockGCPClient.EXPECT().DeleteProject("test-project").Return(nil)
 				mockFileIO.EXPECT().Remove("/tmp/test-infra.json").Return(nil)
+				expectTeardownReport("test-project")
+
+				err := cleanupCmd.ExecuteCleanup(deps)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the DNS records are owned by another environment", func() {
+			It("should leave the records in place and still delete the project", func() {
+				cleanupCmd.Opts.ProjectID = "test-project"
+				cleanupCmd.Opts.Force = true
+
+				validEnv := gcp.CodesphereEnvironment{
+					ProjectID:   "test-project",
+					BaseDomain:  "example.com",
+					DNSZoneName: "test-zone",
+				}
+				envData, _ := json.Marshal(validEnv)
+
+				mockFileIO.EXPECT().Exists("/tmp/test-infra.json").Return(true)
+				mockFileIO.EXPECT().ReadFile("/tmp/test-infra.json").Return(envData, nil)
+				mockGCPClient.EXPECT().GetDNSRecordSet("test-project", "test-zone", "_oms-owner.cs.example.com.", "TXT").Return(&dns.ResourceRecordSet{
+					Name:    "_oms-owner.cs.example.com.",
+					Type:    "TXT",
+					Rrdatas: []string{`"heritage=oms,owner=other-project"`},
+				}, nil)
+				mockGCPClient.EXPECT().DeleteProject("test-project").Return(nil)
+				mockFileIO.EXPECT().Remove("/tmp/test-infra.json").Return(nil)
+				expectTeardownReport("test-project")
+
+				err := cleanupCmd.ExecuteCleanup(deps)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when delete-dns-zone is set", func() {
+			It("should delete the managed zone after its records", func() {
+				cleanupCmd.Opts.ProjectID = "test-project"
+				cleanupCmd.Opts.Force = true
+				cleanupCmd.Opts.DeleteDNSZone = true
+
+				validEnv := gcp.CodesphereEnvironment{
+					ProjectID:   "test-project",
+					BaseDomain:  "example.com",
+					DNSZoneName: "test-zone",
+				}
+				envData, _ := json.Marshal(validEnv)
+
+				mockFileIO.EXPECT().Exists("/tmp/test-infra.json").Return(true)
+				mockFileIO.EXPECT().ReadFile("/tmp/test-infra.json").Return(envData, nil)
+				mockGCPClient.EXPECT().GetDNSRecordSet("test-project", "test-zone", "_oms-owner.cs.example.com.", "TXT").Return(nil, nil)
+				mockGCPClient.EXPECT().DeleteDNSRecordSets("test-project", "test-zone", "example.com").Return(nil)
+				mockGCPClient.EXPECT().GetDNSManagedZone("test-project", "test-zone").Return(&dns.ManagedZone{
+					Name:   "test-zone",
+					Labels: map[string]string{gcp.OMSManagedLabel: "true"},
+				}, nil)
+				mockGCPClient.EXPECT().DeleteDNSManagedZone("test-project", "test-zone").Return(nil)
+				mockGCPClient.EXPECT().DeleteProject("test-project").Return(nil)
+				mockFileIO.EXPECT().Remove("/tmp/test-infra.json").Return(nil)
+				expectTeardownReport("test-project")
+
+				err := cleanupCmd.ExecuteCleanup(deps)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when delete-dns-zone targets a zone not created by OMS", func() {
+			It("should refuse to delete the zone but still delete the project", func() {
+				cleanupCmd.Opts.ProjectID = "test-project"
+				cleanupCmd.Opts.Force = true
+				cleanupCmd.Opts.DeleteDNSZone = true
+
+				validEnv := gcp.CodesphereEnvironment{
+					ProjectID:   "test-project",
+					BaseDomain:  "example.com",
+					DNSZoneName: "test-zone",
+				}
+				envData, _ := json.Marshal(validEnv)
+
+				mockFileIO.EXPECT().Exists("/tmp/test-infra.json").Return(true)
+				mockFileIO.EXPECT().ReadFile("/tmp/test-infra.json").Return(envData, nil)
+				mockGCPClient.EXPECT().GetDNSRecordSet("test-project", "test-zone", "_oms-owner.cs.example.com.", "TXT").Return(nil, nil)
+				mockGCPClient.EXPECT().DeleteDNSRecordSets("test-project", "test-zone", "example.com").Return(nil)
+				mockGCPClient.EXPECT().GetDNSManagedZone("test-project", "test-zone").Return(&dns.ManagedZone{Name: "test-zone"}, nil)
+				mockGCPClient.EXPECT().DeleteProject("test-project").Return(nil)
+				mockFileIO.EXPECT().Remove("/tmp/test-infra.json").Return(nil)
+				expectTeardownReport("test-project")
 
 				err := cleanupCmd.ExecuteCleanup(deps)
 				Expect(err).NotTo(HaveOccurred())
@@ -338,7 +519,9 @@ var _ = Describe("BootstrapGcpCleanupCmd", func() {
 				cleanupCmd.Opts.SkipDNSCleanup = true
 
 				mockFileIO.EXPECT().Exists("/tmp/test-infra.json").Return(false)
+				mockGCPClient.EXPECT().IsProtectedProject("test-project").Return(false, nil)
 				mockGCPClient.EXPECT().DeleteProject("test-project").Return(nil)
+				expectTeardownReport("test-project")
 
 				err := cleanupCmd.ExecuteCleanup(deps)
 				Expect(err).NotTo(HaveOccurred())
@@ -359,7 +542,9 @@ var _ = Describe("BootstrapGcpCleanupCmd", func() {
 
 				mockFileIO.EXPECT().Exists("/tmp/test-infra.json").Return(true)
 				mockFileIO.EXPECT().ReadFile("/tmp/test-infra.json").Return(envData, nil)
+				mockGCPClient.EXPECT().IsProtectedProject("other-project").Return(false, nil)
 				mockGCPClient.EXPECT().DeleteProject("other-project").Return(nil)
+				expectTeardownReport("other-project")
 
 				err := cleanupCmd.ExecuteCleanup(deps)
 				Expect(err).NotTo(HaveOccurred())
@@ -373,7 +558,9 @@ var _ = Describe("BootstrapGcpCleanupCmd", func() {
 
 				mockFileIO.EXPECT().Exists("/tmp/test-infra.json").Return(true)
 				mockFileIO.EXPECT().ReadFile("/tmp/test-infra.json").Return(nil, os.ErrPermission)
+				mockGCPClient.EXPECT().IsProtectedProject("test-project").Return(false, nil)
 				mockGCPClient.EXPECT().DeleteProject("test-project").Return(nil)
+				expectTeardownReport("test-project")
 
 				err := cleanupCmd.ExecuteCleanup(deps)
 				Expect(err).NotTo(HaveOccurred())
@@ -387,10 +574,59 @@ var _ = Describe("BootstrapGcpCleanupCmd", func() {
 
 				mockFileIO.EXPECT().Exists("/tmp/test-infra.json").Return(true)
 				mockFileIO.EXPECT().ReadFile("/tmp/test-infra.json").Return([]byte("invalid-json"), nil)
+				mockGCPClient.EXPECT().IsProtectedProject("test-project").Return(false, nil)
+				mockGCPClient.EXPECT().DeleteProject("test-project").Return(nil)
+				expectTeardownReport("test-project")
+
+				err := cleanupCmd.ExecuteCleanup(deps)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the infra file records a managed disk encryption key", func() {
+			const keyName = "projects/test-project/locations/us-central1/keyRings/oms/cryptoKeys/disk-encryption-key"
+
+			var envData []byte
+
+			BeforeEach(func() {
+				cleanupCmd.Opts.Force = true
+				cleanupCmd.Opts.SkipDNSCleanup = true
+
+				keyEnv := gcp.CodesphereEnvironment{
+					ProjectID:                "test-project",
+					DiskEncryptionKey:        keyName,
+					ManagedDiskEncryptionKey: true,
+				}
+				envData, _ = json.Marshal(keyEnv)
+
+				mockFileIO.EXPECT().Exists("/tmp/test-infra.json").Return(true)
+				mockFileIO.EXPECT().ReadFile("/tmp/test-infra.json").Return(envData, nil)
+			})
+
+			It("should destroy the key versions and record a destruction certificate", func() {
+				mockGCPClient.EXPECT().DestroyKMSKeyVersions(keyName).Return([]string{keyName + "/cryptoKeyVersions/1"}, nil)
 				mockGCPClient.EXPECT().DeleteProject("test-project").Return(nil)
+				mockFileIO.EXPECT().Remove("/tmp/test-infra.json").Return(nil)
+
+				var report []byte
+				mockFileIO.EXPECT().WriteFile("/tmp/teardown-report-test-project.json", mock.Anything, os.FileMode(0644)).RunAndReturn(
+					func(_ string, data []byte, _ os.FileMode) error {
+						report = data
+						return nil
+					})
 
 				err := cleanupCmd.ExecuteCleanup(deps)
 				Expect(err).NotTo(HaveOccurred())
+				Expect(string(report)).To(ContainSubstring(`"key_destruction"`))
+				Expect(string(report)).To(ContainSubstring(keyName + "/cryptoKeyVersions/1"))
+			})
+
+			It("should fail when key destruction fails", func() {
+				mockGCPClient.EXPECT().DestroyKMSKeyVersions(keyName).Return(nil, errors.New("KMS error"))
+
+				err := cleanupCmd.ExecuteCleanup(deps)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to destroy disk encryption key"))
 			})
 		})
 	})
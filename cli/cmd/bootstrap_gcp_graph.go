@@ -0,0 +1,83 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	csio "github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/spf13/cobra"
+)
+
+type BootstrapGcpGraphCmd struct {
+	cmd  *cobra.Command
+	Opts *BootstrapGcpGraphOpts
+}
+
+type BootstrapGcpGraphOpts struct {
+	*util.GlobalOptions
+	Format string
+}
+
+func (c *BootstrapGcpGraphCmd) RunE(_ *cobra.Command, _ []string) error {
+	return RenderStepGraph(c.Opts.Format, c.cmd.OutOrStdout())
+}
+
+// RenderStepGraph writes the declared bootstrap step graph in the requested
+// format. The dot output can be piped into graphviz for documentation; the
+// json output is consumed by the web UI.
+func RenderStepGraph(format string, out io.Writer) error {
+	steps := gcp.BootstrapSteps()
+
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(steps)
+	case "dot":
+		fmt.Fprintln(out, "digraph bootstrap {")
+		fmt.Fprintln(out, "  rankdir=TB;")
+		for _, step := range steps {
+			fmt.Fprintf(out, "  %q [label=%q];\n", step.ID, step.Name)
+		}
+		for _, step := range steps {
+			for _, dep := range step.DependsOn {
+				fmt.Fprintf(out, "  %q -> %q;\n", dep, step.ID)
+			}
+		}
+		fmt.Fprintln(out, "}")
+		return nil
+	default:
+		return fmt.Errorf("unsupported graph format %q (supported: dot, json)", format)
+	}
+}
+
+func AddBootstrapGcpGraphCmd(bootstrapGcp *cobra.Command, opts *util.GlobalOptions) {
+	graph := BootstrapGcpGraphCmd{
+		cmd: &cobra.Command{
+			Use:   "graph",
+			Short: "Print the bootstrap step dependency graph",
+			Long: csio.Long(`Prints the declared bootstrap step graph with names, descriptions
+				and dependencies. The dot format can be rendered with graphviz for
+				documentation; the json format is consumable by tooling and the web UI.`),
+			Example: util.FormatExamples("beta bootstrap-gcp graph", []csio.Example{
+				{Cmd: "--format dot | dot -Tsvg -o bootstrap.svg", Desc: "Render the step graph as an SVG"},
+				{Cmd: "--format json", Desc: "Print the step graph as JSON"},
+			}),
+		},
+		Opts: &BootstrapGcpGraphOpts{
+			GlobalOptions: opts,
+		},
+	}
+
+	flags := graph.cmd.Flags()
+	flags.StringVar(&graph.Opts.Format, "format", "dot", "Output format: dot or json (default: dot)")
+
+	util.AddCmd(bootstrapGcp, graph.cmd)
+	graph.cmd.RunE = graph.RunE
+}
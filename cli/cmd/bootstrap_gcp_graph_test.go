@@ -0,0 +1,62 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/codesphere-cloud/oms/cli/cmd"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+)
+
+var _ = Describe("BootstrapGcpGraphCmd", func() {
+	Describe("command registration", func() {
+		It("registers graph with the format flag", func() {
+			parentCmd := &cobra.Command{Use: "bootstrap-gcp"}
+			cmd.AddBootstrapGcpGraphCmd(parentCmd, &util.GlobalOptions{})
+
+			graphCmd, _, err := parentCmd.Find([]string{"graph"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(graphCmd.Use).To(Equal("graph"))
+			Expect(graphCmd.Flags().Lookup("format")).NotTo(BeNil())
+		})
+	})
+
+	Describe("RenderStepGraph", func() {
+		var out *bytes.Buffer
+
+		BeforeEach(func() {
+			out = &bytes.Buffer{}
+		})
+
+		It("renders the step graph as dot", func() {
+			Expect(cmd.RenderStepGraph("dot", out)).To(Succeed())
+
+			Expect(out.String()).To(HavePrefix("digraph bootstrap {"))
+			Expect(out.String()).To(ContainSubstring(`"ensure-project" [label="Ensure project"];`))
+			Expect(out.String()).To(ContainSubstring(`"validate-input" -> "ensure-project";`))
+		})
+
+		It("renders the step graph as json", func() {
+			Expect(cmd.RenderStepGraph("json", out)).To(Succeed())
+
+			var steps []gcp.BootstrapStep
+			Expect(json.Unmarshal(out.Bytes(), &steps)).To(Succeed())
+			Expect(steps).To(HaveLen(len(gcp.BootstrapSteps())))
+			Expect(steps[0].ID).To(Equal("validate-input"))
+			Expect(steps[0].Description).NotTo(BeEmpty())
+		})
+
+		It("rejects unsupported formats", func() {
+			err := cmd.RenderStepGraph("yaml", out)
+			Expect(err).To(MatchError(ContainSubstring(`unsupported graph format "yaml"`)))
+		})
+	})
+})
@@ -0,0 +1,107 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	csio "github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/spf13/cobra"
+)
+
+type BootstrapGcpListCmd struct {
+	cmd  *cobra.Command
+	Opts *BootstrapGcpListOpts
+}
+
+type BootstrapGcpListOpts struct {
+	*util.GlobalOptions
+	FolderID string
+	Labels   map[string]string
+}
+
+func (c *BootstrapGcpListCmd) RunE(_ *cobra.Command, _ []string) error {
+	ctx := c.cmd.Context()
+	stlog := bootstrap.NewStepLogger(false)
+	gcpClient := gcp.NewGCPClient(ctx, stlog, os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"))
+
+	return ListEnvironments(gcpClient, c.Opts, os.Stdout)
+}
+
+// ListEnvironments discovers OMS-managed environments by the oms-managed label
+// plus any additional label filters and prints them as a table.
+func ListEnvironments(gcpClient gcp.GCPClientManager, opts *BootstrapGcpListOpts, out io.Writer) error {
+	labels := map[string]string{gcp.OMSManagedLabel: "true"}
+	for key, value := range opts.Labels {
+		labels[key] = value
+	}
+
+	projects, err := gcpClient.SearchProjectsByLabels(opts.FolderID, labels)
+	if err != nil {
+		return fmt.Errorf("failed to search for OMS-managed projects: %w", err)
+	}
+
+	if len(projects) == 0 {
+		fmt.Fprintln(out, "No OMS-managed environments found.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROJECT ID\tNAME\tLABELS")
+	for _, project := range projects {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", project.GetProjectId(), project.GetDisplayName(), formatLabels(project.GetLabels()))
+	}
+
+	return w.Flush()
+}
+
+// formatLabels renders a label map as sorted key=value pairs, omitting the
+// oms-managed label every listed environment carries by definition.
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		if key == gcp.OMSManagedLabel {
+			continue
+		}
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func AddBootstrapGcpListCmd(bootstrapGcp *cobra.Command, opts *util.GlobalOptions) {
+	list := BootstrapGcpListCmd{
+		cmd: &cobra.Command{
+			Use:   "list",
+			Short: "List OMS-managed GCP environments",
+			Long: csio.Long(`Lists GCP environments bootstrapped by OMS.
+				Environments are discovered by the oms-managed=true label applied to
+				every bootstrapped project and can be narrowed down further with
+				--labels filters (e.g. owner or cost-center).`),
+			Example: util.FormatExamples("beta bootstrap-gcp list", []csio.Example{
+				{Desc: "List all OMS-managed environments"},
+				{Cmd: "--folder-id 123456", Desc: "List environments in a specific folder"},
+				{Cmd: "--labels owner=team-a", Desc: "List environments owned by team-a"},
+			}),
+		},
+		Opts: &BootstrapGcpListOpts{
+			GlobalOptions: opts,
+		},
+	}
+
+	flags := list.cmd.Flags()
+	flags.StringVar(&list.Opts.FolderID, "folder-id", "", "GCP folder ID to search in (optional)")
+	flags.StringToStringVar(&list.Opts.Labels, "labels", nil, "Additional label filters as key=value pairs (optional)")
+
+	util.AddCmd(bootstrapGcp, list.cmd)
+	list.cmd.RunE = list.RunE
+}
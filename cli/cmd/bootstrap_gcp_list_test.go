@@ -0,0 +1,78 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+
+	"cloud.google.com/go/resourcemanager/apiv3/resourcemanagerpb"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/codesphere-cloud/oms/cli/cmd"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+)
+
+var _ = Describe("BootstrapGcpListCmd", func() {
+	Describe("command registration", func() {
+		It("registers list with the expected flags", func() {
+			parentCmd := &cobra.Command{Use: "bootstrap-gcp"}
+			cmd.AddBootstrapGcpListCmd(parentCmd, &util.GlobalOptions{})
+
+			listCmd, _, err := parentCmd.Find([]string{"list"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listCmd.Use).To(Equal("list"))
+
+			for _, flag := range []string{"folder-id", "labels"} {
+				Expect(listCmd.Flags().Lookup(flag)).NotTo(BeNil(), "expected flag %q to exist", flag)
+			}
+		})
+	})
+
+	Describe("ListEnvironments", func() {
+		var (
+			mockGCPClient *gcp.MockGCPClientManager
+			out           *bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			mockGCPClient = gcp.NewMockGCPClientManager(GinkgoT())
+			out = &bytes.Buffer{}
+		})
+
+		It("discovers environments by the oms-managed label and extra filters", func() {
+			mockGCPClient.EXPECT().SearchProjectsByLabels("123456", map[string]string{
+				"oms-managed": "true",
+				"owner":       "team-a",
+			}).Return([]*resourcemanagerpb.Project{
+				{
+					ProjectId:   "env-abc123",
+					DisplayName: "env",
+					Labels:      map[string]string{"oms-managed": "true", "owner": "team-a", "cost-center": "123"},
+				},
+			}, nil)
+
+			err := cmd.ListEnvironments(mockGCPClient, &cmd.BootstrapGcpListOpts{
+				FolderID: "123456",
+				Labels:   map[string]string{"owner": "team-a"},
+			}, out)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(out.String()).To(ContainSubstring("env-abc123"))
+			Expect(out.String()).To(ContainSubstring("cost-center=123,owner=team-a"))
+			Expect(out.String()).NotTo(ContainSubstring("oms-managed=true"))
+		})
+
+		It("reports when no environments are found", func() {
+			mockGCPClient.EXPECT().SearchProjectsByLabels("", map[string]string{"oms-managed": "true"}).Return(nil, nil)
+
+			err := cmd.ListEnvironments(mockGCPClient, &cmd.BootstrapGcpListOpts{}, out)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(out.String()).To(ContainSubstring("No OMS-managed environments found."))
+		})
+	})
+})
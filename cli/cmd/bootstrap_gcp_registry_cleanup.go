@@ -0,0 +1,129 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	csio "github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	intutil "github.com/codesphere-cloud/oms/internal/util"
+	"github.com/spf13/cobra"
+)
+
+type BootstrapGcpRegistryCleanupCmd struct {
+	cmd  *cobra.Command
+	Opts *BootstrapGcpRegistryCleanupOpts
+}
+
+type BootstrapGcpRegistryCleanupOpts struct {
+	*util.GlobalOptions
+	ProjectID             string
+	Region                string
+	KeepTagCount          int
+	UntaggedRetentionDays int
+}
+
+// resolveProjectAndRegion returns the project ID and region from flags or the infra file.
+// If both flags are set they are used directly; if neither is set, the infra file is read.
+// Providing only one of --project-id / --region is an error.
+func (c *BootstrapGcpRegistryCleanupCmd) resolveProjectAndRegion(fw intutil.FileIO) (string, string, error) {
+	projectID := c.Opts.ProjectID
+	region := c.Opts.Region
+
+	if (projectID == "") != (region == "") {
+		return "", "", fmt.Errorf("--project-id and --region must be provided together")
+	}
+	if projectID != "" {
+		return projectID, region, nil
+	}
+
+	infraFilePath := gcp.GetInfraFilePath()
+	infraEnv, exists, err := gcp.LoadInfraFile(fw, infraFilePath)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to load infra file: %w", err)
+	}
+	if !exists {
+		return "", "", fmt.Errorf("infra file not found at %s; use --project-id and --region flags", infraFilePath)
+	}
+	if infraEnv.ProjectID == "" || infraEnv.Region == "" {
+		return "", "", fmt.Errorf("infra file is missing project ID or region; use --project-id and --region flags")
+	}
+	return infraEnv.ProjectID, infraEnv.Region, nil
+}
+
+func (c *BootstrapGcpRegistryCleanupCmd) RunE(_ *cobra.Command, _ []string) error {
+	ctx := c.cmd.Context()
+	stlog := bootstrap.NewStepLogger(false)
+	fw := intutil.NewFilesystemWriter()
+
+	if c.Opts.KeepTagCount <= 0 && c.Opts.UntaggedRetentionDays <= 0 {
+		return fmt.Errorf("at least one of --keep-tag-count and --untagged-retention-days must be greater than zero")
+	}
+
+	projectID, region, err := c.resolveProjectAndRegion(fw)
+	if err != nil {
+		return err
+	}
+
+	gcpClient := gcp.NewGCPClient(ctx, stlog, os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"))
+
+	csEnv := &gcp.CodesphereEnvironment{
+		ProjectID:                     projectID,
+		Region:                        region,
+		RegistryKeepTagCount:          c.Opts.KeepTagCount,
+		RegistryUntaggedRetentionDays: c.Opts.UntaggedRetentionDays,
+	}
+
+	bs, err := gcp.NewGCPBootstrapper(
+		ctx,
+		nil, stlog, csEnv, nil, gcpClient, fw, nil, nil, intutil.NewTime(), nil, nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create bootstrapper: %w", err)
+	}
+
+	log.Printf("Applying registry cleanup policies in project %s (region %s)...", projectID, region)
+	if err := bs.EnsureRegistryCleanupPolicies(); err != nil {
+		return fmt.Errorf("failed to apply registry cleanup policies: %w", err)
+	}
+	log.Printf("Registry cleanup policies applied successfully.")
+
+	return nil
+}
+
+func AddBootstrapGcpRegistryCleanupCmd(bootstrapGcp *cobra.Command, opts *util.GlobalOptions) {
+	registryCleanup := BootstrapGcpRegistryCleanupCmd{
+		cmd: &cobra.Command{
+			Use:   "registry-cleanup",
+			Short: "Apply cleanup policies to the Codesphere artifact registry",
+			Long: csio.Long(`Applies cleanup policies to the Codesphere Artifact Registry repository
+				of an existing environment, retroactively bounding its growth: the most recent
+				tagged image versions are kept per image and untagged versions are deleted
+				after a retention period.
+				Project ID and region are read from the local infra file if available.`),
+			Example: util.FormatExamples("beta bootstrap-gcp registry-cleanup", []csio.Example{
+				{Desc: "Apply default cleanup policies using project info from the local infra file"},
+				{Cmd: "--keep-tag-count 20 --untagged-retention-days 7", Desc: "Keep more tagged versions, delete untagged images sooner"},
+				{Cmd: "--project-id my-project --region us-central1", Desc: "Apply cleanup policies with explicit project and region"},
+			}),
+		},
+		Opts: &BootstrapGcpRegistryCleanupOpts{
+			GlobalOptions: opts,
+		},
+	}
+
+	flags := registryCleanup.cmd.Flags()
+	flags.StringVar(&registryCleanup.Opts.ProjectID, "project-id", "", "GCP Project ID (optional, will use infra file if not provided)")
+	flags.StringVar(&registryCleanup.Opts.Region, "region", "", "GCP Region (optional, will use infra file if not provided)")
+	flags.IntVar(&registryCleanup.Opts.KeepTagCount, "keep-tag-count", 10, "Number of most recent tagged image versions to keep per image")
+	flags.IntVar(&registryCleanup.Opts.UntaggedRetentionDays, "untagged-retention-days", 30, "Days after which untagged image versions are deleted")
+
+	registryCleanup.cmd.RunE = registryCleanup.RunE
+	bootstrapGcp.AddCommand(registryCleanup.cmd)
+}
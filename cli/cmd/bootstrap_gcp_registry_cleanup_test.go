@@ -0,0 +1,80 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/codesphere-cloud/oms/cli/cmd"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+)
+
+var _ = Describe("BootstrapGcpRegistryCleanupCmd", func() {
+	var (
+		globalOpts *util.GlobalOptions
+		parentCmd  *cobra.Command
+	)
+
+	BeforeEach(func() {
+		globalOpts = &util.GlobalOptions{}
+		parentCmd = &cobra.Command{Use: "bootstrap-gcp"}
+		cmd.AddBootstrapGcpRegistryCleanupCmd(parentCmd, globalOpts)
+	})
+
+	findRegistryCleanupCmd := func() *cobra.Command {
+		c, _, err := parentCmd.Find([]string{"registry-cleanup"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).NotTo(BeNil())
+		return c
+	}
+
+	Describe("command registration", func() {
+		It("registers registry-cleanup with the expected flags", func() {
+			registryCleanupCmd := findRegistryCleanupCmd()
+			Expect(registryCleanupCmd.Use).To(Equal("registry-cleanup"))
+
+			for _, flag := range []string{"project-id", "region", "keep-tag-count", "untagged-retention-days"} {
+				Expect(registryCleanupCmd.Flags().Lookup(flag)).NotTo(BeNil(), "expected flag %q to exist", flag)
+			}
+		})
+
+		It("binds flag values to opts", func() {
+			registryCleanupCmd := findRegistryCleanupCmd()
+
+			Expect(registryCleanupCmd.Flags().Set("keep-tag-count", "20")).To(Succeed())
+			Expect(registryCleanupCmd.Flags().Set("untagged-retention-days", "7")).To(Succeed())
+
+			val, err := registryCleanupCmd.Flags().GetInt("keep-tag-count")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal(20))
+
+			val, err = registryCleanupCmd.Flags().GetInt("untagged-retention-days")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal(7))
+		})
+	})
+
+	Describe("flag validation", func() {
+		It("rejects --project-id without --region", func() {
+			registryCleanupCmd := findRegistryCleanupCmd()
+			Expect(registryCleanupCmd.Flags().Set("project-id", "my-proj")).To(Succeed())
+
+			err := registryCleanupCmd.RunE(registryCleanupCmd, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("--project-id and --region must be provided together"))
+		})
+
+		It("rejects disabling both cleanup policies", func() {
+			registryCleanupCmd := findRegistryCleanupCmd()
+			Expect(registryCleanupCmd.Flags().Set("keep-tag-count", "0")).To(Succeed())
+			Expect(registryCleanupCmd.Flags().Set("untagged-retention-days", "0")).To(Succeed())
+
+			err := registryCleanupCmd.RunE(registryCleanupCmd, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must be greater than zero"))
+		})
+	})
+})
@@ -75,7 +75,7 @@ func (c *BootstrapGcpRestartVMsCmd) RunE(_ *cobra.Command, _ []string) error {
 
 	bs, err := gcp.NewGCPBootstrapper(
 		ctx,
-		nil, stlog, csEnv, nil, gcpClient, fw, nil, nil, intutil.NewTime(), nil,
+		nil, stlog, csEnv, nil, gcpClient, fw, nil, nil, intutil.NewTime(), nil, nil,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create bootstrapper: %w", err)
@@ -0,0 +1,109 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	csio "github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	intutil "github.com/codesphere-cloud/oms/internal/util"
+	"github.com/spf13/cobra"
+)
+
+type BootstrapGcpScaleCmd struct {
+	cmd  *cobra.Command
+	Opts *BootstrapGcpScaleOpts
+}
+
+type BootstrapGcpScaleOpts struct {
+	*util.GlobalOptions
+	Workers           int
+	InstallConfigPath string
+	SSHQuiet          bool
+}
+
+func (c *BootstrapGcpScaleCmd) RunE(_ *cobra.Command, _ []string) error {
+	if c.Opts.Workers <= 0 {
+		return fmt.Errorf("--workers must be a positive number")
+	}
+
+	ctx := c.cmd.Context()
+	fw := intutil.NewFilesystemWriter()
+
+	infraFilePath := gcp.GetInfraFilePath()
+	infraEnv, exists, err := gcp.LoadInfraFile(fw, infraFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to load infra file: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("infra file not found at %s; bootstrap the environment first", infraFilePath)
+	}
+	if infraEnv.Jumpbox == nil {
+		return fmt.Errorf("infra file does not contain a jumpbox; scaling requires a jumpbox environment")
+	}
+
+	nodeClient := node.NewSSHNodeClient(c.Opts.SSHQuiet)
+	infraEnv.Jumpbox.NodeClient = nodeClient
+	// Nodes loaded from the infra file lost their jumpbox reference, so
+	// rebuild them as sub-nodes of the connected jumpbox.
+	for i, n := range infraEnv.ControlPlaneNodes {
+		infraEnv.ControlPlaneNodes[i] = infraEnv.Jumpbox.CreateSubNode(n.GetName(), n.GetExternalIP(), n.GetInternalIP())
+	}
+	infraEnv.InstallConfigPath = c.Opts.InstallConfigPath
+
+	icg := installer.NewInstallConfigManager()
+	if err := icg.LoadInstallConfigFromFile(c.Opts.InstallConfigPath); err != nil {
+		return fmt.Errorf("failed to load config file: %w", err)
+	}
+
+	stlog := bootstrap.NewStepLogger(false)
+	gcpClient := gcp.NewGCPClient(ctx, stlog, os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"))
+
+	bs, err := gcp.NewGCPBootstrapper(
+		ctx,
+		nil, stlog, &infraEnv, icg, gcpClient, fw, nodeClient, nil, intutil.NewTime(), nil, nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create bootstrapper: %w", err)
+	}
+
+	return bs.ScaleWorkers(c.Opts.Workers)
+}
+
+func AddBootstrapGcpScaleCmd(bootstrapGcp *cobra.Command, opts *util.GlobalOptions) {
+	scale := BootstrapGcpScaleCmd{
+		cmd: &cobra.Command{
+			Use:   "scale",
+			Short: "Scale the k0s worker pool of an existing environment",
+			Long: csio.Long(`Adds or removes k0s worker instances on a bootstrapped environment.
+				New workers are provisioned and joined to the cluster via the jumpbox;
+				removed workers are drained first. The install config worker list and
+				the local infra file are updated to match.
+				The first three k0s nodes host the control plane and cannot be removed.`),
+			Example: util.FormatExamples("beta bootstrap-gcp scale", []csio.Example{
+				{Cmd: "--workers 5", Desc: "Scale the environment to 5 worker nodes"},
+				{Cmd: "--workers 3", Desc: "Scale back down to the base control plane nodes"},
+			}),
+		},
+		Opts: &BootstrapGcpScaleOpts{
+			GlobalOptions: opts,
+		},
+	}
+
+	flags := scale.cmd.Flags()
+	flags.IntVar(&scale.Opts.Workers, "workers", 0, "Desired total number of k0s worker nodes")
+	flags.StringVar(&scale.Opts.InstallConfigPath, "install-config", "config.yaml", "Path to install config file (optional)")
+	flags.BoolVar(&scale.Opts.SSHQuiet, "ssh-quiet", false, "Suppress output of commands run on the nodes via SSH (optional)")
+
+	util.MarkFlagRequired(scale.cmd, "workers")
+
+	scale.cmd.RunE = scale.RunE
+	util.AddCmd(bootstrapGcp, scale.cmd)
+}
@@ -0,0 +1,147 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	csio "github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	intutil "github.com/codesphere-cloud/oms/internal/util"
+	"github.com/spf13/cobra"
+)
+
+type BootstrapGcpTimersCmd struct {
+	cmd  *cobra.Command
+	Opts *BootstrapGcpTimersOpts
+}
+
+type BootstrapGcpTimersOpts struct {
+	*util.GlobalOptions
+	Set      string
+	Schedule string
+	Command  string
+	Remove   string
+	SSHQuiet bool
+}
+
+func (c *BootstrapGcpTimersCmd) RunE(_ *cobra.Command, _ []string) error {
+	fw := intutil.NewFilesystemWriter()
+
+	infraEnv, exists, err := gcp.LoadInfraFile(fw, gcp.GetInfraFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to load infra file: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("infra file not found at %s; bootstrap the environment first", gcp.GetInfraFilePath())
+	}
+	if infraEnv.Jumpbox == nil {
+		return fmt.Errorf("infra file does not contain a jumpbox")
+	}
+	infraEnv.Jumpbox.NodeClient = node.NewSSHNodeClient(c.Opts.SSHQuiet)
+
+	return c.ManageTimers(fw, &infraEnv)
+}
+
+// ManageTimers applies the requested scheduled job change on the jumpbox and
+// persists the declared jobs in the infra file.
+func (c *BootstrapGcpTimersCmd) ManageTimers(fw intutil.FileIO, infraEnv *gcp.CodesphereEnvironment) error {
+	switch {
+	case c.Opts.Set != "" && c.Opts.Remove != "":
+		return fmt.Errorf("--set and --remove are mutually exclusive")
+
+	case c.Opts.Set != "":
+		if c.Opts.Schedule == "" || c.Opts.Command == "" {
+			return fmt.Errorf("--schedule and --command are required with --set")
+		}
+		job := gcp.ScheduledJob{Name: c.Opts.Set, Schedule: c.Opts.Schedule, Command: c.Opts.Command}
+		if err := gcp.InstallScheduledJob(infraEnv.Jumpbox, job); err != nil {
+			return err
+		}
+		infraEnv.ScheduledJobs = upsertScheduledJob(infraEnv.ScheduledJobs, job)
+		log.Printf("Scheduled job %s installed (%s)", job.Name, job.Schedule)
+
+	case c.Opts.Remove != "":
+		jobs, found := removeScheduledJob(infraEnv.ScheduledJobs, c.Opts.Remove)
+		if !found {
+			return fmt.Errorf("no scheduled job named %s declared in the environment", c.Opts.Remove)
+		}
+		if err := gcp.RemoveScheduledJob(infraEnv.Jumpbox, c.Opts.Remove); err != nil {
+			return err
+		}
+		infraEnv.ScheduledJobs = jobs
+		log.Printf("Scheduled job %s removed", c.Opts.Remove)
+
+	default:
+		if len(infraEnv.ScheduledJobs) == 0 {
+			log.Println("No scheduled jobs declared in the environment.")
+			return nil
+		}
+		for _, job := range infraEnv.ScheduledJobs {
+			if err := gcp.InstallScheduledJob(infraEnv.Jumpbox, job); err != nil {
+				return err
+			}
+			log.Printf("Scheduled job %s installed (%s)", job.Name, job.Schedule)
+		}
+	}
+
+	return gcp.SaveInfraFile(fw, infraEnv)
+}
+
+// upsertScheduledJob replaces the job with the same name or appends it.
+func upsertScheduledJob(jobs []gcp.ScheduledJob, job gcp.ScheduledJob) []gcp.ScheduledJob {
+	for i, existing := range jobs {
+		if existing.Name == job.Name {
+			jobs[i] = job
+			return jobs
+		}
+	}
+	return append(jobs, job)
+}
+
+// removeScheduledJob drops the named job and reports whether it was declared.
+func removeScheduledJob(jobs []gcp.ScheduledJob, name string) ([]gcp.ScheduledJob, bool) {
+	for i, existing := range jobs {
+		if existing.Name == name {
+			return append(jobs[:i], jobs[i+1:]...), true
+		}
+	}
+	return jobs, false
+}
+
+func AddBootstrapGcpTimersCmd(bootstrapGcp *cobra.Command, opts *util.GlobalOptions) {
+	timers := BootstrapGcpTimersCmd{
+		cmd: &cobra.Command{
+			Use:   "timers",
+			Short: "Manage systemd timers for maintenance tasks on the jumpbox",
+			Long: csio.Long(`Manages systemd timers on the jumpbox for recurring maintenance tasks
+				such as backup triggers, registry garbage collection, and certificate checks.
+				Jobs are declared in the environment (the local infra file) and installed as
+				oms-<name>.service/.timer units.
+				Without flags, all declared jobs are (re-)installed on the jumpbox.`),
+			Example: util.FormatExamples("beta bootstrap-gcp timers", []csio.Example{
+				{Desc: "Reinstall all declared scheduled jobs on the jumpbox"},
+				{Cmd: "--set registry-gc --schedule weekly --command 'oms registry gc'", Desc: "Add or update a weekly registry garbage collection job"},
+				{Cmd: "--set cert-check --schedule '*-*-* 03:00:00' --command 'oms verify certs'", Desc: "Add a nightly certificate check"},
+				{Cmd: "--remove registry-gc", Desc: "Remove a scheduled job"},
+			}),
+		},
+		Opts: &BootstrapGcpTimersOpts{
+			GlobalOptions: opts,
+		},
+	}
+
+	flags := timers.cmd.Flags()
+	flags.StringVar(&timers.Opts.Set, "set", "", "Name of a scheduled job to add or update (requires --schedule and --command)")
+	flags.StringVar(&timers.Opts.Schedule, "schedule", "", "systemd OnCalendar expression for the job (e.g. daily, weekly, '*-*-* 03:00:00')")
+	flags.StringVar(&timers.Opts.Command, "command", "", "Shell command the job executes on the jumpbox")
+	flags.StringVar(&timers.Opts.Remove, "remove", "", "Name of a scheduled job to remove")
+	flags.BoolVar(&timers.Opts.SSHQuiet, "ssh-quiet", false, "Suppress SSH command output (default: false)")
+
+	timers.cmd.RunE = timers.RunE
+	util.AddCmd(bootstrapGcp, timers.cmd)
+}
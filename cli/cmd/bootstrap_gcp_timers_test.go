@@ -0,0 +1,136 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/codesphere-cloud/oms/cli/cmd"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	intutil "github.com/codesphere-cloud/oms/internal/util"
+)
+
+var _ = Describe("BootstrapGcpTimersCmd", func() {
+	Describe("AddBootstrapGcpTimersCmd", func() {
+		It("registers the timers command with its flags", func() {
+			parent := &cobra.Command{Use: "bootstrap-gcp"}
+			cmd.AddBootstrapGcpTimersCmd(parent, &util.GlobalOptions{})
+
+			timers, _, err := parent.Find([]string{"timers"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(timers.Use).To(Equal("timers"))
+			Expect(timers.Flags().Lookup("set")).NotTo(BeNil())
+			Expect(timers.Flags().Lookup("schedule")).NotTo(BeNil())
+			Expect(timers.Flags().Lookup("command")).NotTo(BeNil())
+			Expect(timers.Flags().Lookup("remove")).NotTo(BeNil())
+		})
+	})
+
+	Describe("ManageTimers", func() {
+		var (
+			timersCmd  *cmd.BootstrapGcpTimersCmd
+			nodeClient *node.MockNodeClient
+			mockFileIO *intutil.MockFileIO
+			infraEnv   *gcp.CodesphereEnvironment
+		)
+
+		BeforeEach(func() {
+			nodeClient = node.NewMockNodeClient(GinkgoT())
+			mockFileIO = intutil.NewMockFileIO(GinkgoT())
+
+			timersCmd = &cmd.BootstrapGcpTimersCmd{
+				Opts: &cmd.BootstrapGcpTimersOpts{
+					GlobalOptions: &util.GlobalOptions{},
+				},
+			}
+
+			infraEnv = &gcp.CodesphereEnvironment{
+				ProjectID: "test-project",
+				Jumpbox:   &node.Node{Name: "jumpbox", ExternalIP: "1.2.3.4", NodeClient: nodeClient},
+			}
+		})
+
+		expectInfraFileWrite := func() {
+			mockFileIO.EXPECT().MkdirAll(mock.Anything, mock.Anything).Return(nil)
+			mockFileIO.EXPECT().WriteFile(gcp.GetInfraFilePath(), mock.Anything, mock.Anything).Return(nil)
+		}
+
+		It("rejects --set combined with --remove", func() {
+			timersCmd.Opts.Set = "backup"
+			timersCmd.Opts.Remove = "backup"
+
+			err := timersCmd.ManageTimers(mockFileIO, infraEnv)
+			Expect(err).To(MatchError(ContainSubstring("mutually exclusive")))
+		})
+
+		It("requires schedule and command with --set", func() {
+			timersCmd.Opts.Set = "backup"
+
+			err := timersCmd.ManageTimers(mockFileIO, infraEnv)
+			Expect(err).To(MatchError(ContainSubstring("--schedule and --command are required")))
+		})
+
+		It("installs a job and declares it in the infra file", func() {
+			timersCmd.Opts.Set = "backup"
+			timersCmd.Opts.Schedule = "daily"
+			timersCmd.Opts.Command = "oms backup run"
+
+			nodeClient.EXPECT().RunCommand(mock.Anything, "root", mock.Anything).Return(nil)
+			expectInfraFileWrite()
+
+			Expect(timersCmd.ManageTimers(mockFileIO, infraEnv)).To(Succeed())
+			Expect(infraEnv.ScheduledJobs).To(HaveLen(1))
+			Expect(infraEnv.ScheduledJobs[0].Name).To(Equal("backup"))
+		})
+
+		It("updates an already declared job instead of duplicating it", func() {
+			infraEnv.ScheduledJobs = []gcp.ScheduledJob{{Name: "backup", Schedule: "weekly", Command: "oms backup run"}}
+			timersCmd.Opts.Set = "backup"
+			timersCmd.Opts.Schedule = "daily"
+			timersCmd.Opts.Command = "oms backup run"
+
+			nodeClient.EXPECT().RunCommand(mock.Anything, "root", mock.Anything).Return(nil)
+			expectInfraFileWrite()
+
+			Expect(timersCmd.ManageTimers(mockFileIO, infraEnv)).To(Succeed())
+			Expect(infraEnv.ScheduledJobs).To(HaveLen(1))
+			Expect(infraEnv.ScheduledJobs[0].Schedule).To(Equal("daily"))
+		})
+
+		It("removes a declared job from the jumpbox and the infra file", func() {
+			infraEnv.ScheduledJobs = []gcp.ScheduledJob{{Name: "backup", Schedule: "daily", Command: "oms backup run"}}
+			timersCmd.Opts.Remove = "backup"
+
+			nodeClient.EXPECT().RunCommand(mock.Anything, "root", mock.Anything).Return(nil)
+			expectInfraFileWrite()
+
+			Expect(timersCmd.ManageTimers(mockFileIO, infraEnv)).To(Succeed())
+			Expect(infraEnv.ScheduledJobs).To(BeEmpty())
+		})
+
+		It("refuses to remove a job that is not declared", func() {
+			timersCmd.Opts.Remove = "missing"
+
+			err := timersCmd.ManageTimers(mockFileIO, infraEnv)
+			Expect(err).To(MatchError(ContainSubstring("no scheduled job named missing")))
+		})
+
+		It("reinstalls all declared jobs without flags", func() {
+			infraEnv.ScheduledJobs = []gcp.ScheduledJob{
+				{Name: "backup", Schedule: "daily", Command: "oms backup run"},
+				{Name: "registry-gc", Schedule: "weekly", Command: "oms registry gc"},
+			}
+
+			nodeClient.EXPECT().RunCommand(mock.Anything, "root", mock.Anything).Return(nil).Times(8)
+			expectInfraFileWrite()
+
+			Expect(timersCmd.ManageTimers(mockFileIO, infraEnv)).To(Succeed())
+		})
+	})
+})
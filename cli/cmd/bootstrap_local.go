@@ -90,6 +90,15 @@ func AddBootstrapLocalCmd(parent *cobra.Command) {
 	flags.StringVar(&bootstrapLocalCmd.CodesphereEnv.ServiceCIDR, "service-cidr", "", "Service CIDR of the Kubernetes cluster. If not specified, OMS will try to determine it.")
 	flags.StringVar(&bootstrapLocalCmd.CodesphereEnv.PodCIDR, "pod-cidr", "", "Service CIDR of the Kubernetes cluster. If not specified, OMS will try to determine it.")
 
+	// MetalLB, for clusters without a cloud load-balancer integration
+	flags.StringArrayVar(&bootstrapLocalCmd.CodesphereEnv.MetalLBPool, "metallb-pool", []string{}, "MetalLB address pool entry, as a CIDR (10.0.0.64/26) or a range (10.0.0.10-10.0.0.20). Repeatable; enables MetalLB in the install config (optional)")
+	flags.StringVar(&bootstrapLocalCmd.CodesphereEnv.MetalLBMode, "metallb-mode", local.MetalLBModeL2, "How MetalLB announces the pool addresses: l2 or bgp (default: l2)")
+	flags.StringVar(&bootstrapLocalCmd.CodesphereEnv.NodeSubnet, "node-subnet", "", "CIDR of the node network, used to validate the MetalLB address ranges in L2 mode (required with --metallb-pool in L2 mode)")
+	flags.IntVar(&bootstrapLocalCmd.CodesphereEnv.MetalLBBGPMyASN, "metallb-bgp-my-asn", 0, "Local AS number of the MetalLB speakers (required with --metallb-mode bgp)")
+	flags.IntVar(&bootstrapLocalCmd.CodesphereEnv.MetalLBBGPPeerASN, "metallb-bgp-peer-asn", 0, "AS number of the BGP peer router (required with --metallb-mode bgp)")
+	flags.StringVar(&bootstrapLocalCmd.CodesphereEnv.MetalLBBGPPeerAddress, "metallb-bgp-peer-address", "", "Address of the BGP peer router (required with --metallb-mode bgp)")
+	flags.StringVar(&bootstrapLocalCmd.CodesphereEnv.MetalLBBGPBFDProfile, "metallb-bgp-bfd-profile", "", "BFD profile for fast BGP peer failure detection (optional)")
+
 	// Config
 	flags.StringVar(&bootstrapLocalCmd.CodesphereEnv.InstallDir, "install-dir", ".installer", "Directory for config, secrets, and bundle files")
 	flags.StringVar(&bootstrapLocalCmd.CodesphereEnv.InstallConfigPath, "install-config", "", "Path to install config file (default: <install-dir>/config.yaml)")
@@ -131,6 +140,11 @@ func (c *BootstrapLocalCmd) BootstrapLocal() error {
 		return err
 	}
 
+	// Fail on invalid MetalLB options before any infrastructure is touched.
+	if err := local.ValidateMetalLBConfig(c.CodesphereEnv); err != nil {
+		return err
+	}
+
 	if c.cmd.Flags().Changed("experiments") {
 		if c.cmd.Flags().Changed("internal-flags") {
 			log.Printf("Warning: both --experiments and --internal-flags were set; ignoring deprecated --experiments values %v", c.experiments)
@@ -250,10 +264,6 @@ func (c *BootstrapLocalCmd) ValidatePrerequisites(ctx context.Context) error {
 		return err
 	}
 
-	if err := c.ValidateEncryptionTools(); err != nil {
-		return err
-	}
-
 	return nil
 }
 
@@ -297,15 +307,3 @@ func (c *BootstrapLocalCmd) ValidateHelmVersion(ctx context.Context) error {
 
 	return nil
 }
-
-func (c *BootstrapLocalCmd) ValidateEncryptionTools() error {
-	if _, err := exec.LookPath("sops"); err != nil {
-		return fmt.Errorf("sops binary not found in PATH; install it with: brew install sops")
-	}
-
-	if _, err := exec.LookPath("age-keygen"); err != nil {
-		return fmt.Errorf("age binary not found in PATH (age-keygen missing); install it with: brew install age")
-	}
-
-	return nil
-}
@@ -4,6 +4,7 @@
 package codesphere
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -16,6 +17,7 @@ import (
 	"github.com/codesphere-cloud/oms/internal/installer/argocd"
 	"github.com/codesphere-cloud/oms/internal/installer/files"
 	"github.com/codesphere-cloud/oms/internal/installer/vault"
+	"github.com/codesphere-cloud/oms/internal/telemetry"
 	intutil "github.com/codesphere-cloud/oms/internal/util"
 	"github.com/spf13/cobra"
 	"go.yaml.in/yaml/v3"
@@ -54,8 +56,17 @@ type InstallCodesphereOpts struct {
 	PCAppsValues         []string
 }
 
-func (c *InstallCodesphereCmd) RunE(cmd *cobra.Command, _ []string) error {
+func (c *InstallCodesphereCmd) RunE(cmd *cobra.Command, _ []string) (retErr error) {
 	ctx := cmd.Context()
+
+	shutdownTracing, err := telemetry.SetupTracing(ctx, "oms-install")
+	if err != nil {
+		return fmt.Errorf("failed to set up tracing: %w", err)
+	}
+	defer func() { _ = shutdownTracing(context.Background()) }()
+	ctx, rootSpan := telemetry.StartSpan(ctx, "install-codesphere")
+	defer func() { telemetry.EndSpan(rootSpan, retErr) }()
+
 	effectiveOpts, cfg, cleanup, err := prepareInstallConfig(c.Opts, installer.NewConfig())
 	if err != nil {
 		return err
@@ -75,18 +86,43 @@ func (c *InstallCodesphereCmd) RunE(cmd *cobra.Command, _ []string) error {
 		AllowedSteps: installer.PlatformSteps,
 	}
 
+	// Progress is persisted to the status file so `oms-cli install status` can
+	// follow the installation from another machine, even after the SSH session
+	// that started it dropped.
+	status := installer.NewStatusWriter(intutil.NewFilesystemWriter(), intutil.NewTime(), installer.InstallStatusPath)
+	err = c.runInstallPhases(ctx, effectiveOpts, cfg, status, infraInstaller, dependenciesInstaller, platformInstaller)
+	status.Finish(err)
+	return err
+}
+
+func (c *InstallCodesphereCmd) runInstallPhases(
+	ctx context.Context,
+	effectiveOpts *InstallCodesphereOpts,
+	cfg files.RootConfig,
+	status *installer.StatusWriter,
+	infraInstaller, dependenciesInstaller, platformInstaller *installer.CodesphereInstaller,
+) error {
 	if c.Opts.CodesphereOnly {
-		return installCodespherePlatform(ctx, effectiveOpts, cfg, c.Env)
+		status.BeginPhase("platform", platformInstaller.ExecutableSteps(cfg))
+		err := installCodespherePlatform(ctx, effectiveOpts, cfg, c.Env)
+		status.EndPhase(err)
+		return err
 	}
 
 	if infraInstaller.HasExecutableSteps(cfg) {
-		if err := installCodesphereInfra(effectiveOpts, c.Env); err != nil {
+		status.BeginPhase("infrastructure", infraInstaller.ExecutableSteps(cfg))
+		err := installCodesphereInfra(effectiveOpts, c.Env)
+		status.EndPhase(err)
+		if err != nil {
 			return err
 		}
 	}
 
 	if dependenciesInstaller.HasExecutableSteps(cfg) || !installer.IsStepSkipped(cfg, c.Opts.SkipSteps, installer.ArgoCDStep) {
-		if err := installCodesphereDepencies(effectiveOpts, cfg, c.Env); err != nil {
+		status.BeginPhase("dependencies", dependenciesInstaller.ExecutableSteps(cfg))
+		err := installCodesphereDepencies(effectiveOpts, cfg, c.Env)
+		status.EndPhase(err)
+		if err != nil {
 			return err
 		}
 	}
@@ -95,7 +131,10 @@ func (c *InstallCodesphereCmd) RunE(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
-	return installCodespherePlatform(ctx, effectiveOpts, cfg, c.Env)
+	status.BeginPhase("platform", platformInstaller.ExecutableSteps(cfg))
+	err := installCodespherePlatform(ctx, effectiveOpts, cfg, c.Env)
+	status.EndPhase(err)
+	return err
 }
 
 func AddInstallCmd(install *cobra.Command, opts *util.GlobalOptions) {
@@ -39,6 +39,14 @@ func installCodespherePlatform(ctx context.Context, opts *InstallCodesphereOpts,
 		return fmt.Errorf("failed to set cluster admin email: %w", err)
 	}
 
+	if err := installer.EnsureWorkspaceEgressPolicies(ctx, opts.Vault, opts.PrivKey, cfg); err != nil {
+		return fmt.Errorf("failed to apply workspace egress policies: %w", err)
+	}
+
+	if err := installer.EnsureNodeLocalDNS(ctx, opts.Vault, opts.PrivKey, cfg); err != nil {
+		return fmt.Errorf("failed to deploy node-local-dns: %w", err)
+	}
+
 	workdir := env.GetOmsWorkdir()
 	pm := installer.NewPackage(workdir, opts.Package)
 	cm := installer.NewConfig()
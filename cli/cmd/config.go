@@ -0,0 +1,30 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+type ConfigCmd struct {
+	cmd *cobra.Command
+}
+
+func AddConfigCmd(rootCmd *cobra.Command, opts *util.GlobalOptions) {
+	config := ConfigCmd{
+		cmd: &cobra.Command{
+			Use:   "config",
+			Short: "Inspect and normalize OMS configuration files",
+			Long:  io.Long(`Inspect and normalize OMS configuration files.`),
+		},
+	}
+
+	AddConfigRenderCmd(config.cmd, opts)
+	AddConfigSchemaCmd(config.cmd, opts)
+	AddConfigValidateCmd(config.cmd, opts)
+	AddConfigMigrateCmd(config.cmd, opts)
+	util.AddCmd(rootCmd, config.cmd)
+}
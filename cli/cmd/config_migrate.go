@@ -0,0 +1,97 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/spf13/cobra"
+)
+
+type ConfigMigrateCmd struct {
+	cmd  *cobra.Command
+	Opts ConfigMigrateOpts
+}
+
+type ConfigMigrateOpts struct {
+	*util.GlobalOptions
+	Config string
+	Write  bool
+}
+
+func (c *ConfigMigrateCmd) RunE(cmd *cobra.Command, _ []string) error {
+	data, err := os.ReadFile(c.Opts.Config)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", c.Opts.Config, err)
+	}
+
+	migrated, applied, err := files.MigrateConfigYAML(data)
+	if err != nil {
+		return err
+	}
+
+	if len(applied) == 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "%s is already at schema version %d, nothing to migrate\n", c.Opts.Config, files.CurrentSchemaVersion)
+		return nil
+	}
+
+	for _, transformation := range applied {
+		fmt.Fprintf(cmd.ErrOrStderr(), "applied %s\n", transformation)
+	}
+
+	if c.Opts.Write {
+		if err := os.WriteFile(c.Opts.Config, migrated, 0600); err != nil {
+			return fmt.Errorf("failed to write migrated config to %s: %w", c.Opts.Config, err)
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "updated %s to schema version %d\n", c.Opts.Config, files.CurrentSchemaVersion)
+		return nil
+	}
+
+	if _, err := fmt.Fprint(cmd.OutOrStdout(), string(migrated)); err != nil {
+		return fmt.Errorf("failed to write migrated config: %w", err)
+	}
+	return nil
+}
+
+func AddConfigMigrateCmd(parentCmd *cobra.Command, opts *util.GlobalOptions) {
+	migrateCmd := &ConfigMigrateCmd{
+		cmd: &cobra.Command{
+			Use:   "migrate",
+			Short: "Upgrade a config.yaml to the current schema version",
+			Long: io.Long(`Upgrade a config.yaml written for an older OMS release to the current
+				config schema version.
+
+				The migrations operate on the raw YAML document, so comments, key order, and
+				fields unknown to this release are preserved. Every applied transformation is
+				reported, and the schemaVersion field is set to the current version. Without
+				--write the migrated config is printed to stdout and the input file is left
+				untouched.`),
+			Example: util.FormatExamples("config migrate", []io.Example{
+				{
+					Cmd:  "--config config.yaml",
+					Desc: "Print the migrated config without changing the file",
+				},
+				{
+					Cmd:  "--config config.yaml --write",
+					Desc: "Upgrade config.yaml in place",
+				},
+			}),
+			Args: cobra.ExactArgs(0),
+		},
+		Opts: ConfigMigrateOpts{GlobalOptions: opts},
+	}
+
+	migrateCmd.cmd.Flags().StringVarP(&migrateCmd.Opts.Config, "config", "c", "", "Path to the config.yaml to migrate (required)")
+	migrateCmd.cmd.Flags().BoolVarP(&migrateCmd.Opts.Write, "write", "w", false, "Update the config file in place instead of printing to stdout")
+
+	util.MarkFlagRequired(migrateCmd.cmd, "config")
+
+	util.AddCmd(parentCmd, migrateCmd.cmd)
+
+	migrateCmd.cmd.RunE = migrateCmd.RunE
+}
@@ -0,0 +1,52 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"github.com/codesphere-cloud/oms/cli/cmd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConfigMigrateCmd", func() {
+	migrateConfig := func(content string, extraArgs ...string) (string, string, error) {
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.yaml")
+		Expect(os.WriteFile(configPath, []byte(content), 0644)).To(Succeed())
+
+		rootCmd := cmd.GetRootCmd()
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(append([]string{"config", "migrate", "-c", configPath}, extraArgs...))
+
+		err := rootCmd.Execute()
+		return stdout.String(), stderr.String(), err
+	}
+
+	It("prints the migrated config and the applied transformations", func() {
+		stdout, stderr, err := migrateConfig("datacenter:\n  id: 1\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(ContainSubstring("schemaVersion: 2"))
+		Expect(stdout).To(ContainSubstring("dataCenter:"))
+		Expect(stderr).To(ContainSubstring("applied v1 -> v2"))
+	})
+
+	It("reports when the config is already current", func() {
+		stdout, stderr, err := migrateConfig("schemaVersion: 2\ndataCenter:\n  id: 1\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(BeEmpty())
+		Expect(stderr).To(ContainSubstring("nothing to migrate"))
+	})
+
+	It("fails for configs newer than the supported schema", func() {
+		_, _, err := migrateConfig("schemaVersion: 99\n")
+		Expect(err).To(MatchError(ContainSubstring("newer than the supported version")))
+	})
+})
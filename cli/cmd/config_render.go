@@ -0,0 +1,87 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/spf13/cobra"
+)
+
+type ConfigRenderCmd struct {
+	cmd  *cobra.Command
+	Opts ConfigRenderOpts
+}
+
+type ConfigRenderOpts struct {
+	*util.GlobalOptions
+	Config string
+}
+
+func (c *ConfigRenderCmd) RunE(cmd *cobra.Command, _ []string) error {
+	rendered, err := c.Render()
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprint(cmd.OutOrStdout(), string(rendered)); err != nil {
+		return fmt.Errorf("failed to write rendered config: %w", err)
+	}
+
+	return nil
+}
+
+func AddConfigRenderCmd(parentCmd *cobra.Command, opts *util.GlobalOptions) {
+	renderCmd := &ConfigRenderCmd{
+		cmd: &cobra.Command{
+			Use:   "render",
+			Short: "Render a config.yaml in canonical form",
+			Long: io.Long(`Render a config.yaml in canonical form.
+
+The config is parsed and marshalled deterministically: struct keys keep a fixed order,
+map keys are sorted, and optional sections that only hold their default values are dropped.
+Rendering the same configuration always yields byte-identical output, so the result can be
+compared against golden files in CI to spot unintended changes to generated configs.`),
+			Example: util.FormatExamples("config render", []io.Example{
+				{
+					Cmd:  "--config config.yaml > config.golden.yaml",
+					Desc: "Render config.yaml in canonical form and save it as a golden file",
+				},
+			}),
+			Args: cobra.ExactArgs(0),
+		},
+		Opts: ConfigRenderOpts{GlobalOptions: opts},
+	}
+
+	renderCmd.cmd.Flags().StringVarP(&renderCmd.Opts.Config, "config", "c", "", "Path to the config.yaml to render (required)")
+
+	util.MarkFlagRequired(renderCmd.cmd, "config")
+
+	util.AddCmd(parentCmd, renderCmd.cmd)
+
+	renderCmd.cmd.RunE = renderCmd.RunE
+}
+
+func (c *ConfigRenderCmd) Render() ([]byte, error) {
+	data, err := os.ReadFile(c.Opts.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", c.Opts.Config, err)
+	}
+
+	config := &files.RootConfig{}
+	if err := config.Unmarshal(data); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", c.Opts.Config, err)
+	}
+
+	rendered, err := config.MarshalDeterministic()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render config: %w", err)
+	}
+
+	return rendered, nil
+}
@@ -0,0 +1,67 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"github.com/codesphere-cloud/oms/cli/cmd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConfigRenderCmd", func() {
+	renderConfig := func(content string) string {
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.yaml")
+		Expect(os.WriteFile(configPath, []byte(content), 0644)).To(Succeed())
+
+		rootCmd := cmd.GetRootCmd()
+		var output bytes.Buffer
+		rootCmd.SetOut(&output)
+		rootCmd.SetErr(&output)
+		rootCmd.SetArgs([]string{"config", "render", "--config", configPath})
+
+		Expect(rootCmd.Execute()).To(Succeed())
+		return output.String()
+	}
+
+	It("renders equivalent configs byte-identically", func() {
+		minimal := renderConfig(`dataCenter:
+  id: 1
+  name: test-dc
+codesphere:
+  domain: test.example.com
+`)
+		// Same config with reordered keys and defaults spelled out.
+		explicit := renderConfig(`codesphere:
+  domain: test.example.com
+dataCenter:
+  name: test-dc
+  id: 1
+registry:
+  server: ""
+  replaceImagesInBom: false
+  loadContainerImages: false
+pcApps: {}
+`)
+
+		Expect(explicit).To(Equal(minimal))
+	})
+
+	It("adds the render command with required flags", func() {
+		rootCmd := cmd.GetRootCmd()
+
+		renderCmd, _, err := rootCmd.Find([]string{"config", "render"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(renderCmd).NotTo(BeNil())
+		Expect(renderCmd.Use).To(Equal("render"))
+		Expect(renderCmd.Short).To(Equal("Render a config.yaml in canonical form"))
+
+		Expect(renderCmd.Flags().Lookup("config")).NotTo(BeNil())
+	})
+})
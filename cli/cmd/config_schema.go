@@ -0,0 +1,55 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/spf13/cobra"
+)
+
+type ConfigSchemaCmd struct {
+	cmd *cobra.Command
+}
+
+func (c *ConfigSchemaCmd) RunE(cmd *cobra.Command, _ []string) error {
+	schema, err := files.ConfigSchemaJSON()
+	if err != nil {
+		return fmt.Errorf("failed to generate config schema: %w", err)
+	}
+
+	if _, err := fmt.Fprintln(cmd.OutOrStdout(), string(schema)); err != nil {
+		return fmt.Errorf("failed to write config schema: %w", err)
+	}
+
+	return nil
+}
+
+func AddConfigSchemaCmd(parentCmd *cobra.Command, opts *util.GlobalOptions) {
+	schemaCmd := &ConfigSchemaCmd{
+		cmd: &cobra.Command{
+			Use:   "schema",
+			Short: "Print the JSON Schema of the install config",
+			Long: io.Long(`Print the JSON Schema of the install config (config.yaml) to stdout.
+
+The schema is generated from the config structure of this OMS version and can be used
+for editor completion and linting, or stored alongside a config to validate it in CI.
+The same schema backs the config validate command.`),
+			Example: util.FormatExamples("config schema", []io.Example{
+				{
+					Cmd:  "> config-schema.json",
+					Desc: "Export the config schema for use in an editor or CI",
+				},
+			}),
+			Args: cobra.ExactArgs(0),
+		},
+	}
+
+	util.AddCmd(parentCmd, schemaCmd.cmd)
+
+	schemaCmd.cmd.RunE = schemaCmd.RunE
+}
@@ -0,0 +1,78 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/spf13/cobra"
+)
+
+type ConfigValidateCmd struct {
+	cmd *cobra.Command
+}
+
+func (c *ConfigValidateCmd) RunE(cmd *cobra.Command, args []string) error {
+	configPath := args[0]
+
+	issues, err := c.Validate(configPath)
+	if err != nil {
+		return err
+	}
+
+	if len(issues) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s is valid\n", configPath)
+		return nil
+	}
+
+	for _, issue := range issues {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", configPath, issue.String())
+	}
+	return fmt.Errorf("config file %s has %d validation issue(s)", configPath, len(issues))
+}
+
+// Validate checks the given config file against the generated install
+// config schema and returns the violations found.
+func (c *ConfigValidateCmd) Validate(configPath string) ([]files.ConfigValidationIssue, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
+
+	issues, err := files.ValidateConfigYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate config file %s: %w", configPath, err)
+	}
+	return issues, nil
+}
+
+func AddConfigValidateCmd(parentCmd *cobra.Command, opts *util.GlobalOptions) {
+	validateCmd := &ConfigValidateCmd{
+		cmd: &cobra.Command{
+			Use:   "validate <file>",
+			Short: "Validate a config.yaml against the install config schema",
+			Long: io.Long(`Validate a config.yaml against the JSON Schema generated from the config
+structure of this OMS version (see config schema).
+
+Unknown keys, type mismatches, and missing required fields are reported with their
+line numbers, so mistakes surface before an install or update fails deep inside
+the installer.`),
+			Example: util.FormatExamples("config validate", []io.Example{
+				{
+					Cmd:  "config.yaml",
+					Desc: "Validate config.yaml and list all schema violations",
+				},
+			}),
+			Args: cobra.ExactArgs(1),
+		},
+	}
+
+	util.AddCmd(parentCmd, validateCmd.cmd)
+
+	validateCmd.cmd.RunE = validateCmd.RunE
+}
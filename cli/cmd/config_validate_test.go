@@ -0,0 +1,56 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"github.com/codesphere-cloud/oms/cli/cmd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConfigValidateCmd", func() {
+	validateConfig := func(content string) (string, error) {
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.yaml")
+		Expect(os.WriteFile(configPath, []byte(content), 0644)).To(Succeed())
+
+		rootCmd := cmd.GetRootCmd()
+		var output bytes.Buffer
+		rootCmd.SetOut(&output)
+		rootCmd.SetErr(&output)
+		rootCmd.SetArgs([]string{"config", "validate", configPath})
+
+		err := rootCmd.Execute()
+		return output.String(), err
+	}
+
+	It("reports schema violations with line numbers and fails", func() {
+		output, err := validateConfig(`dataCenter:
+  id: one
+notAConfigKey: true
+`)
+		Expect(err).To(MatchError(ContainSubstring("validation issue")))
+		Expect(output).To(ContainSubstring("line "))
+		Expect(output).To(ContainSubstring("/dataCenter/id"))
+	})
+})
+
+var _ = Describe("ConfigSchemaCmd", func() {
+	It("prints the config schema as JSON", func() {
+		rootCmd := cmd.GetRootCmd()
+		var output bytes.Buffer
+		rootCmd.SetOut(&output)
+		rootCmd.SetErr(&output)
+		rootCmd.SetArgs([]string{"config", "schema"})
+
+		Expect(rootCmd.Execute()).To(Succeed())
+		Expect(output.String()).To(ContainSubstring(`"$schema"`))
+		Expect(output.String()).To(ContainSubstring(`"dataCenter"`))
+	})
+})
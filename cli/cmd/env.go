@@ -0,0 +1,54 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/spf13/cobra"
+)
+
+type EnvCmd struct {
+	cmd *cobra.Command
+}
+
+func AddEnvCmd(rootCmd *cobra.Command, opts *util.GlobalOptions) {
+	envCmd := EnvCmd{
+		cmd: &cobra.Command{
+			Use:   "env",
+			Short: "Manage a bootstrapped environment as a whole",
+			Long:  io.Long(`Manage a bootstrapped environment as a whole, e.g. stop and start all of its VMs.`),
+		},
+	}
+
+	AddEnvShowCmd(envCmd.cmd, opts)
+	AddEnvStopCmd(envCmd.cmd, opts)
+	AddEnvStartCmd(envCmd.cmd, opts)
+	util.AddCmd(rootCmd, envCmd.cmd)
+}
+
+// rehydrateInfraNodes reattaches the node client to the nodes loaded from the
+// infra file. Nodes other than the jumpbox lost their jumpbox reference
+// during serialization, so they are rebuilt as sub-nodes of the connected
+// jumpbox.
+func rehydrateInfraNodes(infraEnv *gcp.CodesphereEnvironment, nodeClient node.NodeClient) {
+	if infraEnv.Jumpbox == nil {
+		return
+	}
+	infraEnv.Jumpbox.NodeClient = nodeClient
+	for i, n := range infraEnv.ControlPlaneNodes {
+		infraEnv.ControlPlaneNodes[i] = infraEnv.Jumpbox.CreateSubNode(n.GetName(), n.GetExternalIP(), n.GetInternalIP())
+	}
+	if infraEnv.PostgreSQLNode != nil {
+		infraEnv.PostgreSQLNode = infraEnv.Jumpbox.CreateSubNode(infraEnv.PostgreSQLNode.GetName(), infraEnv.PostgreSQLNode.GetExternalIP(), infraEnv.PostgreSQLNode.GetInternalIP())
+	}
+	if infraEnv.PostgreSQLReplicaNode != nil {
+		infraEnv.PostgreSQLReplicaNode = infraEnv.Jumpbox.CreateSubNode(infraEnv.PostgreSQLReplicaNode.GetName(), infraEnv.PostgreSQLReplicaNode.GetExternalIP(), infraEnv.PostgreSQLReplicaNode.GetInternalIP())
+	}
+	for i, n := range infraEnv.CephNodes {
+		infraEnv.CephNodes[i] = infraEnv.Jumpbox.CreateSubNode(n.GetName(), n.GetExternalIP(), n.GetInternalIP())
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	csio "github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	intutil "github.com/codesphere-cloud/oms/internal/util"
+	"github.com/spf13/cobra"
+)
+
+type EnvShowCmd struct {
+	cmd  *cobra.Command
+	Opts *EnvShowOpts
+}
+
+type EnvShowOpts struct {
+	*util.GlobalOptions
+}
+
+func (c *EnvShowCmd) RunE(_ *cobra.Command, _ []string) error {
+	fw := intutil.NewFilesystemWriter()
+
+	infraFilePath := gcp.GetInfraFilePath()
+	infraEnv, exists, err := gcp.LoadInfraFile(fw, infraFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to load infra file: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("infra file not found at %s; bootstrap the environment first", infraFilePath)
+	}
+
+	return ShowEnvironment(&infraEnv, c.cmd.OutOrStdout())
+}
+
+// ShowEnvironment prints a summary of the environment from the infra file:
+// versions, node addresses and the last recorded installer failure.
+func ShowEnvironment(infraEnv *gcp.CodesphereEnvironment, out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Project ID:\t%s\n", infraEnv.ProjectID)
+	fmt.Fprintf(w, "Zone:\t%s\n", infraEnv.Zone)
+	fmt.Fprintf(w, "Base domain:\t%s\n", infraEnv.BaseDomain)
+	fmt.Fprintf(w, "Codesphere version:\t%s\n", orUnknown(infraEnv.InstallVersion))
+	fmt.Fprintf(w, "Kubernetes version:\t%s\n", orUnknown(infraEnv.KubernetesVersion))
+	fmt.Fprintf(w, "Bootstrapped by oms-cli:\t%s\n", orUnknown(infraEnv.OmsVersion))
+
+	fmt.Fprintln(w, "Nodes:")
+	for _, n := range infraEnv.AllNodes() {
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", n.GetName(), n.GetInternalIP(), n.GetExternalIP())
+	}
+
+	if infraEnv.LastFailure != nil {
+		fmt.Fprintln(w, "Last install failure:")
+		if infraEnv.LastFailure.Step != "" {
+			fmt.Fprintf(w, "  Step:\t%s\n", infraEnv.LastFailure.Step)
+		}
+		fmt.Fprintf(w, "  Reason:\t%s\n", infraEnv.LastFailure.Reason)
+		fmt.Fprintf(w, "  At:\t%s\n", infraEnv.LastFailure.At.Format("2006-01-02 15:04:05 MST"))
+	} else {
+		fmt.Fprintln(w, "Last install failure:\tnone")
+	}
+
+	return w.Flush()
+}
+
+// orUnknown substitutes fields older infra files do not record yet.
+func orUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
+
+func AddEnvShowCmd(envCmd *cobra.Command, opts *util.GlobalOptions) {
+	show := EnvShowCmd{
+		cmd: &cobra.Command{
+			Use:   "show",
+			Short: "Show the state of the bootstrapped environment",
+			Long: csio.Long(`Shows the environment recorded in the local infra file: versions,
+				node addresses and the cause of the last installer failure, if any.`),
+			Example: util.FormatExamples("beta env show", []csio.Example{
+				{Cmd: "", Desc: "Show the environment of the current directory's infra file"},
+			}),
+		},
+		Opts: &EnvShowOpts{
+			GlobalOptions: opts,
+		},
+	}
+
+	show.cmd.RunE = show.RunE
+	util.AddCmd(envCmd, show.cmd)
+}
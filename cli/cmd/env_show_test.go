@@ -0,0 +1,59 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/codesphere-cloud/oms/cli/cmd"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+)
+
+var _ = Describe("ShowEnvironment", func() {
+	var (
+		infraEnv *gcp.CodesphereEnvironment
+		out      *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		infraEnv = &gcp.CodesphereEnvironment{
+			ProjectID:         "test-project-abc123",
+			Zone:              "us-central1-a",
+			BaseDomain:        "example.com",
+			InstallVersion:    "v1.2.3",
+			KubernetesVersion: "v1.33.2+k0s.0",
+			Jumpbox:           &node.Node{Name: "jumpbox", ExternalIP: "1.2.3.4", InternalIP: "10.0.0.1"},
+		}
+	})
+
+	It("prints the versions and nodes of the environment", func() {
+		Expect(cmd.ShowEnvironment(infraEnv, out)).To(Succeed())
+
+		Expect(out.String()).To(ContainSubstring("test-project-abc123"))
+		Expect(out.String()).To(MatchRegexp(`Codesphere version:\s+v1\.2\.3`))
+		Expect(out.String()).To(MatchRegexp(`Kubernetes version:\s+v1\.33\.2\+k0s\.0`))
+		Expect(out.String()).To(MatchRegexp(`jumpbox\s+10\.0\.0\.1\s+1\.2\.3\.4`))
+		Expect(out.String()).To(MatchRegexp(`Last install failure:\s+none`))
+	})
+
+	It("prints the last recorded install failure", func() {
+		infraEnv.LastFailure = &gcp.InstallFailure{
+			Step:   "load-container-images",
+			Reason: "registry unreachable",
+			At:     time.Date(2026, 8, 30, 12, 0, 0, 0, time.UTC),
+		}
+
+		Expect(cmd.ShowEnvironment(infraEnv, out)).To(Succeed())
+
+		Expect(out.String()).To(ContainSubstring("load-container-images"))
+		Expect(out.String()).To(ContainSubstring("registry unreachable"))
+		Expect(out.String()).To(ContainSubstring("2026-08-30"))
+	})
+})
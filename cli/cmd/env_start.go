@@ -0,0 +1,82 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	csio "github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	intutil "github.com/codesphere-cloud/oms/internal/util"
+	"github.com/spf13/cobra"
+)
+
+type EnvStartCmd struct {
+	cmd  *cobra.Command
+	Opts *EnvStartOpts
+}
+
+type EnvStartOpts struct {
+	*util.GlobalOptions
+	SSHQuiet bool
+}
+
+func (c *EnvStartCmd) RunE(_ *cobra.Command, _ []string) error {
+	ctx := c.cmd.Context()
+	fw := intutil.NewFilesystemWriter()
+
+	infraFilePath := gcp.GetInfraFilePath()
+	infraEnv, exists, err := gcp.LoadInfraFile(fw, infraFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to load infra file: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("infra file not found at %s; bootstrap the environment first", infraFilePath)
+	}
+
+	nodeClient := node.NewSSHNodeClient(c.Opts.SSHQuiet)
+	rehydrateInfraNodes(&infraEnv, nodeClient)
+
+	stlog := bootstrap.NewStepLogger(false)
+	gcpClient := gcp.NewGCPClient(ctx, stlog, os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"))
+
+	bs, err := gcp.NewGCPBootstrapper(
+		ctx,
+		nil, stlog, &infraEnv, installer.NewInstallConfigManager(), gcpClient, fw, nodeClient, nil, intutil.NewTime(), nil, nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create bootstrapper: %w", err)
+	}
+
+	return bs.StartEnvironment()
+}
+
+func AddEnvStartCmd(envCmd *cobra.Command, opts *util.GlobalOptions) {
+	start := EnvStartCmd{
+		cmd: &cobra.Command{
+			Use:   "start",
+			Short: "Start all VMs of a stopped environment",
+			Long: csio.Long(`Starts every stopped VM of a bootstrapped environment and verifies it
+				came back healthy: all nodes reachable over SSH and the Kubernetes
+				nodes Ready. Counterpart of env stop.`),
+			Example: util.FormatExamples("beta env start", []csio.Example{
+				{Cmd: "", Desc: "Start the environment recorded in the local infra file"},
+			}),
+		},
+		Opts: &EnvStartOpts{
+			GlobalOptions: opts,
+		},
+	}
+
+	flags := start.cmd.Flags()
+	flags.BoolVar(&start.Opts.SSHQuiet, "ssh-quiet", false, "Suppress output of commands run on the nodes via SSH (optional)")
+
+	start.cmd.RunE = start.RunE
+	util.AddCmd(envCmd, start.cmd)
+}
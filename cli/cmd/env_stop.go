@@ -0,0 +1,83 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	csio "github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	intutil "github.com/codesphere-cloud/oms/internal/util"
+	"github.com/spf13/cobra"
+)
+
+type EnvStopCmd struct {
+	cmd  *cobra.Command
+	Opts *EnvStopOpts
+}
+
+type EnvStopOpts struct {
+	*util.GlobalOptions
+	SSHQuiet bool
+}
+
+func (c *EnvStopCmd) RunE(_ *cobra.Command, _ []string) error {
+	ctx := c.cmd.Context()
+	fw := intutil.NewFilesystemWriter()
+
+	infraFilePath := gcp.GetInfraFilePath()
+	infraEnv, exists, err := gcp.LoadInfraFile(fw, infraFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to load infra file: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("infra file not found at %s; bootstrap the environment first", infraFilePath)
+	}
+
+	nodeClient := node.NewSSHNodeClient(c.Opts.SSHQuiet)
+	rehydrateInfraNodes(&infraEnv, nodeClient)
+
+	stlog := bootstrap.NewStepLogger(false)
+	gcpClient := gcp.NewGCPClient(ctx, stlog, os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"))
+
+	bs, err := gcp.NewGCPBootstrapper(
+		ctx,
+		nil, stlog, &infraEnv, installer.NewInstallConfigManager(), gcpClient, fw, nodeClient, nil, intutil.NewTime(), nil, nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create bootstrapper: %w", err)
+	}
+
+	return bs.StopEnvironment()
+}
+
+func AddEnvStopCmd(envCmd *cobra.Command, opts *util.GlobalOptions) {
+	stop := EnvStopCmd{
+		cmd: &cobra.Command{
+			Use:   "stop",
+			Short: "Stop all VMs of an environment to save cost",
+			Long: csio.Long(`Gracefully shuts down the Codesphere workloads and stops every VM of a
+				bootstrapped environment. Disks and static IPs are preserved, so the
+				environment can be started again later with env start.
+				Ideal for demo environments that only run a few hours a week.`),
+			Example: util.FormatExamples("beta env stop", []csio.Example{
+				{Cmd: "", Desc: "Stop the environment recorded in the local infra file"},
+			}),
+		},
+		Opts: &EnvStopOpts{
+			GlobalOptions: opts,
+		},
+	}
+
+	flags := stop.cmd.Flags()
+	flags.BoolVar(&stop.Opts.SSHQuiet, "ssh-quiet", false, "Suppress output of commands run on the nodes via SSH (optional)")
+
+	stop.cmd.RunE = stop.RunE
+	util.AddCmd(envCmd, stop.cmd)
+}
@@ -0,0 +1,162 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	csio "github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/env"
+	"github.com/codesphere-cloud/oms/internal/fix"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	intutil "github.com/codesphere-cloud/oms/internal/util"
+	"github.com/spf13/cobra"
+)
+
+type FixCmd struct {
+	cmd  *cobra.Command
+	Opts *FixOpts
+}
+
+type FixOpts struct {
+	*util.GlobalOptions
+	Node     string
+	Yes      bool
+	List     bool
+	SSHQuiet bool
+}
+
+// GetFixLogPath returns the path of the local fix invocation log.
+func GetFixLogPath() string {
+	return fmt.Sprintf("%s/fix.log", env.NewEnv().GetOmsWorkdir())
+}
+
+// ResolveFixNode finds the named node in the environment and returns it
+// connected through the jumpbox.
+func ResolveFixNode(infraEnv *gcp.CodesphereEnvironment, name string) (*node.Node, error) {
+	if infraEnv.Jumpbox == nil {
+		return nil, fmt.Errorf("infra file does not contain a jumpbox")
+	}
+	if name == infraEnv.Jumpbox.GetName() {
+		return infraEnv.Jumpbox, nil
+	}
+
+	candidates := []*node.Node{infraEnv.PostgreSQLNode, infraEnv.CIRunnerNode}
+	candidates = append(candidates, infraEnv.ControlPlaneNodes...)
+	candidates = append(candidates, infraEnv.CephNodes...)
+	for _, candidate := range candidates {
+		if candidate == nil || candidate.GetName() != name {
+			continue
+		}
+		// Nodes loaded from the infra file lost their jumpbox reference, so
+		// rebuild them as sub-nodes of the connected jumpbox.
+		return infraEnv.Jumpbox.CreateSubNode(candidate.GetName(), candidate.GetExternalIP(), candidate.GetInternalIP()), nil
+	}
+	return nil, fmt.Errorf("no node named %s in the environment", name)
+}
+
+func (c *FixCmd) RunE(_ *cobra.Command, args []string) error {
+	if c.Opts.List || len(args) == 0 {
+		log.Println("Available fix scripts:")
+		for _, script := range fix.Library() {
+			log.Printf("  %s (v%d): %s", script.Name, script.Version, script.Description)
+		}
+		return nil
+	}
+
+	script, err := fix.Lookup(args[0])
+	if err != nil {
+		return err
+	}
+
+	fw := intutil.NewFilesystemWriter()
+	infraEnv, exists, err := gcp.LoadInfraFile(fw, gcp.GetInfraFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to load infra file: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("infra file not found at %s; bootstrap the environment first", gcp.GetInfraFilePath())
+	}
+	if infraEnv.Jumpbox != nil {
+		infraEnv.Jumpbox.NodeClient = node.NewSSHNodeClient(c.Opts.SSHQuiet)
+	}
+
+	target, err := ResolveFixNode(&infraEnv, c.Opts.Node)
+	if err != nil {
+		return err
+	}
+
+	if !c.Opts.Yes {
+		if err := confirmFix(bufio.NewReader(os.Stdin), script, target.GetName()); err != nil {
+			return err
+		}
+	}
+
+	runErr := script.Run(target, log.Printf)
+	if err := fix.LogInvocation(fw, GetFixLogPath(), script, target.GetName(), runErr, time.Now()); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	if runErr != nil {
+		return runErr
+	}
+
+	log.Printf("Fix %s completed on %s", script.Name, target.GetName())
+	return nil
+}
+
+// confirmFix shows what is about to run and aborts unless the answer is yes.
+func confirmFix(in *bufio.Reader, script fix.Script, nodeName string) error {
+	log.Printf("About to run fix %s (v%d) on node %s:", script.Name, script.Version, nodeName)
+	for _, command := range script.Commands {
+		log.Printf("  %s", command)
+	}
+	fmt.Print("Proceed? (y/N): ")
+
+	answer, err := in.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+		return fmt.Errorf("fix aborted")
+	}
+	return nil
+}
+
+func AddFixCmd(rootCmd *cobra.Command, opts *util.GlobalOptions) {
+	fixCmd := FixCmd{
+		cmd: &cobra.Command{
+			Use:   "fix [name]",
+			Short: "Run a curated remediation script on an environment node",
+			Long: csio.Long(`Runs a script from the curated remediation library on a node of the
+				environment via SSH, e.g. restarting a stuck k0s service or clearing ceph
+				warnings. Every invocation is recorded in a local log file.
+				Without a name, the available scripts are listed.`),
+			Example: util.FormatExamples("beta fix", []csio.Example{
+				{Cmd: "--list", Desc: "List the available fix scripts"},
+				{Cmd: "restart-k0s --node control-plane-1", Desc: "Restart k0s on a control plane node"},
+				{Cmd: "vacuum-postgres --node postgres --yes", Desc: "Vacuum postgres without a confirmation prompt"},
+			}),
+			Args: cobra.MaximumNArgs(1),
+		},
+		Opts: &FixOpts{
+			GlobalOptions: opts,
+		},
+	}
+
+	flags := fixCmd.cmd.Flags()
+	flags.StringVar(&fixCmd.Opts.Node, "node", "jumpbox", "Name of the node to run the script on (default: jumpbox)")
+	flags.BoolVar(&fixCmd.Opts.Yes, "yes", false, "Skip the confirmation prompt (default: false)")
+	flags.BoolVar(&fixCmd.Opts.List, "list", false, "List the available fix scripts (default: false)")
+	flags.BoolVar(&fixCmd.Opts.SSHQuiet, "ssh-quiet", false, "Suppress SSH command output (default: false)")
+
+	fixCmd.cmd.RunE = fixCmd.RunE
+	util.AddCmd(rootCmd, fixCmd.cmd)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/codesphere-cloud/oms/cli/cmd"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+)
+
+var _ = Describe("FixCmd", func() {
+	Describe("AddFixCmd", func() {
+		It("registers the fix command with its flags", func() {
+			parent := &cobra.Command{Use: "beta"}
+			cmd.AddFixCmd(parent, &util.GlobalOptions{})
+
+			fixCmd, _, err := parent.Find([]string{"fix"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fixCmd.Use).To(Equal("fix [name]"))
+			Expect(fixCmd.Flags().Lookup("node")).NotTo(BeNil())
+			Expect(fixCmd.Flags().Lookup("yes")).NotTo(BeNil())
+			Expect(fixCmd.Flags().Lookup("list")).NotTo(BeNil())
+		})
+	})
+
+	Describe("ResolveFixNode", func() {
+		var infraEnv *gcp.CodesphereEnvironment
+
+		BeforeEach(func() {
+			infraEnv = &gcp.CodesphereEnvironment{
+				Jumpbox:        &node.Node{Name: "jumpbox", ExternalIP: "1.2.3.4"},
+				PostgreSQLNode: &node.Node{Name: "postgres", InternalIP: "10.0.0.2"},
+				ControlPlaneNodes: []*node.Node{
+					{Name: "control-plane-1", InternalIP: "10.0.0.3"},
+				},
+			}
+		})
+
+		It("returns the jumpbox directly", func() {
+			target, err := cmd.ResolveFixNode(infraEnv, "jumpbox")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(target).To(BeIdenticalTo(infraEnv.Jumpbox))
+		})
+
+		It("rebuilds other nodes as sub-nodes of the jumpbox", func() {
+			target, err := cmd.ResolveFixNode(infraEnv, "control-plane-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(target.GetInternalIP()).To(Equal("10.0.0.3"))
+			Expect(target.Jumpbox).To(BeIdenticalTo(infraEnv.Jumpbox))
+		})
+
+		It("fails for unknown node names", func() {
+			_, err := cmd.ResolveFixNode(infraEnv, "ceph-7")
+			Expect(err).To(MatchError(ContainSubstring("no node named ceph-7")))
+		})
+	})
+})
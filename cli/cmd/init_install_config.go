@@ -12,6 +12,7 @@ import (
 	"github.com/codesphere-cloud/oms/cli/cmd/util"
 	"github.com/codesphere-cloud/oms/internal/installer"
 	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/codesphere-cloud/oms/internal/installer/wizard"
 	intutil "github.com/codesphere-cloud/oms/internal/util"
 	"github.com/spf13/cobra"
 )
@@ -127,6 +128,12 @@ func AddInitInstallConfigCmd(init *cobra.Command, opts *util.GlobalOptions) {
 			- dev: Single-node development setup
 			- production: HA multi-node setup
 			- minimal: Minimal testing setup
+			- ha-production: Production setup with HA postgres and load balanced object storage
+			- airgapped: Setup without internet access, images served from the local registry
+			- gpu: Minimal setup with a GPU workspace plan
+
+			A path to a YAML file can be given instead of a profile name; its
+			contents are merged over the base configuration.
 			`),
 			Example: util.FormatExamples("init install-config", []csio.Example{
 				{Cmd: "-c config.yaml --vault prod.vault.yaml", Desc: "Create config files interactively"},
@@ -143,7 +150,7 @@ func AddInitInstallConfigCmd(init *cobra.Command, opts *util.GlobalOptions) {
 	c.cmd.Flags().StringVarP(&c.Opts.ConfigFile, "config", "c", "config.yaml", "Output file path for config.yaml")
 	c.cmd.Flags().StringVar(&c.Opts.VaultFile, "vault", "prod.vault.yaml", "Output file path for prod.vault.yaml")
 
-	c.cmd.Flags().StringVar(&c.Opts.Profile, "profile", "", "Use a predefined configuration profile (dev, production, minimal)")
+	c.cmd.Flags().StringVar(&c.Opts.Profile, "profile", "", "Use a predefined configuration profile (dev, production, minimal, ha-production, airgapped, gpu) or a path to a profile file")
 	c.cmd.Flags().StringVar(&c.Opts.AnsibleInventoryFile, "ansible-inventory", "", "Path to Ansible inventory file to import host information from")
 
 	c.cmd.Flags().BoolVar(&c.Opts.ValidateOnly, "validate", false, "Validate existing config files instead of creating new ones")
@@ -220,7 +227,7 @@ func (c *InitInstallConfigCmd) InitInstallConfig(icg installer.InstallConfigMana
 	}
 
 	if c.Opts.Interactive {
-		err = icg.CollectInteractively()
+		err = c.collectInteractively(icg)
 		if err != nil {
 			return fmt.Errorf("failed to collect configuration interactively: %w", err)
 		}
@@ -253,6 +260,31 @@ func (c *InitInstallConfigCmd) InitInstallConfig(icg installer.InstallConfigMana
 	return nil
 }
 
+// collectInteractively runs the full-screen wizard when attached to a
+// terminal and falls back to the linear prompter otherwise (e.g. when input
+// is piped in). If the output config file already exists it is loaded first,
+// so the wizard edits the current values instead of the profile defaults.
+func (c *InitInstallConfigCmd) collectInteractively(icg installer.InstallConfigManager) error {
+	if !wizard.IsTerminal() {
+		return icg.CollectInteractively()
+	}
+
+	if c.FileWriter.Exists(c.Opts.ConfigFile) {
+		if err := icg.LoadInstallConfigFromFile(c.Opts.ConfigFile); err != nil {
+			return fmt.Errorf("failed to load existing config for editing: %w", err)
+		}
+	}
+
+	accepted, err := wizard.Run(icg.GetInstallConfig())
+	if err != nil {
+		return err
+	}
+	if !accepted {
+		return fmt.Errorf("configuration wizard aborted, no files written")
+	}
+	return nil
+}
+
 func (c *InitInstallConfigCmd) printWelcomeMessage() {
 	log.Println("Welcome to OMS!")
 	log.Println("This wizard will help you create config.yaml and prod.vault.yaml for Codesphere installation.")
@@ -83,7 +83,7 @@ var _ = Describe("UpdateConfigFromOpts", func() {
 	})
 
 	It("uses postgres-server as the server address in external mode", func() {
-		config := &files.RootConfig{Postgres: files.PostgresConfig{Mode: "external"}}
+		config := files.NewTestRootConfig(files.WithPostgresMode("external"))
 		command := &InitInstallConfigCmd{Opts: &InitInstallConfigOpts{
 			PostgresMode:          "external",
 			PostgresServerAddress: "postgres.example.com:5432",
@@ -29,4 +29,6 @@ func AddInstallCmd(rootCmd *cobra.Command, opts *util.GlobalOptions) {
 	codesphere.AddInstallCmd(install.cmd, opts)
 	k0s.AddInstallCmd(install.cmd, opts)
 	AddInstallOpenBaoCmd(install.cmd, opts)
+	AddInstallStatusCmd(install.cmd, opts)
+	AddInstallAttachCmd(install.cmd, opts)
 }
@@ -0,0 +1,81 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	csio "github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	intutil "github.com/codesphere-cloud/oms/internal/util"
+	"github.com/spf13/cobra"
+)
+
+type InstallAttachCmd struct {
+	cmd  *cobra.Command
+	Opts *InstallAttachOpts
+}
+
+type InstallAttachOpts struct {
+	*util.GlobalOptions
+}
+
+func (c *InstallAttachCmd) RunE(_ *cobra.Command, _ []string) error {
+	fw := intutil.NewFilesystemWriter()
+
+	infraEnv, exists, err := gcp.LoadInfraFile(fw, gcp.GetInfraFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to load infra file: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("infra file not found at %s; bootstrap the environment first", gcp.GetInfraFilePath())
+	}
+	if infraEnv.Jumpbox == nil {
+		return fmt.Errorf("infra file does not contain a jumpbox")
+	}
+	nc := node.NewSSHNodeClient(false)
+	infraEnv.Jumpbox.NodeClient = nc
+
+	if !nc.HasFile(infraEnv.Jumpbox, installer.InstallLogPath) {
+		return fmt.Errorf("no install log found at %s on %s; has a detached installation been started?",
+			installer.InstallLogPath, infraEnv.Jumpbox.GetName())
+	}
+
+	log.Printf("Attaching to the %s unit on %s. Detaching is safe; the install keeps running.",
+		installer.InstallUnitName, infraEnv.Jumpbox.GetName())
+	if err := infraEnv.Jumpbox.RunSSHCommand("root", installer.AttachInstallCommand()); err != nil {
+		return fmt.Errorf("installation failed: %w", err)
+	}
+
+	log.Println("Installation finished successfully.")
+	return nil
+}
+
+func AddInstallAttachCmd(install *cobra.Command, opts *util.GlobalOptions) {
+	attach := InstallAttachCmd{
+		cmd: &cobra.Command{
+			Use:   "attach",
+			Short: "Reconnect to a detached Codesphere installation and stream its output",
+			Long: csio.Long(`Reconnects to an installation running as a transient systemd unit on the
+				jumpbox and streams its log until it finishes.
+
+				Installs started by the bootstrap run detached, so a dropped VPN or SSH
+				connection does not abort them; attach picks up where the session left off.`),
+			Example: util.FormatExamples("install attach", []csio.Example{
+				{
+					Cmd:  "",
+					Desc: "Stream the ongoing installation until it completes",
+				},
+			}),
+		},
+		Opts: &InstallAttachOpts{GlobalOptions: opts},
+	}
+
+	util.AddCmd(install, attach.cmd)
+	attach.cmd.RunE = attach.RunE
+}
@@ -11,7 +11,6 @@ import (
 	"io"
 	"log"
 	"os"
-	"os/exec"
 	"os/signal"
 	"path/filepath"
 	"strings"
@@ -47,10 +46,6 @@ type InstallOpenBaoOpts struct {
 }
 
 func (c *InstallOpenBaoCmd) RunE(_ *cobra.Command, _ []string) error {
-	if err := validateOpenBaoPrereqs(); err != nil {
-		return err
-	}
-
 	configDir, err := os.UserConfigDir()
 	if err != nil {
 		return fmt.Errorf("determining user config directory: %w", err)
@@ -154,14 +149,3 @@ func AddInstallOpenBaoCmd(install *cobra.Command, opts *util.GlobalOptions) {
 
 	openbao.cmd.RunE = openbao.RunE
 }
-
-// validateOpenBaoPrereqs checks that required external tools are available.
-func validateOpenBaoPrereqs() error {
-	if _, err := exec.LookPath("sops"); err != nil {
-		return fmt.Errorf("sops not found in PATH — install from https://github.com/getsops/sops")
-	}
-	if _, err := exec.LookPath("age-keygen"); err != nil {
-		return fmt.Errorf("age-keygen not found in PATH — install from https://github.com/FiloSottile/age")
-	}
-	return nil
-}
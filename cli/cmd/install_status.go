@@ -0,0 +1,151 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	csio "github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	intutil "github.com/codesphere-cloud/oms/internal/util"
+	"github.com/spf13/cobra"
+)
+
+// installStatusPollInterval is how often --watch re-reads the status file.
+const installStatusPollInterval = 10 * time.Second
+
+type InstallStatusCmd struct {
+	cmd  *cobra.Command
+	Opts *InstallStatusOpts
+	Time intutil.Time
+}
+
+type InstallStatusOpts struct {
+	*util.GlobalOptions
+	Watch bool
+}
+
+// FetchInstallStatus downloads and parses the status file written by a remote
+// `oms install codesphere` run on the given node.
+func FetchInstallStatus(nc node.NodeClient, n *node.Node) (installer.InstallStatus, error) {
+	if !nc.HasFile(n, installer.InstallStatusPath) {
+		return installer.InstallStatus{}, fmt.Errorf("no install status found on %s; has an installation been started?", n.GetName())
+	}
+
+	tmpFile, err := os.CreateTemp("", "oms-install-status-*.json")
+	if err != nil {
+		return installer.InstallStatus{}, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer intutil.IgnoreError(func() error { return os.Remove(tmpFile.Name()) })
+	intutil.CloseFileIgnoreError(tmpFile)
+
+	if err := nc.DownloadFile(n, installer.InstallStatusPath, tmpFile.Name()); err != nil {
+		return installer.InstallStatus{}, fmt.Errorf("failed to download install status: %w", err)
+	}
+
+	data, err := os.ReadFile(tmpFile.Name())
+	if err != nil {
+		return installer.InstallStatus{}, fmt.Errorf("failed to read downloaded install status: %w", err)
+	}
+
+	return installer.ParseInstallStatus(data)
+}
+
+// FormatInstallStatus renders an install status report for the terminal.
+func FormatInstallStatus(status installer.InstallStatus, now time.Time) string {
+	var sb strings.Builder
+
+	age := now.Sub(status.UpdatedAt).Round(time.Second)
+	fmt.Fprintf(&sb, "Installation %s (pid %d, started %s, last update %s ago)\n",
+		status.State, status.Pid, status.StartedAt.Format(time.RFC3339), age)
+	if status.Error != "" {
+		fmt.Fprintf(&sb, "Error: %s\n", status.Error)
+	}
+
+	for _, phase := range status.Phases {
+		marker := "..."
+		switch phase.State {
+		case installer.StatusSucceeded:
+			marker = "✓"
+		case installer.StatusFailed:
+			marker = "✗"
+		}
+		fmt.Fprintf(&sb, "  %s %s", phase.Name, marker)
+		if len(phase.Steps) > 0 {
+			fmt.Fprintf(&sb, " (steps: %s)", strings.Join(phase.Steps, ", "))
+		}
+		if phase.Error != "" {
+			fmt.Fprintf(&sb, " failed: %s", phase.Error)
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func (c *InstallStatusCmd) RunE(_ *cobra.Command, _ []string) error {
+	fw := intutil.NewFilesystemWriter()
+
+	infraEnv, exists, err := gcp.LoadInfraFile(fw, gcp.GetInfraFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to load infra file: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("infra file not found at %s; bootstrap the environment first", gcp.GetInfraFilePath())
+	}
+	if infraEnv.Jumpbox == nil {
+		return fmt.Errorf("infra file does not contain a jumpbox")
+	}
+	nc := node.NewSSHNodeClient(true)
+	infraEnv.Jumpbox.NodeClient = nc
+
+	for {
+		status, err := FetchInstallStatus(nc, infraEnv.Jumpbox)
+		if err != nil {
+			return err
+		}
+		fmt.Print(FormatInstallStatus(status, c.Time.Now()))
+
+		if !c.Opts.Watch || status.State != installer.StatusRunning {
+			return nil
+		}
+		c.Time.Sleep(installStatusPollInterval)
+	}
+}
+
+func AddInstallStatusCmd(install *cobra.Command, opts *util.GlobalOptions) {
+	status := InstallStatusCmd{
+		cmd: &cobra.Command{
+			Use:   "status",
+			Short: "Show the progress of a remote Codesphere installation",
+			Long: csio.Long(`Reads the structured step status that a remote oms install run writes
+				on the jumpbox and prints where the installation currently is.
+
+				The status survives dropped SSH sessions, so a long-running install
+				can be checked from a fresh connection at any time.`),
+			Example: util.FormatExamples("install status", []csio.Example{
+				{
+					Cmd:  "",
+					Desc: "Print the current installation progress once",
+				},
+				{
+					Cmd:  "--watch",
+					Desc: "Keep polling until the installation finishes or fails",
+				},
+			}),
+		},
+		Opts: &InstallStatusOpts{GlobalOptions: opts},
+		Time: intutil.NewTime(),
+	}
+	status.cmd.Flags().BoolVarP(&status.Opts.Watch, "watch", "w", false, "Poll the status until the installation reaches a final state")
+
+	util.AddCmd(install, status.cmd)
+	status.cmd.RunE = status.RunE
+}
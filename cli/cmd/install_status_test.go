@@ -0,0 +1,102 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/codesphere-cloud/oms/cli/cmd"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+)
+
+var _ = Describe("InstallStatusCmd", func() {
+	Describe("AddInstallStatusCmd", func() {
+		It("registers the status command with its watch flag", func() {
+			parent := &cobra.Command{Use: "install"}
+			cmd.AddInstallStatusCmd(parent, &util.GlobalOptions{})
+
+			statusCmd, _, err := parent.Find([]string{"status"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statusCmd.Use).To(Equal("status"))
+			Expect(statusCmd.Flags().Lookup("watch")).NotTo(BeNil())
+		})
+	})
+
+	Describe("FetchInstallStatus", func() {
+		var (
+			nc      *node.MockNodeClient
+			jumpbox *node.Node
+		)
+
+		BeforeEach(func() {
+			nc = node.NewMockNodeClient(GinkgoT())
+			jumpbox = &node.Node{Name: "jumpbox", ExternalIP: "1.2.3.4"}
+		})
+
+		It("downloads and parses the remote status file", func() {
+			nc.EXPECT().HasFile(jumpbox, installer.InstallStatusPath).Return(true)
+			nc.EXPECT().DownloadFile(jumpbox, installer.InstallStatusPath, mock.Anything).RunAndReturn(
+				func(_ *node.Node, _ string, dst string) error {
+					return os.WriteFile(dst, []byte(`{"pid":42,"state":"running"}`), 0644)
+				})
+
+			status, err := cmd.FetchInstallStatus(nc, jumpbox)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Pid).To(Equal(42))
+			Expect(status.State).To(Equal(installer.StatusRunning))
+		})
+
+		It("fails when no status file exists on the node", func() {
+			nc.EXPECT().HasFile(jumpbox, installer.InstallStatusPath).Return(false)
+
+			_, err := cmd.FetchInstallStatus(nc, jumpbox)
+			Expect(err).To(MatchError(ContainSubstring("no install status found on jumpbox")))
+		})
+	})
+
+	Describe("FormatInstallStatus", func() {
+		It("renders the overall state and each phase", func() {
+			started := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+			finished := started.Add(10 * time.Minute)
+			status := installer.InstallStatus{
+				Pid:       42,
+				State:     installer.StatusRunning,
+				StartedAt: started,
+				UpdatedAt: finished,
+				Phases: []installer.PhaseStatus{
+					{Name: "infrastructure", State: installer.StatusSucceeded, Steps: []string{"postgres", "ceph"}},
+					{Name: "platform", State: installer.StatusRunning, Steps: []string{"codesphere"}},
+				},
+			}
+
+			out := cmd.FormatInstallStatus(status, finished.Add(5*time.Second))
+			Expect(out).To(ContainSubstring("Installation running (pid 42"))
+			Expect(out).To(ContainSubstring("last update 5s ago"))
+			Expect(out).To(ContainSubstring("infrastructure ✓ (steps: postgres, ceph)"))
+			Expect(out).To(ContainSubstring("platform ... (steps: codesphere)"))
+		})
+
+		It("includes phase errors", func() {
+			status := installer.InstallStatus{
+				State: installer.StatusFailed,
+				Error: "postgres broke",
+				Phases: []installer.PhaseStatus{
+					{Name: "infrastructure", State: installer.StatusFailed, Error: "postgres broke"},
+				},
+			}
+
+			out := cmd.FormatInstallStatus(status, time.Now())
+			Expect(out).To(ContainSubstring("Error: postgres broke"))
+			Expect(out).To(ContainSubstring("infrastructure ✗ failed: postgres broke"))
+		})
+	})
+})
@@ -42,32 +42,11 @@ var _ = Describe("K0s Install-Config Integration", func() {
 	})
 
 	createBaseConfig := func(name string, ip string) *files.RootConfig {
-		return &files.RootConfig{
-			Datacenter: files.DatacenterConfig{
-				ID:          1,
-				Name:        name,
-				City:        "Test City",
-				CountryCode: "US",
-			},
-			Kubernetes: files.KubernetesConfig{
-				ManagedByCodesphere: true,
-				ControlPlanes: []files.K8sNode{
-					{IPAddress: ip},
-				},
-				APIServerHost: "api.test.example.com",
-			},
-			Codesphere: files.CodesphereConfig{
-				Domain:   "test.example.com",
-				PublicIP: ip,
-				DeployConfig: files.DeployConfig{
-					Images: map[string]files.ImageConfig{},
-				},
-				Plans: files.PlansConfig{
-					HostingPlans:   map[int]files.HostingPlan{},
-					WorkspacePlans: map[int]files.WorkspacePlan{},
-				},
-			},
-		}
+		return files.NewTestRootConfig(
+			files.WithDatacenterName(name),
+			files.WithControlPlanes(ip),
+			files.WithPublicIP(ip),
+		)
 	}
 
 	Describe("Complete Workflow", func() {
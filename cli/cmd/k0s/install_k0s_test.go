@@ -100,32 +100,11 @@ var _ = Describe("InstallK0sCmd", func() {
 		})
 
 		createTestConfig := func(managedByCodesphere bool) *files.RootConfig {
-			return &files.RootConfig{
-				Datacenter: files.DatacenterConfig{
-					ID:          1,
-					Name:        "test-dc",
-					City:        "Test City",
-					CountryCode: "US",
-				},
-				Kubernetes: files.KubernetesConfig{
-					ManagedByCodesphere: managedByCodesphere,
-					ControlPlanes: []files.K8sNode{
-						{IPAddress: "192.168.1.100"},
-					},
-					APIServerHost: "api.test.example.com",
-				},
-				Codesphere: files.CodesphereConfig{
-					Domain:   "test.example.com",
-					PublicIP: "192.168.1.100",
-					DeployConfig: files.DeployConfig{
-						Images: map[string]files.ImageConfig{},
-					},
-					Plans: files.PlansConfig{
-						HostingPlans:   map[int]files.HostingPlan{},
-						WorkspacePlans: map[int]files.WorkspacePlan{},
-					},
-				},
+			var opts []files.RootConfigOption
+			if !managedByCodesphere {
+				opts = append(opts, files.WithSelfManagedKubernetes())
 			}
+			return files.NewTestRootConfig(opts...)
 		}
 
 		writeTestConfig := func(config *files.RootConfig) string {
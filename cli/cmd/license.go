@@ -0,0 +1,27 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+type LicenseCmd struct {
+	cmd *cobra.Command
+}
+
+func AddLicenseCmd(rootCmd *cobra.Command, opts *util.GlobalOptions) {
+	license := LicenseCmd{
+		cmd: &cobra.Command{
+			Use:   "license",
+			Short: "Inspect the Codesphere license of this environment",
+			Long:  io.Long(`Inspect the Codesphere license of this environment.`),
+		},
+	}
+
+	AddLicenseStatusCmd(license.cmd, opts)
+	util.AddCmd(rootCmd, license.cmd)
+}
@@ -0,0 +1,159 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	csio "github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/codesphere-cloud/oms/internal/portal"
+	intutil "github.com/codesphere-cloud/oms/internal/util"
+	"github.com/spf13/cobra"
+)
+
+// licenseExpiryWarningWindow is how far ahead of the expiry date the status
+// report starts warning about an expiring license.
+const licenseExpiryWarningWindow = 30 * 24 * time.Hour
+
+type LicenseStatusCmd struct {
+	cmd  *cobra.Command
+	Opts *LicenseStatusOpts
+	Time intutil.Time
+}
+
+type LicenseStatusOpts struct {
+	*util.GlobalOptions
+	Config string
+}
+
+// FormatLicenseStatus renders the entitlement and compares it against the
+// features and workspace plans the environment actually uses. usedFeatures
+// and plans may be empty when no bootstrapped environment or config is known.
+func FormatLicenseStatus(entitlement portal.Entitlement, usedFeatures []string, plans map[int]files.WorkspacePlan, now time.Time) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Seats: %d\n", entitlement.Seats)
+	if entitlement.MaxReplicas > 0 {
+		fmt.Fprintf(&sb, "Replica limit: %d per workspace plan\n", entitlement.MaxReplicas)
+	} else {
+		fmt.Fprintf(&sb, "Replica limit: none\n")
+	}
+
+	switch {
+	case entitlement.ExpiresAt.IsZero():
+		fmt.Fprintf(&sb, "Expires: never\n")
+	case entitlement.ExpiresAt.Before(now):
+		fmt.Fprintf(&sb, "Expires: EXPIRED since %s; installs will fail\n", entitlement.ExpiresAt.Format(time.DateOnly))
+	case entitlement.ExpiresAt.Sub(now) < licenseExpiryWarningWindow:
+		days := int(entitlement.ExpiresAt.Sub(now).Hours() / 24)
+		fmt.Fprintf(&sb, "Expires: %s (WARNING: in %d days)\n", entitlement.ExpiresAt.Format(time.DateOnly), days)
+	default:
+		fmt.Fprintf(&sb, "Expires: %s\n", entitlement.ExpiresAt.Format(time.DateOnly))
+	}
+
+	if len(entitlement.Features) > 0 {
+		fmt.Fprintf(&sb, "Licensed features: %s\n", strings.Join(entitlement.Features, ", "))
+	} else {
+		fmt.Fprintf(&sb, "Licensed features: none\n")
+	}
+
+	licensed := intutil.StringSliceToBoolMap(entitlement.Features)
+	for _, feature := range usedFeatures {
+		if !licensed[feature] {
+			fmt.Fprintf(&sb, "WARNING: feature %q is in use but not covered by the license\n", feature)
+		}
+	}
+
+	if len(plans) > 0 {
+		ids := make([]int, 0, len(plans))
+		for id := range plans {
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+
+		fmt.Fprintf(&sb, "Workspace plans:\n")
+		for _, id := range ids {
+			plan := plans[id]
+			fmt.Fprintf(&sb, "  %d %s: %d replicas", id, plan.Name, plan.MaxReplicas)
+			if entitlement.MaxReplicas > 0 && plan.MaxReplicas > entitlement.MaxReplicas {
+				fmt.Fprintf(&sb, " (exceeds license limit of %d)", entitlement.MaxReplicas)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
+
+func (c *LicenseStatusCmd) RunE(cmd *cobra.Command, _ []string) error {
+	p := portal.NewPortalClient()
+	entitlement, err := p.GetEntitlement()
+	if err != nil {
+		return fmt.Errorf("failed to get entitlement from portal: %w", err)
+	}
+
+	// The infra file is only present after a bootstrap; without it the
+	// report simply omits the feature usage comparison.
+	usedFeatures := []string{}
+	infraEnv, exists, err := gcp.LoadInfraFile(intutil.NewFilesystemWriter(), gcp.GetInfraFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to load infra file: %w", err)
+	}
+	if exists {
+		usedFeatures = infraEnv.FeatureFlags
+	}
+
+	plans := map[int]files.WorkspacePlan{}
+	if c.Opts.Config != "" {
+		data, err := os.ReadFile(c.Opts.Config)
+		if err != nil {
+			return fmt.Errorf("failed to read config file %s: %w", c.Opts.Config, err)
+		}
+		config := &files.RootConfig{}
+		if err := config.Unmarshal(data); err != nil {
+			return fmt.Errorf("failed to parse config file %s: %w", c.Opts.Config, err)
+		}
+		plans = config.Codesphere.Plans.WorkspacePlans
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), FormatLicenseStatus(entitlement, usedFeatures, plans, c.Time.Now()))
+	return nil
+}
+
+func AddLicenseStatusCmd(license *cobra.Command, opts *util.GlobalOptions) {
+	status := LicenseStatusCmd{
+		cmd: &cobra.Command{
+			Use:   "status",
+			Short: "Show the license entitlement and usage of this environment",
+			Long: csio.Long(`Queries the entitlement (seats, licensed features, plan limits and
+				expiry) from the OMS portal and compares it against what the
+				environment uses, so an expiring or exceeded license is noticed
+				before the next install fails.`),
+			Example: util.FormatExamples("license status", []csio.Example{
+				{
+					Cmd:  "",
+					Desc: "Print the license entitlement and the features used by the bootstrapped environment",
+				},
+				{
+					Cmd:  "--config config.yaml",
+					Desc: "Additionally compare the workspace plans in config.yaml against the license limits",
+				},
+			}),
+			Args: cobra.NoArgs,
+		},
+		Opts: &LicenseStatusOpts{GlobalOptions: opts},
+		Time: intutil.NewTime(),
+	}
+	status.cmd.Flags().StringVarP(&status.Opts.Config, "config", "c", "", "Path to a config.yaml to check workspace plans against the license")
+
+	util.AddCmd(license, status.cmd)
+	status.cmd.RunE = status.RunE
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/codesphere-cloud/oms/cli/cmd"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/codesphere-cloud/oms/internal/portal"
+)
+
+var _ = Describe("LicenseStatusCmd", func() {
+	Describe("AddLicenseStatusCmd", func() {
+		It("registers the status command with its config flag", func() {
+			parent := &cobra.Command{Use: "license"}
+			cmd.AddLicenseStatusCmd(parent, &util.GlobalOptions{})
+
+			statusCmd, _, err := parent.Find([]string{"status"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statusCmd.Use).To(Equal("status"))
+			Expect(statusCmd.Flags().Lookup("config")).NotTo(BeNil())
+		})
+	})
+
+	Describe("FormatLicenseStatus", func() {
+		now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+		It("renders seats, limits, expiry and licensed features", func() {
+			entitlement := portal.Entitlement{
+				Seats:       50,
+				Features:    []string{"on-demand-workspaces", "private-registry"},
+				MaxReplicas: 5,
+				ExpiresAt:   now.AddDate(1, 0, 0),
+			}
+
+			out := cmd.FormatLicenseStatus(entitlement, nil, nil, now)
+			Expect(out).To(ContainSubstring("Seats: 50"))
+			Expect(out).To(ContainSubstring("Replica limit: 5 per workspace plan"))
+			Expect(out).To(ContainSubstring("Expires: 2027-06-01"))
+			Expect(out).To(ContainSubstring("Licensed features: on-demand-workspaces, private-registry"))
+			Expect(out).NotTo(ContainSubstring("WARNING"))
+		})
+
+		It("reports a license without expiry or replica limit", func() {
+			out := cmd.FormatLicenseStatus(portal.Entitlement{Seats: 10}, nil, nil, now)
+			Expect(out).To(ContainSubstring("Replica limit: none"))
+			Expect(out).To(ContainSubstring("Expires: never"))
+			Expect(out).To(ContainSubstring("Licensed features: none"))
+		})
+
+		It("warns when the license expires soon", func() {
+			entitlement := portal.Entitlement{Seats: 10, ExpiresAt: now.AddDate(0, 0, 14)}
+
+			out := cmd.FormatLicenseStatus(entitlement, nil, nil, now)
+			Expect(out).To(ContainSubstring("WARNING: in 14 days"))
+		})
+
+		It("reports an expired license", func() {
+			entitlement := portal.Entitlement{Seats: 10, ExpiresAt: now.AddDate(0, -1, 0)}
+
+			out := cmd.FormatLicenseStatus(entitlement, nil, nil, now)
+			Expect(out).To(ContainSubstring("EXPIRED since 2026-05-01"))
+		})
+
+		It("warns about used features outside the license", func() {
+			entitlement := portal.Entitlement{Seats: 10, Features: []string{"private-registry"}}
+
+			out := cmd.FormatLicenseStatus(entitlement, []string{"private-registry", "multi-cluster"}, nil, now)
+			Expect(out).To(ContainSubstring(`feature "multi-cluster" is in use but not covered by the license`))
+			Expect(out).NotTo(ContainSubstring(`feature "private-registry" is in use`))
+		})
+
+		It("flags workspace plans exceeding the replica limit", func() {
+			entitlement := portal.Entitlement{Seats: 10, MaxReplicas: 3}
+			plans := map[int]files.WorkspacePlan{
+				1: {Name: "small", MaxReplicas: 2},
+				2: {Name: "large", MaxReplicas: 8},
+			}
+
+			out := cmd.FormatLicenseStatus(entitlement, nil, plans, now)
+			Expect(out).To(ContainSubstring("1 small: 2 replicas\n"))
+			Expect(out).To(ContainSubstring("2 large: 8 replicas (exceeds license limit of 3)"))
+		})
+	})
+})
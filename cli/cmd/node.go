@@ -0,0 +1,27 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+type NodeCmd struct {
+	cmd *cobra.Command
+}
+
+func AddNodeCmd(rootCmd *cobra.Command, opts *util.GlobalOptions) {
+	nodeCmd := NodeCmd{
+		cmd: &cobra.Command{
+			Use:   "node",
+			Short: "Manage nodes of a bootstrapped environment",
+			Long:  io.Long(`Manage individual nodes of a bootstrapped environment.`),
+		},
+	}
+
+	AddNodeGrowDiskCmd(nodeCmd.cmd, opts)
+	util.AddCmd(rootCmd, nodeCmd.cmd)
+}
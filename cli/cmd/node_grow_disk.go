@@ -0,0 +1,96 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	csio "github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	intutil "github.com/codesphere-cloud/oms/internal/util"
+	"github.com/spf13/cobra"
+)
+
+type NodeGrowDiskCmd struct {
+	cmd  *cobra.Command
+	Opts *NodeGrowDiskOpts
+}
+
+type NodeGrowDiskOpts struct {
+	*util.GlobalOptions
+	Node     string
+	Size     int64
+	SSHQuiet bool
+}
+
+func (c *NodeGrowDiskCmd) RunE(_ *cobra.Command, _ []string) error {
+	if c.Opts.Size <= 0 {
+		return fmt.Errorf("--size must be a positive number of GB")
+	}
+
+	ctx := c.cmd.Context()
+	fw := intutil.NewFilesystemWriter()
+
+	infraFilePath := gcp.GetInfraFilePath()
+	infraEnv, exists, err := gcp.LoadInfraFile(fw, infraFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to load infra file: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("infra file not found at %s; bootstrap the environment first", infraFilePath)
+	}
+
+	nodeClient := node.NewSSHNodeClient(c.Opts.SSHQuiet)
+	rehydrateInfraNodes(&infraEnv, nodeClient)
+
+	icg := installer.NewInstallConfigManager()
+	stlog := bootstrap.NewStepLogger(false)
+	gcpClient := gcp.NewGCPClient(ctx, stlog, os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"))
+
+	bs, err := gcp.NewGCPBootstrapper(
+		ctx,
+		nil, stlog, &infraEnv, icg, gcpClient, fw, nodeClient, nil, intutil.NewTime(), nil, nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create bootstrapper: %w", err)
+	}
+
+	return bs.GrowDisk(c.Opts.Node, c.Opts.Size)
+}
+
+func AddNodeGrowDiskCmd(nodeCmd *cobra.Command, opts *util.GlobalOptions) {
+	growDisk := NodeGrowDiskCmd{
+		cmd: &cobra.Command{
+			Use:   "grow-disk",
+			Short: "Grow the root disk of a bootstrapped node",
+			Long: csio.Long(`Resizes the GCP root disk of a node, grows the partition and
+				filesystem on the node via SSH, and records the new size in the
+				local infra file. Replaces the manual resize procedure.
+				Disks can only grow; GCP does not support shrinking.`),
+			Example: util.FormatExamples("beta node grow-disk", []csio.Example{
+				{Cmd: "--node postgres --size 500", Desc: "Grow the postgres root disk to 500 GB"},
+				{Cmd: "--node k0s-1 --size 200", Desc: "Grow the first k0s node root disk to 200 GB"},
+			}),
+		},
+		Opts: &NodeGrowDiskOpts{
+			GlobalOptions: opts,
+		},
+	}
+
+	flags := growDisk.cmd.Flags()
+	flags.StringVar(&growDisk.Opts.Node, "node", "", "Name of the node whose disk to grow")
+	flags.Int64Var(&growDisk.Opts.Size, "size", 0, "New disk size in GB")
+	flags.BoolVar(&growDisk.Opts.SSHQuiet, "ssh-quiet", false, "Suppress output of commands run on the nodes via SSH (optional)")
+
+	util.MarkFlagRequired(growDisk.cmd, "node")
+	util.MarkFlagRequired(growDisk.cmd, "size")
+
+	growDisk.cmd.RunE = growDisk.RunE
+	util.AddCmd(nodeCmd, growDisk.cmd)
+}
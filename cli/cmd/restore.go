@@ -0,0 +1,27 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+type RestoreCmd struct {
+	cmd *cobra.Command
+}
+
+func AddRestoreCmd(rootCmd *cobra.Command, opts *util.GlobalOptions) {
+	restore := RestoreCmd{
+		cmd: &cobra.Command{
+			Use:   "restore",
+			Short: "Restore parts of a Codesphere installation from a backup",
+			Long:  io.Long(`Restore parts of a Codesphere installation from a backup.`),
+		},
+	}
+
+	AddRestorePostgresCmd(restore.cmd, opts)
+	util.AddCmd(rootCmd, restore.cmd)
+}
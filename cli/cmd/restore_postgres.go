@@ -0,0 +1,151 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	csio "github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/audit"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/env"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	intutil "github.com/codesphere-cloud/oms/internal/util"
+	"github.com/spf13/cobra"
+)
+
+type RestorePostgresCmd struct {
+	cmd  *cobra.Command
+	Opts *RestorePostgresOpts
+}
+
+type RestorePostgresOpts struct {
+	*util.GlobalOptions
+	Bucket         string
+	Yes            bool
+	DryRun         bool
+	OverrideWindow bool
+	SSHQuiet       bool
+}
+
+// PGRestoreCommand returns the shell command that streams a backup object from
+// the bucket into psql, replacing the databases contained in the dump.
+func PGRestoreCommand(bucket, object string) string {
+	return fmt.Sprintf("set -o pipefail; gcloud storage cat gs://%s/%s | gunzip | runuser -u postgres -- psql --set ON_ERROR_STOP=on postgres", bucket, object)
+}
+
+func (c *RestorePostgresCmd) RunE(_ *cobra.Command, args []string) error {
+	fw := intutil.NewFilesystemWriter()
+	infraEnv, exists, err := gcp.LoadInfraFile(fw, gcp.GetInfraFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to load infra file: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("infra file not found at %s; bootstrap the environment first", gcp.GetInfraFilePath())
+	}
+	if infraEnv.Jumpbox != nil {
+		infraEnv.Jumpbox.NodeClient = node.NewSSHNodeClient(c.Opts.SSHQuiet)
+	}
+
+	target, err := ResolvePostgresNode(&infraEnv)
+	if err != nil {
+		return err
+	}
+
+	bucket := c.Opts.Bucket
+	if bucket == "" {
+		bucket = gcp.PGBackupBucketName(infraEnv.ProjectID)
+	}
+	object := args[0]
+
+	if c.Opts.DryRun {
+		log.Println("Dry run: nothing will be restored.")
+		log.Printf("Would restore gs://%s/%s into postgres on %s, replacing the databases contained in the dump.", bucket, object, target.GetName())
+		return nil
+	}
+
+	if err := gcp.ConfirmMaintenanceWindow(infraEnv.MaintenanceWindow, intutil.NewTime(), "restore", c.Opts.OverrideWindow); err != nil {
+		return err
+	}
+
+	if infraEnv.Protected {
+		if err := gcp.ConfirmProtectedOperation(infraEnv.ProjectID, "restore", c.Opts.Yes, bufio.NewReader(os.Stdin)); err != nil {
+			return err
+		}
+	} else if !c.Opts.Yes {
+		if err := confirmRestore(bufio.NewReader(os.Stdin), bucket, object, target.GetName()); err != nil {
+			return err
+		}
+	}
+
+	// The restore is recorded in the audit log, so who replaced the databases
+	// and when stays reviewable.
+	if err := audit.Open(env.NewEnv().GetOmsWorkdir()); err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer func() { _ = audit.Close() }()
+	audit.Log("postgres.restore", target.GetName(), fmt.Sprintf("gs://%s/%s", bucket, object))
+
+	log.Printf("Restoring gs://%s/%s into postgres on %s", bucket, object, target.GetName())
+	err = target.RunSSHCommand("root", PGRestoreCommand(bucket, object))
+	if err != nil {
+		return fmt.Errorf("failed to restore postgres: %w", err)
+	}
+
+	log.Printf("Restore of gs://%s/%s completed", bucket, object)
+	return nil
+}
+
+// confirmRestore warns that the restore overwrites the current databases and
+// aborts unless the answer is yes.
+func confirmRestore(in *bufio.Reader, bucket, object, nodeName string) error {
+	log.Printf("About to restore gs://%s/%s into postgres on node %s.", bucket, object, nodeName)
+	log.Println("This replaces the databases contained in the dump with the backed up state.")
+	fmt.Print("Proceed? (y/N): ")
+
+	answer, err := in.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+		return fmt.Errorf("restore aborted")
+	}
+	return nil
+}
+
+func AddRestorePostgresCmd(rootCmd *cobra.Command, opts *util.GlobalOptions) {
+	restorePostgres := RestorePostgresCmd{
+		cmd: &cobra.Command{
+			Use:   "postgres <object>",
+			Short: "Restore PostgreSQL from a backup in the backup bucket",
+			Long: csio.Long(`Streams a backup object from the GCS backup bucket into psql on the
+				postgres node via the jumpbox, replacing the databases contained in
+				the dump. The restore asks for confirmation unless --yes is set.
+				The environment is read from the local infra file created during bootstrap.`),
+			Example: util.FormatExamples("beta restore postgres", []csio.Example{
+				{Cmd: "pg-backup-20260831-020000.sql.gz", Desc: "Restore a backup from the environment's backup bucket"},
+				{Cmd: "pg-backup-20260831-020000.sql.gz --yes", Desc: "Restore without a confirmation prompt"},
+			}),
+			Args: cobra.ExactArgs(1),
+		},
+		Opts: &RestorePostgresOpts{
+			GlobalOptions: opts,
+		},
+	}
+
+	flags := restorePostgres.cmd.Flags()
+	flags.StringVar(&restorePostgres.Opts.Bucket, "bucket", "", "GCS bucket to read the backup from (default: the environment's backup bucket)")
+	flags.BoolVar(&restorePostgres.Opts.Yes, "yes", false, "Skip the confirmation prompt (default: false)")
+	flags.BoolVar(&restorePostgres.Opts.DryRun, "dry-run", false, "Print what would be restored without changing anything (default: false)")
+	flags.BoolVar(&restorePostgres.Opts.OverrideWindow, "override-window", false, "Run the restore outside the environment's maintenance window (default: false)")
+	flags.BoolVar(&restorePostgres.Opts.SSHQuiet, "ssh-quiet", false, "Suppress SSH command output (default: false)")
+
+	restorePostgres.cmd.RunE = restorePostgres.RunE
+	util.AddCmd(rootCmd, restorePostgres.cmd)
+}
@@ -57,6 +57,7 @@ func GetRootCmd() *cobra.Command {
 	AddVersionCmd(rootCmd)
 	AddBetaCmd(rootCmd, opts)
 	AddUpdateCmd(rootCmd, opts)
+	AddUpgradeCmd(rootCmd, opts)
 
 	// Package commands
 	AddListCmd(rootCmd, opts)
@@ -64,8 +65,10 @@ func GetRootCmd() *cobra.Command {
 	AddInstallCmd(rootCmd, opts)
 	AddInitCmd(rootCmd, opts)
 	AddTemplateCmd(rootCmd, opts)
+	AddConfigCmd(rootCmd, opts)
 	AddBuildCmd(rootCmd, opts)
 	AddLicensesCmd(rootCmd)
+	AddLicenseCmd(rootCmd, opts)
 
 	// OMS API key management commands
 	apikey.AddRegisterCmd(rootCmd, opts)
@@ -74,11 +77,15 @@ func GetRootCmd() *cobra.Command {
 	// Smoke test commands
 	AddSmoketestCmd(rootCmd, opts)
 
+	// Verification commands
+	AddVerifyCmd(rootCmd, opts)
+
 	// Resource creation commands
 	AddCreateCmd(rootCmd, opts)
 
 	// Cluster administration commands
 	AddAddClusterAdminCmd(rootCmd, opts)
+	AddSecretsCmd(rootCmd, opts)
 
 	return rootCmd
 }
@@ -0,0 +1,27 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+type SecretsCmd struct {
+	cmd *cobra.Command
+}
+
+func AddSecretsCmd(rootCmd *cobra.Command, opts *util.GlobalOptions) {
+	secrets := SecretsCmd{
+		cmd: &cobra.Command{
+			Use:   "secrets",
+			Short: "Manage secrets of a Codesphere installation",
+			Long:  io.Long(`Manage secrets of a Codesphere installation.`),
+		},
+	}
+
+	AddSecretsRotateCmd(secrets.cmd, opts)
+	util.AddCmd(rootCmd, secrets.cmd)
+}
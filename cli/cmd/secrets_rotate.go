@@ -0,0 +1,315 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	csio "github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/audit"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/codesphere"
+	"github.com/codesphere-cloud/oms/internal/env"
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/secrets"
+	"github.com/codesphere-cloud/oms/internal/installer/vault"
+	intutil "github.com/codesphere-cloud/oms/internal/util"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+// Rotation targets selectable via --secret.
+const (
+	RotateTargetPostgresUsers  = "postgres-users"
+	RotateTargetRegistry       = "registry"
+	RotateTargetDomainAuthKeys = "domain-auth-keys"
+)
+
+// allRotateTargets is the default selection when no --secret flag is given.
+var allRotateTargets = []string{RotateTargetPostgresUsers, RotateTargetRegistry, RotateTargetDomainAuthKeys}
+
+type SecretsRotateCmd struct {
+	cmd  *cobra.Command
+	Opts *SecretsRotateOpts
+}
+
+type SecretsRotateOpts struct {
+	*util.GlobalOptions
+
+	VaultFile  string
+	AgeKeyPath string
+	Secrets    []string
+
+	WithComments   bool
+	DryRun         bool
+	Force          bool
+	OverrideWindow bool
+
+	Push       bool
+	Namespace  string
+	SecretName string
+}
+
+func (c *SecretsRotateCmd) RunE(_ *cobra.Command, _ []string) error {
+	targets := c.Opts.Secrets
+	if len(targets) == 0 {
+		targets = allRotateTargets
+	}
+	if err := validateRotateTargets(targets); err != nil {
+		return err
+	}
+
+	if c.Opts.DryRun {
+		printRotationPlan(targets)
+		return nil
+	}
+
+	if err := c.confirmRotation(intutil.NewFilesystemWriter(), intutil.NewTime(), os.Stdin); err != nil {
+		return err
+	}
+
+	// Rotations are recorded in the audit log, so who rotated which secrets
+	// and when stays reviewable.
+	if err := audit.Open(env.NewEnv().GetOmsWorkdir()); err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer func() { _ = audit.Close() }()
+
+	icg := installer.NewInstallConfigManager()
+
+	return c.Rotate(icg)
+}
+
+// confirmRotation guards the rotation: the old credentials are gone once the
+// vault is rewritten. The environment's project ID (or the vault file, when
+// no infra file is around) has to be typed to confirm, protected environments
+// additionally require --force, and a configured maintenance window refuses
+// the rotation outside the window.
+func (c *SecretsRotateCmd) confirmRotation(fw intutil.FileIO, clock intutil.Time, in io.Reader) error {
+	infraEnv, exists, err := gcp.LoadInfraFile(fw, gcp.GetInfraFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to load infra file: %w", err)
+	}
+
+	if err := gcp.ConfirmMaintenanceWindow(infraEnv.MaintenanceWindow, clock, "secret rotation", c.Opts.OverrideWindow); err != nil {
+		return err
+	}
+
+	name := c.Opts.VaultFile
+	if exists {
+		name = infraEnv.ProjectID
+	}
+	if exists && infraEnv.Protected {
+		return gcp.ConfirmProtectedOperation(name, "secret rotation", c.Opts.Force, in)
+	}
+	if c.Opts.Force {
+		return nil
+	}
+
+	log.Printf("WARNING: rotating secrets invalidates the current credentials; downstream services must be restarted.")
+	log.Printf("Type '%s' to confirm the rotation: ", name)
+
+	reader := bufio.NewReader(in)
+	confirmation, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	if strings.TrimSpace(confirmation) != name {
+		return fmt.Errorf("confirmation did not match, aborting rotation")
+	}
+	return nil
+}
+
+// printRotationPlan lists what a rotation would regenerate and which services
+// would need restarting, without touching the vault.
+func printRotationPlan(targets []string) {
+	log.Println("Dry run: no secrets will be rotated.")
+	log.Println("Would rotate:")
+	for _, target := range targets {
+		log.Printf("  %s, requiring a restart of:\n", target)
+		for _, hint := range restartHints(target) {
+			log.Printf("    - %s\n", hint)
+		}
+	}
+}
+
+func AddSecretsRotateCmd(secretsCmd *cobra.Command, opts *util.GlobalOptions) {
+	c := SecretsRotateCmd{
+		cmd: &cobra.Command{
+			Use:   "rotate",
+			Short: "Rotate generated secrets in the vault",
+			Long: csio.Long(`Rotate generated secrets in the SOPS-encrypted vault file.
+
+			Regenerates the selected secrets (per-service postgres passwords, the registry
+			password, the domain auth key pair), writes the updated vault and re-encrypts it
+			with the same age key. With --push the rotated vault is also applied to the
+			cluster as a Kubernetes secret, like 'oms beta vault-secret' does.
+
+			After rotating, the affected downstream services must be restarted to pick up
+			the new credentials; the command prints which ones.
+
+			The rotation has to be confirmed by typing the environment's project ID (or the
+			vault file name when no infra file is around). --force skips the prompt, except
+			for protected environments.`),
+			Example: util.FormatExamples("secrets rotate", []csio.Example{
+				{Cmd: "--vault prod.vault.yaml", Desc: "Rotate all supported secrets"},
+				{Cmd: "--vault prod.vault.yaml --secret postgres-users", Desc: "Rotate only the per-service postgres passwords"},
+				{Cmd: "--vault prod.vault.yaml --secret registry --push", Desc: "Rotate the registry password and update the cluster secret"},
+				{Cmd: "--vault prod.vault.yaml --dry-run", Desc: "Show what would be rotated without touching the vault"},
+			}),
+		},
+		Opts: &SecretsRotateOpts{GlobalOptions: opts},
+	}
+
+	c.cmd.Flags().StringVar(&c.Opts.VaultFile, "vault", "prod.vault.yaml", "Path to the SOPS-encrypted vault file")
+	c.cmd.Flags().StringVar(&c.Opts.AgeKeyPath, "age-key", "", "Path to the age key file (optional, will use defaults if not provided)")
+	c.cmd.Flags().StringSliceVar(&c.Opts.Secrets, "secret", []string{},
+		fmt.Sprintf("Secrets to rotate: %s (default: all)", strings.Join(allRotateTargets, ", ")))
+	c.cmd.Flags().BoolVar(&c.Opts.WithComments, "with-comments", false, "Add helpful comments to the generated YAML files")
+	c.cmd.Flags().BoolVar(&c.Opts.DryRun, "dry-run", false, "Print what would be rotated without touching the vault (default: false)")
+	c.cmd.Flags().BoolVar(&c.Opts.Force, "force", false, "Skip the typed confirmation. Protected environments still require it (default: false)")
+	c.cmd.Flags().BoolVar(&c.Opts.OverrideWindow, "override-window", false, "Rotate outside the environment's maintenance window (default: false)")
+	c.cmd.Flags().BoolVar(&c.Opts.Push, "push", false, "Update the Kubernetes secret in the target cluster with the rotated vault")
+	c.cmd.Flags().StringVar(&c.Opts.Namespace, "namespace", "codesphere", "Kubernetes namespace of the vault secret (used with --push)")
+	c.cmd.Flags().StringVar(&c.Opts.SecretName, "secret-name", "cs-vault", "Name of the Kubernetes vault secret (used with --push)")
+
+	util.MarkFlagRequired(c.cmd, "vault")
+
+	c.cmd.RunE = c.RunE
+	util.AddCmd(secretsCmd, c.cmd)
+}
+
+func (c *SecretsRotateCmd) Rotate(icg installer.InstallConfigManager) error {
+	targets := c.Opts.Secrets
+	if len(targets) == 0 {
+		targets = allRotateTargets
+	}
+	if err := validateRotateTargets(targets); err != nil {
+		return err
+	}
+
+	// Resolve the age key up front so the vault is not rewritten when re-encryption
+	// would fail afterwards.
+	recipient, _, err := vault.ResolveAgeKey(c.Opts.AgeKeyPath, filepath.Dir(c.Opts.VaultFile))
+	if err != nil {
+		return fmt.Errorf("failed to resolve age key: %w", err)
+	}
+
+	log.Printf("Loading existing vault from: %s\n", c.Opts.VaultFile)
+	if err := icg.LoadVaultFromFile(c.Opts.VaultFile); err != nil {
+		return fmt.Errorf("failed to load vault file: %w", err)
+	}
+
+	v := icg.GetVault()
+	for _, target := range targets {
+		log.Printf("Rotating %s...\n", target)
+		switch target {
+		case RotateTargetPostgresUsers:
+			err = secrets.RotatePostgresUserPasswords(v)
+		case RotateTargetRegistry:
+			err = secrets.RotateRegistryPassword(v)
+		case RotateTargetDomainAuthKeys:
+			err = secrets.RotateDomainAuthKeys(v)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to rotate %s: %w", target, err)
+		}
+		audit.Log("secrets.rotate", target)
+	}
+
+	if err := icg.WriteVault(c.Opts.VaultFile, c.Opts.WithComments); err != nil {
+		return fmt.Errorf("failed to write vault file: %w", err)
+	}
+
+	if err := vault.EncryptFileWithSOPS(c.Opts.VaultFile, c.Opts.VaultFile, recipient); err != nil {
+		return fmt.Errorf("failed to re-encrypt vault file: %w", err)
+	}
+
+	if c.Opts.Push {
+		if err := c.pushVaultSecret(); err != nil {
+			return fmt.Errorf("failed to push rotated vault to cluster: %w", err)
+		}
+	}
+
+	printRestartReport(targets)
+
+	return nil
+}
+
+func validateRotateTargets(targets []string) error {
+	for _, target := range targets {
+		switch target {
+		case RotateTargetPostgresUsers, RotateTargetRegistry, RotateTargetDomainAuthKeys:
+		default:
+			return fmt.Errorf("unsupported secret %q (supported: %s)", target, strings.Join(allRotateTargets, ", "))
+		}
+	}
+	return nil
+}
+
+// pushVaultSecret updates the Kubernetes vault secret with the rotated vault,
+// mirroring 'oms beta vault-secret'.
+func (c *SecretsRotateCmd) pushVaultSecret() error {
+	kubeConfig, err := ctrlconfig.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load kubernetes config: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("failed to add kubernetes scheme: %w", err)
+	}
+
+	kubeClient, err := ctrlclient.New(kubeConfig, ctrlclient.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	creator := vault.NewVaultSecretCreator(kubeClient)
+
+	return creator.CreateSecretFromFile(c.cmd.Context(), c.Opts.VaultFile, c.Opts.AgeKeyPath, c.Opts.Namespace, c.Opts.SecretName)
+}
+
+// restartHints maps each rotation target to the downstream services that must be
+// restarted to pick up the new credentials.
+func restartHints(target string) []string {
+	switch target {
+	case RotateTargetPostgresUsers:
+		services := []string{}
+		for _, svc := range codesphere.PostgresServices {
+			services = append(services, svc.Name)
+		}
+		return services
+	case RotateTargetRegistry:
+		return []string{"local container registry (update the htpasswd file)", "image pull secrets of the workspace namespaces"}
+	case RotateTargetDomainAuthKeys:
+		return []string{"auth-service", "workspace-proxy", "ssh-workspace-proxy"}
+	}
+	return nil
+}
+
+func printRestartReport(targets []string) {
+	log.Println("\n" + strings.Repeat("=", 70))
+	log.Println("Secrets successfully rotated!")
+	log.Println(strings.Repeat("=", 70))
+
+	log.Println("\nThe following downstream services need restarting:")
+	for _, target := range targets {
+		log.Printf("  %s:\n", target)
+		for _, hint := range restartHints(target) {
+			log.Printf("    - %s\n", hint)
+		}
+	}
+	log.Println()
+}
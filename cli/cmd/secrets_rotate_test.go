@@ -0,0 +1,184 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/codesphere-cloud/oms/internal/installer/vault"
+	intutil "github.com/codesphere-cloud/oms/internal/util"
+)
+
+var _ = Describe("SecretsRotate", func() {
+	var (
+		icg          *installer.MockInstallConfigManager
+		cmd          *SecretsRotateCmd
+		installVault *files.InstallVault
+		vaultPath    string
+	)
+
+	BeforeEach(func() {
+		tmpDir := GinkgoT().TempDir()
+		vaultPath = filepath.Join(tmpDir, "prod.vault.yaml")
+		ageKeyPath := filepath.Join(tmpDir, "age_key.txt")
+		_, err := vault.GenerateAgeKey(ageKeyPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		installVault = &files.InstallVault{}
+		installVault.SetSecret(files.SecretEntry{Name: "postgresPasswordAuth", Fields: &files.SecretFields{Password: "old-postgres-pw"}})
+		installVault.SetSecret(files.SecretEntry{Name: files.SecretRegistryPassword, Fields: &files.SecretFields{Password: "old-registry-pw"}})
+		installVault.SetSecret(files.SecretEntry{Name: files.SecretDomainAuthPrivateKey, File: &files.SecretFile{Name: "key.pem", Content: "old-domain-key"}})
+
+		icg = installer.NewMockInstallConfigManager(GinkgoT())
+
+		cmd = &SecretsRotateCmd{
+			Opts: &SecretsRotateOpts{
+				GlobalOptions: &util.GlobalOptions{},
+				VaultFile:     vaultPath,
+				AgeKeyPath:    ageKeyPath,
+			},
+		}
+	})
+
+	expectVaultWritten := func() {
+		icg.EXPECT().WriteVault(vaultPath, false).RunAndReturn(func(path string, _ bool) error {
+			return os.WriteFile(path, []byte("secrets:\n  password: hunter2\n"), 0600)
+		})
+	}
+
+	It("rotates all secrets and re-encrypts the vault", func() {
+		icg.EXPECT().LoadVaultFromFile(vaultPath).Return(nil)
+		icg.EXPECT().GetVault().Return(installVault)
+		expectVaultWritten()
+
+		Expect(cmd.Rotate(icg)).To(Succeed())
+
+		Expect(installVault.GetSecret("postgresPasswordAuth").Fields.Password).NotTo(Equal("old-postgres-pw"))
+		Expect(installVault.GetSecret(files.SecretRegistryPassword).Fields.Password).NotTo(Equal("old-registry-pw"))
+		Expect(installVault.GetSecret(files.SecretDomainAuthPrivateKey).File.Content).NotTo(Equal("old-domain-key"))
+		Expect(installVault.GetSecret(files.SecretDomainAuthPublicKey)).NotTo(BeNil())
+
+		data, err := os.ReadFile(vaultPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("sops:"))
+		Expect(string(data)).NotTo(ContainSubstring("hunter2"))
+	})
+
+	It("rotates only the selected secrets", func() {
+		cmd.Opts.Secrets = []string{RotateTargetRegistry}
+
+		icg.EXPECT().LoadVaultFromFile(vaultPath).Return(nil)
+		icg.EXPECT().GetVault().Return(installVault)
+		expectVaultWritten()
+
+		Expect(cmd.Rotate(icg)).To(Succeed())
+
+		Expect(installVault.GetSecret("postgresPasswordAuth").Fields.Password).To(Equal("old-postgres-pw"))
+		Expect(installVault.GetSecret(files.SecretRegistryPassword).Fields.Password).NotTo(Equal("old-registry-pw"))
+	})
+
+	It("fails for an unsupported secret", func() {
+		cmd.Opts.Secrets = []string{"token-keys"}
+
+		err := cmd.Rotate(icg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unsupported secret"))
+	})
+
+	It("fails when the vault cannot be loaded", func() {
+		icg.EXPECT().LoadVaultFromFile(vaultPath).Return(os.ErrNotExist)
+
+		err := cmd.Rotate(icg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("failed to load vault file"))
+	})
+
+	Describe("confirmRotation", func() {
+		var mockFw *intutil.MockFileIO
+
+		BeforeEach(func() {
+			mockFw = intutil.NewMockFileIO(GinkgoT())
+		})
+
+		It("accepts the typed vault file name when no infra file exists", func() {
+			mockFw.EXPECT().Exists(gcp.GetInfraFilePath()).Return(false)
+
+			Expect(cmd.confirmRotation(mockFw, intutil.NewFakeTime(), strings.NewReader(vaultPath+"\n"))).To(Succeed())
+		})
+
+		It("aborts when the confirmation does not match", func() {
+			mockFw.EXPECT().Exists(gcp.GetInfraFilePath()).Return(false)
+
+			err := cmd.confirmRotation(mockFw, intutil.NewFakeTime(), strings.NewReader("something-else\n"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("confirmation did not match"))
+		})
+
+		It("skips the prompt with --force", func() {
+			cmd.Opts.Force = true
+			mockFw.EXPECT().Exists(gcp.GetInfraFilePath()).Return(false)
+
+			Expect(cmd.confirmRotation(mockFw, intutil.NewFakeTime(), strings.NewReader(""))).To(Succeed())
+		})
+
+		It("refuses protected environments without --force", func() {
+			infra, err := json.Marshal(gcp.CodesphereEnvironment{ProjectID: "prod-project", Protected: true})
+			Expect(err).NotTo(HaveOccurred())
+			mockFw.EXPECT().Exists(gcp.GetInfraFilePath()).Return(true)
+			mockFw.EXPECT().ReadFile(gcp.GetInfraFilePath()).Return(infra, nil)
+
+			err = cmd.confirmRotation(mockFw, intutil.NewFakeTime(), strings.NewReader("prod-project\n"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("protected"))
+		})
+
+		It("refuses a rotation outside the maintenance window", func() {
+			infra, err := json.Marshal(gcp.CodesphereEnvironment{
+				ProjectID:         "prod-project",
+				MaintenanceWindow: &gcp.MaintenanceWindow{Start: "10:00", End: "11:00"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			mockFw.EXPECT().Exists(gcp.GetInfraFilePath()).Return(true)
+			mockFw.EXPECT().ReadFile(gcp.GetInfraFilePath()).Return(infra, nil)
+
+			// The fake clock sits at midnight UTC, outside the window.
+			err = cmd.confirmRotation(mockFw, intutil.NewFakeTime(), strings.NewReader("prod-project\n"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("outside the maintenance window"))
+		})
+
+		It("rotates outside the maintenance window with --override-window", func() {
+			cmd.Opts.OverrideWindow = true
+			infra, err := json.Marshal(gcp.CodesphereEnvironment{
+				ProjectID:         "prod-project",
+				MaintenanceWindow: &gcp.MaintenanceWindow{Start: "10:00", End: "11:00"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			mockFw.EXPECT().Exists(gcp.GetInfraFilePath()).Return(true)
+			mockFw.EXPECT().ReadFile(gcp.GetInfraFilePath()).Return(infra, nil)
+
+			Expect(cmd.confirmRotation(mockFw, intutil.NewFakeTime(), strings.NewReader("prod-project\n"))).To(Succeed())
+		})
+
+		It("requires the project ID for protected environments even with --force", func() {
+			cmd.Opts.Force = true
+			infra, err := json.Marshal(gcp.CodesphereEnvironment{ProjectID: "prod-project", Protected: true})
+			Expect(err).NotTo(HaveOccurred())
+			mockFw.EXPECT().Exists(gcp.GetInfraFilePath()).Return(true)
+			mockFw.EXPECT().ReadFile(gcp.GetInfraFilePath()).Return(infra, nil)
+
+			Expect(cmd.confirmRotation(mockFw, intutil.NewFakeTime(), strings.NewReader("prod-project\n"))).To(Succeed())
+		})
+	})
+})
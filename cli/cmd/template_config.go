@@ -64,6 +64,10 @@ Template syntax in config.yaml:
   # Inject a file secret's content
   caCert: "{{ secret "caCert" "file.content" }}"
 
+  # Inject environment variables (both forms fail when the variable is unset)
+  domain: "${CS_DOMAIN}"
+  domain: "{{ env "CS_DOMAIN" }}"
+
 Secret names and selectors must match entries in the prod.vault.yaml file.`),
 			Example: util.FormatExamples("template config", []io.Example{
 				{
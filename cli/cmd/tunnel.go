@@ -0,0 +1,163 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+
+	csio "github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	intutil "github.com/codesphere-cloud/oms/internal/util"
+	"github.com/spf13/cobra"
+)
+
+type TunnelCmd struct {
+	cmd  *cobra.Command
+	Opts *TunnelOpts
+}
+
+type TunnelOpts struct {
+	*util.GlobalOptions
+	LocalPort int
+	Socks     bool
+}
+
+// DefaultSocksPort is the local port the SOCKS proxy listens on by default.
+const DefaultSocksPort = 1080
+
+// TunnelTarget is the in-cluster endpoint a named service tunnel connects to.
+type TunnelTarget struct {
+	// RemoteAddr is the host:port of the service, reachable from the jumpbox.
+	RemoteAddr string
+	// DefaultLocalPort is the local port used when --local-port is not set.
+	DefaultLocalPort int
+}
+
+// ResolveTunnelTarget maps a service name to its endpoint in the environment.
+func ResolveTunnelTarget(infraEnv *gcp.CodesphereEnvironment, service string) (TunnelTarget, error) {
+	switch service {
+	case "postgres":
+		if infraEnv.PostgreSQLNode == nil {
+			return TunnelTarget{}, fmt.Errorf("environment has no postgres node")
+		}
+		return TunnelTarget{RemoteAddr: infraEnv.PostgreSQLNode.GetInternalIP() + ":5432", DefaultLocalPort: 5432}, nil
+	case "k8s":
+		if len(infraEnv.ControlPlaneNodes) == 0 {
+			return TunnelTarget{}, fmt.Errorf("environment has no control plane nodes")
+		}
+		return TunnelTarget{RemoteAddr: infraEnv.ControlPlaneNodes[0].GetInternalIP() + ":6443", DefaultLocalPort: 6443}, nil
+	case "ceph":
+		if len(infraEnv.CephNodes) == 0 {
+			return TunnelTarget{}, fmt.Errorf("environment has no ceph nodes")
+		}
+		return TunnelTarget{RemoteAddr: infraEnv.CephNodes[0].GetInternalIP() + ":8443", DefaultLocalPort: 8443}, nil
+	case "registry":
+		if infraEnv.PostgreSQLNode == nil {
+			return TunnelTarget{}, fmt.Errorf("environment has no postgres node")
+		}
+		return TunnelTarget{RemoteAddr: infraEnv.PostgreSQLNode.GetInternalIP() + ":5000", DefaultLocalPort: 5000}, nil
+	default:
+		return TunnelTarget{}, fmt.Errorf("unknown service %q (options: postgres, k8s, ceph, registry)", service)
+	}
+}
+
+func (c *TunnelCmd) RunE(_ *cobra.Command, args []string) error {
+	fw := intutil.NewFilesystemWriter()
+
+	infraEnv, exists, err := gcp.LoadInfraFile(fw, gcp.GetInfraFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to load infra file: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("infra file not found at %s; bootstrap the environment first", gcp.GetInfraFilePath())
+	}
+	if infraEnv.Jumpbox == nil {
+		return fmt.Errorf("infra file does not contain a jumpbox")
+	}
+	infraEnv.Jumpbox.NodeClient = node.NewSSHNodeClient(true)
+
+	var listener net.Listener
+	switch {
+	case c.Opts.Socks:
+		if len(args) > 0 {
+			return fmt.Errorf("--socks opens a dynamic proxy and cannot be combined with a service")
+		}
+		localPort := c.Opts.LocalPort
+		if localPort == 0 {
+			localPort = DefaultSocksPort
+		}
+		localAddr := fmt.Sprintf("127.0.0.1:%d", localPort)
+		listener, err = infraEnv.Jumpbox.OpenSOCKSProxy("ubuntu", localAddr)
+		if err != nil {
+			return fmt.Errorf("failed to open SOCKS proxy: %w", err)
+		}
+		log.Printf("SOCKS5 proxy listening on %s, dialing through the jumpbox. Press Ctrl+C to stop.", localAddr)
+
+	case len(args) == 1:
+		target, err := ResolveTunnelTarget(&infraEnv, args[0])
+		if err != nil {
+			return err
+		}
+		localPort := c.Opts.LocalPort
+		if localPort == 0 {
+			localPort = target.DefaultLocalPort
+		}
+		localAddr := fmt.Sprintf("127.0.0.1:%d", localPort)
+		listener, err = infraEnv.Jumpbox.OpenTunnel("ubuntu", localAddr, target.RemoteAddr)
+		if err != nil {
+			return fmt.Errorf("failed to open tunnel: %w", err)
+		}
+		log.Printf("Forwarding %s -> %s (%s) through the jumpbox. Press Ctrl+C to stop.", localAddr, target.RemoteAddr, args[0])
+
+	default:
+		return fmt.Errorf("specify a service to forward or --socks for a dynamic proxy")
+	}
+	defer intutil.IgnoreError(listener.Close)
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	<-interrupt
+	log.Println("Closing tunnel.")
+	return nil
+}
+
+func AddTunnelCmd(rootCmd *cobra.Command, opts *util.GlobalOptions) {
+	tunnel := TunnelCmd{
+		cmd: &cobra.Command{
+			Use:   "tunnel [service]",
+			Short: "Forward a local port to an in-cluster service through the jumpbox",
+			Long: csio.Long(`Establishes an SSH tunnel through the jumpbox to a service inside
+				the environment, so local tooling (psql, kubectl, a browser) can reach
+				endpoints that are only exposed on the internal network.
+
+				Supported services: postgres (5432), k8s (6443), ceph (8443), registry (5000).
+				With --socks, a dynamic SOCKS5 proxy (like ssh -D) is opened instead, so
+				browsers and tooling can reach any internal-only endpoint.
+				The environment is read from the local infra file created during bootstrap.`),
+			Example: util.FormatExamples("beta tunnel", []csio.Example{
+				{Cmd: "postgres", Desc: "Forward localhost:5432 to the postgres node"},
+				{Cmd: "k8s", Desc: "Forward localhost:6443 to the Kubernetes API"},
+				{Cmd: "ceph --local-port 9443", Desc: "Forward localhost:9443 to the Ceph dashboard"},
+				{Cmd: "--socks", Desc: "Open a SOCKS5 proxy on localhost:1080 for internal endpoints"},
+			}),
+			Args: cobra.MaximumNArgs(1),
+		},
+		Opts: &TunnelOpts{
+			GlobalOptions: opts,
+		},
+	}
+
+	flags := tunnel.cmd.Flags()
+	flags.IntVar(&tunnel.Opts.LocalPort, "local-port", 0, "Local port to listen on (default: the service's own port, 1080 for --socks)")
+	flags.BoolVar(&tunnel.Opts.Socks, "socks", false, "Open a dynamic SOCKS5 proxy through the jumpbox instead of forwarding a single service (default: false)")
+
+	tunnel.cmd.RunE = tunnel.RunE
+	util.AddCmd(rootCmd, tunnel.cmd)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/codesphere-cloud/oms/cli/cmd"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+)
+
+var _ = Describe("TunnelCmd", func() {
+	Describe("AddTunnelCmd", func() {
+		It("registers the tunnel command with its flags", func() {
+			parent := &cobra.Command{Use: "beta"}
+			cmd.AddTunnelCmd(parent, &util.GlobalOptions{})
+
+			tunnel, _, err := parent.Find([]string{"tunnel"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tunnel.Use).To(Equal("tunnel [service]"))
+			Expect(tunnel.Flags().Lookup("local-port")).NotTo(BeNil())
+			Expect(tunnel.Flags().Lookup("socks")).NotTo(BeNil())
+		})
+	})
+
+	Describe("ResolveTunnelTarget", func() {
+		var infraEnv *gcp.CodesphereEnvironment
+
+		BeforeEach(func() {
+			infraEnv = &gcp.CodesphereEnvironment{
+				PostgreSQLNode: &node.Node{Name: "postgres", InternalIP: "10.0.0.2"},
+				ControlPlaneNodes: []*node.Node{
+					{Name: "control-plane-1", InternalIP: "10.0.0.3"},
+				},
+				CephNodes: []*node.Node{
+					{Name: "ceph-1", InternalIP: "10.0.0.4"},
+				},
+			}
+		})
+
+		It("resolves postgres to the postgres node", func() {
+			target, err := cmd.ResolveTunnelTarget(infraEnv, "postgres")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(target.RemoteAddr).To(Equal("10.0.0.2:5432"))
+			Expect(target.DefaultLocalPort).To(Equal(5432))
+		})
+
+		It("resolves k8s to the first control plane node", func() {
+			target, err := cmd.ResolveTunnelTarget(infraEnv, "k8s")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(target.RemoteAddr).To(Equal("10.0.0.3:6443"))
+			Expect(target.DefaultLocalPort).To(Equal(6443))
+		})
+
+		It("resolves ceph to the first ceph node", func() {
+			target, err := cmd.ResolveTunnelTarget(infraEnv, "ceph")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(target.RemoteAddr).To(Equal("10.0.0.4:8443"))
+			Expect(target.DefaultLocalPort).To(Equal(8443))
+		})
+
+		It("resolves registry to the registry container on the postgres node", func() {
+			target, err := cmd.ResolveTunnelTarget(infraEnv, "registry")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(target.RemoteAddr).To(Equal("10.0.0.2:5000"))
+			Expect(target.DefaultLocalPort).To(Equal(5000))
+		})
+
+		It("rejects unknown services", func() {
+			_, err := cmd.ResolveTunnelTarget(infraEnv, "grafana")
+			Expect(err).To(MatchError(ContainSubstring("unknown service")))
+		})
+
+		It("fails when the service's node is missing from the environment", func() {
+			infraEnv.CephNodes = nil
+
+			_, err := cmd.ResolveTunnelTarget(infraEnv, "ceph")
+			Expect(err).To(MatchError(ContainSubstring("no ceph nodes")))
+		})
+	})
+})
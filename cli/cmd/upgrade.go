@@ -13,14 +13,16 @@ type UpgradeCmd struct {
 	cmd *cobra.Command
 }
 
-func AddUpgradeCmd(rootCmd *cobra.Command) {
+func AddUpgradeCmd(rootCmd *cobra.Command, opts *util.GlobalOptions) {
 	upgrade := UpgradeCmd{
 		cmd: &cobra.Command{
 			Use:   "upgrade",
-			Short: "Coming soon: Upgrade Codesphere OMS",
-			Long:  `Coming soon: Upgrade Codesphere OMS to the latest or a specific version.`,
+			Short: "Upgrade components of a Codesphere installation",
+			Long:  `Upgrade components of a Codesphere installation, like the Kubernetes distribution on the cluster nodes.`,
 		},
 	}
 
+	AddUpgradeK8sCmd(upgrade.cmd, opts)
+
 	util.AddCmd(rootCmd, upgrade.cmd)
 }
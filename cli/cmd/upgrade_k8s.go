@@ -0,0 +1,96 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	csio "github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/codesphere-cloud/oms/internal/portal"
+	intutil "github.com/codesphere-cloud/oms/internal/util"
+	"github.com/spf13/cobra"
+)
+
+type UpgradeK8sCmd struct {
+	cmd  *cobra.Command
+	Opts *UpgradeK8sOpts
+}
+
+type UpgradeK8sOpts struct {
+	*util.GlobalOptions
+	Version  string
+	SSHQuiet bool
+}
+
+func (c *UpgradeK8sCmd) RunE(_ *cobra.Command, _ []string) error {
+	ctx := c.cmd.Context()
+	fw := intutil.NewFilesystemWriter()
+
+	infraFilePath := gcp.GetInfraFilePath()
+	infraEnv, exists, err := gcp.LoadInfraFile(fw, infraFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to load infra file: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("infra file not found at %s; bootstrap the environment first", infraFilePath)
+	}
+
+	version := c.Opts.Version
+	if version == "" {
+		version = infraEnv.KubernetesVersion
+	}
+	if version == "" {
+		return fmt.Errorf("no --version given and no kubernetes version pinned in the environment")
+	}
+
+	nodeClient := node.NewSSHNodeClient(c.Opts.SSHQuiet)
+	rehydrateInfraNodes(&infraEnv, nodeClient)
+
+	icg := installer.NewInstallConfigManager()
+	stlog := bootstrap.NewStepLogger(false)
+	gcpClient := gcp.NewGCPClient(ctx, stlog, os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"))
+
+	bs, err := gcp.NewGCPBootstrapper(
+		ctx,
+		nil, stlog, &infraEnv, icg, gcpClient, fw, nodeClient, portal.NewPortalClient(), intutil.NewTime(), nil, nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create bootstrapper: %w", err)
+	}
+
+	return bs.UpgradeK8s(version)
+}
+
+func AddUpgradeK8sCmd(upgradeCmd *cobra.Command, opts *util.GlobalOptions) {
+	upgradeK8s := UpgradeK8sCmd{
+		cmd: &cobra.Command{
+			Use:   "k8s",
+			Short: "Upgrade k0s on the nodes of a bootstrapped environment",
+			Long: csio.Long(`Upgrades the k0s release on the cluster nodes of a bootstrapped
+				environment. The control plane is upgraded first, then each worker
+				is drained, upgraded and uncordoned one at a time, so workloads
+				keep running. The new version is recorded in the local infra file.`),
+			Example: util.FormatExamples("upgrade k8s", []csio.Example{
+				{Cmd: "--version v1.33.2+k0s.0", Desc: "Upgrade the cluster to k0s v1.33.2+k0s.0"},
+				{Cmd: "", Desc: "Upgrade the cluster to the version pinned at bootstrap"},
+			}),
+		},
+		Opts: &UpgradeK8sOpts{
+			GlobalOptions: opts,
+		},
+	}
+
+	flags := upgradeK8s.cmd.Flags()
+	flags.StringVar(&upgradeK8s.Opts.Version, "version", "", "k0s release to upgrade to, e.g. v1.33.2+k0s.0 (default: version pinned in the environment)")
+	flags.BoolVar(&upgradeK8s.Opts.SSHQuiet, "ssh-quiet", false, "Suppress output of commands run on the nodes via SSH (optional)")
+
+	upgradeK8s.cmd.RunE = upgradeK8s.RunE
+	util.AddCmd(upgradeCmd, upgradeK8s.cmd)
+}
@@ -0,0 +1,27 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+type VerifyCmd struct {
+	cmd *cobra.Command
+}
+
+func AddVerifyCmd(rootCmd *cobra.Command, opts *util.GlobalOptions) {
+	verify := VerifyCmd{
+		cmd: &cobra.Command{
+			Use:   "verify",
+			Short: "Verify a Codesphere installation against its configuration",
+			Long:  io.Long(`Verify a Codesphere installation against its configuration.`),
+		},
+	}
+
+	AddVerifyConfigCmd(verify.cmd, opts)
+	util.AddCmd(rootCmd, verify.cmd)
+}
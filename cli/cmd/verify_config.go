@@ -0,0 +1,108 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/codesphere-cloud/cs-go/pkg/io"
+	"github.com/codesphere-cloud/oms/cli/cmd/util"
+	"github.com/codesphere-cloud/oms/internal/drift"
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	intutil "github.com/codesphere-cloud/oms/internal/util"
+	"github.com/spf13/cobra"
+)
+
+type VerifyConfigCmd struct {
+	cmd  *cobra.Command
+	Opts VerifyConfigOpts
+}
+
+type VerifyConfigOpts struct {
+	*util.GlobalOptions
+	Config  string
+	Webhook string
+}
+
+func (c *VerifyConfigCmd) RunE(cmd *cobra.Command, _ []string) error {
+	data, err := os.ReadFile(c.Opts.Config)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", c.Opts.Config, err)
+	}
+
+	config := &files.RootConfig{}
+	if err := config.Unmarshal(data); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", c.Opts.Config, err)
+	}
+
+	clientset, _, err := intutil.NewClients()
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	checker := drift.NewChecker(clientset, config)
+	items, err := checker.Check(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to check cluster for drift: %w", err)
+	}
+
+	if c.Opts.Webhook != "" {
+		report := drift.NewReport(items, time.Now())
+		if err := drift.PostReport(cmd.Context(), c.Opts.Webhook, report); err != nil {
+			return fmt.Errorf("failed to post drift report: %w", err)
+		}
+	}
+
+	if len(items) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "cluster matches %s, no drift detected\n", c.Opts.Config)
+		return nil
+	}
+
+	for _, item := range items {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s\n", item.String())
+	}
+	return fmt.Errorf("cluster drifted from %s in %d place(s)", c.Opts.Config, len(items))
+}
+
+func AddVerifyConfigCmd(parentCmd *cobra.Command, opts *util.GlobalOptions) {
+	verifyCmd := &VerifyConfigCmd{
+		cmd: &cobra.Command{
+			Use:   "config",
+			Short: "Compare the install config with the running cluster",
+			Long: io.Long(`Compare the install config with the state of the running cluster and
+				report drift, e.g. introduced by manual kubectl edits after the installation.
+
+				Checked areas are the gateway services (type and external IPs), the domains
+				served by the platform ingresses, the registry the platform images are pulled
+				from, and the hosting and workspace plans. The command exits non-zero when
+				drift is found, so it can gate upgrades in automation.
+
+				The target cluster is determined by the current kubeconfig context. Set the
+				KUBECONFIG environment variable to target a different cluster.`),
+			Example: util.FormatExamples("verify config", []io.Example{
+				{
+					Cmd:  "--config config.yaml",
+					Desc: "Report drift between config.yaml and the running cluster",
+				},
+				{
+					Cmd:  "--config config.yaml --webhook https://hooks.example.com/oms",
+					Desc: "Additionally post the result as JSON to a webhook",
+				},
+			}),
+			Args: cobra.ExactArgs(0),
+		},
+		Opts: VerifyConfigOpts{GlobalOptions: opts},
+	}
+
+	verifyCmd.cmd.Flags().StringVarP(&verifyCmd.Opts.Config, "config", "c", "", "Path to the config.yaml to verify (required)")
+	verifyCmd.cmd.Flags().StringVar(&verifyCmd.Opts.Webhook, "webhook", "", "URL to post the verification result to as JSON")
+
+	util.MarkFlagRequired(verifyCmd.cmd, "config")
+
+	util.AddCmd(parentCmd, verifyCmd.cmd)
+
+	verifyCmd.cmd.RunE = verifyCmd.RunE
+}
@@ -0,0 +1,104 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package audit writes an append-only JSON lines log of the mutating
+// actions OMS performs against a customer environment — GCP resource
+// changes and SSH commands on nodes — so a security review can
+// reconstruct what the bootstrapper did on a project.
+package audit
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry is a single audited action.
+type Entry struct {
+	Time   time.Time `json:"time"`
+	User   string    `json:"user,omitempty"`
+	Action string    `json:"action"`
+	Target string    `json:"target"`
+	Detail string    `json:"detail,omitempty"`
+}
+
+// username is recorded as the acting user in every entry. It is resolved
+// once; the environment fallback covers static builds without user database
+// access.
+var username = func() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}()
+
+var (
+	mu   sync.Mutex
+	file *os.File
+)
+
+// Open starts auditing into <dir>/audit.jsonl. The file is opened
+// append-only so entries from earlier runs stay reviewable. Until Open
+// is called, Log is a no-op.
+func Open(dir string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if file != nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, "audit.jsonl"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	file = f
+	return nil
+}
+
+// Close stops auditing and closes the log file.
+func Close() error {
+	mu.Lock()
+	defer mu.Unlock()
+	if file == nil {
+		return nil
+	}
+
+	err := file.Close()
+	file = nil
+	return err
+}
+
+// Log appends one entry to the audit log. Write failures are reported as
+// warnings instead of failing the audited operation itself.
+func Log(action string, target string, detail ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if file == nil {
+		return
+	}
+
+	line, err := json.Marshal(Entry{
+		Time:   time.Now().UTC(),
+		User:   username,
+		Action: action,
+		Target: target,
+		Detail: strings.Join(detail, " "),
+	})
+	if err != nil {
+		log.Printf("Warning: failed to marshal audit entry: %v\n", err)
+		return
+	}
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		log.Printf("Warning: failed to write audit log: %v\n", err)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package audit_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/codesphere-cloud/oms/internal/audit"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestAudit(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Audit Suite")
+}
+
+var _ = Describe("Log", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	AfterEach(func() {
+		Expect(audit.Close()).To(Succeed())
+	})
+
+	readEntries := func() []audit.Entry {
+		data, err := os.ReadFile(filepath.Join(dir, "audit.jsonl"))
+		Expect(err).NotTo(HaveOccurred())
+
+		var entries []audit.Entry
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			var entry audit.Entry
+			Expect(json.Unmarshal([]byte(line), &entry)).To(Succeed())
+			entries = append(entries, entry)
+		}
+		return entries
+	}
+
+	It("is a no-op before Open is called", func() {
+		audit.Log("gcp.create-project", "my-project")
+		Expect(filepath.Join(dir, "audit.jsonl")).NotTo(BeAnExistingFile())
+	})
+
+	It("writes one JSON line per action", func() {
+		Expect(audit.Open(dir)).To(Succeed())
+		audit.Log("gcp.create-project", "my-project")
+		audit.Log("ssh.run", "jumpbox", "ubuntu", "sudo")
+
+		entries := readEntries()
+		Expect(entries).To(HaveLen(2))
+		Expect(entries[0].Action).To(Equal("gcp.create-project"))
+		Expect(entries[0].Target).To(Equal("my-project"))
+		Expect(entries[0].Time).NotTo(BeZero())
+		Expect(entries[1].Detail).To(Equal("ubuntu sudo"))
+	})
+
+	It("appends to the log of a previous run", func() {
+		Expect(audit.Open(dir)).To(Succeed())
+		audit.Log("gcp.create-vpc", "vpc-1")
+		Expect(audit.Close()).To(Succeed())
+
+		Expect(audit.Open(dir)).To(Succeed())
+		audit.Log("gcp.delete-instance", "node-1")
+
+		entries := readEntries()
+		Expect(entries).To(HaveLen(2))
+		Expect(entries[1].Action).To(Equal("gcp.delete-instance"))
+	})
+})
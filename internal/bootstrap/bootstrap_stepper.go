@@ -3,10 +3,18 @@
 
 package bootstrap
 
-// Note: In this file we need to use fmt.Print for the line reset.
+// Note: In this file we need to use fmt.Fprint for the line reset.
 // Line resets don't work with log.Print as expected.
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/codesphere-cloud/oms/internal/redact"
 )
 
 const (
@@ -16,74 +24,225 @@ const (
 	RESET_TEXT         = "\033[0m"
 	RED_TEXT           = "\033[31m"
 	GREEN_TEXT         = "\033[32m"
+	YELLOW_TEXT        = "\033[33m"
 )
 
+// StepRecord captures the outcome of one executed step for the summary
+// report: how long it took, how often it was retried, and whether it failed.
+type StepRecord struct {
+	Name            string        `json:"name"`
+	Duration        time.Duration `json:"-"`
+	DurationSeconds float64       `json:"durationSeconds"`
+	Retries         int           `json:"retries"`
+	Failed          bool          `json:"failed"`
+	Error           string        `json:"error,omitempty"`
+	Warnings        []string      `json:"warnings,omitempty"`
+}
+
 type StepLogger struct {
-	silent      bool
-	subSteps    int
-	currentStep string
+	silent  bool
+	compact bool
+	out     io.Writer
+	// depth is the nesting level of the currently running step body:
+	// 0 inside a top-level step, one more per enclosing substep.
+	depth int
+	// pendingLines counts the lines printed since the current top-level
+	// step started, so its success line can clear them.
+	pendingLines int
+	currentStep  string
+	retries      int
+	warnings     []string
+	records      []StepRecord
 }
 
 func NewStepLogger(silent bool) *StepLogger {
 	return &StepLogger{
 		silent: silent,
+		out:    os.Stdout,
 	}
 }
 
+// SetCompact switches the logger to compact mode: one plain line per
+// completed top-level step instead of in-place progress, and no substep
+// output. Suitable for CI logs and other non-interactive terminals.
+func (b *StepLogger) SetCompact(compact bool) {
+	b.compact = compact
+}
+
 func (b *StepLogger) Step(name string, fn func() error) error {
 	if b.silent {
-		return fn()
+		return b.record(name, fn)
 	}
 
-	b.subSteps = 0
+	if b.compact {
+		err := b.record(name, fn)
+		last := b.records[len(b.records)-1]
+		if err != nil {
+			fmt.Fprintf(b.out, "%s%s failed: %s%s\n", RED_TEXT, name, redact.Redact(err.Error()), RESET_TEXT)
+		} else {
+			fmt.Fprintf(b.out, "%s %s✓%s (%s)\n", name, GREEN_TEXT, RESET_TEXT, last.Duration.Round(100*time.Millisecond))
+		}
+		return err
+	}
+
+	b.pendingLines = 0
 	b.currentStep = name
 
-	fmt.Printf("%s%s%s...", LINE_RESET, RESET_TEXT, name)
-	err := fn()
+	fmt.Fprintf(b.out, "%s%s%s...", LINE_RESET, RESET_TEXT, name)
+	err := b.record(name, fn)
 	if err != nil {
-		fmt.Printf("%s%s%s failed: %v%s\n", LINE_RESET, RED_TEXT, name, err, RESET_TEXT)
+		fmt.Fprintf(b.out, "%s%s%s failed: %s%s\n", LINE_RESET, RED_TEXT, name, redact.Redact(err.Error()), RESET_TEXT)
 	} else {
-		for i := 0; i < b.subSteps; i++ {
-			fmt.Printf("%s", MOVE_UP_CLEAR_LINE)
+		for i := 0; i < b.pendingLines; i++ {
+			fmt.Fprintf(b.out, "%s", MOVE_UP_CLEAR_LINE)
+		}
+		fmt.Fprintf(b.out, "%s%s%s %s✓%s\n", LINE_RESET, RESET_TEXT, name, GREEN_TEXT, RESET_TEXT)
+		// Warnings must stay visible after the substep lines are cleared.
+		for _, warning := range b.records[len(b.records)-1].Warnings {
+			fmt.Fprintf(b.out, "%s   %sWarning: %s%s\n", LINE_RESET, YELLOW_TEXT, warning, RESET_TEXT)
 		}
-		fmt.Printf("%s%s%s %s✓%s\n", LINE_RESET, RESET_TEXT, name, GREEN_TEXT, RESET_TEXT)
 	}
 	return err
 }
 
+// Substep runs fn as a substep of the currently running step. Substeps nest:
+// a substep started from another substep's body is indented one level
+// further. All substep lines are cleared when the top-level step succeeds.
 func (b *StepLogger) Substep(name string, fn func() error) error {
-	if b.silent {
+	if b.silent || b.compact {
 		return fn()
 	}
 
-	b.subSteps += 1
-	b.currentStep = name
+	b.pendingLines += 1
+	indent := b.indent(1)
 
-	fmt.Printf("%s%s   %s...", LINE_RESET, RESET_TEXT, name)
+	fmt.Fprintf(b.out, "%s%s%s%s...", LINE_RESET, RESET_TEXT, indent, name)
+	previousStep := b.currentStep
+	b.currentStep = name
+	b.depth += 1
 	err := fn()
+	b.depth -= 1
+	b.currentStep = previousStep
 	if err != nil {
-		fmt.Printf("%s%s   %s failed: %v%s\n", LINE_RESET, RED_TEXT, name, err, RESET_TEXT)
+		fmt.Fprintf(b.out, "%s%s%s%s failed: %s%s\n", LINE_RESET, RED_TEXT, indent, name, redact.Redact(err.Error()), RESET_TEXT)
 	} else {
-		fmt.Printf("%s%s   %s %s✓%s\n", LINE_RESET, RESET_TEXT, name, GREEN_TEXT, RESET_TEXT)
+		fmt.Fprintf(b.out, "%s%s%s%s %s✓%s\n", LINE_RESET, RESET_TEXT, indent, name, GREEN_TEXT, RESET_TEXT)
 	}
 	return err
 }
 
+// ConcurrentStep names one branch of a concurrent step group. The body
+// receives the branch's own logger and must use it for substeps and
+// messages instead of the parent.
+type ConcurrentStep struct {
+	Name string
+	Run  func(stlog *StepLogger) error
+}
+
+// Concurrent runs the given steps in parallel, each logging into its own
+// buffered branch. A branch's output is flushed in declaration order once it
+// and all branches before it have finished, so concurrent steps never
+// interleave their lines. Step records are merged in the same order; the
+// returned error joins all branch errors.
+func (b *StepLogger) Concurrent(steps ...ConcurrentStep) error {
+	buffers := make([]*bytes.Buffer, len(steps))
+	branches := make([]*StepLogger, len(steps))
+	done := make([]chan error, len(steps))
+	for i := range steps {
+		step := steps[i]
+		buffers[i] = &bytes.Buffer{}
+		branch := NewStepLogger(b.silent)
+		branch.compact = b.compact
+		branch.out = buffers[i]
+		branches[i] = branch
+		done[i] = make(chan error, 1)
+		go func() {
+			done[i] <- branch.Step(step.Name, func() error { return step.Run(branch) })
+		}()
+	}
+
+	var errs []error
+	for i := range steps {
+		if err := <-done[i]; err != nil {
+			errs = append(errs, err)
+		}
+		if !b.silent {
+			fmt.Fprint(b.out, buffers[i].String())
+		}
+		b.records = append(b.records, branches[i].records...)
+	}
+	return errors.Join(errs...)
+}
+
+// record runs a step function and captures its duration, retry count,
+// warnings and outcome for the summary report.
+func (b *StepLogger) record(name string, fn func() error) error {
+	b.retries = 0
+	b.warnings = nil
+	start := time.Now()
+	err := fn()
+	duration := time.Since(start)
+
+	stepRecord := StepRecord{
+		Name:            name,
+		Duration:        duration,
+		DurationSeconds: duration.Seconds(),
+		Retries:         b.retries,
+		Warnings:        b.warnings,
+	}
+	if err != nil {
+		stepRecord.Failed = true
+		stepRecord.Error = redact.Redact(err.Error())
+	}
+	b.records = append(b.records, stepRecord)
+
+	return err
+}
+
+// Records returns the captured step outcomes in execution order.
+func (b *StepLogger) Records() []StepRecord {
+	return b.records
+}
+
 // LogRetry prints a retry message for the current step.
 func (b *StepLogger) LogRetry() {
-	if b.subSteps > 0 {
-		fmt.Printf("%s%s   Retrying: %s...%s", LINE_RESET, RESET_TEXT, b.currentStep, RESET_TEXT)
-	} else {
-		fmt.Printf("%s%sRetrying: %s...%s", LINE_RESET, RESET_TEXT, b.currentStep, RESET_TEXT)
+	b.retries++
+	if b.silent || b.compact {
+		return
 	}
+	fmt.Fprintf(b.out, "%s%s%sRetrying: %s...%s", LINE_RESET, RESET_TEXT, b.indent(0), b.currentStep, RESET_TEXT)
 }
 
 // Logf prints a log message for the current step.
 func (b *StepLogger) Logf(message string, args ...interface{}) {
+	if b.silent || b.compact {
+		return
+	}
+
+	b.pendingLines += 1
+	fmt.Fprintf(b.out, "%s%s%s%s%s\n", LINE_RESET, RESET_TEXT, b.indent(2), redact.Redact(fmt.Sprintf(message, args...)), RESET_TEXT)
+}
+
+// Warnf prints a warning for the current step. Unlike substep and log lines,
+// warnings are re-printed after the step's success line, so they stay
+// visible once the step completes.
+func (b *StepLogger) Warnf(message string, args ...interface{}) {
+	warning := redact.Redact(fmt.Sprintf(message, args...))
+	b.warnings = append(b.warnings, warning)
 	if b.silent {
 		return
 	}
 
-	b.subSteps += 1
-	fmt.Printf("%s%s      %s%s\n", LINE_RESET, RESET_TEXT, fmt.Sprintf(message, args...), RESET_TEXT)
+	if b.compact {
+		fmt.Fprintf(b.out, "%sWarning: %s%s\n", YELLOW_TEXT, warning, RESET_TEXT)
+		return
+	}
+	b.pendingLines += 1
+	fmt.Fprintf(b.out, "%s%s%sWarning: %s%s\n", LINE_RESET, YELLOW_TEXT, b.indent(1), warning, RESET_TEXT)
+}
+
+// indent returns the indentation for a line printed extra levels below the
+// currently running step body.
+func (b *StepLogger) indent(extra int) string {
+	return strings.Repeat("   ", b.depth+extra)
 }
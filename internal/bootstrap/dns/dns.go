@@ -0,0 +1,101 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dns abstracts the DNS providers the bootstrapper can manage zones
+// and records with, so GCP compute can be combined with external DNS.
+package dns
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Provider names selectable via --dns-provider.
+const (
+	ProviderCloudDNS = "clouddns"
+	ProviderRoute53  = "route53"
+)
+
+// ManagedZoneComment marks zones created by OMS, so teardown can tell them
+// apart from pre-existing zones it must not delete.
+const ManagedZoneComment = "Codesphere DNS zone"
+
+// Record is a provider-independent DNS record set. Names are fully qualified
+// including the trailing dot.
+type Record struct {
+	Name    string
+	Type    string
+	TTL     int64
+	Rrdatas []string
+}
+
+// DNSProvider manages the DNS zone and records of a Codesphere environment.
+//
+//mockery:generate: true
+type DNSProvider interface {
+	// EnsureZone ensures a managed zone named zoneName for dnsName exists.
+	EnsureZone(zoneName, dnsName string) error
+	// EnsureRecords creates or updates the given record sets in the zone for dnsName.
+	EnsureRecords(zoneName, dnsName string, records []Record) error
+	// DeleteRecords deletes the OMS-managed records for baseDomain from the zone.
+	DeleteRecords(zoneName, baseDomain string) error
+	// LookupRecord returns the record set with the given name and type from
+	// the zone for baseDomain, or nil when it does not exist.
+	LookupRecord(zoneName, baseDomain, name, rtype string) (*Record, error)
+	// DeleteZone deletes the zone for baseDomain. Zones not created by OMS
+	// are refused.
+	DeleteZone(zoneName, baseDomain string) error
+}
+
+// ManagedRecordNames returns the names and types of the records OMS creates
+// for a given base domain, used when deleting records.
+func ManagedRecordNames(baseDomain string) []Record {
+	prefixes := []string{"cs.", "*.cs.", "ws.", "*.ws.", "*.ssh.cs."}
+
+	records := make([]Record, len(prefixes))
+	for i, prefix := range prefixes {
+		records[i] = Record{
+			Name: fmt.Sprintf("%s%s.", prefix, baseDomain),
+			Type: "A",
+		}
+	}
+	return append(records, Record{Name: OwnershipRecordName(baseDomain), Type: "TXT"})
+}
+
+// OwnershipRecordName returns the name of the TXT record annotating the
+// managed records for baseDomain with their owning environment.
+func OwnershipRecordName(baseDomain string) string {
+	return fmt.Sprintf("_oms-owner.cs.%s.", baseDomain)
+}
+
+// OwnershipRecord returns the TXT record marking the managed records for
+// baseDomain as owned by the given environment, so teardown only garbage
+// collects records it owns.
+func OwnershipRecord(baseDomain, owner string, ttl int64) Record {
+	return Record{
+		Name:    OwnershipRecordName(baseDomain),
+		Type:    "TXT",
+		TTL:     ttl,
+		Rrdatas: []string{fmt.Sprintf("%q", "heritage=oms,owner="+owner)},
+	}
+}
+
+// OwnerFromRecord extracts the owner from an ownership TXT record. It returns
+// an empty string for a nil record or one without an OMS ownership annotation.
+func OwnerFromRecord(record *Record) string {
+	if record == nil {
+		return ""
+	}
+	for _, data := range record.Rrdatas {
+		value := strings.Trim(data, `"`)
+		if !strings.Contains(value, "heritage=oms") {
+			continue
+		}
+		for _, part := range strings.Split(value, ",") {
+			if owner, found := strings.CutPrefix(part, "owner="); found {
+				return owner
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,877 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package dns
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDNSProvider creates a new instance of MockDNSProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDNSProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDNSProvider {
+	mock := &MockDNSProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDNSProvider is an autogenerated mock type for the DNSProvider type
+type MockDNSProvider struct {
+	mock.Mock
+}
+
+type MockDNSProvider_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDNSProvider) EXPECT() *MockDNSProvider_Expecter {
+	return &MockDNSProvider_Expecter{mock: &_m.Mock}
+}
+
+// DeleteRecords provides a mock function for the type MockDNSProvider
+func (_mock *MockDNSProvider) DeleteRecords(zoneName string, baseDomain string) error {
+	ret := _mock.Called(zoneName, baseDomain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRecords")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = returnFunc(zoneName, baseDomain)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDNSProvider_DeleteRecords_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRecords'
+type MockDNSProvider_DeleteRecords_Call struct {
+	*mock.Call
+}
+
+// DeleteRecords is a helper method to define mock.On call
+//   - zoneName string
+//   - baseDomain string
+func (_e *MockDNSProvider_Expecter) DeleteRecords(zoneName any, baseDomain any) *MockDNSProvider_DeleteRecords_Call {
+	return &MockDNSProvider_DeleteRecords_Call{Call: _e.mock.On("DeleteRecords", zoneName, baseDomain)}
+}
+
+func (_c *MockDNSProvider_DeleteRecords_Call) Run(run func(zoneName string, baseDomain string)) *MockDNSProvider_DeleteRecords_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDNSProvider_DeleteRecords_Call) Return(err error) *MockDNSProvider_DeleteRecords_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDNSProvider_DeleteRecords_Call) RunAndReturn(run func(zoneName string, baseDomain string) error) *MockDNSProvider_DeleteRecords_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteZone provides a mock function for the type MockDNSProvider
+func (_mock *MockDNSProvider) DeleteZone(zoneName string, baseDomain string) error {
+	ret := _mock.Called(zoneName, baseDomain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteZone")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = returnFunc(zoneName, baseDomain)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDNSProvider_DeleteZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteZone'
+type MockDNSProvider_DeleteZone_Call struct {
+	*mock.Call
+}
+
+// DeleteZone is a helper method to define mock.On call
+//   - zoneName string
+//   - baseDomain string
+func (_e *MockDNSProvider_Expecter) DeleteZone(zoneName any, baseDomain any) *MockDNSProvider_DeleteZone_Call {
+	return &MockDNSProvider_DeleteZone_Call{Call: _e.mock.On("DeleteZone", zoneName, baseDomain)}
+}
+
+func (_c *MockDNSProvider_DeleteZone_Call) Run(run func(zoneName string, baseDomain string)) *MockDNSProvider_DeleteZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDNSProvider_DeleteZone_Call) Return(err error) *MockDNSProvider_DeleteZone_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDNSProvider_DeleteZone_Call) RunAndReturn(run func(zoneName string, baseDomain string) error) *MockDNSProvider_DeleteZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnsureRecords provides a mock function for the type MockDNSProvider
+func (_mock *MockDNSProvider) EnsureRecords(zoneName string, dnsName string, records []Record) error {
+	ret := _mock.Called(zoneName, dnsName, records)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureRecords")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, []Record) error); ok {
+		r0 = returnFunc(zoneName, dnsName, records)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDNSProvider_EnsureRecords_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureRecords'
+type MockDNSProvider_EnsureRecords_Call struct {
+	*mock.Call
+}
+
+// EnsureRecords is a helper method to define mock.On call
+//   - zoneName string
+//   - dnsName string
+//   - records []Record
+func (_e *MockDNSProvider_Expecter) EnsureRecords(zoneName any, dnsName any, records any) *MockDNSProvider_EnsureRecords_Call {
+	return &MockDNSProvider_EnsureRecords_Call{Call: _e.mock.On("EnsureRecords", zoneName, dnsName, records)}
+}
+
+func (_c *MockDNSProvider_EnsureRecords_Call) Run(run func(zoneName string, dnsName string, records []Record)) *MockDNSProvider_EnsureRecords_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []Record
+		if args[2] != nil {
+			arg2 = args[2].([]Record)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDNSProvider_EnsureRecords_Call) Return(err error) *MockDNSProvider_EnsureRecords_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDNSProvider_EnsureRecords_Call) RunAndReturn(run func(zoneName string, dnsName string, records []Record) error) *MockDNSProvider_EnsureRecords_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnsureZone provides a mock function for the type MockDNSProvider
+func (_mock *MockDNSProvider) EnsureZone(zoneName string, dnsName string) error {
+	ret := _mock.Called(zoneName, dnsName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureZone")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = returnFunc(zoneName, dnsName)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDNSProvider_EnsureZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureZone'
+type MockDNSProvider_EnsureZone_Call struct {
+	*mock.Call
+}
+
+// EnsureZone is a helper method to define mock.On call
+//   - zoneName string
+//   - dnsName string
+func (_e *MockDNSProvider_Expecter) EnsureZone(zoneName any, dnsName any) *MockDNSProvider_EnsureZone_Call {
+	return &MockDNSProvider_EnsureZone_Call{Call: _e.mock.On("EnsureZone", zoneName, dnsName)}
+}
+
+func (_c *MockDNSProvider_EnsureZone_Call) Run(run func(zoneName string, dnsName string)) *MockDNSProvider_EnsureZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDNSProvider_EnsureZone_Call) Return(err error) *MockDNSProvider_EnsureZone_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDNSProvider_EnsureZone_Call) RunAndReturn(run func(zoneName string, dnsName string) error) *MockDNSProvider_EnsureZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LookupRecord provides a mock function for the type MockDNSProvider
+func (_mock *MockDNSProvider) LookupRecord(zoneName string, baseDomain string, name string, rtype string) (*Record, error) {
+	ret := _mock.Called(zoneName, baseDomain, name, rtype)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LookupRecord")
+	}
+
+	var r0 *Record
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, string) (*Record, error)); ok {
+		return returnFunc(zoneName, baseDomain, name, rtype)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, string) *Record); ok {
+		r0 = returnFunc(zoneName, baseDomain, name, rtype)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Record)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, string, string) error); ok {
+		r1 = returnFunc(zoneName, baseDomain, name, rtype)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDNSProvider_LookupRecord_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LookupRecord'
+type MockDNSProvider_LookupRecord_Call struct {
+	*mock.Call
+}
+
+// LookupRecord is a helper method to define mock.On call
+//   - zoneName string
+//   - baseDomain string
+//   - name string
+//   - rtype string
+func (_e *MockDNSProvider_Expecter) LookupRecord(zoneName any, baseDomain any, name any, rtype any) *MockDNSProvider_LookupRecord_Call {
+	return &MockDNSProvider_LookupRecord_Call{Call: _e.mock.On("LookupRecord", zoneName, baseDomain, name, rtype)}
+}
+
+func (_c *MockDNSProvider_LookupRecord_Call) Run(run func(zoneName string, baseDomain string, name string, rtype string)) *MockDNSProvider_LookupRecord_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDNSProvider_LookupRecord_Call) Return(record *Record, err error) *MockDNSProvider_LookupRecord_Call {
+	_c.Call.Return(record, err)
+	return _c
+}
+
+func (_c *MockDNSProvider_LookupRecord_Call) RunAndReturn(run func(zoneName string, baseDomain string, name string, rtype string) (*Record, error)) *MockDNSProvider_LookupRecord_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRoute53API creates a new instance of MockRoute53API. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRoute53API(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRoute53API {
+	mock := &MockRoute53API{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRoute53API is an autogenerated mock type for the Route53API type
+type MockRoute53API struct {
+	mock.Mock
+}
+
+type MockRoute53API_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRoute53API) EXPECT() *MockRoute53API_Expecter {
+	return &MockRoute53API_Expecter{mock: &_m.Mock}
+}
+
+// ChangeResourceRecordSets provides a mock function for the type MockRoute53API
+func (_mock *MockRoute53API) ChangeResourceRecordSets(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+	var tmpRet mock.Arguments
+	if len(optFns) > 0 {
+		tmpRet = _mock.Called(ctx, params, optFns)
+	} else {
+		tmpRet = _mock.Called(ctx, params)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeResourceRecordSets")
+	}
+
+	var r0 *route53.ChangeResourceRecordSetsOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *route53.ChangeResourceRecordSetsInput, ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error)); ok {
+		return returnFunc(ctx, params, optFns...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *route53.ChangeResourceRecordSetsInput, ...func(*route53.Options)) *route53.ChangeResourceRecordSetsOutput); ok {
+		r0 = returnFunc(ctx, params, optFns...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*route53.ChangeResourceRecordSetsOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *route53.ChangeResourceRecordSetsInput, ...func(*route53.Options)) error); ok {
+		r1 = returnFunc(ctx, params, optFns...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRoute53API_ChangeResourceRecordSets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeResourceRecordSets'
+type MockRoute53API_ChangeResourceRecordSets_Call struct {
+	*mock.Call
+}
+
+// ChangeResourceRecordSets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params *route53.ChangeResourceRecordSetsInput
+//   - optFns ...func(*route53.Options)
+func (_e *MockRoute53API_Expecter) ChangeResourceRecordSets(ctx any, params any, optFns ...any) *MockRoute53API_ChangeResourceRecordSets_Call {
+	return &MockRoute53API_ChangeResourceRecordSets_Call{Call: _e.mock.On("ChangeResourceRecordSets",
+		append([]any{ctx, params}, optFns...)...)}
+}
+
+func (_c *MockRoute53API_ChangeResourceRecordSets_Call) Run(run func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options))) *MockRoute53API_ChangeResourceRecordSets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *route53.ChangeResourceRecordSetsInput
+		if args[1] != nil {
+			arg1 = args[1].(*route53.ChangeResourceRecordSetsInput)
+		}
+		var arg2 []func(*route53.Options)
+		var variadicArgs []func(*route53.Options)
+		if len(args) > 2 {
+			variadicArgs = args[2].([]func(*route53.Options))
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRoute53API_ChangeResourceRecordSets_Call) Return(changeResourceRecordSetsOutput *route53.ChangeResourceRecordSetsOutput, err error) *MockRoute53API_ChangeResourceRecordSets_Call {
+	_c.Call.Return(changeResourceRecordSetsOutput, err)
+	return _c
+}
+
+func (_c *MockRoute53API_ChangeResourceRecordSets_Call) RunAndReturn(run func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error)) *MockRoute53API_ChangeResourceRecordSets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateHostedZone provides a mock function for the type MockRoute53API
+func (_mock *MockRoute53API) CreateHostedZone(ctx context.Context, params *route53.CreateHostedZoneInput, optFns ...func(*route53.Options)) (*route53.CreateHostedZoneOutput, error) {
+	var tmpRet mock.Arguments
+	if len(optFns) > 0 {
+		tmpRet = _mock.Called(ctx, params, optFns)
+	} else {
+		tmpRet = _mock.Called(ctx, params)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateHostedZone")
+	}
+
+	var r0 *route53.CreateHostedZoneOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *route53.CreateHostedZoneInput, ...func(*route53.Options)) (*route53.CreateHostedZoneOutput, error)); ok {
+		return returnFunc(ctx, params, optFns...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *route53.CreateHostedZoneInput, ...func(*route53.Options)) *route53.CreateHostedZoneOutput); ok {
+		r0 = returnFunc(ctx, params, optFns...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*route53.CreateHostedZoneOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *route53.CreateHostedZoneInput, ...func(*route53.Options)) error); ok {
+		r1 = returnFunc(ctx, params, optFns...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRoute53API_CreateHostedZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateHostedZone'
+type MockRoute53API_CreateHostedZone_Call struct {
+	*mock.Call
+}
+
+// CreateHostedZone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params *route53.CreateHostedZoneInput
+//   - optFns ...func(*route53.Options)
+func (_e *MockRoute53API_Expecter) CreateHostedZone(ctx any, params any, optFns ...any) *MockRoute53API_CreateHostedZone_Call {
+	return &MockRoute53API_CreateHostedZone_Call{Call: _e.mock.On("CreateHostedZone",
+		append([]any{ctx, params}, optFns...)...)}
+}
+
+func (_c *MockRoute53API_CreateHostedZone_Call) Run(run func(ctx context.Context, params *route53.CreateHostedZoneInput, optFns ...func(*route53.Options))) *MockRoute53API_CreateHostedZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *route53.CreateHostedZoneInput
+		if args[1] != nil {
+			arg1 = args[1].(*route53.CreateHostedZoneInput)
+		}
+		var arg2 []func(*route53.Options)
+		var variadicArgs []func(*route53.Options)
+		if len(args) > 2 {
+			variadicArgs = args[2].([]func(*route53.Options))
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRoute53API_CreateHostedZone_Call) Return(createHostedZoneOutput *route53.CreateHostedZoneOutput, err error) *MockRoute53API_CreateHostedZone_Call {
+	_c.Call.Return(createHostedZoneOutput, err)
+	return _c
+}
+
+func (_c *MockRoute53API_CreateHostedZone_Call) RunAndReturn(run func(ctx context.Context, params *route53.CreateHostedZoneInput, optFns ...func(*route53.Options)) (*route53.CreateHostedZoneOutput, error)) *MockRoute53API_CreateHostedZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteHostedZone provides a mock function for the type MockRoute53API
+func (_mock *MockRoute53API) DeleteHostedZone(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error) {
+	var tmpRet mock.Arguments
+	if len(optFns) > 0 {
+		tmpRet = _mock.Called(ctx, params, optFns)
+	} else {
+		tmpRet = _mock.Called(ctx, params)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteHostedZone")
+	}
+
+	var r0 *route53.DeleteHostedZoneOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *route53.DeleteHostedZoneInput, ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error)); ok {
+		return returnFunc(ctx, params, optFns...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *route53.DeleteHostedZoneInput, ...func(*route53.Options)) *route53.DeleteHostedZoneOutput); ok {
+		r0 = returnFunc(ctx, params, optFns...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*route53.DeleteHostedZoneOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *route53.DeleteHostedZoneInput, ...func(*route53.Options)) error); ok {
+		r1 = returnFunc(ctx, params, optFns...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRoute53API_DeleteHostedZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteHostedZone'
+type MockRoute53API_DeleteHostedZone_Call struct {
+	*mock.Call
+}
+
+// DeleteHostedZone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params *route53.DeleteHostedZoneInput
+//   - optFns ...func(*route53.Options)
+func (_e *MockRoute53API_Expecter) DeleteHostedZone(ctx any, params any, optFns ...any) *MockRoute53API_DeleteHostedZone_Call {
+	return &MockRoute53API_DeleteHostedZone_Call{Call: _e.mock.On("DeleteHostedZone",
+		append([]any{ctx, params}, optFns...)...)}
+}
+
+func (_c *MockRoute53API_DeleteHostedZone_Call) Run(run func(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options))) *MockRoute53API_DeleteHostedZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *route53.DeleteHostedZoneInput
+		if args[1] != nil {
+			arg1 = args[1].(*route53.DeleteHostedZoneInput)
+		}
+		var arg2 []func(*route53.Options)
+		var variadicArgs []func(*route53.Options)
+		if len(args) > 2 {
+			variadicArgs = args[2].([]func(*route53.Options))
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRoute53API_DeleteHostedZone_Call) Return(deleteHostedZoneOutput *route53.DeleteHostedZoneOutput, err error) *MockRoute53API_DeleteHostedZone_Call {
+	_c.Call.Return(deleteHostedZoneOutput, err)
+	return _c
+}
+
+func (_c *MockRoute53API_DeleteHostedZone_Call) RunAndReturn(run func(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error)) *MockRoute53API_DeleteHostedZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHostedZone provides a mock function for the type MockRoute53API
+func (_mock *MockRoute53API) GetHostedZone(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
+	var tmpRet mock.Arguments
+	if len(optFns) > 0 {
+		tmpRet = _mock.Called(ctx, params, optFns)
+	} else {
+		tmpRet = _mock.Called(ctx, params)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHostedZone")
+	}
+
+	var r0 *route53.GetHostedZoneOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *route53.GetHostedZoneInput, ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error)); ok {
+		return returnFunc(ctx, params, optFns...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *route53.GetHostedZoneInput, ...func(*route53.Options)) *route53.GetHostedZoneOutput); ok {
+		r0 = returnFunc(ctx, params, optFns...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*route53.GetHostedZoneOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *route53.GetHostedZoneInput, ...func(*route53.Options)) error); ok {
+		r1 = returnFunc(ctx, params, optFns...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRoute53API_GetHostedZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHostedZone'
+type MockRoute53API_GetHostedZone_Call struct {
+	*mock.Call
+}
+
+// GetHostedZone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params *route53.GetHostedZoneInput
+//   - optFns ...func(*route53.Options)
+func (_e *MockRoute53API_Expecter) GetHostedZone(ctx any, params any, optFns ...any) *MockRoute53API_GetHostedZone_Call {
+	return &MockRoute53API_GetHostedZone_Call{Call: _e.mock.On("GetHostedZone",
+		append([]any{ctx, params}, optFns...)...)}
+}
+
+func (_c *MockRoute53API_GetHostedZone_Call) Run(run func(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options))) *MockRoute53API_GetHostedZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *route53.GetHostedZoneInput
+		if args[1] != nil {
+			arg1 = args[1].(*route53.GetHostedZoneInput)
+		}
+		var arg2 []func(*route53.Options)
+		var variadicArgs []func(*route53.Options)
+		if len(args) > 2 {
+			variadicArgs = args[2].([]func(*route53.Options))
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRoute53API_GetHostedZone_Call) Return(getHostedZoneOutput *route53.GetHostedZoneOutput, err error) *MockRoute53API_GetHostedZone_Call {
+	_c.Call.Return(getHostedZoneOutput, err)
+	return _c
+}
+
+func (_c *MockRoute53API_GetHostedZone_Call) RunAndReturn(run func(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error)) *MockRoute53API_GetHostedZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListHostedZonesByName provides a mock function for the type MockRoute53API
+func (_mock *MockRoute53API) ListHostedZonesByName(ctx context.Context, params *route53.ListHostedZonesByNameInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesByNameOutput, error) {
+	var tmpRet mock.Arguments
+	if len(optFns) > 0 {
+		tmpRet = _mock.Called(ctx, params, optFns)
+	} else {
+		tmpRet = _mock.Called(ctx, params)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListHostedZonesByName")
+	}
+
+	var r0 *route53.ListHostedZonesByNameOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *route53.ListHostedZonesByNameInput, ...func(*route53.Options)) (*route53.ListHostedZonesByNameOutput, error)); ok {
+		return returnFunc(ctx, params, optFns...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *route53.ListHostedZonesByNameInput, ...func(*route53.Options)) *route53.ListHostedZonesByNameOutput); ok {
+		r0 = returnFunc(ctx, params, optFns...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*route53.ListHostedZonesByNameOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *route53.ListHostedZonesByNameInput, ...func(*route53.Options)) error); ok {
+		r1 = returnFunc(ctx, params, optFns...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRoute53API_ListHostedZonesByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListHostedZonesByName'
+type MockRoute53API_ListHostedZonesByName_Call struct {
+	*mock.Call
+}
+
+// ListHostedZonesByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params *route53.ListHostedZonesByNameInput
+//   - optFns ...func(*route53.Options)
+func (_e *MockRoute53API_Expecter) ListHostedZonesByName(ctx any, params any, optFns ...any) *MockRoute53API_ListHostedZonesByName_Call {
+	return &MockRoute53API_ListHostedZonesByName_Call{Call: _e.mock.On("ListHostedZonesByName",
+		append([]any{ctx, params}, optFns...)...)}
+}
+
+func (_c *MockRoute53API_ListHostedZonesByName_Call) Run(run func(ctx context.Context, params *route53.ListHostedZonesByNameInput, optFns ...func(*route53.Options))) *MockRoute53API_ListHostedZonesByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *route53.ListHostedZonesByNameInput
+		if args[1] != nil {
+			arg1 = args[1].(*route53.ListHostedZonesByNameInput)
+		}
+		var arg2 []func(*route53.Options)
+		var variadicArgs []func(*route53.Options)
+		if len(args) > 2 {
+			variadicArgs = args[2].([]func(*route53.Options))
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRoute53API_ListHostedZonesByName_Call) Return(listHostedZonesByNameOutput *route53.ListHostedZonesByNameOutput, err error) *MockRoute53API_ListHostedZonesByName_Call {
+	_c.Call.Return(listHostedZonesByNameOutput, err)
+	return _c
+}
+
+func (_c *MockRoute53API_ListHostedZonesByName_Call) RunAndReturn(run func(ctx context.Context, params *route53.ListHostedZonesByNameInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesByNameOutput, error)) *MockRoute53API_ListHostedZonesByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListResourceRecordSets provides a mock function for the type MockRoute53API
+func (_mock *MockRoute53API) ListResourceRecordSets(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+	var tmpRet mock.Arguments
+	if len(optFns) > 0 {
+		tmpRet = _mock.Called(ctx, params, optFns)
+	} else {
+		tmpRet = _mock.Called(ctx, params)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListResourceRecordSets")
+	}
+
+	var r0 *route53.ListResourceRecordSetsOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *route53.ListResourceRecordSetsInput, ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error)); ok {
+		return returnFunc(ctx, params, optFns...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *route53.ListResourceRecordSetsInput, ...func(*route53.Options)) *route53.ListResourceRecordSetsOutput); ok {
+		r0 = returnFunc(ctx, params, optFns...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*route53.ListResourceRecordSetsOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *route53.ListResourceRecordSetsInput, ...func(*route53.Options)) error); ok {
+		r1 = returnFunc(ctx, params, optFns...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRoute53API_ListResourceRecordSets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListResourceRecordSets'
+type MockRoute53API_ListResourceRecordSets_Call struct {
+	*mock.Call
+}
+
+// ListResourceRecordSets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params *route53.ListResourceRecordSetsInput
+//   - optFns ...func(*route53.Options)
+func (_e *MockRoute53API_Expecter) ListResourceRecordSets(ctx any, params any, optFns ...any) *MockRoute53API_ListResourceRecordSets_Call {
+	return &MockRoute53API_ListResourceRecordSets_Call{Call: _e.mock.On("ListResourceRecordSets",
+		append([]any{ctx, params}, optFns...)...)}
+}
+
+func (_c *MockRoute53API_ListResourceRecordSets_Call) Run(run func(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options))) *MockRoute53API_ListResourceRecordSets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *route53.ListResourceRecordSetsInput
+		if args[1] != nil {
+			arg1 = args[1].(*route53.ListResourceRecordSetsInput)
+		}
+		var arg2 []func(*route53.Options)
+		var variadicArgs []func(*route53.Options)
+		if len(args) > 2 {
+			variadicArgs = args[2].([]func(*route53.Options))
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRoute53API_ListResourceRecordSets_Call) Return(listResourceRecordSetsOutput *route53.ListResourceRecordSetsOutput, err error) *MockRoute53API_ListResourceRecordSets_Call {
+	_c.Call.Return(listResourceRecordSetsOutput, err)
+	return _c
+}
+
+func (_c *MockRoute53API_ListResourceRecordSets_Call) RunAndReturn(run func(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error)) *MockRoute53API_ListResourceRecordSets_Call {
+	_c.Call.Return(run)
+	return _c
+}
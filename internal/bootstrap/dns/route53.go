@@ -0,0 +1,253 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+)
+
+// Route53API is the subset of the Route 53 client used by the provider.
+//
+//mockery:generate: true
+type Route53API interface {
+	ListHostedZonesByName(ctx context.Context, params *route53.ListHostedZonesByNameInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesByNameOutput, error)
+	CreateHostedZone(ctx context.Context, params *route53.CreateHostedZoneInput, optFns ...func(*route53.Options)) (*route53.CreateHostedZoneOutput, error)
+	ChangeResourceRecordSets(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error)
+	ListResourceRecordSets(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error)
+	GetHostedZone(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error)
+	DeleteHostedZone(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error)
+}
+
+// Route53Provider implements DNSProvider backed by AWS Route 53. The zone
+// name is only used as the caller reference; Route 53 identifies zones by
+// their DNS name.
+type Route53Provider struct {
+	ctx    context.Context
+	Client Route53API
+}
+
+// NewRoute53Provider creates a Route53Provider using the default AWS
+// credential chain.
+func NewRoute53Provider(ctx context.Context) (*Route53Provider, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return &Route53Provider{ctx: ctx, Client: route53.NewFromConfig(cfg)}, nil
+}
+
+// NewRoute53ProviderWithClient creates a Route53Provider with the given client.
+func NewRoute53ProviderWithClient(ctx context.Context, client Route53API) *Route53Provider {
+	return &Route53Provider{ctx: ctx, Client: client}
+}
+
+// hostedZoneID returns the ID of the hosted zone for dnsName, or an empty
+// string when no such zone exists.
+func (p *Route53Provider) hostedZoneID(dnsName string) (string, error) {
+	out, err := p.Client.ListHostedZonesByName(p.ctx, &route53.ListHostedZonesByNameInput{
+		DNSName:  aws.String(dnsName),
+		MaxItems: aws.Int32(1),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list hosted zones: %w", err)
+	}
+
+	if len(out.HostedZones) == 0 || aws.ToString(out.HostedZones[0].Name) != dnsName {
+		return "", nil
+	}
+	return aws.ToString(out.HostedZones[0].Id), nil
+}
+
+// EnsureZone creates the hosted zone for dnsName if it does not exist yet.
+func (p *Route53Provider) EnsureZone(zoneName, dnsName string) error {
+	zoneID, err := p.hostedZoneID(dnsName)
+	if err != nil {
+		return err
+	}
+	if zoneID != "" {
+		return nil
+	}
+
+	_, err = p.Client.CreateHostedZone(p.ctx, &route53.CreateHostedZoneInput{
+		// Reusing the zone name as caller reference makes retries idempotent.
+		CallerReference: aws.String(fmt.Sprintf("%s-%s", zoneName, dnsName)),
+		Name:            aws.String(dnsName),
+		HostedZoneConfig: &types.HostedZoneConfig{
+			Comment: aws.String(ManagedZoneComment),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create hosted zone for %s: %w", dnsName, err)
+	}
+	return nil
+}
+
+// EnsureRecords upserts the given record sets in the hosted zone for dnsName.
+func (p *Route53Provider) EnsureRecords(zoneName, dnsName string, records []Record) error {
+	zoneID, err := p.hostedZoneID(dnsName)
+	if err != nil {
+		return err
+	}
+	if zoneID == "" {
+		return fmt.Errorf("no hosted zone found for %s", dnsName)
+	}
+
+	changes := make([]types.Change, len(records))
+	for i, record := range records {
+		changes[i] = types.Change{
+			Action:            types.ChangeActionUpsert,
+			ResourceRecordSet: toRoute53RecordSet(record),
+		}
+	}
+
+	_, err = p.Client.ChangeResourceRecordSets(p.ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+		ChangeBatch:  &types.ChangeBatch{Changes: changes},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upsert record sets in zone %s: %w", dnsName, err)
+	}
+	return nil
+}
+
+// DeleteRecords deletes the OMS-managed records for baseDomain from its
+// hosted zone. Records that no longer exist are skipped.
+func (p *Route53Provider) DeleteRecords(zoneName, baseDomain string) error {
+	dnsName := baseDomain + "."
+	zoneID, err := p.hostedZoneID(dnsName)
+	if err != nil {
+		return err
+	}
+	if zoneID == "" {
+		return nil
+	}
+
+	existing, err := p.Client.ListResourceRecordSets(p.ctx, &route53.ListResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list record sets in zone %s: %w", dnsName, err)
+	}
+
+	var changes []types.Change
+	for _, managed := range ManagedRecordNames(baseDomain) {
+		for i := range existing.ResourceRecordSets {
+			recordSet := existing.ResourceRecordSets[i]
+			if normalizeRoute53Name(aws.ToString(recordSet.Name)) != managed.Name || string(recordSet.Type) != managed.Type {
+				continue
+			}
+			changes = append(changes, types.Change{
+				Action:            types.ChangeActionDelete,
+				ResourceRecordSet: &recordSet,
+			})
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	_, err = p.Client.ChangeResourceRecordSets(p.ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+		ChangeBatch:  &types.ChangeBatch{Changes: changes},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete record sets in zone %s: %w", dnsName, err)
+	}
+	return nil
+}
+
+// LookupRecord returns the record set with the given name and type from the
+// hosted zone for baseDomain, or nil when the zone or record does not exist.
+func (p *Route53Provider) LookupRecord(zoneName, baseDomain, name, rtype string) (*Record, error) {
+	dnsName := baseDomain + "."
+	zoneID, err := p.hostedZoneID(dnsName)
+	if err != nil {
+		return nil, err
+	}
+	if zoneID == "" {
+		return nil, nil
+	}
+
+	out, err := p.Client.ListResourceRecordSets(p.ctx, &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(zoneID),
+		StartRecordName: aws.String(name),
+		StartRecordType: types.RRType(rtype),
+		MaxItems:        aws.Int32(1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list record sets in zone %s: %w", dnsName, err)
+	}
+
+	for i := range out.ResourceRecordSets {
+		recordSet := out.ResourceRecordSets[i]
+		if normalizeRoute53Name(aws.ToString(recordSet.Name)) != name || string(recordSet.Type) != rtype {
+			continue
+		}
+		values := make([]string, len(recordSet.ResourceRecords))
+		for j, value := range recordSet.ResourceRecords {
+			values[j] = aws.ToString(value.Value)
+		}
+		return &Record{
+			Name:    name,
+			Type:    rtype,
+			TTL:     aws.ToInt64(recordSet.TTL),
+			Rrdatas: values,
+		}, nil
+	}
+	return nil, nil
+}
+
+// DeleteZone deletes the hosted zone for baseDomain. Zones whose comment does
+// not carry the OMS marker are refused; a zone that no longer exists is a no-op.
+func (p *Route53Provider) DeleteZone(zoneName, baseDomain string) error {
+	dnsName := baseDomain + "."
+	zoneID, err := p.hostedZoneID(dnsName)
+	if err != nil {
+		return err
+	}
+	if zoneID == "" {
+		return nil
+	}
+
+	zone, err := p.Client.GetHostedZone(p.ctx, &route53.GetHostedZoneInput{Id: aws.String(zoneID)})
+	if err != nil {
+		return fmt.Errorf("failed to get hosted zone for %s: %w", dnsName, err)
+	}
+	if zone.HostedZone.Config == nil || aws.ToString(zone.HostedZone.Config.Comment) != ManagedZoneComment {
+		return fmt.Errorf("hosted zone for %s was not created by OMS, refusing to delete it", dnsName)
+	}
+
+	_, err = p.Client.DeleteHostedZone(p.ctx, &route53.DeleteHostedZoneInput{Id: aws.String(zoneID)})
+	if err != nil {
+		return fmt.Errorf("failed to delete hosted zone for %s: %w", dnsName, err)
+	}
+	return nil
+}
+
+// toRoute53RecordSet converts a provider-independent record to a Route 53 record set.
+func toRoute53RecordSet(record Record) *types.ResourceRecordSet {
+	values := make([]types.ResourceRecord, len(record.Rrdatas))
+	for i, value := range record.Rrdatas {
+		values[i] = types.ResourceRecord{Value: aws.String(value)}
+	}
+	return &types.ResourceRecordSet{
+		Name:            aws.String(record.Name),
+		Type:            types.RRType(record.Type),
+		TTL:             aws.Int64(record.TTL),
+		ResourceRecords: values,
+	}
+}
+
+// normalizeRoute53Name undoes the octal escaping Route 53 applies to
+// wildcard labels in API responses.
+func normalizeRoute53Name(name string) string {
+	return strings.ReplaceAll(name, `\052`, "*")
+}
@@ -0,0 +1,199 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package dns_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap/dns"
+)
+
+var _ = Describe("Route53Provider", func() {
+	var (
+		client   *dns.MockRoute53API
+		provider *dns.Route53Provider
+	)
+
+	BeforeEach(func() {
+		client = dns.NewMockRoute53API(GinkgoT())
+		provider = dns.NewRoute53ProviderWithClient(context.Background(), client)
+	})
+
+	zoneListing := func(zones ...types.HostedZone) *route53.ListHostedZonesByNameOutput {
+		return &route53.ListHostedZonesByNameOutput{HostedZones: zones}
+	}
+
+	Describe("EnsureZone", func() {
+		It("creates the hosted zone when it does not exist", func() {
+			client.EXPECT().ListHostedZonesByName(mock.Anything, mock.Anything).Return(zoneListing(), nil)
+			client.EXPECT().CreateHostedZone(mock.Anything, mock.Anything).RunAndReturn(
+				func(ctx context.Context, params *route53.CreateHostedZoneInput, optFns ...func(*route53.Options)) (*route53.CreateHostedZoneOutput, error) {
+					Expect(aws.ToString(params.Name)).To(Equal("dev.codesphere.com."))
+					return &route53.CreateHostedZoneOutput{}, nil
+				})
+
+			Expect(provider.EnsureZone("oms-testing", "dev.codesphere.com.")).To(Succeed())
+		})
+
+		It("does not create a zone that already exists", func() {
+			client.EXPECT().ListHostedZonesByName(mock.Anything, mock.Anything).Return(
+				zoneListing(types.HostedZone{Id: aws.String("Z123"), Name: aws.String("dev.codesphere.com.")}), nil)
+
+			Expect(provider.EnsureZone("oms-testing", "dev.codesphere.com.")).To(Succeed())
+		})
+
+		It("ignores zones with a different DNS name", func() {
+			client.EXPECT().ListHostedZonesByName(mock.Anything, mock.Anything).Return(
+				zoneListing(types.HostedZone{Id: aws.String("Z456"), Name: aws.String("other.codesphere.com.")}), nil)
+			client.EXPECT().CreateHostedZone(mock.Anything, mock.Anything).Return(&route53.CreateHostedZoneOutput{}, nil)
+
+			Expect(provider.EnsureZone("oms-testing", "dev.codesphere.com.")).To(Succeed())
+		})
+	})
+
+	Describe("EnsureRecords", func() {
+		records := []dns.Record{
+			{Name: "cs.dev.codesphere.com.", Type: "A", TTL: 300, Rrdatas: []string{"1.2.3.4"}},
+			{Name: "*.cs.dev.codesphere.com.", Type: "A", TTL: 300, Rrdatas: []string{"1.2.3.4"}},
+		}
+
+		It("upserts all records in a single change batch", func() {
+			client.EXPECT().ListHostedZonesByName(mock.Anything, mock.Anything).Return(
+				zoneListing(types.HostedZone{Id: aws.String("Z123"), Name: aws.String("dev.codesphere.com.")}), nil)
+			client.EXPECT().ChangeResourceRecordSets(mock.Anything, mock.Anything).RunAndReturn(
+				func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+					Expect(aws.ToString(params.HostedZoneId)).To(Equal("Z123"))
+					Expect(params.ChangeBatch.Changes).To(HaveLen(2))
+					for _, change := range params.ChangeBatch.Changes {
+						Expect(change.Action).To(Equal(types.ChangeActionUpsert))
+						Expect(aws.ToInt64(change.ResourceRecordSet.TTL)).To(Equal(int64(300)))
+					}
+					return &route53.ChangeResourceRecordSetsOutput{}, nil
+				})
+
+			Expect(provider.EnsureRecords("oms-testing", "dev.codesphere.com.", records)).To(Succeed())
+		})
+
+		It("fails when the hosted zone does not exist", func() {
+			client.EXPECT().ListHostedZonesByName(mock.Anything, mock.Anything).Return(zoneListing(), nil)
+
+			err := provider.EnsureRecords("oms-testing", "dev.codesphere.com.", records)
+			Expect(err).To(MatchError(ContainSubstring("no hosted zone found for dev.codesphere.com.")))
+		})
+	})
+
+	Describe("DeleteRecords", func() {
+		It("deletes only the OMS-managed records, unescaping wildcard names", func() {
+			client.EXPECT().ListHostedZonesByName(mock.Anything, mock.Anything).Return(
+				zoneListing(types.HostedZone{Id: aws.String("Z123"), Name: aws.String("dev.codesphere.com.")}), nil)
+			client.EXPECT().ListResourceRecordSets(mock.Anything, mock.Anything).Return(
+				&route53.ListResourceRecordSetsOutput{ResourceRecordSets: []types.ResourceRecordSet{
+					{Name: aws.String("cs.dev.codesphere.com."), Type: types.RRTypeA},
+					{Name: aws.String(`\052.cs.dev.codesphere.com.`), Type: types.RRTypeA},
+					{Name: aws.String("unrelated.dev.codesphere.com."), Type: types.RRTypeA},
+					{Name: aws.String("cs.dev.codesphere.com."), Type: types.RRTypeTxt},
+				}}, nil)
+			client.EXPECT().ChangeResourceRecordSets(mock.Anything, mock.Anything).RunAndReturn(
+				func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+					Expect(params.ChangeBatch.Changes).To(HaveLen(2))
+					for _, change := range params.ChangeBatch.Changes {
+						Expect(change.Action).To(Equal(types.ChangeActionDelete))
+					}
+					return &route53.ChangeResourceRecordSetsOutput{}, nil
+				})
+
+			Expect(provider.DeleteRecords("oms-testing", "dev.codesphere.com")).To(Succeed())
+		})
+
+		It("is a no-op when the hosted zone does not exist", func() {
+			client.EXPECT().ListHostedZonesByName(mock.Anything, mock.Anything).Return(zoneListing(), nil)
+
+			Expect(provider.DeleteRecords("oms-testing", "dev.codesphere.com")).To(Succeed())
+		})
+
+		It("is a no-op when no managed records exist in the zone", func() {
+			client.EXPECT().ListHostedZonesByName(mock.Anything, mock.Anything).Return(
+				zoneListing(types.HostedZone{Id: aws.String("Z123"), Name: aws.String("dev.codesphere.com.")}), nil)
+			client.EXPECT().ListResourceRecordSets(mock.Anything, mock.Anything).Return(
+				&route53.ListResourceRecordSetsOutput{}, nil)
+
+			Expect(provider.DeleteRecords("oms-testing", "dev.codesphere.com")).To(Succeed())
+		})
+	})
+
+	Describe("LookupRecord", func() {
+		It("returns the record when it exists", func() {
+			client.EXPECT().ListHostedZonesByName(mock.Anything, mock.Anything).Return(
+				zoneListing(types.HostedZone{Id: aws.String("Z123"), Name: aws.String("dev.codesphere.com.")}), nil)
+			client.EXPECT().ListResourceRecordSets(mock.Anything, mock.Anything).Return(
+				&route53.ListResourceRecordSetsOutput{ResourceRecordSets: []types.ResourceRecordSet{
+					{
+						Name:            aws.String("_oms-owner.cs.dev.codesphere.com."),
+						Type:            types.RRTypeTxt,
+						TTL:             aws.Int64(300),
+						ResourceRecords: []types.ResourceRecord{{Value: aws.String(`"heritage=oms,owner=my-project"`)}},
+					},
+				}}, nil)
+
+			record, err := provider.LookupRecord("oms-testing", "dev.codesphere.com", "_oms-owner.cs.dev.codesphere.com.", "TXT")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record).NotTo(BeNil())
+			Expect(dns.OwnerFromRecord(record)).To(Equal("my-project"))
+		})
+
+		It("returns nil when the record does not exist", func() {
+			client.EXPECT().ListHostedZonesByName(mock.Anything, mock.Anything).Return(
+				zoneListing(types.HostedZone{Id: aws.String("Z123"), Name: aws.String("dev.codesphere.com.")}), nil)
+			client.EXPECT().ListResourceRecordSets(mock.Anything, mock.Anything).Return(
+				&route53.ListResourceRecordSetsOutput{ResourceRecordSets: []types.ResourceRecordSet{
+					{Name: aws.String("other.dev.codesphere.com."), Type: types.RRTypeA},
+				}}, nil)
+
+			record, err := provider.LookupRecord("oms-testing", "dev.codesphere.com", "_oms-owner.cs.dev.codesphere.com.", "TXT")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record).To(BeNil())
+		})
+	})
+
+	Describe("DeleteZone", func() {
+		It("deletes a hosted zone created by OMS", func() {
+			client.EXPECT().ListHostedZonesByName(mock.Anything, mock.Anything).Return(
+				zoneListing(types.HostedZone{Id: aws.String("Z123"), Name: aws.String("dev.codesphere.com.")}), nil)
+			client.EXPECT().GetHostedZone(mock.Anything, mock.Anything).Return(
+				&route53.GetHostedZoneOutput{HostedZone: &types.HostedZone{
+					Id:     aws.String("Z123"),
+					Config: &types.HostedZoneConfig{Comment: aws.String(dns.ManagedZoneComment)},
+				}}, nil)
+			client.EXPECT().DeleteHostedZone(mock.Anything, mock.Anything).Return(&route53.DeleteHostedZoneOutput{}, nil)
+
+			Expect(provider.DeleteZone("oms-testing", "dev.codesphere.com")).To(Succeed())
+		})
+
+		It("refuses to delete a hosted zone it did not create", func() {
+			client.EXPECT().ListHostedZonesByName(mock.Anything, mock.Anything).Return(
+				zoneListing(types.HostedZone{Id: aws.String("Z123"), Name: aws.String("dev.codesphere.com.")}), nil)
+			client.EXPECT().GetHostedZone(mock.Anything, mock.Anything).Return(
+				&route53.GetHostedZoneOutput{HostedZone: &types.HostedZone{
+					Id:     aws.String("Z123"),
+					Config: &types.HostedZoneConfig{Comment: aws.String("imported zone")},
+				}}, nil)
+
+			err := provider.DeleteZone("oms-testing", "dev.codesphere.com")
+			Expect(err).To(MatchError(ContainSubstring("not created by OMS")))
+		})
+
+		It("is a no-op when the hosted zone does not exist", func() {
+			client.EXPECT().ListHostedZonesByName(mock.Anything, mock.Anything).Return(zoneListing(), nil)
+
+			Expect(provider.DeleteZone("oms-testing", "dev.codesphere.com")).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,119 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+)
+
+// ArtifactsMountDir is where the artifact storage is mounted on the ops node.
+// The install package is downloaded and extracted there when a bucket or an
+// extra disk is configured, keeping the artifacts off the root disk.
+const ArtifactsMountDir = "/root/artifacts"
+
+// validateArtifactStorage checks the artifact storage options. The extra disk
+// is declared on the jumpbox instance, so it cannot be combined with
+// jumpbox-less mode.
+func (b *GCPBootstrapper) validateArtifactStorage() error {
+	if b.Env.ArtifactsBucket != "" && b.Env.ArtifactsDiskGB > 0 {
+		return fmt.Errorf("--artifacts-bucket and --artifacts-disk-size are mutually exclusive")
+	}
+	if b.Env.ArtifactsDiskGB < 0 {
+		return fmt.Errorf("--artifacts-disk-size must be positive")
+	}
+	if b.Env.ArtifactsDiskGB > 0 && b.Env.NoJumpbox {
+		return fmt.Errorf("--artifacts-disk-size requires a jumpbox; use --artifacts-bucket in jumpbox-less mode")
+	}
+	return nil
+}
+
+// usesArtifactStorage reports whether installer artifacts live on dedicated
+// storage instead of the ops node root disk.
+func (b *GCPBootstrapper) usesArtifactStorage() bool {
+	return b.Env.ArtifactsBucket != "" || b.Env.ArtifactsDiskGB > 0
+}
+
+// artifactsDir returns the directory the install package is downloaded to
+// and extracted in on the ops node.
+func (b *GCPBootstrapper) artifactsDir() string {
+	if b.usesArtifactStorage() {
+		return ArtifactsMountDir
+	}
+	return "/root"
+}
+
+// artifactStorageScript returns the script mounting the artifact storage on
+// the ops node. Both variants are idempotent: an already mounted directory is
+// left alone and the fstab entry makes the mount survive reboots.
+func (b *GCPBootstrapper) artifactStorageScript() string {
+	if b.Env.ArtifactsBucket != "" {
+		return fmt.Sprintf(`#!/bin/bash
+set -euo pipefail
+
+mkdir -p %[1]s
+if mountpoint -q %[1]s; then
+  exit 0
+fi
+
+if ! command -v gcsfuse >/dev/null 2>&1; then
+  echo "deb https://packages.cloud.google.com/apt gcsfuse-$(lsb_release -c -s) main" > /etc/apt/sources.list.d/gcsfuse.list
+  curl -fsSL https://packages.cloud.google.com/apt/doc/apt-key.gpg | apt-key add -
+  apt-get update -qq
+  apt-get install -y -qq gcsfuse
+fi
+
+grep -q " %[1]s gcsfuse " /etc/fstab || echo "%[2]s %[1]s gcsfuse rw,_netdev,implicit_dirs 0 0" >> /etc/fstab
+gcsfuse --implicit-dirs %[2]s %[1]s
+`, ArtifactsMountDir, b.Env.ArtifactsBucket)
+	}
+
+	return fmt.Sprintf(`#!/bin/bash
+set -euo pipefail
+
+mkdir -p %[1]s
+if mountpoint -q %[1]s; then
+  exit 0
+fi
+
+# The artifact disk is the only non-boot disk attached to the jumpbox.
+DEVICE=""
+for dev in $(lsblk -dnpo NAME,TYPE | awk '$2=="disk"{print $1}'); do
+  if [ -z "$(lsblk -no MOUNTPOINTS "$dev" | tr -d '[:space:]')" ]; then
+    DEVICE="$dev"
+    break
+  fi
+done
+if [ -z "$DEVICE" ]; then
+  echo "no unmounted disk found for artifact storage" >&2
+  exit 1
+fi
+
+blkid "$DEVICE" >/dev/null 2>&1 || mkfs.ext4 -q "$DEVICE"
+grep -q " %[1]s ext4 " /etc/fstab || echo "$DEVICE %[1]s ext4 defaults,nofail 0 2" >> /etc/fstab
+mount %[1]s
+`, ArtifactsMountDir)
+}
+
+// EnsureArtifactStorage mounts the configured GCS bucket or the attached
+// artifact disk at the artifacts directory on the ops node, so the install
+// package and its extracted contents do not fill up the root disk.
+func (b *GCPBootstrapper) EnsureArtifactStorage() error {
+	ops := b.opsNode()
+
+	err := b.fw.WriteFile("artifact-storage.sh", []byte(b.artifactStorageScript()), 0755)
+	if err != nil {
+		return fmt.Errorf("failed to write artifact-storage.sh: %w", err)
+	}
+	err = ops.NodeClient.CopyFile(ops, "artifact-storage.sh", "/root/artifact-storage.sh")
+	if err != nil {
+		return fmt.Errorf("failed to copy artifact-storage.sh to %s: %w", ops.GetName(), err)
+	}
+	err = ops.RunSSHCommand("root", "chmod +x /root/artifact-storage.sh && /root/artifact-storage.sh")
+	if err != nil {
+		return fmt.Errorf("failed to mount artifact storage on %s: %w", ops.GetName(), err)
+	}
+
+	b.stlog.Logf("Artifact storage mounted at %s", ArtifactsMountDir)
+	return nil
+}
@@ -0,0 +1,153 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/codesphere-cloud/oms/internal/util"
+)
+
+// MinByoK8sMinorVersion is the oldest 1.x Kubernetes minor version Codesphere
+// is installed onto when the operator brings their own cluster.
+const MinByoK8sMinorVersion = 27
+
+// byoK8s reports whether the operator brings their own Kubernetes cluster
+// instead of having k0s nodes provisioned.
+func (b *GCPBootstrapper) byoK8s() bool {
+	return b.Env.KubeconfigPath != ""
+}
+
+// validateByoK8s checks the BYO Kubernetes options. Since no k8s nodes are
+// provisioned, the node pool options do not apply, and the jumpbox is the
+// only node left to run the installation from.
+func (b *GCPBootstrapper) validateByoK8s() error {
+	if !b.byoK8s() {
+		return nil
+	}
+	if b.Env.DedicatedControlPlane || b.Env.WorkerCount != 0 || len(b.Env.WorkerTaints) != 0 {
+		return fmt.Errorf("--kubeconfig provisions no kubernetes nodes and cannot be combined with worker pool options")
+	}
+	if b.Env.K8sDistribution != "" {
+		return fmt.Errorf("--kubeconfig uses the existing cluster and cannot be combined with --k8s-distribution")
+	}
+	if b.Env.NoJumpbox {
+		return fmt.Errorf("--kubeconfig requires a jumpbox to run the installation from")
+	}
+	return nil
+}
+
+// checkByoCluster validates the existing cluster: a supported version, Ready
+// nodes with a working CNI, and a default storage class for workspace
+// volumes. Returns one message per failed check.
+func (b *GCPBootstrapper) checkByoCluster(clientset kubernetes.Interface) []string {
+	var failures []string
+
+	version, err := clientset.Discovery().ServerVersion()
+	if err != nil {
+		return []string{fmt.Sprintf("failed to query the cluster version: %v", err)}
+	}
+	minor, convErr := strconv.Atoi(strings.TrimSuffix(version.Minor, "+"))
+	if version.Major != "1" || convErr != nil {
+		failures = append(failures, fmt.Sprintf("unexpected cluster version %s.%s", version.Major, version.Minor))
+	} else if minor < MinByoK8sMinorVersion {
+		failures = append(failures, fmt.Sprintf("cluster version 1.%d is too old; 1.%d or newer is required", minor, MinByoK8sMinorVersion))
+	}
+
+	nodes, err := clientset.CoreV1().Nodes().List(b.ctx, metav1.ListOptions{})
+	if err != nil {
+		failures = append(failures, fmt.Sprintf("failed to list cluster nodes: %v", err))
+	} else if len(nodes.Items) == 0 {
+		failures = append(failures, "cluster has no nodes")
+	} else {
+		for _, n := range nodes.Items {
+			for _, cond := range n.Status.Conditions {
+				if cond.Type == corev1.NodeReady && cond.Status != corev1.ConditionTrue {
+					failures = append(failures, fmt.Sprintf("node %s is not Ready", n.Name))
+				}
+				if cond.Type == corev1.NodeNetworkUnavailable && cond.Status == corev1.ConditionTrue {
+					failures = append(failures, fmt.Sprintf("node %s reports NetworkUnavailable; is the CNI healthy?", n.Name))
+				}
+			}
+		}
+	}
+
+	classes, err := clientset.StorageV1().StorageClasses().List(b.ctx, metav1.ListOptions{})
+	if err != nil {
+		failures = append(failures, fmt.Sprintf("failed to list storage classes: %v", err))
+	} else if len(classes.Items) == 0 {
+		failures = append(failures, "cluster has no storage classes")
+	} else {
+		hasDefault := false
+		for _, sc := range classes.Items {
+			if sc.Annotations["storageclass.kubernetes.io/is-default-class"] == "true" {
+				hasDefault = true
+				break
+			}
+		}
+		if !hasDefault {
+			failures = append(failures, "cluster has no default storage class for workspace volumes")
+		}
+	}
+
+	return failures
+}
+
+// readKubeconfig returns the contents of the operator-provided kubeconfig.
+func (b *GCPBootstrapper) readKubeconfig() ([]byte, error) {
+	content, err := b.fw.ReadFile(util.ExpandPath(b.Env.KubeconfigPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kubeconfig %s: %w", b.Env.KubeconfigPath, err)
+	}
+	return content, nil
+}
+
+// EnsureByoClusterPreflight validates the operator-provided cluster before
+// anything is installed onto it, so misconfigurations surface as one
+// actionable message instead of a failed installation.
+func (b *GCPBootstrapper) EnsureByoClusterPreflight() error {
+	kubeconfig, err := b.readKubeconfig()
+	if err != nil {
+		return err
+	}
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to parse kubeconfig %s: %w", b.Env.KubeconfigPath, err)
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	failures := b.checkByoCluster(clientset)
+	if len(failures) > 0 {
+		return fmt.Errorf("BYO Kubernetes preflight failed:\n  - %s", strings.Join(failures, "\n  - "))
+	}
+
+	b.stlog.Logf("Existing cluster validated: version, nodes and storage classes look good")
+	return nil
+}
+
+// ensureKubeconfigInVault stores the operator-provided kubeconfig in the
+// vault, where the installer expects cluster credentials it does not manage
+// itself.
+func (b *GCPBootstrapper) ensureKubeconfigInVault() error {
+	content, err := b.readKubeconfig()
+	if err != nil {
+		return err
+	}
+	b.icg.GetVault().SetSecret(files.SecretEntry{
+		Name: files.SecretKubeConfig,
+		File: &files.SecretFile{Name: "kubeconfig", Content: string(content)},
+	})
+	return nil
+}
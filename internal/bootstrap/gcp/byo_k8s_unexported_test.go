@@ -0,0 +1,100 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("BYO cluster checks - Unexported", func() {
+	var b *GCPBootstrapper
+
+	readyNode := func(name string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				{Type: corev1.NodeNetworkUnavailable, Status: corev1.ConditionFalse},
+			}},
+		}
+	}
+
+	defaultStorageClass := func() *storagev1.StorageClass {
+		return &storagev1.StorageClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "standard",
+				Annotations: map[string]string{"storageclass.kubernetes.io/is-default-class": "true"},
+			},
+		}
+	}
+
+	newFakeCluster := func(minor string, objects ...runtime.Object) *fake.Clientset {
+		clientset := fake.NewClientset(objects...)
+		clientset.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{
+			Major: "1",
+			Minor: minor,
+		}
+		return clientset
+	}
+
+	BeforeEach(func() {
+		b = &GCPBootstrapper{ctx: context.Background()}
+	})
+
+	It("passes for a healthy cluster", func() {
+		clientset := newFakeCluster("29", readyNode("worker-1"), defaultStorageClass())
+
+		Expect(b.checkByoCluster(clientset)).To(BeEmpty())
+	})
+
+	It("tolerates a minor version suffix", func() {
+		clientset := newFakeCluster("31+", readyNode("worker-1"), defaultStorageClass())
+
+		Expect(b.checkByoCluster(clientset)).To(BeEmpty())
+	})
+
+	It("reports a cluster that is too old", func() {
+		clientset := newFakeCluster("26", readyNode("worker-1"), defaultStorageClass())
+
+		Expect(b.checkByoCluster(clientset)).To(ConsistOf(ContainSubstring("too old")))
+	})
+
+	It("reports a cluster without nodes", func() {
+		clientset := newFakeCluster("29", defaultStorageClass())
+
+		Expect(b.checkByoCluster(clientset)).To(ConsistOf("cluster has no nodes"))
+	})
+
+	It("reports nodes that are not Ready or without a CNI", func() {
+		brokenNode := readyNode("worker-2")
+		brokenNode.Status.Conditions = []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionFalse},
+			{Type: corev1.NodeNetworkUnavailable, Status: corev1.ConditionTrue},
+		}
+		clientset := newFakeCluster("29", readyNode("worker-1"), brokenNode, defaultStorageClass())
+
+		Expect(b.checkByoCluster(clientset)).To(ConsistOf(
+			"node worker-2 is not Ready",
+			"node worker-2 reports NetworkUnavailable; is the CNI healthy?",
+		))
+	})
+
+	It("reports a missing default storage class", func() {
+		class := defaultStorageClass()
+		class.Annotations = nil
+		clientset := newFakeCluster("29", readyNode("worker-1"), class)
+
+		Expect(b.checkByoCluster(clientset)).To(ConsistOf("cluster has no default storage class for workspace volumes"))
+	})
+})
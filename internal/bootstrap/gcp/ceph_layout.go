@@ -0,0 +1,98 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+)
+
+// Default Ceph OSD layout: one OSD per host backed by a data and a DB disk.
+const (
+	DefaultCephDataDiskGB  int64 = 100
+	DefaultCephDBDiskGB    int64 = 10
+	DefaultCephOSDsPerHost       = 1
+)
+
+// Resolved layout accessors: environments constructed without explicit Ceph
+// sizing (e.g. older infra files) fall back to the defaults above.
+
+func (b *GCPBootstrapper) cephDataDiskGB() int64 {
+	if b.Env.CephDataDiskGB == 0 {
+		return DefaultCephDataDiskGB
+	}
+	return b.Env.CephDataDiskGB
+}
+
+func (b *GCPBootstrapper) cephDBDiskGB() int64 {
+	if b.Env.CephDBDiskGB == 0 {
+		return DefaultCephDBDiskGB
+	}
+	return b.Env.CephDBDiskGB
+}
+
+func (b *GCPBootstrapper) cephOSDsPerHost() int {
+	if b.Env.CephOSDsPerHost == 0 {
+		return DefaultCephOSDsPerHost
+	}
+	return b.Env.CephOSDsPerHost
+}
+
+// cephAdditionalDisks returns the disks attached to each ceph VM: a DB and a
+// data disk per OSD, sized from the environment.
+func (b *GCPBootstrapper) cephAdditionalDisks() []int64 {
+	disks := make([]int64, 0, 2*b.cephOSDsPerHost())
+	for i := 0; i < b.cephOSDsPerHost(); i++ {
+		disks = append(disks, b.cephDBDiskGB(), b.cephDataDiskGB())
+	}
+	return disks
+}
+
+// validateCephLayout ensures the Ceph OSD layout is consistent before any VM
+// is created. The OSD spec tells DB and data devices apart purely by size, so
+// the DB disk must stay below half the data disk size (the generated device
+// selectors meet at that cutoff).
+func (b *GCPBootstrapper) validateCephLayout() error {
+	if !b.usesCephStorage() || b.Env.StorageMode == StorageModeExternal {
+		return nil
+	}
+	if b.Env.CephOSDsPerHost < 0 {
+		return fmt.Errorf("--ceph-osds-per-host must be at least 1")
+	}
+	if b.cephDataDiskGB() < 10 {
+		return fmt.Errorf("--ceph-data-disk-size must be at least 10 GB")
+	}
+	if b.cephDBDiskGB() < 1 {
+		return fmt.Errorf("--ceph-db-disk-size must be at least 1 GB")
+	}
+	if b.cephDBDiskGB() >= b.cephDataDiskGB()/2 {
+		return fmt.Errorf("--ceph-db-disk-size must be smaller than half of --ceph-data-disk-size so the OSD spec can tell DB and data devices apart")
+	}
+	if b.Env.CephReplicaCount < 0 {
+		return fmt.Errorf("--ceph-replica-count must be at least 1")
+	}
+	switch b.Env.CephFailureDomain {
+	case "", "host":
+		if b.Env.CephReplicaCount > len(cephVMDefs()) {
+			return fmt.Errorf("--ceph-replica-count must not exceed the number of ceph hosts (%d) with the host failure domain", len(cephVMDefs()))
+		}
+	case "osd":
+		if b.Env.CephReplicaCount > len(cephVMDefs())*b.cephOSDsPerHost() {
+			return fmt.Errorf("--ceph-replica-count must not exceed the number of OSDs (%d) with the osd failure domain", len(cephVMDefs())*b.cephOSDsPerHost())
+		}
+	default:
+		return fmt.Errorf("invalid ceph failure domain %q: must be host or osd", b.Env.CephFailureDomain)
+	}
+	return nil
+}
+
+// cephVMDefs returns the ceph VM definitions from the static topology.
+func cephVMDefs() []VMDef {
+	defs := []VMDef{}
+	for _, vm := range vmDefs {
+		if vm.Tags[0] == "ceph" {
+			defs = append(defs, vm)
+		}
+	}
+	return defs
+}
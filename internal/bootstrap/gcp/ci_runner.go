@@ -0,0 +1,118 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// CIRunnerVMName is the name of the optional CI runner instance.
+	CIRunnerVMName = "ci-runner"
+
+	// defaultCIRunnerMachineType is used when no machine type override is configured.
+	defaultCIRunnerMachineType = "e2-medium"
+
+	// ciRunnerDir is where the Actions runner is unpacked on the runner VM.
+	ciRunnerDir = "/opt/actions-runner"
+
+	// actionsRunnerVersion is the GitHub Actions runner release installed on the VM.
+	actionsRunnerVersion = "2.328.0"
+)
+
+// CIRunnerConfig describes an optional in-environment GitHub Actions runner
+// VM registered against the customer's GitHub organization.
+type CIRunnerConfig struct {
+	// Org is the GitHub organization the runner is registered with.
+	Org string `json:"org"`
+	// MachineType overrides the runner VM size (default: e2-medium).
+	MachineType string `json:"machine_type,omitempty"`
+	// Labels are additional runner labels next to the defaults (oms and the
+	// project ID), used to target the runner from workflows.
+	Labels []string `json:"labels,omitempty"`
+}
+
+// validateCIRunnerParams checks that the CI runner configuration is complete.
+func (b *GCPBootstrapper) validateCIRunnerParams() error {
+	if b.Env.CIRunner == nil {
+		return nil
+	}
+	if b.Env.CIRunner.Org == "" {
+		return fmt.Errorf("ci-runner-org must be set when a CI runner is configured")
+	}
+	if b.Env.GitHubPAT == "" {
+		return fmt.Errorf("github-pat is required to register the CI runner against organization %s", b.Env.CIRunner.Org)
+	}
+	return nil
+}
+
+// EnsureCIRunner provisions the CI runner VM and registers it as a
+// self-hosted Actions runner with the configured GitHub organization.
+// Registration is skipped when the runner is already configured on the VM.
+func (b *GCPBootstrapper) EnsureCIRunner() error {
+	cfg := b.Env.CIRunner
+
+	machineType := cfg.MachineType
+	if machineType == "" {
+		machineType = defaultCIRunnerMachineType
+	}
+
+	vm := VMDef{Name: CIRunnerVMName, MachineType: machineType, Tags: []string{CIRunnerVMName}}
+	logCh := make(chan string, 4)
+	result, err := b.ensureVM(vm, b.Env.RootDiskSize, DefaultSourceImage, []string{DefaultServiceAccountScope}, logCh)
+	close(logCh)
+	for msg := range logCh {
+		b.stlog.Logf("%s", msg)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to ensure CI runner VM: %w", err)
+	}
+
+	runner := b.opsNode().CreateSubNode(result.name, result.externalIP, result.internalIP)
+	if b.Env.NoJumpbox {
+		// Without a jumpbox there is no hop to proxy through; the IAP tunnel
+		// reaches the runner directly.
+		runner.Jumpbox = nil
+	}
+	b.Env.CIRunnerNode = runner
+
+	err = runner.NodeClient.WaitReady(runner, 30*time.Second)
+	if err != nil {
+		return fmt.Errorf("CI runner VM did not become reachable: %w", err)
+	}
+
+	if runner.NodeClient.HasFile(runner, ciRunnerDir+"/.runner") {
+		b.stlog.Logf("CI runner is already registered, skipping registration")
+		return nil
+	}
+
+	token, err := b.GitHubClient.CreateOrgRunnerRegistrationToken(b.ctx, cfg.Org)
+	if err != nil {
+		return fmt.Errorf("failed to create runner registration token for organization %s: %w", cfg.Org, err)
+	}
+
+	labels := append([]string{"oms", b.Env.ProjectID}, cfg.Labels...)
+	downloadURL := fmt.Sprintf("https://github.com/actions/runner/releases/download/v%s/actions-runner-linux-x64-%s.tar.gz",
+		actionsRunnerVersion, actionsRunnerVersion)
+
+	commands := []string{
+		"id runner >/dev/null 2>&1 || useradd -m runner",
+		fmt.Sprintf("mkdir -p %s", ciRunnerDir),
+		fmt.Sprintf("curl -fsSL %s | tar -xz -C %s", downloadURL, ciRunnerDir),
+		fmt.Sprintf("chown -R runner:runner %s", ciRunnerDir),
+		fmt.Sprintf("cd %s && sudo -u runner ./config.sh --unattended --replace --url https://github.com/%s --token %s --name %s --labels %s",
+			ciRunnerDir, cfg.Org, token, b.Env.ProjectID+"-"+CIRunnerVMName, strings.Join(labels, ",")),
+		fmt.Sprintf("cd %s && ./svc.sh install runner && ./svc.sh start", ciRunnerDir),
+	}
+	for _, command := range commands {
+		if err := runner.RunSSHCommand("root", command); err != nil {
+			return fmt.Errorf("failed to configure CI runner: %w", err)
+		}
+	}
+
+	b.stlog.Logf("CI runner registered with organization %s", cfg.Org)
+	return nil
+}
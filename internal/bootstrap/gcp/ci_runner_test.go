@@ -0,0 +1,132 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/cloudflare"
+	"github.com/codesphere-cloud/oms/internal/env"
+	"github.com/codesphere-cloud/oms/internal/github"
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/codesphere-cloud/oms/internal/portal"
+	"github.com/codesphere-cloud/oms/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("CI runner", func() {
+	var (
+		nodeClient       *node.MockNodeClient
+		gc               *gcp.MockGCPClientManager
+		mockGitHubClient *github.MockGitHubClient
+		csEnv            *gcp.CodesphereEnvironment
+
+		bs *gcp.GCPBootstrapper
+	)
+
+	JustBeforeEach(func() {
+		var err error
+		bs, err = gcp.NewGCPBootstrapper(
+			context.Background(),
+			env.NewEnv(),
+			bootstrap.NewStepLogger(false),
+			csEnv,
+			installer.NewMockInstallConfigManager(GinkgoT()),
+			gc,
+			util.NewMockFileIO(GinkgoT()),
+			nodeClient,
+			portal.NewMockPortal(GinkgoT()),
+			util.NewFakeTime(),
+			mockGitHubClient,
+			cloudflare.NewMockCloudflareClient(GinkgoT()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	BeforeEach(func() {
+		nodeClient = node.NewMockNodeClient(GinkgoT())
+		gc = gcp.NewMockGCPClientManager(GinkgoT())
+		mockGitHubClient = github.NewMockGitHubClient(GinkgoT())
+
+		csEnv = &gcp.CodesphereEnvironment{
+			ProjectID:  "fake-project",
+			Zone:       "europe-west3-a",
+			BaseDomain: "dev.codesphere.com",
+			GitHubPAT:  "fake-pat",
+			Jumpbox:    fakeNode("jumpbox", nodeClient),
+			CIRunner: &gcp.CIRunnerConfig{
+				Org:    "fake-org",
+				Labels: []string{"staging"},
+			},
+		}
+	})
+
+	Describe("ValidateInput", func() {
+		It("rejects a CI runner without an organization", func() {
+			csEnv.CIRunner.Org = ""
+
+			err := bs.ValidateInput()
+			Expect(err).To(MatchError(ContainSubstring("ci-runner-org must be set")))
+		})
+
+		It("rejects a CI runner without a GitHub PAT", func() {
+			csEnv.GitHubPAT = ""
+
+			err := bs.ValidateInput()
+			Expect(err).To(MatchError(ContainSubstring("github-pat is required to register the CI runner")))
+		})
+	})
+
+	Describe("EnsureCIRunner", func() {
+		var (
+			commandsMu sync.Mutex
+			commands   []string
+		)
+
+		BeforeEach(func() {
+			commands = nil
+
+			gc.EXPECT().GetInstance("fake-project", "europe-west3-a", "ci-runner").
+				Return(makeRunningInstance("10.0.0.9", ""), nil)
+			nodeClient.EXPECT().WaitReady(mock.Anything, mock.Anything).Return(nil)
+		})
+
+		It("skips registration when the runner is already configured", func() {
+			nodeClient.EXPECT().HasFile(mock.Anything, "/opt/actions-runner/.runner").Return(true)
+
+			Expect(bs.EnsureCIRunner()).To(Succeed())
+			Expect(bs.Env.CIRunnerNode.GetInternalIP()).To(Equal("10.0.0.9"))
+		})
+
+		It("downloads, configures, and starts the runner", func() {
+			nodeClient.EXPECT().HasFile(mock.Anything, "/opt/actions-runner/.runner").Return(false)
+			mockGitHubClient.EXPECT().CreateOrgRunnerRegistrationToken(mock.Anything, "fake-org").
+				Return("fake-token", nil)
+			nodeClient.EXPECT().RunCommand(mock.Anything, "root", mock.Anything).RunAndReturn(
+				func(n *node.Node, username, command string) error {
+					commandsMu.Lock()
+					defer commandsMu.Unlock()
+					commands = append(commands, command)
+					return nil
+				})
+
+			Expect(bs.EnsureCIRunner()).To(Succeed())
+
+			joined := strings.Join(commands, "\n")
+			Expect(joined).To(ContainSubstring("actions-runner-linux-x64"))
+			Expect(joined).To(ContainSubstring("--url https://github.com/fake-org"))
+			Expect(joined).To(ContainSubstring("--token fake-token"))
+			Expect(joined).To(ContainSubstring("--name fake-project-ci-runner"))
+			Expect(joined).To(ContainSubstring("--labels oms,fake-project,staging"))
+			Expect(joined).To(ContainSubstring("./svc.sh install runner && ./svc.sh start"))
+		})
+	})
+})
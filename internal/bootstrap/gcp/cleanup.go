@@ -5,12 +5,17 @@ package gcp
 
 import (
 	"bufio"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/dns"
 	"github.com/codesphere-cloud/oms/internal/util"
 )
 
@@ -19,6 +24,14 @@ type CleanupOpts struct {
 	ProjectID      string
 	Force          bool
 	SkipDNSCleanup bool
+	// DeleteDNSZone also deletes the managed zone after the records, when it
+	// was created by OMS.
+	DeleteDNSZone bool
+	// DryRun prints what the cleanup would delete without changing anything.
+	DryRun bool
+	// OverrideWindow runs the teardown outside the environment's maintenance
+	// window.
+	OverrideWindow bool
 	BaseDomain     string
 	DNSZoneName    string
 	DNSProjectID   string
@@ -31,6 +44,26 @@ type CleanupDeps struct {
 	StepLogger    *bootstrap.StepLogger
 	ConfirmReader io.Reader
 	InfraFilePath string
+	// Clock evaluates the maintenance window; nil falls back to real time.
+	Clock util.Time
+}
+
+// KeyDestruction certifies the cryptographic erasure performed during
+// teardown: with every version of the disk encryption key destroyed, the
+// encrypted disks can no longer be decrypted.
+type KeyDestruction struct {
+	KeyName           string    `json:"key_name"`
+	DestroyedVersions []string  `json:"destroyed_versions"`
+	DestroyedAt       time.Time `json:"destroyed_at"`
+}
+
+// TeardownReport documents what a cleanup run removed. When a managed disk
+// encryption key was destroyed, it doubles as the destruction certificate
+// for audits.
+type TeardownReport struct {
+	ProjectID      string          `json:"project_id"`
+	CompletedAt    time.Time       `json:"completed_at"`
+	KeyDestruction *KeyDestruction `json:"key_destruction,omitempty"`
 }
 
 // CleanupExecutor manages state and logic for each cleanup step.
@@ -43,6 +76,8 @@ type CleanupExecutor struct {
 	BaseDomain      string
 	DNSZoneName     string
 	DNSProjectID    string
+	protected       bool
+	keyDestruction  *KeyDestruction
 }
 
 // NewCleanupExecutor resolves configuration from options and the infra file,
@@ -136,8 +171,23 @@ func (e *CleanupExecutor) resolveDNSSettings() {
 }
 
 // VerifyAndConfirm checks that the project is OMS-managed and prompts the user
-// for deletion confirmation, unless Force is set.
+// for deletion confirmation, unless Force is set. Protected environments are
+// only deleted with Force plus a typed project ID confirmation, and a
+// configured maintenance window refuses the teardown outside the window.
 func (e *CleanupExecutor) VerifyAndConfirm() error {
+	if err := ConfirmMaintenanceWindow(e.maintenanceWindow(), e.clock(), "teardown", e.Opts.OverrideWindow); err != nil {
+		return err
+	}
+
+	protected, err := e.isProtected()
+	if err != nil {
+		return fmt.Errorf("failed to verify environment protection: %w", err)
+	}
+	e.protected = protected
+	if protected {
+		return ConfirmProtectedOperation(e.ProjectID, "deletion", e.Opts.Force, e.Deps.ConfirmReader)
+	}
+
 	if e.Opts.Force {
 		log.Printf("Skipping OMS-managed verification and deletion confirmation (--force flag used)")
 		return nil
@@ -154,6 +204,65 @@ func (e *CleanupExecutor) VerifyAndConfirm() error {
 	return e.confirmDeletion()
 }
 
+// isProtected reports whether the environment is locked against teardown. The
+// infra file is authoritative when loaded; otherwise the project label is checked.
+func (e *CleanupExecutor) isProtected() (bool, error) {
+	if e.InfraFileLoaded {
+		return e.InfraEnv.Protected, nil
+	}
+	return e.Deps.GCPClient.IsProtectedProject(e.ProjectID)
+}
+
+// maintenanceWindow returns the environment's maintenance window, which is
+// only known when the infra file was loaded.
+func (e *CleanupExecutor) maintenanceWindow() *MaintenanceWindow {
+	if !e.InfraFileLoaded {
+		return nil
+	}
+	return e.InfraEnv.MaintenanceWindow
+}
+
+func (e *CleanupExecutor) clock() util.Time {
+	if e.Deps.Clock == nil {
+		return util.NewTime()
+	}
+	return e.Deps.Clock
+}
+
+// PrintTeardownPlan lists what the cleanup would delete without changing
+// anything. The protection state is resolved the same way VerifyAndConfirm
+// does, so a dry run also reveals whether the teardown would be refused.
+func (e *CleanupExecutor) PrintTeardownPlan() error {
+	protected, err := e.isProtected()
+	if err != nil {
+		return fmt.Errorf("failed to verify environment protection: %w", err)
+	}
+
+	log.Printf("Dry run: no resources will be deleted.")
+	log.Printf("Would delete GCP project '%s' and all its resources.", e.ProjectID)
+	switch {
+	case e.Opts.SkipDNSCleanup:
+		log.Printf("DNS records would be kept (--skip-dns-cleanup).")
+	case e.BaseDomain == "" || e.DNSZoneName == "":
+		log.Printf("DNS cleanup would be skipped: missing base domain or DNS zone name.")
+	default:
+		log.Printf("Would delete the OMS-created DNS records for %s in zone %s (project %s).", e.BaseDomain, e.DNSZoneName, e.DNSProjectID)
+		if e.Opts.DeleteDNSZone {
+			log.Printf("Would also delete the managed DNS zone %s.", e.DNSZoneName)
+		}
+	}
+	log.Printf("Would destroy the disk encryption key and remove deletion liens.")
+	if protected {
+		log.Printf("The environment is protected: the teardown would require --force and typing the project ID.")
+	}
+	if w := e.maintenanceWindow(); w != nil {
+		if inside, err := w.Contains(e.clock().Now()); err == nil && !inside {
+			log.Printf("The teardown would currently be refused: outside the maintenance window (%s).", w)
+		}
+	}
+	return nil
+}
+
 func (e *CleanupExecutor) confirmDeletion() error {
 	log.Printf("WARNING: This will permanently delete the GCP project '%s' and all its resources.", e.ProjectID)
 	log.Printf("This action cannot be undone.\n")
@@ -180,7 +289,43 @@ func (e *CleanupExecutor) CleanupDNSRecords() error {
 		log.Printf("Skipping DNS cleanup: missing base domain or DNS zone name (provide --base-domain/--dns-zone-name or use --skip-dns-cleanup)")
 		return nil
 	}
-	return e.Deps.GCPClient.DeleteDNSRecordSets(e.DNSProjectID, e.DNSZoneName, e.BaseDomain)
+
+	provider, err := e.dnsProvider()
+	if err != nil {
+		return err
+	}
+
+	// Records annotated with a different owner belong to another environment
+	// reusing the base domain; deleting them would take that environment down.
+	ownership, err := provider.LookupRecord(e.DNSZoneName, e.BaseDomain, dns.OwnershipRecordName(e.BaseDomain), "TXT")
+	if err != nil {
+		return fmt.Errorf("failed to look up DNS ownership record: %w", err)
+	}
+	if owner := dns.OwnerFromRecord(ownership); owner != "" && owner != e.ProjectID {
+		log.Printf("Skipping DNS cleanup: records for %s are owned by %s, not %s", e.BaseDomain, owner, e.ProjectID)
+		return nil
+	}
+
+	if err := provider.DeleteRecords(e.DNSZoneName, e.BaseDomain); err != nil {
+		return err
+	}
+
+	if e.Opts.DeleteDNSZone {
+		if err := provider.DeleteZone(e.DNSZoneName, e.BaseDomain); err != nil {
+			return fmt.Errorf("failed to delete DNS zone: %w", err)
+		}
+		log.Printf("Deleted managed DNS zone %s", e.DNSZoneName)
+	}
+	return nil
+}
+
+// dnsProvider returns the DNS provider the environment was bootstrapped with,
+// defaulting to Cloud DNS when no infra file declares one.
+func (e *CleanupExecutor) dnsProvider() (dns.DNSProvider, error) {
+	if e.InfraFileLoaded && e.InfraEnv.DNSProvider == dns.ProviderRoute53 {
+		return dns.NewRoute53Provider(context.Background())
+	}
+	return NewCloudDNSProvider(e.Deps.GCPClient, e.DNSProjectID, nil, false), nil
 }
 
 // RemoveDNSIAMBinding removes the cloud-controller service account's IAM binding
@@ -192,6 +337,62 @@ func (e *CleanupExecutor) RemoveDNSIAMBinding() error {
 	return e.Deps.GCPClient.RemoveIAMRoleBinding(e.DNSProjectID, "cloud-controller", e.ProjectID, []string{"roles/dns.admin"})
 }
 
+// DestroyDiskEncryptionKey destroys all versions of a managed per-environment
+// disk encryption key, cryptographically erasing the encrypted disks. Keys
+// provided by the operator via --disk-encryption-key are left untouched.
+func (e *CleanupExecutor) DestroyDiskEncryptionKey() error {
+	if !e.InfraFileLoaded || !e.InfraEnv.ManagedDiskEncryptionKey || e.InfraEnv.DiskEncryptionKey == "" {
+		return nil
+	}
+
+	destroyed, err := e.Deps.GCPClient.DestroyKMSKeyVersions(e.InfraEnv.DiskEncryptionKey)
+	if err != nil {
+		return fmt.Errorf("failed to destroy disk encryption key versions: %w", err)
+	}
+
+	e.keyDestruction = &KeyDestruction{
+		KeyName:           e.InfraEnv.DiskEncryptionKey,
+		DestroyedVersions: destroyed,
+		DestroyedAt:       time.Now().UTC(),
+	}
+	log.Printf("Destroyed %d version(s) of disk encryption key %s; the encrypted disks are cryptographically erased", len(destroyed), e.InfraEnv.DiskEncryptionKey)
+	return nil
+}
+
+// WriteTeardownReport writes a report of the teardown, including the
+// destruction certificate for a managed disk encryption key, next to the
+// infra file. Failures only log a warning since the teardown itself succeeded.
+func (e *CleanupExecutor) WriteTeardownReport() {
+	report := TeardownReport{
+		ProjectID:      e.ProjectID,
+		CompletedAt:    time.Now().UTC(),
+		KeyDestruction: e.keyDestruction,
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Printf("Warning: failed to marshal teardown report: %v", err)
+		return
+	}
+
+	path := filepath.Join(filepath.Dir(e.Deps.InfraFilePath), fmt.Sprintf("teardown-report-%s.json", e.ProjectID))
+	if err := e.Deps.FileIO.WriteFile(path, data, 0644); err != nil {
+		log.Printf("Warning: failed to write teardown report: %v", err)
+		return
+	}
+	log.Printf("Teardown report written to %s", path)
+}
+
+// RemoveDeletionLiens removes the lien bootstrap placed on a protected
+// project so the confirmed teardown can delete it. Liens from other origins
+// are left in place and will still block the deletion.
+func (e *CleanupExecutor) RemoveDeletionLiens() error {
+	if !e.protected {
+		return nil
+	}
+	return e.Deps.GCPClient.RemoveProjectLiens(e.ProjectID)
+}
+
 // DeleteProject deletes the GCP project.
 func (e *CleanupExecutor) DeleteProject() error {
 	return e.Deps.GCPClient.DeleteProject(e.ProjectID)
@@ -0,0 +1,205 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"text/template"
+
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+)
+
+//go:embed manifests/cloud-controller-manager/daemonset.yaml
+var cloudControllerManagerTemplate []byte
+
+//go:embed manifests/cloud-controller-manager/cloud.conf
+var cloudConfigTemplate []byte
+
+const (
+	// DefaultCCMVersion is the cloud-provider-gcp release deployed when no
+	// version is selected.
+	DefaultCCMVersion = "v0.28.2"
+
+	// ccmImageRepository is the repository the CCM image is pulled from when
+	// no image override is configured.
+	ccmImageRepository = "k8scloudprovidergcp/cloud-controller-manager"
+)
+
+// ccmVersion returns the selected cloud-provider-gcp release, falling back to
+// the default.
+func (b *GCPBootstrapper) ccmVersion() string {
+	if b.Env.CCMVersion != "" {
+		return b.Env.CCMVersion
+	}
+	return DefaultCCMVersion
+}
+
+// ccmImage returns the configured cloud controller manager image, derived
+// from the selected release unless overridden.
+func (b *GCPBootstrapper) ccmImage() string {
+	if b.Env.CCMImage != "" {
+		return b.Env.CCMImage
+	}
+	return ccmImageRepository + ":" + b.ccmVersion()
+}
+
+// ccmManifestURL returns the upstream bundle with the RBAC rules and service
+// account of the cloud controller manager for the selected release.
+func (b *GCPBootstrapper) ccmManifestURL() string {
+	if b.Env.CCMManifestURL != "" {
+		return b.Env.CCMManifestURL
+	}
+	return fmt.Sprintf("https://raw.githubusercontent.com/kubernetes/cloud-provider-gcp/refs/tags/providers/%s/deploy/packages/default/manifest.yaml", b.ccmVersion())
+}
+
+// ensureCCMImage verifies the CCM image exists in its registry before
+// anything is applied to the cluster. A missing or retagged upstream image
+// would otherwise only surface as a crash-looping DaemonSet.
+func (b *GCPBootstrapper) ensureCCMImage() error {
+	image := b.ccmImage()
+	if err := b.imageChecker().ImageExists(image); err != nil {
+		return fmt.Errorf("cloud controller manager image %s not found in its registry: %w", image, err)
+	}
+	return nil
+}
+
+type cloudControllerTemplateData struct {
+	Image                   string
+	LeaderElectResourceName string
+}
+
+// renderCloudControllerManifest renders the cloud-controller-manager DaemonSet
+// for the selected distribution.
+func (b *GCPBootstrapper) renderCloudControllerManifest() ([]byte, error) {
+	tmpl, err := template.New("cloud-controller-manager").Parse(string(cloudControllerManagerTemplate))
+	if err != nil {
+		return nil, fmt.Errorf("parsing cloud-controller-manager template: %w", err)
+	}
+
+	data := cloudControllerTemplateData{
+		Image:                   b.ccmImage(),
+		LeaderElectResourceName: b.distribution().Name + "-gcp-ccm",
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("rendering cloud-controller-manager template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// renderCloudConfig renders the GCE cloud.conf handed to the cloud controller
+// manager.
+func (b *GCPBootstrapper) renderCloudConfig() ([]byte, error) {
+	tmpl, err := template.New("cloud.conf").Parse(string(cloudConfigTemplate))
+	if err != nil {
+		return nil, fmt.Errorf("parsing cloud.conf template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct{ ProjectID string }{ProjectID: b.Env.ProjectID}); err != nil {
+		return nil, fmt.Errorf("rendering cloud.conf template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ConfigureCloudController deploys the GCP cloud controller manager so the
+// cluster adopts the nodes and provisions load balancers, and points the
+// gateway services at their static IP addresses.
+func (b *GCPBootstrapper) ConfigureCloudController() error {
+	err := b.ensureCCMImage()
+	if err != nil {
+		return err
+	}
+
+	cloudConf, err := b.renderCloudConfig()
+	if err != nil {
+		return err
+	}
+	manifest, err := b.renderCloudControllerManifest()
+	if err != nil {
+		return err
+	}
+
+	k := b.kubectl()
+	controller := b.Env.ControlPlaneNodes[0]
+
+	err = b.fw.WriteFile("cloud.conf", cloudConf, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write cloud.conf: %w", err)
+	}
+	err = controller.NodeClient.CopyFile(controller, "cloud.conf", "/root/cloud.conf")
+	if err != nil {
+		return fmt.Errorf("failed to copy cloud.conf to %s: %w", controller.GetName(), err)
+	}
+	err = k.run("create configmap cloud-config --from-file=cloud.conf=/root/cloud.conf -n kube-system")
+	if err != nil {
+		return err
+	}
+
+	err = b.ensureKubectlAliases(controller)
+	if err != nil {
+		return err
+	}
+
+	err = k.run("apply -f " + b.ccmManifestURL())
+	if err != nil {
+		return err
+	}
+	err = k.applyManifest("cc-deployment.yaml", manifest)
+	if err != nil {
+		return err
+	}
+
+	// Point the gateway services at the static IPs the DNS records resolve to.
+	err = k.run(fmt.Sprintf(`patch svc public-gateway-controller -n codesphere -p '{"spec": {"loadBalancerIP": "%s"}}'`, b.Env.PublicGatewayIP))
+	if err != nil {
+		return err
+	}
+	err = k.run(fmt.Sprintf(`patch svc gateway-controller -n codesphere -p '{"spec": {"loadBalancerIP": "%s"}}'`, b.Env.GatewayIP))
+	if err != nil {
+		return err
+	}
+
+	return b.enableCloudProviderFlag()
+}
+
+// ensureKubectlAliases keeps kubectl and k working in interactive shells on
+// the control plane node.
+func (b *GCPBootstrapper) ensureKubectlAliases(controller *node.Node) error {
+	kubectl := b.distribution().KubectlCommand
+	cmd := fmt.Sprintf(`echo alias kubectl=\"%s\" >> /root/.bashrc && echo alias k=\"%s\" >> /root/.bashrc`, kubectl, kubectl)
+	err := controller.RunSSHCommand("root", cmd)
+	if err != nil {
+		return fmt.Errorf("failed to set kubectl aliases on %s: %w", controller.GetName(), err)
+	}
+	return nil
+}
+
+// enableCloudProviderFlag patches the systemd units of distributions that need
+// --enable-cloud-provider on the kubelet before the cloud controller manager
+// can adopt the nodes.
+func (b *GCPBootstrapper) enableCloudProviderFlag() error {
+	dist := b.distribution()
+	controllerCmd := dist.cloudProviderUnitPatch(dist.ControllerUnit)
+	if controllerCmd == "" {
+		return nil
+	}
+	controller := b.Env.ControlPlaneNodes[0]
+	err := controller.RunSSHCommand("root", controllerCmd)
+	if err != nil {
+		return fmt.Errorf("failed to enable cloud provider on %s: %w", controller.GetName(), err)
+	}
+
+	workerCmd := dist.cloudProviderUnitPatch(dist.WorkerUnit)
+	for _, worker := range b.Env.ControlPlaneNodes[1:] {
+		err := worker.RunSSHCommand("root", workerCmd)
+		if err != nil {
+			return fmt.Errorf("failed to enable cloud provider on worker %s: %w", worker.GetName(), err)
+		}
+	}
+	return nil
+}
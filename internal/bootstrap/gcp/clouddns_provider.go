@@ -0,0 +1,107 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+
+	googledns "google.golang.org/api/dns/v1"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap/dns"
+)
+
+// CloudDNSProvider implements dns.DNSProvider backed by Google Cloud DNS.
+type CloudDNSProvider struct {
+	client    GCPClientManager
+	projectID string
+	labels    map[string]string
+	dnssec    bool
+}
+
+// NewCloudDNSProvider creates a CloudDNSProvider managing zones in the given project.
+func NewCloudDNSProvider(client GCPClientManager, projectID string, labels map[string]string, dnssec bool) *CloudDNSProvider {
+	return &CloudDNSProvider{
+		client:    client,
+		projectID: projectID,
+		labels:    labels,
+		dnssec:    dnssec,
+	}
+}
+
+// EnsureZone ensures the managed zone exists in the DNS project.
+func (p *CloudDNSProvider) EnsureZone(zoneName, dnsName string) error {
+	return p.client.EnsureDNSManagedZone(p.projectID, zoneName, dnsName, dns.ManagedZoneComment, p.labels, p.dnssec)
+}
+
+// EnsureRecords creates or updates the given record sets in the managed zone.
+func (p *CloudDNSProvider) EnsureRecords(zoneName, dnsName string, records []dns.Record) error {
+	recordSets := make([]*googledns.ResourceRecordSet, len(records))
+	for i, record := range records {
+		recordSets[i] = &googledns.ResourceRecordSet{
+			Name:    record.Name,
+			Type:    record.Type,
+			Ttl:     record.TTL,
+			Rrdatas: record.Rrdatas,
+		}
+	}
+	return p.client.EnsureDNSRecordSets(p.projectID, zoneName, recordSets)
+}
+
+// DeleteRecords deletes the OMS-managed records for baseDomain from the managed zone.
+func (p *CloudDNSProvider) DeleteRecords(zoneName, baseDomain string) error {
+	return p.client.DeleteDNSRecordSets(p.projectID, zoneName, baseDomain)
+}
+
+// LookupRecord returns the record set with the given name and type from the
+// managed zone, or nil when it does not exist.
+func (p *CloudDNSProvider) LookupRecord(zoneName, baseDomain, name, rtype string) (*dns.Record, error) {
+	recordSet, err := p.client.GetDNSRecordSet(p.projectID, zoneName, name, rtype)
+	if err != nil || recordSet == nil {
+		return nil, err
+	}
+	return &dns.Record{
+		Name:    recordSet.Name,
+		Type:    recordSet.Type,
+		TTL:     recordSet.Ttl,
+		Rrdatas: recordSet.Rrdatas,
+	}, nil
+}
+
+// DeleteZone deletes the managed zone. Zones without the oms-managed label
+// were not created by OMS and are refused; a zone that no longer exists is a
+// no-op.
+func (p *CloudDNSProvider) DeleteZone(zoneName, baseDomain string) error {
+	zone, err := p.client.GetDNSManagedZone(p.projectID, zoneName)
+	if err != nil {
+		return err
+	}
+	if zone == nil {
+		return nil
+	}
+	if !CheckOMSManagedLabel(zone.Labels) {
+		return fmt.Errorf("managed zone %s was not created by OMS, refusing to delete it", zoneName)
+	}
+	return p.client.DeleteDNSManagedZone(p.projectID, zoneName)
+}
+
+// dnsProvider returns the DNS provider configured for the environment,
+// defaulting to Cloud DNS. Tests can inject a provider via DNSClient.
+func (b *GCPBootstrapper) dnsProvider() (dns.DNSProvider, error) {
+	if b.DNSClient != nil {
+		return b.DNSClient, nil
+	}
+
+	switch b.Env.DNSProvider {
+	case "", dns.ProviderCloudDNS:
+		projectID := b.Env.DNSProjectID
+		if projectID == "" {
+			projectID = b.Env.ProjectID
+		}
+		return NewCloudDNSProvider(b.GCPClient, projectID, b.resourceLabels(), b.Env.DNSSEC), nil
+	case dns.ProviderRoute53:
+		return dns.NewRoute53Provider(b.ctx)
+	default:
+		return nil, fmt.Errorf("unknown DNS provider %q (options: %s, %s)", b.Env.DNSProvider, dns.ProviderCloudDNS, dns.ProviderRoute53)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/codesphere-cloud/oms/internal/version"
+)
+
+// buildCloudInitUserData renders the cloud-init user-data attached to every
+// instance, so most node preparation already happens at first boot: sysctl
+// values for inotify and memory maps, root login over SSH, and on the jumpbox
+// the AcceptEnv configuration and OMS CLI install. The SSH-based Ensure steps
+// still run afterwards; on a node prepared by cloud-init their checks pass and
+// they become cheap no-ops, while nodes booted from images without cloud-init
+// are configured over SSH as before.
+func (b *GCPBootstrapper) buildCloudInitUserData(vmType string) string {
+	nodeOS := b.nodeOS()
+
+	sysctlLines := []string{
+		"fs.inotify.max_user_watches=1048576",
+		"fs.inotify.max_user_instances=8192",
+		"vm.max_map_count=262144",
+	}
+
+	commands := []string{
+		nodeOS.ApplySysctlCommand(),
+		`sed -i 's/^#\?PermitRootLogin.*/PermitRootLogin yes/' /etc/ssh/sshd_config`,
+		// The guest agent may not have written authorized_keys yet; the
+		// SSH-based fallback strips the restrictions in that case.
+		`test ! -f /root/.ssh/authorized_keys || sed -i 's/no-port-forwarding.*$//g' /root/.ssh/authorized_keys`,
+	}
+
+	if vmType == "jumpbox" {
+		commands = append(commands,
+			`grep -qxF 'AcceptEnv OMS_PORTAL_API_KEY OMS_PORTAL_API' /etc/ssh/sshd_config || printf '\nAcceptEnv OMS_PORTAL_API_KEY OMS_PORTAL_API\n' >> /etc/ssh/sshd_config`,
+		)
+		// A locally supplied binary is copied over SSH instead (see
+		// RemoteOmsBinaryPath), so only the release install moves to first boot.
+		if b.Env.RemoteOmsBinaryPath == "" {
+			build := version.Build{}
+			commands = append(commands, omsInstallCommands(build.Version())...)
+		}
+	}
+
+	commands = append(commands, "systemctl restart sshd")
+
+	var sb strings.Builder
+	sb.WriteString("#cloud-config\n")
+	sb.WriteString("write_files:\n")
+	sb.WriteString(fmt.Sprintf("  - path: %s\n", nodeOS.SysctlConfigPath()))
+	sb.WriteString("    append: true\n")
+	sb.WriteString("    content: |\n")
+	for _, line := range sysctlLines {
+		sb.WriteString("      " + line + "\n")
+	}
+	sb.WriteString(fmt.Sprintf("  - path: %s\n", node.SSHDHardeningPath))
+	sb.WriteString("    content: |\n")
+	for _, line := range strings.Split(strings.TrimSuffix(node.SSHDHardeningConfig(b.sshPort()), "\n"), "\n") {
+		sb.WriteString("      " + line + "\n")
+	}
+	sb.WriteString("runcmd:\n")
+	for _, cmd := range commands {
+		// Each command as a literal block scalar, so shell quoting survives
+		// the YAML round trip unescaped.
+		sb.WriteString("  - |\n")
+		for _, line := range strings.Split(cmd, "\n") {
+			sb.WriteString("    " + line + "\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// omsInstallCommands returns the shell commands that download, verify and
+// install the OMS CLI release matching version; development builds (empty or
+// 0.0.0 version) fall back to the latest release. Mirrors Node.InstallOms.
+func omsInstallCommands(version string) []string {
+	release := "latest"
+	if version != "" && version != "0.0.0" {
+		release = "tags/v" + strings.TrimPrefix(version, "v")
+	}
+	releaseURL := fmt.Sprintf("https://api.github.com/repos/codesphere-cloud/oms/releases/%s", release)
+
+	return []string{
+		fmt.Sprintf("wget -qO- '%s' | jq -r '.assets[] | select(.name | match(\"oms.*linux_amd64$\")) | .browser_download_url' | xargs wget -qO oms", releaseURL),
+		fmt.Sprintf("wget -qO- '%s' | jq -r '.assets[] | select(.name | test(\"checksums\")) | .browser_download_url' | xargs wget -qO oms-checksums.txt", releaseURL),
+		"grep -E ' oms[^ ]*linux_amd64$' oms-checksums.txt | awk '{print $1 \"  oms\"}' | sha256sum -c -",
+		"chmod +x oms; mv oms /usr/local/bin/; rm -f oms-checksums.txt",
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/codesphere-cloud/oms/internal/codesphere"
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/codesphere-cloud/oms/internal/installer/secrets"
+	"github.com/codesphere-cloud/oms/internal/testuser"
+	sqladmin "google.golang.org/api/sqladmin/v1"
+)
+
+// CloudSQLInstanceName is the name of the managed Cloud SQL instance, taking
+// the place of the postgres VM in cloudsql mode.
+const CloudSQLInstanceName = "postgres"
+
+// cloudSQLDatabaseVersion pins the PostgreSQL major version of the managed
+// instance to the one the postgres VM would run.
+const cloudSQLDatabaseVersion = "POSTGRES_16"
+
+// cloudSQLTier matches the postgres VM shape (e2-standard-2: 2 vCPUs, 8 GB).
+const cloudSQLTier = "db-custom-2-8192"
+
+// EnsureCloudSQLInstance ensures a Cloud SQL for PostgreSQL instance exists
+// with a private IP in the environment VPC, waits until it is RUNNABLE, and
+// records its private IP for the install config.
+func (b *GCPBootstrapper) EnsureCloudSQLInstance() error {
+	networkName := fmt.Sprintf("%s-vpc", b.Env.ProjectID)
+	if err := b.GCPClient.EnsurePrivateServiceAccess(b.Env.ProjectID, networkName); err != nil {
+		return fmt.Errorf("failed to ensure private service access: %w", err)
+	}
+
+	existing, err := b.GCPClient.GetCloudSQLInstance(b.Env.ProjectID, CloudSQLInstanceName)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		b.stlog.Logf("Creating Cloud SQL instance %s", CloudSQLInstanceName)
+		instance := &sqladmin.DatabaseInstance{
+			Name:            CloudSQLInstanceName,
+			Region:          b.Env.Region,
+			DatabaseVersion: cloudSQLDatabaseVersion,
+			Settings: &sqladmin.Settings{
+				Tier:       cloudSQLTier,
+				UserLabels: b.resourceLabels(),
+				IpConfiguration: &sqladmin.IpConfiguration{
+					Ipv4Enabled:    false,
+					PrivateNetwork: fmt.Sprintf("projects/%s/global/networks/%s", b.Env.ProjectID, networkName),
+				},
+			},
+		}
+		if err := b.GCPClient.CreateCloudSQLInstance(b.Env.ProjectID, instance); err != nil {
+			return err
+		}
+	} else {
+		b.stlog.Logf("Cloud SQL instance %s already exists", CloudSQLInstanceName)
+	}
+
+	instance, err := b.waitForCloudSQLRunnable()
+	if err != nil {
+		return err
+	}
+
+	for _, address := range instance.IpAddresses {
+		if address.Type == "PRIVATE" {
+			b.Env.CloudSQLInstanceIP = address.IpAddress
+		}
+	}
+	if b.Env.CloudSQLInstanceIP == "" {
+		return fmt.Errorf("Cloud SQL instance %s has no private IP", CloudSQLInstanceName)
+	}
+	b.stlog.Logf("Cloud SQL instance %s is RUNNABLE at %s", CloudSQLInstanceName, b.Env.CloudSQLInstanceIP)
+
+	return nil
+}
+
+// waitForCloudSQLRunnable polls the instance until it reports RUNNABLE.
+// Instance creation routinely takes several minutes.
+func (b *GCPBootstrapper) waitForCloudSQLRunnable() (*sqladmin.DatabaseInstance, error) {
+	const (
+		maxAttempts  = 120
+		pollInterval = 10 * time.Second
+	)
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		instance, err := b.GCPClient.GetCloudSQLInstance(b.Env.ProjectID, CloudSQLInstanceName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to poll Cloud SQL instance %s: %w", CloudSQLInstanceName, err)
+		}
+		if instance != nil && instance.State == "RUNNABLE" {
+			return instance, nil
+		}
+		if attempt < maxAttempts-1 {
+			b.Time.Sleep(pollInterval)
+		}
+	}
+	return nil, fmt.Errorf("timed out waiting for Cloud SQL instance %s to be RUNNABLE after %s",
+		CloudSQLInstanceName, pollInterval*time.Duration(maxAttempts))
+}
+
+// EnsureCloudSQLUsers creates the codesphere database, the admin user, and the
+// per-service users on the Cloud SQL instance through the admin API, using the
+// credentials generated into the vault. Passwords are converged on the vault
+// state, so rotated secrets take effect on re-runs.
+func (b *GCPBootstrapper) EnsureCloudSQLUsers() error {
+	vault := b.icg.GetVault()
+
+	// The admin password is normally generated together with the postgres VM
+	// certificates, which external postgres skips.
+	adminSecret := vault.GetSecret(files.SecretPostgresPassword)
+	if adminSecret == nil || adminSecret.Fields == nil {
+		adminPwd, err := secrets.GeneratePassword(32)
+		if err != nil {
+			return fmt.Errorf("failed to generate postgres admin password: %w", err)
+		}
+		vault.SetSecret(files.SecretEntry{Name: files.SecretPostgresPassword, Fields: &files.SecretFields{Password: adminPwd}})
+		if err := b.icg.WriteVault(b.Env.SecretsFilePath, true); err != nil {
+			return fmt.Errorf("failed to write vault file: %w", err)
+		}
+		adminSecret = vault.GetSecret(files.SecretPostgresPassword)
+	}
+	err := b.GCPClient.EnsureCloudSQLUser(b.Env.ProjectID, CloudSQLInstanceName, testuser.DefaultUser, adminSecret.Fields.Password)
+	if err != nil {
+		return fmt.Errorf("failed to ensure admin user: %w", err)
+	}
+
+	err = b.GCPClient.EnsureCloudSQLDatabase(b.Env.ProjectID, CloudSQLInstanceName, testuser.DefaultDBName)
+	if err != nil {
+		return fmt.Errorf("failed to ensure database %s: %w", testuser.DefaultDBName, err)
+	}
+
+	for _, svc := range codesphere.PostgresServices {
+		pwdSecret := vault.GetSecret(fmt.Sprintf("postgresPassword%s", files.Capitalize(svc.Name)))
+		if pwdSecret == nil || pwdSecret.Fields == nil {
+			return fmt.Errorf("postgres password for %s not found in vault", svc.Name)
+		}
+		err := b.GCPClient.EnsureCloudSQLUser(b.Env.ProjectID, CloudSQLInstanceName, svc.DBUsername(), pwdSecret.Fields.Password)
+		if err != nil {
+			return fmt.Errorf("failed to ensure user %s: %w", svc.DBUsername(), err)
+		}
+	}
+
+	return nil
+}
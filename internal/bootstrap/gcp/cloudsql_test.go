@@ -0,0 +1,170 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/cloudflare"
+	"github.com/codesphere-cloud/oms/internal/codesphere"
+	"github.com/codesphere-cloud/oms/internal/env"
+	"github.com/codesphere-cloud/oms/internal/github"
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/codesphere-cloud/oms/internal/portal"
+	"github.com/codesphere-cloud/oms/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	sqladmin "google.golang.org/api/sqladmin/v1"
+)
+
+var _ = Describe("Cloud SQL", func() {
+	var (
+		gc    *gcp.MockGCPClientManager
+		icg   *installer.MockInstallConfigManager
+		csEnv *gcp.CodesphereEnvironment
+
+		bs *gcp.GCPBootstrapper
+	)
+
+	JustBeforeEach(func() {
+		var err error
+		bs, err = gcp.NewGCPBootstrapper(
+			context.Background(),
+			env.NewEnv(),
+			bootstrap.NewStepLogger(false),
+			csEnv,
+			icg,
+			gc,
+			util.NewMockFileIO(GinkgoT()),
+			node.NewMockNodeClient(GinkgoT()),
+			portal.NewMockPortal(GinkgoT()),
+			util.NewFakeTime(),
+			github.NewMockGitHubClient(GinkgoT()),
+			cloudflare.NewMockCloudflareClient(GinkgoT()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	BeforeEach(func() {
+		gc = gcp.NewMockGCPClientManager(GinkgoT())
+		icg = installer.NewMockInstallConfigManager(GinkgoT())
+
+		csEnv = &gcp.CodesphereEnvironment{
+			ProjectID:       "fake-project",
+			Region:          "europe-west4",
+			PostgresMode:    gcp.PostgresModeCloudSQL,
+			SecretsFilePath: "fake-secret",
+		}
+	})
+
+	Describe("EnsureCloudSQLInstance", func() {
+		runnable := &sqladmin.DatabaseInstance{
+			Name:  gcp.CloudSQLInstanceName,
+			State: "RUNNABLE",
+			IpAddresses: []*sqladmin.IpMapping{
+				{Type: "PRIVATE", IpAddress: "10.20.0.3"},
+			},
+		}
+
+		It("creates the instance and records its private IP", func() {
+			gc.EXPECT().EnsurePrivateServiceAccess("fake-project", "fake-project-vpc").Return(nil)
+			gc.EXPECT().GetCloudSQLInstance("fake-project", gcp.CloudSQLInstanceName).Return(nil, nil).Once()
+			gc.EXPECT().CreateCloudSQLInstance("fake-project", mock.MatchedBy(func(instance *sqladmin.DatabaseInstance) bool {
+				return instance.Name == gcp.CloudSQLInstanceName &&
+					instance.Region == "europe-west4" &&
+					instance.Settings != nil &&
+					!instance.Settings.IpConfiguration.Ipv4Enabled &&
+					instance.Settings.IpConfiguration.PrivateNetwork == "projects/fake-project/global/networks/fake-project-vpc"
+			})).Return(nil)
+			gc.EXPECT().GetCloudSQLInstance("fake-project", gcp.CloudSQLInstanceName).Return(runnable, nil).Once()
+
+			err := bs.EnsureCloudSQLInstance()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(csEnv.CloudSQLInstanceIP).To(Equal("10.20.0.3"))
+		})
+
+		It("adopts an existing instance without creating it", func() {
+			gc.EXPECT().EnsurePrivateServiceAccess("fake-project", "fake-project-vpc").Return(nil)
+			gc.EXPECT().GetCloudSQLInstance("fake-project", gcp.CloudSQLInstanceName).Return(runnable, nil).Times(2)
+
+			err := bs.EnsureCloudSQLInstance()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(csEnv.CloudSQLInstanceIP).To(Equal("10.20.0.3"))
+		})
+
+		It("fails when the instance has no private IP", func() {
+			public := &sqladmin.DatabaseInstance{
+				Name:        gcp.CloudSQLInstanceName,
+				State:       "RUNNABLE",
+				IpAddresses: []*sqladmin.IpMapping{{Type: "PRIMARY", IpAddress: "34.1.2.3"}},
+			}
+			gc.EXPECT().EnsurePrivateServiceAccess("fake-project", "fake-project-vpc").Return(nil)
+			gc.EXPECT().GetCloudSQLInstance("fake-project", gcp.CloudSQLInstanceName).Return(public, nil).Times(2)
+
+			err := bs.EnsureCloudSQLInstance()
+			Expect(err).To(MatchError(ContainSubstring("no private IP")))
+		})
+	})
+
+	Describe("EnsureCloudSQLUsers", func() {
+		var vault *files.InstallVault
+
+		BeforeEach(func() {
+			vault = &files.InstallVault{}
+			icg.EXPECT().GetVault().Return(vault).Maybe()
+		})
+
+		It("ensures the database, admin user and all service users", func() {
+			vault.SetSecret(files.SecretEntry{Name: files.SecretPostgresPassword, Fields: &files.SecretFields{Password: "admin-pwd"}})
+			for _, svc := range codesphere.PostgresServices {
+				vault.SetSecret(files.SecretEntry{
+					Name:   fmt.Sprintf("postgresPassword%s", files.Capitalize(svc.Name)),
+					Fields: &files.SecretFields{Password: "pwd-" + svc.Name},
+				})
+			}
+
+			gc.EXPECT().EnsureCloudSQLUser("fake-project", gcp.CloudSQLInstanceName, "postgres", "admin-pwd").Return(nil)
+			gc.EXPECT().EnsureCloudSQLDatabase("fake-project", gcp.CloudSQLInstanceName, "codesphere").Return(nil)
+			for _, svc := range codesphere.PostgresServices {
+				gc.EXPECT().EnsureCloudSQLUser("fake-project", gcp.CloudSQLInstanceName, svc.DBUsername(), "pwd-"+svc.Name).Return(nil)
+			}
+
+			Expect(bs.EnsureCloudSQLUsers()).To(Succeed())
+		})
+
+		It("generates the admin password when the vault has none", func() {
+			for _, svc := range codesphere.PostgresServices {
+				vault.SetSecret(files.SecretEntry{
+					Name:   fmt.Sprintf("postgresPassword%s", files.Capitalize(svc.Name)),
+					Fields: &files.SecretFields{Password: "pwd-" + svc.Name},
+				})
+			}
+
+			icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
+			gc.EXPECT().EnsureCloudSQLUser("fake-project", gcp.CloudSQLInstanceName, "postgres", mock.Anything).Return(nil)
+			gc.EXPECT().EnsureCloudSQLDatabase("fake-project", gcp.CloudSQLInstanceName, "codesphere").Return(nil)
+			for _, svc := range codesphere.PostgresServices {
+				gc.EXPECT().EnsureCloudSQLUser("fake-project", gcp.CloudSQLInstanceName, svc.DBUsername(), "pwd-"+svc.Name).Return(nil)
+			}
+
+			Expect(bs.EnsureCloudSQLUsers()).To(Succeed())
+			Expect(vault.GetSecret(files.SecretPostgresPassword)).NotTo(BeNil())
+		})
+
+		It("fails when a service password is missing from the vault", func() {
+			vault.SetSecret(files.SecretEntry{Name: files.SecretPostgresPassword, Fields: &files.SecretFields{Password: "admin-pwd"}})
+
+			gc.EXPECT().EnsureCloudSQLUser("fake-project", gcp.CloudSQLInstanceName, "postgres", "admin-pwd").Return(nil)
+			gc.EXPECT().EnsureCloudSQLDatabase("fake-project", gcp.CloudSQLInstanceName, "codesphere").Return(nil)
+
+			Expect(bs.EnsureCloudSQLUsers()).To(MatchError(ContainSubstring("not found in vault")))
+		})
+	})
+})
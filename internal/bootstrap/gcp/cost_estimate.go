@@ -0,0 +1,194 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"google.golang.org/api/cloudbilling/v1"
+)
+
+// hoursPerMonth is the averaged number of hours GCP uses for monthly list prices.
+const hoursPerMonth = 730
+
+// CostLine is a single position of a cost estimate.
+type CostLine struct {
+	Description string
+	Quantity    string
+	MonthlyCost float64
+}
+
+// CostEstimate approximates the monthly cost of the planned topology based on
+// on-demand list prices from the Cloud Billing Catalog.
+type CostEstimate struct {
+	Lines    []CostLine
+	Currency string
+}
+
+// Total returns the summed monthly cost of all estimate lines.
+func (e *CostEstimate) Total() float64 {
+	total := 0.0
+	for _, line := range e.Lines {
+		total += line.MonthlyCost
+	}
+	return total
+}
+
+// Print writes the estimate as a table, followed by the total and a reminder
+// that list prices do not include discounts.
+func (e *CostEstimate) Print(out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RESOURCE\tQUANTITY\tMONTHLY COST")
+	for _, line := range e.Lines {
+		fmt.Fprintf(w, "%s\t%s\t%.2f %s\n", line.Description, line.Quantity, line.MonthlyCost, e.Currency)
+	}
+	fmt.Fprintf(w, "TOTAL\t\t%.2f %s\n", e.Total(), e.Currency)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintln(out, "\nEstimate based on on-demand list prices; sustained use and committed use discounts are not included.")
+	return err
+}
+
+// EstimateCost approximates the monthly cost of the planned topology (VMs,
+// disks, static IPs, NAT) using Compute Engine SKUs from the Cloud Billing
+// Catalog for the configured region.
+func (b *GCPBootstrapper) EstimateCost() (*CostEstimate, error) {
+	skus, err := b.GCPClient.ListComputeSKUs(b.Env.Region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Compute Engine SKUs: %w", err)
+	}
+
+	estimate := &CostEstimate{}
+
+	externalIPs := 0
+	totalDiskGB := int64(0)
+	for _, vm := range b.activeVMDefs() {
+		cores, memGB, err := machineTypeResources(vm.MachineType)
+		if err != nil {
+			return nil, err
+		}
+
+		family := strings.ToUpper(strings.SplitN(vm.MachineType, "-", 2)[0])
+		coreRate, currency, err := findSKURate(skus, family+" Instance Core")
+		if err != nil {
+			return nil, err
+		}
+		ramRate, _, err := findSKURate(skus, family+" Instance Ram")
+		if err != nil {
+			return nil, err
+		}
+		estimate.Currency = currency
+
+		monthly := (float64(cores)*coreRate + memGB*ramRate) * hoursPerMonth
+		estimate.Lines = append(estimate.Lines, CostLine{
+			Description: fmt.Sprintf("VM %s (%s)", vm.Name, vm.MachineType),
+			Quantity:    fmt.Sprintf("%d vCPU / %.0f GB", cores, memGB),
+			MonthlyCost: monthly,
+		})
+
+		totalDiskGB += b.Env.RootDiskSize
+		for _, diskSize := range vm.AdditionalDisks {
+			totalDiskGB += diskSize
+		}
+		if vm.ExternalIP && !b.Env.UseIAPTunnel {
+			externalIPs++
+		}
+	}
+
+	// pd-ssd capacity is priced per GiB-month.
+	diskRate, _, err := findSKURate(skus, "SSD backed PD Capacity")
+	if err != nil {
+		return nil, err
+	}
+	estimate.Lines = append(estimate.Lines, CostLine{
+		Description: "pd-ssd disks",
+		Quantity:    fmt.Sprintf("%d GB", totalDiskGB),
+		MonthlyCost: float64(totalDiskGB) * diskRate,
+	})
+
+	// The gateway, public gateway, and SSH proxy addresses are always
+	// reserved, plus one address per VM with an external IP.
+	staticIPs := 3 + externalIPs
+	ipRate, _, err := findSKURate(skus, "Static Ip Charge")
+	if err != nil {
+		return nil, err
+	}
+	estimate.Lines = append(estimate.Lines, CostLine{
+		Description: "Static external IPs",
+		Quantity:    strconv.Itoa(staticIPs),
+		MonthlyCost: float64(staticIPs) * ipRate * hoursPerMonth,
+	})
+
+	natRate, _, err := findSKURate(skus, "NAT Gateway")
+	if err != nil {
+		return nil, err
+	}
+	estimate.Lines = append(estimate.Lines, CostLine{
+		Description: "Cloud NAT gateway",
+		Quantity:    "1",
+		MonthlyCost: natRate * hoursPerMonth,
+	})
+
+	return estimate, nil
+}
+
+// machineTypeResources returns the vCPU and memory sizing of a machine type.
+// Shared-core types are listed explicitly; the predefined families follow the
+// <family>-<kind>-<vCPUs> naming scheme.
+func machineTypeResources(machineType string) (cores int, memGB float64, err error) {
+	switch machineType {
+	case "e2-micro":
+		return 2, 1, nil
+	case "e2-small":
+		return 2, 2, nil
+	case "e2-medium":
+		return 2, 4, nil
+	}
+
+	parts := strings.Split(machineType, "-")
+	if len(parts) == 3 {
+		if n, convErr := strconv.Atoi(parts[2]); convErr == nil {
+			switch parts[1] {
+			case "standard":
+				return n, 4 * float64(n), nil
+			case "highmem":
+				return n, 8 * float64(n), nil
+			case "highcpu":
+				return n, float64(n), nil
+			}
+		}
+	}
+
+	return 0, 0, fmt.Errorf("cannot estimate cost for unknown machine type %s", machineType)
+}
+
+// findSKURate returns the on-demand unit price of the first SKU whose
+// description contains the given pattern, along with its currency.
+func findSKURate(skus []*cloudbilling.Sku, pattern string) (rate float64, currency string, err error) {
+	for _, sku := range skus {
+		if sku.Category == nil || sku.Category.UsageType != "OnDemand" {
+			continue
+		}
+		if !strings.Contains(sku.Description, pattern) {
+			continue
+		}
+		if len(sku.PricingInfo) == 0 || sku.PricingInfo[0].PricingExpression == nil {
+			continue
+		}
+		rates := sku.PricingInfo[0].PricingExpression.TieredRates
+		if len(rates) == 0 || rates[len(rates)-1].UnitPrice == nil {
+			continue
+		}
+		price := rates[len(rates)-1].UnitPrice
+		return float64(price.Units) + float64(price.Nanos)/1e9, price.CurrencyCode, nil
+	}
+
+	return 0, "", fmt.Errorf("no Compute Engine SKU found matching %q", pattern)
+}
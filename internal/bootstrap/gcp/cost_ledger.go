@@ -0,0 +1,191 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+)
+
+// BillableResourceKind classifies a recorded resource for pricing.
+type BillableResourceKind string
+
+const (
+	BillableVM       BillableResourceKind = "vm"
+	BillableDisk     BillableResourceKind = "disk"
+	BillableStaticIP BillableResourceKind = "static-ip"
+)
+
+// BillableResource is a billable GCP resource created during a bootstrap run,
+// attributed to the step that created it.
+type BillableResource struct {
+	Step        string
+	Kind        BillableResourceKind
+	Name        string
+	MachineType string // set for VMs
+	SizeGB      int64  // set for disks
+}
+
+// CostLedger records the billable resources each bootstrap step creates, so the
+// run summary can report the incremental monthly cost the run added. Resources
+// that already existed before the run are not recorded, which keeps re-runs at
+// zero incremental cost.
+type CostLedger struct {
+	mu        sync.Mutex
+	step      string
+	Resources []BillableResource
+}
+
+// SetStep attributes subsequently recorded resources to the given step.
+func (l *CostLedger) SetStep(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.step = name
+}
+
+// RecordVM records a newly created compute instance.
+func (l *CostLedger) RecordVM(name, machineType string) {
+	l.record(BillableResource{Kind: BillableVM, Name: name, MachineType: machineType})
+}
+
+// RecordDisk records newly created pd-ssd capacity.
+func (l *CostLedger) RecordDisk(name string, sizeGB int64) {
+	l.record(BillableResource{Kind: BillableDisk, Name: name, SizeGB: sizeGB})
+}
+
+// RecordStaticIP records a newly reserved static external IP address.
+func (l *CostLedger) RecordStaticIP(name string) {
+	l.record(BillableResource{Kind: BillableStaticIP, Name: name})
+}
+
+func (l *CostLedger) record(resource BillableResource) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	resource.Step = l.step
+	l.Resources = append(l.Resources, resource)
+}
+
+// StepCost groups the estimated monthly cost of the resources one step created.
+type StepCost struct {
+	Step  string
+	Lines []CostLine
+}
+
+// RunCost is the per-step cost accounting of a bootstrap run, estimating the
+// incremental monthly cost of the resources the run created.
+type RunCost struct {
+	Steps    []StepCost
+	Currency string
+}
+
+// Total returns the summed monthly cost across all steps.
+func (c *RunCost) Total() float64 {
+	total := 0.0
+	for _, step := range c.Steps {
+		for _, line := range step.Lines {
+			total += line.MonthlyCost
+		}
+	}
+	return total
+}
+
+// Print writes the per-step cost accounting as a table, followed by the total.
+func (c *RunCost) Print(out io.Writer) error {
+	if _, err := fmt.Fprintln(out, "Resources created during this run:"); err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STEP\tRESOURCE\tQUANTITY\tMONTHLY COST")
+	for _, step := range c.Steps {
+		for _, line := range step.Lines {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%.2f %s\n", step.Step, line.Description, line.Quantity, line.MonthlyCost, c.Currency)
+		}
+	}
+	fmt.Fprintf(w, "TOTAL\t\t\t%.2f %s\n", c.Total(), c.Currency)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintln(out, "\nIncremental estimate based on on-demand list prices; resources that already existed are not included.")
+	return err
+}
+
+// ReportRunCost prices the billable resources recorded in the ledger using
+// Compute Engine SKUs, grouped by the step that created them. Runs that
+// created no billable resources yield an empty report without a SKU lookup.
+func (b *GCPBootstrapper) ReportRunCost() (*RunCost, error) {
+	resources := b.Ledger.Resources
+	cost := &RunCost{}
+	if len(resources) == 0 {
+		return cost, nil
+	}
+
+	skus, err := b.GCPClient.ListComputeSKUs(b.Env.Region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Compute Engine SKUs: %w", err)
+	}
+
+	stepIndex := map[string]int{}
+	addLine := func(step string, line CostLine) {
+		i, ok := stepIndex[step]
+		if !ok {
+			i = len(cost.Steps)
+			stepIndex[step] = i
+			cost.Steps = append(cost.Steps, StepCost{Step: step})
+		}
+		cost.Steps[i].Lines = append(cost.Steps[i].Lines, line)
+	}
+
+	for _, resource := range resources {
+		switch resource.Kind {
+		case BillableVM:
+			cores, memGB, err := machineTypeResources(resource.MachineType)
+			if err != nil {
+				return nil, err
+			}
+			family := strings.ToUpper(strings.SplitN(resource.MachineType, "-", 2)[0])
+			coreRate, currency, err := findSKURate(skus, family+" Instance Core")
+			if err != nil {
+				return nil, err
+			}
+			ramRate, _, err := findSKURate(skus, family+" Instance Ram")
+			if err != nil {
+				return nil, err
+			}
+			cost.Currency = currency
+			addLine(resource.Step, CostLine{
+				Description: fmt.Sprintf("VM %s (%s)", resource.Name, resource.MachineType),
+				Quantity:    fmt.Sprintf("%d vCPU / %.0f GB", cores, memGB),
+				MonthlyCost: (float64(cores)*coreRate + memGB*ramRate) * hoursPerMonth,
+			})
+		case BillableDisk:
+			diskRate, currency, err := findSKURate(skus, "SSD backed PD Capacity")
+			if err != nil {
+				return nil, err
+			}
+			cost.Currency = currency
+			addLine(resource.Step, CostLine{
+				Description: fmt.Sprintf("pd-ssd disks %s", resource.Name),
+				Quantity:    fmt.Sprintf("%d GB", resource.SizeGB),
+				MonthlyCost: float64(resource.SizeGB) * diskRate,
+			})
+		case BillableStaticIP:
+			ipRate, currency, err := findSKURate(skus, "Static Ip Charge")
+			if err != nil {
+				return nil, err
+			}
+			cost.Currency = currency
+			addLine(resource.Step, CostLine{
+				Description: fmt.Sprintf("Static external IP %s", resource.Name),
+				Quantity:    "1",
+				MonthlyCost: ipRate * hoursPerMonth,
+			})
+		}
+	}
+
+	return cost, nil
+}
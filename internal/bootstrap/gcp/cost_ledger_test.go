@@ -0,0 +1,150 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"context"
+	"strings"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/cloudflare"
+	"github.com/codesphere-cloud/oms/internal/env"
+	"github.com/codesphere-cloud/oms/internal/github"
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/codesphere-cloud/oms/internal/portal"
+	"github.com/codesphere-cloud/oms/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/api/cloudbilling/v1"
+)
+
+var _ = Describe("Cost ledger", func() {
+	var (
+		gc    *gcp.MockGCPClientManager
+		csEnv *gcp.CodesphereEnvironment
+
+		bs *gcp.GCPBootstrapper
+	)
+
+	sku := func(description string, rate float64) *cloudbilling.Sku {
+		return &cloudbilling.Sku{
+			Description: description,
+			Category:    &cloudbilling.Category{UsageType: "OnDemand"},
+			PricingInfo: []*cloudbilling.PricingInfo{{
+				PricingExpression: &cloudbilling.PricingExpression{
+					TieredRates: []*cloudbilling.TierRate{{
+						UnitPrice: &cloudbilling.Money{Nanos: int64(rate * 1e9), CurrencyCode: "USD"},
+					}},
+				},
+			}},
+		}
+	}
+
+	JustBeforeEach(func() {
+		var err error
+		bs, err = gcp.NewGCPBootstrapper(
+			context.Background(),
+			env.NewEnv(),
+			bootstrap.NewStepLogger(false),
+			csEnv,
+			installer.NewMockInstallConfigManager(GinkgoT()),
+			gc,
+			util.NewMockFileIO(GinkgoT()),
+			node.NewMockNodeClient(GinkgoT()),
+			portal.NewMockPortal(GinkgoT()),
+			util.NewFakeTime(),
+			github.NewMockGitHubClient(GinkgoT()),
+			cloudflare.NewMockCloudflareClient(GinkgoT()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	BeforeEach(func() {
+		gc = gcp.NewMockGCPClientManager(GinkgoT())
+
+		csEnv = &gcp.CodesphereEnvironment{
+			ProjectID: "fake-project",
+			Region:    "europe-west4",
+		}
+	})
+
+	Describe("CostLedger", func() {
+		It("attributes recorded resources to the current step", func() {
+			ledger := &gcp.CostLedger{}
+			ledger.SetStep("Ensure gateway IP addresses")
+			ledger.RecordStaticIP("gateway")
+			ledger.SetStep("Ensure compute instances")
+			ledger.RecordVM("jumpbox", "e2-medium")
+			ledger.RecordDisk("jumpbox", 50)
+
+			Expect(ledger.Resources).To(HaveLen(3))
+			Expect(ledger.Resources[0].Step).To(Equal("Ensure gateway IP addresses"))
+			Expect(ledger.Resources[0].Kind).To(Equal(gcp.BillableStaticIP))
+			Expect(ledger.Resources[1].Step).To(Equal("Ensure compute instances"))
+			Expect(ledger.Resources[1].MachineType).To(Equal("e2-medium"))
+			Expect(ledger.Resources[2].SizeGB).To(Equal(int64(50)))
+		})
+	})
+
+	Describe("ReportRunCost", func() {
+		It("prices the recorded resources per step", func() {
+			gc.EXPECT().ListComputeSKUs("europe-west4").Return([]*cloudbilling.Sku{
+				sku("E2 Instance Core running in Netherlands", 0.03),
+				sku("E2 Instance Ram running in Netherlands", 0.004),
+				sku("SSD backed PD Capacity", 0.2),
+				sku("Static Ip Charge on a Standard VM", 0.004),
+			}, nil)
+
+			bs.Ledger.SetStep("Ensure gateway IP addresses")
+			bs.Ledger.RecordStaticIP("gateway")
+			bs.Ledger.SetStep("Ensure compute instances")
+			bs.Ledger.RecordVM("jumpbox", "e2-medium")
+			bs.Ledger.RecordDisk("jumpbox", 50)
+
+			cost, err := bs.ReportRunCost()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cost.Currency).To(Equal("USD"))
+			Expect(cost.Steps).To(HaveLen(2))
+
+			Expect(cost.Steps[0].Step).To(Equal("Ensure gateway IP addresses"))
+			// 0.004/h * 730h.
+			Expect(cost.Steps[0].Lines[0].MonthlyCost).To(BeNumerically("~", 2.92, 0.01))
+
+			Expect(cost.Steps[1].Step).To(Equal("Ensure compute instances"))
+			// e2-medium: (2 cores * 0.03 + 4 GB * 0.004) * 730h.
+			Expect(cost.Steps[1].Lines[0].Description).To(Equal("VM jumpbox (e2-medium)"))
+			Expect(cost.Steps[1].Lines[0].MonthlyCost).To(BeNumerically("~", 55.48, 0.01))
+			// 50 GB at 0.2/GiB-month.
+			Expect(cost.Steps[1].Lines[1].MonthlyCost).To(BeNumerically("~", 10.0, 0.01))
+
+			Expect(cost.Total()).To(BeNumerically("~", 68.4, 0.01))
+		})
+
+		It("skips the SKU lookup when the run created nothing", func() {
+			cost, err := bs.ReportRunCost()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cost.Steps).To(BeEmpty())
+		})
+
+		It("prints the per-step accounting with a total", func() {
+			gc.EXPECT().ListComputeSKUs("europe-west4").Return([]*cloudbilling.Sku{
+				sku("Static Ip Charge on a Standard VM", 0.004),
+			}, nil)
+
+			bs.Ledger.SetStep("Ensure gateway IP addresses")
+			bs.Ledger.RecordStaticIP("gateway")
+
+			cost, err := bs.ReportRunCost()
+			Expect(err).NotTo(HaveOccurred())
+
+			out := &strings.Builder{}
+			Expect(cost.Print(out)).To(Succeed())
+			Expect(out.String()).To(ContainSubstring("STEP"))
+			Expect(out.String()).To(ContainSubstring("Static external IP gateway"))
+			Expect(out.String()).To(ContainSubstring("TOTAL"))
+		})
+	})
+})
@@ -0,0 +1,118 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/codesphere-cloud/oms/internal/portal"
+)
+
+// This should ALWAYS be empty. Internal flags are for internal feature
+// development and not intended for customer use.
+// Atm. it's not empty as the internal flags below are likely preview or
+// feature flags, but are still in the internal bucket for historical
+// reasons (before we only had one "experiments" bucket).
+var DefaultInternalFlags []string = []string{
+	"headless-services",
+	"vcluster",
+	"custom-service-image",
+	"ms-in-ls",
+}
+
+var DefaultPreviewFlags []string = []string{
+	"secret-management",
+	"sub-path-mount",
+	"workspace-ssh",
+}
+
+var DefaultFeatureFlags []string = []string{}
+
+// releaseDefaultFlags binds flag defaults to the Codesphere minor release
+// they first apply to.
+type releaseDefaultFlags struct {
+	// Release is the "major.minor" release the defaults were introduced
+	// with; they stay in effect until a later entry supersedes them.
+	Release string
+	Flags   portal.DefaultFlags
+}
+
+// defaultFlagsByRelease records the built-in flag defaults per Codesphere
+// release, oldest first. It is the fallback when the portal does not publish
+// default-flags metadata for the installed release; new experiments should
+// ship as portal metadata rather than new entries here.
+var defaultFlagsByRelease = []releaseDefaultFlags{
+	{
+		Release: "0.0",
+		Flags: portal.DefaultFlags{
+			Internal: DefaultInternalFlags,
+			Preview:  DefaultPreviewFlags,
+			Feature:  DefaultFeatureFlags,
+		},
+	},
+}
+
+// DefaultFlagsForVersion returns the built-in flag defaults for a Codesphere
+// version: the newest release entry not newer than the version. Unparseable
+// or empty versions (e.g. local builds) get the newest entry.
+func DefaultFlagsForVersion(version string) portal.DefaultFlags {
+	major, minor, ok := parseRelease(version)
+	flags := defaultFlagsByRelease[0].Flags
+	for _, entry := range defaultFlagsByRelease {
+		entryMajor, entryMinor, entryOk := parseRelease(entry.Release)
+		if ok && entryOk && (entryMajor > major || (entryMajor == major && entryMinor > minor)) {
+			break
+		}
+		flags = entry.Flags
+	}
+	return flags
+}
+
+// parseRelease extracts the major and minor release numbers from a version
+// string like "1.52.3" or "v1.52".
+func parseRelease(version string) (major, minor int, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
+
+// applyDefaultFlags fills the flag buckets the user did not set explicitly
+// with the defaults for the installed Codesphere release: preferably the
+// metadata the portal publishes for it, otherwise the built-in per-release
+// table.
+func (b *GCPBootstrapper) applyDefaultFlags() {
+	if b.Env.InternalFlags != nil && b.Env.PreviewFlags != nil && b.Env.FeatureFlags != nil {
+		return
+	}
+
+	defaults := DefaultFlagsForVersion(b.Env.InstallVersion)
+	if b.PortalClient != nil && b.Env.InstallVersion != "" {
+		if portalFlags, err := b.PortalClient.GetDefaultFlags(b.Env.InstallVersion); err == nil {
+			defaults = portalFlags
+		} else {
+			b.stlog.Logf("Using built-in default flags; portal metadata unavailable: %v", err)
+		}
+	}
+
+	if b.Env.InternalFlags == nil {
+		b.Env.InternalFlags = defaults.Internal
+	}
+	if b.Env.PreviewFlags == nil {
+		b.Env.PreviewFlags = defaults.Preview
+	}
+	if b.Env.FeatureFlags == nil {
+		b.Env.FeatureFlags = defaults.Feature
+	}
+}
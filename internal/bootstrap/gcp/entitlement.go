@@ -0,0 +1,67 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/codesphere-cloud/oms/internal/util"
+)
+
+// CheckEntitlement queries the customer's entitlement from the portal and
+// gates the requested features and plan limits against the license. Requested
+// feature flags outside the license refuse the bootstrap; without explicitly
+// requested features, everything the license covers is enabled.
+func (b *GCPBootstrapper) CheckEntitlement() error {
+	entitlement, err := b.PortalClient.GetEntitlement()
+	if err != nil {
+		return fmt.Errorf("failed to get entitlement from portal: %w", err)
+	}
+	b.Env.Entitlement = &entitlement
+
+	if entitlement.Seats == 0 {
+		return fmt.Errorf("the license has no seats; reach out to the Codesphere support at support@codesphere.com")
+	}
+
+	licensed := util.StringSliceToBoolMap(entitlement.Features)
+	for _, feature := range b.Env.FeatureFlags {
+		if !licensed[feature] {
+			return fmt.Errorf("feature %q is not covered by the license (licensed features: %s)",
+				feature, strings.Join(entitlement.Features, ", "))
+		}
+	}
+	if len(b.Env.FeatureFlags) == 0 {
+		b.Env.FeatureFlags = entitlement.Features
+	}
+
+	// Plans from an existing install config must stay within the license;
+	// the default plans applied later are clamped instead (see
+	// applyEntitlementPlanLimits).
+	if entitlement.MaxReplicas > 0 {
+		for id, plan := range b.Env.InstallConfig.Codesphere.Plans.WorkspacePlans {
+			if plan.MaxReplicas > entitlement.MaxReplicas {
+				return fmt.Errorf("workspace plan %d allows %d replicas, but the license allows at most %d",
+					id, plan.MaxReplicas, entitlement.MaxReplicas)
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyEntitlementPlanLimits caps the workspace plans at the replica limit of
+// the license.
+func (b *GCPBootstrapper) applyEntitlementPlanLimits() {
+	if b.Env.Entitlement == nil || b.Env.Entitlement.MaxReplicas <= 0 {
+		return
+	}
+
+	for id, plan := range b.Env.InstallConfig.Codesphere.Plans.WorkspacePlans {
+		if plan.MaxReplicas > b.Env.Entitlement.MaxReplicas {
+			plan.MaxReplicas = b.Env.Entitlement.MaxReplicas
+			b.Env.InstallConfig.Codesphere.Plans.WorkspacePlans[id] = plan
+		}
+	}
+}
@@ -5,6 +5,7 @@ package gcp
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	"google.golang.org/api/googleapi"
@@ -12,12 +13,40 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// Sentinel errors the GCP client wraps API errors with, so callers can branch
+// on idempotency conditions with errors.Is instead of matching
+// transport-specific codes or error strings.
+var (
+	ErrNotFound      = errors.New("resource not found")
+	ErrAlreadyExists = errors.New("resource already exists")
+)
+
+// TypedError wraps an API error with the matching sentinel error when it
+// indicates a "not found" or "already exists" condition. Other errors are
+// returned unchanged. The GCP client applies this to the errors of its
+// instance and address operations.
+func TypedError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case IsNotFoundError(err):
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	case IsAlreadyExistsError(err):
+		return fmt.Errorf("%w: %w", ErrAlreadyExists, err)
+	}
+	return err
+}
+
 // IsNotFoundError checks if the error indicates a "not found" condition.
-// It handles both gRPC status errors (from Compute API) and googleapi.Error (from DNS/HTTP APIs).
+// It handles the ErrNotFound sentinel, gRPC status errors (from Compute API)
+// and googleapi.Error (from DNS/HTTP APIs).
 func IsNotFoundError(err error) bool {
 	if err == nil {
 		return false
 	}
+	if errors.Is(err, ErrNotFound) {
+		return true
+	}
 	if status.Code(err) == codes.NotFound {
 		return true
 	}
@@ -44,9 +73,14 @@ func IsSpotCapacityError(err error) bool {
 }
 
 // IsAlreadyExistsError checks if the error indicates the resource already exists.
+// It handles the ErrAlreadyExists sentinel, gRPC status errors and the error
+// strings the Compute REST API returns for duplicate inserts.
 func IsAlreadyExistsError(err error) bool {
 	if err == nil {
 		return false
 	}
+	if errors.Is(err, ErrAlreadyExists) {
+		return true
+	}
 	return status.Code(err) == codes.AlreadyExists || strings.Contains(err.Error(), "already exists")
 }
@@ -0,0 +1,98 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+)
+
+// StopEnvironment gracefully shuts down the Codesphere workloads and stops
+// every VM of the environment. Disks and static IPs are preserved, so the
+// environment can be thawed again with StartEnvironment. Intended for demo
+// environments that only run a few hours a week.
+func (b *GCPBootstrapper) StopEnvironment() error {
+	dist := b.distribution()
+
+	// Stop the Kubernetes services before the instances go away so workloads
+	// terminate cleanly: workers first, then the controller.
+	for i := len(b.Env.ControlPlaneNodes) - 1; i >= 1; i-- {
+		worker := b.Env.ControlPlaneNodes[i]
+		err := worker.RunSSHCommand("root", "systemctl stop "+dist.WorkerUnit)
+		if err != nil {
+			return fmt.Errorf("failed to stop workloads on %s: %w", worker.GetName(), err)
+		}
+	}
+	controller := b.Env.ControlPlaneNodes[0]
+	err := controller.RunSSHCommand("root", "systemctl stop "+dist.ControllerUnit)
+	if err != nil {
+		return fmt.Errorf("failed to stop the control plane on %s: %w", controller.GetName(), err)
+	}
+
+	// Shut down postgres cleanly as well; a managed Cloud SQL instance is not
+	// ours to stop.
+	if b.Env.PostgresMode != PostgresModeCloudSQL {
+		for _, pg := range []*node.Node{b.Env.PostgreSQLReplicaNode, b.Env.PostgreSQLNode} {
+			if pg == nil {
+				continue
+			}
+			err := pg.RunSSHCommand("root", "systemctl stop postgresql")
+			if err != nil {
+				return fmt.Errorf("failed to stop postgres on %s: %w", pg.GetName(), err)
+			}
+		}
+	}
+
+	var errs []error
+	for _, vm := range b.activeVMDefs() {
+		inst, err := b.GCPClient.GetInstance(b.Env.ProjectID, b.Env.Zone, vm.Name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get instance %s: %w", vm.Name, err))
+			continue
+		}
+		if inst.GetStatus() != "RUNNING" {
+			b.stlog.Logf("Instance %s is already %s", vm.Name, inst.GetStatus())
+			continue
+		}
+		if err := b.GCPClient.StopInstance(b.Env.ProjectID, b.Env.Zone, vm.Name); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop instance %s: %w", vm.Name, err))
+			continue
+		}
+		b.stlog.Logf("Stopped instance %s", vm.Name)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("errors stopping VMs: %w", errors.Join(errs...))
+	}
+
+	b.stlog.Logf("Environment stopped; disks and IPs are preserved")
+	return nil
+}
+
+// StartEnvironment starts all stopped VMs of the environment and verifies it
+// came back healthy: every node reachable over SSH and all Kubernetes nodes
+// Ready. The systemd units stopped by StopEnvironment come back on boot.
+func (b *GCPBootstrapper) StartEnvironment() error {
+	if err := b.RestartVMs(); err != nil {
+		return err
+	}
+
+	for _, n := range b.allNodes() {
+		if err := n.NodeClient.WaitReady(n, 5*time.Minute); err != nil {
+			return fmt.Errorf("node %s did not come back after start: %w", n.GetName(), err)
+		}
+	}
+
+	dist := b.distribution()
+	controller := b.Env.ControlPlaneNodes[0]
+	healthCmd := fmt.Sprintf("%s wait --for=condition=Ready node --all --timeout=600s", dist.KubectlCommand)
+	if err := controller.RunSSHCommand("root", healthCmd); err != nil {
+		return fmt.Errorf("cluster did not become healthy after start: %w", err)
+	}
+
+	b.stlog.Logf("Environment is running and healthy")
+	return nil
+}
@@ -0,0 +1,164 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/cloudflare"
+	"github.com/codesphere-cloud/oms/internal/env"
+	"github.com/codesphere-cloud/oms/internal/github"
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/codesphere-cloud/oms/internal/portal"
+	"github.com/codesphere-cloud/oms/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("Environment freeze and thaw", func() {
+	var (
+		nodeClient *node.MockNodeClient
+		gc         *gcp.MockGCPClientManager
+		csEnv      *gcp.CodesphereEnvironment
+
+		bs *gcp.GCPBootstrapper
+	)
+
+	nodeNamed := func(name string) interface{} {
+		return mock.MatchedBy(func(n *node.Node) bool { return n.GetName() == name })
+	}
+
+	// The longhorn backend keeps the VM set small: jumpbox, postgres and the
+	// three k0s nodes.
+	vmNames := []string{"jumpbox", "postgres", "k0s-1", "k0s-2", "k0s-3"}
+
+	JustBeforeEach(func() {
+		var err error
+		bs, err = gcp.NewGCPBootstrapper(
+			context.Background(),
+			env.NewEnv(),
+			bootstrap.NewStepLogger(false),
+			csEnv,
+			installer.NewMockInstallConfigManager(GinkgoT()),
+			gc,
+			util.NewMockFileIO(GinkgoT()),
+			nodeClient,
+			portal.NewMockPortal(GinkgoT()),
+			util.NewFakeTime(),
+			github.NewMockGitHubClient(GinkgoT()),
+			cloudflare.NewMockCloudflareClient(GinkgoT()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	BeforeEach(func() {
+		nodeClient = node.NewMockNodeClient(GinkgoT())
+		gc = gcp.NewMockGCPClientManager(GinkgoT())
+
+		csEnv = &gcp.CodesphereEnvironment{
+			ProjectID:      "pid",
+			Zone:           "us-central1-a",
+			StorageBackend: gcp.StorageBackendLonghorn,
+			Jumpbox:        fakeNode("jumpbox", nodeClient),
+			PostgreSQLNode: fakeNode("postgres", nodeClient),
+			ControlPlaneNodes: []*node.Node{
+				fakeNode("k0s-1", nodeClient),
+				fakeNode("k0s-2", nodeClient),
+				fakeNode("k0s-3", nodeClient),
+			},
+		}
+	})
+
+	Describe("StopEnvironment", func() {
+		expectWorkloadShutdown := func() {
+			nodeClient.EXPECT().RunCommand(nodeNamed("k0s-3"), "root", "systemctl stop k0sworker").Return(nil).Once()
+			nodeClient.EXPECT().RunCommand(nodeNamed("k0s-2"), "root", "systemctl stop k0sworker").Return(nil).Once()
+			nodeClient.EXPECT().RunCommand(nodeNamed("k0s-1"), "root", "systemctl stop k0scontroller").Return(nil).Once()
+			nodeClient.EXPECT().RunCommand(nodeNamed("postgres"), "root", "systemctl stop postgresql").Return(nil).Once()
+		}
+
+		It("stops the workloads and every running VM", func() {
+			expectWorkloadShutdown()
+			for _, name := range vmNames {
+				gc.EXPECT().GetInstance("pid", "us-central1-a", name).Return(makeRunningInstance("10.0.0.1", "1.2.3.4"), nil).Once()
+				gc.EXPECT().StopInstance("pid", "us-central1-a", name).Return(nil).Once()
+			}
+
+			Expect(bs.StopEnvironment()).To(Succeed())
+		})
+
+		It("leaves already stopped instances alone", func() {
+			expectWorkloadShutdown()
+			for _, name := range vmNames {
+				gc.EXPECT().GetInstance("pid", "us-central1-a", name).Return(makeStoppedInstance("10.0.0.1", "1.2.3.4"), nil).Once()
+			}
+
+			Expect(bs.StopEnvironment()).To(Succeed())
+		})
+
+		It("fails when shutting down workloads fails", func() {
+			nodeClient.EXPECT().RunCommand(nodeNamed("k0s-3"), "root", "systemctl stop k0sworker").Return(fmt.Errorf("ssh error")).Once()
+
+			err := bs.StopEnvironment()
+			Expect(err).To(MatchError(ContainSubstring("failed to stop workloads on k0s-3")))
+		})
+	})
+
+	Describe("StartEnvironment", func() {
+		expectHealthyCluster := func() {
+			for _, name := range []string{"jumpbox", "k0s-1", "k0s-2", "k0s-3", "postgres"} {
+				nodeClient.EXPECT().WaitReady(nodeNamed(name), mock.Anything).Return(nil).Once()
+			}
+			nodeClient.EXPECT().RunCommand(nodeNamed("k0s-1"), "root", mock.MatchedBy(func(command string) bool {
+				return strings.Contains(command, "wait --for=condition=Ready node --all")
+			})).Return(nil).Once()
+		}
+
+		It("verifies health when the VMs are already running", func() {
+			gc.EXPECT().GetInstance("pid", "us-central1-a", mock.Anything).Return(makeRunningInstance("10.0.0.1", "1.2.3.4"), nil).Times(len(vmNames))
+			expectHealthyCluster()
+
+			Expect(bs.StartEnvironment()).To(Succeed())
+		})
+
+		It("starts stopped instances before checking health", func() {
+			instanceCalls := make(map[string]int)
+			var mu sync.Mutex
+			gc.EXPECT().GetInstance("pid", "us-central1-a", mock.Anything).RunAndReturn(func(_, _, name string) (*computepb.Instance, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				instanceCalls[name]++
+				if instanceCalls[name] == 1 {
+					return makeStoppedInstance("10.0.0.1", "1.2.3.4"), nil
+				}
+				return makeRunningInstance("10.0.0.1", "1.2.3.4"), nil
+			}).Times(len(vmNames) * 2)
+			for _, name := range vmNames {
+				gc.EXPECT().StartInstance("pid", "us-central1-a", name).Return(nil).Once()
+			}
+			expectHealthyCluster()
+
+			Expect(bs.StartEnvironment()).To(Succeed())
+		})
+
+		It("fails when the cluster does not become healthy", func() {
+			gc.EXPECT().GetInstance("pid", "us-central1-a", mock.Anything).Return(makeRunningInstance("10.0.0.1", "1.2.3.4"), nil).Times(len(vmNames))
+			for _, name := range []string{"jumpbox", "k0s-1", "k0s-2", "k0s-3", "postgres"} {
+				nodeClient.EXPECT().WaitReady(nodeNamed(name), mock.Anything).Return(nil).Once()
+			}
+			nodeClient.EXPECT().RunCommand(nodeNamed("k0s-1"), "root", mock.Anything).Return(fmt.Errorf("nodes not ready")).Once()
+
+			err := bs.StartEnvironment()
+			Expect(err).To(MatchError(ContainSubstring("did not become healthy")))
+		})
+	})
+})
@@ -38,33 +38,271 @@ var vmDefs = []VMDef{
 	{"k0s-3", "e2-standard-8", []string{"k0s"}, []int64{}, false},
 }
 
+// postgresReplicaVMDef is provisioned in addition to vmDefs when HA postgres
+// is enabled. It shares the postgres role so image, scope, and firewall
+// configuration apply to both nodes of the pair.
+var postgresReplicaVMDef = VMDef{"postgres-replica", "e2-standard-2", []string{"postgres"}, []int64{}, true}
+
+// DefaultSourceImage is the boot image used for all node roles unless a
+// per-role override is configured.
+const DefaultSourceImage = "projects/ubuntu-os-cloud/global/images/family/ubuntu-2204-lts"
+
+// DefaultServiceAccountScope is granted to every instance service account
+// unless a per-role scope override is configured.
+const DefaultServiceAccountScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// resolveServiceAccountScopes resolves the service account scopes for every
+// node role. Overrides list multiple scopes separated by semicolons.
+func (b *GCPBootstrapper) resolveServiceAccountScopes() (map[string][]string, error) {
+	roles := map[string]bool{}
+	for _, vm := range vmDefs {
+		roles[vm.Tags[0]] = true
+	}
+	for role := range b.Env.ServiceAccountScopes {
+		if !roles[role] {
+			return nil, fmt.Errorf("unknown node role %q in service account scope overrides", role)
+		}
+	}
+
+	scopes := map[string][]string{}
+	for role := range roles {
+		override, ok := b.Env.ServiceAccountScopes[role]
+		if !ok {
+			scopes[role] = []string{DefaultServiceAccountScope}
+			continue
+		}
+
+		var roleScopes []string
+		for _, scope := range strings.Split(override, ";") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				roleScopes = append(roleScopes, scope)
+			}
+		}
+		if len(roleScopes) == 0 {
+			return nil, fmt.Errorf("empty service account scope override for role %s", role)
+		}
+		scopes[role] = roleScopes
+	}
+
+	return scopes, nil
+}
+
+// resolveSourceImages resolves the boot image for every node role. Configured
+// overrides are validated against the image API before any instance is created.
+func (b *GCPBootstrapper) resolveSourceImages() (map[string]string, error) {
+	roles := map[string]bool{}
+	for _, vm := range vmDefs {
+		roles[vm.Tags[0]] = true
+	}
+	for role := range b.Env.SourceImages {
+		if !roles[role] {
+			return nil, fmt.Errorf("unknown node role %q in source image overrides", role)
+		}
+	}
+
+	images := map[string]string{}
+	for role := range roles {
+		override, ok := b.Env.SourceImages[role]
+		if !ok {
+			images[role] = DefaultSourceImage
+			continue
+		}
+
+		resolved, err := b.resolveImage(override)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve source image for role %s: %w", role, err)
+		}
+		images[role] = resolved
+	}
+
+	return images, nil
+}
+
+// resolveImage resolves an image reference to its self link. Supported forms
+// are a full image path, an image family path, and a bare image name in the
+// bootstrap project.
+func (b *GCPBootstrapper) resolveImage(image string) (string, error) {
+	var (
+		resolved *computepb.Image
+		err      error
+	)
+
+	parts := strings.Split(image, "/")
+	switch {
+	case strings.Contains(image, "/images/family/"):
+		resolved, err = b.GCPClient.GetImageFromFamily(parts[1], parts[len(parts)-1])
+	case strings.Contains(image, "/images/"):
+		resolved, err = b.GCPClient.GetImage(parts[1], parts[len(parts)-1])
+	default:
+		resolved, err = b.GCPClient.GetImage(b.Env.ProjectID, image)
+	}
+	if err != nil {
+		return "", fmt.Errorf("image %s not found: %w", image, err)
+	}
+
+	return resolved.GetSelfLink(), nil
+}
+
 // validateVMProvisioningOptions checks that spot and preemptible options are not both set
 func (b *GCPBootstrapper) validateVMProvisioningOptions() error {
 	if b.Env.SpotVMs && b.Env.Preemptible {
 		return fmt.Errorf("cannot specify both --spot-vms and --preemptible flags; use --spot-vms for the newer spot VM model")
 	}
+	if b.Env.ConfidentialVMs && (b.Env.SpotVMs || b.Env.Preemptible) {
+		return fmt.Errorf("confidential VMs cannot be combined with spot or preemptible VMs")
+	}
 	return nil
 }
 
+// validatePostgresMode ensures the postgres mode is one of the supported
+// values and is not combined with options that require a postgres VM.
+func (b *GCPBootstrapper) validatePostgresMode() error {
+	switch b.Env.PostgresMode {
+	case "", PostgresModeVM:
+	case PostgresModeCloudSQL:
+		if b.Env.HAPostgres {
+			return fmt.Errorf("--ha-postgres cannot be combined with --postgres-mode cloudsql; Cloud SQL manages its own high availability")
+		}
+		if b.Env.CustomPgIP != "" {
+			return fmt.Errorf("--custom-pg-ip cannot be combined with --postgres-mode cloudsql; the instance IP is assigned by Cloud SQL")
+		}
+		if b.Env.RegistryType == RegistryTypeLocalContainer {
+			return fmt.Errorf("--registry-type local-container cannot be combined with --postgres-mode cloudsql; the local registry runs on the postgres VM")
+		}
+	default:
+		return fmt.Errorf("invalid postgres mode %q: must be %s or %s", b.Env.PostgresMode, PostgresModeVM, PostgresModeCloudSQL)
+	}
+	return nil
+}
+
+// validateStorageMode ensures the storage mode is one of the supported values
+// and that an external Ceph cluster comes with its mon hosts and keyring.
+func (b *GCPBootstrapper) validateStorageMode() error {
+	switch b.Env.StorageMode {
+	case "", StorageModeVM:
+	case StorageModeExternal:
+		if len(b.Env.CephMonHosts) == 0 {
+			return fmt.Errorf("--storage-mode external requires --ceph-mon-hosts pointing at the existing Ceph cluster")
+		}
+		if b.Env.CephKeyringFile == "" {
+			return fmt.Errorf("--storage-mode external requires --ceph-keyring-file with the keyring of the existing Ceph cluster")
+		}
+		if !b.fw.Exists(b.Env.CephKeyringFile) {
+			return fmt.Errorf("ceph keyring file %s does not exist", b.Env.CephKeyringFile)
+		}
+	default:
+		return fmt.Errorf("invalid storage mode %q: must be %s or %s", b.Env.StorageMode, StorageModeVM, StorageModeExternal)
+	}
+	return nil
+}
+
+// validateNoJumpbox ensures jumpbox-less mode is only combined with IAP
+// tunneling, since without a jumpbox IAP is the only path to the nodes.
+func (b *GCPBootstrapper) validateNoJumpbox() error {
+	if b.Env.NoJumpbox && !b.Env.UseIAPTunnel {
+		return fmt.Errorf("--no-jumpbox requires --iap-tunnel; without a jumpbox the nodes are only reachable through IAP")
+	}
+	return nil
+}
+
+// validateTopology checks the dedicated control plane options. The worker
+// pool settings only make sense when the base k0s nodes stop acting as
+// workers themselves.
+func (b *GCPBootstrapper) validateTopology() error {
+	if !b.Env.DedicatedControlPlane {
+		if b.Env.WorkerCount != 0 {
+			return fmt.Errorf("--worker-count requires --dedicated-control-plane")
+		}
+		if len(b.Env.WorkerTaints) != 0 {
+			return fmt.Errorf("--worker-taints requires --dedicated-control-plane")
+		}
+		return nil
+	}
+	if b.Env.WorkerCount < 1 {
+		return fmt.Errorf("--dedicated-control-plane requires at least one worker node (--worker-count)")
+	}
+	return nil
+}
+
+// activeVMDefs returns the VM definitions provisioned for this environment.
+// Jumpbox-less environments provision no jumpbox VM; HA postgres environments
+// additionally provision a postgres replica VM; Cloud SQL environments
+// provision no postgres VMs, and environments whose storage is not backed by
+// OMS-deployed Ceph (external cluster or a non-Ceph backend) no ceph VMs at
+// all. Ceph VM disks are sized from the environment's OSD layout, and
+// dedicated control plane environments additionally provision their worker
+// pool instances. BYO Kubernetes environments provision no k0s VMs.
+func (b *GCPBootstrapper) activeVMDefs() []VMDef {
+	defs := make([]VMDef, 0, len(vmDefs)+1)
+	for _, vm := range vmDefs {
+		if vm.Name == "jumpbox" {
+			if b.Env.NoJumpbox {
+				continue
+			}
+			if b.Env.ArtifactsDiskGB > 0 {
+				vm.AdditionalDisks = []int64{b.Env.ArtifactsDiskGB}
+			}
+		}
+		if vm.Tags[0] == "k0s" && b.byoK8s() {
+			continue
+		}
+		if vm.Tags[0] == "postgres" && b.Env.PostgresMode == PostgresModeCloudSQL {
+			continue
+		}
+		if vm.Tags[0] == "ceph" {
+			if b.Env.StorageMode == StorageModeExternal || !b.usesCephStorage() {
+				continue
+			}
+			vm.AdditionalDisks = b.cephAdditionalDisks()
+		}
+		defs = append(defs, vm)
+	}
+	if b.Env.HAPostgres {
+		defs = append(defs, postgresReplicaVMDef)
+	}
+	if b.Env.DedicatedControlPlane {
+		base := len(k0sVMDefs())
+		for i := base + 1; i <= base+b.Env.WorkerCount; i++ {
+			defs = append(defs, workerVMDef(workerNodeName(i)))
+		}
+	}
+	return defs
+}
+
 type vmResult struct {
 	vmType     string // jumpbox, postgres, ceph, k0s
 	name       string
 	externalIP string
 	internalIP string
+	// created is true when the instance was created in this run rather than
+	// adopted from a previous bootstrap.
+	created bool
 }
 
 // EnsureComputeInstances ensures that all required compute instances are present and running.
 func (b *GCPBootstrapper) EnsureComputeInstances() error {
+	sourceImages, err := b.resolveSourceImages()
+	if err != nil {
+		return fmt.Errorf("failed to resolve source images: %w", err)
+	}
+
+	scopes, err := b.resolveServiceAccountScopes()
+	if err != nil {
+		return fmt.Errorf("failed to resolve service account scopes: %w", err)
+	}
+
+	defs := b.activeVMDefs()
+
 	wg := sync.WaitGroup{}
-	errCh := make(chan error, len(vmDefs))
-	resultCh := make(chan vmResult, len(vmDefs))
-	logCh := make(chan string, len(vmDefs))
+	errCh := make(chan error, len(defs))
+	resultCh := make(chan vmResult, len(defs))
+	logCh := make(chan string, len(defs))
 
-	for _, vm := range vmDefs {
+	for _, vm := range defs {
 		wg.Add(1)
 		go func(vm VMDef) {
 			defer wg.Done()
-			result, err := b.ensureVM(vm, b.Env.RootDiskSize, logCh)
+			result, err := b.ensureVM(vm, b.rootDiskSize(vm.Name), sourceImages[vm.Tags[0]], scopes[vm.Tags[0]], logCh)
 			if err != nil {
 				errCh <- err
 				return
@@ -91,22 +329,61 @@ func (b *GCPBootstrapper) EnsureComputeInstances() error {
 	}
 
 	// Create nodes from results (in main goroutine, not in spawned goroutines)
-	b.Env.Jumpbox = &node.Node{
+	template := &node.Node{
 		NodeClient: b.NodeClient,
 		FileIO:     b.fw,
 	}
+	// The key file is only used when the key is not held by a running
+	// ssh-agent; agent keys (including hardware-backed ones) take precedence.
+	template.SetSSHKeyPath(b.Env.SSHPrivateKeyPath)
+	template.SetOS(b.nodeOS())
+	template.SSHPort = b.Env.SSHPort
+	if b.Env.UseIAPTunnel {
+		template.IAPTunnel = &node.IAPTunnel{
+			Project: b.Env.ProjectID,
+			Zone:    b.Env.Zone,
+		}
+	}
+	if !b.Env.NoJumpbox {
+		b.Env.Jumpbox = template
+	}
+	createNode := func(result vmResult) *node.Node {
+		n := template.CreateSubNode(result.name, result.externalIP, result.internalIP)
+		if b.Env.NoJumpbox {
+			// Without a jumpbox there is no hop to proxy through; the IAP
+			// tunnel reaches the node directly.
+			n.Jumpbox = nil
+		}
+		return n
+	}
 	for result := range resultCh {
+		if result.created {
+			if def := findVMDef(defs, result.name); def != nil {
+				b.Ledger.RecordVM(def.Name, def.MachineType)
+				diskGB := b.Env.RootDiskSize
+				for _, diskSize := range def.AdditionalDisks {
+					diskGB += diskSize
+				}
+				b.Ledger.RecordDisk(def.Name, diskGB)
+			}
+		}
 		switch result.vmType {
 		case "jumpbox":
 			b.Env.Jumpbox.UpdateNode(result.name, result.externalIP, result.internalIP)
 		case "postgres":
-			b.Env.PostgreSQLNode = b.Env.Jumpbox.CreateSubNode(result.name, result.externalIP, result.internalIP)
+			if result.name == postgresReplicaVMDef.Name {
+				b.Env.PostgreSQLReplicaNode = createNode(result)
+			} else {
+				b.Env.PostgreSQLNode = createNode(result)
+			}
 		case "ceph":
-			node := b.Env.Jumpbox.CreateSubNode(result.name, result.externalIP, result.internalIP)
-			b.Env.CephNodes = append(b.Env.CephNodes, node)
+			b.Env.CephNodes = append(b.Env.CephNodes, createNode(result))
 		case "k0s":
-			node := b.Env.Jumpbox.CreateSubNode(result.name, result.externalIP, result.internalIP)
+			node := createNode(result)
 			b.Env.ControlPlaneNodes = append(b.Env.ControlPlaneNodes, node)
+			if result.created {
+				b.Env.NewWorkerNodes = append(b.Env.NewWorkerNodes, result.name)
+			}
 		}
 	}
 
@@ -122,9 +399,44 @@ func (b *GCPBootstrapper) EnsureComputeInstances() error {
 	return nil
 }
 
+// EnsureHostKeysRecorded seeds the managed known_hosts file with the SSH host
+// keys the instances published to their guest attributes, so even the first
+// connection to a node is verified. Instances that have not published keys
+// (e.g. created before guest attributes were enabled) are skipped and fall
+// back to trust on first use.
+func (b *GCPBootstrapper) EnsureHostKeysRecorded() error {
+	for _, n := range b.allNodes() {
+		hostKeys, err := b.GCPClient.GetInstanceHostKeys(b.Env.ProjectID, b.Env.Zone, n.GetName())
+		if err != nil {
+			b.stlog.Logf("No host keys published for %s, falling back to trust on first use: %v", n.GetName(), err)
+			continue
+		}
+
+		// Record each key under every address the node is reached by.
+		hosts := []string{n.GetName()}
+		if ip := n.GetInternalIP(); ip != "" {
+			hosts = append(hosts, ip)
+		}
+		if ip := n.GetExternalIP(); ip != "" {
+			hosts = append(hosts, ip)
+		}
+
+		for keyType, keyData := range hostKeys {
+			for _, host := range hosts {
+				err := node.RecordHostKey(host, keyType+" "+keyData)
+				if err != nil {
+					return fmt.Errorf("failed to record host key for %s: %w", n.GetName(), err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 // ensureVM handles the full lifecycle of a single VM: check existence, restart if stopped,
 // or create a new instance with spot fallback. Returns the VM result with assigned IPs.
-func (b *GCPBootstrapper) ensureVM(vm VMDef, rootDiskSize int64, logCh chan<- string) (vmResult, error) {
+func (b *GCPBootstrapper) ensureVM(vm VMDef, rootDiskSize int64, sourceImage string, scopes []string, logCh chan<- string) (vmResult, error) {
 	projectID := b.Env.ProjectID
 	zone := b.Env.Zone
 
@@ -143,7 +455,7 @@ func (b *GCPBootstrapper) ensureVM(vm VMDef, rootDiskSize int64, logCh chan<- st
 			return vmResult{}, fmt.Errorf("instance %s is SUSPENDED; manual resume is required", vm.Name)
 		}
 	} else {
-		instance, err := b.buildInstanceSpec(vm, rootDiskSize)
+		instance, err := b.buildInstanceSpec(vm, rootDiskSize, sourceImage, scopes)
 		if err != nil {
 			return vmResult{}, err
 		}
@@ -152,7 +464,7 @@ func (b *GCPBootstrapper) ensureVM(vm VMDef, rootDiskSize int64, logCh chan<- st
 		}
 	}
 
-	readyInstance, err := b.waitForInstanceRunning(projectID, zone, vm.Name, vm.ExternalIP)
+	readyInstance, err := b.waitForInstanceRunning(projectID, zone, vm.Name, vm.ExternalIP && !b.Env.UseIAPTunnel)
 	if err != nil {
 		return vmResult{}, fmt.Errorf("instance %s did not become ready: %w", vm.Name, err)
 	}
@@ -163,11 +475,12 @@ func (b *GCPBootstrapper) ensureVM(vm VMDef, rootDiskSize int64, logCh chan<- st
 		name:       vm.Name,
 		externalIP: externalIP,
 		internalIP: internalIP,
+		created:    existingInstance == nil,
 	}, nil
 }
 
 // buildInstanceSpec constructs the full compute instance specification for a VM.
-func (b *GCPBootstrapper) buildInstanceSpec(vm VMDef, rootDiskSize int64) (*computepb.Instance, error) {
+func (b *GCPBootstrapper) buildInstanceSpec(vm VMDef, rootDiskSize int64, sourceImage string, scopes []string) (*computepb.Instance, error) {
 	projectID := b.Env.ProjectID
 	region := b.Env.Region
 	zone := b.Env.Zone
@@ -184,7 +497,8 @@ func (b *GCPBootstrapper) buildInstanceSpec(vm VMDef, rootDiskSize int64) (*comp
 			InitializeParams: &computepb.AttachedDiskInitializeParams{
 				DiskType:    &diskType,
 				DiskSizeGb:  protoInt64(rootDiskSize),
-				SourceImage: protoString("projects/ubuntu-os-cloud/global/images/family/ubuntu-2204-lts"),
+				SourceImage: protoString(sourceImage),
+				Labels:      b.resourceLabels(),
 			},
 		},
 	}
@@ -196,10 +510,20 @@ func (b *GCPBootstrapper) buildInstanceSpec(vm VMDef, rootDiskSize int64) (*comp
 			InitializeParams: &computepb.AttachedDiskInitializeParams{
 				DiskSizeGb: protoInt64(diskSize),
 				DiskType:   &diskType,
+				Labels:     b.resourceLabels(),
 			},
 		})
 	}
 
+	// Encrypt all disks with the customer-managed KMS key when configured.
+	if b.Env.DiskEncryptionKey != "" {
+		for _, disk := range disks {
+			disk.DiskEncryptionKey = &computepb.CustomerEncryptionKey{
+				KmsKeyName: protoString(b.Env.DiskEncryptionKey),
+			}
+		}
+	}
+
 	sshKeys := ""
 	if b.Env.GitHubPAT != "" && b.Env.GitHubTeamOrg != "" && b.Env.GitHubTeamSlug != "" {
 		var err error
@@ -222,13 +546,14 @@ func (b *GCPBootstrapper) buildInstanceSpec(vm VMDef, rootDiskSize int64) (*comp
 		ServiceAccounts: []*computepb.ServiceAccount{
 			{
 				Email:  protoString(serviceAccount),
-				Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+				Scopes: scopes,
 			},
 		},
 		MachineType: protoString(fmt.Sprintf("zones/%s/machineTypes/%s", zone, vm.MachineType)),
 		Tags: &computepb.Tags{
 			Items: vm.Tags,
 		},
+		Labels:     b.resourceLabels(),
 		Scheduling: b.BuildSchedulingConfig(),
 		NetworkInterfaces: []*computepb.NetworkInterface{
 			{
@@ -243,11 +568,23 @@ func (b *GCPBootstrapper) buildInstanceSpec(vm VMDef, rootDiskSize int64) (*comp
 					Key:   protoString("ssh-keys"),
 					Value: protoString(sshKeys),
 				},
+				{
+					// Let the guest environment publish the SSH host keys so
+					// the bootstrapper can seed the managed known_hosts file.
+					Key:   protoString("enable-guest-attributes"),
+					Value: protoString("TRUE"),
+				},
+				{
+					// Prepare the node at first boot; the SSH-based Ensure
+					// steps then only verify and fill gaps.
+					Key:   protoString("user-data"),
+					Value: protoString(b.buildCloudInitUserData(vm.Tags[0])),
+				},
 			},
 		},
 	}
 
-	if vm.ExternalIP {
+	if vm.ExternalIP && !b.Env.UseIAPTunnel {
 		instance.NetworkInterfaces[0].AccessConfigs = []*computepb.AccessConfig{
 			{
 				Name: protoString("External NAT"),
@@ -256,6 +593,27 @@ func (b *GCPBootstrapper) buildInstanceSpec(vm VMDef, rootDiskSize int64) (*comp
 		}
 	}
 
+	// --shielded-vms enables all Shielded VM features; the individual
+	// settings allow enabling a subset, e.g. vTPM without secure boot.
+	secureBoot := b.Env.ShieldedVMs || b.Env.ShieldedSecureBoot
+	vtpm := b.Env.ShieldedVMs || b.Env.ShieldedVtpm
+	integrityMonitoring := b.Env.ShieldedVMs || b.Env.ShieldedIntegrityMonitoring
+	if secureBoot || vtpm || integrityMonitoring {
+		instance.ShieldedInstanceConfig = &computepb.ShieldedInstanceConfig{
+			EnableSecureBoot:          protoBool(secureBoot),
+			EnableVtpm:                protoBool(vtpm),
+			EnableIntegrityMonitoring: protoBool(integrityMonitoring),
+		}
+	}
+
+	if b.Env.ConfidentialVMs {
+		instance.ConfidentialInstanceConfig = &computepb.ConfidentialInstanceConfig{
+			EnableConfidentialCompute: protoBool(true),
+		}
+		// Confidential VMs cannot be live migrated.
+		instance.Scheduling.OnHostMaintenance = protoString("TERMINATE")
+	}
+
 	return instance, nil
 }
 
@@ -363,8 +721,8 @@ func (b *GCPBootstrapper) waitForInstanceRunning(projectID, zone, name string, n
 }
 
 // findVMDef looks up a VM definition by name. Returns nil if not found.
-func findVMDef(name string) *VMDef {
-	for _, vm := range vmDefs {
+func findVMDef(defs []VMDef, name string) *VMDef {
+	for _, vm := range defs {
 		if vm.Name == name {
 			return &vm
 		}
@@ -372,20 +730,22 @@ func findVMDef(name string) *VMDef {
 	return nil
 }
 
-// validVMNames returns the list of known VM names from vmDefs.
-func validVMNames() []string {
-	names := make([]string, len(vmDefs))
-	for i, vm := range vmDefs {
+// validVMNames returns the list of known VM names from the given definitions.
+func validVMNames(defs []VMDef) []string {
+	names := make([]string, len(defs))
+	for i, vm := range defs {
 		names[i] = vm.Name
 	}
 	return names
 }
 
-// RestartVM restarts a single stopped or terminated VM by a name that is defined in vmDefs.
+// RestartVM restarts a single stopped or terminated VM by a name that is
+// provisioned for this environment.
 func (b *GCPBootstrapper) RestartVM(name string) error {
-	vm := findVMDef(name)
+	defs := b.activeVMDefs()
+	vm := findVMDef(defs, name)
 	if vm == nil {
-		return fmt.Errorf("unknown VM name %q; valid names are: %s", name, strings.Join(validVMNames(), ", "))
+		return fmt.Errorf("unknown VM name %q; valid names are: %s", name, strings.Join(validVMNames(defs), ", "))
 	}
 
 	projectID := b.Env.ProjectID
@@ -424,10 +784,10 @@ func (b *GCPBootstrapper) RestartVM(name string) error {
 	return nil
 }
 
-// RestartVMs restarts all stopped or terminated VMs defined in vmDefs.
+// RestartVMs restarts all stopped or terminated VMs provisioned for this environment.
 func (b *GCPBootstrapper) RestartVMs() error {
 	var errs []error
-	for _, vm := range vmDefs {
+	for _, vm := range b.activeVMDefs() {
 		if err := b.RestartVM(vm.Name); err != nil {
 			errs = append(errs, err)
 		}
@@ -463,6 +823,13 @@ func (b *GCPBootstrapper) GetNodeByName(name string) (*node.Node, error) {
 	existingNode := &node.Node{
 		NodeClient: b.NodeClient,
 		FileIO:     b.fw,
+		SSHPort:    b.Env.SSHPort,
+	}
+	if b.Env.UseIAPTunnel {
+		existingNode.IAPTunnel = &node.IAPTunnel{
+			Project: b.Env.ProjectID,
+			Zone:    b.Env.Zone,
+		}
 	}
 
 	internalIP, externalIP := ExtractInstanceIPs(existingInstance)
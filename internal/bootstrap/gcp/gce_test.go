@@ -4,6 +4,7 @@
 package gcp_test
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -107,6 +108,40 @@ var _ = Describe("GCE", func() {
 				Expect(gcp.IsNotFoundError(wrappedErr)).To(BeTrue())
 			})
 		})
+
+		Context("when error wraps the ErrNotFound sentinel", func() {
+			It("should return true", func() {
+				wrappedErr := fmt.Errorf("failed to get instance: %w", gcp.ErrNotFound)
+				Expect(gcp.IsNotFoundError(wrappedErr)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("TypedError", func() {
+		It("returns nil for nil", func() {
+			Expect(gcp.TypedError(nil)).To(Succeed())
+		})
+
+		It("wraps not-found conditions with ErrNotFound", func() {
+			err := gcp.TypedError(&googleapi.Error{Code: 404, Message: "not found"})
+			Expect(errors.Is(err, gcp.ErrNotFound)).To(BeTrue())
+		})
+
+		It("wraps already-exists conditions with ErrAlreadyExists", func() {
+			err := gcp.TypedError(grpcstatus.Errorf(codes.AlreadyExists, "already exists"))
+			Expect(errors.Is(err, gcp.ErrAlreadyExists)).To(BeTrue())
+		})
+
+		It("keeps the original error in the chain", func() {
+			inner := &googleapi.Error{Code: 404, Message: "not found"}
+			var apiErr *googleapi.Error
+			Expect(errors.As(gcp.TypedError(inner), &apiErr)).To(BeTrue())
+		})
+
+		It("returns other errors unchanged", func() {
+			inner := fmt.Errorf("some other error")
+			Expect(gcp.TypedError(inner)).To(MatchError(inner))
+		})
 	})
 
 	Describe("IsSpotCapacityError", func() {
@@ -237,6 +272,58 @@ var _ = Describe("GCE", func() {
 			Expect(err.Error()).To(ContainSubstring("cannot specify both --spot-vms and --preemptible"))
 			Expect(err.Error()).To(ContainSubstring("use --spot-vms for the newer spot VM model"))
 		})
+
+		It("fails when confidential VMs are combined with spot VMs", func() {
+			csEnv.ConfidentialVMs = true
+			csEnv.SpotVMs = true
+			gc := gcp.NewMockGCPClientManager(GinkgoT())
+			bs := newTestBootstrapper(csEnv, gc)
+			err := bs.ValidateInput()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("confidential VMs cannot be combined with spot or preemptible VMs"))
+		})
+	})
+
+	Describe("validateNoJumpbox", func() {
+		var csEnv *gcp.CodesphereEnvironment
+
+		BeforeEach(func() {
+			csEnv = &gcp.CodesphereEnvironment{
+				ProjectName:           "test",
+				Region:                "us-central1",
+				Zone:                  "us-central1-a",
+				BaseDomain:            "example.com",
+				DNSProjectID:          "dns-project",
+				DNSZoneName:           "test-zone",
+				SecretsDir:            "/etc/codesphere/secrets",
+				DatacenterID:          1,
+				InternalFlags:         gcp.DefaultInternalFlags,
+				PreviewFlags:          gcp.DefaultPreviewFlags,
+				InstallConfigPath:     "fake-config",
+				SecretsFilePath:       "fake-secrets",
+				GitHubAppName:         "fake-app",
+				GitHubAppClientID:     "fake-id",
+				GitHubAppClientSecret: "fake-secret",
+			}
+		})
+
+		It("fails when no-jumpbox is used without IAP tunneling", func() {
+			csEnv.NoJumpbox = true
+			gc := gcp.NewMockGCPClientManager(GinkgoT())
+			bs := newTestBootstrapper(csEnv, gc)
+			err := bs.ValidateInput()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("--no-jumpbox requires --iap-tunnel"))
+		})
+
+		It("succeeds when no-jumpbox is combined with IAP tunneling", func() {
+			csEnv.NoJumpbox = true
+			csEnv.UseIAPTunnel = true
+			gc := gcp.NewMockGCPClientManager(GinkgoT())
+			bs := newTestBootstrapper(csEnv, gc)
+			err := bs.ValidateInput()
+			Expect(err).NotTo(HaveOccurred())
+		})
 	})
 
 	Describe("CreateInstanceWithFallback", func() {
@@ -469,6 +556,11 @@ var _ = Describe("GCE", func() {
 			Expect(gcp.IsAlreadyExistsError(err)).To(BeTrue())
 		})
 
+		It("returns true for a wrapped ErrAlreadyExists sentinel", func() {
+			err := fmt.Errorf("failed to create instance: %w", gcp.ErrAlreadyExists)
+			Expect(gcp.IsAlreadyExistsError(err)).To(BeTrue())
+		})
+
 		It("returns false for unrelated error", func() {
 			err := fmt.Errorf("permission denied")
 			Expect(gcp.IsAlreadyExistsError(err)).To(BeFalse())
@@ -589,6 +681,358 @@ var _ = Describe("GCE", func() {
 				})
 			})
 
+			Context("When shielded and confidential VMs are enabled", func() {
+				BeforeEach(func() {
+					csEnv.ShieldedVMs = true
+					csEnv.ConfidentialVMs = true
+					ipResp := makeRunningInstance("10.0.0.x", "1.2.3.x")
+					mockGetInstanceNotFoundThenRunning(gc, csEnv.ProjectID, csEnv.Zone, ipResp, 8)
+				})
+
+				It("Sets the shielded and confidential instance configs", func() {
+					fw.EXPECT().ReadFile(mock.Anything).Return([]byte("ssh-rsa AAA..."), nil).Times(8)
+					allConfigsCorrect := true
+					mu := sync.Mutex{}
+					gc.EXPECT().CreateInstance(csEnv.ProjectID, csEnv.Zone, mock.Anything).RunAndReturn(
+						func(projectID, zone string, instance *computepb.Instance) error {
+							ok := instance.GetShieldedInstanceConfig().GetEnableSecureBoot() &&
+								instance.GetShieldedInstanceConfig().GetEnableVtpm() &&
+								instance.GetShieldedInstanceConfig().GetEnableIntegrityMonitoring() &&
+								instance.GetConfidentialInstanceConfig().GetEnableConfidentialCompute() &&
+								instance.GetScheduling().GetOnHostMaintenance() == "TERMINATE"
+							if !ok {
+								mu.Lock()
+								allConfigsCorrect = false
+								mu.Unlock()
+							}
+							return nil
+						},
+					).Times(8)
+
+					err := bs.EnsureComputeInstances()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(allConfigsCorrect).To(BeTrue())
+				})
+			})
+
+			Context("When jumpbox-less mode is enabled", func() {
+				BeforeEach(func() {
+					csEnv.NoJumpbox = true
+					csEnv.UseIAPTunnel = true
+					ipResp := makeRunningInstance("10.0.0.x", "1.2.3.x")
+					mockGetInstanceNotFoundThenRunning(gc, csEnv.ProjectID, csEnv.Zone, ipResp, 7)
+				})
+
+				It("provisions no jumpbox VM and connects nodes directly through IAP", func() {
+					fw.EXPECT().ReadFile(mock.Anything).Return([]byte("ssh-rsa AAA..."), nil).Times(7)
+					var createdNames []string
+					mu := sync.Mutex{}
+					gc.EXPECT().CreateInstance(csEnv.ProjectID, csEnv.Zone, mock.Anything).RunAndReturn(
+						func(projectID, zone string, instance *computepb.Instance) error {
+							mu.Lock()
+							createdNames = append(createdNames, instance.GetName())
+							mu.Unlock()
+							return nil
+						},
+					).Times(7)
+
+					err := bs.EnsureComputeInstances()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(createdNames).NotTo(ContainElement("jumpbox"))
+					Expect(csEnv.Jumpbox).To(BeNil())
+					Expect(csEnv.ControlPlaneNodes).To(HaveLen(3))
+					for _, n := range csEnv.ControlPlaneNodes {
+						Expect(n.Jumpbox).To(BeNil())
+						Expect(n.IAPTunnel).NotTo(BeNil())
+					}
+				})
+			})
+
+			Context("When HA postgres is enabled", func() {
+				BeforeEach(func() {
+					csEnv.HAPostgres = true
+					ipResp := makeRunningInstance("10.0.0.x", "1.2.3.x")
+					mockGetInstanceNotFoundThenRunning(gc, csEnv.ProjectID, csEnv.Zone, ipResp, 9)
+				})
+
+				It("provisions a postgres replica VM alongside the primary", func() {
+					fw.EXPECT().ReadFile(mock.Anything).Return([]byte("ssh-rsa AAA..."), nil).Times(9)
+					var createdNames []string
+					mu := sync.Mutex{}
+					gc.EXPECT().CreateInstance(csEnv.ProjectID, csEnv.Zone, mock.Anything).RunAndReturn(
+						func(projectID, zone string, instance *computepb.Instance) error {
+							mu.Lock()
+							createdNames = append(createdNames, instance.GetName())
+							mu.Unlock()
+							return nil
+						},
+					).Times(9)
+
+					err := bs.EnsureComputeInstances()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(createdNames).To(ContainElement("postgres-replica"))
+					Expect(csEnv.PostgreSQLNode.GetName()).To(Equal("postgres"))
+					Expect(csEnv.PostgreSQLReplicaNode).NotTo(BeNil())
+					Expect(csEnv.PostgreSQLReplicaNode.GetName()).To(Equal("postgres-replica"))
+				})
+			})
+
+			Context("When Cloud SQL postgres is enabled", func() {
+				BeforeEach(func() {
+					csEnv.PostgresMode = gcp.PostgresModeCloudSQL
+					ipResp := makeRunningInstance("10.0.0.x", "1.2.3.x")
+					mockGetInstanceNotFoundThenRunning(gc, csEnv.ProjectID, csEnv.Zone, ipResp, 7)
+				})
+
+				It("provisions no postgres VM", func() {
+					fw.EXPECT().ReadFile(mock.Anything).Return([]byte("ssh-rsa AAA..."), nil).Times(7)
+					var createdNames []string
+					mu := sync.Mutex{}
+					gc.EXPECT().CreateInstance(csEnv.ProjectID, csEnv.Zone, mock.Anything).RunAndReturn(
+						func(projectID, zone string, instance *computepb.Instance) error {
+							mu.Lock()
+							createdNames = append(createdNames, instance.GetName())
+							mu.Unlock()
+							return nil
+						},
+					).Times(7)
+
+					err := bs.EnsureComputeInstances()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(createdNames).NotTo(ContainElement("postgres"))
+					Expect(csEnv.PostgreSQLNode).To(BeNil())
+				})
+			})
+
+			Context("When external Ceph storage is enabled", func() {
+				BeforeEach(func() {
+					csEnv.StorageMode = gcp.StorageModeExternal
+					ipResp := makeRunningInstance("10.0.0.x", "1.2.3.x")
+					mockGetInstanceNotFoundThenRunning(gc, csEnv.ProjectID, csEnv.Zone, ipResp, 5)
+				})
+
+				It("provisions no ceph VMs", func() {
+					fw.EXPECT().ReadFile(mock.Anything).Return([]byte("ssh-rsa AAA..."), nil).Times(5)
+					var createdNames []string
+					mu := sync.Mutex{}
+					gc.EXPECT().CreateInstance(csEnv.ProjectID, csEnv.Zone, mock.Anything).RunAndReturn(
+						func(projectID, zone string, instance *computepb.Instance) error {
+							mu.Lock()
+							createdNames = append(createdNames, instance.GetName())
+							mu.Unlock()
+							return nil
+						},
+					).Times(5)
+
+					err := bs.EnsureComputeInstances()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(createdNames).NotTo(ContainElement("ceph-1"))
+					Expect(csEnv.CephNodes).To(BeEmpty())
+					Expect(csEnv.ControlPlaneNodes).To(HaveLen(3))
+				})
+			})
+
+			Context("When a custom ceph OSD layout is configured", func() {
+				BeforeEach(func() {
+					csEnv.CephDataDiskGB = 200
+					csEnv.CephDBDiskGB = 20
+					csEnv.CephOSDsPerHost = 2
+					ipResp := makeRunningInstance("10.0.0.x", "1.2.3.x")
+					mockGetInstanceNotFoundThenRunning(gc, csEnv.ProjectID, csEnv.Zone, ipResp, 8)
+				})
+
+				It("attaches a DB and data disk pair per OSD to the ceph VMs", func() {
+					fw.EXPECT().ReadFile(mock.Anything).Return([]byte("ssh-rsa AAA..."), nil).Times(8)
+					instances := map[string]*computepb.Instance{}
+					mu := sync.Mutex{}
+					gc.EXPECT().CreateInstance(csEnv.ProjectID, csEnv.Zone, mock.Anything).RunAndReturn(
+						func(projectID, zone string, instance *computepb.Instance) error {
+							mu.Lock()
+							instances[instance.GetName()] = instance
+							mu.Unlock()
+							return nil
+						},
+					).Times(8)
+
+					err := bs.EnsureComputeInstances()
+					Expect(err).NotTo(HaveOccurred())
+
+					cephDisks := instances["ceph-1"].GetDisks()
+					Expect(cephDisks).To(HaveLen(5)) // boot disk + 2 OSDs x (DB + data)
+					var sizes []int64
+					for _, disk := range cephDisks[1:] {
+						sizes = append(sizes, disk.GetInitializeParams().GetDiskSizeGb())
+					}
+					Expect(sizes).To(Equal([]int64{20, 200, 20, 200}))
+				})
+			})
+
+			Context("When a non-Ceph storage backend is selected", func() {
+				BeforeEach(func() {
+					csEnv.StorageBackend = gcp.StorageBackendLonghorn
+					ipResp := makeRunningInstance("10.0.0.x", "1.2.3.x")
+					mockGetInstanceNotFoundThenRunning(gc, csEnv.ProjectID, csEnv.Zone, ipResp, 5)
+				})
+
+				It("provisions no ceph VMs", func() {
+					fw.EXPECT().ReadFile(mock.Anything).Return([]byte("ssh-rsa AAA..."), nil).Times(5)
+					var createdNames []string
+					mu := sync.Mutex{}
+					gc.EXPECT().CreateInstance(csEnv.ProjectID, csEnv.Zone, mock.Anything).RunAndReturn(
+						func(projectID, zone string, instance *computepb.Instance) error {
+							mu.Lock()
+							createdNames = append(createdNames, instance.GetName())
+							mu.Unlock()
+							return nil
+						},
+					).Times(5)
+
+					err := bs.EnsureComputeInstances()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(createdNames).NotTo(ContainElement("ceph-1"))
+					Expect(csEnv.CephNodes).To(BeEmpty())
+				})
+			})
+
+			Context("When a dedicated control plane is configured", func() {
+				BeforeEach(func() {
+					csEnv.DedicatedControlPlane = true
+					csEnv.WorkerCount = 2
+					ipResp := makeRunningInstance("10.0.0.x", "1.2.3.x")
+					mockGetInstanceNotFoundThenRunning(gc, csEnv.ProjectID, csEnv.Zone, ipResp, 10)
+				})
+
+				It("provisions the worker pool instances", func() {
+					fw.EXPECT().ReadFile(mock.Anything).Return([]byte("ssh-rsa AAA..."), nil).Times(10)
+					var createdNames []string
+					mu := sync.Mutex{}
+					gc.EXPECT().CreateInstance(csEnv.ProjectID, csEnv.Zone, mock.Anything).RunAndReturn(
+						func(projectID, zone string, instance *computepb.Instance) error {
+							mu.Lock()
+							createdNames = append(createdNames, instance.GetName())
+							mu.Unlock()
+							return nil
+						},
+					).Times(10)
+
+					err := bs.EnsureComputeInstances()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(createdNames).To(ContainElements("k0s-4", "k0s-5"))
+					Expect(csEnv.ControlPlaneNodes).To(HaveLen(5))
+				})
+			})
+
+			Context("When source image overrides are configured", func() {
+				BeforeEach(func() {
+					csEnv.SourceImages = map[string]string{
+						"jumpbox": "projects/my-proj/global/images/golden-jumpbox",
+						"k0s":     "projects/my-proj/global/images/family/golden-k0s",
+					}
+					gc.EXPECT().GetImage("my-proj", "golden-jumpbox").Return(&computepb.Image{SelfLink: protoString("jumpbox-self-link")}, nil)
+					gc.EXPECT().GetImageFromFamily("my-proj", "golden-k0s").Return(&computepb.Image{SelfLink: protoString("k0s-self-link")}, nil)
+					ipResp := makeRunningInstance("10.0.0.x", "1.2.3.x")
+					mockGetInstanceNotFoundThenRunning(gc, csEnv.ProjectID, csEnv.Zone, ipResp, 8)
+				})
+
+				It("uses the resolved images for the overridden roles", func() {
+					fw.EXPECT().ReadFile(mock.Anything).Return([]byte("ssh-rsa AAA..."), nil).Times(8)
+					allImagesCorrect := true
+					mu := sync.Mutex{}
+					gc.EXPECT().CreateInstance(csEnv.ProjectID, csEnv.Zone, mock.Anything).RunAndReturn(
+						func(projectID, zone string, instance *computepb.Instance) error {
+							image := instance.GetDisks()[0].GetInitializeParams().GetSourceImage()
+							want := gcp.DefaultSourceImage
+							switch {
+							case instance.GetName() == "jumpbox":
+								want = "jumpbox-self-link"
+							case strings.HasPrefix(instance.GetName(), "k0s"):
+								want = "k0s-self-link"
+							}
+							if image != want {
+								mu.Lock()
+								allImagesCorrect = false
+								mu.Unlock()
+							}
+							return nil
+						},
+					).Times(8)
+
+					err := bs.EnsureComputeInstances()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(allImagesCorrect).To(BeTrue())
+				})
+			})
+
+			Context("When service account scope overrides are configured", func() {
+				BeforeEach(func() {
+					csEnv.ServiceAccountScopes = map[string]string{
+						"k0s": "https://www.googleapis.com/auth/compute; https://www.googleapis.com/auth/devstorage.read_only",
+					}
+					ipResp := makeRunningInstance("10.0.0.x", "1.2.3.x")
+					mockGetInstanceNotFoundThenRunning(gc, csEnv.ProjectID, csEnv.Zone, ipResp, 8)
+				})
+
+				It("uses the overridden scopes for the role and the default elsewhere", func() {
+					fw.EXPECT().ReadFile(mock.Anything).Return([]byte("ssh-rsa AAA..."), nil).Times(8)
+					allScopesCorrect := true
+					mu := sync.Mutex{}
+					gc.EXPECT().CreateInstance(csEnv.ProjectID, csEnv.Zone, mock.Anything).RunAndReturn(
+						func(projectID, zone string, instance *computepb.Instance) error {
+							scopes := instance.GetServiceAccounts()[0].GetScopes()
+							want := []string{gcp.DefaultServiceAccountScope}
+							if strings.HasPrefix(instance.GetName(), "k0s") {
+								want = []string{
+									"https://www.googleapis.com/auth/compute",
+									"https://www.googleapis.com/auth/devstorage.read_only",
+								}
+							}
+							if len(scopes) != len(want) || scopes[0] != want[0] {
+								mu.Lock()
+								allScopesCorrect = false
+								mu.Unlock()
+							}
+							return nil
+						},
+					).Times(8)
+
+					err := bs.EnsureComputeInstances()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(allScopesCorrect).To(BeTrue())
+				})
+			})
+
+			Context("When only some shielded features are enabled", func() {
+				BeforeEach(func() {
+					csEnv.ShieldedVtpm = true
+					csEnv.ShieldedIntegrityMonitoring = true
+					ipResp := makeRunningInstance("10.0.0.x", "1.2.3.x")
+					mockGetInstanceNotFoundThenRunning(gc, csEnv.ProjectID, csEnv.Zone, ipResp, 8)
+				})
+
+				It("enables only the selected features", func() {
+					fw.EXPECT().ReadFile(mock.Anything).Return([]byte("ssh-rsa AAA..."), nil).Times(8)
+					allConfigsCorrect := true
+					mu := sync.Mutex{}
+					gc.EXPECT().CreateInstance(csEnv.ProjectID, csEnv.Zone, mock.Anything).RunAndReturn(
+						func(projectID, zone string, instance *computepb.Instance) error {
+							ok := !instance.GetShieldedInstanceConfig().GetEnableSecureBoot() &&
+								instance.GetShieldedInstanceConfig().GetEnableVtpm() &&
+								instance.GetShieldedInstanceConfig().GetEnableIntegrityMonitoring()
+							if !ok {
+								mu.Lock()
+								allConfigsCorrect = false
+								mu.Unlock()
+							}
+							return nil
+						},
+					).Times(8)
+
+					err := bs.EnsureComputeInstances()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(allConfigsCorrect).To(BeTrue())
+				})
+			})
+
 			It("creates all instances", func() {
 				fw.EXPECT().ReadFile(mock.Anything).Return([]byte("ssh-rsa AAA..."), nil).Times(8)
 				gc.EXPECT().CreateInstance(csEnv.ProjectID, csEnv.Zone, mock.Anything).Return(nil).Times(8)
@@ -603,6 +1047,40 @@ var _ = Describe("GCE", func() {
 				Expect(bs.Env.Jumpbox).NotTo(BeNil())
 			})
 
+			It("attaches cloud-init user-data preparing the node at first boot", func() {
+				fw.EXPECT().ReadFile(mock.Anything).Return([]byte("ssh-rsa AAA..."), nil).Times(8)
+				ipResp := makeRunningInstance("10.0.0.x", "1.2.3.x")
+				mockGetInstanceNotFoundThenRunning(gc, csEnv.ProjectID, csEnv.Zone, ipResp, 8)
+
+				gc.EXPECT().CreateInstance(csEnv.ProjectID, csEnv.Zone, mock.Anything).RunAndReturn(func(projectID, zone string, instance *computepb.Instance) error {
+					userData := ""
+					for _, item := range instance.GetMetadata().GetItems() {
+						if item.GetKey() == "user-data" {
+							userData = item.GetValue()
+						}
+					}
+					if !strings.HasPrefix(userData, "#cloud-config") {
+						return fmt.Errorf("expected cloud-init user-data on %s", instance.GetName())
+					}
+					for _, want := range []string{"vm.max_map_count=262144", "fs.inotify.max_user_watches=1048576", "PermitRootLogin yes"} {
+						if !strings.Contains(userData, want) {
+							return fmt.Errorf("expected user-data of %s to contain %q", instance.GetName(), want)
+						}
+					}
+					isJumpbox := instance.GetName() == "jumpbox"
+					if strings.Contains(userData, "AcceptEnv OMS_PORTAL_API_KEY") != isJumpbox {
+						return fmt.Errorf("AcceptEnv configuration should only be on the jumpbox, got it on %s", instance.GetName())
+					}
+					if strings.Contains(userData, "browser_download_url") != isJumpbox {
+						return fmt.Errorf("OMS CLI install should only be on the jumpbox, got it on %s", instance.GetName())
+					}
+					return nil
+				}).Times(8)
+
+				err := bs.EnsureComputeInstances()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
 			Context("When github org is set", func() {
 				BeforeEach(func() {
 					csEnv.GitHubTeamOrg = "someorg"
@@ -661,6 +1139,40 @@ var _ = Describe("GCE", func() {
 		Describe("Invalid cases", func() {
 			notFoundErr := grpcstatus.Errorf(codes.NotFound, "not found")
 
+			It("fails for a source image override with an unknown role", func() {
+				csEnv.SourceImages = map[string]string{"loadbalancer": "golden-image"}
+
+				err := bs.EnsureComputeInstances()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("unknown node role \"loadbalancer\""))
+			})
+
+			It("fails for a scope override with an unknown role", func() {
+				csEnv.ServiceAccountScopes = map[string]string{"loadbalancer": "https://www.googleapis.com/auth/compute"}
+
+				err := bs.EnsureComputeInstances()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("unknown node role \"loadbalancer\" in service account scope overrides"))
+			})
+
+			It("fails for an empty scope override", func() {
+				csEnv.ServiceAccountScopes = map[string]string{"k0s": " ; "}
+
+				err := bs.EnsureComputeInstances()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("empty service account scope override for role k0s"))
+			})
+
+			It("fails when an overridden source image does not exist", func() {
+				csEnv.SourceImages = map[string]string{"jumpbox": "golden-jumpbox"}
+				gc.EXPECT().GetImage(csEnv.ProjectID, "golden-jumpbox").Return(nil, notFoundErr)
+
+				err := bs.EnsureComputeInstances()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to resolve source images"))
+				Expect(err.Error()).To(ContainSubstring("image golden-jumpbox not found"))
+			})
+
 			It("fails when SSH key read fails", func() {
 				gc.EXPECT().GetInstance(csEnv.ProjectID, csEnv.Zone, mock.Anything).Return(nil, notFoundErr).Maybe()
 				fw.EXPECT().ReadFile(mock.Anything).Return(nil, fmt.Errorf("read error")).Maybe()
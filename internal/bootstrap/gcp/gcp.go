@@ -8,13 +8,18 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"os"
 	"path/filepath"
+	"regexp"
 	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	"cloud.google.com/go/compute/apiv1/computepb"
 	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	dnsprovider "github.com/codesphere-cloud/oms/internal/bootstrap/dns"
+	"github.com/codesphere-cloud/oms/internal/cloudflare"
 	"github.com/codesphere-cloud/oms/internal/clusteradmin"
 	"github.com/codesphere-cloud/oms/internal/env"
 	"github.com/codesphere-cloud/oms/internal/github"
@@ -22,10 +27,14 @@ import (
 	"github.com/codesphere-cloud/oms/internal/installer/files"
 	"github.com/codesphere-cloud/oms/internal/installer/node"
 	"github.com/codesphere-cloud/oms/internal/portal"
+	"github.com/codesphere-cloud/oms/internal/redact"
+	"github.com/codesphere-cloud/oms/internal/secretstore"
+	"github.com/codesphere-cloud/oms/internal/telemetry"
 	"github.com/codesphere-cloud/oms/internal/testuser"
 	"github.com/codesphere-cloud/oms/internal/util"
+	"github.com/codesphere-cloud/oms/internal/version"
 	"github.com/lithammer/shortuuid"
-	"google.golang.org/api/dns/v1"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type RegistryType string
@@ -34,6 +43,43 @@ const (
 	RegistryTypeLocalContainer   RegistryType = "local-container"
 	RegistryTypeArtifactRegistry RegistryType = "artifact-registry"
 	RegistryTypeGitHub           RegistryType = "github"
+	RegistryTypeHarbor           RegistryType = "harbor"
+)
+
+// Supported backends for externalizing vault secrets.
+const (
+	SecretBackendGCPSecretManager = "gcpsm"
+	SecretBackendHashiVault       = "hcvault"
+)
+
+// Supported PostgreSQL provisioning modes.
+const (
+	// PostgresModeVM provisions PostgreSQL on a dedicated compute instance.
+	PostgresModeVM = "vm"
+	// PostgresModeCloudSQL provisions a managed Cloud SQL for PostgreSQL
+	// instance with a private IP instead of a postgres VM.
+	PostgresModeCloudSQL = "cloudsql"
+)
+
+// Supported storage provisioning modes.
+const (
+	// StorageModeVM provisions the Ceph cluster on dedicated compute instances.
+	StorageModeVM = "vm"
+	// StorageModeExternal points the installation at an existing Ceph cluster
+	// instead of provisioning ceph VMs.
+	StorageModeExternal = "external"
+)
+
+// Supported storage backends.
+const (
+	// StorageBackendCeph deploys a Ceph cluster for workspace storage.
+	StorageBackendCeph = "ceph"
+	// StorageBackendLonghorn deploys Longhorn on the k0s nodes and provisions
+	// no ceph VMs. Intended for small PoC environments.
+	StorageBackendLonghorn = "longhorn"
+	// StorageBackendLocalPath deploys the local-path provisioner on the k0s
+	// nodes and provisions no ceph VMs. No replication; PoC only.
+	StorageBackendLocalPath = "local-path"
 )
 
 // CheckOMSManagedLabel checks if the given labels map indicates an OMS-managed project.
@@ -60,29 +106,10 @@ func GetDNSRecordNames(baseDomain string) []struct {
 		{fmt.Sprintf("ws.%s.", baseDomain), "A"},
 		{fmt.Sprintf("*.ws.%s.", baseDomain), "A"},
 		{fmt.Sprintf("*.ssh.cs.%s.", baseDomain), "A"},
+		{dnsprovider.OwnershipRecordName(baseDomain), "TXT"},
 	}
 }
 
-// This should ALWAYS be empty. Internal flags are for internal feature
-// development and not intended for customer use.
-// Atm. it's not empty as the internal flags below are likely preview or
-// feature flags, but are still in the internal bucket for historical
-// reasons (before we only had one "experiments" bucket).
-var DefaultInternalFlags []string = []string{
-	"headless-services",
-	"vcluster",
-	"custom-service-image",
-	"ms-in-ls",
-}
-
-var DefaultPreviewFlags []string = []string{
-	"secret-management",
-	"sub-path-mount",
-	"workspace-ssh",
-}
-
-var DefaultFeatureFlags []string = []string{}
-
 type GCPBootstrapper struct {
 	ctx       context.Context
 	stlog     *bootstrap.StepLogger
@@ -93,49 +120,85 @@ type GCPBootstrapper struct {
 	// Environment
 	Env *CodesphereEnvironment
 	// SSH command runner
-	NodeClient   node.NodeClient
-	PortalClient portal.Portal
-	GitHubClient github.GitHubClient
+	NodeClient       node.NodeClient
+	PortalClient     portal.Portal
+	GitHubClient     github.GitHubClient
+	CloudflareClient cloudflare.CloudflareClient
+	// DNSClient overrides the provider selected via Env.DNSProvider when set.
+	DNSClient dnsprovider.DNSProvider
+	// Resolver overrides the system resolver used for manual DNS verification.
+	Resolver DNSResolver
+	// Images overrides the registry client used to verify container images.
+	Images ImageChecker
+	// Ledger records the billable resources each step creates for the run
+	// cost accounting.
+	Ledger *CostLedger
 }
 
 type CodesphereEnvironment struct {
-	ProjectID                     string       `json:"project_id"`
-	ProjectTTL                    string       `json:"project_ttl"`
-	ProjectName                   string       `json:"project_name"`
-	DNSProjectID                  string       `json:"dns_project_id"`
-	Jumpbox                       *node.Node   `json:"jumpbox"`
-	PostgreSQLNode                *node.Node   `json:"postgres_node"`
-	ControlPlaneNodes             []*node.Node `json:"control_plane_nodes"`
-	CephNodes                     []*node.Node `json:"ceph_nodes"`
-	ContainerRegistryURL          string       `json:"-"`
-	ExistingConfigUsed            bool         `json:"-"`
-	InstallVersion                string       `json:"install_version"`
-	InstallLocal                  string       `json:"install_local"`
-	InstallHash                   string       `json:"install_hash"`
-	InstallSkipSteps              []string     `json:"install_skip_steps"`
-	Preemptible                   bool         `json:"preemptible"`
-	SpotVMs                       bool         `json:"spot_vms"`
-	WriteConfig                   bool         `json:"-"`
-	RecoverConfig                 bool         `json:"-"`
-	GatewayIP                     string       `json:"gateway_ip"`
-	PublicGatewayIP               string       `json:"public_gateway_ip"`
-	SshProxyIP                    string       `json:"ssh_proxy_ip"`
-	RegistryType                  RegistryType `json:"registry_type"`
-	GitHubPAT                     string       `json:"-"`
-	GitHubAppName                 string       `json:"-"`
-	GitHubTeamOrg                 string       `json:"github_team_org"`
-	GitHubTeamSlug                string       `json:"github_team_slug"`
-	RegistryUser                  string       `json:"-"`
-	InternalFlags                 []string     `json:"internal"`
-	PreviewFlags                  []string     `json:"preview"`
-	FeatureFlags                  []string     `json:"feature_flags"`
-	ExternalLokiEndpoint          string       `json:"external_loki_endpoint,omitempty"`
-	ExternalLokiSecret            string       `json:"-"`
-	ExternalLokiUser              string       `json:"external_loki_user,omitempty"`
-	PrometheusRemoteWriteUser     string       `json:"prometheus_remote_write_user,omitempty"`
-	PrometheusRemoteWritePassword string       `json:"-"`
-	PrometheusRemoteWriteURL      string       `json:"prometheus_remote_write_url,omitempty"`
-	ClusterAdminEmail             string       `json:"cluster_admin_email,omitempty"`
+	ProjectID                     string            `json:"project_id"`
+	ProjectTTL                    string            `json:"project_ttl"`
+	ProjectName                   string            `json:"project_name"`
+	DNSProjectID                  string            `json:"dns_project_id"`
+	Jumpbox                       *node.Node        `json:"jumpbox"`
+	PostgreSQLNode                *node.Node        `json:"postgres_node"`
+	PostgreSQLReplicaNode         *node.Node        `json:"postgres_replica_node,omitempty"`
+	ControlPlaneNodes             []*node.Node      `json:"control_plane_nodes"`
+	CephNodes                     []*node.Node      `json:"ceph_nodes"`
+	ContainerRegistryURL          string            `json:"-"`
+	ExistingConfigUsed            bool              `json:"-"`
+	InstallVersion                string            `json:"install_version"`
+	InstallLocal                  string            `json:"install_local"`
+	InstallHash                   string            `json:"install_hash"`
+	InstallSkipSteps              []string          `json:"install_skip_steps"`
+	PrePullImages                 []string          `json:"pre_pull_images,omitempty"`
+	PreWarmDeployImages           bool              `json:"pre_warm_deploy_images,omitempty"`
+	NewWorkerNodes                []string          `json:"-"`
+	Preemptible                   bool              `json:"preemptible"`
+	SpotVMs                       bool              `json:"spot_vms"`
+	ShieldedVMs                   bool              `json:"shielded_vms"`
+	ShieldedSecureBoot            bool              `json:"shielded_secure_boot,omitempty"`
+	ShieldedVtpm                  bool              `json:"shielded_vtpm,omitempty"`
+	ShieldedIntegrityMonitoring   bool              `json:"shielded_integrity_monitoring,omitempty"`
+	ConfidentialVMs               bool              `json:"confidential_vms"`
+	DiskEncryptionKey             string            `json:"disk_encryption_key"`
+	GenerateDiskEncryptionKey     bool              `json:"-"`
+	KMSVault                      bool              `json:"kms_vault,omitempty"`
+	VaultKMSKeyName               string            `json:"vault_kms_key_name,omitempty"`
+	SecretBackend                 string            `json:"secret_backend,omitempty"`
+	NodeParallelism               int               `json:"-"`
+	CleanPartialResources         bool              `json:"-"`
+	NodeOS                        string            `json:"node_os,omitempty"`
+	StepTimeout                   time.Duration     `json:"-"`
+	ManagedDiskEncryptionKey      bool              `json:"managed_disk_encryption_key,omitempty"`
+	SourceImages                  map[string]string `json:"source_images"`
+	ServiceAccountScopes          map[string]string `json:"service_account_scopes,omitempty"`
+	WriteConfig                   bool              `json:"-"`
+	RecoverConfig                 bool              `json:"-"`
+	GatewayIP                     string            `json:"gateway_ip"`
+	PublicGatewayIP               string            `json:"public_gateway_ip"`
+	SshProxyIP                    string            `json:"ssh_proxy_ip"`
+	GatewayIPv6                   string            `json:"gateway_ipv6,omitempty"`
+	PublicGatewayIPv6             string            `json:"public_gateway_ipv6,omitempty"`
+	SshProxyIPv6                  string            `json:"ssh_proxy_ipv6,omitempty"`
+	RegistryType                  RegistryType      `json:"registry_type"`
+	RegistryKeepTagCount          int               `json:"registry_keep_tag_count,omitempty"`
+	RegistryUntaggedRetentionDays int               `json:"registry_untagged_retention_days,omitempty"`
+	GitHubPAT                     string            `json:"-"`
+	GitHubAppName                 string            `json:"-"`
+	GitHubTeamOrg                 string            `json:"github_team_org"`
+	GitHubTeamSlug                string            `json:"github_team_slug"`
+	RegistryUser                  string            `json:"-"`
+	InternalFlags                 []string          `json:"internal"`
+	PreviewFlags                  []string          `json:"preview"`
+	FeatureFlags                  []string          `json:"feature_flags"`
+	ExternalLokiEndpoint          string            `json:"external_loki_endpoint,omitempty"`
+	ExternalLokiSecret            string            `json:"-"`
+	ExternalLokiUser              string            `json:"external_loki_user,omitempty"`
+	PrometheusRemoteWriteUser     string            `json:"prometheus_remote_write_user,omitempty"`
+	PrometheusRemoteWritePassword string            `json:"-"`
+	PrometheusRemoteWriteURL      string            `json:"prometheus_remote_write_url,omitempty"`
+	ClusterAdminEmail             string            `json:"cluster_admin_email,omitempty"`
 
 	// ACME Issuer
 	GoogleACMEIssuer bool `json:"google_acme_issuer,omitempty"`
@@ -153,7 +216,14 @@ type CodesphereEnvironment struct {
 	CentralOtelSpanMetrics bool   `json:"-"`
 	LocalTraceEndpoint     string `json:"-"`
 
+	// External secret store for generated credentials
+	SecretStore *secretstore.Config `json:"-"`
+
+	// License entitlement fetched from the portal
+	Entitlement *portal.Entitlement `json:"-"`
+
 	// Config
+	InstallProfile    string              `json:"install_profile,omitempty"`
 	InstallConfigPath string              `json:"-"`
 	SecretsFilePath   string              `json:"-"`
 	InstallConfig     *files.RootConfig   `json:"-"`
@@ -179,17 +249,184 @@ type CodesphereEnvironment struct {
 	FolderID                   string `json:"folder_id"`
 	SSHPublicKeyPath           string `json:"-"`
 	SSHPrivateKeyPath          string `json:"-"`
-	DatacenterID               int    `json:"-"`
-	DatacenterName             string `json:"-"`
-	CustomPgIP                 string `json:"custom_pg_ip"`
-	Region                     string `json:"region"`
-	Zone                       string `json:"zone"`
-	DNSZoneName                string `json:"dns_zone_name"`
+	// SSHPort is the port sshd listens on on every node; zero or 22 keeps the
+	// default. Applied through the sshd hardening drop-in and reflected in
+	// the SSH firewall rules.
+	SSHPort        int    `json:"ssh_port,omitempty"`
+	DatacenterID   int    `json:"-"`
+	DatacenterName string `json:"-"`
+	CustomPgIP     string `json:"custom_pg_ip"`
+	Region         string `json:"region"`
+	Zone           string `json:"zone"`
+	DNSZoneName    string `json:"dns_zone_name"`
+	DNSRecordTTL   int64  `json:"dns_record_ttl"`
+	// DNSProvider selects where zones and records are managed (clouddns or
+	// route53); empty defaults to Cloud DNS.
+	DNSProvider string `json:"dns_provider,omitempty"`
+	// DNSMode selects between OMS-managed records (managed) and operator-created
+	// records that are only verified (manual).
+	DNSMode string `json:"dns_mode,omitempty"`
+	// DNSSEC enables DNSSEC signing on the managed Cloud DNS zone.
+	DNSSEC bool `json:"dnssec,omitempty"`
+	// DNSSECDSRecord is the DS record that must be published at the registrar,
+	// recorded so the bootstrap summary can surface it.
+	DNSSECDSRecord string `json:"dnssec_ds_record,omitempty"`
+	// ManualDNSTimeout bounds how long manual DNS verification polls resolvers.
+	ManualDNSTimeout  time.Duration `json:"-"`
+	ApexDomainRecords bool          `json:"apex_domain_records"`
+	UseIAPTunnel      bool          `json:"use_iap_tunnel"`
+	// NoJumpbox provisions the environment without a dedicated jumpbox VM.
+	// The operator machine reaches every node directly through IAP tunnels,
+	// and duties that would run on the jumpbox (OMS tooling, preflight
+	// checks, scheduled jobs, the install itself) run on the first control
+	// plane node instead. Requires UseIAPTunnel.
+	NoJumpbox bool `json:"no_jumpbox,omitempty"`
+	// DedicatedControlPlane keeps workloads off the base k0s nodes: they
+	// become tainted control planes and WorkerCount additional worker
+	// instances carry the workloads instead.
+	DedicatedControlPlane bool `json:"dedicated_control_plane,omitempty"`
+	// WorkerCount is the size of the dedicated worker pool. Only used with
+	// DedicatedControlPlane.
+	WorkerCount int `json:"worker_count,omitempty"`
+	// WorkerTaints are applied to every dedicated worker node, e.g. to
+	// reserve a pool for specific workloads.
+	WorkerTaints []string `json:"worker_taints,omitempty"`
+	// ArtifactsBucket is a GCS bucket mounted on the ops node via gcsfuse;
+	// the install package and its extracted contents live there instead of
+	// the root disk.
+	ArtifactsBucket string `json:"artifacts_bucket,omitempty"`
+	// ArtifactsDiskGB attaches an extra disk of this size to the jumpbox and
+	// mounts it for the install package and its extracted contents.
+	ArtifactsDiskGB int64 `json:"artifacts_disk_gb,omitempty"`
+	// IPv6 enables dual-stack networking: an IPv6-enabled subnet, IPv6
+	// firewall rules, IPv6 gateway addresses, and AAAA DNS records.
+	IPv6 bool `json:"ipv6,omitempty"`
+	// HAPostgres provisions a PostgreSQL primary + replica pair instead of a
+	// single postgres VM and wires the replica into the install config.
+	HAPostgres bool `json:"ha_postgres,omitempty"`
+	// PostgresMode selects how PostgreSQL is provisioned: on a dedicated VM
+	// (vm) or as a managed Cloud SQL instance with a private IP (cloudsql);
+	// empty defaults to vm.
+	PostgresMode string `json:"postgres_mode,omitempty"`
+	// CloudSQLInstanceIP is the private IP of the Cloud SQL instance, recorded
+	// so re-runs can wire the install config without another API lookup.
+	CloudSQLInstanceIP string `json:"cloudsql_instance_ip,omitempty"`
+	// StorageMode selects where workspace storage comes from: a Ceph cluster
+	// on dedicated VMs (vm) or an existing external Ceph cluster (external);
+	// empty defaults to vm.
+	StorageMode string `json:"storage_mode,omitempty"`
+	// StorageBackend selects the CSI stack providing workspace storage:
+	// ceph (default), longhorn, or local-path. Non-Ceph backends provision
+	// no ceph VMs and are installed on the k0s nodes after Codesphere.
+	StorageBackend string `json:"storage_backend,omitempty"`
+	// K8sDistribution selects the Kubernetes distribution deployed on the
+	// cluster nodes: k0s (default), k3s, or rke2.
+	K8sDistribution string `json:"k8s_distribution,omitempty"`
+	// KubeconfigPath points at the kubeconfig of an existing cluster the
+	// operator brings. When set, no kubernetes nodes are provisioned; the
+	// cluster is validated and Codesphere is installed onto it.
+	KubeconfigPath string `json:"kubeconfig_path,omitempty"`
+	// KubernetesVersion pins the k0s release running on the cluster nodes,
+	// e.g. v1.33.2+k0s.0. Empty keeps whatever version the installer bundles.
+	KubernetesVersion string `json:"kubernetes_version,omitempty"`
+	// CCMVersion selects the cloud-provider-gcp release deployed on the
+	// cluster. Empty deploys DefaultCCMVersion.
+	CCMVersion string `json:"ccm_version,omitempty"`
+	// CCMImage overrides the cloud controller manager image. Empty derives
+	// the image from the selected CCM version.
+	CCMImage string `json:"ccm_image,omitempty"`
+	// CCMManifestURL overrides the upstream manifest with the RBAC rules and
+	// service account of the cloud controller manager, e.g. for mirrors.
+	CCMManifestURL string `json:"ccm_manifest_url,omitempty"`
+	// OmsVersion is the oms-cli version that last bootstrapped the
+	// environment, recorded for the version skew check.
+	OmsVersion string `json:"oms_version,omitempty"`
+	// LastFailure is the last remote installer failure, cleared again by a
+	// successful installation.
+	LastFailure *InstallFailure `json:"last_failure,omitempty"`
+	// CephMonHosts are the monitor addresses of the external Ceph cluster.
+	CephMonHosts []string `json:"ceph_mon_hosts,omitempty"`
+	// CephKeyringFile is a local file whose contents are stored in the vault
+	// as the keyring authenticating against the external Ceph cluster.
+	CephKeyringFile string `json:"ceph_keyring_file,omitempty"`
+	// Ceph OSD layout of the managed cluster: disk sizes and OSD count per
+	// host shape the ceph VMs, replica count and failure domain the pool.
+	// Zero values fall back to the package defaults.
+	CephDataDiskGB    int64  `json:"ceph_data_disk_gb,omitempty"`
+	CephDBDiskGB      int64  `json:"ceph_db_disk_gb,omitempty"`
+	CephOSDsPerHost   int    `json:"ceph_osds_per_host,omitempty"`
+	CephReplicaCount  int    `json:"ceph_replica_count,omitempty"`
+	CephFailureDomain string `json:"ceph_failure_domain,omitempty"`
+	NodeLocalDNS      bool   `json:"node_local_dns"`
+	// ResourceLabels are user-provided labels (e.g. owner, cost-center,
+	// environment) applied to every labelable resource the bootstrapper
+	// creates, in addition to oms-managed=true.
+	ResourceLabels map[string]string `json:"resource_labels,omitempty"`
+
+	// CIRunner optionally provisions an in-environment GitHub Actions runner.
+	CIRunner     *CIRunnerConfig `json:"ci_runner,omitempty"`
+	CIRunnerNode *node.Node      `json:"ci_runner_node,omitempty"`
+
+	// Harbor settings, used when RegistryType is harbor: the release to
+	// install, the size of the VM disk holding the registry data, and the
+	// node record of the dedicated Harbor VM.
+	HarborVersion string     `json:"harbor_version,omitempty"`
+	HarborDiskGB  int64      `json:"harbor_disk_gb,omitempty"`
+	HarborNode    *node.Node `json:"harbor_node,omitempty"`
+
+	// Protected locks the environment against teardown and re-bootstrapping:
+	// destructive commands require --force plus typing the project ID.
+	Protected bool `json:"protected,omitempty"`
+
+	// MaintenanceWindow restricts when disruptive operations may run; outside
+	// the window they are refused unless --override-window is passed.
+	MaintenanceWindow *MaintenanceWindow `json:"maintenance_window,omitempty"`
+
+	// ScheduledJobs are systemd timers installed on the jumpbox for recurring
+	// maintenance tasks, managed via the bootstrap-gcp timers command.
+	ScheduledJobs []ScheduledJob `json:"scheduled_jobs,omitempty"`
+
+	// VerificationSchedule installs a scheduled job that runs the config
+	// verification suite (OnCalendar expression, e.g. "hourly").
+	VerificationSchedule string `json:"verification_schedule,omitempty"`
+	// VerificationWebhook is the URL the verification job posts its results to.
+	VerificationWebhook string `json:"verification_webhook,omitempty"`
+
+	// PGBackups provisions a GCS backup bucket with a retention lifecycle
+	// policy and declares a scheduled job that uploads regular PostgreSQL
+	// dumps to it.
+	PGBackups bool `json:"pg_backups,omitempty"`
+	// PGBackupSchedule is the OnCalendar expression of the backup job.
+	PGBackupSchedule string `json:"pg_backup_schedule,omitempty"`
+	// PGBackupRetentionDays bounds how long dumps are kept before the bucket
+	// lifecycle policy deletes them.
+	PGBackupRetentionDays int64 `json:"pg_backup_retention_days,omitempty"`
+
+	// Governance metadata: Essential Contacts receive GCP notifications for
+	// their category, owner and customer ID are recorded as project labels.
+	SecurityContact  string `json:"security_contact,omitempty"`
+	BillingContact   string `json:"billing_contact,omitempty"`
+	TechnicalContact string `json:"technical_contact,omitempty"`
+	ProjectOwner     string `json:"project_owner,omitempty"`
+	CustomerID       string `json:"customer_id,omitempty"`
+
+	// Cloud Armor
+	CloudArmorPolicy           bool     `json:"cloud_armor_policy"`
+	CloudArmorRateLimitQPM     int32    `json:"cloud_armor_rate_limit_qpm"`
+	CloudArmorBlockedCountries []string `json:"cloud_armor_blocked_countries"`
+
+	// Cloudflare exposure mode
+	UseCloudflare      bool   `json:"use_cloudflare"`
+	CloudflareZoneID   string `json:"cloudflare_zone_id"`
+	CloudflareAPIToken string `json:"-"`
 
 	// Test user creation
 	CreateTestUser bool   `json:"-"`
 	OmsWorkdir     string `json:"-"`
 	RootDiskSize   int64  `json:"root_disk_size"`
+	// DiskSizes records per-node root disk sizes that diverge from
+	// RootDiskSize, e.g. after a grow-disk run. Keyed by node name.
+	DiskSizes map[string]int64 `json:"disk_sizes,omitempty"`
 	// Local OMS binary copied to the jumpbox instead of installing a release.
 	RemoteOmsBinaryPath string `json:"-"`
 }
@@ -206,257 +443,476 @@ func NewGCPBootstrapper(
 	portalClient portal.Portal,
 	time util.Time,
 	gitHubClient github.GitHubClient,
+	cloudflareClient cloudflare.CloudflareClient,
 ) (*GCPBootstrapper, error) {
 	return &GCPBootstrapper{
-		ctx:          ctx,
-		stlog:        stlog,
-		fw:           fw,
-		icg:          icg,
-		GCPClient:    gcpClient,
-		Env:          CodesphereEnv,
-		NodeClient:   sshRunner,
-		PortalClient: portalClient,
-		Time:         time,
-		GitHubClient: gitHubClient,
+		ctx:              ctx,
+		stlog:            stlog,
+		fw:               fw,
+		icg:              icg,
+		GCPClient:        gcpClient,
+		Env:              CodesphereEnv,
+		NodeClient:       sshRunner,
+		PortalClient:     portalClient,
+		Time:             time,
+		GitHubClient:     gitHubClient,
+		CloudflareClient: cloudflareClient,
+		Ledger:           &CostLedger{},
 	}, nil
 }
 
+// runStep executes a bootstrap step, honoring context cancellation and the
+// configured per-step timeout. When the context is cancelled (e.g. Ctrl-C) or
+// the timeout elapses, the step is abandoned and its error is returned so the
+// caller can persist the checkpoint state; GCP client calls observe the same
+// context and unwind shortly after.
+func (b *GCPBootstrapper) runStep(name string, fn func() error) error {
+	if err := b.ctx.Err(); err != nil {
+		return fmt.Errorf("bootstrap aborted: %w", err)
+	}
+
+	stepCtx := b.ctx
+	if b.Env.StepTimeout > 0 {
+		var cancel context.CancelFunc
+		stepCtx, cancel = context.WithTimeout(b.ctx, b.Env.StepTimeout)
+		defer cancel()
+	}
+
+	return b.stlog.Step(name, func() error {
+		_, span := telemetry.StartSpan(stepCtx, name, attribute.String("oms.bootstrap.step", name))
+		done := make(chan error, 1)
+		go func() { done <- fn() }()
+		select {
+		case err := <-done:
+			telemetry.EndSpan(span, err)
+			return err
+		case <-stepCtx.Done():
+			err := fmt.Errorf("step aborted: %w", stepCtx.Err())
+			telemetry.EndSpan(span, err)
+			return err
+		}
+	})
+}
+
 func (b *GCPBootstrapper) Bootstrap() error {
-	err := b.stlog.Step("Validate input", b.ValidateInput)
+	for _, step := range BootstrapSteps() {
+		if step.Condition != nil && !step.Condition(b.Env) {
+			continue
+		}
+		b.Ledger.SetStep(step.Name)
+		if err := b.runStep(step.Name, func() error { return step.Run(b) }); err != nil {
+			return fmt.Errorf(step.ErrorFormat, err)
+		}
+	}
+
+	if b.Env.CreateTestUser {
+		if err := b.createTestUser(); err != nil {
+			log.Printf("warning: failed to create test user: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// createTestUser creates a test user in the PostgreSQL instance using the testuser package and logs the credentials.
+func (b *GCPBootstrapper) createTestUser() error {
+	if b.Env.PostgreSQLNode == nil {
+		return fmt.Errorf("postgres node not found in bootstrap environment")
+	}
+
+	pgHost := b.Env.PostgreSQLNode.GetExternalIP()
+	if pgHost == "" {
+		return fmt.Errorf("postgres node has no external IP")
+	}
+
+	if b.Env.InstallConfig == nil {
+		return fmt.Errorf("install config not found in bootstrap environment")
+	}
+	pgPasswordSecret := b.icg.GetVault().GetSecret(files.SecretPostgresPassword)
+	if pgPasswordSecret == nil || pgPasswordSecret.Fields == nil {
+		return fmt.Errorf("postgres admin password not found in vault")
+	}
+	pgPassword := pgPasswordSecret.Fields.Password
+
+	result, err := testuser.CreateTestUser(testuser.CreateTestUserOpts{
+		Host:         pgHost,
+		Port:         testuser.DefaultPort,
+		User:         testuser.DefaultUser,
+		Password:     pgPassword,
+		DBName:       testuser.DefaultDBName,
+		SSLMode:      "require",
+		DatacenterID: b.Env.DatacenterID,
+	})
 	if err != nil {
-		return fmt.Errorf("invalid input: %w", err)
+		return err
 	}
 
-	err = b.stlog.Step("Ensure install config", b.EnsureInstallConfig)
+	testuser.LogAndPersistResult(result, b.Env.OmsWorkdir)
+	return nil
+}
+func (b *GCPBootstrapper) ValidateInput() error {
+	if b.Env.GoogleACMEIssuer && b.Env.ACMEStaging {
+		return fmt.Errorf("acme-staging cannot be combined with google-acme-issuer")
+	}
+
+	if b.Env.RemoteOmsBinaryPath != "" && !b.fw.Exists(b.Env.RemoteOmsBinaryPath) {
+		return fmt.Errorf("remote OMS binary not found at path: %s", b.Env.RemoteOmsBinaryPath)
+	}
+
+	err := b.validateInstallVersion()
 	if err != nil {
-		return fmt.Errorf("failed to ensure install config: %w", err)
+		return err
 	}
 
-	err = b.stlog.Step("Ensure secrets", b.EnsureSecrets)
+	err = b.validateVMProvisioningOptions()
 	if err != nil {
-		return fmt.Errorf("failed to ensure secrets: %w", err)
+		return err
 	}
 
-	err = b.stlog.Step("Ensure project", b.EnsureProject)
+	err = b.validatePostgresMode()
 	if err != nil {
-		return fmt.Errorf("failed to ensure GCP project: %w", err)
+		return err
 	}
 
-	err = b.stlog.Step("Write infrastructure file", b.WriteInfraFile)
+	err = b.validateStorageMode()
 	if err != nil {
-		return fmt.Errorf("failed to write infrastructure file: %w", err)
+		return err
 	}
 
-	err = b.stlog.Step("Ensure billing", b.EnsureBilling)
+	err = b.validateStorageBackend()
 	if err != nil {
-		return fmt.Errorf("failed to ensure billing is enabled: %w", err)
+		return err
 	}
 
-	err = b.stlog.Step("Ensure APIs enabled", b.EnsureAPIsEnabled)
+	err = b.validateCephLayout()
 	if err != nil {
-		return fmt.Errorf("failed to enable required APIs: %w", err)
+		return err
 	}
 
-	if b.Env.RegistryType == RegistryTypeArtifactRegistry {
-		err = b.stlog.Step("Ensure artifact registry", b.EnsureArtifactRegistry)
-		if err != nil {
-			return fmt.Errorf("failed to ensure artifact registry: %w", err)
-		}
+	err = b.validateK8sDistribution()
+	if err != nil {
+		return err
 	}
 
-	err = b.stlog.Step("Ensure service accounts", b.EnsureServiceAccounts)
+	err = b.validateByoK8s()
 	if err != nil {
-		return fmt.Errorf("failed to ensure service accounts: %w", err)
+		return err
 	}
 
-	err = b.stlog.Step("Ensure IAM roles", b.EnsureIAMRoles)
+	err = b.validateKubernetesVersion()
 	if err != nil {
-		return fmt.Errorf("failed to ensure IAM roles: %w", err)
+		return err
 	}
 
-	err = b.stlog.Step("Ensure VPC", b.EnsureVPC)
+	err = b.validateNoJumpbox()
 	if err != nil {
-		return fmt.Errorf("failed to ensure VPC: %w", err)
+		return err
 	}
 
-	err = b.stlog.Step("Ensure firewall rules", b.EnsureFirewallRules)
+	err = b.validateTopology()
 	if err != nil {
-		return fmt.Errorf("failed to ensure firewall rules: %w", err)
+		return err
 	}
 
-	err = b.stlog.Step("Ensure compute instances", b.EnsureComputeInstances)
+	err = b.validateArtifactStorage()
 	if err != nil {
-		return fmt.Errorf("failed to ensure compute instances: %w", err)
+		return err
 	}
 
-	err = b.stlog.Step("Ensure gateway IP addresses", b.EnsureGatewayIPAddresses)
+	err = b.validateGitHubParams()
 	if err != nil {
-		return fmt.Errorf("failed to ensure external IP addresses: %w", err)
+		return err
 	}
 
-	err = b.stlog.Step("Ensure root login enabled", b.EnsureRootLoginEnabled)
+	err = b.validateGitProviderParams()
 	if err != nil {
-		return fmt.Errorf("failed to ensure root login is enabled: %w", err)
+		return err
 	}
 
-	err = b.stlog.Step("Ensure jumpbox configured", b.EnsureJumpboxConfigured)
+	err = b.validateOidcParams()
 	if err != nil {
-		return fmt.Errorf("failed to ensure jumpbox is configured: %w", err)
+		return err
 	}
 
-	err = b.stlog.Step("Ensure hosts are configured", b.EnsureHostsConfigured)
+	err = b.validateExternalLokiParams()
 	if err != nil {
-		return fmt.Errorf("failed to ensure hosts are configured: %w", err)
+		return err
 	}
 
-	if b.Env.RegistryType == RegistryTypeLocalContainer {
-		err = b.stlog.Step("Ensure local container registry", b.EnsureLocalContainerRegistry)
-		if err != nil {
-			return fmt.Errorf("failed to ensure local container registry: %w", err)
-		}
+	err = b.validatePrometheusRemoteWriteParams()
+	if err != nil {
+		return err
 	}
 
-	if b.Env.RegistryType == RegistryTypeGitHub {
-		err = b.stlog.Step("Ensure GitHub access configured", b.EnsureGitHubAccessConfigured)
-		if err != nil {
-			return fmt.Errorf("failed to update install config: %w", err)
-		}
+	err = b.validateClusterAdminEmail()
+	if err != nil {
+		return err
 	}
 
-	if b.Env.WriteConfig {
-		err = b.stlog.Step("Update install config", b.UpdateInstallConfig)
-		if err != nil {
-			return fmt.Errorf("failed to update install config: %w", err)
-		}
+	err = b.validateGovernanceParams()
+	if err != nil {
+		return err
+	}
 
-		err = b.stlog.Step("Ensure age key", b.EnsureAgeKey)
-		if err != nil {
-			return fmt.Errorf("failed to ensure age key: %w", err)
-		}
+	err = b.validateSSHPort()
+	if err != nil {
+		return err
+	}
 
-		err = b.stlog.Step("Encrypt vault", b.EncryptVault)
-		if err != nil {
-			return fmt.Errorf("failed to encrypt vault: %w", err)
-		}
+	err = b.validateDNSParams()
+	if err != nil {
+		return err
 	}
 
-	err = b.stlog.Step("Ensure DNS records", b.EnsureDNSRecords)
+	err = b.validateDiskEncryptionKey()
 	if err != nil {
-		return fmt.Errorf("failed to ensure DNS records: %w", err)
+		return err
 	}
 
-	err = b.stlog.Step("Generate k0s config script", b.GenerateK0sConfigScript)
+	err = b.validateNodeOS()
 	if err != nil {
-		return fmt.Errorf("failed to generate k0s config script: %w", err)
+		return err
 	}
 
-	if b.Env.InstallVersion != "" || b.Env.InstallLocal != "" {
-		err = b.stlog.Step("Install Codesphere", b.InstallCodesphere)
-		if err != nil {
-			return fmt.Errorf("failed to install Codesphere: %w", err)
-		}
+	err = b.validateCloudflareParams()
+	if err != nil {
+		return err
+	}
 
-		err = b.stlog.Step("Run k0s config script", b.RunK0sConfigScript)
-		if err != nil {
-			return fmt.Errorf("failed to run k0s config script: %w", err)
-		}
+	err = b.validateResourceLabels()
+	if err != nil {
+		return err
 	}
 
-	if b.Env.CreateTestUser {
-		if err := b.createTestUser(); err != nil {
-			log.Printf("warning: failed to create test user: %v", err)
+	err = b.validateCIRunnerParams()
+	if err != nil {
+		return err
+	}
+
+	err = b.declareVerificationJob()
+	if err != nil {
+		return err
+	}
+
+	err = b.declarePGBackupJob()
+	if err != nil {
+		return err
+	}
+
+	b.applyDefaultFlags()
+
+	err = b.validateScheduledJobs()
+	if err != nil {
+		return err
+	}
+
+	return b.validateTelemetryExportParams()
+}
+
+// validateResourceLabels ensures the user-provided resource labels are valid
+// GCP labels, since invalid labels would only fail deep inside resource
+// creation otherwise.
+func (b *GCPBootstrapper) validateResourceLabels() error {
+	labelRegex := regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`)
+	valueRegex := regexp.MustCompile(`^[a-z0-9_-]{0,63}$`)
+
+	for key, value := range b.Env.ResourceLabels {
+		if key == OMSManagedLabel {
+			return fmt.Errorf("label %q is reserved and set automatically", OMSManagedLabel)
+		}
+		if !labelRegex.MatchString(key) {
+			return fmt.Errorf("invalid label key %q: must start with a lowercase letter and contain only lowercase letters, digits, underscores and dashes", key)
+		}
+		if !valueRegex.MatchString(value) {
+			return fmt.Errorf("invalid value %q for label %q: must contain only lowercase letters, digits, underscores and dashes", value, key)
 		}
 	}
 
 	return nil
 }
 
-// createTestUser creates a test user in the PostgreSQL instance using the testuser package and logs the credentials.
-func (b *GCPBootstrapper) createTestUser() error {
-	if b.Env.PostgreSQLNode == nil {
-		return fmt.Errorf("postgres node not found in bootstrap environment")
+// resourceLabels returns the label set applied to every labelable resource the
+// bootstrapper creates, so environments can be discovered and attributed by
+// label. Firewall rules do not support labels in GCP and are excluded.
+func (b *GCPBootstrapper) resourceLabels() map[string]string {
+	labels := map[string]string{OMSManagedLabel: "true"}
+	for key, value := range b.Env.ResourceLabels {
+		labels[key] = value
 	}
+	return labels
+}
 
-	pgHost := b.Env.PostgreSQLNode.GetExternalIP()
-	if pgHost == "" {
-		return fmt.Errorf("postgres node has no external IP")
+// validateCloudflareParams checks that the Cloudflare exposure mode has the
+// required API token and zone ID configured.
+func (b *GCPBootstrapper) validateCloudflareParams() error {
+	if !b.Env.UseCloudflare {
+		return nil
 	}
 
-	if b.Env.InstallConfig == nil {
-		return fmt.Errorf("install config not found in bootstrap environment")
+	if b.Env.CloudflareAPIToken == "" {
+		return fmt.Errorf("cloudflare API token must be set when using Cloudflare exposure mode")
 	}
-	pgPasswordSecret := b.icg.GetVault().GetSecret(files.SecretPostgresPassword)
-	if pgPasswordSecret == nil || pgPasswordSecret.Fields == nil {
-		return fmt.Errorf("postgres admin password not found in vault")
+	if b.Env.CloudflareZoneID == "" {
+		return fmt.Errorf("cloudflare zone ID must be set when using Cloudflare exposure mode")
 	}
-	pgPassword := pgPasswordSecret.Fields.Password
 
-	result, err := testuser.CreateTestUser(testuser.CreateTestUserOpts{
-		Host:         pgHost,
-		Port:         testuser.DefaultPort,
-		User:         testuser.DefaultUser,
-		Password:     pgPassword,
-		DBName:       testuser.DefaultDBName,
-		SSLMode:      "require",
-		DatacenterID: b.Env.DatacenterID,
-	})
+	return nil
+}
+
+// EnsureDiskEncryptionKey creates the per-environment Cloud KMS key used to
+// encrypt all instance disks and grants the compute service agent access to
+// it. The key name is persisted in the infra file so teardown can destroy it
+// for cryptographic erasure.
+func (b *GCPBootstrapper) EnsureDiskEncryptionKey() error {
+	keyName, err := b.GCPClient.EnsureKMSKey(b.Env.ProjectID, b.Env.Region, "oms", "disk-encryption-key")
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to ensure KMS key: %w", err)
+	}
+
+	projectNumber, err := b.GCPClient.GetProjectNumber(b.Env.ProjectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project number: %w", err)
+	}
+	computeAgent := fmt.Sprintf("serviceAccount:service-%d@compute-system.iam.gserviceaccount.com", projectNumber)
+	err = b.GCPClient.AssignIAMRoleToMember(b.Env.ProjectID, computeAgent, []string{"roles/cloudkms.cryptoKeyEncrypterDecrypter"})
+	if err != nil {
+		return fmt.Errorf("failed to grant the compute service agent access to the disk encryption key: %w", err)
+	}
+
+	b.Env.DiskEncryptionKey = keyName
+	b.Env.ManagedDiskEncryptionKey = true
+
+	return b.WriteInfraFile()
+}
+
+// EnsureVaultKMSKey creates the Cloud KMS key used as SOPS recipient for the
+// secrets vault and grants the jumpbox service account decrypt rights, so the
+// vault can be read on the jumpbox without an age key. The key name is
+// persisted in the infra file. Only Cloud KMS is supported for now; the key
+// group handling in the vault package is provider-agnostic so AWS KMS can
+// follow.
+func (b *GCPBootstrapper) EnsureVaultKMSKey() error {
+	keyName, err := b.GCPClient.EnsureKMSKey(b.Env.ProjectID, b.Env.Region, "oms", "vault-encryption-key")
+	if err != nil {
+		return fmt.Errorf("failed to ensure KMS key: %w", err)
+	}
+
+	member := fmt.Sprintf("serviceAccount:cloud-controller@%s.iam.gserviceaccount.com", b.Env.ProjectID)
+	err = b.GCPClient.AssignIAMRoleToMember(b.Env.ProjectID, member, []string{"roles/cloudkms.cryptoKeyDecrypter"})
+	if err != nil {
+		return fmt.Errorf("failed to grant the jumpbox service account access to the vault encryption key: %w", err)
+	}
+
+	b.Env.VaultKMSKeyName = keyName
+
+	return b.WriteInfraFile()
+}
+
+// validateDiskEncryptionKey checks that the configured CMEK disk encryption key
+// is a fully qualified Cloud KMS key resource name.
+func (b *GCPBootstrapper) validateDiskEncryptionKey() error {
+	if b.Env.DiskEncryptionKey == "" {
+		return nil
+	}
+
+	if b.Env.GenerateDiskEncryptionKey {
+		return fmt.Errorf("cannot combine --generate-disk-encryption-key with an explicit --disk-encryption-key")
+	}
+
+	if !strings.HasPrefix(b.Env.DiskEncryptionKey, "projects/") || !strings.Contains(b.Env.DiskEncryptionKey, "/cryptoKeys/") {
+		return fmt.Errorf("disk encryption key must be a full Cloud KMS key resource name (projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>), got %q", b.Env.DiskEncryptionKey)
 	}
 
-	testuser.LogAndPersistResult(result, b.Env.OmsWorkdir)
 	return nil
 }
-func (b *GCPBootstrapper) ValidateInput() error {
-	if b.Env.GoogleACMEIssuer && b.Env.ACMEStaging {
-		return fmt.Errorf("acme-staging cannot be combined with google-acme-issuer")
+
+// validateSSHPort checks that a custom SSH port is in the valid port range.
+func (b *GCPBootstrapper) validateSSHPort() error {
+	if b.Env.SSHPort == 0 {
+		return nil
+	}
+	if b.Env.SSHPort < 1 || b.Env.SSHPort > 65535 {
+		return fmt.Errorf("invalid SSH port %d: must be between 1 and 65535", b.Env.SSHPort)
 	}
+	return nil
+}
 
-	if b.Env.RemoteOmsBinaryPath != "" && !b.fw.Exists(b.Env.RemoteOmsBinaryPath) {
-		return fmt.Errorf("remote OMS binary not found at path: %s", b.Env.RemoteOmsBinaryPath)
+// sshPort returns the SSH port used across the environment, defaulting to 22.
+func (b *GCPBootstrapper) sshPort() int {
+	if b.Env.SSHPort == 0 {
+		return node.DefaultSSHPort
 	}
+	return b.Env.SSHPort
+}
 
-	err := b.validateInstallVersion()
+// validateNodeOS checks that the configured node operating system is supported.
+func (b *GCPBootstrapper) validateNodeOS() error {
+	_, err := node.OSFromName(b.Env.NodeOS)
+	return err
+}
+
+// nodeOS returns the configured node operating system. ValidateInput already
+// rejected unsupported names, so unknown values fall back to Ubuntu.
+func (b *GCPBootstrapper) nodeOS() node.OperatingSystem {
+	os, err := node.OSFromName(b.Env.NodeOS)
 	if err != nil {
-		return err
+		return node.UbuntuOS{}
 	}
+	return os
+}
 
-	err = b.validateVMProvisioningOptions()
-	if err != nil {
-		return err
+// retryPolicy returns the default retry policy for bootstrapper steps. It
+// retries every error because IAM and service account propagation delays
+// surface as arbitrary errors shortly after resource creation.
+func (b *GCPBootstrapper) retryPolicy() RetryPolicy {
+	return RetryPolicy{
+		OnRetry: b.stlog.LogRetry,
+		Time:    b.Time,
 	}
+}
 
-	err = b.validateGitHubParams()
-	if err != nil {
-		return err
+// validateDNSParams checks the DNS record TTL and the base domain / zone apex relationship.
+func (b *GCPBootstrapper) validateDNSParams() error {
+	if b.Env.DNSRecordTTL < 0 {
+		return fmt.Errorf("DNS record TTL must not be negative, got %d", b.Env.DNSRecordTTL)
 	}
 
-	err = b.validateGitProviderParams()
-	if err != nil {
-		return err
+	domain := strings.TrimSuffix(b.Env.BaseDomain, ".")
+	if domain == "" || strings.HasPrefix(domain, ".") || strings.Contains(domain, "..") {
+		return fmt.Errorf("invalid base domain: %q", b.Env.BaseDomain)
 	}
 
-	err = b.validateOidcParams()
-	if err != nil {
-		return err
+	// Apex records live at the base domain itself, so the base domain must be
+	// the apex of the managed zone, i.e. a registrable domain (at least two labels).
+	if b.Env.ApexDomainRecords && strings.Count(domain, ".") < 1 {
+		return fmt.Errorf("apex domain records require a registrable base domain (e.g. example.com), got %q", domain)
 	}
 
-	err = b.validateExternalLokiParams()
-	if err != nil {
-		return err
+	switch b.Env.DNSProvider {
+	case "", dnsprovider.ProviderCloudDNS, dnsprovider.ProviderRoute53:
+	default:
+		return fmt.Errorf("unknown DNS provider %q (options: %s, %s)", b.Env.DNSProvider, dnsprovider.ProviderCloudDNS, dnsprovider.ProviderRoute53)
 	}
 
-	err = b.validatePrometheusRemoteWriteParams()
-	if err != nil {
-		return err
+	switch b.Env.DNSMode {
+	case "", DNSModeManaged, DNSModeManual:
+	default:
+		return fmt.Errorf("unknown DNS mode %q (options: %s, %s)", b.Env.DNSMode, DNSModeManaged, DNSModeManual)
+	}
+	if b.Env.DNSMode == DNSModeManual && b.Env.UseCloudflare {
+		return fmt.Errorf("manual DNS mode cannot be combined with Cloudflare exposure mode")
 	}
 
-	err = b.validateClusterAdminEmail()
-	if err != nil {
-		return err
+	if b.Env.DNSSEC {
+		if b.Env.DNSProvider == dnsprovider.ProviderRoute53 {
+			return fmt.Errorf("DNSSEC is only supported with the clouddns DNS provider")
+		}
+		if b.Env.DNSMode == DNSModeManual || b.Env.UseCloudflare {
+			return fmt.Errorf("DNSSEC requires an OMS-managed Cloud DNS zone")
+		}
 	}
 
-	return b.validateTelemetryExportParams()
+	return nil
 }
 
 func (b *GCPBootstrapper) validateClusterAdminEmail() error {
@@ -625,6 +1081,23 @@ func (b *GCPBootstrapper) EnsureArtifactRegistry() error {
 	return nil
 }
 
+// EnsureRegistryCleanupPolicies applies cleanup policies to the Codesphere artifact
+// registry so old images do not accumulate without bound: the most recent tagged
+// versions per image are kept, untagged versions are deleted after a retention period.
+func (b *GCPBootstrapper) EnsureRegistryCleanupPolicies() error {
+	retention := time.Duration(b.Env.RegistryUntaggedRetentionDays) * 24 * time.Hour
+	b.stlog.Logf("Applying registry cleanup policies: keep %d tagged versions, delete untagged after %d days",
+		b.Env.RegistryKeepTagCount, b.Env.RegistryUntaggedRetentionDays)
+
+	err := b.GCPClient.ApplyArtifactRegistryCleanupPolicies(b.Env.ProjectID, b.Env.Region, "codesphere-registry",
+		b.Env.RegistryKeepTagCount, retention)
+	if err != nil {
+		return fmt.Errorf("failed to apply artifact registry cleanup policies: %w", err)
+	}
+
+	return nil
+}
+
 func (b *GCPBootstrapper) ensureDnsPermissions() error {
 	dnsProject := b.Env.DNSProjectID
 	if b.Env.DNSProjectID == "" {
@@ -644,7 +1117,7 @@ func (b *GCPBootstrapper) EnsureVPC() error {
 	natName := fmt.Sprintf("%s-nat-gateway", b.Env.ProjectID)
 
 	// Create VPC
-	err := b.GCPClient.CreateVPC(b.Env.ProjectID, b.Env.Region, networkName, subnetName, routerName, natName)
+	err := b.GCPClient.CreateVPC(b.Env.ProjectID, b.Env.Region, networkName, subnetName, routerName, natName, b.Env.IPv6)
 	if err != nil {
 		return fmt.Errorf("failed to ensure VPC: %w", err)
 	}
@@ -655,7 +1128,8 @@ func (b *GCPBootstrapper) EnsureVPC() error {
 func (b *GCPBootstrapper) EnsureFirewallRules() error {
 	networkName := fmt.Sprintf("%s-vpc", b.Env.ProjectID)
 
-	// Allow external SSH to Jumpbox
+	// Allow external SSH to Jumpbox, or SSH from Google IAP to all instances
+	// when tunneling through IAP instead of public IPs.
 	sshRule := &computepb.Firewall{
 		Name:      protoString("allow-ssh-ext"),
 		Network:   protoString(fmt.Sprintf("projects/%s/global/networks/%s", b.Env.ProjectID, networkName)),
@@ -664,13 +1138,21 @@ func (b *GCPBootstrapper) EnsureFirewallRules() error {
 		Allowed: []*computepb.Allowed{
 			{
 				IPProtocol: protoString("tcp"),
-				Ports:      []string{"22"},
+				Ports:      []string{fmt.Sprintf("%d", b.sshPort())},
 			},
 		},
 		SourceRanges: []string{"0.0.0.0/0"},
 		TargetTags:   []string{"ssh"},
 		Description:  protoString("Allow external SSH to Jumpbox"),
 	}
+	if b.Env.UseIAPTunnel {
+		sshRule.Name = protoString("allow-ssh-iap")
+		// Google IAP TCP forwarding originates from this fixed range.
+		sshRule.SourceRanges = []string{"35.235.240.0/20"}
+		// IAP connects to every instance directly, not only the jumpbox.
+		sshRule.TargetTags = nil
+		sshRule.Description = protoString("Allow SSH from Google Identity-Aware Proxy")
+	}
 	err := b.GCPClient.CreateFirewallRule(b.Env.ProjectID, sshRule)
 	if err != nil {
 		return fmt.Errorf("failed to create jumpbox ssh firewall rule: %w", err)
@@ -710,39 +1192,231 @@ func (b *GCPBootstrapper) EnsureFirewallRules() error {
 		return fmt.Errorf("failed to create egress firewall rule: %w", err)
 	}
 
-	// Allow ingress for web (HTTP/HTTPS)
-	webRule := &computepb.Firewall{
-		Name:      protoString("allow-ingress-web"),
-		Network:   protoString(fmt.Sprintf("projects/%s/global/networks/%s", b.Env.ProjectID, networkName)),
-		Direction: protoString("INGRESS"),
-		Priority:  protoInt32(1000),
-		Allowed: []*computepb.Allowed{
-			{IPProtocol: protoString("tcp"), Ports: []string{"80", "443"}},
+	// Allow ingress for web (HTTP/HTTPS). In Cloudflare exposure mode only the
+	// Cloudflare proxy ranges may reach the gateways directly.
+	webSourceRanges := []string{"0.0.0.0/0"}
+	if b.Env.UseCloudflare {
+		webSourceRanges, err = b.CloudflareClient.GetIPRanges()
+		if err != nil {
+			return fmt.Errorf("failed to fetch Cloudflare IP ranges: %w", err)
+		}
+	}
+	webRule := &computepb.Firewall{
+		Name:      protoString("allow-ingress-web"),
+		Network:   protoString(fmt.Sprintf("projects/%s/global/networks/%s", b.Env.ProjectID, networkName)),
+		Direction: protoString("INGRESS"),
+		Priority:  protoInt32(1000),
+		Allowed: []*computepb.Allowed{
+			{IPProtocol: protoString("tcp"), Ports: []string{"80", "443"}},
+		},
+		SourceRanges: webSourceRanges,
+		Description:  protoString("Allow HTTP/HTTPS ingress"),
+	}
+	err = b.GCPClient.CreateFirewallRule(b.Env.ProjectID, webRule)
+	if err != nil {
+		return fmt.Errorf("failed to create web firewall rule: %w", err)
+	}
+
+	// Allow ingress for PostgreSQL
+	postgresRule := &computepb.Firewall{
+		Name:      protoString("allow-ingress-postgres"),
+		Network:   protoString(fmt.Sprintf("projects/%s/global/networks/%s", b.Env.ProjectID, networkName)),
+		Direction: protoString("INGRESS"),
+		Priority:  protoInt32(1000),
+		Allowed: []*computepb.Allowed{
+			{IPProtocol: protoString("tcp"), Ports: []string{"5432"}},
+		},
+		SourceRanges: []string{"0.0.0.0/0"},
+		TargetTags:   []string{"postgres"},
+		Description:  protoString("Allow external access to PostgreSQL"),
+	}
+	err = b.GCPClient.CreateFirewallRule(b.Env.ProjectID, postgresRule)
+	if err != nil {
+		return fmt.Errorf("failed to create postgres firewall rule: %w", err)
+	}
+
+	// Allow streaming replication between the postgres pair. The internal rule
+	// already covers this today; a dedicated rule keeps replication working if
+	// internal traffic is ever restricted.
+	if b.Env.HAPostgres {
+		replicationRule := &computepb.Firewall{
+			Name:      protoString("allow-postgres-replication"),
+			Network:   protoString(fmt.Sprintf("projects/%s/global/networks/%s", b.Env.ProjectID, networkName)),
+			Direction: protoString("INGRESS"),
+			Priority:  protoInt32(1000),
+			Allowed: []*computepb.Allowed{
+				{IPProtocol: protoString("tcp"), Ports: []string{"5432"}},
+			},
+			SourceTags:  []string{"postgres"},
+			TargetTags:  []string{"postgres"},
+			Description: protoString("Allow PostgreSQL streaming replication between primary and replica"),
+		}
+		err = b.GCPClient.CreateFirewallRule(b.Env.ProjectID, replicationRule)
+		if err != nil {
+			return fmt.Errorf("failed to create postgres replication firewall rule: %w", err)
+		}
+	}
+
+	if b.Env.IPv6 {
+		err = b.ensureIPv6FirewallRules(networkName)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureIPv6FirewallRules mirrors the externally facing IPv4 rules for IPv6
+// sources. Internal traffic stays IPv4-only; Cloudflare exposure mode already
+// includes the proxy's IPv6 ranges in the web rule.
+func (b *GCPBootstrapper) ensureIPv6FirewallRules(networkName string) error {
+	network := protoString(fmt.Sprintf("projects/%s/global/networks/%s", b.Env.ProjectID, networkName))
+
+	rules := []*computepb.Firewall{
+		{
+			Name:      protoString("allow-ssh-ext-ipv6"),
+			Network:   network,
+			Direction: protoString("INGRESS"),
+			Priority:  protoInt32(1000),
+			Allowed: []*computepb.Allowed{
+				{IPProtocol: protoString("tcp"), Ports: []string{fmt.Sprintf("%d", b.sshPort())}},
+			},
+			SourceRanges: []string{"::/0"},
+			TargetTags:   []string{"ssh"},
+			Description:  protoString("Allow external SSH to Jumpbox over IPv6"),
+		},
+		{
+			Name:      protoString("allow-all-egress-ipv6"),
+			Network:   network,
+			Direction: protoString("EGRESS"),
+			Priority:  protoInt32(1000),
+			Allowed: []*computepb.Allowed{
+				{IPProtocol: protoString("all")},
+			},
+			DestinationRanges: []string{"::/0"},
+			Description:       protoString("Allow all egress over IPv6"),
+		},
+		{
+			Name:      protoString("allow-ingress-web-ipv6"),
+			Network:   network,
+			Direction: protoString("INGRESS"),
+			Priority:  protoInt32(1000),
+			Allowed: []*computepb.Allowed{
+				{IPProtocol: protoString("tcp"), Ports: []string{"80", "443"}},
+			},
+			SourceRanges: []string{"::/0"},
+			Description:  protoString("Allow HTTP/HTTPS ingress over IPv6"),
+		},
+		{
+			Name:      protoString("allow-ingress-postgres-ipv6"),
+			Network:   network,
+			Direction: protoString("INGRESS"),
+			Priority:  protoInt32(1000),
+			Allowed: []*computepb.Allowed{
+				{IPProtocol: protoString("tcp"), Ports: []string{"5432"}},
+			},
+			SourceRanges: []string{"::/0"},
+			TargetTags:   []string{"postgres"},
+			Description:  protoString("Allow external access to PostgreSQL over IPv6"),
+		},
+	}
+
+	for _, rule := range rules {
+		err := b.GCPClient.CreateFirewallRule(b.Env.ProjectID, rule)
+		if err != nil {
+			return fmt.Errorf("failed to create firewall rule %s: %w", rule.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// CloudArmorPolicyName is the name of the Cloud Armor security policy attached
+// in front of the public gateway.
+const CloudArmorPolicyName = "codesphere-public-gateway-policy"
+
+// EnsureCloudArmorPolicy provisions a Cloud Armor security policy with rate
+// limiting, optional geo blocking, and preconfigured OWASP rules. The policy is
+// attached to the public gateway via the install config.
+func (b *GCPBootstrapper) EnsureCloudArmorPolicy() error {
+	rateLimit := b.Env.CloudArmorRateLimitQPM
+	if rateLimit <= 0 {
+		rateLimit = 600
+	}
+
+	rules := []*computepb.SecurityPolicyRule{
+		{
+			Priority:    protoInt32(1000),
+			Action:      protoString("throttle"),
+			Description: protoString("Per-IP rate limiting"),
+			Match: &computepb.SecurityPolicyRuleMatcher{
+				VersionedExpr: protoString("SRC_IPS_V1"),
+				Config: &computepb.SecurityPolicyRuleMatcherConfig{
+					SrcIpRanges: []string{"*"},
+				},
+			},
+			RateLimitOptions: &computepb.SecurityPolicyRuleRateLimitOptions{
+				ConformAction: protoString("allow"),
+				ExceedAction:  protoString("deny(429)"),
+				EnforceOnKey:  protoString("IP"),
+				RateLimitThreshold: &computepb.SecurityPolicyRuleRateLimitOptionsThreshold{
+					Count:       protoInt32(rateLimit),
+					IntervalSec: protoInt32(60),
+				},
+			},
+		},
+		{
+			Priority:    protoInt32(2000),
+			Action:      protoString("deny(403)"),
+			Description: protoString("Preconfigured OWASP rules (SQLi, XSS)"),
+			Match: &computepb.SecurityPolicyRuleMatcher{
+				Expr: &computepb.Expr{
+					Expression: protoString("evaluatePreconfiguredWaf('sqli-v33-stable') || evaluatePreconfiguredWaf('xss-v33-stable')"),
+				},
+			},
 		},
-		SourceRanges: []string{"0.0.0.0/0"},
-		Description:  protoString("Allow HTTP/HTTPS ingress"),
 	}
-	err = b.GCPClient.CreateFirewallRule(b.Env.ProjectID, webRule)
-	if err != nil {
-		return fmt.Errorf("failed to create web firewall rule: %w", err)
+
+	if len(b.Env.CloudArmorBlockedCountries) > 0 {
+		countryExprs := []string{}
+		for _, country := range b.Env.CloudArmorBlockedCountries {
+			countryExprs = append(countryExprs, fmt.Sprintf("origin.region_code == '%s'", strings.ToUpper(country)))
+		}
+		rules = append(rules, &computepb.SecurityPolicyRule{
+			Priority:    protoInt32(3000),
+			Action:      protoString("deny(403)"),
+			Description: protoString("Geo blocking"),
+			Match: &computepb.SecurityPolicyRuleMatcher{
+				Expr: &computepb.Expr{
+					Expression: protoString(strings.Join(countryExprs, " || ")),
+				},
+			},
+		})
 	}
 
-	// Allow ingress for PostgreSQL
-	postgresRule := &computepb.Firewall{
-		Name:      protoString("allow-ingress-postgres"),
-		Network:   protoString(fmt.Sprintf("projects/%s/global/networks/%s", b.Env.ProjectID, networkName)),
-		Direction: protoString("INGRESS"),
-		Priority:  protoInt32(1000),
-		Allowed: []*computepb.Allowed{
-			{IPProtocol: protoString("tcp"), Ports: []string{"5432"}},
+	// Cloud Armor requires an explicit default rule at the lowest priority.
+	rules = append(rules, &computepb.SecurityPolicyRule{
+		Priority:    protoInt32(2147483647),
+		Action:      protoString("allow"),
+		Description: protoString("Default rule"),
+		Match: &computepb.SecurityPolicyRuleMatcher{
+			VersionedExpr: protoString("SRC_IPS_V1"),
+			Config: &computepb.SecurityPolicyRuleMatcherConfig{
+				SrcIpRanges: []string{"*"},
+			},
 		},
-		SourceRanges: []string{"0.0.0.0/0"},
-		TargetTags:   []string{"postgres"},
-		Description:  protoString("Allow external access to PostgreSQL"),
+	})
+
+	policy := &computepb.SecurityPolicy{
+		Name:        protoString(CloudArmorPolicyName),
+		Description: protoString("Codesphere public gateway WAF policy"),
+		Rules:       rules,
 	}
-	err = b.GCPClient.CreateFirewallRule(b.Env.ProjectID, postgresRule)
+
+	err := b.GCPClient.CreateSecurityPolicy(b.Env.ProjectID, policy)
 	if err != nil {
-		return fmt.Errorf("failed to create postgres firewall rule: %w", err)
+		return fmt.Errorf("failed to create security policy %s: %w", CloudArmorPolicyName, err)
 	}
 
 	return nil
@@ -765,6 +1439,21 @@ func (b *GCPBootstrapper) EnsureGatewayIPAddresses() error {
 		return fmt.Errorf("failed to ensure ssh proxy IP: %w", err)
 	}
 
+	if b.Env.IPv6 {
+		b.Env.GatewayIPv6, err = b.EnsureExternalIPv6("gateway-ipv6")
+		if err != nil {
+			return fmt.Errorf("failed to ensure gateway IPv6: %w", err)
+		}
+		b.Env.PublicGatewayIPv6, err = b.EnsureExternalIPv6("public-gateway-ipv6")
+		if err != nil {
+			return fmt.Errorf("failed to ensure public gateway IPv6: %w", err)
+		}
+		b.Env.SshProxyIPv6, err = b.EnsureExternalIPv6("ssh-proxy-ipv6")
+		if err != nil {
+			return fmt.Errorf("failed to ensure ssh proxy IPv6: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -774,6 +1463,7 @@ func (b *GCPBootstrapper) EnsureExternalIP(name string) (string, error) {
 		Name:        &name,
 		AddressType: protoString("EXTERNAL"),
 		Region:      &b.Env.Region,
+		Labels:      b.resourceLabels(),
 	}
 
 	// Figure out if address already exists and get IP
@@ -788,6 +1478,7 @@ func (b *GCPBootstrapper) EnsureExternalIP(name string) (string, error) {
 	}
 
 	if createdIP != "" {
+		b.Ledger.RecordStaticIP(name)
 		return createdIP, nil
 	}
 
@@ -800,24 +1491,90 @@ func (b *GCPBootstrapper) EnsureExternalIP(name string) (string, error) {
 	return "", fmt.Errorf("failed to get address %s after creation", name)
 }
 
-func (b *GCPBootstrapper) EnsureRootLoginEnabled() error {
-	allNodes := []*node.Node{
-		b.Env.Jumpbox,
+// EnsureExternalIPv6 ensures that a static external IPv6 address with the
+// given name exists in the dual-stack subnet.
+func (b *GCPBootstrapper) EnsureExternalIPv6(name string) (string, error) {
+	subnetName := fmt.Sprintf("%s-%s-subnet", b.Env.ProjectID, b.Env.Region)
+	desiredAddress := &computepb.Address{
+		Name:             &name,
+		AddressType:      protoString("EXTERNAL"),
+		IpVersion:        protoString("IPV6"),
+		Ipv6EndpointType: protoString("NETLB"),
+		Subnetwork:       protoString(fmt.Sprintf("projects/%s/regions/%s/subnetworks/%s", b.Env.ProjectID, b.Env.Region, subnetName)),
+		Region:           &b.Env.Region,
+		Labels:           b.resourceLabels(),
+	}
+
+	// Figure out if address already exists and get IP
+	address, err := b.GCPClient.GetAddress(b.Env.ProjectID, b.Env.Region, name)
+	if err == nil && address != nil {
+		return address.GetAddress(), nil
+	}
+
+	createdIP, err := b.GCPClient.CreateAddress(b.Env.ProjectID, b.Env.Region, desiredAddress)
+	if err != nil && !IsAlreadyExistsError(err) {
+		return "", fmt.Errorf("failed to create address %s: %w", name, err)
 	}
-	allNodes = append(allNodes, b.Env.ControlPlaneNodes...)
-	allNodes = append(allNodes, b.Env.PostgreSQLNode)
-	allNodes = append(allNodes, b.Env.CephNodes...)
 
+	if createdIP != "" {
+		b.Ledger.RecordStaticIP(name)
+		return createdIP, nil
+	}
+
+	address, err = b.GCPClient.GetAddress(b.Env.ProjectID, b.Env.Region, name)
+
+	if err == nil && address != nil {
+		return address.GetAddress(), nil
+	}
+
+	return "", fmt.Errorf("failed to get address %s after creation", name)
+}
+
+// allNodes returns every provisioned node, including the jumpbox when the
+// environment has one.
+func (b *GCPBootstrapper) allNodes() []*node.Node {
+	return b.Env.AllNodes()
+}
+
+// AllNodes returns every node of the environment: the jumpbox, the k8s
+// nodes, the postgres VM(s) and the ceph nodes, as far as they exist.
+func (e *CodesphereEnvironment) AllNodes() []*node.Node {
+	var nodes []*node.Node
+	if e.Jumpbox != nil {
+		nodes = append(nodes, e.Jumpbox)
+	}
+	nodes = append(nodes, e.ControlPlaneNodes...)
+	if e.PostgreSQLNode != nil {
+		nodes = append(nodes, e.PostgreSQLNode)
+	}
+	if e.PostgreSQLReplicaNode != nil {
+		nodes = append(nodes, e.PostgreSQLReplicaNode)
+	}
+	nodes = append(nodes, e.CephNodes...)
+	return nodes
+}
+
+// opsNode returns the node that performs jumpbox duties: the jumpbox itself,
+// or the first control plane node in jumpbox-less mode.
+func (b *GCPBootstrapper) opsNode() *node.Node {
+	if b.Env.Jumpbox != nil {
+		return b.Env.Jumpbox
+	}
+	return b.Env.ControlPlaneNodes[0]
+}
+
+func (b *GCPBootstrapper) EnsureRootLoginEnabled() error {
+	allNodes := b.allNodes()
+
+	b.stlog.Logf("Ensuring root login enabled on %d nodes", len(allNodes))
+	tasks := make([]bootstrap.NodeTask, 0, len(allNodes))
 	for _, node := range allNodes {
-		err := b.stlog.Substep(fmt.Sprintf("Ensuring root login enabled on %s", node.GetName()), func() error {
+		tasks = append(tasks, bootstrap.NodeTask{Name: node.GetName(), Run: func() error {
 			return b.ensureRootLoginEnabledInNode(node)
-		})
-		if err != nil {
-			return err
-		}
+		}})
 	}
 
-	return nil
+	return bootstrap.RunNodeTasks(b.Env.NodeParallelism, tasks)
 }
 
 func (b *GCPBootstrapper) ensureRootLoginEnabledInNode(node *node.Node) error {
@@ -831,84 +1588,113 @@ func (b *GCPBootstrapper) ensureRootLoginEnabledInNode(node *node.Node) error {
 		return nil
 	}
 
-	for i := range 3 {
-		err := node.EnableRootLogin()
-		if err == nil {
-			break
-		}
-		if i == 2 {
-			return fmt.Errorf("failed to enable root login on %s: %w", node.GetName(), err)
-		}
-		b.stlog.LogRetry()
-		b.Time.Sleep(10 * time.Second)
+	policy := b.retryPolicy()
+	policy.MaxAttempts = 3
+	policy.InitialBackoff = 10 * time.Second
+	err = policy.Retry(node.EnableRootLogin)
+	if err != nil {
+		return fmt.Errorf("failed to enable root login on %s: %w", node.GetName(), err)
 	}
 
 	return nil
 }
 
+// EnsureJumpboxConfigured installs the OMS binary and its dependencies on the
+// node performing jumpbox duties. In jumpbox-less mode that is the first
+// control plane node.
 func (b *GCPBootstrapper) EnsureJumpboxConfigured() error {
-	if !b.Env.Jumpbox.HasAcceptEnvConfigured() {
-		err := b.Env.Jumpbox.ConfigureAcceptEnv()
+	ops := b.opsNode()
+	if !ops.HasAcceptEnvConfigured() {
+		err := ops.ConfigureAcceptEnv()
+		if err != nil {
+			return fmt.Errorf("failed to configure AcceptEnv on %s: %w", ops.GetName(), err)
+		}
+	}
+	if !ops.HasHardenedSSHD() {
+		err := ops.HardenSSHD()
 		if err != nil {
-			return fmt.Errorf("failed to configure AcceptEnv on jumpbox: %w", err)
+			return fmt.Errorf("failed to harden sshd configuration on %s: %w", ops.GetName(), err)
 		}
 	}
 
 	err := b.EnsureOmsInstalled()
 	if err != nil {
-		return fmt.Errorf("failed to ensure OMS is present on jumpbox: %w", err)
+		return fmt.Errorf("failed to ensure OMS is present on %s: %w", ops.GetName(), err)
 	}
 
-	err = b.Env.Jumpbox.EnsureOmsDependencies()
+	err = ops.EnsureOmsDependencies()
 	if err != nil {
-		return fmt.Errorf("failed to ensure OMS dependencies on jumpbox: %w", err)
+		return fmt.Errorf("failed to ensure OMS dependencies on %s: %w", ops.GetName(), err)
 	}
 
 	return nil
 }
 
 func (b *GCPBootstrapper) EnsureOmsInstalled() (err error) {
+	ops := b.opsNode()
 	if b.Env.RemoteOmsBinaryPath != "" {
-		err = b.Env.Jumpbox.NodeClient.CopyFile(b.Env.Jumpbox, b.Env.RemoteOmsBinaryPath, "/usr/local/bin/oms")
+		err = ops.NodeClient.CopyFile(ops, b.Env.RemoteOmsBinaryPath, "/usr/local/bin/oms")
 		if err != nil {
-			return fmt.Errorf("failed to copy local OMS binary to jumpbox: %w", err)
+			return fmt.Errorf("failed to copy local OMS binary to %s: %w", ops.GetName(), err)
 		}
 
-		err = b.Env.Jumpbox.RunSSHCommand("root", "chmod 0755 /usr/local/bin/oms")
+		err = ops.RunSSHCommand("root", "chmod 0755 /usr/local/bin/oms")
 		if err != nil {
-			return fmt.Errorf("failed to make local OMS binary executable on jumpbox: %w", err)
+			return fmt.Errorf("failed to make local OMS binary executable on %s: %w", ops.GetName(), err)
 		}
 		return nil
 	}
 
-	if b.Env.Jumpbox.HasCommand("oms") {
+	if ops.HasCommand("oms") {
 		return nil
 	}
 
-	err = b.Env.Jumpbox.InstallOms()
+	build := version.Build{}
+	err = ops.InstallOms(build.Version())
 	if err != nil {
-		return fmt.Errorf("failed to install OMS on jumpbox: %w", err)
+		return fmt.Errorf("failed to install OMS on %s: %w", ops.GetName(), err)
 	}
 
 	return nil
 }
 
 func (b *GCPBootstrapper) EnsureHostsConfigured() error {
-	allNodes := append(b.Env.ControlPlaneNodes, b.Env.PostgreSQLNode)
+	allNodes := append([]*node.Node{}, b.Env.ControlPlaneNodes...)
+	if b.Env.PostgreSQLNode != nil {
+		allNodes = append(allNodes, b.Env.PostgreSQLNode)
+	}
+	if b.Env.PostgreSQLReplicaNode != nil {
+		allNodes = append(allNodes, b.Env.PostgreSQLReplicaNode)
+	}
 	allNodes = append(allNodes, b.Env.CephNodes...)
 
+	tasks := make([]bootstrap.NodeTask, 0, len(allNodes))
 	for _, node := range allNodes {
-		if !node.HasInotifyWatchesConfigured() {
-			err := node.ConfigureInotifyWatches()
-			if err != nil {
-				return fmt.Errorf("failed to configure inotify watches on %s: %w", node.GetName(), err)
-			}
+		tasks = append(tasks, bootstrap.NodeTask{Name: node.GetName(), Run: func() error {
+			return b.ensureHostConfigured(node)
+		}})
+	}
+
+	return bootstrap.RunNodeTasks(b.Env.NodeParallelism, tasks)
+}
+
+func (b *GCPBootstrapper) ensureHostConfigured(node *node.Node) error {
+	if !node.HasInotifyWatchesConfigured() {
+		err := node.ConfigureInotifyWatches()
+		if err != nil {
+			return fmt.Errorf("failed to configure inotify watches on %s: %w", node.GetName(), err)
 		}
-		if !node.HasMemoryMapConfigured() {
-			err := node.ConfigureMemoryMap()
-			if err != nil {
-				return fmt.Errorf("failed to configure memory map on %s: %w", node.GetName(), err)
-			}
+	}
+	if !node.HasMemoryMapConfigured() {
+		err := node.ConfigureMemoryMap()
+		if err != nil {
+			return fmt.Errorf("failed to configure memory map on %s: %w", node.GetName(), err)
+		}
+	}
+	if !node.HasHardenedSSHD() {
+		err := node.HardenSSHD()
+		if err != nil {
+			return fmt.Errorf("failed to harden sshd configuration on %s: %w", node.GetName(), err)
 		}
 	}
 
@@ -930,6 +1716,7 @@ func (b *GCPBootstrapper) EnsureLocalContainerRegistry() error {
 	}
 	if s := b.icg.GetVault().GetSecret(files.SecretRegistryPassword); s != nil && s.Fields != nil {
 		registryPassword = s.Fields.Password
+		redact.Add(registryPassword)
 	}
 	if err == nil && b.Env.InstallConfig.Registry != nil && b.Env.InstallConfig.Registry.Server == localRegistryServer &&
 		registryUsername != "" && registryPassword != "" {
@@ -940,13 +1727,32 @@ func (b *GCPBootstrapper) EnsureLocalContainerRegistry() error {
 	b.Env.InstallConfig.Registry.Server = localRegistryServer
 	registryUsername = "custom-registry"
 	registryPassword = shortuuid.New()
+	redact.Add(registryPassword)
 	b.icg.GetVault().SetSecret(files.SecretEntry{Name: files.SecretRegistryUsername, Fields: &files.SecretFields{Password: registryUsername}})
 	b.icg.GetVault().SetSecret(files.SecretEntry{Name: files.SecretRegistryPassword, Fields: &files.SecretFields{Password: registryPassword}})
 
+	nodeOS := b.Env.PostgreSQLNode.OS()
+	setupCommands := []string{
+		nodeOS.RefreshPackagesCommand(),
+		nodeOS.InstallPackagesCommand("podman", "apache2-utils"),
+	}
+	for _, cmd := range setupCommands {
+		b.stlog.Logf("Running command on postgres node: %s", util.Truncate(cmd, 12))
+		err := b.Env.PostgreSQLNode.RunSSHCommand("root", cmd)
+		if err != nil {
+			return fmt.Errorf("failed to run command on postgres node: %w", err)
+		}
+	}
+
+	// The password is passed on stdin (htpasswd -i) so it never appears in a
+	// command line, process listing or log.
+	b.stlog.Logf("Writing registry htpasswd file on postgres node")
+	err = b.Env.PostgreSQLNode.RunSSHCommandWithInput("root", "htpasswd -iBc /root/registry.password "+registryUsername, registryPassword)
+	if err != nil {
+		return fmt.Errorf("failed to write registry htpasswd file on postgres node: %w", err)
+	}
+
 	commands := []string{
-		"apt-get update",
-		"apt-get install -y podman apache2-utils",
-		"htpasswd -bBc /root/registry.password " + registryUsername + " " + registryPassword,
 		"openssl req -newkey rsa:4096 -nodes -sha256 -keyout /root/registry.key -x509 -days 365 -out /root/registry.crt -subj \"/C=DE/ST=BW/L=Karlsruhe/O=Codesphere/CN=" + b.Env.PostgreSQLNode.GetInternalIP() + "\" -addext \"subjectAltName = DNS:postgres,IP:" + b.Env.PostgreSQLNode.GetInternalIP() + "\"",
 		"podman rm -f registry || true",
 		`podman run -d \
@@ -972,20 +1778,152 @@ func (b *GCPBootstrapper) EnsureLocalContainerRegistry() error {
 		}
 	}
 
+	// Distribute the certificate through the node client instead of remote scp,
+	// so the transfer does not depend on host keys or ssh_config on the nodes.
+	certFile, err := os.CreateTemp("", "registry-*.crt")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file for registry certificate: %w", err)
+	}
+	defer util.IgnoreError(func() error { return os.Remove(certFile.Name()) })
+	util.CloseFileIgnoreError(certFile)
+	err = b.Env.PostgreSQLNode.NodeClient.DownloadFile(b.Env.PostgreSQLNode, "/root/registry.crt", certFile.Name())
+	if err != nil {
+		return fmt.Errorf("failed to download registry certificate from postgres node: %w", err)
+	}
+
 	allNodes := append(b.Env.ControlPlaneNodes, b.Env.CephNodes...)
+	b.stlog.Logf("Configuring %d nodes to trust local registry certificate", len(allNodes))
+	tasks := make([]bootstrap.NodeTask, 0, len(allNodes))
 	for _, node := range allNodes {
-		b.stlog.Logf("Configuring node '%s' to trust local registry certificate", node.GetName())
-		err := b.Env.PostgreSQLNode.RunSSHCommand("root", "scp -o StrictHostKeyChecking=no /root/registry.crt root@"+node.GetInternalIP()+":/usr/local/share/ca-certificates/registry.crt")
+		tasks = append(tasks, bootstrap.NodeTask{Name: node.GetName(), Run: func() error {
+			return b.distributeRegistryCert(node, certFile.Name())
+		}})
+	}
+
+	return bootstrap.RunNodeTasks(b.Env.NodeParallelism, tasks)
+}
+
+// distributeRegistryCert makes a node trust the local registry certificate.
+func (b *GCPBootstrapper) distributeRegistryCert(node *node.Node, certPath string) error {
+	err := node.NodeClient.CopyFile(node, certPath, node.OS().CACertPath("registry"))
+	if err != nil {
+		return fmt.Errorf("failed to copy registry certificate to node %s: %w", node.GetInternalIP(), err)
+	}
+	err = node.RunSSHCommand("root", node.OS().UpdateCATrustCommand())
+	if err != nil {
+		return fmt.Errorf("failed to update CA certificates on node %s: %w", node.GetInternalIP(), err)
+	}
+	err = node.RunSSHCommand("root", node.OS().RestartServiceCommand("docker.service")+" || true") // docker is probably not yet installed
+	if err != nil {
+		return fmt.Errorf("failed to restart docker service on node %s: %w", node.GetInternalIP(), err)
+	}
+
+	return nil
+}
+
+// EnsurePrePulledImages pulls the configured workspace agent and base images
+// onto all worker nodes so the first workspace start does not block on a cold
+// image pull.
+func (b *GCPBootstrapper) EnsurePrePulledImages() error {
+	workerNodes := b.Env.ControlPlaneNodes
+
+	b.stlog.Logf("Pre-pulling %d images on %d worker nodes", len(b.Env.PrePullImages), len(workerNodes))
+	tasks := make([]bootstrap.NodeTask, 0, len(workerNodes))
+	for _, node := range workerNodes {
+		tasks = append(tasks, bootstrap.NodeTask{Name: node.GetName(), Run: func() error {
+			return b.prePullImagesOnNode(node, b.Env.PrePullImages)
+		}})
+	}
+
+	return bootstrap.RunNodeTasks(b.Env.NodeParallelism, tasks)
+}
+
+// EnsureDeployImagesPreWarmed pre-pulls the deploy-config image set onto
+// worker nodes that were newly created in this run, so scaled-up workers do
+// not serve their first workspace from a cold image cache. Only plain image
+// references are pulled; BOM-referenced images are loaded from the install
+// package anyway.
+func (b *GCPBootstrapper) EnsureDeployImagesPreWarmed() error {
+	images := b.deployImageSet()
+	if len(images) == 0 {
+		b.stlog.Logf("No plain deploy images to pre-warm")
+		return nil
+	}
+
+	created := util.StringSliceToBoolMap(b.Env.NewWorkerNodes)
+	newWorkers := make([]*node.Node, 0, len(b.Env.ControlPlaneNodes))
+	for _, node := range b.Env.ControlPlaneNodes {
+		if created[node.GetName()] {
+			newWorkers = append(newWorkers, node)
+		}
+	}
+	if len(newWorkers) == 0 {
+		b.stlog.Logf("No newly created worker nodes to pre-warm")
+		return nil
+	}
+
+	b.stlog.Logf("Pre-warming %d images on %d new worker nodes", len(images), len(newWorkers))
+	tasks := make([]bootstrap.NodeTask, 0, len(newWorkers))
+	for _, node := range newWorkers {
+		tasks = append(tasks, bootstrap.NodeTask{Name: node.GetName(), Run: func() error {
+			return b.prePullImagesOnNode(node, images)
+		}})
+	}
+
+	return bootstrap.RunNodeTasks(b.Env.NodeParallelism, tasks)
+}
+
+// deployImageSet collects the plain image references from the deploy config
+// and workspace image overrides in the install config, deduplicated and
+// sorted for stable pull order.
+func (b *GCPBootstrapper) deployImageSet() []string {
+	images := []string{}
+	seen := map[string]bool{}
+	add := func(ref *files.ImageRef) {
+		if ref == nil || ref.ImageName == "" || seen[ref.ImageName] {
+			return
+		}
+		seen[ref.ImageName] = true
+		images = append(images, ref.ImageName)
+	}
+
+	for _, imageConfig := range b.Env.InstallConfig.Codesphere.DeployConfig.Images {
+		for _, flavor := range imageConfig.Flavors {
+			ref := flavor.Image
+			add(&ref)
+		}
+	}
+	if ws := b.Env.InstallConfig.Codesphere.WorkspaceImages; ws != nil {
+		add(ws.Agent)
+		add(ws.AgentGpu)
+		add(ws.Server)
+		add(ws.VPN)
+	}
+
+	sort.Strings(images)
+	return images
+}
+
+// prePullImagesOnNode pulls the given images on one node, installing podman
+// first if the node does not have it yet.
+func (b *GCPBootstrapper) prePullImagesOnNode(node *node.Node, images []string) error {
+	if !node.HasCommand("podman") {
+		nodeOS := node.OS()
+		err := node.RunSSHCommand("root", nodeOS.RefreshPackagesCommand())
 		if err != nil {
-			return fmt.Errorf("failed to copy registry certificate to node %s: %w", node.GetInternalIP(), err)
+			return fmt.Errorf("failed to refresh packages on %s: %w", node.GetName(), err)
 		}
-		err = node.RunSSHCommand("root", "update-ca-certificates")
+		err = node.RunSSHCommand("root", nodeOS.InstallPackagesCommand("podman"))
 		if err != nil {
-			return fmt.Errorf("failed to update CA certificates on node %s: %w", node.GetInternalIP(), err)
+			return fmt.Errorf("failed to install podman on %s: %w", node.GetName(), err)
 		}
-		err = node.RunSSHCommand("root", "systemctl restart docker.service || true") // docker is probably not yet installed
+	}
+
+	for i, image := range images {
+		b.stlog.Logf("Pulling image %d/%d on %s: %s", i+1, len(images), node.GetName(), image)
+		err := node.RunSSHCommand("root", "podman pull "+image)
 		if err != nil {
-			return fmt.Errorf("failed to restart docker service on node %s: %w", node.GetInternalIP(), err)
+			return fmt.Errorf("failed to pull image %s on %s: %w", image, node.GetName(), err)
 		}
 	}
 
@@ -1004,85 +1942,253 @@ func (b *GCPBootstrapper) EnsureGitHubAccessConfigured() error {
 	return nil
 }
 
+// VerifyGitHubAppURLs checks after the installation that the GitHub App
+// backing the GitHub git provider points at the generated cs.<domain>
+// endpoints. The API only exposes the app's homepage URL, so the OAuth
+// callback URL is always included in the printed instructions; without a PAT
+// the expected values are only printed.
+func (b *GCPBootstrapper) VerifyGitHubAppURLs() error {
+	homepageURL := fmt.Sprintf("https://cs.%s", b.Env.BaseDomain)
+	callbackURL := homepageURL + "/ide/auth/github/callback"
+	settingsURL := fmt.Sprintf("https://github.com/settings/apps/%s", b.Env.GitHubAppName)
+
+	if b.Env.GitHubPAT == "" {
+		b.stlog.Logf("No GitHub PAT set, cannot verify the GitHub App via the API")
+		b.stlog.Logf("Ensure the app at %s uses homepage URL %s and callback URL %s", settingsURL, homepageURL, callbackURL)
+		return nil
+	}
+
+	app, err := b.GitHubClient.GetAppBySlug(b.ctx, b.Env.GitHubAppName)
+	if err != nil {
+		return fmt.Errorf("failed to look up GitHub App %q: %w", b.Env.GitHubAppName, err)
+	}
+
+	if strings.TrimSuffix(app.GetExternalURL(), "/") != homepageURL {
+		b.stlog.Warnf("GitHub App %q has homepage URL %q, expected %s", b.Env.GitHubAppName, app.GetExternalURL(), homepageURL)
+		b.stlog.Warnf("Fix it at %s and set the callback URL to %s", settingsURL, callbackURL)
+		return nil
+	}
+
+	b.stlog.Logf("GitHub App %q homepage URL matches %s", b.Env.GitHubAppName, homepageURL)
+	b.stlog.Logf("The callback URL is not exposed by the API; it must be %s", callbackURL)
+	return nil
+}
+
+// DefaultDNSRecordTTL is the record TTL used when no custom TTL is configured.
+const DefaultDNSRecordTTL int64 = 300
+
+// dnsRecordTTL returns the configured DNS record TTL, falling back to the default.
+func (b *GCPBootstrapper) dnsRecordTTL() int64 {
+	if b.Env.DNSRecordTTL > 0 {
+		return b.Env.DNSRecordTTL
+	}
+	return DefaultDNSRecordTTL
+}
+
 func (b *GCPBootstrapper) EnsureDNSRecords() error {
-	gcpProject := b.Env.DNSProjectID
-	if b.Env.DNSProjectID == "" {
-		gcpProject = b.Env.ProjectID
+	if b.Env.UseCloudflare {
+		return b.ensureCloudflareDNSRecords()
+	}
+	if b.Env.DNSMode == DNSModeManual {
+		return b.ensureManualDNSRecords()
+	}
+
+	provider, err := b.dnsProvider()
+	if err != nil {
+		return err
 	}
 
 	zoneName := b.Env.DNSZoneName
-	err := b.GCPClient.EnsureDNSManagedZone(gcpProject, zoneName, b.Env.BaseDomain+".", "Codesphere DNS zone")
+	dnsName := b.Env.BaseDomain + "."
+	err = provider.EnsureZone(zoneName, dnsName)
 	if err != nil {
 		return fmt.Errorf("failed to ensure DNS managed zone: %w", err)
 	}
 
-	records := []*dns.ResourceRecordSet{
+	// The ownership record annotates the managed records with this
+	// environment, so teardown only garbage collects records it owns.
+	records := append(b.requiredDNSRecords(),
+		dnsprovider.OwnershipRecord(b.Env.BaseDomain, b.Env.ProjectID, b.dnsRecordTTL()))
+	err = provider.EnsureRecords(zoneName, dnsName, records)
+	if err != nil {
+		return fmt.Errorf("failed to ensure DNS record sets: %w", err)
+	}
+
+	if b.Env.DNSSEC {
+		projectID := b.Env.DNSProjectID
+		if projectID == "" {
+			projectID = b.Env.ProjectID
+		}
+		dsRecord, err := b.GCPClient.GetDNSSECDSRecord(projectID, zoneName)
+		if err != nil {
+			return fmt.Errorf("failed to get DNSSEC DS record: %w", err)
+		}
+		b.Env.DNSSECDSRecord = dsRecord
+		b.stlog.Logf("DNSSEC enabled; DS record to publish at the registrar: %s", dsRecord)
+	}
+
+	return nil
+}
+
+// requiredDNSRecords returns the record sets the environment needs, pointing
+// at the gateway, public gateway, and SSH proxy IPs.
+func (b *GCPBootstrapper) requiredDNSRecords() []dnsprovider.Record {
+	ttl := b.dnsRecordTTL()
+	records := []dnsprovider.Record{
 		{
 			Name:    fmt.Sprintf("cs.%s.", b.Env.BaseDomain),
 			Type:    "A",
-			Ttl:     300,
+			TTL:     ttl,
 			Rrdatas: []string{b.Env.GatewayIP},
 		},
 		{
 			Name:    fmt.Sprintf("*.cs.%s.", b.Env.BaseDomain),
 			Type:    "A",
-			Ttl:     300,
+			TTL:     ttl,
 			Rrdatas: []string{b.Env.GatewayIP},
 		},
 		{
 			Name:    fmt.Sprintf("*.ws.%s.", b.Env.BaseDomain),
 			Type:    "A",
-			Ttl:     300,
+			TTL:     ttl,
 			Rrdatas: []string{b.Env.PublicGatewayIP},
 		},
 		{
 			Name:    fmt.Sprintf("ws.%s.", b.Env.BaseDomain),
 			Type:    "A",
-			Ttl:     300,
+			TTL:     ttl,
 			Rrdatas: []string{b.Env.PublicGatewayIP},
 		},
 		{
 			Name:    fmt.Sprintf("*.ssh.cs.%s.", b.Env.BaseDomain),
 			Type:    "A",
-			Ttl:     300,
+			TTL:     ttl,
 			Rrdatas: []string{b.Env.SshProxyIP},
 		},
 	}
 
-	err = b.GCPClient.EnsureDNSRecordSets(gcpProject, zoneName, records)
+	// Customers hosting Codesphere at the zone apex get an additional A record
+	// at the base domain itself. Both Cloud DNS and Route 53 support plain A
+	// records at the apex, so no ALIAS record type is needed.
+	if b.Env.ApexDomainRecords {
+		records = append(records, dnsprovider.Record{
+			Name:    fmt.Sprintf("%s.", b.Env.BaseDomain),
+			Type:    "A",
+			TTL:     ttl,
+			Rrdatas: []string{b.Env.GatewayIP},
+		})
+	}
+
+	// Dual-stack environments get a matching AAAA record for every A record.
+	if b.Env.IPv6 {
+		ipv6 := map[string]string{
+			b.Env.GatewayIP:       b.Env.GatewayIPv6,
+			b.Env.PublicGatewayIP: b.Env.PublicGatewayIPv6,
+			b.Env.SshProxyIP:      b.Env.SshProxyIPv6,
+		}
+		for _, record := range slices.Clone(records) {
+			address := ipv6[record.Rrdatas[0]]
+			if address == "" {
+				continue
+			}
+			records = append(records, dnsprovider.Record{
+				Name:    record.Name,
+				Type:    "AAAA",
+				TTL:     ttl,
+				Rrdatas: []string{address},
+			})
+		}
+	}
+
+	return records
+}
+
+// ensureCloudflareDNSRecords creates the DNS records in the configured
+// Cloudflare zone. Web-facing records are proxied through Cloudflare; the SSH
+// workspace proxy record stays unproxied because Cloudflare only proxies
+// HTTP(S) traffic.
+func (b *GCPBootstrapper) ensureCloudflareDNSRecords() error {
+	type record struct {
+		name    string
+		content string
+		proxied bool
+	}
+
+	records := []record{
+		{fmt.Sprintf("cs.%s", b.Env.BaseDomain), b.Env.GatewayIP, true},
+		{fmt.Sprintf("*.cs.%s", b.Env.BaseDomain), b.Env.GatewayIP, true},
+		{fmt.Sprintf("*.ws.%s", b.Env.BaseDomain), b.Env.PublicGatewayIP, true},
+		{fmt.Sprintf("ws.%s", b.Env.BaseDomain), b.Env.PublicGatewayIP, true},
+		{fmt.Sprintf("*.ssh.cs.%s", b.Env.BaseDomain), b.Env.SshProxyIP, false},
+	}
+
+	if b.Env.ApexDomainRecords {
+		records = append(records, record{b.Env.BaseDomain, b.Env.GatewayIP, true})
+	}
+
+	for _, r := range records {
+		err := b.CloudflareClient.EnsureDNSRecord(b.Env.CloudflareZoneID, "A", r.name, r.content, r.proxied)
+		if err != nil {
+			return fmt.Errorf("failed to ensure Cloudflare DNS record %s: %w", r.name, err)
+		}
+	}
+
+	return nil
+}
+
+// EnsureOriginCertificate issues a Cloudflare origin CA certificate covering
+// the Codesphere domains and stores it in the vault. Does nothing if the vault
+// already contains a certificate.
+func (b *GCPBootstrapper) EnsureOriginCertificate() error {
+	if s := b.icg.GetVault().GetSecret(files.SecretCloudflareOriginCertPem); s != nil && s.Fields != nil && s.Fields.Password != "" {
+		return nil
+	}
+
+	hostnames := []string{
+		b.Env.BaseDomain,
+		fmt.Sprintf("*.%s", b.Env.BaseDomain),
+		fmt.Sprintf("*.cs.%s", b.Env.BaseDomain),
+		fmt.Sprintf("*.ws.%s", b.Env.BaseDomain),
+	}
+
+	cert, key, err := b.CloudflareClient.CreateOriginCertificate(hostnames)
 	if err != nil {
-		return fmt.Errorf("failed to ensure DNS record sets: %w", err)
+		return fmt.Errorf("failed to create origin certificate: %w", err)
 	}
 
+	b.icg.GetVault().SetSecret(files.SecretEntry{Name: files.SecretCloudflareOriginCertPem, Fields: &files.SecretFields{Password: cert}})
+	b.icg.GetVault().SetSecret(files.SecretEntry{Name: files.SecretCloudflareOriginKeyPem, Fields: &files.SecretFields{Password: key}})
+
 	return nil
 }
 
 func (b *GCPBootstrapper) InstallCodesphere() error {
-	fullPackageFilename, err := b.ensureCodespherePackageOnJumpbox()
+	fullPackageFilename, err := b.ensureCodespherePackageOnOpsNode()
 	if err != nil {
-		return fmt.Errorf("failed to ensure Codesphere package on jumpbox: %w", err)
+		return fmt.Errorf("failed to ensure Codesphere package on %s: %w", b.opsNode().GetName(), err)
 	}
 
 	err = b.runInstallCommand(fullPackageFilename)
 	if err != nil {
-		return fmt.Errorf("failed to install Codesphere from jumpbox: %w", err)
+		return fmt.Errorf("failed to install Codesphere from %s: %w", b.opsNode().GetName(), err)
 	}
 
 	return nil
 }
 
-func (b *GCPBootstrapper) ensureCodespherePackageOnJumpbox() (string, error) {
+func (b *GCPBootstrapper) ensureCodespherePackageOnOpsNode() (string, error) {
+	ops := b.opsNode()
 	packageFilename := "installer.tar.gz"
 	if b.Env.RegistryType == RegistryTypeGitHub {
 		packageFilename = "installer-lite.tar.gz"
 	}
 
 	if b.Env.InstallLocal != "" {
-		b.stlog.Logf("Copying local package %s to jumpbox...", b.Env.InstallLocal)
+		b.stlog.Logf("Copying local package %s to %s...", b.Env.InstallLocal, ops.GetName())
 		fullPackageFilename := fmt.Sprintf("local-%s", packageFilename)
-		err := b.Env.Jumpbox.NodeClient.CopyFile(b.Env.Jumpbox, b.Env.InstallLocal, "/root/"+fullPackageFilename)
+		err := ops.NodeClient.CopyFile(ops, b.Env.InstallLocal, filepath.Join(b.artifactsDir(), fullPackageFilename))
 		if err != nil {
-			return "", fmt.Errorf("failed to copy local install package to jumpbox: %w", err)
+			return "", fmt.Errorf("failed to copy local install package to %s: %w", ops.GetName(), err)
 		}
 		return fullPackageFilename, nil
 	}
@@ -1098,9 +2204,12 @@ func (b *GCPBootstrapper) ensureCodespherePackageOnJumpbox() (string, error) {
 	b.stlog.Logf("Downloading Codesphere package...")
 	downloadCmd := fmt.Sprintf("oms download package -f %s -H %s %s",
 		packageFilename, b.Env.InstallHash, b.Env.InstallVersion)
-	err := b.Env.Jumpbox.RunSSHCommand("root", downloadCmd)
+	if b.usesArtifactStorage() {
+		downloadCmd = fmt.Sprintf("cd %s && %s", b.artifactsDir(), downloadCmd)
+	}
+	err := ops.RunSSHCommand("root", downloadCmd)
 	if err != nil {
-		return "", fmt.Errorf("failed to download Codesphere package from jumpbox: %w", err)
+		return "", fmt.Errorf("failed to download Codesphere package on %s: %w", ops.GetName(), err)
 	}
 
 	return fullPackageFilename, nil
@@ -1110,7 +2219,18 @@ func (b *GCPBootstrapper) runInstallCommand(packageFilename string) error {
 	b.stlog.Logf("Installing Codesphere...")
 	installCmd := fmt.Sprintf("oms install codesphere -c /etc/codesphere/config.yaml -k %s/age_key.txt --vault %s -p %s%s",
 		b.Env.SecretsDir, filepath.Join(b.Env.SecretsDir, "prod.vault.yaml"), packageFilename, b.generateSkipStepsArg())
-	return b.Env.Jumpbox.RunSSHCommand("root", installCmd)
+
+	// The install runs detached in a transient systemd unit so a dropped SSH
+	// connection does not kill it; `oms-cli install attach` reconnects to it.
+	err := b.opsNode().RunSSHCommand("root", installer.DetachedInstallCommand(installCmd, b.artifactsDir()))
+	if err != nil {
+		return fmt.Errorf("failed to start detached install: %w", err)
+	}
+	if err := b.opsNode().RunSSHCommand("root", installer.AttachInstallCommand()); err != nil {
+		return b.recordInstallFailure(err)
+	}
+	b.Env.LastFailure = nil
+	return nil
 }
 
 func (b *GCPBootstrapper) generateSkipStepsArg() string {
@@ -1124,111 +2244,3 @@ func (b *GCPBootstrapper) generateSkipStepsArg() string {
 
 	return " -s " + strings.Join(skipSteps, ",")
 }
-
-func (b *GCPBootstrapper) GenerateK0sConfigScript() error {
-	script := `#!/bin/bash
-
-cat <<EOF > cloud.conf
-[Global]
-project-id = "$PROJECT_ID"
-EOF
-
-cat <<EOF >> cc-deployment.yaml
-apiVersion: apps/v1
-kind: DaemonSet
-metadata:
-  name: cloud-controller-manager
-  namespace: kube-system
-  labels:
-    component: cloud-controller-manager
-spec:
-  selector:
-    matchLabels:
-      component: cloud-controller-manager
-  template:
-    metadata:
-      labels:
-        component: cloud-controller-manager
-    spec:
-      serviceAccountName: cloud-controller-manager
-      containers:
-      - name: cloud-controller-manager
-        image: k8scloudprovidergcp/cloud-controller-manager:latest
-        command:
-        - /usr/local/bin/cloud-controller-manager
-        args:
-        - --v=5
-        - --cloud-provider=gce
-        - --cloud-config=/etc/gce/cloud.conf
-        - --leader-elect-resource-name=k0s-gcp-ccm
-        - --use-service-account-credentials=true
-        - --controllers=cloud-node,cloud-node-lifecycle,service
-        - --allocate-node-cidrs=false
-        - --configure-cloud-routes=false
-        volumeMounts:
-        - name: cloud-config-volume
-          mountPath: /etc/gce
-          readOnly: true
-      volumes:
-      - name: cloud-config-volume
-        configMap:
-          name: cloud-config
-      tolerations:
-      - key: node.cloudprovider.kubernetes.io/uninitialized
-        value: "true"
-        effect: NoSchedule
-      - key: node-role.kubernetes.io/master
-        effect: NoSchedule
-      - key: node-role.kubernetes.io/control-plane
-        effect: NoSchedule
-EOF
-
-KUBECTL="/etc/codesphere/deps/kubernetes/files/k0s kubectl"
-$KUBECTL create configmap cloud-config --from-file=cloud.conf -n kube-system
-echo alias kubectl=\"$KUBECTL\" >> /root/.bashrc
-echo alias k=\"$KUBECTL\" >> /root/.bashrc
-
-$KUBECTL apply -f https://raw.githubusercontent.com/kubernetes/cloud-provider-gcp/refs/tags/providers/v0.28.2/deploy/packages/default/manifest.yaml
-
-$KUBECTL apply -f cc-deployment.yaml
-
-# set loadBalancerIP for public-gateway-controller and gateway-controller
-$KUBECTL patch svc public-gateway-controller -n codesphere -p '{"spec": {"loadBalancerIP": "'` + b.Env.PublicGatewayIP + `'"}}'
-$KUBECTL patch svc gateway-controller -n codesphere -p '{"spec": {"loadBalancerIP": "'` + b.Env.GatewayIP + `'"}}'
-
-sed -i 's/k0scontroller/k0scontroller --enable-cloud-provider/g' /etc/systemd/system/k0scontroller.service
-
-ssh -o StrictHostKeyChecking=no root@` + b.Env.ControlPlaneNodes[1].GetInternalIP() + ` "sed -i 's/k0sworker/k0sworker --enable-cloud-provider/g' /etc/systemd/system/k0sworker.service; systemctl daemon-reload; systemctl restart k0sworker"
-
-ssh -o StrictHostKeyChecking=no root@` + b.Env.ControlPlaneNodes[2].GetInternalIP() + ` "sed -i 's/k0sworker/k0sworker --enable-cloud-provider/g' /etc/systemd/system/k0sworker.service; systemctl daemon-reload; systemctl restart k0sworker"
-
-systemctl daemon-reload
-systemctl restart k0scontroller
-`
-	// Probably we need to enable the cloud provider plugin in k0s configuration.
-	// --enable-cloud-provider on worker nodes systemd file /etc/systemd/system/k0sworker.service
-	// in addition on the first node: /etc/systemd/system/k0scontroller.service the flag --enable-cloud-provider
-
-	err := b.fw.WriteFile("configure-k0s.sh", []byte(script), 0755)
-	if err != nil {
-		return fmt.Errorf("failed to write configure-k0s.sh: %w", err)
-	}
-	err = b.Env.ControlPlaneNodes[0].NodeClient.CopyFile(b.Env.ControlPlaneNodes[0], "configure-k0s.sh", "/root/configure-k0s.sh")
-	if err != nil {
-		return fmt.Errorf("failed to copy configure-k0s.sh to control plane node: %w", err)
-	}
-	err = b.Env.ControlPlaneNodes[0].RunSSHCommand("root", "chmod +x /root/configure-k0s.sh")
-	if err != nil {
-		return fmt.Errorf("failed to make configure-k0s.sh executable on control plane node: %w", err)
-	}
-	return nil
-}
-
-func (b *GCPBootstrapper) RunK0sConfigScript() error {
-	err := b.Env.ControlPlaneNodes[0].RunSSHCommand("root", "/root/configure-k0s.sh")
-	if err != nil {
-		return fmt.Errorf("failed to install Codesphere from jumpbox: %w", err)
-	}
-
-	return nil
-}
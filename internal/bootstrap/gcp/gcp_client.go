@@ -6,8 +6,10 @@ package gcp
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"slices"
 
@@ -16,20 +18,30 @@ import (
 	compute "cloud.google.com/go/compute/apiv1"
 	"cloud.google.com/go/compute/apiv1/computepb"
 	"cloud.google.com/go/iam/apiv1/iampb"
+	kms "cloud.google.com/go/kms/apiv1"
+	"cloud.google.com/go/kms/apiv1/kmspb"
 	resourcemanager "cloud.google.com/go/resourcemanager/apiv3"
 	"cloud.google.com/go/resourcemanager/apiv3/resourcemanagerpb"
 	serviceusage "cloud.google.com/go/serviceusage/apiv1"
 	"cloud.google.com/go/serviceusage/apiv1/serviceusagepb"
+	"github.com/codesphere-cloud/oms/internal/audit"
 	"github.com/codesphere-cloud/oms/internal/bootstrap"
 	"github.com/codesphere-cloud/oms/internal/util"
 	"github.com/lithammer/shortuuid"
 	"google.golang.org/api/cloudbilling/v1"
+	"google.golang.org/api/cloudresourcemanager/v1"
 	"google.golang.org/api/dns/v1"
+	essentialcontacts "google.golang.org/api/essentialcontacts/v1"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/iam/v1"
 	"google.golang.org/api/iterator"
 	publicca "google.golang.org/api/publicca/v1"
+	servicenetworking "google.golang.org/api/servicenetworking/v1"
+	sqladmin "google.golang.org/api/sqladmin/v1"
+	storage "google.golang.org/api/storage/v1"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
@@ -41,28 +53,62 @@ type GCPClientManager interface {
 	CreateProjectID(projectName string) string
 	CreateProject(parent, projectName, displayName string, labels map[string]string) (string, error)
 	UpdateProject(projectID string, labels map[string]string) error
+	GetProjectLabels(projectID string) (map[string]string, error)
 	DeleteProject(projectID string) error
 	IsOMSManagedProject(projectID string) (bool, error)
+	IsProtectedProject(projectID string) (bool, error)
+	EnsureProjectLien(projectID, reason string) (string, error)
+	RemoveProjectLiens(projectID string) error
+	EnsureEssentialContact(projectID, email, category string) error
 	GetBillingInfo(projectID string) (*cloudbilling.ProjectBillingInfo, error)
+	ListComputeSKUs(region string) ([]*cloudbilling.Sku, error)
+	GetRegionQuotas(projectID, region string) ([]*computepb.Quota, error)
 	EnableBilling(projectID, billingAccount string) error
 	EnableAPIs(projectID string, apis []string) error
 	GetArtifactRegistry(projectID, region, repoName string) (*artifactpb.Repository, error)
 	CreateArtifactRegistry(projectID, region, repoName string) (*artifactpb.Repository, error)
+	ApplyArtifactRegistryCleanupPolicies(projectID, region, repoName string, keepTagCount int, untaggedRetention time.Duration) error
 	CreateServiceAccount(projectID, name, displayName string) (string, bool, error)
 	CreateServiceAccountKey(projectID, saEmail string) (string, error)
 	AssignIAMRole(projectID, saEmail string, saProjectID string, roles []string) error
+	AssignIAMRoleToMember(projectID, member string, roles []string) error
+	GetProjectNumber(projectID string) (int64, error)
 	RemoveIAMRoleBinding(projectID, saName string, saProjectID string, roles []string) error
-	CreateVPC(projectID, region, networkName, subnetName, routerName, natName string) error
+	CreateVPC(projectID, region, networkName, subnetName, routerName, natName string, dualStack bool) error
 	CreateFirewallRule(projectID string, rule *computepb.Firewall) error
+	CreateSecurityPolicy(projectID string, policy *computepb.SecurityPolicy) error
 	CreateInstance(projectID, zone string, instance *computepb.Instance) error
 	GetInstance(projectID, zone, instanceName string) (*computepb.Instance, error)
+	GetInstanceHostKeys(projectID, zone, instanceName string) (map[string]string, error)
+	ListInstancesByLabels(projectID, zone string, labels map[string]string) ([]*computepb.Instance, error)
+	DeleteInstance(projectID, zone, instanceName string) error
+	ResizeDisk(projectID, zone, diskName string, sizeGb int64) error
+	ListAddressesByLabels(projectID, region string, labels map[string]string) ([]*computepb.Address, error)
+	DeleteAddress(projectID, region, addressName string) error
+	GetImage(projectID, imageName string) (*computepb.Image, error)
+	GetImageFromFamily(projectID, family string) (*computepb.Image, error)
 	StartInstance(projectID, zone, instanceName string) error
+	StopInstance(projectID, zone, instanceName string) error
 	CreateAddress(projectID, region string, address *computepb.Address) (string, error)
 	GetAddress(projectID, region, addressName string) (*computepb.Address, error)
-	EnsureDNSManagedZone(projectID, zoneName, dnsName, description string) error
+	EnsureDNSManagedZone(projectID, zoneName, dnsName, description string, labels map[string]string, dnssec bool) error
+	GetDNSSECDSRecord(projectID, zoneName string) (string, error)
+	SearchProjectsByLabels(folderID string, labels map[string]string) ([]*resourcemanagerpb.Project, error)
 	EnsureDNSRecordSets(projectID, zoneName string, records []*dns.ResourceRecordSet) error
 	DeleteDNSRecordSets(projectID, zoneName, baseDomain string) error
+	GetDNSRecordSet(projectID, zoneName, name, rtype string) (*dns.ResourceRecordSet, error)
+	GetDNSManagedZone(projectID, zoneName string) (*dns.ManagedZone, error)
+	DeleteDNSManagedZone(projectID, zoneName string) error
+	EnsurePrivateServiceAccess(projectID, networkName string) error
+	EnsureGCSBucket(projectID, bucketName, location string, labels map[string]string, retentionDays int64) error
+	GrantBucketIAMRole(bucketName, member, role string) error
+	GetCloudSQLInstance(projectID, instanceName string) (*sqladmin.DatabaseInstance, error)
+	CreateCloudSQLInstance(projectID string, instance *sqladmin.DatabaseInstance) error
+	EnsureCloudSQLDatabase(projectID, instanceName, database string) error
+	EnsureCloudSQLUser(projectID, instanceName, name, password string) error
 	CreatePublicCAExternalAccountKey(projectID string) (keyID, b64MacKey string, err error)
+	EnsureKMSKey(projectID, location, keyRingID, keyID string) (string, error)
+	DestroyKMSKeyVersions(keyName string) ([]string, error)
 }
 
 // Concrete implementation
@@ -70,6 +116,7 @@ type GCPClient struct {
 	ctx             context.Context
 	st              *bootstrap.StepLogger
 	CredentialsFile string
+	retry           RetryPolicy
 }
 
 func NewGCPClient(ctx context.Context, st *bootstrap.StepLogger, credentialsFile string) *GCPClient {
@@ -77,6 +124,13 @@ func NewGCPClient(ctx context.Context, st *bootstrap.StepLogger, credentialsFile
 		ctx:             ctx,
 		st:              st,
 		CredentialsFile: credentialsFile,
+		// Transparently retry transient API failures; idempotency conditions
+		// are passed through for the callers to branch on.
+		retry: RetryPolicy{
+			Retryable: IsRetryableGCPError,
+			OnRetry:   st.LogRetry,
+			Time:      util.NewTime(),
+		},
 	}
 }
 
@@ -122,6 +176,8 @@ func (c *GCPClient) CreateProjectID(projectName string) string {
 // CreateProject creates a new GCP project under the specified parent (folder or organization).
 // It returns the project ID of the newly created project.
 func (c *GCPClient) CreateProject(parent, projectID, displayName string, labels map[string]string) (string, error) {
+	audit.Log("gcp.create-project", projectID)
+
 	client, err := resourcemanager.NewProjectsClient(c.ctx)
 	if err != nil {
 		return "", err
@@ -151,6 +207,8 @@ func (c *GCPClient) CreateProject(parent, projectID, displayName string, labels
 // UpdateProject updates the project's labels of an existing GCP project.
 // Returns an error if the update operation fails or if the project does not exist.
 func (c *GCPClient) UpdateProject(projectID string, labels map[string]string) error {
+	audit.Log("gcp.update-project", projectID)
+
 	client, err := resourcemanager.NewProjectsClient(c.ctx)
 	if err != nil {
 		return err
@@ -179,8 +237,29 @@ func (c *GCPClient) UpdateProject(projectID string, labels map[string]string) er
 	return nil
 }
 
+// GetProjectLabels returns the current labels of the project, so single
+// labels can be added or removed without clobbering the others.
+func (c *GCPClient) GetProjectLabels(projectID string) (map[string]string, error) {
+	client, err := resourcemanager.NewProjectsClient(c.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource manager client: %w", err)
+	}
+	defer util.IgnoreError(client.Close)
+
+	project, err := client.GetProject(c.ctx, &resourcemanagerpb.GetProjectRequest{
+		Name: getProjectResourceName(projectID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return project.Labels, nil
+}
+
 // DeleteProject deletes the specified GCP project.
 func (c *GCPClient) DeleteProject(projectID string) error {
+	audit.Log("gcp.delete-project", projectID)
+
 	client, err := resourcemanager.NewProjectsClient(c.ctx)
 	if err != nil {
 		return fmt.Errorf("failed to create resource manager client: %w", err)
@@ -219,6 +298,146 @@ func (c *GCPClient) IsOMSManagedProject(projectID string) (bool, error) {
 	return CheckOMSManagedLabel(project.Labels), nil
 }
 
+// IsProtectedProject checks if the given project is locked against teardown by
+// verifying the 'oms-protected' label.
+func (c *GCPClient) IsProtectedProject(projectID string) (bool, error) {
+	client, err := resourcemanager.NewProjectsClient(c.ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to create resource manager client: %w", err)
+	}
+	defer util.IgnoreError(client.Close)
+
+	project, err := client.GetProject(c.ctx, &resourcemanagerpb.GetProjectRequest{
+		Name: getProjectResourceName(projectID),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return CheckProtectedLabel(project.Labels), nil
+}
+
+// OMSLienOrigin identifies project liens created by OMS.
+const OMSLienOrigin = "oms-bootstrap"
+
+// EnsureProjectLien places a lien on the project that blocks project deletion
+// until the lien is removed, and returns the lien name. An existing OMS lien
+// is reused.
+func (c *GCPClient) EnsureProjectLien(projectID, reason string) (string, error) {
+	audit.Log("gcp.ensure-project-lien", projectID)
+
+	service, err := cloudresourcemanager.NewService(c.ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to create resource manager service: %w", err)
+	}
+
+	parent := getProjectResourceName(projectID)
+	existing := ""
+	err = service.Liens.List().Parent(parent).Pages(c.ctx, func(resp *cloudresourcemanager.ListLiensResponse) error {
+		for _, lien := range resp.Liens {
+			if lien.Origin == OMSLienOrigin {
+				existing = lien.Name
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list liens: %w", err)
+	}
+	if existing != "" {
+		return existing, nil
+	}
+
+	lien, err := service.Liens.Create(&cloudresourcemanager.Lien{
+		Parent:       parent,
+		Origin:       OMSLienOrigin,
+		Reason:       reason,
+		Restrictions: []string{"resourcemanager.projects.delete"},
+	}).Context(c.ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("failed to create lien: %w", err)
+	}
+
+	return lien.Name, nil
+}
+
+// RemoveProjectLiens removes the liens OMS placed on the project so an
+// explicit teardown can delete it. Liens from other origins stay in place.
+func (c *GCPClient) RemoveProjectLiens(projectID string) error {
+	audit.Log("gcp.remove-project-liens", projectID)
+
+	service, err := cloudresourcemanager.NewService(c.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create resource manager service: %w", err)
+	}
+
+	var names []string
+	err = service.Liens.List().Parent(getProjectResourceName(projectID)).Pages(c.ctx, func(resp *cloudresourcemanager.ListLiensResponse) error {
+		for _, lien := range resp.Liens {
+			if lien.Origin == OMSLienOrigin {
+				names = append(names, lien.Name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list liens: %w", err)
+	}
+
+	for _, name := range names {
+		if _, err := service.Liens.Delete(name).Context(c.ctx).Do(); err != nil {
+			return fmt.Errorf("failed to delete lien %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// EnsureEssentialContact registers an email address as an Essential Contact
+// for the given notification category on the project. An existing contact
+// already subscribed to the category (directly or via ALL) is left untouched.
+// The essentialcontacts.googleapis.com API must be enabled on the project.
+func (c *GCPClient) EnsureEssentialContact(projectID, email, category string) error {
+	audit.Log("gcp.ensure-essential-contact", projectID)
+
+	svc, err := essentialcontacts.NewService(c.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create essential contacts service: %w", err)
+	}
+
+	parent := getProjectResourceName(projectID)
+	exists := false
+	err = svc.Projects.Contacts.List(parent).Pages(c.ctx, func(resp *essentialcontacts.GoogleCloudEssentialcontactsV1ListContactsResponse) error {
+		for _, contact := range resp.Contacts {
+			if !strings.EqualFold(contact.Email, email) {
+				continue
+			}
+			if slices.Contains(contact.NotificationCategorySubscriptions, category) ||
+				slices.Contains(contact.NotificationCategorySubscriptions, "ALL") {
+				exists = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list essential contacts: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	_, err = svc.Projects.Contacts.Create(parent, &essentialcontacts.GoogleCloudEssentialcontactsV1Contact{
+		Email:                             email,
+		LanguageTag:                       "en-US",
+		NotificationCategorySubscriptions: []string{category},
+	}).Context(c.ctx).Do()
+	if err != nil {
+		return fmt.Errorf("failed to create essential contact for %s: %w", email, err)
+	}
+
+	return nil
+}
+
 func getProjectResourceName(projectID string) string {
 	return fmt.Sprintf("projects/%s", projectID)
 }
@@ -238,8 +457,56 @@ func (c *GCPClient) GetBillingInfo(projectID string) (*cloudbilling.ProjectBilli
 	return billingInfo, nil
 }
 
+// computeEngineService is the Cloud Billing Catalog service ID of Compute Engine.
+const computeEngineService = "services/6F81-5844-456A"
+
+// ListComputeSKUs returns the Compute Engine SKUs from the Cloud Billing
+// Catalog that apply in the given region (or globally).
+func (c *GCPClient) ListComputeSKUs(region string) ([]*cloudbilling.Sku, error) {
+	billingService, err := cloudbilling.NewService(c.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var skus []*cloudbilling.Sku
+	err = billingService.Services.Skus.List(computeEngineService).Pages(c.ctx, func(resp *cloudbilling.ListSkusResponse) error {
+		for _, sku := range resp.Skus {
+			if slices.Contains(sku.ServiceRegions, region) || slices.Contains(sku.ServiceRegions, "global") {
+				skus = append(skus, sku)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SKUs: %w", err)
+	}
+
+	return skus, nil
+}
+
+// GetRegionQuotas returns the quota metrics of the region, including usage.
+func (c *GCPClient) GetRegionQuotas(projectID, region string) ([]*computepb.Quota, error) {
+	client, err := compute.NewRegionsRESTClient(c.ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer util.IgnoreError(client.Close)
+
+	resp, err := client.Get(c.ctx, &computepb.GetRegionRequest{
+		Project: projectID,
+		Region:  region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get region %s: %w", region, err)
+	}
+
+	return resp.GetQuotas(), nil
+}
+
 // EnableBilling enables billing for the given project using the specified billing account.
 func (c *GCPClient) EnableBilling(projectID, billingAccount string) error {
+	audit.Log("gcp.enable-billing", projectID, billingAccount)
+
 	billingService, err := cloudbilling.NewService(c.ctx)
 	if err != nil {
 		return err
@@ -255,6 +522,8 @@ func (c *GCPClient) EnableBilling(projectID, billingAccount string) error {
 
 // EnableAPIs enables the specified APIs for the given project.
 func (c *GCPClient) EnableAPIs(projectID string, apis []string) error {
+	audit.Log("gcp.enable-apis", projectID, strings.Join(apis, ","))
+
 	client, err := serviceusage.NewClient(c.ctx)
 	if err != nil {
 		return err
@@ -303,6 +572,8 @@ func (c *GCPClient) EnableAPIs(projectID string, apis []string) error {
 
 // CreateArtifactRegistry creates and returns an Artifact Registry repository by its name.
 func (c *GCPClient) CreateArtifactRegistry(projectID, region, repoName string) (*artifactpb.Repository, error) {
+	audit.Log("gcp.create-artifact-registry", repoName)
+
 	client, err := artifact.NewClient(c.ctx)
 	if err != nil {
 		return nil, err
@@ -358,10 +629,59 @@ func (c *GCPClient) GetArtifactRegistry(projectID, region, repoName string) (*ar
 	return repo, nil
 }
 
+// ApplyArtifactRegistryCleanupPolicies sets the cleanup policies of an Artifact Registry
+// repository: keep the most recent keepTagCount tagged versions per image and delete
+// untagged versions older than untaggedRetention. Existing cleanup policies are replaced.
+func (c *GCPClient) ApplyArtifactRegistryCleanupPolicies(projectID, region, repoName string, keepTagCount int, untaggedRetention time.Duration) error {
+	audit.Log("gcp.apply-artifact-registry-cleanup-policies", repoName)
+
+	client, err := artifact.NewClient(c.ctx)
+	if err != nil {
+		return err
+	}
+	defer util.IgnoreError(client.Close)
+
+	keepCount := int32(keepTagCount)
+	repo := &artifactpb.Repository{
+		Name: fmt.Sprintf("projects/%s/locations/%s/repositories/%s", projectID, region, repoName),
+		CleanupPolicies: map[string]*artifactpb.CleanupPolicy{
+			"keep-recent-versions": {
+				Id:     "keep-recent-versions",
+				Action: artifactpb.CleanupPolicy_KEEP,
+				ConditionType: &artifactpb.CleanupPolicy_MostRecentVersions{
+					MostRecentVersions: &artifactpb.CleanupPolicyMostRecentVersions{KeepCount: &keepCount},
+				},
+			},
+			"delete-untagged": {
+				Id:     "delete-untagged",
+				Action: artifactpb.CleanupPolicy_DELETE,
+				ConditionType: &artifactpb.CleanupPolicy_Condition{
+					Condition: &artifactpb.CleanupPolicyCondition{
+						TagState:  artifactpb.CleanupPolicyCondition_UNTAGGED.Enum(),
+						OlderThan: durationpb.New(untaggedRetention),
+					},
+				},
+			},
+		},
+	}
+
+	_, err = client.UpdateRepository(c.ctx, &artifactpb.UpdateRepositoryRequest{
+		Repository: repo,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"cleanup_policies"}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update artifact registry cleanup policies: %w", err)
+	}
+
+	return nil
+}
+
 // CreateServiceAccount creates a new service account with the given name and display name.
 // It returns the email of the created service account, a boolean indicating whether the account was newly created,
 // and an error if any occurred during the process.
 func (c *GCPClient) CreateServiceAccount(projectID, name, displayName string) (string, bool, error) {
+	audit.Log("gcp.create-service-account", name)
+
 	saMail := fmt.Sprintf("%s@%s.iam.gserviceaccount.com", name, projectID)
 	iamService, err := iam.NewService(c.ctx)
 	if err != nil {
@@ -388,6 +708,8 @@ func (c *GCPClient) CreateServiceAccount(projectID, name, displayName string) (s
 // CreateServiceAccountKey creates a new key for the specified service account.
 // It returns the private key data in PEM format and an error if any occurred during the process.
 func (c *GCPClient) CreateServiceAccountKey(projectID, saEmail string) (string, error) {
+	audit.Log("gcp.create-service-account-key", saEmail)
+
 	iamService, err := iam.NewService(c.ctx)
 	if err != nil {
 		return "", err
@@ -411,13 +733,56 @@ func (c *GCPClient) AssignIAMRole(projectID, saName string, saProjectID string,
 	return c.addRoleBindingToProject(member, roles, resource)
 }
 
+// AssignIAMRoleToMember assigns the specified IAM roles to an arbitrary member
+// (e.g. a Google-managed service agent) in a project.
+func (c *GCPClient) AssignIAMRoleToMember(projectID, member string, roles []string) error {
+	resource := fmt.Sprintf("projects/%s", projectID)
+	return c.addRoleBindingToProject(member, roles, resource)
+}
+
+// GetProjectNumber returns the numeric project number for the given project ID.
+func (c *GCPClient) GetProjectNumber(projectID string) (int64, error) {
+	client, err := resourcemanager.NewProjectsClient(c.ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer util.IgnoreError(client.Close)
+
+	project, err := client.GetProject(c.ctx, &resourcemanagerpb.GetProjectRequest{
+		Name: getProjectResourceName(projectID),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	// The resource name has the form "projects/<number>".
+	numberStr := strings.TrimPrefix(project.GetName(), "projects/")
+	number, err := strconv.ParseInt(numberStr, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse project number from %q: %w", project.GetName(), err)
+	}
+
+	return number, nil
+}
+
+// addRoleBindingToProject adds the member to the given roles with a
+// read-modify-write of the project policy. Concurrent policy updates abort the
+// write; the retry policy re-reads and re-applies the change in that case.
 func (c *GCPClient) addRoleBindingToProject(member string, roles []string, resource string) error {
+	audit.Log("gcp.add-iam-binding", member, strings.Join(roles, ","))
+
 	client, err := resourcemanager.NewProjectsClient(c.ctx)
 	if err != nil {
 		return err
 	}
 	defer util.IgnoreError(client.Close)
 
+	return c.retry.Retry(func() error {
+		return c.setRoleBinding(client, member, roles, resource)
+	})
+}
+
+func (c *GCPClient) setRoleBinding(client *resourcemanager.ProjectsClient, member string, roles []string, resource string) error {
 	getReq := &iampb.GetIamPolicyRequest{
 		Resource: resource,
 	}
@@ -474,12 +839,20 @@ func (c *GCPClient) RemoveIAMRoleBinding(projectID, saName string, saProjectID s
 }
 
 func (c *GCPClient) removeRoleBindingFromProject(member string, roles []string, resource string) error {
+	audit.Log("gcp.remove-iam-binding", member, strings.Join(roles, ","))
+
 	client, err := resourcemanager.NewProjectsClient(c.ctx)
 	if err != nil {
 		return err
 	}
 	defer util.IgnoreError(client.Close)
 
+	return c.retry.Retry(func() error {
+		return c.unsetRoleBinding(client, member, roles, resource)
+	})
+}
+
+func (c *GCPClient) unsetRoleBinding(client *resourcemanager.ProjectsClient, member string, roles []string, resource string) error {
 	policy, err := client.GetIamPolicy(c.ctx, &iampb.GetIamPolicyRequest{Resource: resource})
 	if err != nil {
 		return err
@@ -521,8 +894,12 @@ func (c *GCPClient) removeRoleBindingFromProject(member string, roles []string,
 	return err
 }
 
-// CreateVPC creates a VPC network with the specified subnet, router, and NAT gateway.
-func (c *GCPClient) CreateVPC(projectID, region, networkName, subnetName, routerName, natName string) error {
+// CreateVPC creates a VPC network with the specified subnet, router, and NAT
+// gateway. With dualStack, the subnet additionally gets externally routable
+// IPv6 addresses.
+func (c *GCPClient) CreateVPC(projectID, region, networkName, subnetName, routerName, natName string, dualStack bool) error {
+	audit.Log("gcp.create-vpc", networkName)
+
 	// Create Network
 	networksClient, err := compute.NewNetworksRESTClient(c.ctx)
 	if err != nil {
@@ -562,6 +939,10 @@ func (c *GCPClient) CreateVPC(projectID, region, networkName, subnetName, router
 		Region:      &region,
 		Network:     protoString(fmt.Sprintf("projects/%s/global/networks/%s", projectID, networkName)),
 	}
+	if dualStack {
+		subnet.StackType = protoString("IPV4_IPV6")
+		subnet.Ipv6AccessType = protoString("EXTERNAL")
+	}
 	op, err = subnetsClient.Insert(c.ctx, &computepb.InsertSubnetworkRequest{
 		Project:            projectID,
 		Region:             region,
@@ -643,6 +1024,8 @@ func (c *GCPClient) CreateVPC(projectID, region, networkName, subnetName, router
 
 // CreateFirewallRule creates a firewall rule in the specified project.
 func (c *GCPClient) CreateFirewallRule(projectID string, rule *computepb.Firewall) error {
+	audit.Log("gcp.create-firewall-rule", rule.GetName())
+
 	firewallsClient, err := compute.NewFirewallsRESTClient(c.ctx)
 	if err != nil {
 		return err
@@ -653,15 +1036,46 @@ func (c *GCPClient) CreateFirewallRule(projectID string, rule *computepb.Firewal
 		Project:          projectID,
 		FirewallResource: rule,
 	})
-	if err != nil && !strings.Contains(err.Error(), "already exists") {
+	if err != nil && !IsAlreadyExistsError(err) {
+		return err
+	}
+
+	return nil
+}
+
+// CreateSecurityPolicy creates a Cloud Armor security policy in the specified project.
+// Does nothing if a policy with the same name already exists.
+func (c *GCPClient) CreateSecurityPolicy(projectID string, policy *computepb.SecurityPolicy) error {
+	audit.Log("gcp.create-security-policy", policy.GetName())
+
+	client, err := compute.NewSecurityPoliciesRESTClient(c.ctx)
+	if err != nil {
+		return err
+	}
+	defer util.IgnoreError(client.Close)
+
+	op, err := client.Insert(c.ctx, &computepb.InsertSecurityPolicyRequest{
+		Project:                projectID,
+		SecurityPolicyResource: policy,
+	})
+	if err != nil && !IsAlreadyExistsError(err) {
 		return err
 	}
+	if err == nil {
+		if err := op.Wait(c.ctx); err != nil {
+			return err
+		}
+	}
+
+	c.st.Logf("Security policy %s ensured", policy.GetName())
 
 	return nil
 }
 
 // CreateInstance creates a new Compute Engine instance in the specified project and zone.
 func (c *GCPClient) CreateInstance(projectID, zone string, instance *computepb.Instance) error {
+	audit.Log("gcp.create-instance", instance.GetName())
+
 	client, err := compute.NewInstancesRESTClient(c.ctx)
 	if err != nil {
 		return err
@@ -674,10 +1088,10 @@ func (c *GCPClient) CreateInstance(projectID, zone string, instance *computepb.I
 		InstanceResource: instance,
 	})
 	if err != nil {
-		return err
+		return TypedError(err)
 	}
 
-	return op.Wait(c.ctx)
+	return TypedError(op.Wait(c.ctx))
 }
 
 // GetInstance retrieves a Compute Engine instance by its name in the specified project and zone.
@@ -688,92 +1102,368 @@ func (c *GCPClient) GetInstance(projectID, zone, instanceName string) (*computep
 	}
 	defer util.IgnoreError(client.Close)
 
-	return client.Get(c.ctx, &computepb.GetInstanceRequest{
+	instance, err := client.Get(c.ctx, &computepb.GetInstanceRequest{
 		Project:  projectID,
 		Zone:     zone,
 		Instance: instanceName,
 	})
+	return instance, TypedError(err)
 }
 
-// StartInstance starts a stopped Compute Engine instance in the specified project and zone.
-func (c *GCPClient) StartInstance(projectID, zone, instanceName string) error {
+// GetInstanceHostKeys returns the SSH host keys an instance published to its
+// guest attributes, keyed by key type (e.g. "ssh-ed25519"). Instances only
+// publish host keys when guest attributes are enabled in their metadata.
+func (c *GCPClient) GetInstanceHostKeys(projectID, zone, instanceName string) (map[string]string, error) {
 	client, err := compute.NewInstancesRESTClient(c.ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer util.IgnoreError(client.Close)
 
-	op, err := client.Start(c.ctx, &computepb.StartInstanceRequest{
-		Project:  projectID,
-		Zone:     zone,
-		Instance: instanceName,
+	queryPath := "hostkeys/"
+	attrs, err := client.GetGuestAttributes(c.ctx, &computepb.GetGuestAttributesInstanceRequest{
+		Project:   projectID,
+		Zone:      zone,
+		Instance:  instanceName,
+		QueryPath: &queryPath,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return op.Wait(c.ctx)
+	hostKeys := map[string]string{}
+	for _, item := range attrs.GetQueryValue().GetItems() {
+		hostKeys[item.GetKey()] = strings.TrimSpace(item.GetValue())
+	}
+	return hostKeys, nil
 }
 
-// CreateAddress creates a new static IP address in the specified project and region.
-func (c *GCPClient) CreateAddress(projectID, region string, address *computepb.Address) (string, error) {
-	client, err := compute.NewAddressesRESTClient(c.ctx)
+// ListInstancesByLabels returns all instances in the zone that carry the
+// given labels. It is used to discover resources left behind by a previous
+// interrupted bootstrap run.
+func (c *GCPClient) ListInstancesByLabels(projectID, zone string, labels map[string]string) ([]*computepb.Instance, error) {
+	client, err := compute.NewInstancesRESTClient(c.ctx)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer util.IgnoreError(client.Close)
 
-	op, err := client.Insert(c.ctx, &computepb.InsertAddressRequest{
-		Project:         projectID,
-		Region:          region,
-		AddressResource: address,
+	var instances []*computepb.Instance
+	it := client.List(c.ctx, &computepb.ListInstancesRequest{
+		Project: projectID,
+		Zone:    zone,
 	})
-	if err != nil {
-		return "", err
+	for {
+		instance, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list instances: %w", err)
+		}
+		if matchesLabels(instance.GetLabels(), labels) {
+			instances = append(instances, instance)
+		}
 	}
-	if err = op.Wait(c.ctx); err != nil {
-		return "", err
+
+	return instances, nil
+}
+
+// DeleteInstance deletes a Compute Engine instance and waits for the deletion to complete.
+func (c *GCPClient) DeleteInstance(projectID, zone, instanceName string) error {
+	audit.Log("gcp.delete-instance", instanceName)
+
+	client, err := compute.NewInstancesRESTClient(c.ctx)
+	if err != nil {
+		return err
 	}
+	defer util.IgnoreError(client.Close)
 
-	// Fetch the created address to get the IP
-	createdAddress, err := client.Get(c.ctx, &computepb.GetAddressRequest{
-		Project: projectID,
-		Region:  region,
-		Address: *address.Name,
+	op, err := client.Delete(c.ctx, &computepb.DeleteInstanceRequest{
+		Project:  projectID,
+		Zone:     zone,
+		Instance: instanceName,
 	})
 	if err != nil {
-		return "", err
+		return TypedError(err)
 	}
 
-	return *createdAddress.Address, nil
+	return TypedError(op.Wait(c.ctx))
 }
 
-// GetAddress retrieves a static IP address by its name in the specified project and region.
-func (c *GCPClient) GetAddress(projectID, region, addressName string) (*computepb.Address, error) {
-	client, err := compute.NewAddressesRESTClient(c.ctx)
+// ResizeDisk grows a zonal disk to the given size and waits for the operation
+// to complete. GCP only allows disks to grow, never to shrink.
+func (c *GCPClient) ResizeDisk(projectID, zone, diskName string, sizeGb int64) error {
+	audit.Log("gcp.resize-disk", diskName)
+
+	client, err := compute.NewDisksRESTClient(c.ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer util.IgnoreError(client.Close)
 
-	return client.Get(c.ctx, &computepb.GetAddressRequest{
+	op, err := client.Resize(c.ctx, &computepb.ResizeDiskRequest{
 		Project: projectID,
-		Region:  region,
-		Address: addressName,
+		Zone:    zone,
+		Disk:    diskName,
+		DisksResizeRequestResource: &computepb.DisksResizeRequest{
+			SizeGb: protoInt64(sizeGb),
+		},
 	})
-}
-
-// EnsureDNSManagedZone ensures that a DNS managed zone exists in the specified project.
-func (c *GCPClient) EnsureDNSManagedZone(projectID, zoneName, dnsName, description string) error {
+	if err != nil {
+		return TypedError(err)
+	}
+
+	return TypedError(op.Wait(c.ctx))
+}
+
+// ListAddressesByLabels returns all static addresses in the region that carry
+// the given labels.
+func (c *GCPClient) ListAddressesByLabels(projectID, region string, labels map[string]string) ([]*computepb.Address, error) {
+	client, err := compute.NewAddressesRESTClient(c.ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer util.IgnoreError(client.Close)
+
+	var addresses []*computepb.Address
+	it := client.List(c.ctx, &computepb.ListAddressesRequest{
+		Project: projectID,
+		Region:  region,
+	})
+	for {
+		address, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list addresses: %w", err)
+		}
+		if matchesLabels(address.GetLabels(), labels) {
+			addresses = append(addresses, address)
+		}
+	}
+
+	return addresses, nil
+}
+
+// DeleteAddress deletes a static IP address and waits for the deletion to complete.
+func (c *GCPClient) DeleteAddress(projectID, region, addressName string) error {
+	audit.Log("gcp.delete-address", addressName)
+
+	client, err := compute.NewAddressesRESTClient(c.ctx)
+	if err != nil {
+		return err
+	}
+	defer util.IgnoreError(client.Close)
+
+	op, err := client.Delete(c.ctx, &computepb.DeleteAddressRequest{
+		Project: projectID,
+		Region:  region,
+		Address: addressName,
+	})
+	if err != nil {
+		return TypedError(err)
+	}
+
+	return TypedError(op.Wait(c.ctx))
+}
+
+// matchesLabels reports whether have contains every label in want.
+func matchesLabels(have, want map[string]string) bool {
+	for key, value := range want {
+		if have[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// GetImage retrieves a specific image from the given project.
+func (c *GCPClient) GetImage(projectID, imageName string) (*computepb.Image, error) {
+	client, err := compute.NewImagesRESTClient(c.ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer util.IgnoreError(client.Close)
+
+	return client.Get(c.ctx, &computepb.GetImageRequest{
+		Project: projectID,
+		Image:   imageName,
+	})
+}
+
+// GetImageFromFamily retrieves the latest non-deprecated image of an image family.
+func (c *GCPClient) GetImageFromFamily(projectID, family string) (*computepb.Image, error) {
+	client, err := compute.NewImagesRESTClient(c.ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer util.IgnoreError(client.Close)
+
+	return client.GetFromFamily(c.ctx, &computepb.GetFromFamilyImageRequest{
+		Project: projectID,
+		Family:  family,
+	})
+}
+
+// StartInstance starts a stopped Compute Engine instance in the specified project and zone.
+func (c *GCPClient) StartInstance(projectID, zone, instanceName string) error {
+	audit.Log("gcp.start-instance", instanceName)
+
+	client, err := compute.NewInstancesRESTClient(c.ctx)
+	if err != nil {
+		return err
+	}
+	defer util.IgnoreError(client.Close)
+
+	op, err := client.Start(c.ctx, &computepb.StartInstanceRequest{
+		Project:  projectID,
+		Zone:     zone,
+		Instance: instanceName,
+	})
+	if err != nil {
+		return TypedError(err)
+	}
+
+	return TypedError(op.Wait(c.ctx))
+}
+
+// StopInstance stops a Compute Engine instance and waits for it to shut down.
+// Disks and static IPs stay around, so the instance can be started again later.
+func (c *GCPClient) StopInstance(projectID, zone, instanceName string) error {
+	audit.Log("gcp.stop-instance", instanceName)
+
+	client, err := compute.NewInstancesRESTClient(c.ctx)
+	if err != nil {
+		return err
+	}
+	defer util.IgnoreError(client.Close)
+
+	op, err := client.Stop(c.ctx, &computepb.StopInstanceRequest{
+		Project:  projectID,
+		Zone:     zone,
+		Instance: instanceName,
+	})
+	if err != nil {
+		return TypedError(err)
+	}
+
+	return TypedError(op.Wait(c.ctx))
+}
+
+// CreateAddress creates a new static IP address in the specified project and region.
+func (c *GCPClient) CreateAddress(projectID, region string, address *computepb.Address) (string, error) {
+	audit.Log("gcp.create-address", address.GetName())
+
+	client, err := compute.NewAddressesRESTClient(c.ctx)
+	if err != nil {
+		return "", err
+	}
+	defer util.IgnoreError(client.Close)
+
+	op, err := client.Insert(c.ctx, &computepb.InsertAddressRequest{
+		Project:         projectID,
+		Region:          region,
+		AddressResource: address,
+	})
+	if err != nil {
+		return "", TypedError(err)
+	}
+	if err = op.Wait(c.ctx); err != nil {
+		return "", TypedError(err)
+	}
+
+	// Fetch the created address to get the IP
+	createdAddress, err := client.Get(c.ctx, &computepb.GetAddressRequest{
+		Project: projectID,
+		Region:  region,
+		Address: *address.Name,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return *createdAddress.Address, nil
+}
+
+// GetAddress retrieves a static IP address by its name in the specified project and region.
+func (c *GCPClient) GetAddress(projectID, region, addressName string) (*computepb.Address, error) {
+	client, err := compute.NewAddressesRESTClient(c.ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer util.IgnoreError(client.Close)
+
+	address, err := client.Get(c.ctx, &computepb.GetAddressRequest{
+		Project: projectID,
+		Region:  region,
+		Address: addressName,
+	})
+	return address, TypedError(err)
+}
+
+// SearchProjectsByLabels returns all active projects carrying the given labels,
+// optionally restricted to a folder. It is used to discover OMS-managed
+// environments by their label set.
+func (c *GCPClient) SearchProjectsByLabels(folderID string, labels map[string]string) ([]*resourcemanagerpb.Project, error) {
+	client, err := resourcemanager.NewProjectsClient(c.ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer util.IgnoreError(client.Close)
+
+	queryParts := []string{"state:ACTIVE"}
+	if folderID != "" {
+		queryParts = append(queryParts, fmt.Sprintf("parent:folders/%s", folderID))
+	}
+	for key, value := range labels {
+		queryParts = append(queryParts, fmt.Sprintf("labels.%s:%s", key, value))
+	}
+
+	req := &resourcemanagerpb.SearchProjectsRequest{
+		Query: strings.Join(queryParts, " "),
+	}
+
+	var projects []*resourcemanagerpb.Project
+	it := client.SearchProjects(c.ctx, req)
+	for {
+		project, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error searching projects: %w", err)
+		}
+		projects = append(projects, project)
+	}
+
+	return projects, nil
+}
+
+// EnsureDNSManagedZone ensures that a DNS managed zone exists in the specified
+// project, enabling DNSSEC signing when requested.
+func (c *GCPClient) EnsureDNSManagedZone(projectID, zoneName, dnsName, description string, labels map[string]string, dnssec bool) error {
+	audit.Log("gcp.ensure-dns-zone", zoneName)
+
 	service, err := dns.NewService(c.ctx)
 	if err != nil {
 		return fmt.Errorf("failed to create DNS service: %w", err)
 	}
 
 	// Check if zone exists
-	_, err = service.ManagedZones.Get(projectID, zoneName).Context(c.ctx).Do()
+	existing, err := service.ManagedZones.Get(projectID, zoneName).Context(c.ctx).Do()
 	if err == nil {
-		// Zone exists
+		// Zone exists; turn on DNSSEC if it is requested but not active yet.
+		if dnssec && (existing.DnssecConfig == nil || existing.DnssecConfig.State != "on") {
+			patch := &dns.ManagedZone{
+				DnssecConfig: &dns.ManagedZoneDnsSecConfig{State: "on"},
+			}
+			_, err = service.ManagedZones.Patch(projectID, zoneName, patch).Context(c.ctx).Do()
+			if err != nil {
+				return fmt.Errorf("failed to enable DNSSEC on zone %s: %w", zoneName, err)
+			}
+		}
 		return nil
 	}
 
@@ -782,6 +1472,10 @@ func (c *GCPClient) EnsureDNSManagedZone(projectID, zoneName, dnsName, descripti
 		Name:        zoneName,
 		DnsName:     dnsName,
 		Description: description,
+		Labels:      labels,
+	}
+	if dnssec {
+		zone.DnssecConfig = &dns.ManagedZoneDnsSecConfig{State: "on"}
 	}
 	_, err = service.ManagedZones.Create(projectID, zone).Context(c.ctx).Do()
 	if err != nil {
@@ -791,8 +1485,60 @@ func (c *GCPClient) EnsureDNSManagedZone(projectID, zoneName, dnsName, descripti
 	return nil
 }
 
+// dnssecAlgorithmNumbers maps Cloud DNS algorithm names to the numbers used
+// in DS records (RFC 8624).
+var dnssecAlgorithmNumbers = map[string]int{
+	"rsasha1":         5,
+	"rsasha256":       8,
+	"rsasha512":       10,
+	"ecdsap256sha256": 13,
+	"ecdsap384sha384": 14,
+}
+
+// dnssecDigestTypeNumbers maps Cloud DNS digest type names to DS record
+// digest type numbers.
+var dnssecDigestTypeNumbers = map[string]int{
+	"sha1":   1,
+	"sha256": 2,
+	"sha384": 4,
+}
+
+// GetDNSSECDSRecord returns the DS record for the zone's active key signing
+// key, which must be published at the parent zone's registrar.
+func (c *GCPClient) GetDNSSECDSRecord(projectID, zoneName string) (string, error) {
+	service, err := dns.NewService(c.ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to create DNS service: %w", err)
+	}
+
+	keys, err := service.DnsKeys.List(projectID, zoneName).Context(c.ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("failed to list DNSSEC keys for zone %s: %w", zoneName, err)
+	}
+
+	for _, key := range keys.DnsKeys {
+		if key.Type != "keySigning" || !key.IsActive || len(key.Digests) == 0 {
+			continue
+		}
+		algorithm, ok := dnssecAlgorithmNumbers[key.Algorithm]
+		if !ok {
+			return "", fmt.Errorf("unknown DNSSEC algorithm %q", key.Algorithm)
+		}
+		digest := key.Digests[0]
+		digestType, ok := dnssecDigestTypeNumbers[digest.Type]
+		if !ok {
+			return "", fmt.Errorf("unknown DNSSEC digest type %q", digest.Type)
+		}
+		return fmt.Sprintf("%d %d %d %s", key.KeyTag, algorithm, digestType, digest.Digest), nil
+	}
+
+	return "", fmt.Errorf("no active key signing key found for zone %s", zoneName)
+}
+
 // EnsureDNSRecordSets ensures that the specified DNS record sets exist in the given managed zone.
 func (c *GCPClient) EnsureDNSRecordSets(projectID, zoneName string, records []*dns.ResourceRecordSet) error {
+	audit.Log("gcp.ensure-dns-records", zoneName)
+
 	service, err := dns.NewService(c.ctx)
 	if err != nil {
 		return fmt.Errorf("failed to create DNS service: %w", err)
@@ -830,6 +1576,8 @@ func (c *GCPClient) EnsureDNSRecordSets(projectID, zoneName string, records []*d
 
 // DeleteDNSRecordSets deletes DNS record sets created by OMS for the given base domain.
 func (c *GCPClient) DeleteDNSRecordSets(projectID, zoneName, baseDomain string) error {
+	audit.Log("gcp.delete-dns-records", zoneName, baseDomain)
+
 	service, err := dns.NewService(c.ctx)
 	if err != nil {
 		return fmt.Errorf("failed to create DNS service: %w", err)
@@ -857,11 +1605,326 @@ func (c *GCPClient) DeleteDNSRecordSets(projectID, zoneName, baseDomain string)
 	return nil
 }
 
+// GetDNSRecordSet returns the record set with the given name and type from
+// the managed zone, or nil when it does not exist.
+func (c *GCPClient) GetDNSRecordSet(projectID, zoneName, name, rtype string) (*dns.ResourceRecordSet, error) {
+	service, err := dns.NewService(c.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create DNS service: %w", err)
+	}
+
+	record, err := service.ResourceRecordSets.Get(projectID, zoneName, name, rtype).Context(c.ctx).Do()
+	if IsNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS record %s: %w", name, err)
+	}
+	return record, nil
+}
+
+// GetDNSManagedZone returns the managed zone, or nil when it does not exist.
+func (c *GCPClient) GetDNSManagedZone(projectID, zoneName string) (*dns.ManagedZone, error) {
+	service, err := dns.NewService(c.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create DNS service: %w", err)
+	}
+
+	zone, err := service.ManagedZones.Get(projectID, zoneName).Context(c.ctx).Do()
+	if IsNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS zone %s: %w", zoneName, err)
+	}
+	return zone, nil
+}
+
+// DeleteDNSManagedZone deletes the managed zone. The zone must not contain
+// any record sets besides its SOA and NS records.
+func (c *GCPClient) DeleteDNSManagedZone(projectID, zoneName string) error {
+	audit.Log("gcp.delete-dns-zone", zoneName)
+
+	service, err := dns.NewService(c.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create DNS service: %w", err)
+	}
+
+	if err := service.ManagedZones.Delete(projectID, zoneName).Context(c.ctx).Do(); err != nil {
+		return fmt.Errorf("failed to delete DNS zone %s: %w", zoneName, err)
+	}
+	return nil
+}
+
+// EnsureGCSBucket ensures the bucket exists with a lifecycle rule deleting
+// objects older than retentionDays. The retention of an existing bucket is
+// converged, so changed retention flags take effect on re-runs.
+func (c *GCPClient) EnsureGCSBucket(projectID, bucketName, location string, labels map[string]string, retentionDays int64) error {
+	service, err := storage.NewService(c.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create storage service: %w", err)
+	}
+
+	lifecycle := &storage.BucketLifecycle{
+		Rule: []*storage.BucketLifecycleRule{{
+			Action:    &storage.BucketLifecycleRuleAction{Type: "Delete"},
+			Condition: &storage.BucketLifecycleRuleCondition{Age: googleapi.Int64(retentionDays)},
+		}},
+	}
+
+	_, err = service.Buckets.Get(bucketName).Context(c.ctx).Do()
+	if err == nil {
+		_, err = service.Buckets.Patch(bucketName, &storage.Bucket{Lifecycle: lifecycle}).Context(c.ctx).Do()
+		if err != nil {
+			return fmt.Errorf("failed to update lifecycle of bucket %s: %w", bucketName, err)
+		}
+		return nil
+	}
+	if !IsNotFoundError(err) {
+		return fmt.Errorf("failed to get bucket %s: %w", bucketName, err)
+	}
+
+	audit.Log("gcp.create-gcs-bucket", bucketName)
+	bucket := &storage.Bucket{
+		Name:      bucketName,
+		Location:  location,
+		Labels:    labels,
+		Lifecycle: lifecycle,
+		IamConfiguration: &storage.BucketIamConfiguration{
+			UniformBucketLevelAccess: &storage.BucketIamConfigurationUniformBucketLevelAccess{Enabled: true},
+		},
+	}
+	_, err = service.Buckets.Insert(projectID, bucket).Context(c.ctx).Do()
+	if err != nil {
+		return fmt.Errorf("failed to create bucket %s: %w", bucketName, err)
+	}
+	return nil
+}
+
+// GrantBucketIAMRole adds the member to the role binding on the bucket's IAM
+// policy; a member that already holds the role is left untouched.
+func (c *GCPClient) GrantBucketIAMRole(bucketName, member, role string) error {
+	service, err := storage.NewService(c.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create storage service: %w", err)
+	}
+
+	policy, err := service.Buckets.GetIamPolicy(bucketName).Context(c.ctx).Do()
+	if err != nil {
+		return fmt.Errorf("failed to get IAM policy of bucket %s: %w", bucketName, err)
+	}
+
+	bound := false
+	for _, binding := range policy.Bindings {
+		if binding.Role != role {
+			continue
+		}
+		if slices.Contains(binding.Members, member) {
+			return nil
+		}
+		binding.Members = append(binding.Members, member)
+		bound = true
+	}
+	if !bound {
+		policy.Bindings = append(policy.Bindings, &storage.PolicyBindings{Role: role, Members: []string{member}})
+	}
+
+	audit.Log("gcp.grant-bucket-iam-role", bucketName, member, role)
+	_, err = service.Buckets.SetIamPolicy(bucketName, policy).Context(c.ctx).Do()
+	if err != nil {
+		return fmt.Errorf("failed to set IAM policy of bucket %s: %w", bucketName, err)
+	}
+	return nil
+}
+
+// privateServiceRangeName is the allocated IP range peered with Google's
+// service producer network, so Cloud SQL instances get a private IP in the VPC.
+const privateServiceRangeName = "google-managed-services"
+
+// EnsurePrivateServiceAccess ensures the VPC has an allocated IP range and a
+// service networking peering connection for Google-managed services. Both are
+// prerequisites for private-IP Cloud SQL instances.
+func (c *GCPClient) EnsurePrivateServiceAccess(projectID, networkName string) error {
+	audit.Log("gcp.ensure-private-service-access", networkName)
+
+	network := fmt.Sprintf("projects/%s/global/networks/%s", projectID, networkName)
+
+	client, err := compute.NewGlobalAddressesRESTClient(c.ctx)
+	if err != nil {
+		return err
+	}
+	defer util.IgnoreError(client.Close)
+
+	_, err = client.Get(c.ctx, &computepb.GetGlobalAddressRequest{
+		Project: projectID,
+		Address: privateServiceRangeName,
+	})
+	if IsNotFoundError(err) {
+		op, err := client.Insert(c.ctx, &computepb.InsertGlobalAddressRequest{
+			Project: projectID,
+			AddressResource: &computepb.Address{
+				Name:         protoString(privateServiceRangeName),
+				Purpose:      protoString("VPC_PEERING"),
+				AddressType:  protoString("INTERNAL"),
+				PrefixLength: protoInt32(16),
+				Network:      protoString(network),
+			},
+		})
+		if err != nil {
+			return TypedError(err)
+		}
+		if err = op.Wait(c.ctx); err != nil {
+			return TypedError(err)
+		}
+	} else if err != nil {
+		return TypedError(err)
+	}
+
+	service, err := servicenetworking.NewService(c.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create service networking service: %w", err)
+	}
+
+	parent := "services/servicenetworking.googleapis.com"
+	list, err := service.Services.Connections.List(parent).Network(network).Context(c.ctx).Do()
+	if err != nil {
+		return fmt.Errorf("failed to list service networking connections: %w", err)
+	}
+	for _, conn := range list.Connections {
+		if slices.Contains(conn.ReservedPeeringRanges, privateServiceRangeName) {
+			return nil
+		}
+	}
+
+	op, err := service.Services.Connections.Create(parent, &servicenetworking.Connection{
+		Network:               network,
+		ReservedPeeringRanges: []string{privateServiceRangeName},
+	}).Context(c.ctx).Do()
+	if err != nil {
+		return fmt.Errorf("failed to create service networking connection: %w", err)
+	}
+
+	return c.waitForServiceNetworkingOp(service, op.Name)
+}
+
+// waitForServiceNetworkingOp polls a service networking operation until it
+// completes; these are not gRPC long-running operations with a Wait helper.
+func (c *GCPClient) waitForServiceNetworkingOp(service *servicenetworking.APIService, name string) error {
+	for attempt := 0; attempt < 60; attempt++ {
+		op, err := service.Operations.Get(name).Context(c.ctx).Do()
+		if err != nil {
+			return fmt.Errorf("failed to get service networking operation %s: %w", name, err)
+		}
+		if op.Done {
+			if op.Error != nil {
+				return fmt.Errorf("service networking operation %s failed: %s", name, op.Error.Message)
+			}
+			return nil
+		}
+		c.retry.Time.Sleep(5 * time.Second)
+	}
+	return fmt.Errorf("timed out waiting for service networking operation %s", name)
+}
+
+// GetCloudSQLInstance returns the Cloud SQL instance, or nil when it does not exist.
+func (c *GCPClient) GetCloudSQLInstance(projectID, instanceName string) (*sqladmin.DatabaseInstance, error) {
+	service, err := sqladmin.NewService(c.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cloud SQL admin service: %w", err)
+	}
+
+	instance, err := service.Instances.Get(projectID, instanceName).Context(c.ctx).Do()
+	if IsNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Cloud SQL instance %s: %w", instanceName, err)
+	}
+	return instance, nil
+}
+
+// CreateCloudSQLInstance creates a Cloud SQL instance. Creation is
+// asynchronous; callers poll GetCloudSQLInstance until the state is RUNNABLE.
+func (c *GCPClient) CreateCloudSQLInstance(projectID string, instance *sqladmin.DatabaseInstance) error {
+	audit.Log("gcp.create-cloudsql-instance", instance.Name)
+
+	service, err := sqladmin.NewService(c.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Cloud SQL admin service: %w", err)
+	}
+
+	_, err = service.Instances.Insert(projectID, instance).Context(c.ctx).Do()
+	if err != nil {
+		return fmt.Errorf("failed to create Cloud SQL instance %s: %w", instance.Name, err)
+	}
+	return nil
+}
+
+// EnsureCloudSQLDatabase ensures a database exists on the Cloud SQL instance.
+func (c *GCPClient) EnsureCloudSQLDatabase(projectID, instanceName, database string) error {
+	service, err := sqladmin.NewService(c.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Cloud SQL admin service: %w", err)
+	}
+
+	_, err = service.Databases.Get(projectID, instanceName, database).Context(c.ctx).Do()
+	if err == nil {
+		return nil
+	}
+	if !IsNotFoundError(err) {
+		return fmt.Errorf("failed to get database %s on instance %s: %w", database, instanceName, err)
+	}
+
+	audit.Log("gcp.create-cloudsql-database", database)
+	_, err = service.Databases.Insert(projectID, instanceName, &sqladmin.Database{
+		Name: database,
+	}).Context(c.ctx).Do()
+	if err != nil {
+		return fmt.Errorf("failed to create database %s on instance %s: %w", database, instanceName, err)
+	}
+	return nil
+}
+
+// EnsureCloudSQLUser creates a user on the Cloud SQL instance or updates its
+// password when the user already exists, so re-runs converge on the vault state.
+func (c *GCPClient) EnsureCloudSQLUser(projectID, instanceName, name, password string) error {
+	audit.Log("gcp.ensure-cloudsql-user", name)
+
+	service, err := sqladmin.NewService(c.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Cloud SQL admin service: %w", err)
+	}
+
+	user := &sqladmin.User{Name: name, Password: password}
+
+	existing, err := service.Users.List(projectID, instanceName).Context(c.ctx).Do()
+	if err != nil {
+		return fmt.Errorf("failed to list users on instance %s: %w", instanceName, err)
+	}
+	for _, u := range existing.Items {
+		if u.Name == name {
+			_, err = service.Users.Update(projectID, instanceName, user).Name(name).Context(c.ctx).Do()
+			if err != nil {
+				return fmt.Errorf("failed to update user %s on instance %s: %w", name, instanceName, err)
+			}
+			return nil
+		}
+	}
+
+	_, err = service.Users.Insert(projectID, instanceName, user).Context(c.ctx).Do()
+	if err != nil {
+		return fmt.Errorf("failed to create user %s on instance %s: %w", name, instanceName, err)
+	}
+	return nil
+}
+
 // CreatePublicCAExternalAccountKey requests a fresh External Account Binding
 // key pair from the Google Public CA, used to register an ACME account against
 // https://dv.acme-v02.api.pki.goog/directory. The publicca.googleapis.com API
 // must be enabled on the project.
 func (c *GCPClient) CreatePublicCAExternalAccountKey(projectID string) (string, string, error) {
+	audit.Log("gcp.create-public-ca-eab-key", projectID)
+
 	svc, err := publicca.NewService(c.ctx)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to create publicca client: %w", err)
@@ -874,6 +1937,84 @@ func (c *GCPClient) CreatePublicCAExternalAccountKey(projectID string) (string,
 	return key.KeyId, key.B64MacKey, nil
 }
 
+// EnsureKMSKey creates a Cloud KMS key ring and symmetric encryption key if
+// they do not exist yet and returns the full key resource name.
+func (c *GCPClient) EnsureKMSKey(projectID, location, keyRingID, keyID string) (string, error) {
+	audit.Log("gcp.ensure-kms-key", keyID)
+
+	client, err := kms.NewKeyManagementClient(c.ctx)
+	if err != nil {
+		return "", err
+	}
+	defer util.IgnoreError(client.Close)
+
+	parent := fmt.Sprintf("projects/%s/locations/%s", projectID, location)
+	_, err = client.CreateKeyRing(c.ctx, &kmspb.CreateKeyRingRequest{
+		Parent:    parent,
+		KeyRingId: keyRingID,
+	})
+	if err != nil && status.Code(err) != codes.AlreadyExists {
+		return "", fmt.Errorf("failed to create key ring: %w", err)
+	}
+
+	keyRingName := fmt.Sprintf("%s/keyRings/%s", parent, keyRingID)
+	key, err := client.CreateCryptoKey(c.ctx, &kmspb.CreateCryptoKeyRequest{
+		Parent:      keyRingName,
+		CryptoKeyId: keyID,
+		CryptoKey: &kmspb.CryptoKey{
+			Purpose: kmspb.CryptoKey_ENCRYPT_DECRYPT,
+		},
+	})
+	if err != nil {
+		if status.Code(err) != codes.AlreadyExists {
+			return "", fmt.Errorf("failed to create crypto key: %w", err)
+		}
+		return fmt.Sprintf("%s/cryptoKeys/%s", keyRingName, keyID), nil
+	}
+
+	return key.GetName(), nil
+}
+
+// DestroyKMSKeyVersions schedules every enabled or disabled version of the
+// given crypto key for destruction and returns the names of the affected
+// versions. Once all versions are destroyed, data encrypted with the key is
+// unrecoverable.
+func (c *GCPClient) DestroyKMSKeyVersions(keyName string) ([]string, error) {
+	audit.Log("gcp.destroy-kms-key-versions", keyName)
+
+	client, err := kms.NewKeyManagementClient(c.ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer util.IgnoreError(client.Close)
+
+	it := client.ListCryptoKeyVersions(c.ctx, &kmspb.ListCryptoKeyVersionsRequest{Parent: keyName})
+
+	var destroyed []string
+	for {
+		version, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return destroyed, fmt.Errorf("error iterating key versions: %w", err)
+		}
+
+		state := version.GetState()
+		if state != kmspb.CryptoKeyVersion_ENABLED && state != kmspb.CryptoKeyVersion_DISABLED {
+			continue
+		}
+
+		_, err = client.DestroyCryptoKeyVersion(c.ctx, &kmspb.DestroyCryptoKeyVersionRequest{Name: version.GetName()})
+		if err != nil {
+			return destroyed, fmt.Errorf("failed to destroy key version %s: %w", version.GetName(), err)
+		}
+		destroyed = append(destroyed, version.GetName())
+	}
+
+	return destroyed, nil
+}
+
 // Helper functions
 func protoString(s string) *string { return &s }
 func protoBool(b bool) *bool       { return &b }
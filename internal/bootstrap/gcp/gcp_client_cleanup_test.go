@@ -103,7 +103,7 @@ var _ = Describe("GCP Client Cleanup Methods", func() {
 				baseDomain := "example.com"
 				records := gcp.GetDNSRecordNames(baseDomain)
 
-				Expect(records).To(HaveLen(5))
+				Expect(records).To(HaveLen(6))
 				Expect(records[0].Name).To(Equal("cs.example.com."))
 				Expect(records[0].Rtype).To(Equal("A"))
 				Expect(records[1].Name).To(Equal("*.cs.example.com."))
@@ -114,6 +114,8 @@ var _ = Describe("GCP Client Cleanup Methods", func() {
 				Expect(records[3].Rtype).To(Equal("A"))
 				Expect(records[4].Name).To(Equal("*.ssh.cs.example.com."))
 				Expect(records[4].Rtype).To(Equal("A"))
+				Expect(records[5].Name).To(Equal("_oms-owner.cs.example.com."))
+				Expect(records[5].Rtype).To(Equal("TXT"))
 			})
 		})
 
@@ -122,21 +124,21 @@ var _ = Describe("GCP Client Cleanup Methods", func() {
 				baseDomain := "internal.codesphere.com"
 				records := gcp.GetDNSRecordNames(baseDomain)
 
-				Expect(records).To(HaveLen(5))
+				Expect(records).To(HaveLen(6))
 				for _, record := range records {
 					Expect(record.Name).To(ContainSubstring("internal.codesphere.com"))
 					Expect(record.Name).To(HaveSuffix("."))
-					Expect(record.Rtype).To(Equal("A"))
 				}
 			})
 		})
 
-		Context("when ensuring all records are A type", func() {
-			It("should only generate A records", func() {
+		Context("when ensuring record types", func() {
+			It("should generate A records plus the ownership TXT record", func() {
 				records := gcp.GetDNSRecordNames("test.com")
-				for _, record := range records {
+				for _, record := range records[:len(records)-1] {
 					Expect(record.Rtype).To(Equal("A"))
 				}
+				Expect(records[len(records)-1].Rtype).To(Equal("TXT"))
 			})
 		})
 
@@ -7,19 +7,26 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"cloud.google.com/go/artifactregistry/apiv1/artifactregistrypb"
 	"cloud.google.com/go/compute/apiv1/computepb"
 	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	dnsprovider "github.com/codesphere-cloud/oms/internal/bootstrap/dns"
 	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/cloudflare"
 	"github.com/codesphere-cloud/oms/internal/env"
 	"github.com/codesphere-cloud/oms/internal/github"
 	"github.com/codesphere-cloud/oms/internal/installer"
 	"github.com/codesphere-cloud/oms/internal/installer/files"
 	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/codesphere-cloud/oms/internal/installer/vault"
 	"github.com/codesphere-cloud/oms/internal/portal"
 	"github.com/codesphere-cloud/oms/internal/util"
+	gh "github.com/google/go-github/v74/github"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
@@ -44,6 +51,7 @@ var _ = Describe("GCP Bootstrapper", func() {
 		stlog            *bootstrap.StepLogger
 		mockPortalClient *portal.MockPortal
 		mockGitHubClient *github.MockGitHubClient
+		cfc              *cloudflare.MockCloudflareClient
 
 		bs *gcp.GCPBootstrapper
 	)
@@ -62,6 +70,7 @@ var _ = Describe("GCP Bootstrapper", func() {
 			mockPortalClient,
 			util.NewFakeTime(),
 			mockGitHubClient,
+			cfc,
 		)
 		Expect(err).NotTo(HaveOccurred())
 	})
@@ -75,6 +84,7 @@ var _ = Describe("GCP Bootstrapper", func() {
 		fw = util.NewMockFileIO(GinkgoT())
 		mockPortalClient = portal.NewMockPortal(GinkgoT())
 		mockGitHubClient = github.NewMockGitHubClient(GinkgoT())
+		cfc = cloudflare.NewMockCloudflareClient(GinkgoT())
 		stlog = bootstrap.NewStepLogger(false)
 
 		csEnv = &gcp.CodesphereEnvironment{
@@ -147,6 +157,612 @@ var _ = Describe("GCP Bootstrapper", func() {
 		})
 	})
 
+	Describe("ValidateInput postgres mode", func() {
+		It("accepts the cloudsql mode", func() {
+			csEnv.PostgresMode = gcp.PostgresModeCloudSQL
+			csEnv.RegistryType = gcp.RegistryTypeArtifactRegistry
+
+			Expect(bs.ValidateInput()).To(Succeed())
+		})
+
+		It("rejects an unknown postgres mode", func() {
+			csEnv.PostgresMode = "rds"
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("invalid postgres mode")))
+		})
+
+		It("rejects combining cloudsql with HA postgres", func() {
+			csEnv.PostgresMode = gcp.PostgresModeCloudSQL
+			csEnv.RegistryType = gcp.RegistryTypeArtifactRegistry
+			csEnv.HAPostgres = true
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("--ha-postgres cannot be combined with --postgres-mode cloudsql")))
+		})
+
+		It("rejects combining cloudsql with the local container registry", func() {
+			csEnv.PostgresMode = gcp.PostgresModeCloudSQL
+			csEnv.RegistryType = gcp.RegistryTypeLocalContainer
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("local-container cannot be combined with --postgres-mode cloudsql")))
+		})
+
+		It("rejects combining cloudsql with postgres backups", func() {
+			csEnv.PostgresMode = gcp.PostgresModeCloudSQL
+			csEnv.RegistryType = gcp.RegistryTypeArtifactRegistry
+			csEnv.PGBackups = true
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("--pg-backups requires a postgres VM")))
+		})
+
+		It("declares the backup job when postgres backups are enabled", func() {
+			csEnv.PGBackups = true
+			csEnv.PGBackupSchedule = "daily"
+
+			Expect(bs.ValidateInput()).To(Succeed())
+			Expect(csEnv.ScheduledJobs).To(ContainElement(gcp.PGBackupJob("daily", "pid-pg-backups")))
+		})
+	})
+
+	Describe("ValidateInput storage mode", func() {
+		It("accepts an external Ceph cluster with mon hosts and keyring", func() {
+			csEnv.StorageMode = gcp.StorageModeExternal
+			csEnv.CephMonHosts = []string{"10.0.0.10:6789"}
+			csEnv.CephKeyringFile = "fake-keyring"
+			fw.EXPECT().Exists("fake-keyring").Return(true)
+
+			Expect(bs.ValidateInput()).To(Succeed())
+		})
+
+		It("rejects an unknown storage mode", func() {
+			csEnv.StorageMode = "nfs"
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("invalid storage mode")))
+		})
+
+		It("rejects external storage without mon hosts", func() {
+			csEnv.StorageMode = gcp.StorageModeExternal
+			csEnv.CephKeyringFile = "fake-keyring"
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("requires --ceph-mon-hosts")))
+		})
+
+		It("rejects external storage without a keyring file", func() {
+			csEnv.StorageMode = gcp.StorageModeExternal
+			csEnv.CephMonHosts = []string{"10.0.0.10:6789"}
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("requires --ceph-keyring-file")))
+		})
+
+		It("rejects a missing keyring file", func() {
+			csEnv.StorageMode = gcp.StorageModeExternal
+			csEnv.CephMonHosts = []string{"10.0.0.10:6789"}
+			csEnv.CephKeyringFile = "fake-keyring"
+			fw.EXPECT().Exists("fake-keyring").Return(false)
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("does not exist")))
+		})
+	})
+
+	Describe("ValidateInput storage backend", func() {
+		It("accepts the longhorn backend", func() {
+			csEnv.StorageBackend = gcp.StorageBackendLonghorn
+
+			Expect(bs.ValidateInput()).To(Succeed())
+		})
+
+		It("rejects an unknown storage backend", func() {
+			csEnv.StorageBackend = "nfs"
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("invalid storage backend")))
+		})
+
+		It("rejects a non-Ceph backend combined with external storage mode", func() {
+			csEnv.StorageBackend = gcp.StorageBackendLocalPath
+			csEnv.StorageMode = gcp.StorageModeExternal
+			csEnv.CephMonHosts = []string{"10.0.0.10:6789"}
+			csEnv.CephKeyringFile = "fake-keyring"
+			fw.EXPECT().Exists("fake-keyring").Return(true).Maybe()
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("requires the ceph storage backend")))
+		})
+	})
+
+	Describe("ValidateInput topology", func() {
+		It("accepts a dedicated control plane with a worker pool", func() {
+			csEnv.DedicatedControlPlane = true
+			csEnv.WorkerCount = 2
+
+			Expect(bs.ValidateInput()).To(Succeed())
+		})
+
+		It("rejects a dedicated control plane without workers", func() {
+			csEnv.DedicatedControlPlane = true
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("requires at least one worker node")))
+		})
+
+		It("rejects worker pool options without a dedicated control plane", func() {
+			csEnv.WorkerCount = 2
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("--worker-count requires --dedicated-control-plane")))
+		})
+
+		It("rejects worker taints without a dedicated control plane", func() {
+			csEnv.WorkerTaints = []string{"pool=batch:NoSchedule"}
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("--worker-taints requires --dedicated-control-plane")))
+		})
+	})
+
+	Describe("ValidateInput artifact storage", func() {
+		It("accepts a GCS bucket for artifacts", func() {
+			csEnv.ArtifactsBucket = "fake-artifacts-bucket"
+
+			Expect(bs.ValidateInput()).To(Succeed())
+		})
+
+		It("rejects combining a bucket with an extra disk", func() {
+			csEnv.ArtifactsBucket = "fake-artifacts-bucket"
+			csEnv.ArtifactsDiskGB = 200
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("mutually exclusive")))
+		})
+
+		It("rejects an artifact disk without a jumpbox", func() {
+			csEnv.NoJumpbox = true
+			csEnv.UseIAPTunnel = true
+			csEnv.ArtifactsDiskGB = 200
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("requires a jumpbox")))
+		})
+	})
+
+	Describe("ValidateInput kubernetes distribution", func() {
+		It("accepts a supported distribution", func() {
+			csEnv.K8sDistribution = gcp.K8sDistributionRKE2
+
+			Expect(bs.ValidateInput()).To(Succeed())
+		})
+
+		It("rejects an unknown distribution", func() {
+			csEnv.K8sDistribution = "microk8s"
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("invalid kubernetes distribution")))
+		})
+	})
+
+	Describe("ValidateInput BYO kubernetes", func() {
+		BeforeEach(func() {
+			csEnv.KubeconfigPath = "~/.kube/config"
+		})
+
+		It("accepts a plain BYO environment", func() {
+			Expect(bs.ValidateInput()).To(Succeed())
+		})
+
+		It("rejects worker pool options", func() {
+			csEnv.WorkerCount = 3
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("cannot be combined with worker pool options")))
+		})
+
+		It("rejects a distribution choice", func() {
+			csEnv.K8sDistribution = gcp.K8sDistributionK3s
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("cannot be combined with --k8s-distribution")))
+		})
+
+		It("rejects --no-jumpbox", func() {
+			csEnv.NoJumpbox = true
+			csEnv.UseIAPTunnel = true
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("requires a jumpbox")))
+		})
+	})
+
+	Describe("ValidateInput kubernetes version", func() {
+		It("accepts a pinned k0s release", func() {
+			csEnv.KubernetesVersion = "v1.33.2+k0s.0"
+
+			Expect(bs.ValidateInput()).To(Succeed())
+		})
+
+		It("rejects a pin for other distributions", func() {
+			csEnv.K8sDistribution = gcp.K8sDistributionK3s
+			csEnv.KubernetesVersion = "v1.33.2+k0s.0"
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("only supported for k0s environments")))
+		})
+
+		It("rejects a version that is not a k0s release", func() {
+			csEnv.KubernetesVersion = "1.33.2"
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("invalid kubernetes version")))
+		})
+	})
+
+	Describe("ValidateInput ceph layout", func() {
+		It("accepts a custom OSD layout", func() {
+			csEnv.CephDataDiskGB = 200
+			csEnv.CephDBDiskGB = 20
+			csEnv.CephOSDsPerHost = 2
+			csEnv.CephReplicaCount = 3
+			csEnv.CephFailureDomain = "host"
+
+			Expect(bs.ValidateInput()).To(Succeed())
+		})
+
+		It("rejects a DB disk at or above half the data disk size", func() {
+			csEnv.CephDataDiskGB = 100
+			csEnv.CephDBDiskGB = 50
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("smaller than half")))
+		})
+
+		It("rejects an unknown failure domain", func() {
+			csEnv.CephFailureDomain = "rack"
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("invalid ceph failure domain")))
+		})
+
+		It("rejects a replica count above the host count for the host failure domain", func() {
+			csEnv.CephReplicaCount = 4
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("must not exceed the number of ceph hosts")))
+		})
+
+		It("allows more replicas than hosts for the osd failure domain", func() {
+			csEnv.CephReplicaCount = 4
+			csEnv.CephOSDsPerHost = 2
+			csEnv.CephFailureDomain = "osd"
+
+			Expect(bs.ValidateInput()).To(Succeed())
+		})
+	})
+
+	Describe("ValidateInput default flags", func() {
+		BeforeEach(func() {
+			csEnv.InternalFlags = nil
+			csEnv.PreviewFlags = nil
+			csEnv.FeatureFlags = nil
+		})
+
+		It("fills unset flag buckets from the built-in defaults", func() {
+			Expect(bs.ValidateInput()).To(Succeed())
+
+			Expect(csEnv.InternalFlags).To(Equal(gcp.DefaultInternalFlags))
+			Expect(csEnv.PreviewFlags).To(Equal(gcp.DefaultPreviewFlags))
+			Expect(csEnv.FeatureFlags).To(Equal(gcp.DefaultFeatureFlags))
+		})
+
+		It("keeps user-provided flags and only fills the unset buckets", func() {
+			csEnv.InternalFlags = []string{"custom-flag"}
+
+			Expect(bs.ValidateInput()).To(Succeed())
+
+			Expect(csEnv.InternalFlags).To(Equal([]string{"custom-flag"}))
+			Expect(csEnv.PreviewFlags).To(Equal(gcp.DefaultPreviewFlags))
+		})
+
+		It("resolves the built-in defaults for unparseable versions", func() {
+			flags := gcp.DefaultFlagsForVersion("local-build")
+
+			Expect(flags.Internal).To(Equal(gcp.DefaultInternalFlags))
+			Expect(flags.Preview).To(Equal(gcp.DefaultPreviewFlags))
+		})
+
+		Context("when a version is installed", func() {
+			BeforeEach(func() {
+				csEnv.InstallVersion = "v1.2.3"
+			})
+
+			JustBeforeEach(func() {
+				mockPortalClient.EXPECT().GetBuild(portal.CodesphereProduct, "v1.2.3", "").Return(portal.Build{
+					Artifacts: []portal.Artifact{{Filename: "installer.tar.gz"}},
+					Hash:      "abc123",
+					Version:   "v1.2.3",
+				}, nil)
+			})
+
+			It("prefers the release metadata published by the portal", func() {
+				mockPortalClient.EXPECT().GetDefaultFlags("v1.2.3").Return(portal.DefaultFlags{
+					Internal: []string{"portal-internal"},
+					Preview:  []string{"portal-preview"},
+				}, nil)
+
+				Expect(bs.ValidateInput()).To(Succeed())
+
+				Expect(csEnv.InternalFlags).To(Equal([]string{"portal-internal"}))
+				Expect(csEnv.PreviewFlags).To(Equal([]string{"portal-preview"}))
+			})
+
+			It("falls back to the built-in defaults when the portal has no metadata", func() {
+				mockPortalClient.EXPECT().GetDefaultFlags("v1.2.3").Return(portal.DefaultFlags{}, fmt.Errorf("fake error"))
+
+				Expect(bs.ValidateInput()).To(Succeed())
+
+				Expect(csEnv.InternalFlags).To(Equal(gcp.DefaultInternalFlags))
+				Expect(csEnv.PreviewFlags).To(Equal(gcp.DefaultPreviewFlags))
+			})
+		})
+	})
+
+	Describe("ValidateInput DNS parameters", func() {
+		It("rejects a negative record TTL", func() {
+			csEnv.DNSRecordTTL = -1
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("DNS record TTL must not be negative")))
+		})
+
+		It("rejects apex records for a single-label base domain", func() {
+			csEnv.ApexDomainRecords = true
+			csEnv.BaseDomain = "localdomain"
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("apex domain records require a registrable base domain")))
+		})
+
+		It("accepts apex records for a registrable base domain", func() {
+			csEnv.ApexDomainRecords = true
+
+			Expect(bs.ValidateInput()).To(Succeed())
+		})
+
+		It("rejects an unknown DNS provider", func() {
+			csEnv.DNSProvider = "azure"
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("unknown DNS provider")))
+		})
+
+		It("accepts the route53 DNS provider", func() {
+			csEnv.DNSProvider = dnsprovider.ProviderRoute53
+
+			Expect(bs.ValidateInput()).To(Succeed())
+		})
+
+		It("rejects DNSSEC with the route53 provider", func() {
+			csEnv.DNSSEC = true
+			csEnv.DNSProvider = dnsprovider.ProviderRoute53
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("DNSSEC is only supported with the clouddns DNS provider")))
+		})
+
+		It("rejects DNSSEC in manual DNS mode", func() {
+			csEnv.DNSSEC = true
+			csEnv.DNSMode = gcp.DNSModeManual
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("DNSSEC requires an OMS-managed Cloud DNS zone")))
+		})
+
+		It("rejects an unknown DNS mode", func() {
+			csEnv.DNSMode = "auto"
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("unknown DNS mode")))
+		})
+
+		It("rejects manual DNS mode combined with Cloudflare", func() {
+			csEnv.DNSMode = gcp.DNSModeManual
+			csEnv.UseCloudflare = true
+			csEnv.CloudflareAPIToken = "token"
+			csEnv.CloudflareZoneID = "zone"
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("cannot be combined with Cloudflare")))
+		})
+	})
+
+	Describe("ValidateInput disk encryption key", func() {
+		It("rejects a key that is not a full resource name", func() {
+			csEnv.DiskEncryptionKey = "my-key"
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("disk encryption key must be a full Cloud KMS key resource name")))
+		})
+
+		It("accepts a fully qualified key", func() {
+			csEnv.DiskEncryptionKey = "projects/p/locations/l/keyRings/r/cryptoKeys/k"
+
+			Expect(bs.ValidateInput()).To(Succeed())
+		})
+	})
+
+	Describe("ValidateInput resource labels", func() {
+		It("accepts valid labels", func() {
+			csEnv.ResourceLabels = map[string]string{"owner": "team-a", "cost-center": "123"}
+
+			Expect(bs.ValidateInput()).To(Succeed())
+		})
+
+		It("rejects label keys with invalid characters", func() {
+			csEnv.ResourceLabels = map[string]string{"Owner": "team-a"}
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring(`invalid label key "Owner"`)))
+		})
+
+		It("rejects label values with invalid characters", func() {
+			csEnv.ResourceLabels = map[string]string{"owner": "Team A"}
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring(`invalid value "Team A" for label "owner"`)))
+		})
+
+		It("rejects overriding the oms-managed label", func() {
+			csEnv.ResourceLabels = map[string]string{"oms-managed": "false"}
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("reserved")))
+		})
+	})
+
+	Describe("ValidateInput Cloudflare parameters", func() {
+		BeforeEach(func() {
+			csEnv.UseCloudflare = true
+			csEnv.CloudflareZoneID = "zone-1"
+			csEnv.CloudflareAPIToken = "token"
+		})
+
+		It("accepts a complete Cloudflare configuration", func() {
+			Expect(bs.ValidateInput()).To(Succeed())
+		})
+
+		It("rejects Cloudflare mode without an API token", func() {
+			csEnv.CloudflareAPIToken = ""
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("cloudflare API token must be set")))
+		})
+
+		It("rejects Cloudflare mode without a zone ID", func() {
+			csEnv.CloudflareZoneID = ""
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("cloudflare zone ID must be set")))
+		})
+	})
+
+	Describe("EstimateCost", func() {
+		sku := func(description string, rate float64) *cloudbilling.Sku {
+			return &cloudbilling.Sku{
+				Description: description,
+				Category:    &cloudbilling.Category{UsageType: "OnDemand"},
+				PricingInfo: []*cloudbilling.PricingInfo{{
+					PricingExpression: &cloudbilling.PricingExpression{
+						TieredRates: []*cloudbilling.TierRate{{
+							UnitPrice: &cloudbilling.Money{Nanos: int64(rate * 1e9), CurrencyCode: "USD"},
+						}},
+					},
+				}},
+			}
+		}
+
+		BeforeEach(func() {
+			csEnv.RootDiskSize = 50
+		})
+
+		It("computes monthly costs for VMs, disks, IPs, and NAT", func() {
+			gc.EXPECT().ListComputeSKUs(csEnv.Region).Return([]*cloudbilling.Sku{
+				sku("E2 Instance Core running in Netherlands", 0.03),
+				sku("E2 Instance Ram running in Netherlands", 0.004),
+				sku("SSD backed PD Capacity", 0.2),
+				sku("Static Ip Charge on a Standard VM", 0.004),
+				sku("Cloud NAT Gateway Uptime", 0.044),
+			}, nil)
+
+			estimate, err := bs.EstimateCost()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(estimate.Currency).To(Equal("USD"))
+
+			// 8 VM lines plus disks, static IPs, and NAT.
+			Expect(estimate.Lines).To(HaveLen(11))
+
+			// e2-medium jumpbox: (2 cores * 0.03 + 4 GB * 0.004) * 730h.
+			Expect(estimate.Lines[0].Description).To(Equal("VM jumpbox (e2-medium)"))
+			Expect(estimate.Lines[0].MonthlyCost).To(BeNumerically("~", 55.48, 0.01))
+
+			// 8 root disks plus the ceph data disks at 0.2/GiB-month.
+			Expect(estimate.Lines[8].Description).To(Equal("pd-ssd disks"))
+			Expect(estimate.Lines[8].Quantity).To(Equal("730 GB"))
+			Expect(estimate.Lines[8].MonthlyCost).To(BeNumerically("~", 146.0, 0.01))
+
+			// Gateway, public gateway, and SSH proxy plus jumpbox and postgres.
+			Expect(estimate.Lines[9].Quantity).To(Equal("5"))
+
+			Expect(estimate.Total()).To(BeNumerically(">", 0))
+		})
+
+		It("fails when no matching SKU is found", func() {
+			gc.EXPECT().ListComputeSKUs(csEnv.Region).Return([]*cloudbilling.Sku{}, nil)
+
+			_, err := bs.EstimateCost()
+			Expect(err).To(MatchError(ContainSubstring("no Compute Engine SKU found")))
+		})
+	})
+
+	Describe("ReportQuotaUsage", func() {
+		quota := func(metric string, usage, limit float64) *computepb.Quota {
+			return &computepb.Quota{Metric: protoString(metric), Usage: &usage, Limit: &limit}
+		}
+
+		BeforeEach(func() {
+			csEnv.RootDiskSize = 50
+		})
+
+		It("reports the relevant quotas and skips the rest", func() {
+			gc.EXPECT().GetRegionQuotas(csEnv.ProjectID, csEnv.Region).Return([]*computepb.Quota{
+				quota("CPUS", 50, 72),
+				quota("IN_USE_ADDRESSES", 5, 8),
+				quota("SSD_TOTAL_GB", 730, 2000),
+				quota("NETWORKS", 1, 5),
+			}, nil)
+
+			report, err := bs.ReportQuotaUsage()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Region).To(Equal(csEnv.Region))
+			Expect(report.Lines).To(HaveLen(3))
+			Expect(report.Lines[0].Metric).To(Equal("CPUS"))
+			Expect(report.Lines[0].Remaining()).To(Equal(22.0))
+			Expect(report.Warnings).To(BeEmpty())
+		})
+
+		It("warns when another worker would exceed the CPU quota", func() {
+			gc.EXPECT().GetRegionQuotas(csEnv.ProjectID, csEnv.Region).Return([]*computepb.Quota{
+				// A k0s worker needs 8 CPUs; only 6 remain.
+				quota("CPUS", 66, 72),
+			}, nil)
+
+			report, err := bs.ReportQuotaUsage()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Warnings).To(HaveLen(1))
+			Expect(report.Warnings[0]).To(ContainSubstring("needs 8 CPUs but only 6 remain"))
+		})
+
+		It("warns when another worker would exceed the SSD quota", func() {
+			gc.EXPECT().GetRegionQuotas(csEnv.ProjectID, csEnv.Region).Return([]*computepb.Quota{
+				quota("SSD_TOTAL_GB", 730, 760),
+			}, nil)
+
+			report, err := bs.ReportQuotaUsage()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Warnings).To(HaveLen(1))
+			Expect(report.Warnings[0]).To(ContainSubstring("needs 50 GB of SSD but only 30 GB remain"))
+		})
+	})
+
+	Describe("CheckEntitlement", func() {
+		It("enables all licensed features when none are requested", func() {
+			mockPortalClient.EXPECT().GetEntitlement().Return(portal.Entitlement{
+				Seats:    50,
+				Features: []string{"agents", "managed-services"},
+			}, nil)
+
+			Expect(bs.CheckEntitlement()).To(Succeed())
+			Expect(bs.Env.FeatureFlags).To(Equal([]string{"agents", "managed-services"}))
+		})
+
+		It("refuses requested features outside the license", func() {
+			csEnv.FeatureFlags = []string{"agents"}
+			mockPortalClient.EXPECT().GetEntitlement().Return(portal.Entitlement{
+				Seats:    50,
+				Features: []string{"managed-services"},
+			}, nil)
+
+			Expect(bs.CheckEntitlement()).To(MatchError(ContainSubstring(`feature "agents" is not covered by the license`)))
+		})
+
+		It("refuses a license without seats", func() {
+			mockPortalClient.EXPECT().GetEntitlement().Return(portal.Entitlement{}, nil)
+
+			Expect(bs.CheckEntitlement()).To(MatchError(ContainSubstring("no seats")))
+		})
+
+		It("refuses configured plans exceeding the licensed replica limit", func() {
+			csEnv.InstallConfig.Codesphere.Plans = files.PlansConfig{
+				WorkspacePlans: map[int]files.WorkspacePlan{
+					1: {Name: "Standard", MaxReplicas: 10},
+				},
+			}
+			mockPortalClient.EXPECT().GetEntitlement().Return(portal.Entitlement{
+				Seats:       50,
+				MaxReplicas: 3,
+			}, nil)
+
+			Expect(bs.CheckEntitlement()).To(MatchError(ContainSubstring("the license allows at most 3")))
+		})
+	})
+
 	Describe("Bootstrap", func() {
 		BeforeEach(func() {
 			csEnv.InstallConfig = &files.RootConfig{Registry: &files.RegistryConfig{}}
@@ -161,6 +777,11 @@ var _ = Describe("GCP Bootstrapper", func() {
 			bs.Env.RegistryType = gcp.RegistryTypeArtifactRegistry
 			bs.Env.WriteConfig = true
 
+			// Vault encryption works on real local files
+			secretsDir := GinkgoT().TempDir()
+			csEnv.SecretsFilePath = filepath.Join(secretsDir, "prod.vault.yaml")
+			ageKeyPath := filepath.Join(secretsDir, "age_key.txt")
+
 			// EnsureInstallConfig
 			fw.EXPECT().Exists("fake-config-file").Return(false)
 			icg.EXPECT().ApplyProfile("minimal").Return(nil)
@@ -174,7 +795,7 @@ var _ = Describe("GCP Bootstrapper", func() {
 			projectId := "test-project-12345"
 
 			// EnsureSecrets
-			fw.EXPECT().Exists("fake-secret").Return(false)
+			fw.EXPECT().Exists(csEnv.SecretsFilePath).Return(false)
 			icg.EXPECT().GetVault().Return(&files.InstallVault{})
 
 			// EnsureProject
@@ -193,6 +814,10 @@ var _ = Describe("GCP Bootstrapper", func() {
 			// EnsureAPIsEnabled
 			gc.EXPECT().EnableAPIs(projectId, mock.Anything).Return(nil)
 
+			// EnsurePartialResourcesHandled
+			gc.EXPECT().ListInstancesByLabels(projectId, "us-central1-a", mock.Anything).Return(nil, nil)
+			gc.EXPECT().ListAddressesByLabels(projectId, "us-central1", mock.Anything).Return(nil, nil)
+
 			// EnsureArtifactRegistry
 			gc.EXPECT().GetArtifactRegistry(projectId, "us-central1", "codesphere-registry").Return(nil, fmt.Errorf("not found"))
 			gc.EXPECT().CreateArtifactRegistry(projectId, "us-central1", "codesphere-registry").Return(&artifactregistrypb.Repository{Name: "codesphere-registry"}, nil)
@@ -208,7 +833,7 @@ var _ = Describe("GCP Bootstrapper", func() {
 			gc.EXPECT().AssignIAMRole(csEnv.DNSProjectID, "cloud-controller", projectId, []string{"roles/dns.admin"}).Return(nil)
 
 			// EnsureVPC
-			gc.EXPECT().CreateVPC(projectId, "us-central1", projectId+"-vpc", projectId+"-us-central1-subnet", projectId+"-router", projectId+"-nat-gateway").Return(nil)
+			gc.EXPECT().CreateVPC(projectId, "us-central1", projectId+"-vpc", projectId+"-us-central1-subnet", projectId+"-router", projectId+"-nat-gateway", false).Return(nil)
 
 			// EnsureFirewallRules (5 times)
 			gc.EXPECT().CreateFirewallRule(projectId, mock.Anything).Return(nil).Times(5)
@@ -230,38 +855,57 @@ var _ = Describe("GCP Bootstrapper", func() {
 			gc.EXPECT().CreateAddress(projectId, "us-central1", mock.MatchedBy(func(addr *computepb.Address) bool { return *addr.Name == "ssh-proxy" })).Return("3.3.3.3", nil)
 			gc.EXPECT().GetAddress(projectId, "us-central1", "ssh-proxy").Return(&computepb.Address{Address: protoString("3.3.3.3")}, nil)
 
-			// UpdateInstallConfig
+			// EnsureHostKeysRecorded
+			gc.EXPECT().GetInstanceHostKeys(projectId, "us-central1-a", mock.Anything).Return(nil, fmt.Errorf("guest attributes not enabled")).Times(8)
+
+			// UpdateInstallConfig writes the vault locally; EncryptVault later
+			// reads it back, so the mock writes a real file.
 			icg.EXPECT().GenerateSecrets().Return(nil)
 			icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 			nodeClient.EXPECT().CopyFile(mock.Anything, "fake-config-file", "/etc/codesphere/config.yaml").Return(nil)
-			nodeClient.EXPECT().CopyFile(mock.Anything, "fake-secret", "/etc/codesphere/secrets/prod.vault.yaml").Return(nil)
-			icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
+			icg.EXPECT().WriteVault(csEnv.SecretsFilePath, true).RunAndReturn(func(path string, _ bool) error {
+				return os.WriteFile(path, []byte("secrets: {}\n"), 0600)
+			})
 
 			// Enable Root Login
 			nodeClient.EXPECT().WaitReady(mock.Anything, mock.Anything).Return(nil).Return(nil)
 			nodeClient.EXPECT().RunCommand(mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-			// EnsureAgeKey
-			nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", "mkdir -p /etc/codesphere/secrets; age-keygen -o /etc/codesphere/secrets/age_key.txt").Return(nil)
+			// EnsureAgeKey generates the key locally and uploads it
 			nodeClient.EXPECT().HasFile(mock.MatchedBy(jumpboxMatcher), "/etc/codesphere/secrets/age_key.txt").Return(false)
+			nodeClient.EXPECT().CopyFile(mock.MatchedBy(jumpboxMatcher), mock.Anything, "/etc/codesphere/secrets/age_key.txt").RunAndReturn(func(_ *node.Node, src, _ string) error {
+				data, err := os.ReadFile(src)
+				if err != nil {
+					return err
+				}
+				return os.WriteFile(ageKeyPath, data, 0600)
+			})
+			nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", "chmod 600 /etc/codesphere/secrets/age_key.txt").Return(nil)
 
-			// EncryptVault
-			nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", "cp /etc/codesphere/secrets/prod.vault.yaml{,.bak}").Return(nil)
-			nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", mock.MatchedBy(func(cmd string) bool {
-				return strings.Contains(cmd, "sops --encrypt")
-			})).Return(nil)
+			// EncryptVault fetches the key, encrypts locally and uploads only ciphertext
+			nodeClient.EXPECT().HasFile(mock.MatchedBy(jumpboxMatcher), "/etc/codesphere/secrets/prod.vault.yaml").Return(false)
+			nodeClient.EXPECT().DownloadFile(mock.MatchedBy(jumpboxMatcher), "/etc/codesphere/secrets/age_key.txt", mock.Anything).RunAndReturn(func(_ *node.Node, _ string, dst string) error {
+				data, err := os.ReadFile(ageKeyPath)
+				if err != nil {
+					return err
+				}
+				return os.WriteFile(dst, data, 0600)
+			})
+			nodeClient.EXPECT().CopyFile(mock.MatchedBy(jumpboxMatcher), mock.Anything, "/etc/codesphere/secrets/prod.vault.yaml").RunAndReturn(func(_ *node.Node, src, _ string) error {
+				data, err := os.ReadFile(src)
+				if err != nil {
+					return err
+				}
+				Expect(string(data)).To(ContainSubstring("sops:"))
+				return nil
+			})
 
 			// EnsureDNSRecords
-			gc.EXPECT().EnsureDNSManagedZone(csEnv.DNSProjectID, "test-zone", "example.com.", mock.Anything).Return(nil)
+			gc.EXPECT().EnsureDNSManagedZone(csEnv.DNSProjectID, "test-zone", "example.com.", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 			gc.EXPECT().EnsureDNSRecordSets(csEnv.DNSProjectID, "test-zone", mock.MatchedBy(func(records []*dns.ResourceRecordSet) bool {
-				return len(records) == 5
+				return len(records) == 6
 			})).Return(nil)
 
-			// GenerateK0sConfigScript
-			fw.EXPECT().WriteFile("configure-k0s.sh", mock.Anything, os.FileMode(0755)).Return(nil)
-			nodeClient.EXPECT().CopyFile(mock.Anything, "configure-k0s.sh", "/root/configure-k0s.sh").Return(nil)
-			nodeClient.EXPECT().RunCommand(mock.Anything, "root", "chmod +x /root/configure-k0s.sh").Return(nil)
-
 			err := bs.Bootstrap()
 			Expect(err).NotTo(HaveOccurred())
 			Expect(bs.Env).NotTo(BeNil())
@@ -296,6 +940,194 @@ var _ = Describe("GCP Bootstrapper", func() {
 
 			Expect(len(bs.Env.InstallConfig.Codesphere.ManagedServices)).To(Equal(5))
 		})
+
+		Context("cancelled context", func() {
+			BeforeEach(func() {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithCancel(context.Background())
+				cancel()
+			})
+
+			It("aborts before running any step", func() {
+				Expect(bs.Bootstrap()).To(MatchError(ContainSubstring("bootstrap aborted")))
+			})
+		})
+	})
+
+	Describe("EnsureDiskEncryptionKey", func() {
+		It("creates the key, grants the compute service agent access, and persists it", func() {
+			keyName := "projects/pid/locations/us-central1/keyRings/oms/cryptoKeys/disk-encryption-key"
+			gc.EXPECT().EnsureKMSKey("pid", "us-central1", "oms", "disk-encryption-key").Return(keyName, nil)
+			gc.EXPECT().GetProjectNumber("pid").Return(int64(123456), nil)
+			gc.EXPECT().AssignIAMRoleToMember("pid", "serviceAccount:service-123456@compute-system.iam.gserviceaccount.com", []string{"roles/cloudkms.cryptoKeyEncrypterDecrypter"}).Return(nil)
+			fw.EXPECT().MkdirAll(mock.Anything, os.FileMode(0755)).Return(nil)
+			fw.EXPECT().WriteFile(mock.Anything, mock.Anything, os.FileMode(0644)).Return(nil)
+
+			Expect(bs.EnsureDiskEncryptionKey()).To(Succeed())
+			Expect(bs.Env.DiskEncryptionKey).To(Equal(keyName))
+			Expect(bs.Env.ManagedDiskEncryptionKey).To(BeTrue())
+		})
+
+		It("fails when the key cannot be created", func() {
+			gc.EXPECT().EnsureKMSKey("pid", "us-central1", "oms", "disk-encryption-key").Return("", fmt.Errorf("KMS error"))
+
+			Expect(bs.EnsureDiskEncryptionKey()).To(MatchError(ContainSubstring("failed to ensure KMS key")))
+		})
+	})
+
+	Describe("EnsureVaultKMSKey", func() {
+		It("creates the key, grants the jumpbox service account decrypt rights, and persists it", func() {
+			keyName := "projects/pid/locations/us-central1/keyRings/oms/cryptoKeys/vault-encryption-key"
+			gc.EXPECT().EnsureKMSKey("pid", "us-central1", "oms", "vault-encryption-key").Return(keyName, nil)
+			gc.EXPECT().AssignIAMRoleToMember("pid", "serviceAccount:cloud-controller@pid.iam.gserviceaccount.com", []string{"roles/cloudkms.cryptoKeyDecrypter"}).Return(nil)
+			fw.EXPECT().MkdirAll(mock.Anything, os.FileMode(0755)).Return(nil)
+			fw.EXPECT().WriteFile(mock.Anything, mock.Anything, os.FileMode(0644)).Return(nil)
+
+			Expect(bs.EnsureVaultKMSKey()).To(Succeed())
+			Expect(bs.Env.VaultKMSKeyName).To(Equal(keyName))
+		})
+
+		It("fails when the key cannot be created", func() {
+			gc.EXPECT().EnsureKMSKey("pid", "us-central1", "oms", "vault-encryption-key").Return("", fmt.Errorf("KMS error"))
+
+			Expect(bs.EnsureVaultKMSKey()).To(MatchError(ContainSubstring("failed to ensure KMS key")))
+		})
+
+		It("fails when the decrypt grant cannot be assigned", func() {
+			keyName := "projects/pid/locations/us-central1/keyRings/oms/cryptoKeys/vault-encryption-key"
+			gc.EXPECT().EnsureKMSKey("pid", "us-central1", "oms", "vault-encryption-key").Return(keyName, nil)
+			gc.EXPECT().AssignIAMRoleToMember("pid", mock.Anything, mock.Anything).Return(fmt.Errorf("IAM error"))
+
+			Expect(bs.EnsureVaultKMSKey()).To(MatchError(ContainSubstring("failed to grant the jumpbox service account access")))
+		})
+	})
+
+	Describe("EnsurePartialResourcesHandled", func() {
+		It("does nothing when no labeled resources exist", func() {
+			gc.EXPECT().ListInstancesByLabels("pid", "us-central1-a", mock.Anything).Return(nil, nil)
+			gc.EXPECT().ListAddressesByLabels("pid", "us-central1", mock.Anything).Return(nil, nil)
+
+			Expect(bs.EnsurePartialResourcesHandled()).To(Succeed())
+		})
+
+		It("adopts leftover resources by default", func() {
+			gc.EXPECT().ListInstancesByLabels("pid", "us-central1-a", mock.Anything).Return([]*computepb.Instance{
+				{Name: protoString("jumpbox")},
+			}, nil)
+			gc.EXPECT().ListAddressesByLabels("pid", "us-central1", mock.Anything).Return([]*computepb.Address{
+				{Name: protoString("gateway")},
+			}, nil)
+
+			Expect(bs.EnsurePartialResourcesHandled()).To(Succeed())
+		})
+
+		It("deletes leftover resources when cleaning is requested", func() {
+			csEnv.CleanPartialResources = true
+			gc.EXPECT().ListInstancesByLabels("pid", "us-central1-a", mock.Anything).Return([]*computepb.Instance{
+				{Name: protoString("jumpbox")},
+				{Name: protoString("postgres")},
+			}, nil)
+			gc.EXPECT().ListAddressesByLabels("pid", "us-central1", mock.Anything).Return([]*computepb.Address{
+				{Name: protoString("gateway")},
+			}, nil)
+			gc.EXPECT().DeleteInstance("pid", "us-central1-a", "jumpbox").Return(nil)
+			gc.EXPECT().DeleteInstance("pid", "us-central1-a", "postgres").Return(nil)
+			gc.EXPECT().DeleteAddress("pid", "us-central1", "gateway").Return(nil)
+
+			Expect(bs.EnsurePartialResourcesHandled()).To(Succeed())
+		})
+
+		It("fails when a leftover instance cannot be deleted", func() {
+			csEnv.CleanPartialResources = true
+			gc.EXPECT().ListInstancesByLabels("pid", "us-central1-a", mock.Anything).Return([]*computepb.Instance{
+				{Name: protoString("jumpbox")},
+			}, nil)
+			gc.EXPECT().ListAddressesByLabels("pid", "us-central1", mock.Anything).Return(nil, nil)
+			gc.EXPECT().DeleteInstance("pid", "us-central1-a", "jumpbox").Return(fmt.Errorf("in use"))
+
+			Expect(bs.EnsurePartialResourcesHandled()).To(MatchError(ContainSubstring("failed to delete instance jumpbox")))
+		})
+	})
+
+	Describe("EnsureDeletionLien", func() {
+		It("places a lien on the project", func() {
+			gc.EXPECT().EnsureProjectLien("pid", mock.Anything).Return("liens/p1234-abcd", nil)
+
+			Expect(bs.EnsureDeletionLien()).To(Succeed())
+		})
+
+		It("fails when the lien cannot be created", func() {
+			gc.EXPECT().EnsureProjectLien("pid", mock.Anything).Return("", fmt.Errorf("permission denied"))
+
+			Expect(bs.EnsureDeletionLien()).To(MatchError(ContainSubstring("failed to ensure project lien")))
+		})
+	})
+
+	Describe("ValidateInput generated disk encryption key", func() {
+		It("rejects combining a generated key with an explicit key", func() {
+			csEnv.GenerateDiskEncryptionKey = true
+			csEnv.DiskEncryptionKey = "projects/p/locations/l/keyRings/r/cryptoKeys/k"
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring("cannot combine --generate-disk-encryption-key")))
+		})
+	})
+
+	Describe("ValidateInput node operating system", func() {
+		It("accepts the supported distro families", func() {
+			for _, os := range []string{"", "ubuntu", "rhel", "suse"} {
+				csEnv.NodeOS = os
+				Expect(bs.ValidateInput()).To(Succeed())
+			}
+		})
+
+		It("rejects an unsupported distro", func() {
+			csEnv.NodeOS = "gentoo"
+
+			Expect(bs.ValidateInput()).To(MatchError(ContainSubstring(`unsupported node operating system "gentoo"`)))
+		})
+	})
+
+	Describe("EnsureHostKeysRecorded", func() {
+		const hostKey = "AAAAC3NzaC1lZDI1NTE5AAAAIGHI4cmZlASasnkOViJYacNtF0VVOzW75YiWWra9UFm+"
+
+		var workdir string
+
+		BeforeEach(func() {
+			workdir = GinkgoT().TempDir()
+			Expect(os.Setenv("OMS_WORKDIR", workdir)).To(Succeed())
+			DeferCleanup(os.Unsetenv, "OMS_WORKDIR")
+		})
+
+		It("records published host keys under every node address", func() {
+			gc.EXPECT().GetInstanceHostKeys("pid", "us-central1-a", mock.Anything).Return(map[string]string{
+				"ssh-ed25519": hostKey,
+			}, nil).Times(8)
+
+			Expect(bs.EnsureHostKeysRecorded()).To(Succeed())
+
+			content, err := os.ReadFile(filepath.Join(workdir, "known_hosts"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("jumpbox ssh-ed25519 " + hostKey))
+			Expect(string(content)).To(ContainSubstring("10.0.0.1 ssh-ed25519 " + hostKey))
+			Expect(string(content)).To(ContainSubstring("1.2.3.4 ssh-ed25519 " + hostKey))
+		})
+
+		It("falls back to trust on first use when no keys are published", func() {
+			gc.EXPECT().GetInstanceHostKeys("pid", "us-central1-a", mock.Anything).Return(nil, fmt.Errorf("guest attributes not enabled")).Times(8)
+
+			Expect(bs.EnsureHostKeysRecorded()).To(Succeed())
+
+			Expect(filepath.Join(workdir, "known_hosts")).NotTo(BeAnExistingFile())
+		})
+
+		It("fails on a host key that cannot be parsed", func() {
+			gc.EXPECT().GetInstanceHostKeys("pid", "us-central1-a", "jumpbox").Return(map[string]string{
+				"ssh-ed25519": "not-a-key",
+			}, nil)
+
+			err := bs.EnsureHostKeysRecorded()
+			Expect(err).To(MatchError(ContainSubstring("failed to record host key for jumpbox")))
+		})
 	})
 
 	Describe("ValidateInput", func() {
@@ -848,6 +1680,32 @@ var _ = Describe("GCP Bootstrapper", func() {
 		})
 	})
 
+	Describe("EnsureRegistryCleanupPolicies", func() {
+		BeforeEach(func() {
+			csEnv.RegistryKeepTagCount = 10
+			csEnv.RegistryUntaggedRetentionDays = 30
+		})
+
+		Describe("Valid EnsureRegistryCleanupPolicies", func() {
+			It("applies the cleanup policies to the registry", func() {
+				gc.EXPECT().ApplyArtifactRegistryCleanupPolicies(csEnv.ProjectID, csEnv.Region, "codesphere-registry", 10, 30*24*time.Hour).Return(nil)
+
+				err := bs.EnsureRegistryCleanupPolicies()
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Describe("Invalid cases", func() {
+			It("fails when applying the cleanup policies fails", func() {
+				gc.EXPECT().ApplyArtifactRegistryCleanupPolicies(csEnv.ProjectID, csEnv.Region, "codesphere-registry", 10, 30*24*time.Hour).Return(fmt.Errorf("update error"))
+
+				err := bs.EnsureRegistryCleanupPolicies()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to apply artifact registry cleanup policies"))
+			})
+		})
+	})
+
 	Describe("EnsureLocalContainerRegistry", func() {
 		Describe("Valid EnsureLocalContainerRegistry", func() {
 			It("installs local registry", func() {
@@ -860,8 +1718,15 @@ var _ = Describe("GCP Bootstrapper", func() {
 					return strings.Contains(cmd, "podman ps")
 				})).Return(fmt.Errorf("not running"))
 
-				// Install commands (8 commands) + scp/update-ca/docker commands (3 per 4 nodes = 12)
-				nodeClient.EXPECT().RunCommand(mock.Anything, "root", mock.Anything).Return(nil).Times(8 + 12)
+				// htpasswd runs with the password on stdin
+				nodeClient.EXPECT().RunCommandWithInput(mock.Anything, "root", mock.MatchedBy(func(cmd string) bool {
+					return strings.Contains(cmd, "htpasswd")
+				}), mock.Anything).Return(nil)
+				// Install commands (7 commands) + update-ca/docker commands (2 per 4 nodes = 8)
+				nodeClient.EXPECT().RunCommand(mock.Anything, "root", mock.Anything).Return(nil).Times(7 + 8)
+				// Certificate is fetched once and distributed to all 4 nodes via SFTP
+				nodeClient.EXPECT().DownloadFile(bs.Env.PostgreSQLNode, "/root/registry.crt", mock.Anything).Return(nil)
+				nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, "/usr/local/share/ca-certificates/registry.crt").Return(nil).Times(4)
 
 				bs.Env.ControlPlaneNodes = []*node.Node{fakeNode("k0s-1", nodeClient), fakeNode("k0s-2", nodeClient)}
 				bs.Env.CephNodes = []*node.Node{fakeNode("ceph-1", nodeClient), fakeNode("ceph-2", nodeClient)}
@@ -891,16 +1756,21 @@ var _ = Describe("GCP Bootstrapper", func() {
 				fw = util.NewMockFileIO(GinkgoT())
 			})
 
-			It("fails when the 8th install command fails", func() {
+			It("fails when the last install command fails", func() {
 				// First check - registry not running
 				nodeClient.EXPECT().RunCommand(csEnv.PostgreSQLNode, "root", mock.MatchedBy(func(cmd string) bool {
 					return strings.Contains(cmd, "podman ps")
 				})).Return(fmt.Errorf("not running"))
 
-				// First 7 install commands succeed
-				nodeClient.EXPECT().RunCommand(csEnv.PostgreSQLNode, "root", mock.Anything).Return(nil).Times(7)
+				// htpasswd runs with the password on stdin
+				nodeClient.EXPECT().RunCommandWithInput(mock.Anything, "root", mock.MatchedBy(func(cmd string) bool {
+					return strings.Contains(cmd, "htpasswd")
+				}), mock.Anything).Return(nil)
+
+				// First 6 install commands succeed
+				nodeClient.EXPECT().RunCommand(csEnv.PostgreSQLNode, "root", mock.Anything).Return(nil).Times(6)
 
-				// 8th install command fails
+				// Last install command fails
 				nodeClient.EXPECT().RunCommand(csEnv.PostgreSQLNode, "root", mock.Anything).Return(fmt.Errorf("ssh error")).Once()
 
 				err := bs.EnsureLocalContainerRegistry()
@@ -908,19 +1778,47 @@ var _ = Describe("GCP Bootstrapper", func() {
 				Expect(err.Error()).To(ContainSubstring("ssh error"))
 			})
 
-			It("fails when the first scp command fails", func() {
+			It("fails when the certificate download fails", func() {
 				// First check - registry not running
 				nodeClient.EXPECT().RunCommand(csEnv.PostgreSQLNode, "root", mock.MatchedBy(func(cmd string) bool {
 					return strings.Contains(cmd, "podman ps")
 				})).Return(fmt.Errorf("not running"))
 
-				// All 8 install commands succeed
-				nodeClient.EXPECT().RunCommand(csEnv.PostgreSQLNode, "root", mock.Anything).Return(nil).Times(8)
+				// htpasswd runs with the password on stdin
+				nodeClient.EXPECT().RunCommandWithInput(mock.Anything, "root", mock.MatchedBy(func(cmd string) bool {
+					return strings.Contains(cmd, "htpasswd")
+				}), mock.Anything).Return(nil)
+				// All 7 install commands succeed
+				nodeClient.EXPECT().RunCommand(csEnv.PostgreSQLNode, "root", mock.Anything).Return(nil).Times(7)
+
+				// Fetching the certificate fails
+				nodeClient.EXPECT().DownloadFile(csEnv.PostgreSQLNode, "/root/registry.crt", mock.Anything).Return(fmt.Errorf("sftp error"))
+
+				err := bs.EnsureLocalContainerRegistry()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to download registry certificate"))
+			})
+
+			It("fails when the certificate copy to a node fails", func() {
+				// Override node setup for this test
+				bs.Env.ControlPlaneNodes = []*node.Node{fakeNode("k0s-1", nodeClient)}
+				bs.Env.CephNodes = []*node.Node{}
 
-				// First scp command fails
+				// First check - registry not running
 				nodeClient.EXPECT().RunCommand(csEnv.PostgreSQLNode, "root", mock.MatchedBy(func(cmd string) bool {
-					return strings.HasPrefix(cmd, "scp ")
-				})).Return(fmt.Errorf("scp error")).Once()
+					return strings.Contains(cmd, "podman ps")
+				})).Return(fmt.Errorf("not running"))
+
+				// htpasswd runs with the password on stdin
+				nodeClient.EXPECT().RunCommandWithInput(mock.Anything, "root", mock.MatchedBy(func(cmd string) bool {
+					return strings.Contains(cmd, "htpasswd")
+				}), mock.Anything).Return(nil)
+				// All 7 install commands succeed
+				nodeClient.EXPECT().RunCommand(csEnv.PostgreSQLNode, "root", mock.Anything).Return(nil).Times(7)
+
+				nodeClient.EXPECT().DownloadFile(csEnv.PostgreSQLNode, "/root/registry.crt", mock.Anything).Return(nil)
+				// Copying to the node fails
+				nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, "/usr/local/share/ca-certificates/registry.crt").Return(fmt.Errorf("sftp error")).Once()
 
 				err := bs.EnsureLocalContainerRegistry()
 				Expect(err).To(HaveOccurred())
@@ -937,12 +1835,15 @@ var _ = Describe("GCP Bootstrapper", func() {
 					return strings.Contains(cmd, "podman ps")
 				})).Return(fmt.Errorf("not running"))
 
-				// All 8 install commands succeed
-				nodeClient.EXPECT().RunCommand(csEnv.PostgreSQLNode, "root", mock.Anything).Return(nil).Times(8)
-				// scp succeeds
-				nodeClient.EXPECT().RunCommand(csEnv.PostgreSQLNode, "root", mock.MatchedBy(func(cmd string) bool {
-					return strings.HasPrefix(cmd, "scp ")
-				})).Return(nil).Once()
+				// htpasswd runs with the password on stdin
+				nodeClient.EXPECT().RunCommandWithInput(mock.Anything, "root", mock.MatchedBy(func(cmd string) bool {
+					return strings.Contains(cmd, "htpasswd")
+				}), mock.Anything).Return(nil)
+				// All 7 install commands succeed
+				nodeClient.EXPECT().RunCommand(csEnv.PostgreSQLNode, "root", mock.Anything).Return(nil).Times(7)
+				// certificate download and copy succeed
+				nodeClient.EXPECT().DownloadFile(csEnv.PostgreSQLNode, "/root/registry.crt", mock.Anything).Return(nil)
+				nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, "/usr/local/share/ca-certificates/registry.crt").Return(nil).Once()
 
 				// update-ca-certificates fails
 				nodeClient.EXPECT().RunCommand(bs.Env.ControlPlaneNodes[0], "root", "update-ca-certificates").Return(fmt.Errorf("ca update error")).Once()
@@ -962,13 +1863,16 @@ var _ = Describe("GCP Bootstrapper", func() {
 					return strings.Contains(cmd, "podman ps")
 				})).Return(fmt.Errorf("not running"))
 
-				// All 8 install commands succeed
-				nodeClient.EXPECT().RunCommand(csEnv.PostgreSQLNode, "root", mock.Anything).Return(nil).Times(8)
+				// htpasswd runs with the password on stdin
+				nodeClient.EXPECT().RunCommandWithInput(mock.Anything, "root", mock.MatchedBy(func(cmd string) bool {
+					return strings.Contains(cmd, "htpasswd")
+				}), mock.Anything).Return(nil)
+				// All 7 install commands succeed
+				nodeClient.EXPECT().RunCommand(csEnv.PostgreSQLNode, "root", mock.Anything).Return(nil).Times(7)
 
-				// scp succeeds
-				nodeClient.EXPECT().RunCommand(csEnv.PostgreSQLNode, "root", mock.MatchedBy(func(cmd string) bool {
-					return strings.HasPrefix(cmd, "scp ")
-				})).Return(nil).Once()
+				// certificate download and copy succeed
+				nodeClient.EXPECT().DownloadFile(csEnv.PostgreSQLNode, "/root/registry.crt", mock.Anything).Return(nil)
+				nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, "/usr/local/share/ca-certificates/registry.crt").Return(nil).Once()
 
 				// update-ca-certificates succeeds
 				nodeClient.EXPECT().RunCommand(bs.Env.ControlPlaneNodes[0], "root", "update-ca-certificates").Return(nil).Once()
@@ -1008,66 +1912,250 @@ var _ = Describe("GCP Bootstrapper", func() {
 			It("returns an error", func() {
 				err := bs.EnsureGitHubAccessConfigured()
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("GitHub PAT is not set"))
+				Expect(err.Error()).To(ContainSubstring("GitHub PAT is not set"))
+			})
+		})
+	})
+
+	Describe("VerifyGitHubAppURLs", func() {
+		app := func(externalURL string) *gh.App {
+			return &gh.App{ExternalURL: &externalURL}
+		}
+
+		BeforeEach(func() {
+			csEnv.GitHubPAT = "fake-pat"
+		})
+
+		It("accepts an app whose homepage matches the generated domain", func() {
+			mockGitHubClient.EXPECT().GetAppBySlug(mock.Anything, "fake-app").Return(app("https://cs.example.com"), nil).Once()
+
+			Expect(bs.VerifyGitHubAppURLs()).To(Succeed())
+		})
+
+		It("tolerates a trailing slash on the homepage URL", func() {
+			mockGitHubClient.EXPECT().GetAppBySlug(mock.Anything, "fake-app").Return(app("https://cs.example.com/"), nil).Once()
+
+			Expect(bs.VerifyGitHubAppURLs()).To(Succeed())
+		})
+
+		It("succeeds with instructions when the homepage does not match", func() {
+			mockGitHubClient.EXPECT().GetAppBySlug(mock.Anything, "fake-app").Return(app("https://cs.old-domain.com"), nil).Once()
+
+			Expect(bs.VerifyGitHubAppURLs()).To(Succeed())
+		})
+
+		It("fails when the app cannot be looked up", func() {
+			mockGitHubClient.EXPECT().GetAppBySlug(mock.Anything, "fake-app").Return(nil, fmt.Errorf("404 Not Found")).Once()
+
+			err := bs.VerifyGitHubAppURLs()
+			Expect(err).To(MatchError(ContainSubstring(`failed to look up GitHub App "fake-app"`)))
+		})
+
+		It("skips the API lookup without a PAT", func() {
+			csEnv.GitHubPAT = ""
+
+			Expect(bs.VerifyGitHubAppURLs()).To(Succeed())
+		})
+	})
+
+	Describe("EnsureVPC", func() {
+		Describe("Valid EnsureVPC", func() {
+			It("creates VPC, subnet, router, and nat", func() {
+				gc.EXPECT().CreateVPC(csEnv.ProjectID, csEnv.Region, "pid-vpc", "pid-us-central1-subnet", "pid-router", "pid-nat-gateway", false).Return(nil)
+
+				err := bs.EnsureVPC()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("requests a dual-stack subnet when IPv6 is enabled", func() {
+				csEnv.IPv6 = true
+				gc.EXPECT().CreateVPC(csEnv.ProjectID, csEnv.Region, "pid-vpc", "pid-us-central1-subnet", "pid-router", "pid-nat-gateway", true).Return(nil)
+
+				err := bs.EnsureVPC()
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Describe("Invalid cases", func() {
+			It("fails when CreateVPC fails", func() {
+				gc.EXPECT().CreateVPC(csEnv.ProjectID, csEnv.Region, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("vpc error"))
+
+				err := bs.EnsureVPC()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to ensure VPC"))
+				Expect(err.Error()).To(ContainSubstring("vpc error"))
+			})
+		})
+	})
+
+	Describe("EnsureFirewallRules", func() {
+		Describe("Valid EnsureFirewallRules", func() {
+			It("creates required firewall rules", func() {
+				// Expect 4 rules: allow-ssh-ext, allow-internal, allow-all-egress, allow-ingress-web, allow-ingress-postgres
+				// Wait, code showed 5 blocks? ssh, internal, egress, web, postgres.
+				gc.EXPECT().CreateFirewallRule(csEnv.ProjectID, mock.MatchedBy(func(r *computepb.Firewall) bool {
+					return *r.Name == "allow-ssh-ext"
+				})).Return(nil)
+				gc.EXPECT().CreateFirewallRule(csEnv.ProjectID, mock.MatchedBy(func(r *computepb.Firewall) bool {
+					return *r.Name == "allow-internal"
+				})).Return(nil)
+				gc.EXPECT().CreateFirewallRule(csEnv.ProjectID, mock.MatchedBy(func(r *computepb.Firewall) bool {
+					return *r.Name == "allow-all-egress"
+				})).Return(nil)
+				gc.EXPECT().CreateFirewallRule(csEnv.ProjectID, mock.MatchedBy(func(r *computepb.Firewall) bool {
+					return *r.Name == "allow-ingress-web"
+				})).Return(nil)
+				gc.EXPECT().CreateFirewallRule(csEnv.ProjectID, mock.MatchedBy(func(r *computepb.Firewall) bool {
+					return *r.Name == "allow-ingress-postgres"
+				})).Return(nil)
+
+				err := bs.EnsureFirewallRules()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("creates a replication rule when HA postgres is enabled", func() {
+				csEnv.HAPostgres = true
+				gc.EXPECT().CreateFirewallRule(csEnv.ProjectID, mock.MatchedBy(func(r *computepb.Firewall) bool {
+					if *r.Name != "allow-postgres-replication" {
+						return true
+					}
+					return slices.Equal(r.SourceTags, []string{"postgres"}) &&
+						slices.Equal(r.TargetTags, []string{"postgres"})
+				})).Return(nil).Times(6)
+
+				err := bs.EnsureFirewallRules()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("mirrors the external rules for IPv6 when dual-stack is enabled", func() {
+				csEnv.IPv6 = true
+				ipv6Rules := 0
+				gc.EXPECT().CreateFirewallRule(csEnv.ProjectID, mock.MatchedBy(func(r *computepb.Firewall) bool {
+					if !strings.HasSuffix(*r.Name, "-ipv6") {
+						return true
+					}
+					ipv6Rules++
+					ranges := r.SourceRanges
+					if *r.Direction == "EGRESS" {
+						ranges = r.DestinationRanges
+					}
+					return slices.Equal(ranges, []string{"::/0"})
+				})).Return(nil).Times(9)
+
+				err := bs.EnsureFirewallRules()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipv6Rules).To(Equal(4))
+			})
+
+			It("restricts web ingress to Cloudflare ranges in Cloudflare exposure mode", func() {
+				csEnv.UseCloudflare = true
+				cfRanges := []string{"173.245.48.0/20", "2400:cb00::/32"}
+				cfc.EXPECT().GetIPRanges().Return(cfRanges, nil)
+				gc.EXPECT().CreateFirewallRule(csEnv.ProjectID, mock.MatchedBy(func(r *computepb.Firewall) bool {
+					if *r.Name != "allow-ingress-web" {
+						return true
+					}
+					return slices.Equal(r.SourceRanges, cfRanges)
+				})).Return(nil).Times(5)
+
+				err := bs.EnsureFirewallRules()
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Describe("Invalid cases", func() {
+			It("fails when first firewall rule creation fails", func() {
+				gc.EXPECT().CreateFirewallRule(csEnv.ProjectID, mock.Anything).Return(fmt.Errorf("firewall error")).Once()
+
+				err := bs.EnsureFirewallRules()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to create jumpbox ssh firewall rule"))
 			})
 		})
 	})
 
-	Describe("EnsureVPC", func() {
-		Describe("Valid EnsureVPC", func() {
-			It("creates VPC, subnet, router, and nat", func() {
-				gc.EXPECT().CreateVPC(csEnv.ProjectID, csEnv.Region, "pid-vpc", "pid-us-central1-subnet", "pid-router", "pid-nat-gateway").Return(nil)
+	Describe("EnsureCloudArmorPolicy", func() {
+		BeforeEach(func() {
+			csEnv.CloudArmorPolicy = true
+		})
 
-				err := bs.EnsureVPC()
+		Describe("Valid EnsureCloudArmorPolicy", func() {
+			It("creates a policy with rate limiting, OWASP and default rules", func() {
+				gc.EXPECT().CreateSecurityPolicy(csEnv.ProjectID, mock.MatchedBy(func(p *computepb.SecurityPolicy) bool {
+					return p.GetName() == gcp.CloudArmorPolicyName && len(p.GetRules()) == 3
+				})).Return(nil)
+
+				err := bs.EnsureCloudArmorPolicy()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("adds a geo blocking rule when countries are configured", func() {
+				csEnv.CloudArmorBlockedCountries = []string{"xx", "YY"}
+				gc.EXPECT().CreateSecurityPolicy(csEnv.ProjectID, mock.MatchedBy(func(p *computepb.SecurityPolicy) bool {
+					if len(p.GetRules()) != 4 {
+						return false
+					}
+					geoRule := p.GetRules()[2]
+					return geoRule.GetMatch().GetExpr().GetExpression() == "origin.region_code == 'XX' || origin.region_code == 'YY'"
+				})).Return(nil)
+
+				err := bs.EnsureCloudArmorPolicy()
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
 
 		Describe("Invalid cases", func() {
-			It("fails when CreateVPC fails", func() {
-				gc.EXPECT().CreateVPC(csEnv.ProjectID, csEnv.Region, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("vpc error"))
+			It("fails when policy creation fails", func() {
+				gc.EXPECT().CreateSecurityPolicy(csEnv.ProjectID, mock.Anything).Return(fmt.Errorf("armor error"))
 
-				err := bs.EnsureVPC()
+				err := bs.EnsureCloudArmorPolicy()
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("failed to ensure VPC"))
-				Expect(err.Error()).To(ContainSubstring("vpc error"))
+				Expect(err.Error()).To(ContainSubstring("failed to create security policy"))
 			})
 		})
 	})
 
-	Describe("EnsureFirewallRules", func() {
-		Describe("Valid EnsureFirewallRules", func() {
-			It("creates required firewall rules", func() {
-				// Expect 4 rules: allow-ssh-ext, allow-internal, allow-all-egress, allow-ingress-web, allow-ingress-postgres
-				// Wait, code showed 5 blocks? ssh, internal, egress, web, postgres.
-				gc.EXPECT().CreateFirewallRule(csEnv.ProjectID, mock.MatchedBy(func(r *computepb.Firewall) bool {
-					return *r.Name == "allow-ssh-ext"
-				})).Return(nil)
-				gc.EXPECT().CreateFirewallRule(csEnv.ProjectID, mock.MatchedBy(func(r *computepb.Firewall) bool {
-					return *r.Name == "allow-internal"
-				})).Return(nil)
-				gc.EXPECT().CreateFirewallRule(csEnv.ProjectID, mock.MatchedBy(func(r *computepb.Firewall) bool {
-					return *r.Name == "allow-all-egress"
-				})).Return(nil)
-				gc.EXPECT().CreateFirewallRule(csEnv.ProjectID, mock.MatchedBy(func(r *computepb.Firewall) bool {
-					return *r.Name == "allow-ingress-web"
-				})).Return(nil)
-				gc.EXPECT().CreateFirewallRule(csEnv.ProjectID, mock.MatchedBy(func(r *computepb.Firewall) bool {
-					return *r.Name == "allow-ingress-postgres"
-				})).Return(nil)
+	Describe("EnsureOriginCertificate", func() {
+		BeforeEach(func() {
+			csEnv.UseCloudflare = true
+			csEnv.CloudflareZoneID = "zone-1"
+		})
 
-				err := bs.EnsureFirewallRules()
+		Describe("Valid EnsureOriginCertificate", func() {
+			It("stores the certificate and key in the vault", func() {
+				vault := &files.InstallVault{}
+				icg.EXPECT().GetVault().Return(vault)
+				cfc.EXPECT().CreateOriginCertificate([]string{
+					csEnv.BaseDomain,
+					"*." + csEnv.BaseDomain,
+					"*.cs." + csEnv.BaseDomain,
+					"*.ws." + csEnv.BaseDomain,
+				}).Return("cert-pem", "key-pem", nil)
+
+				err := bs.EnsureOriginCertificate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vault.GetSecret(files.SecretCloudflareOriginCertPem).Fields.Password).To(Equal("cert-pem"))
+				Expect(vault.GetSecret(files.SecretCloudflareOriginKeyPem).Fields.Password).To(Equal("key-pem"))
+			})
+
+			It("does nothing when the vault already contains a certificate", func() {
+				vault := &files.InstallVault{}
+				vault.SetSecret(files.SecretEntry{Name: files.SecretCloudflareOriginCertPem, Fields: &files.SecretFields{Password: "existing"}})
+				icg.EXPECT().GetVault().Return(vault)
+
+				err := bs.EnsureOriginCertificate()
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
 
 		Describe("Invalid cases", func() {
-			It("fails when first firewall rule creation fails", func() {
-				gc.EXPECT().CreateFirewallRule(csEnv.ProjectID, mock.Anything).Return(fmt.Errorf("firewall error")).Once()
+			It("fails when certificate creation fails", func() {
+				icg.EXPECT().GetVault().Return(&files.InstallVault{})
+				cfc.EXPECT().CreateOriginCertificate(mock.Anything).Return("", "", fmt.Errorf("origin CA error"))
 
-				err := bs.EnsureFirewallRules()
+				err := bs.EnsureOriginCertificate()
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("failed to create jumpbox ssh firewall rule"))
+				Expect(err.Error()).To(ContainSubstring("failed to create origin certificate"))
 			})
 		})
 	})
@@ -1099,6 +2187,27 @@ var _ = Describe("GCP Bootstrapper", func() {
 				Expect(bs.Env.PublicGatewayIP).To(Equal("2.2.2.2"))
 				Expect(bs.Env.SshProxyIP).To(Equal("3.3.3.3"))
 			})
+
+			It("creates additional IPv6 addresses when dual-stack is enabled", func() {
+				csEnv.IPv6 = true
+				for i, name := range []string{"gateway", "public-gateway", "ssh-proxy"} {
+					ip := fmt.Sprintf("%d.%d.%d.%d", i+1, i+1, i+1, i+1)
+					gc.EXPECT().GetAddress(csEnv.ProjectID, csEnv.Region, name).Return(&computepb.Address{Address: &ip}, nil)
+				}
+				for i, name := range []string{"gateway-ipv6", "public-gateway-ipv6", "ssh-proxy-ipv6"} {
+					ip := fmt.Sprintf("2001:db8::%d", i+1)
+					gc.EXPECT().GetAddress(csEnv.ProjectID, csEnv.Region, name).Return(nil, fmt.Errorf("not found"))
+					gc.EXPECT().CreateAddress(csEnv.ProjectID, csEnv.Region, mock.MatchedBy(func(a *computepb.Address) bool {
+						return *a.Name == name && *a.IpVersion == "IPV6"
+					})).Return(ip, nil)
+				}
+
+				err := bs.EnsureGatewayIPAddresses()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bs.Env.GatewayIPv6).To(Equal("2001:db8::1"))
+				Expect(bs.Env.PublicGatewayIPv6).To(Equal("2001:db8::2"))
+				Expect(bs.Env.SshProxyIPv6).To(Equal("2001:db8::3"))
+			})
 		})
 
 		Describe("Invalid cases", func() {
@@ -1173,6 +2282,28 @@ var _ = Describe("GCP Bootstrapper", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 
+			It("downloads a checksum-verified OMS release when OMS is missing", func() {
+				nodeClient.EXPECT().RunCommand(csEnv.Jumpbox, "ubuntu", mock.Anything).Return(nil)
+				nodeClient.EXPECT().RunCommand(csEnv.Jumpbox, "root", "command -v oms >/dev/null 2>&1").Return(fmt.Errorf("missing")).Once()
+				downloadCommands := []string{}
+				nodeClient.EXPECT().RunCommand(csEnv.Jumpbox, "root", mock.MatchedBy(func(command string) bool {
+					if strings.HasPrefix(command, "command -v ") {
+						return false
+					}
+					downloadCommands = append(downloadCommands, command)
+					return true
+				})).Return(nil).Times(4)
+				nodeClient.EXPECT().RunCommand(csEnv.Jumpbox, "root", mock.MatchedBy(func(command string) bool {
+					return strings.HasPrefix(command, "command -v ")
+				})).Return(nil).Twice()
+
+				Expect(bs.EnsureJumpboxConfigured()).To(Succeed())
+				// Dev builds (version 0.0.0) install the latest release; the binary
+				// is verified against the release checksum file before moving it.
+				Expect(strings.Join(downloadCommands, "\n")).To(ContainSubstring("releases/latest"))
+				Expect(strings.Join(downloadCommands, "\n")).To(ContainSubstring("sha256sum -c -"))
+			})
+
 			It("copies and uses a requested local OMS binary even when OMS is already installed", func() {
 				csEnv.RemoteOmsBinaryPath = "./bin/oms-linux-amd64"
 				nodeClient.EXPECT().RunCommand(csEnv.Jumpbox, "ubuntu", mock.Anything).Return(nil)
@@ -1231,9 +2362,35 @@ var _ = Describe("GCP Bootstrapper", func() {
 		Describe("Valid EnsureHostsConfigured", func() {
 			It("configures hosts", func() {
 				nodeClient.EXPECT().RunCommand(mock.Anything, "root", mock.Anything).Return(nil)
+				nodeClient.EXPECT().RunCommand(mock.Anything, "ubuntu", mock.Anything).Return(nil)
+
+				err := bs.EnsureHostsConfigured()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("writes the sshd hardening drop-in when missing", func() {
+				// Restrict to a single node so the expectations are not
+				// interleaved by the parallel per-node tasks.
+				bs.Env.ControlPlaneNodes = []*node.Node{}
+				bs.Env.CephNodes = []*node.Node{}
+
+				nodeClient.EXPECT().RunCommand(mock.Anything, "root", mock.Anything).Return(nil)
+				hardenCommands := []string{}
+				nodeClient.EXPECT().RunCommand(mock.Anything, "ubuntu", mock.MatchedBy(func(command string) bool {
+					if strings.HasPrefix(command, "sudo grep") {
+						return false
+					}
+					hardenCommands = append(hardenCommands, command)
+					return true
+				})).Return(nil).Twice()
+				nodeClient.EXPECT().RunCommand(mock.Anything, "ubuntu", mock.MatchedBy(func(command string) bool {
+					return strings.HasPrefix(command, "sudo grep")
+				})).Return(fmt.Errorf("missing")).Once()
 
 				err := bs.EnsureHostsConfigured()
 				Expect(err).NotTo(HaveOccurred())
+				Expect(strings.Join(hardenCommands, "\n")).To(ContainSubstring("MaxAuthTries 3"))
+				Expect(strings.Join(hardenCommands, "\n")).To(ContainSubstring("systemctl restart sshd"))
 			})
 		})
 
@@ -1247,6 +2404,11 @@ var _ = Describe("GCP Bootstrapper", func() {
 			})
 
 			It("fails when ConfigureMemoryMap fails", func() {
+				// Restrict to a single node so the ordered expectations below
+				// are not interleaved by the parallel per-node tasks.
+				bs.Env.ControlPlaneNodes = []*node.Node{}
+				bs.Env.CephNodes = []*node.Node{}
+
 				mock.InOrder(
 					// HasInotifyWatchesConfigured: all 4 checks pass → skip ConfigureInotifyWatches
 					nodeClient.EXPECT().RunCommand(mock.Anything, "root", "sudo grep -E '^fs.inotify.max_user_watches=1048576' /etc/sysctl.conf >/dev/null 2>&1").Return(nil).Once(),
@@ -1269,11 +2431,135 @@ var _ = Describe("GCP Bootstrapper", func() {
 		})
 	})
 
+	Describe("EnsurePrePulledImages", func() {
+		BeforeEach(func() {
+			csEnv.PrePullImages = []string{"codesphere/workspace-agent:24.04", "ubuntu:24.04"}
+		})
+
+		Describe("Valid EnsurePrePulledImages", func() {
+			It("pulls every image on every worker node", func() {
+				nodeClient.EXPECT().RunCommand(mock.Anything, "root", "command -v podman >/dev/null 2>&1").Return(nil).Times(3)
+				nodeClient.EXPECT().RunCommand(mock.Anything, "root", "podman pull codesphere/workspace-agent:24.04").Return(nil).Times(3)
+				nodeClient.EXPECT().RunCommand(mock.Anything, "root", "podman pull ubuntu:24.04").Return(nil).Times(3)
+
+				err := bs.EnsurePrePulledImages()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("installs podman on nodes that do not have it", func() {
+				bs.Env.ControlPlaneNodes = []*node.Node{fakeNode("k0s-1", nodeClient)}
+
+				nodeClient.EXPECT().RunCommand(mock.Anything, "root", "command -v podman >/dev/null 2>&1").Return(fmt.Errorf("not found")).Once()
+				nodeClient.EXPECT().RunCommand(mock.Anything, "root", "apt-get update").Return(nil).Once()
+				nodeClient.EXPECT().RunCommand(mock.Anything, "root", "apt-get install -y podman").Return(nil).Once()
+				nodeClient.EXPECT().RunCommand(mock.Anything, "root", "podman pull codesphere/workspace-agent:24.04").Return(nil).Once()
+				nodeClient.EXPECT().RunCommand(mock.Anything, "root", "podman pull ubuntu:24.04").Return(nil).Once()
+
+				err := bs.EnsurePrePulledImages()
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Describe("Invalid cases", func() {
+			It("fails when a pull fails and reports the node and image", func() {
+				bs.Env.ControlPlaneNodes = []*node.Node{fakeNode("k0s-1", nodeClient)}
+
+				nodeClient.EXPECT().RunCommand(mock.Anything, "root", "command -v podman >/dev/null 2>&1").Return(nil).Once()
+				nodeClient.EXPECT().RunCommand(mock.Anything, "root", "podman pull codesphere/workspace-agent:24.04").Return(fmt.Errorf("pull error")).Once()
+
+				err := bs.EnsurePrePulledImages()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to pull image codesphere/workspace-agent:24.04 on k0s-1"))
+			})
+
+			It("fails when installing podman fails", func() {
+				bs.Env.ControlPlaneNodes = []*node.Node{fakeNode("k0s-1", nodeClient)}
+
+				nodeClient.EXPECT().RunCommand(mock.Anything, "root", "command -v podman >/dev/null 2>&1").Return(fmt.Errorf("not found")).Once()
+				nodeClient.EXPECT().RunCommand(mock.Anything, "root", "apt-get update").Return(nil).Once()
+				nodeClient.EXPECT().RunCommand(mock.Anything, "root", "apt-get install -y podman").Return(fmt.Errorf("install error")).Once()
+
+				err := bs.EnsurePrePulledImages()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to install podman on k0s-1"))
+			})
+		})
+	})
+
+	Describe("EnsureDeployImagesPreWarmed", func() {
+		BeforeEach(func() {
+			csEnv.InstallConfig.Codesphere.DeployConfig = files.DeployConfig{
+				Images: map[string]files.ImageConfig{
+					"ubuntu-24.04": {Flavors: map[string]files.FlavorConfig{
+						"default": {Image: files.ImageRef{ImageName: "codesphere/workspace-agent:24.04"}},
+						"bom":     {Image: files.ImageRef{BomRef: "workspace-agent-24.04"}},
+					}},
+				},
+			}
+			csEnv.NewWorkerNodes = []string{"k0s-1"}
+		})
+
+		It("pulls the deploy image set only on newly created workers", func() {
+			nodeClient.EXPECT().RunCommand(bs.Env.ControlPlaneNodes[0], "root", "command -v podman >/dev/null 2>&1").Return(nil).Once()
+			nodeClient.EXPECT().RunCommand(bs.Env.ControlPlaneNodes[0], "root", "podman pull codesphere/workspace-agent:24.04").Return(nil).Once()
+
+			err := bs.EnsureDeployImagesPreWarmed()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("includes workspace image overrides in the image set", func() {
+			csEnv.InstallConfig.Codesphere.WorkspaceImages = &files.WorkspaceImagesConfig{
+				Agent: &files.ImageRef{ImageName: "codesphere/agent:1"},
+			}
+
+			nodeClient.EXPECT().RunCommand(bs.Env.ControlPlaneNodes[0], "root", "command -v podman >/dev/null 2>&1").Return(nil).Once()
+			nodeClient.EXPECT().RunCommand(bs.Env.ControlPlaneNodes[0], "root", "podman pull codesphere/agent:1").Return(nil).Once()
+			nodeClient.EXPECT().RunCommand(bs.Env.ControlPlaneNodes[0], "root", "podman pull codesphere/workspace-agent:24.04").Return(nil).Once()
+
+			err := bs.EnsureDeployImagesPreWarmed()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("does nothing when no workers were newly created", func() {
+			csEnv.NewWorkerNodes = nil
+
+			err := bs.EnsureDeployImagesPreWarmed()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("does nothing when the deploy config only references BOM images", func() {
+			csEnv.InstallConfig.Codesphere.DeployConfig = files.DeployConfig{
+				Images: map[string]files.ImageConfig{
+					"ubuntu-24.04": {Flavors: map[string]files.FlavorConfig{
+						"default": {Image: files.ImageRef{BomRef: "workspace-agent-24.04"}},
+					}},
+				},
+			}
+
+			err := bs.EnsureDeployImagesPreWarmed()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("fails when a pull fails on a new worker", func() {
+			nodeClient.EXPECT().RunCommand(bs.Env.ControlPlaneNodes[0], "root", "command -v podman >/dev/null 2>&1").Return(nil).Once()
+			nodeClient.EXPECT().RunCommand(bs.Env.ControlPlaneNodes[0], "root", "podman pull codesphere/workspace-agent:24.04").Return(fmt.Errorf("pull error")).Once()
+
+			err := bs.EnsureDeployImagesPreWarmed()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to pull image codesphere/workspace-agent:24.04 on k0s-1"))
+		})
+	})
+
 	Describe("EnsureAgeKey", func() {
 		Describe("Valid EnsureAgeKey", func() {
-			It("generates key if missing", func() {
+			It("generates key locally and uploads it if missing", func() {
 				nodeClient.EXPECT().HasFile(mock.MatchedBy(jumpboxMatcher), "/etc/codesphere/secrets/age_key.txt").Return(false)
-				nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", "mkdir -p /etc/codesphere/secrets; age-keygen -o /etc/codesphere/secrets/age_key.txt").Return(nil)
+				nodeClient.EXPECT().CopyFile(mock.MatchedBy(jumpboxMatcher), mock.Anything, "/etc/codesphere/secrets/age_key.txt").RunAndReturn(func(_ *node.Node, src, _ string) error {
+					// the uploaded file must contain a valid age identity
+					_, err := vault.RecipientFromKeyFile(src)
+					return err
+				})
+				nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", "chmod 600 /etc/codesphere/secrets/age_key.txt").Return(nil)
 
 				err := bs.EnsureAgeKey()
 				Expect(err).NotTo(HaveOccurred())
@@ -1288,27 +2574,51 @@ var _ = Describe("GCP Bootstrapper", func() {
 		})
 
 		Describe("Invalid cases", func() {
-			It("fails when age-keygen command fails", func() {
+			It("fails when the key upload fails", func() {
 				nodeClient.EXPECT().HasFile(mock.MatchedBy(jumpboxMatcher), "/etc/codesphere/secrets/age_key.txt").Return(false)
-				nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", "mkdir -p /etc/codesphere/secrets; age-keygen -o /etc/codesphere/secrets/age_key.txt").Return(fmt.Errorf("ouch"))
+				nodeClient.EXPECT().CopyFile(mock.MatchedBy(jumpboxMatcher), mock.Anything, "/etc/codesphere/secrets/age_key.txt").Return(fmt.Errorf("ouch"))
 
 				err := bs.EnsureAgeKey()
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("failed to generate age key on jumpbox"))
+				Expect(err.Error()).To(ContainSubstring("failed to copy age key to jumpbox"))
 			})
 		})
 	})
 
 	Describe("EncryptVault", func() {
+		var keyPath string
+
+		BeforeEach(func() {
+			tmpDir := GinkgoT().TempDir()
+			keyPath = filepath.Join(tmpDir, "age_key.txt")
+			_, err := vault.GenerateAgeKey(keyPath)
+			Expect(err).NotTo(HaveOccurred())
+
+			csEnv.SecretsFilePath = filepath.Join(tmpDir, "prod.vault.yaml")
+			Expect(os.WriteFile(csEnv.SecretsFilePath, []byte("secrets:\n  password: hunter2\n"), 0600)).To(Succeed())
+		})
+
 		Describe("Valid EncryptVault", func() {
-			It("encrypts vault using sops", func() {
-				nodeClient.EXPECT().RunCommand(bs.Env.Jumpbox, "root", mock.MatchedBy(func(cmd string) bool {
-					return strings.HasPrefix(cmd, "cp ")
-				})).Return(nil)
+			It("encrypts the vault locally and uploads only the ciphertext", func() {
+				nodeClient.EXPECT().HasFile(bs.Env.Jumpbox, "/etc/codesphere/secrets/prod.vault.yaml").Return(true)
+				nodeClient.EXPECT().RunCommand(bs.Env.Jumpbox, "root", "cp /etc/codesphere/secrets/prod.vault.yaml{,.bak}").Return(nil)
+				nodeClient.EXPECT().DownloadFile(bs.Env.Jumpbox, "/etc/codesphere/secrets/age_key.txt", mock.Anything).RunAndReturn(downloadFakeFile(keyPath))
+				nodeClient.EXPECT().CopyFile(bs.Env.Jumpbox, mock.Anything, "/etc/codesphere/secrets/prod.vault.yaml").RunAndReturn(func(_ *node.Node, src, _ string) error {
+					data, err := os.ReadFile(src)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(data)).To(ContainSubstring("sops:"))
+					Expect(string(data)).NotTo(ContainSubstring("hunter2"))
+					return nil
+				})
 
-				nodeClient.EXPECT().RunCommand(bs.Env.Jumpbox, "root", mock.MatchedBy(func(cmd string) bool {
-					return strings.Contains(cmd, "sops --encrypt")
-				})).Return(nil)
+				err := bs.EncryptVault()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("skips the backup when no vault exists on the jumpbox yet", func() {
+				nodeClient.EXPECT().HasFile(bs.Env.Jumpbox, "/etc/codesphere/secrets/prod.vault.yaml").Return(false)
+				nodeClient.EXPECT().DownloadFile(bs.Env.Jumpbox, "/etc/codesphere/secrets/age_key.txt", mock.Anything).RunAndReturn(downloadFakeFile(keyPath))
+				nodeClient.EXPECT().CopyFile(bs.Env.Jumpbox, mock.Anything, "/etc/codesphere/secrets/prod.vault.yaml").Return(nil)
 
 				err := bs.EncryptVault()
 				Expect(err).NotTo(HaveOccurred())
@@ -1317,6 +2627,7 @@ var _ = Describe("GCP Bootstrapper", func() {
 
 		Describe("Invalid cases", func() {
 			It("fails when backup vault command fails", func() {
+				nodeClient.EXPECT().HasFile(bs.Env.Jumpbox, "/etc/codesphere/secrets/prod.vault.yaml").Return(true)
 				nodeClient.EXPECT().RunCommand(bs.Env.Jumpbox, "root", mock.MatchedBy(func(cmd string) bool {
 					return strings.HasPrefix(cmd, "cp ")
 				})).Return(fmt.Errorf("backup error"))
@@ -1326,18 +2637,13 @@ var _ = Describe("GCP Bootstrapper", func() {
 				Expect(err.Error()).To(ContainSubstring("failed backup vault on jumpbox"))
 			})
 
-			It("fails when sops encrypt command fails", func() {
-				nodeClient.EXPECT().RunCommand(bs.Env.Jumpbox, "root", mock.MatchedBy(func(cmd string) bool {
-					return strings.HasPrefix(cmd, "cp ")
-				})).Return(nil)
-
-				nodeClient.EXPECT().RunCommand(bs.Env.Jumpbox, "root", mock.MatchedBy(func(cmd string) bool {
-					return strings.Contains(cmd, "sops --encrypt")
-				})).Return(fmt.Errorf("encrypt error"))
+			It("fails when the age key download fails", func() {
+				nodeClient.EXPECT().HasFile(bs.Env.Jumpbox, "/etc/codesphere/secrets/prod.vault.yaml").Return(false)
+				nodeClient.EXPECT().DownloadFile(bs.Env.Jumpbox, "/etc/codesphere/secrets/age_key.txt", mock.Anything).Return(fmt.Errorf("download error"))
 
 				err := bs.EncryptVault()
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("failed to encrypt vault on jumpbox"))
+				Expect(err.Error()).To(ContainSubstring("failed to download age key from jumpbox"))
 			})
 		})
 	})
@@ -1345,10 +2651,102 @@ var _ = Describe("GCP Bootstrapper", func() {
 	Describe("EnsureDNSRecords", func() {
 		Describe("Valid EnsureDNSRecords", func() {
 			It("ensures DNS records", func() {
-				gc.EXPECT().EnsureDNSManagedZone(csEnv.DNSProjectID, csEnv.DNSZoneName, csEnv.BaseDomain+".", mock.Anything).Return(nil)
+				gc.EXPECT().EnsureDNSManagedZone(csEnv.DNSProjectID, csEnv.DNSZoneName, csEnv.BaseDomain+".", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				gc.EXPECT().EnsureDNSRecordSets(csEnv.DNSProjectID, csEnv.DNSZoneName, mock.MatchedBy(func(records []*dns.ResourceRecordSet) bool {
+					// Expect 6 records: cs, *.cs, ws, *.ws, *.ssh.cs, plus the ownership TXT record
+					if len(records) != 6 {
+						return false
+					}
+					owner := records[5]
+					return owner.Type == "TXT" && strings.Contains(owner.Rrdatas[0], "heritage=oms,owner="+csEnv.ProjectID)
+				})).Return(nil)
+
+				err := bs.EnsureDNSRecords()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("applies the configured record TTL", func() {
+				csEnv.DNSRecordTTL = 60
+				gc.EXPECT().EnsureDNSManagedZone(csEnv.DNSProjectID, csEnv.DNSZoneName, csEnv.BaseDomain+".", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				gc.EXPECT().EnsureDNSRecordSets(csEnv.DNSProjectID, csEnv.DNSZoneName, mock.MatchedBy(func(records []*dns.ResourceRecordSet) bool {
+					for _, record := range records {
+						if record.Ttl != 60 {
+							return false
+						}
+					}
+					return len(records) == 6
+				})).Return(nil)
+
+				err := bs.EnsureDNSRecords()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("creates proxied Cloudflare records in Cloudflare exposure mode", func() {
+				csEnv.UseCloudflare = true
+				csEnv.CloudflareZoneID = "zone-1"
+				csEnv.GatewayIP = "1.2.3.4"
+				csEnv.PublicGatewayIP = "5.6.7.8"
+				csEnv.SshProxyIP = "9.10.11.12"
+
+				cfc.EXPECT().EnsureDNSRecord("zone-1", "A", "cs."+csEnv.BaseDomain, "1.2.3.4", true).Return(nil)
+				cfc.EXPECT().EnsureDNSRecord("zone-1", "A", "*.cs."+csEnv.BaseDomain, "1.2.3.4", true).Return(nil)
+				cfc.EXPECT().EnsureDNSRecord("zone-1", "A", "*.ws."+csEnv.BaseDomain, "5.6.7.8", true).Return(nil)
+				cfc.EXPECT().EnsureDNSRecord("zone-1", "A", "ws."+csEnv.BaseDomain, "5.6.7.8", true).Return(nil)
+				cfc.EXPECT().EnsureDNSRecord("zone-1", "A", "*.ssh.cs."+csEnv.BaseDomain, "9.10.11.12", false).Return(nil)
+
+				err := bs.EnsureDNSRecords()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("enables DNSSEC and records the DS record", func() {
+				csEnv.DNSSEC = true
+				gc.EXPECT().EnsureDNSManagedZone(csEnv.DNSProjectID, csEnv.DNSZoneName, csEnv.BaseDomain+".", mock.Anything, mock.Anything, true).Return(nil)
+				gc.EXPECT().EnsureDNSRecordSets(csEnv.DNSProjectID, csEnv.DNSZoneName, mock.Anything).Return(nil)
+				gc.EXPECT().GetDNSSECDSRecord(csEnv.DNSProjectID, csEnv.DNSZoneName).Return("12345 13 2 abcdef", nil)
+
+				err := bs.EnsureDNSRecords()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(csEnv.DNSSECDSRecord).To(Equal("12345 13 2 abcdef"))
+			})
+
+			It("creates an apex record when apex domain records are enabled", func() {
+				csEnv.ApexDomainRecords = true
+				csEnv.GatewayIP = "1.2.3.4"
+				gc.EXPECT().EnsureDNSManagedZone(csEnv.DNSProjectID, csEnv.DNSZoneName, csEnv.BaseDomain+".", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				gc.EXPECT().EnsureDNSRecordSets(csEnv.DNSProjectID, csEnv.DNSZoneName, mock.MatchedBy(func(records []*dns.ResourceRecordSet) bool {
+					if len(records) != 7 {
+						return false
+					}
+					apex := records[5]
+					return apex.Name == csEnv.BaseDomain+"." && apex.Type == "A" && apex.Rrdatas[0] == "1.2.3.4"
+				})).Return(nil)
+
+				err := bs.EnsureDNSRecords()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("creates matching AAAA records when dual-stack is enabled", func() {
+				csEnv.IPv6 = true
+				csEnv.GatewayIP = "1.2.3.4"
+				csEnv.PublicGatewayIP = "5.6.7.8"
+				csEnv.SshProxyIP = "9.10.11.12"
+				csEnv.GatewayIPv6 = "2001:db8::1"
+				csEnv.PublicGatewayIPv6 = "2001:db8::2"
+				csEnv.SshProxyIPv6 = "2001:db8::3"
+				gc.EXPECT().EnsureDNSManagedZone(csEnv.DNSProjectID, csEnv.DNSZoneName, csEnv.BaseDomain+".", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				gc.EXPECT().EnsureDNSRecordSets(csEnv.DNSProjectID, csEnv.DNSZoneName, mock.MatchedBy(func(records []*dns.ResourceRecordSet) bool {
-					// Expect 5 records: cs, *.cs, ws, *.ws, *.ssh.cs
-					return len(records) == 5
+					if len(records) != 11 {
+						return false
+					}
+					byName := map[string]string{}
+					for _, record := range records {
+						if record.Type == "AAAA" {
+							byName[record.Name] = record.Rrdatas[0]
+						}
+					}
+					return byName["cs."+csEnv.BaseDomain+"."] == "2001:db8::1" &&
+						byName["ws."+csEnv.BaseDomain+"."] == "2001:db8::2" &&
+						byName["*.ssh.cs."+csEnv.BaseDomain+"."] == "2001:db8::3"
 				})).Return(nil)
 
 				err := bs.EnsureDNSRecords()
@@ -1358,15 +2756,26 @@ var _ = Describe("GCP Bootstrapper", func() {
 
 		Describe("Invalid cases", func() {
 			It("fails when EnsureDNSManagedZone fails", func() {
-				gc.EXPECT().EnsureDNSManagedZone(csEnv.DNSProjectID, csEnv.DNSZoneName, csEnv.BaseDomain+".", mock.Anything).Return(fmt.Errorf("zone error"))
+				gc.EXPECT().EnsureDNSManagedZone(csEnv.DNSProjectID, csEnv.DNSZoneName, csEnv.BaseDomain+".", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("zone error"))
 
 				err := bs.EnsureDNSRecords()
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("failed to ensure DNS managed zone"))
 			})
 
+			It("fails when the DS record cannot be fetched", func() {
+				csEnv.DNSSEC = true
+				gc.EXPECT().EnsureDNSManagedZone(csEnv.DNSProjectID, csEnv.DNSZoneName, csEnv.BaseDomain+".", mock.Anything, mock.Anything, true).Return(nil)
+				gc.EXPECT().EnsureDNSRecordSets(csEnv.DNSProjectID, csEnv.DNSZoneName, mock.Anything).Return(nil)
+				gc.EXPECT().GetDNSSECDSRecord(csEnv.DNSProjectID, csEnv.DNSZoneName).Return("", fmt.Errorf("no keys"))
+
+				err := bs.EnsureDNSRecords()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to get DNSSEC DS record"))
+			})
+
 			It("fails when EnsureDNSRecordSets fails", func() {
-				gc.EXPECT().EnsureDNSManagedZone(csEnv.DNSProjectID, csEnv.DNSZoneName, csEnv.BaseDomain+".", mock.Anything).Return(nil)
+				gc.EXPECT().EnsureDNSManagedZone(csEnv.DNSProjectID, csEnv.DNSZoneName, csEnv.BaseDomain+".", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				gc.EXPECT().EnsureDNSRecordSets(csEnv.DNSProjectID, csEnv.DNSZoneName, mock.Anything).Return(fmt.Errorf("record error"))
 
 				err := bs.EnsureDNSRecords()
@@ -1395,7 +2804,8 @@ var _ = Describe("GCP Bootstrapper", func() {
 
 					// Expect install codesphere
 					nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root",
-						"oms install codesphere -c /etc/codesphere/config.yaml -k /etc/codesphere/secrets/age_key.txt --vault /etc/codesphere/secrets/prod.vault.yaml -p v1.2.3-abc1234567890-installer-lite.tar.gz -s load-container-images").Return(nil)
+						installer.DetachedInstallCommand("oms install codesphere -c /etc/codesphere/config.yaml -k /etc/codesphere/secrets/age_key.txt --vault /etc/codesphere/secrets/prod.vault.yaml -p v1.2.3-abc1234567890-installer-lite.tar.gz -s load-container-images", "/root")).Return(nil)
+					nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", installer.AttachInstallCommand()).Return(nil)
 
 					err := bs.InstallCodesphere()
 					Expect(err).NotTo(HaveOccurred())
@@ -1412,7 +2822,8 @@ var _ = Describe("GCP Bootstrapper", func() {
 					nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", "oms download package -f installer.tar.gz -H def9876543210 v1.2.3").Return(nil)
 
 					// Expect install codesphere
-					nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", "oms install codesphere -c /etc/codesphere/config.yaml -k /etc/codesphere/secrets/age_key.txt --vault /etc/codesphere/secrets/prod.vault.yaml -p v1.2.3-def9876543210-installer.tar.gz").Return(nil)
+					nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", installer.DetachedInstallCommand("oms install codesphere -c /etc/codesphere/config.yaml -k /etc/codesphere/secrets/age_key.txt --vault /etc/codesphere/secrets/prod.vault.yaml -p v1.2.3-def9876543210-installer.tar.gz", "/root")).Return(nil)
+					nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", installer.AttachInstallCommand()).Return(nil)
 
 					err := bs.InstallCodesphere()
 					Expect(err).NotTo(HaveOccurred())
@@ -1421,12 +2832,27 @@ var _ = Describe("GCP Bootstrapper", func() {
 
 			It("downloads and installs codesphere with hash", func() {
 				nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", "oms download package -f installer.tar.gz -H abc1234567890 v1.2.3").Return(nil)
-				nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", "oms install codesphere -c /etc/codesphere/config.yaml -k /etc/codesphere/secrets/age_key.txt --vault /etc/codesphere/secrets/prod.vault.yaml -p v1.2.3-abc1234567890-installer.tar.gz").Return(nil)
+				nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", installer.DetachedInstallCommand("oms install codesphere -c /etc/codesphere/config.yaml -k /etc/codesphere/secrets/age_key.txt --vault /etc/codesphere/secrets/prod.vault.yaml -p v1.2.3-abc1234567890-installer.tar.gz", "/root")).Return(nil)
+				nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", installer.AttachInstallCommand()).Return(nil)
 
 				err := bs.InstallCodesphere()
 				Expect(err).NotTo(HaveOccurred())
 			})
 
+			Context("with artifact storage", func() {
+				BeforeEach(func() {
+					csEnv.ArtifactsBucket = "fake-artifacts-bucket"
+				})
+				It("downloads and extracts the package in the artifacts directory", func() {
+					nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", "cd /root/artifacts && oms download package -f installer.tar.gz -H abc1234567890 v1.2.3").Return(nil)
+					nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", installer.DetachedInstallCommand("oms install codesphere -c /etc/codesphere/config.yaml -k /etc/codesphere/secrets/age_key.txt --vault /etc/codesphere/secrets/prod.vault.yaml -p v1.2.3-abc1234567890-installer.tar.gz", "/root/artifacts")).Return(nil)
+					nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", installer.AttachInstallCommand()).Return(nil)
+
+					err := bs.InstallCodesphere()
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
 			Context("with local package", func() {
 				BeforeEach(func() {
 					csEnv.InstallLocal = "fake-installer-lite.tar.gz"
@@ -1440,7 +2866,8 @@ var _ = Describe("GCP Bootstrapper", func() {
 					It("installs codesphere from local package", func() {
 						nodeClient.EXPECT().CopyFile(mock.Anything, csEnv.InstallLocal, "/root/local-installer-lite.tar.gz").Return(nil)
 						nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root",
-							"oms install codesphere -c /etc/codesphere/config.yaml -k /etc/codesphere/secrets/age_key.txt --vault /etc/codesphere/secrets/prod.vault.yaml -p local-installer-lite.tar.gz -s load-container-images").Return(nil)
+							installer.DetachedInstallCommand("oms install codesphere -c /etc/codesphere/config.yaml -k /etc/codesphere/secrets/age_key.txt --vault /etc/codesphere/secrets/prod.vault.yaml -p local-installer-lite.tar.gz -s load-container-images", "/root")).Return(nil)
+						nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", installer.AttachInstallCommand()).Return(nil)
 
 						err := bs.InstallCodesphere()
 						Expect(err).NotTo(HaveOccurred())
@@ -1454,7 +2881,8 @@ var _ = Describe("GCP Bootstrapper", func() {
 					It("installs codesphere from local package", func() {
 						nodeClient.EXPECT().CopyFile(mock.Anything, csEnv.InstallLocal, "/root/local-installer.tar.gz").Return(nil)
 						nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root",
-							"oms install codesphere -c /etc/codesphere/config.yaml -k /etc/codesphere/secrets/age_key.txt --vault /etc/codesphere/secrets/prod.vault.yaml -p local-installer.tar.gz").Return(nil)
+							installer.DetachedInstallCommand("oms install codesphere -c /etc/codesphere/config.yaml -k /etc/codesphere/secrets/age_key.txt --vault /etc/codesphere/secrets/prod.vault.yaml -p local-installer.tar.gz", "/root")).Return(nil)
+						nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", installer.AttachInstallCommand()).Return(nil)
 
 						err := bs.InstallCodesphere()
 						Expect(err).NotTo(HaveOccurred())
@@ -1493,59 +2921,203 @@ var _ = Describe("GCP Bootstrapper", func() {
 
 				err := bs.InstallCodesphere()
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("failed to download Codesphere package from jumpbox"))
+				Expect(err.Error()).To(ContainSubstring("failed to download Codesphere package on jumpbox"))
 			})
 
 			It("fails when install codesphere fails", func() {
 				nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", "oms download package -f installer.tar.gz -H abc1234567890 v1.2.3").Return(nil).Once()
-				nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", "oms install codesphere -c /etc/codesphere/config.yaml -k /etc/codesphere/secrets/age_key.txt --vault /etc/codesphere/secrets/prod.vault.yaml -p v1.2.3-abc1234567890-installer.tar.gz").Return(fmt.Errorf("install error")).Once()
+				nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", installer.DetachedInstallCommand("oms install codesphere -c /etc/codesphere/config.yaml -k /etc/codesphere/secrets/age_key.txt --vault /etc/codesphere/secrets/prod.vault.yaml -p v1.2.3-abc1234567890-installer.tar.gz", "/root")).Return(fmt.Errorf("install error")).Once()
 
 				err := bs.InstallCodesphere()
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("failed to install Codesphere from jumpbox"))
 			})
+
+			It("records the failure cause from the install status", func() {
+				nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", "oms download package -f installer.tar.gz -H abc1234567890 v1.2.3").Return(nil).Once()
+				nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", installer.DetachedInstallCommand("oms install codesphere -c /etc/codesphere/config.yaml -k /etc/codesphere/secrets/age_key.txt --vault /etc/codesphere/secrets/prod.vault.yaml -p v1.2.3-abc1234567890-installer.tar.gz", "/root")).Return(nil).Once()
+				nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", installer.AttachInstallCommand()).Return(fmt.Errorf("unit failed")).Once()
+
+				nodeClient.EXPECT().DownloadFile(mock.MatchedBy(jumpboxMatcher), installer.InstallStatusPath, "install-status.json").Return(nil).Once()
+				fw.EXPECT().ReadFile("install-status.json").Return([]byte(`{
+					"state": "failed",
+					"error": "install failed",
+					"phases": [
+						{"name": "prepare", "state": "succeeded"},
+						{"name": "load-container-images", "state": "failed", "error": "registry unreachable"}
+					]
+				}`), nil)
+
+				// The failure is recorded in the infra file.
+				fw.EXPECT().MkdirAll(mock.Anything, os.FileMode(0755)).Return(nil)
+				fw.EXPECT().WriteFile(mock.Anything, mock.MatchedBy(func(content []byte) bool {
+					return strings.Contains(string(content), "last_failure")
+				}), os.FileMode(0644)).Return(nil)
+
+				err := bs.InstallCodesphere()
+				Expect(err).To(MatchError(ContainSubstring("install failed at step load-container-images: registry unreachable")))
+				Expect(csEnv.LastFailure).NotTo(BeNil())
+				Expect(csEnv.LastFailure.Step).To(Equal("load-container-images"))
+				Expect(csEnv.LastFailure.Reason).To(Equal("registry unreachable"))
+			})
+		})
+	})
+
+	Describe("EnsureArtifactStorage", func() {
+		It("mounts the configured bucket on the jumpbox", func() {
+			csEnv.ArtifactsBucket = "fake-artifacts-bucket"
+			fw.EXPECT().WriteFile("artifact-storage.sh", mock.MatchedBy(func(script []byte) bool {
+				return strings.Contains(string(script), "gcsfuse --implicit-dirs fake-artifacts-bucket /root/artifacts")
+			}), os.FileMode(0755)).Return(nil)
+			nodeClient.EXPECT().CopyFile(mock.MatchedBy(jumpboxMatcher), "artifact-storage.sh", "/root/artifact-storage.sh").Return(nil)
+			nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", "chmod +x /root/artifact-storage.sh && /root/artifact-storage.sh").Return(nil)
+
+			err := bs.EnsureArtifactStorage()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("formats and mounts the extra disk on the jumpbox", func() {
+			csEnv.ArtifactsDiskGB = 200
+			fw.EXPECT().WriteFile("artifact-storage.sh", mock.MatchedBy(func(script []byte) bool {
+				return strings.Contains(string(script), "mkfs.ext4") &&
+					strings.Contains(string(script), "mount /root/artifacts")
+			}), os.FileMode(0755)).Return(nil)
+			nodeClient.EXPECT().CopyFile(mock.MatchedBy(jumpboxMatcher), "artifact-storage.sh", "/root/artifact-storage.sh").Return(nil)
+			nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", "chmod +x /root/artifact-storage.sh && /root/artifact-storage.sh").Return(nil)
+
+			err := bs.EnsureArtifactStorage()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("fails when the mount script fails on the jumpbox", func() {
+			csEnv.ArtifactsBucket = "fake-artifacts-bucket"
+			fw.EXPECT().WriteFile("artifact-storage.sh", mock.Anything, os.FileMode(0755)).Return(nil)
+			nodeClient.EXPECT().CopyFile(mock.MatchedBy(jumpboxMatcher), "artifact-storage.sh", "/root/artifact-storage.sh").Return(nil)
+			nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", "chmod +x /root/artifact-storage.sh && /root/artifact-storage.sh").Return(fmt.Errorf("mount error"))
+
+			err := bs.EnsureArtifactStorage()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to mount artifact storage on jumpbox"))
 		})
 	})
 
-	Describe("GenerateK0sConfigScript", func() {
-		Describe("Valid GenerateK0sConfigScript", func() {
-			It("generates script", func() {
-				fw.EXPECT().WriteFile("configure-k0s.sh", mock.Anything, os.FileMode(0755)).Return(nil)
-				nodeClient.EXPECT().CopyFile(bs.Env.ControlPlaneNodes[0], "configure-k0s.sh", "/root/configure-k0s.sh").Return(nil)
-				nodeClient.EXPECT().RunCommand(bs.Env.ControlPlaneNodes[0], "root", "chmod +x /root/configure-k0s.sh").Return(nil)
+	Describe("ConfigureCloudController", func() {
+		const k0sKubectl = "/etc/codesphere/deps/kubernetes/files/k0s kubectl"
+		const defaultCCMImage = "k8scloudprovidergcp/cloud-controller-manager:" + gcp.DefaultCCMVersion
+
+		var mockImages *gcp.MockImageChecker
+
+		BeforeEach(func() {
+			csEnv.PublicGatewayIP = "5.6.7.8"
+			csEnv.GatewayIP = "9.9.9.9"
+			mockImages = gcp.NewMockImageChecker(GinkgoT())
+		})
+
+		JustBeforeEach(func() {
+			bs.Images = mockImages
+		})
+
+		// expectCloudConfigStaged matches the rendered cloud.conf being written
+		// locally, staged on the controller and loaded into the configmap.
+		expectCloudConfigStaged := func(kubectl string) {
+			fw.EXPECT().WriteFile("cloud.conf", mock.MatchedBy(func(conf []byte) bool {
+				return strings.Contains(string(conf), `project-id = "pid"`)
+			}), os.FileMode(0644)).Return(nil)
+			nodeClient.EXPECT().CopyFile(bs.Env.ControlPlaneNodes[0], "cloud.conf", "/root/cloud.conf").Return(nil)
+			nodeClient.EXPECT().RunCommand(bs.Env.ControlPlaneNodes[0], "root", kubectl+" create configmap cloud-config --from-file=cloud.conf=/root/cloud.conf -n kube-system").Return(nil)
+		}
+
+		// expectClusterConfigured matches the alias setup, the RBAC bundle,
+		// the rendered DaemonSet and the gateway service patches.
+		expectClusterConfigured := func(kubectl, leaderElectName, image, manifestURL string) {
+			nodeClient.EXPECT().RunCommand(bs.Env.ControlPlaneNodes[0], "root", fmt.Sprintf(`echo alias kubectl=\"%s\" >> /root/.bashrc && echo alias k=\"%s\" >> /root/.bashrc`, kubectl, kubectl)).Return(nil)
+			nodeClient.EXPECT().RunCommand(bs.Env.ControlPlaneNodes[0], "root", kubectl+" apply -f "+manifestURL).Return(nil)
+			fw.EXPECT().WriteFile("cc-deployment.yaml", mock.MatchedBy(func(manifest []byte) bool {
+				return strings.Contains(string(manifest), "image: "+image) &&
+					strings.Contains(string(manifest), "--leader-elect-resource-name="+leaderElectName)
+			}), os.FileMode(0644)).Return(nil)
+			nodeClient.EXPECT().CopyFile(bs.Env.ControlPlaneNodes[0], "cc-deployment.yaml", "/root/cc-deployment.yaml").Return(nil)
+			nodeClient.EXPECT().RunCommand(bs.Env.ControlPlaneNodes[0], "root", kubectl+" apply -f /root/cc-deployment.yaml").Return(nil)
+			nodeClient.EXPECT().RunCommand(bs.Env.ControlPlaneNodes[0], "root", kubectl+` patch svc public-gateway-controller -n codesphere -p '{"spec": {"loadBalancerIP": "5.6.7.8"}}'`).Return(nil)
+			nodeClient.EXPECT().RunCommand(bs.Env.ControlPlaneNodes[0], "root", kubectl+` patch svc gateway-controller -n codesphere -p '{"spec": {"loadBalancerIP": "9.9.9.9"}}'`).Return(nil)
+		}
+
+		Describe("Valid ConfigureCloudController", func() {
+			It("applies the rendered manifests and patches the k0s units", func() {
+				mockImages.EXPECT().ImageExists(defaultCCMImage).Return(nil)
+				expectCloudConfigStaged(k0sKubectl)
+				expectClusterConfigured(k0sKubectl, "k0s-gcp-ccm", defaultCCMImage, "https://raw.githubusercontent.com/kubernetes/cloud-provider-gcp/refs/tags/providers/"+gcp.DefaultCCMVersion+"/deploy/packages/default/manifest.yaml")
+				nodeClient.EXPECT().RunCommand(bs.Env.ControlPlaneNodes[0], "root", mock.MatchedBy(func(cmd string) bool {
+					return strings.Contains(cmd, "k0scontroller --enable-cloud-provider")
+				})).Return(nil)
+				for _, worker := range bs.Env.ControlPlaneNodes[1:] {
+					nodeClient.EXPECT().RunCommand(worker, "root", mock.MatchedBy(func(cmd string) bool {
+						return strings.Contains(cmd, "k0sworker --enable-cloud-provider")
+					})).Return(nil)
+				}
+
+				err := bs.ConfigureCloudController()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("derives the image and manifest from the selected CCM version", func() {
+				csEnv.K8sDistribution = gcp.K8sDistributionK3s
+				csEnv.CCMVersion = "v0.30.0"
 
-				err := bs.GenerateK0sConfigScript()
+				mockImages.EXPECT().ImageExists("k8scloudprovidergcp/cloud-controller-manager:v0.30.0").Return(nil)
+				expectCloudConfigStaged("k3s kubectl")
+				expectClusterConfigured("k3s kubectl", "k3s-gcp-ccm", "k8scloudprovidergcp/cloud-controller-manager:v0.30.0", "https://raw.githubusercontent.com/kubernetes/cloud-provider-gcp/refs/tags/providers/v0.30.0/deploy/packages/default/manifest.yaml")
+
+				err := bs.ConfigureCloudController()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("honors the image and manifest overrides", func() {
+				csEnv.K8sDistribution = gcp.K8sDistributionK3s
+				csEnv.CCMImage = "mirror.example.com/ccm:v0.30.0"
+				csEnv.CCMManifestURL = "https://mirror.example.com/ccm-manifest.yaml"
+
+				mockImages.EXPECT().ImageExists("mirror.example.com/ccm:v0.30.0").Return(nil)
+				expectCloudConfigStaged("k3s kubectl")
+				expectClusterConfigured("k3s kubectl", "k3s-gcp-ccm", "mirror.example.com/ccm:v0.30.0", "https://mirror.example.com/ccm-manifest.yaml")
+
+				err := bs.ConfigureCloudController()
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
 
 		Describe("Invalid cases", func() {
-			It("fails when WriteFile fails", func() {
-				fw.EXPECT().WriteFile("configure-k0s.sh", mock.Anything, os.FileMode(0755)).Return(fmt.Errorf("write error"))
+			It("fails when the CCM image is missing from its registry", func() {
+				mockImages.EXPECT().ImageExists(defaultCCMImage).Return(fmt.Errorf("MANIFEST_UNKNOWN"))
 
-				err := bs.GenerateK0sConfigScript()
+				err := bs.ConfigureCloudController()
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("failed to write configure-k0s.sh"))
+				Expect(err.Error()).To(ContainSubstring("cloud controller manager image " + defaultCCMImage + " not found in its registry"))
 			})
 
-			It("fails when CopyFile fails", func() {
-				fw.EXPECT().WriteFile("configure-k0s.sh", mock.Anything, os.FileMode(0755)).Return(nil)
-				nodeClient.EXPECT().CopyFile(mock.Anything, "configure-k0s.sh", "/root/configure-k0s.sh").Return(fmt.Errorf("copy error"))
+			It("fails when writing cloud.conf fails", func() {
+				mockImages.EXPECT().ImageExists(defaultCCMImage).Return(nil)
+				fw.EXPECT().WriteFile("cloud.conf", mock.Anything, os.FileMode(0644)).Return(fmt.Errorf("write error"))
 
-				err := bs.GenerateK0sConfigScript()
+				err := bs.ConfigureCloudController()
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("failed to copy configure-k0s.sh to control plane node"))
+				Expect(err.Error()).To(ContainSubstring("failed to write cloud.conf"))
 			})
 
-			It("fails when RunSSHCommand chmod fails", func() {
-				fw.EXPECT().WriteFile("configure-k0s.sh", mock.Anything, os.FileMode(0755)).Return(nil)
-
-				nodeClient.EXPECT().CopyFile(bs.Env.ControlPlaneNodes[0], "configure-k0s.sh", "/root/configure-k0s.sh").Return(nil)
-				nodeClient.EXPECT().RunCommand(bs.Env.ControlPlaneNodes[0], "root", "chmod +x /root/configure-k0s.sh").Return(fmt.Errorf("chmod error"))
+			It("fails when a worker unit cannot be patched", func() {
+				mockImages.EXPECT().ImageExists(defaultCCMImage).Return(nil)
+				expectCloudConfigStaged(k0sKubectl)
+				expectClusterConfigured(k0sKubectl, "k0s-gcp-ccm", defaultCCMImage, "https://raw.githubusercontent.com/kubernetes/cloud-provider-gcp/refs/tags/providers/"+gcp.DefaultCCMVersion+"/deploy/packages/default/manifest.yaml")
+				nodeClient.EXPECT().RunCommand(bs.Env.ControlPlaneNodes[0], "root", mock.MatchedBy(func(cmd string) bool {
+					return strings.Contains(cmd, "k0scontroller --enable-cloud-provider")
+				})).Return(nil)
+				nodeClient.EXPECT().RunCommand(bs.Env.ControlPlaneNodes[1], "root", mock.MatchedBy(func(cmd string) bool {
+					return strings.Contains(cmd, "k0sworker --enable-cloud-provider")
+				})).Return(fmt.Errorf("sed error"))
 
-				err := bs.GenerateK0sConfigScript()
+				err := bs.ConfigureCloudController()
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("failed to make configure-k0s.sh executable"))
+				Expect(err.Error()).To(ContainSubstring("failed to enable cloud provider on worker k0s-2"))
 			})
 		})
 	})
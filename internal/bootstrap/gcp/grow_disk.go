@@ -0,0 +1,112 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+)
+
+// growFilesystemScript expands the root partition and filesystem after the
+// underlying disk was resized. growpart exits 1 when the partition already
+// fills the disk, which is fine when only the filesystem still needs growing.
+const growFilesystemScript = `#!/bin/bash
+set -euo pipefail
+
+ROOT_SRC=$(findmnt -n -o SOURCE /)
+DISK="/dev/$(lsblk -no PKNAME "$ROOT_SRC")"
+PARTNUM=$(lsblk -no PARTN "$ROOT_SRC" | tr -d '[:space:]')
+
+growpart "$DISK" "$PARTNUM" || [ $? -eq 1 ]
+
+case "$(findmnt -n -o FSTYPE /)" in
+xfs)
+	xfs_growfs /
+	;;
+*)
+	resize2fs "$ROOT_SRC"
+	;;
+esac
+`
+
+// rootDiskSize returns the root disk size for a VM, honoring a recorded
+// per-node override from an earlier grow-disk run.
+func (b *GCPBootstrapper) rootDiskSize(vmName string) int64 {
+	if size, ok := b.Env.DiskSizes[vmName]; ok {
+		return size
+	}
+	return b.Env.RootDiskSize
+}
+
+// GrowDisk resizes the root disk of the named node to sizeGB, grows the
+// partition and filesystem on the node via SSH, and records the new size in
+// the infra file so the topology stays accurate.
+func (b *GCPBootstrapper) GrowDisk(nodeName string, sizeGB int64) error {
+	var target *node.Node
+	names := []string{}
+	for _, n := range b.allNodes() {
+		if n.GetName() == nodeName {
+			target = n
+			break
+		}
+		names = append(names, n.GetName())
+	}
+	if target == nil {
+		return fmt.Errorf("unknown node %s; environment has: %s", nodeName, strings.Join(names, ", "))
+	}
+
+	instance, err := b.GCPClient.GetInstance(b.Env.ProjectID, b.Env.Zone, nodeName)
+	if err != nil {
+		return fmt.Errorf("failed to get instance %s: %w", nodeName, err)
+	}
+	var bootDisk *computepb.AttachedDisk
+	for _, disk := range instance.GetDisks() {
+		if disk.GetBoot() {
+			bootDisk = disk
+			break
+		}
+	}
+	if bootDisk == nil {
+		return fmt.Errorf("instance %s has no boot disk", nodeName)
+	}
+	if bootDisk.GetDiskSizeGb() >= sizeGB {
+		return fmt.Errorf("disk of %s is already %d GB; disks can only grow", nodeName, bootDisk.GetDiskSizeGb())
+	}
+	diskName := path.Base(bootDisk.GetSource())
+
+	b.stlog.Logf("Resizing disk %s from %d GB to %d GB", diskName, bootDisk.GetDiskSizeGb(), sizeGB)
+	err = b.GCPClient.ResizeDisk(b.Env.ProjectID, b.Env.Zone, diskName, sizeGB)
+	if err != nil {
+		return fmt.Errorf("failed to resize disk %s: %w", diskName, err)
+	}
+
+	err = b.fw.WriteFile("grow-disk.sh", []byte(growFilesystemScript), 0755)
+	if err != nil {
+		return fmt.Errorf("failed to write grow-disk.sh: %w", err)
+	}
+	err = target.NodeClient.CopyFile(target, "grow-disk.sh", "/root/grow-disk.sh")
+	if err != nil {
+		return fmt.Errorf("failed to copy grow-disk.sh to %s: %w", nodeName, err)
+	}
+	err = target.RunSSHCommand("root", "chmod +x /root/grow-disk.sh && /root/grow-disk.sh")
+	if err != nil {
+		return fmt.Errorf("failed to grow filesystem on %s: %w", nodeName, err)
+	}
+
+	if b.Env.DiskSizes == nil {
+		b.Env.DiskSizes = map[string]int64{}
+	}
+	b.Env.DiskSizes[nodeName] = sizeGB
+	err = b.WriteInfraFile()
+	if err != nil {
+		return fmt.Errorf("failed to record new disk size: %w", err)
+	}
+
+	b.stlog.Logf("Disk of %s grown to %d GB", nodeName, sizeGB)
+	return nil
+}
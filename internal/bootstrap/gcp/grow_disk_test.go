@@ -0,0 +1,116 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/codesphere-cloud/oms/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("GrowDisk", func() {
+	var (
+		nodeClient *node.MockNodeClient
+		gc         *gcp.MockGCPClientManager
+		fw         *util.MockFileIO
+		csEnv      *gcp.CodesphereEnvironment
+
+		bs *gcp.GCPBootstrapper
+	)
+
+	// instanceWithBootDisk returns a running instance whose boot disk has the
+	// given name and size.
+	instanceWithBootDisk := func(diskName string, sizeGB int64) *computepb.Instance {
+		boot := true
+		inst := makeRunningInstance("10.0.0.1", "1.2.3.4")
+		inst.Disks = []*computepb.AttachedDisk{
+			{
+				Boot:       &boot,
+				Source:     protoString("projects/pid/zones/us-central1-a/disks/" + diskName),
+				DiskSizeGb: &sizeGB,
+			},
+		}
+		return inst
+	}
+
+	JustBeforeEach(func() {
+		bs = newTestBootstrapperWithFileIO(csEnv, gc, fw)
+	})
+
+	BeforeEach(func() {
+		nodeClient = node.NewMockNodeClient(GinkgoT())
+		gc = gcp.NewMockGCPClientManager(GinkgoT())
+		fw = util.NewMockFileIO(GinkgoT())
+
+		csEnv = &gcp.CodesphereEnvironment{
+			ProjectID:      "pid",
+			Zone:           "us-central1-a",
+			RootDiskSize:   50,
+			Jumpbox:        fakeNode("jumpbox", nodeClient),
+			PostgreSQLNode: fakeNode("postgres", nodeClient),
+			ControlPlaneNodes: []*node.Node{
+				fakeNode("k0s-1", nodeClient),
+			},
+		}
+	})
+
+	It("resizes the disk, grows the filesystem and records the new size", func() {
+		gc.EXPECT().GetInstance("pid", "us-central1-a", "postgres").Return(instanceWithBootDisk("postgres", 50), nil)
+		gc.EXPECT().ResizeDisk("pid", "us-central1-a", "postgres", int64(500)).Return(nil)
+
+		fw.EXPECT().WriteFile("grow-disk.sh", mock.MatchedBy(func(script []byte) bool {
+			return strings.Contains(string(script), "growpart")
+		}), os.FileMode(0755)).Return(nil)
+		nodeClient.EXPECT().CopyFile(mock.MatchedBy(func(n *node.Node) bool { return n.GetName() == "postgres" }), "grow-disk.sh", "/root/grow-disk.sh").Return(nil)
+		nodeClient.EXPECT().RunCommand(mock.MatchedBy(func(n *node.Node) bool { return n.GetName() == "postgres" }), "root", "chmod +x /root/grow-disk.sh && /root/grow-disk.sh").Return(nil)
+
+		// Infra file update recording the new size.
+		fw.EXPECT().MkdirAll(mock.Anything, os.FileMode(0755)).Return(nil)
+		fw.EXPECT().WriteFile(mock.Anything, mock.MatchedBy(func(content []byte) bool {
+			return strings.Contains(string(content), "disk_sizes")
+		}), os.FileMode(0644)).Return(nil)
+
+		Expect(bs.GrowDisk("postgres", 500)).To(Succeed())
+		Expect(csEnv.DiskSizes).To(HaveKeyWithValue("postgres", int64(500)))
+	})
+
+	It("rejects an unknown node", func() {
+		err := bs.GrowDisk("ceph-9", 500)
+		Expect(err).To(MatchError(ContainSubstring("unknown node ceph-9")))
+	})
+
+	It("rejects shrinking the disk", func() {
+		gc.EXPECT().GetInstance("pid", "us-central1-a", "postgres").Return(instanceWithBootDisk("postgres", 500), nil)
+
+		err := bs.GrowDisk("postgres", 100)
+		Expect(err).To(MatchError(ContainSubstring("disks can only grow")))
+	})
+
+	It("fails when the resize fails", func() {
+		gc.EXPECT().GetInstance("pid", "us-central1-a", "postgres").Return(instanceWithBootDisk("postgres", 50), nil)
+		gc.EXPECT().ResizeDisk("pid", "us-central1-a", "postgres", int64(500)).Return(fmt.Errorf("quota exceeded"))
+
+		err := bs.GrowDisk("postgres", 500)
+		Expect(err).To(MatchError(ContainSubstring("failed to resize disk postgres")))
+	})
+
+	It("fails when growing the filesystem fails", func() {
+		gc.EXPECT().GetInstance("pid", "us-central1-a", "postgres").Return(instanceWithBootDisk("postgres", 50), nil)
+		gc.EXPECT().ResizeDisk("pid", "us-central1-a", "postgres", int64(500)).Return(nil)
+		fw.EXPECT().WriteFile("grow-disk.sh", mock.Anything, os.FileMode(0755)).Return(nil)
+		nodeClient.EXPECT().CopyFile(mock.Anything, "grow-disk.sh", "/root/grow-disk.sh").Return(nil)
+		nodeClient.EXPECT().RunCommand(mock.Anything, "root", mock.Anything).Return(fmt.Errorf("growpart failed"))
+
+		err := bs.GrowDisk("postgres", 500)
+		Expect(err).To(MatchError(ContainSubstring("failed to grow filesystem on postgres")))
+	})
+})
@@ -0,0 +1,319 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/template"
+	"time"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/codesphere-cloud/oms/internal/redact"
+	"github.com/codesphere-cloud/oms/internal/util"
+	"github.com/lithammer/shortuuid"
+)
+
+//go:embed manifests/harbor/harbor.yml
+var harborConfigTemplate []byte
+
+//go:embed manifests/harbor/create_robot.sh
+var harborRobotScriptTemplate []byte
+
+const (
+	// HarborVMName is the name of the dedicated registry VM.
+	HarborVMName = "harbor"
+
+	// defaultHarborMachineType sizes the Harbor VM.
+	defaultHarborMachineType = "e2-standard-2"
+
+	// DefaultHarborVersion is the Harbor release installed when no version is
+	// selected.
+	DefaultHarborVersion = "v2.13.1"
+
+	// DefaultHarborDiskGB sizes the Harbor VM disk holding /data, where the
+	// image layers and the registry database live.
+	DefaultHarborDiskGB = 200
+
+	// harborProject is the Harbor project holding the Codesphere images.
+	harborProject = "codesphere"
+
+	// harborRobotName is the short name of the robot account; Harbor exposes
+	// it for docker login as "robot$<name>".
+	harborRobotName = "oms"
+
+	// harborRobotCredentialsPath is where the robot creation script leaves the
+	// generated credentials on the VM.
+	harborRobotCredentialsPath = "/root/harbor-robot.json"
+
+	harborInstallDir      = "/opt/harbor"
+	harborCertificatePath = "/root/harbor-certs/harbor.crt"
+	harborPrivateKeyPath  = "/root/harbor-certs/harbor.key"
+)
+
+// harborVersion returns the selected Harbor release, falling back to the
+// default.
+func (b *GCPBootstrapper) harborVersion() string {
+	if b.Env.HarborVersion != "" {
+		return b.Env.HarborVersion
+	}
+	return DefaultHarborVersion
+}
+
+// harborDownloadURL returns the online installer bundle of the selected
+// Harbor release.
+func (b *GCPBootstrapper) harborDownloadURL() string {
+	version := b.harborVersion()
+	return fmt.Sprintf("https://github.com/goharbor/harbor/releases/download/%s/harbor-online-installer-%s.tgz", version, version)
+}
+
+// renderHarborConfig renders the harbor.yml installed on the Harbor VM.
+func (b *GCPBootstrapper) renderHarborConfig(hostname, adminPassword, databasePassword string) ([]byte, error) {
+	tmpl, err := template.New("harbor.yml").Parse(string(harborConfigTemplate))
+	if err != nil {
+		return nil, fmt.Errorf("parsing harbor config template: %w", err)
+	}
+
+	data := struct {
+		Hostname         string
+		CertificatePath  string
+		PrivateKeyPath   string
+		AdminPassword    string
+		DatabasePassword string
+	}{
+		Hostname:         hostname,
+		CertificatePath:  harborCertificatePath,
+		PrivateKeyPath:   harborPrivateKeyPath,
+		AdminPassword:    adminPassword,
+		DatabasePassword: databasePassword,
+	}
+
+	buf := bytes.Buffer{}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("rendering harbor config template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// renderHarborRobotScript renders the script creating the Codesphere project
+// and the OMS robot account through the Harbor API.
+func (b *GCPBootstrapper) renderHarborRobotScript(hostname string) ([]byte, error) {
+	tmpl, err := template.New("create_robot.sh").Parse(string(harborRobotScriptTemplate))
+	if err != nil {
+		return nil, fmt.Errorf("parsing harbor robot script template: %w", err)
+	}
+
+	data := struct {
+		Hostname        string
+		Project         string
+		RobotName       string
+		CredentialsPath string
+	}{
+		Hostname:        hostname,
+		Project:         harborProject,
+		RobotName:       harborRobotName,
+		CredentialsPath: harborRobotCredentialsPath,
+	}
+
+	buf := bytes.Buffer{}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("rendering harbor robot script template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// harborSecret returns the named secret from the vault, generating and
+// storing a fresh one on first use so re-runs keep the same credentials.
+func (b *GCPBootstrapper) harborSecret(name string) string {
+	if s := b.icg.GetVault().GetSecret(name); s != nil && s.Fields != nil && s.Fields.Password != "" {
+		redact.Add(s.Fields.Password)
+		return s.Fields.Password
+	}
+	secret := shortuuid.New()
+	redact.Add(secret)
+	b.icg.GetVault().SetSecret(files.SecretEntry{Name: name, Fields: &files.SecretFields{Password: secret}})
+	return secret
+}
+
+// EnsureHarborRegistry provisions a dedicated VM running Harbor with TLS and
+// its data on the VM's persistent disk, creates a robot account scoped to the
+// Codesphere project, and wires the robot credentials into the install config.
+func (b *GCPBootstrapper) EnsureHarborRegistry() error {
+	diskGB := b.Env.HarborDiskGB
+	if diskGB == 0 {
+		diskGB = DefaultHarborDiskGB
+	}
+
+	vm := VMDef{Name: HarborVMName, MachineType: defaultHarborMachineType, Tags: []string{HarborVMName}}
+	logCh := make(chan string, 4)
+	result, err := b.ensureVM(vm, diskGB, DefaultSourceImage, []string{DefaultServiceAccountScope}, logCh)
+	close(logCh)
+	for msg := range logCh {
+		b.stlog.Logf("%s", msg)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to ensure harbor VM: %w", err)
+	}
+
+	harbor := b.opsNode().CreateSubNode(result.name, result.externalIP, result.internalIP)
+	if b.Env.NoJumpbox {
+		// Without a jumpbox there is no hop to proxy through; the IAP tunnel
+		// reaches the harbor VM directly.
+		harbor.Jumpbox = nil
+	}
+	b.Env.HarborNode = harbor
+
+	if err := harbor.NodeClient.WaitReady(harbor, 30*time.Second); err != nil {
+		return fmt.Errorf("harbor VM did not become reachable: %w", err)
+	}
+
+	server := harbor.GetInternalIP()
+	registryUsername := ""
+	registryPassword := ""
+	if s := b.icg.GetVault().GetSecret(files.SecretRegistryUsername); s != nil && s.Fields != nil {
+		registryUsername = s.Fields.Password
+	}
+	if s := b.icg.GetVault().GetSecret(files.SecretRegistryPassword); s != nil && s.Fields != nil {
+		registryPassword = s.Fields.Password
+		redact.Add(registryPassword)
+	}
+	if harbor.NodeClient.HasFile(harbor, harborInstallDir+"/harbor.yml") &&
+		b.Env.InstallConfig.Registry != nil && b.Env.InstallConfig.Registry.Server == server &&
+		registryUsername != "" && registryPassword != "" {
+		b.stlog.Logf("Harbor is already running on %s", server)
+		return nil
+	}
+
+	b.Env.InstallConfig.Registry.Server = server
+	adminPassword := b.harborSecret("harborAdminPassword")
+	databasePassword := b.harborSecret("harborDatabasePassword")
+
+	nodeOS := harbor.OS()
+	setupCommands := []string{
+		nodeOS.RefreshPackagesCommand(),
+		nodeOS.InstallPackagesCommand("docker.io", "docker-compose-v2"),
+		"mkdir -p /root/harbor-certs /data",
+		fmt.Sprintf("openssl req -newkey rsa:4096 -nodes -sha256 -keyout %s -x509 -days 365 -out %s -subj \"/C=DE/ST=BW/L=Karlsruhe/O=Codesphere/CN=%s\" -addext \"subjectAltName = DNS:%s,IP:%s\"",
+			harborPrivateKeyPath, harborCertificatePath, server, HarborVMName, server),
+		fmt.Sprintf("curl -fsSL %s | tar -xz -C /opt", b.harborDownloadURL()),
+	}
+	for _, cmd := range setupCommands {
+		b.stlog.Logf("Running command on harbor VM: %s", util.Truncate(cmd, 12))
+		if err := harbor.RunSSHCommand("root", cmd); err != nil {
+			return fmt.Errorf("failed to run command on harbor VM: %w", err)
+		}
+	}
+
+	config, err := b.renderHarborConfig(server, adminPassword, databasePassword)
+	if err != nil {
+		return err
+	}
+	if err := b.stageHarborFile(harbor, "harbor.yml", harborInstallDir+"/harbor.yml", config); err != nil {
+		return err
+	}
+
+	b.stlog.Logf("Running the Harbor installer on %s", server)
+	if err := harbor.RunSSHCommand("root", "cd "+harborInstallDir+" && ./install.sh"); err != nil {
+		return fmt.Errorf("failed to install Harbor: %w", err)
+	}
+
+	script, err := b.renderHarborRobotScript(server)
+	if err != nil {
+		return err
+	}
+	if err := b.stageHarborFile(harbor, "create-harbor-robot.sh", "/root/create-harbor-robot.sh", script); err != nil {
+		return err
+	}
+	// The admin password goes in on stdin so it never appears in a command
+	// line, process listing or log.
+	if err := harbor.RunSSHCommandWithInput("root", "sh /root/create-harbor-robot.sh", adminPassword+"\n"); err != nil {
+		return fmt.Errorf("failed to create harbor robot account: %w", err)
+	}
+
+	robotName, robotSecret, err := b.downloadHarborRobotCredentials(harbor)
+	if err != nil {
+		return err
+	}
+	b.icg.GetVault().SetSecret(files.SecretEntry{Name: files.SecretRegistryUsername, Fields: &files.SecretFields{Password: robotName}})
+	b.icg.GetVault().SetSecret(files.SecretEntry{Name: files.SecretRegistryPassword, Fields: &files.SecretFields{Password: robotSecret}})
+
+	if err := b.distributeHarborCertificate(harbor); err != nil {
+		return err
+	}
+
+	b.stlog.Logf("Harbor registry available at %s with robot account %s", server, robotName)
+	return nil
+}
+
+// stageHarborFile writes the rendered file to the local working directory and
+// copies it onto the Harbor VM.
+func (b *GCPBootstrapper) stageHarborFile(harbor *node.Node, filename, remotePath string, content []byte) error {
+	if err := b.fw.WriteFile(filename, content, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+	if err := harbor.NodeClient.CopyFile(harbor, filename, remotePath); err != nil {
+		return fmt.Errorf("failed to copy %s to harbor VM: %w", filename, err)
+	}
+	return nil
+}
+
+// downloadHarborRobotCredentials reads the robot account credentials the
+// creation script left on the VM.
+func (b *GCPBootstrapper) downloadHarborRobotCredentials(harbor *node.Node) (name string, secret string, err error) {
+	credsFile, err := os.CreateTemp("", "harbor-robot-*.json")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create temp file for harbor robot credentials: %w", err)
+	}
+	defer util.IgnoreError(func() error { return os.Remove(credsFile.Name()) })
+	util.CloseFileIgnoreError(credsFile)
+	if err := harbor.NodeClient.DownloadFile(harbor, harborRobotCredentialsPath, credsFile.Name()); err != nil {
+		return "", "", fmt.Errorf("failed to download harbor robot credentials: %w", err)
+	}
+
+	data, err := os.ReadFile(credsFile.Name())
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read harbor robot credentials: %w", err)
+	}
+	robot := struct {
+		Name   string `json:"name"`
+		Secret string `json:"secret"`
+	}{}
+	if err := json.Unmarshal(data, &robot); err != nil {
+		return "", "", fmt.Errorf("failed to parse harbor robot credentials: %w", err)
+	}
+	if robot.Name == "" || robot.Secret == "" {
+		return "", "", fmt.Errorf("harbor robot account response contains no credentials")
+	}
+	redact.Add(robot.Secret)
+	return robot.Name, robot.Secret, nil
+}
+
+// distributeHarborCertificate makes the cluster and ceph nodes trust the
+// self-signed Harbor certificate.
+func (b *GCPBootstrapper) distributeHarborCertificate(harbor *node.Node) error {
+	certFile, err := os.CreateTemp("", "harbor-*.crt")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file for harbor certificate: %w", err)
+	}
+	defer util.IgnoreError(func() error { return os.Remove(certFile.Name()) })
+	util.CloseFileIgnoreError(certFile)
+	if err := harbor.NodeClient.DownloadFile(harbor, harborCertificatePath, certFile.Name()); err != nil {
+		return fmt.Errorf("failed to download harbor certificate: %w", err)
+	}
+
+	allNodes := append(b.Env.ControlPlaneNodes, b.Env.CephNodes...)
+	b.stlog.Logf("Configuring %d nodes to trust the Harbor certificate", len(allNodes))
+	tasks := make([]bootstrap.NodeTask, 0, len(allNodes))
+	for _, node := range allNodes {
+		tasks = append(tasks, bootstrap.NodeTask{Name: node.GetName(), Run: func() error {
+			return b.distributeRegistryCert(node, certFile.Name())
+		}})
+	}
+	return bootstrap.RunNodeTasks(b.Env.NodeParallelism, tasks)
+}
@@ -0,0 +1,151 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/cloudflare"
+	"github.com/codesphere-cloud/oms/internal/env"
+	"github.com/codesphere-cloud/oms/internal/github"
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/codesphere-cloud/oms/internal/portal"
+	"github.com/codesphere-cloud/oms/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("Harbor registry", func() {
+	var (
+		nodeClient *node.MockNodeClient
+		gc         *gcp.MockGCPClientManager
+		icg        *installer.MockInstallConfigManager
+		fw         *util.MockFileIO
+		vault      *files.InstallVault
+		csEnv      *gcp.CodesphereEnvironment
+
+		bs *gcp.GCPBootstrapper
+	)
+
+	JustBeforeEach(func() {
+		var err error
+		bs, err = gcp.NewGCPBootstrapper(
+			context.Background(),
+			env.NewEnv(),
+			bootstrap.NewStepLogger(false),
+			csEnv,
+			icg,
+			gc,
+			fw,
+			nodeClient,
+			portal.NewMockPortal(GinkgoT()),
+			util.NewFakeTime(),
+			github.NewMockGitHubClient(GinkgoT()),
+			cloudflare.NewMockCloudflareClient(GinkgoT()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	BeforeEach(func() {
+		nodeClient = node.NewMockNodeClient(GinkgoT())
+		gc = gcp.NewMockGCPClientManager(GinkgoT())
+		icg = installer.NewMockInstallConfigManager(GinkgoT())
+		fw = util.NewMockFileIO(GinkgoT())
+		vault = &files.InstallVault{}
+		icg.EXPECT().GetVault().Return(vault)
+
+		csEnv = &gcp.CodesphereEnvironment{
+			ProjectID:         "fake-project",
+			Zone:              "europe-west3-a",
+			RegistryType:      gcp.RegistryTypeHarbor,
+			Jumpbox:           fakeNode("jumpbox", nodeClient),
+			ControlPlaneNodes: []*node.Node{fakeNode("k0s-1", nodeClient)},
+			InstallConfig:     &files.RootConfig{Registry: &files.RegistryConfig{}},
+		}
+	})
+
+	Describe("EnsureHarborRegistry", func() {
+		var (
+			commandsMu sync.Mutex
+			commands   []string
+		)
+
+		BeforeEach(func() {
+			commands = nil
+
+			gc.EXPECT().GetInstance("fake-project", "europe-west3-a", "harbor").
+				Return(makeRunningInstance("10.0.0.7", ""), nil)
+			nodeClient.EXPECT().WaitReady(mock.Anything, mock.Anything).Return(nil)
+		})
+
+		It("skips the installation when Harbor is already running", func() {
+			vault.SetSecret(files.SecretEntry{Name: files.SecretRegistryUsername, Fields: &files.SecretFields{Password: "robot$oms"}})
+			vault.SetSecret(files.SecretEntry{Name: files.SecretRegistryPassword, Fields: &files.SecretFields{Password: "fake-robot-secret"}})
+			csEnv.InstallConfig.Registry.Server = "10.0.0.7"
+			nodeClient.EXPECT().HasFile(mock.Anything, "/opt/harbor/harbor.yml").Return(true)
+
+			Expect(bs.EnsureHarborRegistry()).To(Succeed())
+			Expect(bs.Env.HarborNode.GetInternalIP()).To(Equal("10.0.0.7"))
+		})
+
+		Context("with no Harbor installed yet", func() {
+			BeforeEach(func() {
+				nodeClient.EXPECT().HasFile(mock.Anything, "/opt/harbor/harbor.yml").Return(false)
+				nodeClient.EXPECT().RunCommand(mock.Anything, "root", mock.Anything).RunAndReturn(
+					func(n *node.Node, username, command string) error {
+						commandsMu.Lock()
+						defer commandsMu.Unlock()
+						commands = append(commands, command)
+						return nil
+					})
+				nodeClient.EXPECT().RunCommandWithInput(mock.Anything, "root", "sh /root/create-harbor-robot.sh", mock.Anything).Return(nil)
+
+				fw.EXPECT().WriteFile("harbor.yml", mock.Anything, mock.Anything).Return(nil)
+				fw.EXPECT().WriteFile("create-harbor-robot.sh", mock.Anything, mock.Anything).Return(nil)
+				nodeClient.EXPECT().CopyFile(mock.Anything, "harbor.yml", "/opt/harbor/harbor.yml").Return(nil)
+				nodeClient.EXPECT().CopyFile(mock.Anything, "create-harbor-robot.sh", "/root/create-harbor-robot.sh").Return(nil)
+
+				nodeClient.EXPECT().DownloadFile(mock.Anything, "/root/harbor-robot.json", mock.Anything).RunAndReturn(
+					func(n *node.Node, src, dst string) error {
+						return os.WriteFile(dst, []byte(`{"name": "robot$oms", "secret": "fake-robot-secret"}`), 0600)
+					})
+				nodeClient.EXPECT().DownloadFile(mock.Anything, "/root/harbor-certs/harbor.crt", mock.Anything).Return(nil)
+				nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, "/usr/local/share/ca-certificates/registry.crt").Return(nil)
+			})
+
+			It("installs Harbor and wires the robot credentials into the install config", func() {
+				Expect(bs.EnsureHarborRegistry()).To(Succeed())
+
+				joined := strings.Join(commands, "\n")
+				Expect(joined).To(ContainSubstring("apt-get install -y docker.io docker-compose-v2"))
+				Expect(joined).To(ContainSubstring("harbor-online-installer-v2.13.1.tgz"))
+				Expect(joined).To(ContainSubstring("cd /opt/harbor && ./install.sh"))
+				Expect(joined).To(ContainSubstring("update-ca-certificates"))
+
+				Expect(bs.Env.InstallConfig.Registry.Server).To(Equal("10.0.0.7"))
+				Expect(vault.GetSecret(files.SecretRegistryUsername).Fields.Password).To(Equal("robot$oms"))
+				Expect(vault.GetSecret(files.SecretRegistryPassword).Fields.Password).To(Equal("fake-robot-secret"))
+				Expect(vault.GetSecret("harborAdminPassword").Fields.Password).NotTo(BeEmpty())
+				Expect(vault.GetSecret("harborDatabasePassword").Fields.Password).NotTo(BeEmpty())
+			})
+
+			It("installs the selected Harbor release", func() {
+				csEnv.HarborVersion = "v2.12.0"
+
+				Expect(bs.EnsureHarborRegistry()).To(Succeed())
+
+				joined := strings.Join(commands, "\n")
+				Expect(joined).To(ContainSubstring("https://github.com/goharbor/harbor/releases/download/v2.12.0/harbor-online-installer-v2.12.0.tgz"))
+			})
+		})
+	})
+})
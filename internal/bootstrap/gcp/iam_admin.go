@@ -9,7 +9,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/codesphere-cloud/oms/internal/clusteradmin"
 	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/codesphere-cloud/oms/internal/redact"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -17,9 +19,12 @@ import (
 // OMS Project Label Keys
 const (
 	OMSManagedLabel     = "oms-managed"
+	OMSProtectedLabel   = "oms-protected"
 	DeleteAfterLabel    = "delete-after"
 	InstallVersionLabel = "install-version"
 	InstallHashLabel    = "install-hash"
+	OwnerLabel          = "owner"
+	CustomerIDLabel     = "customer-id"
 )
 
 // EnsureProject creates or updates an existing GCP project with labels
@@ -66,8 +71,11 @@ func (b *GCPBootstrapper) EnsureProject() error {
 // generateProjectLabels creates a map of GCP project labels
 // returns an error if "delete-after" label can not be generated
 func (b *GCPBootstrapper) generateProjectLabels() (map[string]string, error) {
-	labels := make(map[string]string)
-	labels[OMSManagedLabel] = "true"
+	labels := b.resourceLabels()
+
+	if b.Env.Protected {
+		labels[OMSProtectedLabel] = "true"
+	}
 
 	installVersionLabel, err := createLabel(b.Env.InstallVersion)
 	if err == nil {
@@ -79,6 +87,22 @@ func (b *GCPBootstrapper) generateProjectLabels() (map[string]string, error) {
 		labels[InstallHashLabel] = installHashLabel
 	}
 
+	if b.Env.ProjectOwner != "" {
+		ownerLabel, err := createLabel(b.Env.ProjectOwner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create '%s' label: %w", OwnerLabel, err)
+		}
+		labels[OwnerLabel] = ownerLabel
+	}
+
+	if b.Env.CustomerID != "" {
+		customerIDLabel, err := createLabel(b.Env.CustomerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create '%s' label: %w", CustomerIDLabel, err)
+		}
+		labels[CustomerIDLabel] = customerIDLabel
+	}
+
 	deleteProjectAfter, err := calculateProjectExpiryLabel(b.Env.ProjectTTL)
 	if err != nil {
 		return labels, fmt.Errorf("failed to calculate project expiry label: %w", err)
@@ -106,7 +130,7 @@ func createLabel(value string) (string, error) {
 		label = label[:63]
 	}
 
-	invalidChars := []string{"/", "."}
+	invalidChars := []string{"/", ".", "@"}
 	for _, char := range invalidChars {
 		label = strings.ReplaceAll(label, char, "_")
 	}
@@ -169,6 +193,18 @@ func (b *GCPBootstrapper) EnsureAPIsEnabled() error {
 	if b.Env.GoogleACMEIssuer {
 		apis = append(apis, "publicca.googleapis.com")
 	}
+	if b.Env.DiskEncryptionKey != "" || b.Env.GenerateDiskEncryptionKey {
+		apis = append(apis, "cloudkms.googleapis.com")
+	}
+	if b.anyEssentialContactSet() {
+		apis = append(apis, "essentialcontacts.googleapis.com")
+	}
+	if b.Env.PostgresMode == PostgresModeCloudSQL {
+		apis = append(apis, "sqladmin.googleapis.com", "servicenetworking.googleapis.com")
+	}
+	if b.Env.PGBackups {
+		apis = append(apis, "storage.googleapis.com")
+	}
 
 	err := b.GCPClient.EnableAPIs(b.Env.ProjectID, apis)
 	if err != nil {
@@ -178,6 +214,80 @@ func (b *GCPBootstrapper) EnsureAPIsEnabled() error {
 	return nil
 }
 
+// EnsureEssentialContacts registers the configured governance contacts as
+// Essential Contacts on the project, so GCP security, billing, and technical
+// notifications reach the responsible people. Unset contacts are skipped.
+func (b *GCPBootstrapper) EnsureEssentialContacts() error {
+	contacts := []struct {
+		email    string
+		category string
+	}{
+		{b.Env.SecurityContact, "SECURITY"},
+		{b.Env.BillingContact, "BILLING"},
+		{b.Env.TechnicalContact, "TECHNICAL"},
+	}
+
+	for _, contact := range contacts {
+		if contact.email == "" {
+			continue
+		}
+		err := b.GCPClient.EnsureEssentialContact(b.Env.ProjectID, contact.email, contact.category)
+		if err != nil {
+			return fmt.Errorf("failed to ensure %s contact %s: %w", strings.ToLower(contact.category), contact.email, err)
+		}
+	}
+
+	return nil
+}
+
+// anyEssentialContactSet reports whether at least one governance contact is configured.
+func (b *GCPBootstrapper) anyEssentialContactSet() bool {
+	return b.Env.SecurityContact != "" || b.Env.BillingContact != "" || b.Env.TechnicalContact != ""
+}
+
+// validateGovernanceParams normalizes the essential contact emails and checks
+// that owner and customer ID are usable as project labels.
+func (b *GCPBootstrapper) validateGovernanceParams() error {
+	contacts := []*string{&b.Env.SecurityContact, &b.Env.BillingContact, &b.Env.TechnicalContact}
+	for _, contact := range contacts {
+		if *contact == "" {
+			continue
+		}
+		email, err := clusteradmin.NormalizeEmail(*contact)
+		if err != nil {
+			return fmt.Errorf("invalid essential contact: %w", err)
+		}
+		*contact = email
+	}
+
+	if b.Env.ProjectOwner != "" {
+		if _, err := createLabel(b.Env.ProjectOwner); err != nil {
+			return fmt.Errorf("invalid project owner: %w", err)
+		}
+	}
+	if b.Env.CustomerID != "" {
+		if _, err := createLabel(b.Env.CustomerID); err != nil {
+			return fmt.Errorf("invalid customer ID: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// EnsureDeletionLien places a lien on protected projects so accidental
+// project deletion (e.g. a stray 'gcloud projects delete' or an overly broad
+// cleanup script) is blocked at the resource manager level. The lien is
+// removed again during an explicit cleanup.
+func (b *GCPBootstrapper) EnsureDeletionLien() error {
+	name, err := b.GCPClient.EnsureProjectLien(b.Env.ProjectID, "Protected Codesphere environment managed by OMS")
+	if err != nil {
+		return fmt.Errorf("failed to ensure project lien: %w", err)
+	}
+
+	b.stlog.Logf("Project deletion lien in place: %s", name)
+	return nil
+}
+
 // EnsureServiceAccounts creates the required service account and keys.
 func (b *GCPBootstrapper) EnsureServiceAccounts() error {
 	_, _, err := b.GCPClient.CreateServiceAccount(b.Env.ProjectID, "cloud-controller", "cloud-controller")
@@ -199,22 +309,19 @@ func (b *GCPBootstrapper) EnsureServiceAccounts() error {
 			return nil
 		}
 
-		for retries := range 5 {
+		err = b.retryPolicy().Retry(func() error {
 			privateKey, err := b.GCPClient.CreateServiceAccountKey(b.Env.ProjectID, sa)
-
 			if err != nil && status.Code(err) != codes.AlreadyExists {
-				if retries > 3 {
-					return fmt.Errorf("failed to create service account key: %w", err)
-				}
-				b.stlog.LogRetry()
-				b.Time.Sleep(5 * time.Second)
-				continue
+				return err
 			}
 
+			redact.Add(string(privateKey))
 			b.icg.GetVault().SetSecret(files.SecretEntry{Name: files.SecretRegistryPassword, Fields: &files.SecretFields{Password: string(privateKey)}})
 			b.icg.GetVault().SetSecret(files.SecretEntry{Name: files.SecretRegistryUsername, Fields: &files.SecretFields{Password: "_json_key_base64"}})
-
-			break
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create service account key: %w", err)
 		}
 	}
 
@@ -233,6 +340,13 @@ func (b *GCPBootstrapper) EnsureIAMRoles() error {
 		return fmt.Errorf("failed to ensure DNS permissions: %w", err)
 	}
 
+	if b.Env.DiskEncryptionKey != "" {
+		err = b.ensureComputeAgentKMSAccess()
+		if err != nil {
+			return fmt.Errorf("failed to ensure compute service agent KMS access: %w", err)
+		}
+	}
+
 	if b.Env.RegistryType != RegistryTypeArtifactRegistry {
 		return nil
 	}
@@ -245,21 +359,34 @@ func (b *GCPBootstrapper) EnsureIAMRoles() error {
 	return nil
 }
 
+// ensureComputeAgentKMSAccess grants the Compute Engine service agent access to
+// the customer-managed disk encryption key so it can encrypt and decrypt disks.
+func (b *GCPBootstrapper) ensureComputeAgentKMSAccess() error {
+	projectNumber, err := b.GCPClient.GetProjectNumber(b.Env.ProjectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project number: %w", err)
+	}
+
+	member := fmt.Sprintf("serviceAccount:service-%d@compute-system.iam.gserviceaccount.com", projectNumber)
+	roles := []string{"roles/cloudkms.cryptoKeyEncrypterDecrypter"}
+
+	err = b.retryPolicy().Retry(func() error {
+		return b.GCPClient.AssignIAMRoleToMember(b.Env.ProjectID, member, roles)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to assign roles %v to member %s: %w", roles, member, err)
+	}
+	return nil
+}
+
 // ensureIAMRoleWithRetry assigns a list of roles to an existing service account.
 // Will try to assign the role up to 5 times before failing to cover expected Google API delays.
 func (b *GCPBootstrapper) ensureIAMRoleWithRetry(projectID string, serviceAccount string, serviceAccountProjectID string, roles []string) error {
-	var err error
-	for retries := range 5 {
-		err = b.GCPClient.AssignIAMRole(projectID, serviceAccount, serviceAccountProjectID, roles)
-		if err == nil {
-			return nil
-		}
-
-		if retries < 4 {
-			b.stlog.LogRetry()
-			b.Time.Sleep(5 * time.Second)
-		}
+	err := b.retryPolicy().Retry(func() error {
+		return b.GCPClient.AssignIAMRole(projectID, serviceAccount, serviceAccountProjectID, roles)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to assign roles %v to service account %s: %w", roles, serviceAccount, err)
 	}
-
-	return fmt.Errorf("failed to assign roles %v to service account %s: %w", roles, serviceAccount, err)
+	return nil
 }
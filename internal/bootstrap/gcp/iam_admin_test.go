@@ -10,6 +10,7 @@ import (
 	"cloud.google.com/go/resourcemanager/apiv3/resourcemanagerpb"
 	"github.com/codesphere-cloud/oms/internal/bootstrap"
 	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/cloudflare"
 	"google.golang.org/api/cloudbilling/v1"
 
 	"github.com/codesphere-cloud/oms/internal/env"
@@ -55,6 +56,7 @@ var _ = Describe("IAM & Admin", func() {
 			mockPortalClient,
 			util.NewFakeTime(),
 			mockGitHubClient,
+			cloudflare.NewMockCloudflareClient(GinkgoT()),
 		)
 		Expect(err).NotTo(HaveOccurred())
 	})
@@ -220,6 +222,20 @@ var _ = Describe("IAM & Admin", func() {
 				err := bs.EnsureAPIsEnabled()
 				Expect(err).NotTo(HaveOccurred())
 			})
+
+			It("enables the essential contacts API when a contact is configured", func() {
+				csEnv.SecurityContact = "security@example.com"
+				gc.EXPECT().EnableAPIs(csEnv.ProjectID, []string{
+					"compute.googleapis.com",
+					"serviceusage.googleapis.com",
+					"artifactregistry.googleapis.com",
+					"dns.googleapis.com",
+					"essentialcontacts.googleapis.com",
+				}).Return(nil)
+
+				err := bs.EnsureAPIsEnabled()
+				Expect(err).NotTo(HaveOccurred())
+			})
 		})
 
 		Describe("Invalid cases", func() {
@@ -234,6 +250,42 @@ var _ = Describe("IAM & Admin", func() {
 		})
 	})
 
+	Describe("EnsureEssentialContacts", func() {
+		Describe("Valid EnsureEssentialContacts", func() {
+			It("registers each configured contact under its category", func() {
+				csEnv.SecurityContact = "security@example.com"
+				csEnv.BillingContact = "billing@example.com"
+				csEnv.TechnicalContact = "ops@example.com"
+				gc.EXPECT().EnsureEssentialContact(csEnv.ProjectID, "security@example.com", "SECURITY").Return(nil)
+				gc.EXPECT().EnsureEssentialContact(csEnv.ProjectID, "billing@example.com", "BILLING").Return(nil)
+				gc.EXPECT().EnsureEssentialContact(csEnv.ProjectID, "ops@example.com", "TECHNICAL").Return(nil)
+
+				err := bs.EnsureEssentialContacts()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("skips unset contacts", func() {
+				csEnv.SecurityContact = "security@example.com"
+				gc.EXPECT().EnsureEssentialContact(csEnv.ProjectID, "security@example.com", "SECURITY").Return(nil)
+
+				err := bs.EnsureEssentialContacts()
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Describe("Invalid cases", func() {
+			It("fails when EnsureEssentialContact fails", func() {
+				csEnv.BillingContact = "billing@example.com"
+				gc.EXPECT().EnsureEssentialContact(csEnv.ProjectID, "billing@example.com", "BILLING").Return(fmt.Errorf("contact error"))
+
+				err := bs.EnsureEssentialContacts()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to ensure billing contact"))
+				Expect(err.Error()).To(ContainSubstring("contact error"))
+			})
+		})
+	})
+
 	Describe("EnsureServiceAccounts", func() {
 		Describe("Valid EnsureServiceAccounts", func() {
 			Context("When using local container registry", func() {
@@ -305,6 +357,22 @@ var _ = Describe("IAM & Admin", func() {
 					Expect(err).NotTo(HaveOccurred())
 				})
 			})
+
+			Context("When a disk encryption key is configured", func() {
+				BeforeEach(func() {
+					csEnv.DiskEncryptionKey = "projects/p/locations/l/keyRings/r/cryptoKeys/k"
+				})
+				It("grants the compute service agent KMS access", func() {
+					gc.EXPECT().AssignIAMRole(csEnv.ProjectID, "cloud-controller", csEnv.ProjectID, []string{"roles/compute.admin"}).Return(nil)
+					gc.EXPECT().AssignIAMRole(csEnv.DNSProjectID, "cloud-controller", csEnv.ProjectID, []string{"roles/dns.admin"}).Return(nil)
+					gc.EXPECT().GetProjectNumber(csEnv.ProjectID).Return(1234, nil)
+					gc.EXPECT().AssignIAMRoleToMember(csEnv.ProjectID, "serviceAccount:service-1234@compute-system.iam.gserviceaccount.com", []string{"roles/cloudkms.cryptoKeyEncrypterDecrypter"}).Return(nil)
+					gc.EXPECT().AssignIAMRole(csEnv.ProjectID, "artifact-registry-writer", csEnv.ProjectID, []string{"roles/artifactregistry.writer"}).Return(nil)
+
+					err := bs.EnsureIAMRoles()
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
 		})
 
 		Describe("Invalid cases", func() {
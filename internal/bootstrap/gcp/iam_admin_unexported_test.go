@@ -93,6 +93,10 @@ var _ = Describe("IAM & Admin - Unexported", func() {
 				inputValue:    "2026-03-31_11-36-55_utc",
 				expectedLabel: "2026-03-31_11-36-55_utc",
 			}),
+			Entry("email addresses are sanitized", validTestCase{
+				inputValue:    "Owner@example.com",
+				expectedLabel: "owner_example_com",
+			}),
 		)
 
 		It("returns an error for empty input", func() {
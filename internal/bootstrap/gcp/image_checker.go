@@ -0,0 +1,32 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"github.com/google/go-containerregistry/pkg/crane"
+)
+
+// ImageChecker verifies container images exist in their registry, so tests
+// can run without network access.
+//
+//mockery:generate: true
+type ImageChecker interface {
+	ImageExists(ref string) error
+}
+
+type craneImageChecker struct{}
+
+func (craneImageChecker) ImageExists(ref string) error {
+	_, err := crane.Head(ref)
+	return err
+}
+
+// imageChecker returns the injected registry client or the crane-backed
+// default.
+func (b *GCPBootstrapper) imageChecker() ImageChecker {
+	if b.Images != nil {
+		return b.Images
+	}
+	return craneImageChecker{}
+}
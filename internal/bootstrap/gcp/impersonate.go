@@ -0,0 +1,94 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// impersonatedCredentials is the ADC credential configuration for service
+// account impersonation. The google-auth libraries exchange the operator's
+// source credentials for short-lived tokens of the target service account on
+// every refresh, so no key for the provisioning account is ever downloaded.
+type impersonatedCredentials struct {
+	Type                           string          `json:"type"`
+	ServiceAccountImpersonationURL string          `json:"service_account_impersonation_url"`
+	SourceCredentials              json.RawMessage `json:"source_credentials"`
+}
+
+// SetupImpersonatedAuth configures all GCP clients created afterwards to run
+// as the given provisioning service account: it wraps the operator's existing
+// credentials (GOOGLE_APPLICATION_CREDENTIALS or the gcloud user ADC) in an
+// impersonation configuration and points GOOGLE_APPLICATION_CREDENTIALS at
+// it. It returns the path of the generated credential configuration.
+func SetupImpersonatedAuth(serviceAccount, workdir string) (string, error) {
+	if serviceAccount == "" {
+		return "", fmt.Errorf("impersonation requires a service account email")
+	}
+
+	sourcePath, err := sourceCredentialsPath()
+	if err != nil {
+		return "", err
+	}
+	source, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read source credentials %s: %w", sourcePath, err)
+	}
+	if !json.Valid(source) {
+		return "", fmt.Errorf("source credentials %s are not valid JSON", sourcePath)
+	}
+
+	credentials := impersonatedCredentials{
+		Type: "impersonated_service_account",
+		ServiceAccountImpersonationURL: fmt.Sprintf(
+			"https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/%s:generateAccessToken",
+			serviceAccount,
+		),
+		SourceCredentials: source,
+	}
+	credentialsJSON, err := json.MarshalIndent(credentials, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal credential configuration: %w", err)
+	}
+
+	authDir := filepath.Join(workdir, "gcp-impersonation")
+	if err := os.MkdirAll(authDir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create impersonation auth directory: %w", err)
+	}
+	credentialsPath := filepath.Join(authDir, "credentials.json")
+	if err := os.WriteFile(credentialsPath, credentialsJSON, 0600); err != nil {
+		return "", fmt.Errorf("failed to write credential configuration: %w", err)
+	}
+
+	// As with OIDC auth, pointing ADC at the generated configuration makes
+	// every GCP client pick up the impersonated credentials.
+	if err := os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", credentialsPath); err != nil {
+		return "", fmt.Errorf("failed to set GOOGLE_APPLICATION_CREDENTIALS: %w", err)
+	}
+
+	return credentialsPath, nil
+}
+
+// sourceCredentialsPath locates the operator's own credentials used as the
+// source of the impersonation: an explicit GOOGLE_APPLICATION_CREDENTIALS
+// file, or the well-known gcloud user ADC file.
+func sourceCredentialsPath() (string, error) {
+	if path := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"); path != "" {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+	wellKnown := filepath.Join(home, ".config", "gcloud", "application_default_credentials.json")
+	if _, err := os.Stat(wellKnown); err != nil {
+		return "", fmt.Errorf("no source credentials found: set GOOGLE_APPLICATION_CREDENTIALS or run 'gcloud auth application-default login'")
+	}
+
+	return wellKnown, nil
+}
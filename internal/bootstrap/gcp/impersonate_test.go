@@ -0,0 +1,79 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SetupImpersonatedAuth", func() {
+	var workdir string
+
+	setEnv := func(key, value string) {
+		previous, hadPrevious := os.LookupEnv(key)
+		Expect(os.Setenv(key, value)).To(Succeed())
+		DeferCleanup(func() {
+			if hadPrevious {
+				Expect(os.Setenv(key, previous)).To(Succeed())
+				return
+			}
+			Expect(os.Unsetenv(key)).To(Succeed())
+		})
+	}
+
+	BeforeEach(func() {
+		workdir = GinkgoT().TempDir()
+		setEnv("GOOGLE_APPLICATION_CREDENTIALS", "")
+	})
+
+	It("requires a service account email", func() {
+		_, err := gcp.SetupImpersonatedAuth("", workdir)
+
+		Expect(err).To(MatchError(ContainSubstring("requires a service account email")))
+	})
+
+	It("wraps the operator credentials in an impersonation configuration", func() {
+		sourcePath := filepath.Join(workdir, "source.json")
+		Expect(os.WriteFile(sourcePath, []byte(`{"type":"authorized_user","client_id":"operator"}`), 0600)).To(Succeed())
+		setEnv("GOOGLE_APPLICATION_CREDENTIALS", sourcePath)
+
+		credentialsPath, err := gcp.SetupImpersonatedAuth("provisioner@pid.iam.gserviceaccount.com", workdir)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")).To(Equal(credentialsPath))
+
+		data, err := os.ReadFile(credentialsPath)
+		Expect(err).NotTo(HaveOccurred())
+		var credentials map[string]any
+		Expect(json.Unmarshal(data, &credentials)).To(Succeed())
+		Expect(credentials["type"]).To(Equal("impersonated_service_account"))
+		Expect(credentials["service_account_impersonation_url"]).To(Equal(
+			"https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/provisioner@pid.iam.gserviceaccount.com:generateAccessToken"))
+		Expect(credentials["source_credentials"].(map[string]any)["client_id"]).To(Equal("operator"))
+	})
+
+	It("rejects source credentials that are not valid JSON", func() {
+		sourcePath := filepath.Join(workdir, "source.json")
+		Expect(os.WriteFile(sourcePath, []byte("not-json"), 0600)).To(Succeed())
+		setEnv("GOOGLE_APPLICATION_CREDENTIALS", sourcePath)
+
+		_, err := gcp.SetupImpersonatedAuth("provisioner@pid.iam.gserviceaccount.com", workdir)
+
+		Expect(err).To(MatchError(ContainSubstring("not valid JSON")))
+	})
+
+	It("fails with guidance when no source credentials exist", func() {
+		setEnv("HOME", GinkgoT().TempDir())
+
+		_, err := gcp.SetupImpersonatedAuth("provisioner@pid.iam.gserviceaccount.com", workdir)
+
+		Expect(err).To(MatchError(ContainSubstring("gcloud auth application-default login")))
+	})
+})
@@ -38,26 +38,36 @@ func LoadInfraFile(fw util.FileIO, infraFilePath string) (CodesphereEnvironment,
 	return env, true, nil
 }
 
-// WriteInfraFile writes details about the bootstrapped codesphere environment into a file.
-func (b *GCPBootstrapper) WriteInfraFile() error {
-	envBytes, err := json.MarshalIndent(b.Env, "", "  ")
+// SaveInfraFile writes the codesphere environment to the infra file at the
+// default path.
+func SaveInfraFile(fw util.FileIO, csEnv *CodesphereEnvironment) error {
+	envBytes, err := json.MarshalIndent(csEnv, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal codesphere env: %w", err)
 	}
 
 	workdir := env.NewEnv().GetOmsWorkdir()
-	err = b.fw.MkdirAll(workdir, 0755)
+	err = fw.MkdirAll(workdir, 0755)
 	if err != nil {
 		return fmt.Errorf("failed to create workdir: %w", err)
 	}
 
-	infraFilePath := GetInfraFilePath()
-	err = b.fw.WriteFile(infraFilePath, envBytes, 0644)
+	err = fw.WriteFile(GetInfraFilePath(), envBytes, 0644)
 	if err != nil {
 		return fmt.Errorf("failed to write gcp bootstrap env file: %w", err)
 	}
 
-	b.stlog.Logf("Infrastructure details written to %s", infraFilePath)
+	return nil
+}
+
+// WriteInfraFile writes details about the bootstrapped codesphere environment into a file.
+func (b *GCPBootstrapper) WriteInfraFile() error {
+	err := SaveInfraFile(b.fw, b.Env)
+	if err != nil {
+		return err
+	}
+
+	b.stlog.Logf("Infrastructure details written to %s", GetInfraFilePath())
 
 	return nil
 }
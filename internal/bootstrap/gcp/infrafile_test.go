@@ -9,6 +9,7 @@ import (
 
 	"github.com/codesphere-cloud/oms/internal/bootstrap"
 	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/cloudflare"
 	"github.com/codesphere-cloud/oms/internal/env"
 	"github.com/codesphere-cloud/oms/internal/github"
 	"github.com/codesphere-cloud/oms/internal/installer"
@@ -51,6 +52,7 @@ var _ = Describe("Infrafile", func() {
 			mockPortalClient,
 			util.NewFakeTime(),
 			mockGitHubClient,
+			cloudflare.NewMockCloudflareClient(GinkgoT()),
 		)
 		Expect(err).NotTo(HaveOccurred())
 	})
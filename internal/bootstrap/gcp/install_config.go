@@ -5,10 +5,16 @@ package gcp
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/installer"
 	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
 	"github.com/codesphere-cloud/oms/internal/installer/secrets"
+	"github.com/codesphere-cloud/oms/internal/installer/vault"
 	"github.com/codesphere-cloud/oms/internal/util"
 )
 
@@ -17,7 +23,7 @@ const (
 )
 
 // EnsureInstallConfig uses the local config or recovers it from an existing jumpbox if desired.
-// Else it applies the minimal profile to a new config.
+// Else it applies the selected profile (minimal by default) to a new config.
 func (b *GCPBootstrapper) EnsureInstallConfig() error {
 	// recovery will overwrite local config or create a new file
 	if b.Env.RecoverConfig {
@@ -39,7 +45,11 @@ func (b *GCPBootstrapper) EnsureInstallConfig() error {
 
 		b.Env.ExistingConfigUsed = true
 	} else {
-		err := b.icg.ApplyProfile("minimal")
+		profile := b.Env.InstallProfile
+		if profile == "" {
+			profile = installer.PROFILE_MINIMAL
+		}
+		err := b.icg.ApplyProfile(profile)
 		if err != nil {
 			return fmt.Errorf("failed to apply profile: %w", err)
 		}
@@ -63,9 +73,10 @@ func (b *GCPBootstrapper) loadVaultForConfigTemplating() error {
 	return nil
 }
 
-// recoverConfig downloads the config and secrets from the jumpbox if it exists.
-// Since recovery is done when the project or VMs are not ensured, we need to search for the jumpbox IP first.
-// Returns an error if project or jumpbox does not exist or downloading fails.
+// recoverConfig downloads the config and secrets from the node holding them:
+// the jumpbox, or the first control plane node in jumpbox-less mode.
+// Since recovery is done when the project or VMs are not ensured, we need to search for the node IP first.
+// Returns an error if the project or node does not exist or downloading fails.
 func (b *GCPBootstrapper) recoverConfig() error {
 	existingProject, err := b.GCPClient.GetProjectByName(b.Env.FolderID, b.Env.ProjectName)
 	if err != nil {
@@ -73,18 +84,24 @@ func (b *GCPBootstrapper) recoverConfig() error {
 	}
 	b.Env.ProjectID = existingProject.ProjectId
 
-	jumpbox, err := b.GetNodeByName("jumpbox")
+	opsVMName := "jumpbox"
+	if b.Env.NoJumpbox {
+		opsVMName = "k0s-1"
+	}
+	ops, err := b.GetNodeByName(opsVMName)
 	if err != nil {
-		return fmt.Errorf("failed to find jumpbox node for config recovery: %w", err)
+		return fmt.Errorf("failed to find %s node for config recovery: %w", opsVMName, err)
+	}
+	if !b.Env.NoJumpbox {
+		b.Env.Jumpbox = ops
 	}
-	b.Env.Jumpbox = jumpbox
 
-	err = b.Env.Jumpbox.NodeClient.DownloadFile(jumpbox, remoteInstallConfigPath, b.Env.InstallConfigPath)
+	err = ops.NodeClient.DownloadFile(ops, remoteInstallConfigPath, b.Env.InstallConfigPath)
 	if err != nil {
-		return fmt.Errorf("failed to download install config from jumpbox: %w", err)
+		return fmt.Errorf("failed to download install config from %s: %w", opsVMName, err)
 	}
 
-	err = b.recoverVault()
+	err = b.recoverVault(ops)
 	if err != nil {
 		return fmt.Errorf("failed to recover vault: %w", err)
 	}
@@ -92,29 +109,77 @@ func (b *GCPBootstrapper) recoverConfig() error {
 	return nil
 }
 
-// recoverVault unencrypts the secrets file on the jumpbox and download the file to the local destination
-func (b *GCPBootstrapper) recoverVault() error {
-	const vaultCopyPath string = "/tmp/prod.vault.yaml"
-	defer func() {
-		err := b.Env.Jumpbox.RunSSHCommand("root", "rm -f "+vaultCopyPath)
+// recoverVault downloads the encrypted secrets file and its age key from the
+// given node and decrypts it locally, so the plaintext vault never exists on
+// that node. KMS-encrypted vaults need no key download; SOPS resolves the
+// Cloud KMS recipient from the file metadata and Application Default
+// Credentials.
+func (b *GCPBootstrapper) recoverVault(ops *node.Node) error {
+	tmpDir, err := os.MkdirTemp("", "vault-recover-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir for vault recovery: %w", err)
+	}
+	defer util.IgnoreError(func() error { return os.RemoveAll(tmpDir) })
+
+	keyPath := ""
+	if !b.Env.KMSVault {
+		keyPath = filepath.Join(tmpDir, "age_key.txt")
+		err = ops.NodeClient.DownloadFile(ops, b.Env.SecretsDir+"/age_key.txt", keyPath)
 		if err != nil {
-			b.stlog.Logf("failed to remove unencrypted vault file for recovery: %s", err.Error())
+			return fmt.Errorf("failed to download age key from %s: %w", ops.GetName(), err)
 		}
-	}()
+	}
 
-	err := b.decryptVault(vaultCopyPath)
+	encryptedPath := filepath.Join(tmpDir, "prod.vault.yaml")
+	err = ops.NodeClient.DownloadFile(ops, b.Env.SecretsDir+"/prod.vault.yaml", encryptedPath)
 	if err != nil {
-		return fmt.Errorf("failed to create decrypted vault for recovery: %w", err)
+		return fmt.Errorf("failed to download secrets file from %s: %w", ops.GetName(), err)
 	}
 
-	err = b.Env.Jumpbox.NodeClient.DownloadFile(b.Env.Jumpbox, vaultCopyPath, b.Env.SecretsFilePath)
+	plaintext, err := vault.DecryptFileWithSOPS(encryptedPath, keyPath)
 	if err != nil {
-		return fmt.Errorf("failed to download secrets file from jumpbox: %w", err)
+		return fmt.Errorf("failed to decrypt vault for recovery: %w", err)
+	}
+
+	if err := os.WriteFile(b.Env.SecretsFilePath, plaintext, 0600); err != nil {
+		return fmt.Errorf("failed to write recovered secrets file: %w", err)
 	}
 
 	return nil
 }
 
+// ControlPlaneTaint keeps workloads off dedicated control plane nodes.
+const ControlPlaneTaint = "node-role.kubernetes.io/control-plane=:NoSchedule"
+
+// k8sTopology maps the k0s nodes onto the install config node lists. By
+// default the first node is the control plane and every node acts as a
+// worker; with a dedicated control plane the base k0s nodes are tainted
+// control planes and only the worker pool instances carry workloads.
+func (b *GCPBootstrapper) k8sTopology() (controlPlanes, workers []files.K8sNode) {
+	if !b.Env.DedicatedControlPlane {
+		controlPlanes = []files.K8sNode{{IPAddress: b.Env.ControlPlaneNodes[0].GetInternalIP()}}
+		for _, n := range b.Env.ControlPlaneNodes {
+			workers = append(workers, files.K8sNode{IPAddress: n.GetInternalIP()})
+		}
+		return controlPlanes, workers
+	}
+
+	base := len(k0sVMDefs())
+	for _, n := range b.Env.ControlPlaneNodes[:base] {
+		controlPlanes = append(controlPlanes, files.K8sNode{
+			IPAddress: n.GetInternalIP(),
+			Taints:    []string{ControlPlaneTaint},
+		})
+	}
+	for _, n := range b.Env.ControlPlaneNodes[base:] {
+		workers = append(workers, files.K8sNode{
+			IPAddress: n.GetInternalIP(),
+			Taints:    b.Env.WorkerTaints,
+		})
+	}
+	return controlPlanes, workers
+}
+
 func (b *GCPBootstrapper) UpdateInstallConfig() error {
 	// Update install config with necessary values
 	b.Env.InstallConfig.Datacenter.ID = b.Env.DatacenterID
@@ -130,71 +195,77 @@ func (b *GCPBootstrapper) UpdateInstallConfig() error {
 		b.Env.InstallConfig.Registry.LoadContainerImages = true
 	}
 
-	if b.Env.InstallConfig.Postgres.Primary == nil {
-		b.Env.InstallConfig.Postgres.Primary = &files.PostgresPrimaryConfig{
-			Hostname: b.Env.PostgreSQLNode.GetName(),
+	previousPrimaryIP, previousPrimaryHostname := "", ""
+	previousReplicaIP, previousReplicaName := "", ""
+	if b.Env.PostgresMode == PostgresModeCloudSQL {
+		// The managed instance is not installed by the installer and terminates
+		// TLS itself, so no primary/replica wiring or certificates apply.
+		b.Env.InstallConfig.Postgres.Mode = "external"
+		b.Env.InstallConfig.Postgres.ServerAddress = b.Env.CloudSQLInstanceIP + ":5432"
+		b.Env.InstallConfig.Postgres.Primary = nil
+		b.Env.InstallConfig.Postgres.Replica = nil
+	} else {
+		if b.Env.InstallConfig.Postgres.Primary == nil {
+			b.Env.InstallConfig.Postgres.Primary = &files.PostgresPrimaryConfig{
+				Hostname: b.Env.PostgreSQLNode.GetName(),
+			}
 		}
-	}
 
-	previousPrimaryIP := b.Env.InstallConfig.Postgres.Primary.IP
-	previousPrimaryHostname := b.Env.InstallConfig.Postgres.Primary.Hostname
-	b.Env.InstallConfig.Postgres.Primary.IP = b.Env.PostgreSQLNode.GetInternalIP()
-	b.Env.InstallConfig.Postgres.Primary.Hostname = b.Env.PostgreSQLNode.GetName()
+		previousPrimaryIP = b.Env.InstallConfig.Postgres.Primary.IP
+		previousPrimaryHostname = b.Env.InstallConfig.Postgres.Primary.Hostname
+		b.Env.InstallConfig.Postgres.Primary.IP = b.Env.PostgreSQLNode.GetInternalIP()
+		b.Env.InstallConfig.Postgres.Primary.Hostname = b.Env.PostgreSQLNode.GetName()
 
-	b.Env.InstallConfig.Ceph.CsiKubeletDir = "/var/lib/k0s/kubelet"
-	b.Env.InstallConfig.Ceph.NodesSubnet = "10.10.0.0/20"
-	b.Env.InstallConfig.Ceph.Hosts = []files.CephHost{
-		{
-			Hostname:  b.Env.CephNodes[0].GetName(),
-			IsMaster:  true,
-			IPAddress: b.Env.CephNodes[0].GetInternalIP(),
-		},
-		{
-			Hostname:  b.Env.CephNodes[1].GetName(),
-			IPAddress: b.Env.CephNodes[1].GetInternalIP(),
-		},
-		{
-			Hostname:  b.Env.CephNodes[2].GetName(),
-			IPAddress: b.Env.CephNodes[2].GetInternalIP(),
-		},
-	}
-	b.Env.InstallConfig.Ceph.OSDs = []files.CephOSD{
-		{
-			SpecID: "default",
-			Placement: files.CephPlacement{
-				HostPattern: "*",
-			},
-			DataDevices: files.CephDataDevices{
-				Size:  "50G:",
-				Limit: 1,
-			},
-			DBDevices: files.CephDBDevices{
-				Size:  "10G:50G",
-				Limit: 1,
-			},
-		},
+		if b.Env.InstallConfig.Postgres.Replica != nil {
+			previousReplicaIP = b.Env.InstallConfig.Postgres.Replica.IP
+			previousReplicaName = b.Env.InstallConfig.Postgres.Replica.Name
+		}
+		if b.Env.PostgreSQLReplicaNode != nil {
+			if b.Env.InstallConfig.Postgres.Replica == nil {
+				b.Env.InstallConfig.Postgres.Replica = &files.PostgresReplicaConfig{}
+			}
+			b.Env.InstallConfig.Postgres.Replica.IP = b.Env.PostgreSQLReplicaNode.GetInternalIP()
+			// Replica names feed into replication slot names, which only allow
+			// lowercase alphanumerics and underscores.
+			b.Env.InstallConfig.Postgres.Replica.Name = strings.ReplaceAll(b.Env.PostgreSQLReplicaNode.GetName(), "-", "_")
+		}
 	}
 
-	b.Env.InstallConfig.Kubernetes = files.KubernetesConfig{
-		ManagedByCodesphere: true,
-		APIServerHost:       b.Env.ControlPlaneNodes[0].GetInternalIP(),
-		ControlPlanes: []files.K8sNode{
-			{
-				IPAddress: b.Env.ControlPlaneNodes[0].GetInternalIP(),
-			},
-		},
-		Workers: []files.K8sNode{
-			{
-				IPAddress: b.Env.ControlPlaneNodes[0].GetInternalIP(),
-			},
+	b.Env.InstallConfig.Ceph.CsiKubeletDir = b.distribution().KubeletDir
+	if !b.usesCephStorage() {
+		b.applyStorageBackendConfig()
+	} else if b.Env.StorageMode == StorageModeExternal {
+		// The existing cluster is not deployed by the installer; it is reached
+		// through its mon hosts and authenticated with the vaulted keyring.
+		b.Env.InstallConfig.Ceph.Mode = "external"
+		b.Env.InstallConfig.Ceph.MonHosts = b.Env.CephMonHosts
+		b.Env.InstallConfig.Ceph.Hosts = nil
+		b.Env.InstallConfig.Ceph.OSDs = nil
+		if err := b.ensureCephKeyringInVault(); err != nil {
+			return err
+		}
+	} else {
+		b.applyManagedCephConfig()
+	}
 
-			{
-				IPAddress: b.Env.ControlPlaneNodes[1].GetInternalIP(),
-			},
-			{
-				IPAddress: b.Env.ControlPlaneNodes[2].GetInternalIP(),
-			},
-		},
+	if b.byoK8s() {
+		// The existing cluster is not managed by the installer; it is reached
+		// through the operator-provided kubeconfig from the vault.
+		b.Env.InstallConfig.Kubernetes = files.KubernetesConfig{
+			ManagedByCodesphere: false,
+		}
+		if err := b.ensureKubeconfigInVault(); err != nil {
+			return err
+		}
+	} else {
+		controlPlanes, workers := b.k8sTopology()
+		b.Env.InstallConfig.Kubernetes = files.KubernetesConfig{
+			ManagedByCodesphere: true,
+			Distribution:        b.Env.K8sDistribution,
+			APIServerHost:       b.Env.ControlPlaneNodes[0].GetInternalIP(),
+			ControlPlanes:       controlPlanes,
+			Workers:             workers,
+		}
 	}
 
 	b.Env.InstallConfig.Cluster.Kyverno = &files.KyvernoConfig{
@@ -209,6 +280,13 @@ func (b *GCPBootstrapper) UpdateInstallConfig() error {
 	b.Env.InstallConfig.Cluster.PublicGateway.Annotations = map[string]string{
 		"cloud.google.com/load-balancer-ipv4": b.Env.PublicGatewayIP,
 	}
+	if b.Env.IPv6 {
+		b.Env.InstallConfig.Cluster.Gateway.Annotations["cloud.google.com/load-balancer-ipv6"] = b.Env.GatewayIPv6
+		b.Env.InstallConfig.Cluster.PublicGateway.Annotations["cloud.google.com/load-balancer-ipv6"] = b.Env.PublicGatewayIPv6
+	}
+	if b.Env.CloudArmorPolicy {
+		b.Env.InstallConfig.Cluster.PublicGateway.Annotations["cloud.google.com/security-policy"] = CloudArmorPolicyName
+	}
 
 	b.applySshProxyConfig()
 
@@ -257,13 +335,24 @@ func (b *GCPBootstrapper) UpdateInstallConfig() error {
 
 	b.Env.InstallConfig.Codesphere.Domain = "cs." + b.Env.BaseDomain
 	b.Env.InstallConfig.Codesphere.WorkspaceHostingBaseDomain = "ws." + b.Env.BaseDomain
-	b.Env.InstallConfig.Codesphere.PublicIP = b.Env.ControlPlaneNodes[1].GetExternalIP()
+	if b.byoK8s() {
+		// No nodes of our own; workspaces are reached through the gateway.
+		b.Env.InstallConfig.Codesphere.PublicIP = b.Env.GatewayIP
+	} else {
+		b.Env.InstallConfig.Codesphere.PublicIP = b.Env.ControlPlaneNodes[1].GetExternalIP()
+	}
 	b.Env.InstallConfig.Codesphere.CustomDomains = files.CustomDomainsConfig{
 		CNameBaseDomain: "ws." + b.Env.BaseDomain,
 	}
-	b.Env.InstallConfig.Codesphere.DNSServers = []string{"8.8.8.8"}
+	b.Env.InstallConfig.Kubernetes.NodeLocalDNS = b.Env.NodeLocalDNS
+	if b.Env.NodeLocalDNS {
+		b.Env.InstallConfig.Codesphere.DNSServers = []string{installer.NodeLocalDNSIP}
+	} else {
+		b.Env.InstallConfig.Codesphere.DNSServers = []string{"8.8.8.8"}
+	}
 	b.Env.InstallConfig.Codesphere.DeployConfig = bootstrap.DefaultCodesphereDeployConfig()
 	b.Env.InstallConfig.Codesphere.Plans = bootstrap.DefaultCodespherePlans()
+	b.applyEntitlementPlanLimits()
 
 	b.Env.InstallConfig.Codesphere.GitProviders = &files.GitProvidersConfig{}
 	if b.Env.GitHubAppName != "" && b.Env.GitHubAppClientID != "" && b.Env.GitHubAppClientSecret != "" {
@@ -384,7 +473,7 @@ func (b *GCPBootstrapper) UpdateInstallConfig() error {
 			return fmt.Errorf("failed to generate secrets: %w", err)
 		}
 	} else {
-		if err := b.regeneratePostgresCerts(previousPrimaryIP, previousPrimaryHostname); err != nil {
+		if err := b.regeneratePostgresCerts(previousPrimaryIP, previousPrimaryHostname, previousReplicaIP, previousReplicaName); err != nil {
 			return err
 		}
 	}
@@ -418,16 +507,70 @@ func (b *GCPBootstrapper) UpdateInstallConfig() error {
 		return fmt.Errorf("failed to write vault file: %w", err)
 	}
 
-	err := b.Env.Jumpbox.NodeClient.CopyFile(b.Env.Jumpbox, b.Env.InstallConfigPath, remoteInstallConfigPath)
+	ops := b.opsNode()
+	err := ops.NodeClient.CopyFile(ops, b.Env.InstallConfigPath, remoteInstallConfigPath)
 	if err != nil {
-		return fmt.Errorf("failed to copy install config to jumpbox: %w", err)
+		return fmt.Errorf("failed to copy install config to %s: %w", ops.GetName(), err)
 	}
 
-	err = b.Env.Jumpbox.NodeClient.CopyFile(b.Env.Jumpbox, b.Env.SecretsFilePath, b.Env.SecretsDir+"/prod.vault.yaml")
-	if err != nil {
-		return fmt.Errorf("failed to copy secrets file to jumpbox: %w", err)
+	return nil
+}
+
+// applyManagedCephConfig wires the provisioned ceph VMs and the default OSD
+// layout into the install config.
+func (b *GCPBootstrapper) applyManagedCephConfig() {
+	b.Env.InstallConfig.Ceph.NodesSubnet = "10.10.0.0/20"
+	b.Env.InstallConfig.Ceph.Hosts = []files.CephHost{
+		{
+			Hostname:  b.Env.CephNodes[0].GetName(),
+			IsMaster:  true,
+			IPAddress: b.Env.CephNodes[0].GetInternalIP(),
+		},
+		{
+			Hostname:  b.Env.CephNodes[1].GetName(),
+			IPAddress: b.Env.CephNodes[1].GetInternalIP(),
+		},
+		{
+			Hostname:  b.Env.CephNodes[2].GetName(),
+			IPAddress: b.Env.CephNodes[2].GetInternalIP(),
+		},
 	}
+	// DB and data devices are told apart by size alone; the selectors meet
+	// at half the data disk size, which validateCephLayout keeps the DB disk
+	// below.
+	dataCutoff := b.cephDataDiskGB() / 2
+	b.Env.InstallConfig.Ceph.OSDs = []files.CephOSD{
+		{
+			SpecID: "default",
+			Placement: files.CephPlacement{
+				HostPattern: "*",
+			},
+			DataDevices: files.CephDataDevices{
+				Size:  fmt.Sprintf("%dG:", dataCutoff),
+				Limit: b.cephOSDsPerHost(),
+			},
+			DBDevices: files.CephDBDevices{
+				Size:  fmt.Sprintf("%dG:%dG", b.cephDBDiskGB(), dataCutoff),
+				Limit: b.cephOSDsPerHost(),
+			},
+		},
+	}
+	if b.Env.CephReplicaCount != 0 || b.Env.CephFailureDomain != "" {
+		b.Env.InstallConfig.Ceph.Pool = &files.CephPoolConfig{
+			ReplicaCount:  b.Env.CephReplicaCount,
+			FailureDomain: b.Env.CephFailureDomain,
+		}
+	}
+}
 
+// ensureCephKeyringInVault stores the keyring of the external Ceph cluster in
+// the vault; the vault is persisted at the end of UpdateInstallConfig.
+func (b *GCPBootstrapper) ensureCephKeyringInVault() error {
+	keyring, err := b.fw.ReadFile(b.Env.CephKeyringFile)
+	if err != nil {
+		return fmt.Errorf("failed to read ceph keyring file %s: %w", b.Env.CephKeyringFile, err)
+	}
+	b.icg.GetVault().SetSecret(files.SecretEntry{Name: files.SecretCephKeyring, Fields: &files.SecretFields{Password: string(keyring)}})
 	return nil
 }
 
@@ -499,7 +642,11 @@ func (b *GCPBootstrapper) applyPrometheusRemoteWriteConfig() {
 
 // regeneratePostgresCerts regenerates PostgreSQL TLS certificates when the IP/hostname
 // changed or no private key was loaded from the vault.
-func (b *GCPBootstrapper) regeneratePostgresCerts(previousPrimaryIP, previousPrimaryHostname string) error {
+func (b *GCPBootstrapper) regeneratePostgresCerts(previousPrimaryIP, previousPrimaryHostname, previousReplicaIP, previousReplicaName string) error {
+	// External postgres (e.g. Cloud SQL) has no installer-managed certificates.
+	if b.Env.InstallConfig.Postgres.Primary == nil {
+		return nil
+	}
 	vault := b.icg.GetVault()
 	primaryKeySecret := vault.GetSecret(files.SecretPostgresPrimaryServerKeyPem)
 	primaryNeedsRegen := primaryKeySecret == nil || primaryKeySecret.File == nil ||
@@ -527,7 +674,10 @@ func (b *GCPBootstrapper) regeneratePostgresCerts(previousPrimaryIP, previousPri
 	}
 	if b.Env.InstallConfig.Postgres.Replica != nil {
 		replicaKeySecret := vault.GetSecret(files.SecretPostgresReplicaServerKeyPem)
-		if replicaKeySecret == nil || replicaKeySecret.File == nil {
+		replicaNeedsRegen := replicaKeySecret == nil || replicaKeySecret.File == nil ||
+			previousReplicaIP != b.Env.InstallConfig.Postgres.Replica.IP ||
+			previousReplicaName != b.Env.InstallConfig.Postgres.Replica.Name
+		if replicaNeedsRegen {
 			caSecret := vault.GetSecret(files.SecretPostgresCaKeyPem)
 			if caSecret == nil || caSecret.File == nil {
 				return fmt.Errorf("postgres CA key not found in vault")
@@ -550,15 +700,35 @@ func (b *GCPBootstrapper) regeneratePostgresCerts(previousPrimaryIP, previousPri
 	return nil
 }
 
+// EnsureAgeKey makes sure an age key for the secrets vault exists on the node
+// running the install. The key is generated in-process and uploaded, so
+// bootstrap does not depend on age-keygen being installed there.
 func (b *GCPBootstrapper) EnsureAgeKey() error {
-	hasKey := b.Env.Jumpbox.NodeClient.HasFile(b.Env.Jumpbox, b.Env.SecretsDir+"/age_key.txt")
+	ops := b.opsNode()
+	hasKey := ops.NodeClient.HasFile(ops, b.Env.SecretsDir+"/age_key.txt")
 	if hasKey {
 		return nil
 	}
 
-	err := b.Env.Jumpbox.RunSSHCommand("root", fmt.Sprintf("mkdir -p %s; age-keygen -o %s/age_key.txt", b.Env.SecretsDir, b.Env.SecretsDir))
+	tmpDir, err := os.MkdirTemp("", "age-key-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir for age key: %w", err)
+	}
+	defer util.IgnoreError(func() error { return os.RemoveAll(tmpDir) })
+
+	keyPath := filepath.Join(tmpDir, "age_key.txt")
+	if _, err := vault.GenerateAgeKey(keyPath); err != nil {
+		return fmt.Errorf("failed to generate age key: %w", err)
+	}
+
+	err = ops.NodeClient.CopyFile(ops, keyPath, b.Env.SecretsDir+"/age_key.txt")
 	if err != nil {
-		return fmt.Errorf("failed to generate age key on jumpbox: %w", err)
+		return fmt.Errorf("failed to copy age key to %s: %w", ops.GetName(), err)
+	}
+
+	err = ops.RunSSHCommand("root", "chmod 600 "+b.Env.SecretsDir+"/age_key.txt")
+	if err != nil {
+		return fmt.Errorf("failed to restrict age key permissions on %s: %w", ops.GetName(), err)
 	}
 
 	return nil
@@ -575,37 +745,85 @@ func (b *GCPBootstrapper) EnsureSecrets() error {
 	return nil
 }
 
+// EncryptVault encrypts the locally written vault in-process and uploads only
+// the ciphertext, so the plaintext vault never exists on the remote node and
+// no sops binary is needed there.
 func (b *GCPBootstrapper) EncryptVault() error {
-	err := b.Env.Jumpbox.RunSSHCommand("root", "cp "+b.Env.SecretsDir+"/prod.vault.yaml{,.bak}")
+	ops := b.opsNode()
+	remoteVaultPath := b.Env.SecretsDir + "/prod.vault.yaml"
+	if ops.NodeClient.HasFile(ops, remoteVaultPath) {
+		err := ops.RunSSHCommand("root", "cp "+remoteVaultPath+"{,.bak}")
+		if err != nil {
+			return fmt.Errorf("failed backup vault on %s: %w", ops.GetName(), err)
+		}
+	}
+
+	tmpDir, err := os.MkdirTemp("", "vault-encrypt-")
 	if err != nil {
-		return fmt.Errorf("failed backup vault on jumpbox: %w", err)
+		return fmt.Errorf("failed to create temp dir for vault encryption: %w", err)
 	}
+	defer util.IgnoreError(func() error { return os.RemoveAll(tmpDir) })
 
-	err = b.Env.Jumpbox.RunSSHCommand("root", "sops --encrypt --in-place --age $(age-keygen -y "+b.Env.SecretsDir+"/age_key.txt) "+b.Env.SecretsDir+"/prod.vault.yaml")
+	encryptedPath := filepath.Join(tmpDir, "prod.vault.yaml")
+	if b.Env.KMSVault {
+		if err := vault.EncryptFileWithGCPKMS(b.Env.SecretsFilePath, encryptedPath, b.Env.VaultKMSKeyName); err != nil {
+			return fmt.Errorf("failed to encrypt vault: %w", err)
+		}
+	} else {
+		keyPath := filepath.Join(tmpDir, "age_key.txt")
+		err = ops.NodeClient.DownloadFile(ops, b.Env.SecretsDir+"/age_key.txt", keyPath)
+		if err != nil {
+			return fmt.Errorf("failed to download age key from %s: %w", ops.GetName(), err)
+		}
+
+		recipient, err := vault.RecipientFromKeyFile(keyPath)
+		if err != nil {
+			return fmt.Errorf("failed to read age recipient from key: %w", err)
+		}
+
+		if err := vault.EncryptFileWithSOPS(b.Env.SecretsFilePath, encryptedPath, recipient); err != nil {
+			return fmt.Errorf("failed to encrypt vault: %w", err)
+		}
+	}
+
+	err = ops.NodeClient.CopyFile(ops, encryptedPath, remoteVaultPath)
 	if err != nil {
-		return fmt.Errorf("failed to encrypt vault on jumpbox: %w", err)
+		return fmt.Errorf("failed to copy encrypted vault to %s: %w", ops.GetName(), err)
 	}
 
 	return nil
 }
 
-// decryptVault creates an unencrypted copy of the vault in dst on the jumpbox
-// Make sure to delete the unencrypted file when not needed anymore.
-func (b *GCPBootstrapper) decryptVault(dst string) error {
-	err := b.Env.Jumpbox.RunSSHCommand("root", "cp "+b.Env.SecretsDir+"/prod.vault.yaml "+dst)
+// ExternalizeSecrets moves generated secret values (postgres passwords, CA keys,
+// registry credentials) out of the vault into the configured secret backend and
+// rewrites the vault file with reference URIs instead of the values. The
+// installer resolves the references back when it loads the vault.
+func (b *GCPBootstrapper) ExternalizeSecrets() error {
+	backend, err := b.secretBackend()
 	if err != nil {
-		return fmt.Errorf("failed to create tmp vault on jumpbox: %w", err)
+		return err
 	}
 
-	err = b.Env.Jumpbox.NodeClient.RunCommand(b.Env.Jumpbox, "root", "chmod 600 "+dst)
-	if err != nil {
-		return fmt.Errorf("failed to make vault file readable only for root on jumpbox: %w", err)
+	b.stlog.Logf("Externalizing vault secrets to %s...", b.Env.SecretBackend)
+	if err := vault.ExternalizeSecrets(b.icg.GetVault(), backend); err != nil {
+		return err
 	}
 
-	err = b.Env.Jumpbox.RunSSHCommand("root", "SOPS_AGE_KEY_FILE="+b.Env.SecretsDir+"/age_key.txt sops --decrypt --in-place "+dst)
-	if err != nil {
-		return fmt.Errorf("failed to decrypt vault on jumpbox: %w", err)
+	if err := b.icg.WriteVault(b.Env.SecretsFilePath, true); err != nil {
+		return fmt.Errorf("failed to write vault file: %w", err)
 	}
 
 	return nil
 }
+
+func (b *GCPBootstrapper) secretBackend() (vault.SecretBackend, error) {
+	switch b.Env.SecretBackend {
+	case SecretBackendGCPSecretManager:
+		return vault.NewGCPSecretManagerBackend(b.Env.ProjectID), nil
+	case SecretBackendHashiVault:
+		return vault.NewHashiVaultBackendFromEnv()
+	default:
+		return nil, fmt.Errorf("unsupported secret backend %q (supported: %s, %s)",
+			b.Env.SecretBackend, SecretBackendGCPSecretManager, SecretBackendHashiVault)
+	}
+}
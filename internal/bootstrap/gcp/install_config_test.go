@@ -6,17 +6,21 @@ package gcp_test
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"cloud.google.com/go/resourcemanager/apiv3/resourcemanagerpb"
 	"github.com/codesphere-cloud/oms/internal/bootstrap"
 	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/cloudflare"
 	"github.com/codesphere-cloud/oms/internal/env"
 	"github.com/codesphere-cloud/oms/internal/github"
 	"github.com/codesphere-cloud/oms/internal/installer"
 	"github.com/codesphere-cloud/oms/internal/installer/files"
 	"github.com/codesphere-cloud/oms/internal/installer/node"
 	"github.com/codesphere-cloud/oms/internal/installer/secrets"
+	"github.com/codesphere-cloud/oms/internal/installer/vault"
 	"github.com/codesphere-cloud/oms/internal/portal"
 	"github.com/codesphere-cloud/oms/internal/util"
 	. "github.com/onsi/ginkgo/v2"
@@ -26,6 +30,18 @@ import (
 	grpcstatus "google.golang.org/grpc/status"
 )
 
+// downloadFakeFile returns a DownloadFile mock implementation that copies the
+// given local file to the requested destination.
+func downloadFakeFile(src string) func(*node.Node, string, string) error {
+	return func(_ *node.Node, _ string, dst string) error {
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(dst, data, 0600)
+	}
+}
+
 var _ = Describe("Installconfig & Secrets", func() {
 	var (
 		nodeClient *node.MockNodeClient
@@ -57,6 +73,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 			mockPortalClient,
 			util.NewFakeTime(),
 			mockGitHubClient,
+			cloudflare.NewMockCloudflareClient(GinkgoT()),
 		)
 		Expect(err).NotTo(HaveOccurred())
 	})
@@ -147,13 +164,26 @@ var _ = Describe("Installconfig & Secrets", func() {
 			Describe("Config Recovery from Jumpbox", func() {
 				JustBeforeEach(func() {
 					csEnv.RecoverConfig = true
+					csEnv.SecretsFilePath = filepath.Join(GinkgoT().TempDir(), "prod.vault.yaml")
 					gc.EXPECT().GetProjectByName(mock.Anything, mock.Anything).Return(&resourcemanagerpb.Project{ProjectId: csEnv.ProjectID, Name: "existing-proj"}, nil)
 
 					runningResp := makeRunningInstance("10.0.0.x", "1.2.3.x")
 					gc.EXPECT().GetInstance(csEnv.ProjectID, csEnv.Zone, "jumpbox").Return(runningResp, nil)
 
-					nodeClient.EXPECT().DownloadFile(mock.Anything, mock.Anything, mock.Anything).Return(nil)
-					nodeClient.EXPECT().RunCommand(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+					// Provide a real age key and encrypted vault so the local
+					// decryption during recovery succeeds.
+					srcDir := GinkgoT().TempDir()
+					keyPath := filepath.Join(srcDir, "age_key.txt")
+					recipient, err := vault.GenerateAgeKey(keyPath)
+					Expect(err).NotTo(HaveOccurred())
+					plainPath := filepath.Join(srcDir, "plain.vault.yaml")
+					Expect(os.WriteFile(plainPath, []byte("secrets: {}\n"), 0600)).To(Succeed())
+					encryptedPath := filepath.Join(srcDir, "encrypted.vault.yaml")
+					Expect(vault.EncryptFileWithSOPS(plainPath, encryptedPath, recipient)).To(Succeed())
+
+					nodeClient.EXPECT().DownloadFile(mock.Anything, mock.Anything, csEnv.InstallConfigPath).Return(nil)
+					nodeClient.EXPECT().DownloadFile(mock.Anything, "/etc/codesphere/secrets/age_key.txt", mock.Anything).RunAndReturn(downloadFakeFile(keyPath))
+					nodeClient.EXPECT().DownloadFile(mock.Anything, "/etc/codesphere/secrets/prod.vault.yaml", mock.Anything).RunAndReturn(downloadFakeFile(encryptedPath))
 				})
 
 				It("overwrites an existing config", func() {
@@ -164,6 +194,10 @@ var _ = Describe("Installconfig & Secrets", func() {
 
 					err := bs.EnsureInstallConfig()
 					Expect(err).ToNot(HaveOccurred())
+
+					recovered, err := os.ReadFile(csEnv.SecretsFilePath)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(recovered)).To(ContainSubstring("secrets:"))
 				})
 			})
 		})
@@ -227,18 +261,18 @@ var _ = Describe("Installconfig & Secrets", func() {
 					Expect(err.Error()).To(ContainSubstring("failed to download install config from jumpbox"))
 				})
 
-				It("return an error when decrypting the secrets on the jumpbox for recovery", func() {
+				It("return an error when the age key download fails for recovery", func() {
 					gc.EXPECT().GetProjectByName(mock.Anything, mock.Anything).Return(&resourcemanagerpb.Project{ProjectId: csEnv.ProjectID, Name: "existing-proj"}, nil)
 
 					runningResp := makeRunningInstance("10.0.0.x", "1.2.3.x")
 					gc.EXPECT().GetInstance(csEnv.ProjectID, csEnv.Zone, "jumpbox").Return(runningResp, nil)
 
 					nodeClient.EXPECT().DownloadFile(mock.Anything, mock.Anything, csEnv.InstallConfigPath).Return(nil)
-					nodeClient.EXPECT().RunCommand(mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("failed"))
+					nodeClient.EXPECT().DownloadFile(mock.Anything, "/etc/codesphere/secrets/age_key.txt", mock.Anything).Return(fmt.Errorf("failed"))
 
 					err := bs.EnsureInstallConfig()
 					Expect(err).To(HaveOccurred())
-					Expect(err.Error()).To(ContainSubstring("failed to create decrypted vault for recovery"))
+					Expect(err.Error()).To(ContainSubstring("failed to download age key from jumpbox"))
 				})
 
 				It("return an error when secrets download fails from the jumpbox for recovery", func() {
@@ -248,28 +282,57 @@ var _ = Describe("Installconfig & Secrets", func() {
 					gc.EXPECT().GetInstance(csEnv.ProjectID, csEnv.Zone, "jumpbox").Return(runningResp, nil)
 
 					nodeClient.EXPECT().DownloadFile(mock.Anything, mock.Anything, csEnv.InstallConfigPath).Return(nil)
-					nodeClient.EXPECT().RunCommand(mock.Anything, mock.Anything, mock.Anything).Return(nil)
-					nodeClient.EXPECT().DownloadFile(mock.Anything, mock.Anything, csEnv.SecretsFilePath).Return(fmt.Errorf("failed"))
+					nodeClient.EXPECT().DownloadFile(mock.Anything, "/etc/codesphere/secrets/age_key.txt", mock.Anything).Return(nil)
+					nodeClient.EXPECT().DownloadFile(mock.Anything, "/etc/codesphere/secrets/prod.vault.yaml", mock.Anything).Return(fmt.Errorf("failed"))
 
 					err := bs.EnsureInstallConfig()
 					Expect(err).To(HaveOccurred())
 					Expect(err.Error()).To(ContainSubstring("failed to download secrets file from jumpbox"))
 				})
 
+				It("return an error when the recovered vault cannot be decrypted", func() {
+					gc.EXPECT().GetProjectByName(mock.Anything, mock.Anything).Return(&resourcemanagerpb.Project{ProjectId: csEnv.ProjectID, Name: "existing-proj"}, nil)
+
+					runningResp := makeRunningInstance("10.0.0.x", "1.2.3.x")
+					gc.EXPECT().GetInstance(csEnv.ProjectID, csEnv.Zone, "jumpbox").Return(runningResp, nil)
+
+					garbagePath := filepath.Join(GinkgoT().TempDir(), "garbage.yaml")
+					Expect(os.WriteFile(garbagePath, []byte("not: encrypted\n"), 0600)).To(Succeed())
+
+					nodeClient.EXPECT().DownloadFile(mock.Anything, mock.Anything, csEnv.InstallConfigPath).Return(nil)
+					nodeClient.EXPECT().DownloadFile(mock.Anything, "/etc/codesphere/secrets/age_key.txt", mock.Anything).Return(nil)
+					nodeClient.EXPECT().DownloadFile(mock.Anything, "/etc/codesphere/secrets/prod.vault.yaml", mock.Anything).RunAndReturn(downloadFakeFile(garbagePath))
+
+					err := bs.EnsureInstallConfig()
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("failed to decrypt vault for recovery"))
+				})
+
 				It("returns error when recovery is successful, but config file fails to load", func() {
+					csEnv.SecretsFilePath = filepath.Join(GinkgoT().TempDir(), "prod.vault.yaml")
 					gc.EXPECT().GetProjectByName(mock.Anything, mock.Anything).Return(&resourcemanagerpb.Project{ProjectId: csEnv.ProjectID, Name: "existing-proj"}, nil)
 
 					runningResp := makeRunningInstance("10.0.0.x", "1.2.3.x")
 					gc.EXPECT().GetInstance(csEnv.ProjectID, csEnv.Zone, "jumpbox").Return(runningResp, nil)
 
-					nodeClient.EXPECT().DownloadFile(mock.Anything, mock.Anything, mock.Anything).Return(nil)
-					nodeClient.EXPECT().RunCommand(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+					srcDir := GinkgoT().TempDir()
+					keyPath := filepath.Join(srcDir, "age_key.txt")
+					recipient, err := vault.GenerateAgeKey(keyPath)
+					Expect(err).NotTo(HaveOccurred())
+					plainPath := filepath.Join(srcDir, "plain.vault.yaml")
+					Expect(os.WriteFile(plainPath, []byte("secrets: {}\n"), 0600)).To(Succeed())
+					encryptedPath := filepath.Join(srcDir, "encrypted.vault.yaml")
+					Expect(vault.EncryptFileWithSOPS(plainPath, encryptedPath, recipient)).To(Succeed())
+
+					nodeClient.EXPECT().DownloadFile(mock.Anything, mock.Anything, csEnv.InstallConfigPath).Return(nil)
+					nodeClient.EXPECT().DownloadFile(mock.Anything, "/etc/codesphere/secrets/age_key.txt", mock.Anything).RunAndReturn(downloadFakeFile(keyPath))
+					nodeClient.EXPECT().DownloadFile(mock.Anything, "/etc/codesphere/secrets/prod.vault.yaml", mock.Anything).RunAndReturn(downloadFakeFile(encryptedPath))
 
 					fw.EXPECT().Exists(csEnv.InstallConfigPath).Return(true)
 					fw.EXPECT().Exists(csEnv.SecretsFilePath).Return(false)
 					icg.EXPECT().LoadInstallConfigFromFile(csEnv.InstallConfigPath).Return(fmt.Errorf("bad format"))
 
-					err := bs.EnsureInstallConfig()
+					err = bs.EnsureInstallConfig()
 					Expect(err).To(HaveOccurred())
 					Expect(err.Error()).To(ContainSubstring("failed to load config file"))
 					Expect(err.Error()).To(ContainSubstring("bad format"))
@@ -326,7 +389,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 				icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 				icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
 
-				nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+				nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 				err := bs.UpdateInstallConfig()
 				Expect(err).NotTo(HaveOccurred())
@@ -374,13 +437,213 @@ var _ = Describe("Installconfig & Secrets", func() {
 				icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 				icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
 
-				nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+				nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 				err := bs.UpdateInstallConfig()
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(bs.Env.InstallConfig.Datacenter.Name).To(Equal("staging"))
 			})
+			It("wires the postgres replica into the install config when provisioned", func() {
+				csEnv.HAPostgres = true
+				csEnv.PostgreSQLReplicaNode = fakeNode("postgres-replica", nodeClient)
+
+				icg.EXPECT().GenerateSecrets().Return(nil)
+				icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
+				icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
+
+				nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+				err := bs.UpdateInstallConfig()
+				Expect(err).NotTo(HaveOccurred())
+
+				replica := bs.Env.InstallConfig.Postgres.Replica
+				Expect(replica).NotTo(BeNil())
+				// Replica names must stay replication-slot safe, so the VM name is underscored.
+				Expect(replica.Name).To(Equal("postgres_replica"))
+				Expect(replica.IP).To(Equal(csEnv.PostgreSQLReplicaNode.GetInternalIP()))
+			})
+			It("points the install config at the Cloud SQL instance in cloudsql mode", func() {
+				csEnv.PostgresMode = gcp.PostgresModeCloudSQL
+				csEnv.CloudSQLInstanceIP = "10.20.0.3"
+				csEnv.PostgreSQLNode = nil
+
+				icg.EXPECT().GenerateSecrets().Return(nil)
+				icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
+				icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
+
+				nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+				err := bs.UpdateInstallConfig()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(bs.Env.InstallConfig.Postgres.Mode).To(Equal("external"))
+				Expect(bs.Env.InstallConfig.Postgres.ServerAddress).To(Equal("10.20.0.3:5432"))
+				Expect(bs.Env.InstallConfig.Postgres.Primary).To(BeNil())
+				Expect(bs.Env.InstallConfig.Postgres.Replica).To(BeNil())
+			})
+			It("points the install config at the external Ceph cluster in external storage mode", func() {
+				csEnv.StorageMode = gcp.StorageModeExternal
+				csEnv.CephMonHosts = []string{"10.0.0.10:6789", "10.0.0.11:6789"}
+				csEnv.CephKeyringFile = "fake-keyring"
+				csEnv.CephNodes = nil
+
+				fw.EXPECT().ReadFile("fake-keyring").Return([]byte("[client.codesphere]\n\tkey = fake"), nil)
+				icg.EXPECT().GenerateSecrets().Return(nil)
+				icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
+				icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
+
+				nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+				err := bs.UpdateInstallConfig()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(bs.Env.InstallConfig.Ceph.Mode).To(Equal("external"))
+				Expect(bs.Env.InstallConfig.Ceph.MonHosts).To(Equal(csEnv.CephMonHosts))
+				Expect(bs.Env.InstallConfig.Ceph.Hosts).To(BeNil())
+				Expect(bs.Env.InstallConfig.Ceph.OSDs).To(BeNil())
+
+				keyring := vault.GetSecret(files.SecretCephKeyring)
+				Expect(keyring).NotTo(BeNil())
+				Expect(keyring.Fields.Password).To(ContainSubstring("key = fake"))
+			})
+			It("sizes the OSD spec and pool from the configured ceph layout", func() {
+				csEnv.CephDataDiskGB = 200
+				csEnv.CephDBDiskGB = 20
+				csEnv.CephOSDsPerHost = 2
+				csEnv.CephReplicaCount = 2
+				csEnv.CephFailureDomain = "osd"
+
+				icg.EXPECT().GenerateSecrets().Return(nil)
+				icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
+				icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
+
+				nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+				err := bs.UpdateInstallConfig()
+				Expect(err).NotTo(HaveOccurred())
+
+				osd := bs.Env.InstallConfig.Ceph.OSDs[0]
+				Expect(osd.DataDevices.Size).To(Equal("100G:"))
+				Expect(osd.DataDevices.Limit).To(Equal(2))
+				Expect(osd.DBDevices.Size).To(Equal("20G:100G"))
+				Expect(osd.DBDevices.Limit).To(Equal(2))
+				Expect(bs.Env.InstallConfig.Ceph.Pool).To(Equal(&files.CephPoolConfig{
+					ReplicaCount:  2,
+					FailureDomain: "osd",
+				}))
+			})
+			It("configures a non-Ceph storage backend and skips the installer's ceph step", func() {
+				csEnv.StorageBackend = gcp.StorageBackendLonghorn
+				csEnv.CephNodes = nil
+
+				icg.EXPECT().GenerateSecrets().Return(nil)
+				icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
+				icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
+
+				nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+				err := bs.UpdateInstallConfig()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(bs.Env.InstallConfig.Storage).NotTo(BeNil())
+				Expect(bs.Env.InstallConfig.Storage.Backend).To(Equal("longhorn"))
+				Expect(bs.Env.InstallConfig.Storage.StorageClass).To(Equal("longhorn"))
+				Expect(bs.Env.InstallConfig.Ceph).To(Equal(files.CephConfig{}))
+				Expect(bs.Env.InstallConfig.Operations.Skip).To(ContainElement("ceph"))
+			})
+			It("keeps every node a worker without a dedicated control plane", func() {
+				icg.EXPECT().GenerateSecrets().Return(nil)
+				icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
+				icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
+
+				nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+				err := bs.UpdateInstallConfig()
+				Expect(err).NotTo(HaveOccurred())
+
+				k8s := bs.Env.InstallConfig.Kubernetes
+				Expect(k8s.ControlPlanes).To(HaveLen(1))
+				Expect(k8s.ControlPlanes[0].Taints).To(BeEmpty())
+				Expect(k8s.Workers).To(HaveLen(3))
+			})
+			It("separates dedicated control planes from the worker pool", func() {
+				csEnv.DedicatedControlPlane = true
+				csEnv.WorkerCount = 2
+				csEnv.WorkerTaints = []string{"pool=batch:NoSchedule"}
+				csEnv.ControlPlaneNodes = append(csEnv.ControlPlaneNodes, fakeNode("k0s-4", nodeClient), fakeNode("k0s-5", nodeClient))
+
+				icg.EXPECT().GenerateSecrets().Return(nil)
+				icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
+				icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
+
+				nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+				err := bs.UpdateInstallConfig()
+				Expect(err).NotTo(HaveOccurred())
+
+				k8s := bs.Env.InstallConfig.Kubernetes
+				Expect(k8s.ControlPlanes).To(HaveLen(3))
+				for _, cp := range k8s.ControlPlanes {
+					Expect(cp.Taints).To(ConsistOf(gcp.ControlPlaneTaint))
+				}
+				Expect(k8s.Workers).To(HaveLen(2))
+				for _, worker := range k8s.Workers {
+					Expect(worker.Taints).To(Equal(csEnv.WorkerTaints))
+				}
+			})
+			It("records the distribution and its kubelet dir", func() {
+				csEnv.K8sDistribution = gcp.K8sDistributionRKE2
+
+				icg.EXPECT().GenerateSecrets().Return(nil)
+				icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
+				icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
+
+				nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+				err := bs.UpdateInstallConfig()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(bs.Env.InstallConfig.Kubernetes.Distribution).To(Equal("rke2"))
+				Expect(bs.Env.InstallConfig.Ceph.CsiKubeletDir).To(Equal("/var/lib/kubelet"))
+			})
+			It("hands the existing cluster to the installer in BYO mode", func() {
+				csEnv.KubeconfigPath = "fake-kubeconfig"
+				csEnv.GatewayIP = "4.4.4.4"
+				csEnv.ControlPlaneNodes = nil
+
+				fw.EXPECT().ReadFile("fake-kubeconfig").Return([]byte("apiVersion: v1\nkind: Config\n"), nil)
+				icg.EXPECT().GenerateSecrets().Return(nil)
+				icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
+				icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
+
+				nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+				err := bs.UpdateInstallConfig()
+				Expect(err).NotTo(HaveOccurred())
+
+				k8s := bs.Env.InstallConfig.Kubernetes
+				Expect(k8s.ManagedByCodesphere).To(BeFalse())
+				Expect(k8s.ControlPlanes).To(BeEmpty())
+				Expect(k8s.Workers).To(BeEmpty())
+				Expect(bs.Env.InstallConfig.Codesphere.PublicIP).To(Equal("4.4.4.4"))
+
+				kubeconfig := vault.GetSecret(files.SecretKubeConfig)
+				Expect(kubeconfig).NotTo(BeNil())
+				Expect(kubeconfig.File.Content).To(ContainSubstring("kind: Config"))
+			})
+			It("leaves the replica config empty for a single postgres VM", func() {
+				icg.EXPECT().GenerateSecrets().Return(nil)
+				icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
+				icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
+
+				nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+				err := bs.UpdateInstallConfig()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(bs.Env.InstallConfig.Postgres.Replica).To(BeNil())
+			})
 			Context("When internal flags are set in CodesphereEnvironment", func() {
 				BeforeEach(func() {
 					csEnv.InternalFlags = []string{"fake-exp1", "fake-exp2"}
@@ -390,7 +653,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
 
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -407,7 +670,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
 
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -424,7 +687,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
 
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -432,6 +695,39 @@ var _ = Describe("Installconfig & Secrets", func() {
 					Expect(bs.Env.InstallConfig.Codesphere.Features).To(Equal(util.StringSliceToBoolMap(csEnv.FeatureFlags)))
 				})
 			})
+			Context("When node-local DNS is enabled", func() {
+				BeforeEach(func() {
+					csEnv.NodeLocalDNS = true
+				})
+				It("points the Codesphere DNS servers at the node-local cache", func() {
+					icg.EXPECT().GenerateSecrets().Return(nil)
+					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
+					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
+
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+					err := bs.UpdateInstallConfig()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(bs.Env.InstallConfig.Kubernetes.NodeLocalDNS).To(BeTrue())
+					Expect(bs.Env.InstallConfig.Codesphere.DNSServers).To(Equal([]string{installer.NodeLocalDNSIP}))
+				})
+			})
+			Context("When node-local DNS is disabled", func() {
+				It("falls back to the public resolver", func() {
+					icg.EXPECT().GenerateSecrets().Return(nil)
+					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
+					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
+
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+					err := bs.UpdateInstallConfig()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(bs.Env.InstallConfig.Kubernetes.NodeLocalDNS).To(BeFalse())
+					Expect(bs.Env.InstallConfig.Codesphere.DNSServers).To(Equal([]string{"8.8.8.8"}))
+				})
+			})
 			Context("When cluster admin email is set", func() {
 				BeforeEach(func() {
 					csEnv.ClusterAdminEmail = "admin@codesphere.com"
@@ -441,7 +737,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
 
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -458,7 +754,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
 
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -475,7 +771,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
 
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -493,7 +789,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -517,7 +813,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -535,7 +831,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -559,7 +855,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -577,7 +873,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -602,7 +898,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -622,7 +918,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -649,7 +945,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -663,7 +959,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -681,7 +977,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -697,7 +993,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -719,7 +1015,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -737,7 +1033,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -751,7 +1047,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -769,7 +1065,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -800,7 +1096,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -820,7 +1116,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
 
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -842,7 +1138,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
 
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -857,7 +1153,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -877,7 +1173,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
 
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -899,7 +1195,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -919,7 +1215,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -938,7 +1234,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -961,7 +1257,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -986,7 +1282,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -1005,7 +1301,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 					icg.EXPECT().GenerateSecrets().Return(nil)
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -1055,18 +1351,6 @@ var _ = Describe("Installconfig & Secrets", func() {
 				Expect(err.Error()).To(ContainSubstring("failed to copy install config to jumpbox"))
 			})
 
-			It("fails when CopyFile secrets fails", func() {
-				icg.EXPECT().GenerateSecrets().Return(nil)
-				icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
-				icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-
-				nodeClient.EXPECT().CopyFile(mock.Anything, "fake-config-file", mock.Anything).Return(nil).Once()
-				nodeClient.EXPECT().CopyFile(mock.Anything, "fake-secret", mock.Anything).Return(fmt.Errorf("copy error")).Once()
-
-				err := bs.UpdateInstallConfig()
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("failed to copy secrets file to jumpbox"))
-			})
 		})
 
 		Describe("ExistingConfigUsed", func() {
@@ -1096,7 +1380,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -1128,7 +1412,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -1148,6 +1432,55 @@ var _ = Describe("Installconfig & Secrets", func() {
 				})
 			})
 
+			Context("with a replica whose IP changed", func() {
+				BeforeEach(func() {
+					caKey, caCert, err := secrets.GenerateCA("Test CA", "DE", "Berlin", "TestOrg")
+					Expect(err).NotTo(HaveOccurred())
+
+					primaryKey, primaryCert, err := secrets.GenerateServerCertificate(caKey, caCert, "postgres", []string{"10.0.0.1"})
+					Expect(err).NotTo(HaveOccurred())
+					replicaKey, replicaCert, err := secrets.GenerateServerCertificate(caKey, caCert, "postgres_replica", []string{"10.0.0.99"})
+					Expect(err).NotTo(HaveOccurred())
+
+					vault.SetSecret(files.SecretEntry{Name: files.SecretPostgresCaKeyPem, File: &files.SecretFile{Name: "ca.key", Content: caKey}})
+					vault.SetSecret(files.SecretEntry{Name: files.SecretPostgresPrimaryServerKeyPem, File: &files.SecretFile{Name: "primary.key", Content: primaryKey}})
+					vault.SetSecret(files.SecretEntry{Name: files.SecretPostgresReplicaServerKeyPem, File: &files.SecretFile{Name: "replica.key", Content: replicaKey}})
+					csEnv.InstallConfig.Postgres.CACertPem = caCert
+					csEnv.InstallConfig.Postgres.Primary.IP = "10.0.0.1"
+					csEnv.InstallConfig.Postgres.Primary.Hostname = "postgres"
+					csEnv.InstallConfig.Postgres.Primary.SSLConfig.ServerCertPem = primaryCert
+					csEnv.InstallConfig.Postgres.Replica = &files.PostgresReplicaConfig{
+						IP:        "10.0.0.99",
+						Name:      "postgres_replica",
+						SSLConfig: files.SSLConfig{ServerCertPem: replicaCert},
+					}
+					csEnv.HAPostgres = true
+					csEnv.PostgreSQLReplicaNode = fakeNode("postgres-replica", nodeClient)
+				})
+
+				It("regenerates only the replica cert/key", func() {
+					origPrimaryKey := vault.GetSecret(files.SecretPostgresPrimaryServerKeyPem).File.Content
+					origReplicaKey := vault.GetSecret(files.SecretPostgresReplicaServerKeyPem).File.Content
+
+					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
+					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+					err := bs.UpdateInstallConfig()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(vault.GetSecret(files.SecretPostgresPrimaryServerKeyPem).File.Content).To(Equal(origPrimaryKey))
+					Expect(bs.Env.InstallConfig.Postgres.Replica.IP).To(Equal("10.0.0.1"))
+					newReplicaKey := vault.GetSecret(files.SecretPostgresReplicaServerKeyPem).File.Content
+					Expect(newReplicaKey).NotTo(Equal(origReplicaKey))
+					err = secrets.ValidateCertKeyPair(
+						bs.Env.InstallConfig.Postgres.Replica.SSLConfig.ServerCertPem,
+						newReplicaKey,
+					)
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
 			Context("with empty PrivateKey (not loaded from vault)", func() {
 				BeforeEach(func() {
 					caKey, caCert, err := secrets.GenerateCA("Test CA", "DE", "Berlin", "TestOrg")
@@ -1162,7 +1495,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 				It("generates new cert/key pair", func() {
 					icg.EXPECT().WriteInstallConfig("fake-config-file", true).Return(nil)
 					icg.EXPECT().WriteVault("fake-secret", true).Return(nil)
-					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+					nodeClient.EXPECT().CopyFile(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 					err := bs.UpdateInstallConfig()
 					Expect(err).NotTo(HaveOccurred())
@@ -1197,9 +1530,14 @@ var _ = Describe("Installconfig & Secrets", func() {
 
 	Describe("EnsureAgeKey", func() {
 		Describe("Valid EnsureAgeKey", func() {
-			It("generates key if missing", func() {
+			It("generates key locally and uploads it if missing", func() {
 				nodeClient.EXPECT().HasFile(mock.MatchedBy(jumpboxMatcher), "/etc/codesphere/secrets/age_key.txt").Return(false)
-				nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", "mkdir -p /etc/codesphere/secrets; age-keygen -o /etc/codesphere/secrets/age_key.txt").Return(nil)
+				nodeClient.EXPECT().CopyFile(mock.MatchedBy(jumpboxMatcher), mock.Anything, "/etc/codesphere/secrets/age_key.txt").RunAndReturn(func(_ *node.Node, src, _ string) error {
+					// the uploaded file must contain a valid age identity
+					_, err := vault.RecipientFromKeyFile(src)
+					return err
+				})
+				nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", "chmod 600 /etc/codesphere/secrets/age_key.txt").Return(nil)
 
 				err := bs.EnsureAgeKey()
 				Expect(err).NotTo(HaveOccurred())
@@ -1214,27 +1552,42 @@ var _ = Describe("Installconfig & Secrets", func() {
 		})
 
 		Describe("Invalid cases", func() {
-			It("fails when age-keygen command fails", func() {
+			It("fails when the key upload fails", func() {
 				nodeClient.EXPECT().HasFile(mock.MatchedBy(jumpboxMatcher), "/etc/codesphere/secrets/age_key.txt").Return(false)
-				nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", "mkdir -p /etc/codesphere/secrets; age-keygen -o /etc/codesphere/secrets/age_key.txt").Return(fmt.Errorf("ouch"))
+				nodeClient.EXPECT().CopyFile(mock.MatchedBy(jumpboxMatcher), mock.Anything, "/etc/codesphere/secrets/age_key.txt").Return(fmt.Errorf("ouch"))
 
 				err := bs.EnsureAgeKey()
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("failed to generate age key on jumpbox"))
+				Expect(err.Error()).To(ContainSubstring("failed to copy age key to jumpbox"))
 			})
 		})
 	})
 
 	Describe("EncryptVault", func() {
-		Describe("Valid EncryptVault", func() {
-			It("encrypts vault using sops", func() {
-				nodeClient.EXPECT().RunCommand(bs.Env.Jumpbox, "root", mock.MatchedBy(func(cmd string) bool {
-					return strings.HasPrefix(cmd, "cp ")
-				})).Return(nil)
+		var keyPath string
 
-				nodeClient.EXPECT().RunCommand(bs.Env.Jumpbox, "root", mock.MatchedBy(func(cmd string) bool {
-					return strings.Contains(cmd, "sops --encrypt")
-				})).Return(nil)
+		BeforeEach(func() {
+			tmpDir := GinkgoT().TempDir()
+			keyPath = filepath.Join(tmpDir, "age_key.txt")
+			_, err := vault.GenerateAgeKey(keyPath)
+			Expect(err).NotTo(HaveOccurred())
+
+			csEnv.SecretsFilePath = filepath.Join(tmpDir, "prod.vault.yaml")
+			Expect(os.WriteFile(csEnv.SecretsFilePath, []byte("secrets:\n  password: hunter2\n"), 0600)).To(Succeed())
+		})
+
+		Describe("Valid EncryptVault", func() {
+			It("encrypts the vault locally and uploads only the ciphertext", func() {
+				nodeClient.EXPECT().HasFile(bs.Env.Jumpbox, "/etc/codesphere/secrets/prod.vault.yaml").Return(true)
+				nodeClient.EXPECT().RunCommand(bs.Env.Jumpbox, "root", "cp /etc/codesphere/secrets/prod.vault.yaml{,.bak}").Return(nil)
+				nodeClient.EXPECT().DownloadFile(bs.Env.Jumpbox, "/etc/codesphere/secrets/age_key.txt", mock.Anything).RunAndReturn(downloadFakeFile(keyPath))
+				nodeClient.EXPECT().CopyFile(bs.Env.Jumpbox, mock.Anything, "/etc/codesphere/secrets/prod.vault.yaml").RunAndReturn(func(_ *node.Node, src, _ string) error {
+					data, err := os.ReadFile(src)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(data)).To(ContainSubstring("sops:"))
+					Expect(string(data)).NotTo(ContainSubstring("hunter2"))
+					return nil
+				})
 
 				err := bs.EncryptVault()
 				Expect(err).NotTo(HaveOccurred())
@@ -1243,6 +1596,7 @@ var _ = Describe("Installconfig & Secrets", func() {
 
 		Describe("Invalid cases", func() {
 			It("fails when backup vault command fails", func() {
+				nodeClient.EXPECT().HasFile(bs.Env.Jumpbox, "/etc/codesphere/secrets/prod.vault.yaml").Return(true)
 				nodeClient.EXPECT().RunCommand(bs.Env.Jumpbox, "root", mock.MatchedBy(func(cmd string) bool {
 					return strings.HasPrefix(cmd, "cp ")
 				})).Return(fmt.Errorf("backup error"))
@@ -1252,18 +1606,51 @@ var _ = Describe("Installconfig & Secrets", func() {
 				Expect(err.Error()).To(ContainSubstring("failed backup vault on jumpbox"))
 			})
 
-			It("fails when sops encrypt command fails", func() {
-				nodeClient.EXPECT().RunCommand(bs.Env.Jumpbox, "root", mock.MatchedBy(func(cmd string) bool {
-					return strings.HasPrefix(cmd, "cp ")
-				})).Return(nil)
+			It("fails when the vault cannot be encrypted", func() {
+				Expect(os.WriteFile(csEnv.SecretsFilePath, []byte(":\tnot yaml"), 0600)).To(Succeed())
 
-				nodeClient.EXPECT().RunCommand(bs.Env.Jumpbox, "root", mock.MatchedBy(func(cmd string) bool {
-					return strings.Contains(cmd, "sops --encrypt")
-				})).Return(fmt.Errorf("encrypt error"))
+				nodeClient.EXPECT().HasFile(bs.Env.Jumpbox, "/etc/codesphere/secrets/prod.vault.yaml").Return(false)
+				nodeClient.EXPECT().DownloadFile(bs.Env.Jumpbox, "/etc/codesphere/secrets/age_key.txt", mock.Anything).RunAndReturn(downloadFakeFile(keyPath))
 
 				err := bs.EncryptVault()
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("failed to encrypt vault on jumpbox"))
+				Expect(err.Error()).To(ContainSubstring("failed to encrypt vault"))
+			})
+		})
+	})
+
+	Describe("ExternalizeSecrets", func() {
+		BeforeEach(func() {
+			csEnv.SecretBackend = gcp.SecretBackendHashiVault
+			GinkgoT().Setenv("VAULT_ADDR", "https://vault.example.com")
+		})
+
+		Describe("Valid ExternalizeSecrets", func() {
+			It("rewrites the vault file after externalizing", func() {
+				icg.EXPECT().GetVault().Return(&files.InstallVault{})
+				icg.EXPECT().WriteVault(csEnv.SecretsFilePath, true).Return(nil)
+
+				err := bs.ExternalizeSecrets()
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Describe("Invalid cases", func() {
+			It("fails for an unsupported secret backend", func() {
+				csEnv.SecretBackend = "s3"
+
+				err := bs.ExternalizeSecrets()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("unsupported secret backend"))
+			})
+
+			It("fails when the vault cannot be written", func() {
+				icg.EXPECT().GetVault().Return(&files.InstallVault{})
+				icg.EXPECT().WriteVault(csEnv.SecretsFilePath, true).Return(fmt.Errorf("disk full"))
+
+				err := bs.ExternalizeSecrets()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to write vault file"))
 			})
 		})
 	})
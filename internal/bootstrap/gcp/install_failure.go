@@ -0,0 +1,66 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/codesphere-cloud/oms/internal/installer"
+)
+
+// InstallFailure records the last remote installer failure, parsed from the
+// structured status the install command writes on the install host.
+type InstallFailure struct {
+	// Step is the installer phase that failed, empty when the install died
+	// before reaching a phase.
+	Step   string    `json:"step,omitempty"`
+	Reason string    `json:"reason"`
+	At     time.Time `json:"at"`
+}
+
+// recordInstallFailure downloads the installer's structured status from the
+// ops node and records the failed step and reason in the environment state,
+// so the cause is visible without log spelunking on the jumpbox. When the
+// status cannot be fetched or parsed, the original error is returned as is.
+func (b *GCPBootstrapper) recordInstallFailure(installErr error) error {
+	ops := b.opsNode()
+	if err := ops.NodeClient.DownloadFile(ops, installer.InstallStatusPath, "install-status.json"); err != nil {
+		b.stlog.Logf("Warning: failed to download install status from %s: %v", ops.GetName(), err)
+		return installErr
+	}
+	data, err := b.fw.ReadFile("install-status.json")
+	if err != nil {
+		b.stlog.Logf("Warning: failed to read downloaded install status: %v", err)
+		return installErr
+	}
+	status, err := installer.ParseInstallStatus(data)
+	if err != nil {
+		b.stlog.Logf("Warning: %v", err)
+		return installErr
+	}
+
+	failure := &InstallFailure{Reason: status.Error, At: status.UpdatedAt}
+	for _, phase := range status.Phases {
+		if phase.State == installer.StatusFailed {
+			failure.Step = phase.Name
+			if phase.Error != "" {
+				failure.Reason = phase.Error
+			}
+		}
+	}
+	if failure.Step == "" && failure.Reason == "" {
+		return installErr
+	}
+
+	b.Env.LastFailure = failure
+	if err := b.WriteInfraFile(); err != nil {
+		b.stlog.Logf("Warning: failed to record install failure in the infra file: %v", err)
+	}
+
+	if failure.Step != "" {
+		return fmt.Errorf("install failed at step %s: %s", failure.Step, failure.Reason)
+	}
+	return fmt.Errorf("install failed: %s", failure.Reason)
+}
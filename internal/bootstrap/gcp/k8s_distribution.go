@@ -0,0 +1,91 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+)
+
+// Supported Kubernetes distributions.
+const (
+	K8sDistributionK0s  = "k0s"
+	K8sDistributionK3s  = "k3s"
+	K8sDistributionRKE2 = "rke2"
+)
+
+// K8sDistribution captures the distribution-specific bits of the host
+// configuration: how kubectl is invoked on the nodes, which systemd units run
+// the control plane and the workers, and where the kubelet keeps its data.
+type K8sDistribution struct {
+	Name string
+	// KubectlCommand invokes kubectl on the nodes.
+	KubectlCommand string
+	// ControllerUnit and WorkerUnit are the systemd services running the
+	// control plane and the worker processes.
+	ControllerUnit string
+	WorkerUnit     string
+	// KubeletDir is the kubelet root directory CSI drivers must register
+	// under.
+	KubeletDir string
+	// PatchCloudProvider marks distributions whose systemd units must be
+	// patched with --enable-cloud-provider before the GCP cloud controller
+	// manager can adopt the nodes. Only k0s needs this; k3s and RKE2 take
+	// the setting through their own config files.
+	PatchCloudProvider bool
+}
+
+var k8sDistributions = map[string]K8sDistribution{
+	K8sDistributionK0s: {
+		Name:               K8sDistributionK0s,
+		KubectlCommand:     "/etc/codesphere/deps/kubernetes/files/k0s kubectl",
+		ControllerUnit:     "k0scontroller",
+		WorkerUnit:         "k0sworker",
+		KubeletDir:         "/var/lib/k0s/kubelet",
+		PatchCloudProvider: true,
+	},
+	K8sDistributionK3s: {
+		Name:           K8sDistributionK3s,
+		KubectlCommand: "k3s kubectl",
+		ControllerUnit: "k3s",
+		WorkerUnit:     "k3s-agent",
+		KubeletDir:     "/var/lib/kubelet",
+	},
+	K8sDistributionRKE2: {
+		Name:           K8sDistributionRKE2,
+		KubectlCommand: "/var/lib/rancher/rke2/bin/kubectl --kubeconfig /etc/rancher/rke2/rke2.yaml",
+		ControllerUnit: "rke2-server",
+		WorkerUnit:     "rke2-agent",
+		KubeletDir:     "/var/lib/kubelet",
+	},
+}
+
+// validateK8sDistribution ensures the selected distribution is supported.
+func (b *GCPBootstrapper) validateK8sDistribution() error {
+	if b.Env.K8sDistribution == "" {
+		return nil
+	}
+	if _, ok := k8sDistributions[b.Env.K8sDistribution]; !ok {
+		return fmt.Errorf("invalid kubernetes distribution %q: must be %s, %s or %s", b.Env.K8sDistribution, K8sDistributionK0s, K8sDistributionK3s, K8sDistributionRKE2)
+	}
+	return nil
+}
+
+// distribution returns the selected Kubernetes distribution, defaulting
+// to k0s.
+func (b *GCPBootstrapper) distribution() K8sDistribution {
+	if dist, ok := k8sDistributions[b.Env.K8sDistribution]; ok {
+		return dist
+	}
+	return k8sDistributions[K8sDistributionK0s]
+}
+
+// cloudProviderUnitPatch returns the shell commands enabling the external
+// cloud provider on a systemd unit, or an empty string when the distribution
+// does not need the unit patched.
+func (d K8sDistribution) cloudProviderUnitPatch(unit string) string {
+	if !d.PatchCloudProvider {
+		return ""
+	}
+	return fmt.Sprintf("sed -i 's/%[1]s/%[1]s --enable-cloud-provider/g' /etc/systemd/system/%[1]s.service; systemctl daemon-reload; systemctl restart %[1]s", unit)
+}
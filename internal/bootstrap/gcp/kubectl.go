@@ -0,0 +1,54 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/codesphere-cloud/oms/internal/util"
+)
+
+// remoteKubectl invokes the distribution's kubectl on a cluster node through
+// the node client, so manifests rendered locally can be applied without
+// embedding them in shell scripts.
+type remoteKubectl struct {
+	node    *node.Node
+	fw      util.FileIO
+	command string
+}
+
+// kubectl returns a remoteKubectl bound to the first control plane node.
+func (b *GCPBootstrapper) kubectl() remoteKubectl {
+	return remoteKubectl{
+		node:    b.Env.ControlPlaneNodes[0],
+		fw:      b.fw,
+		command: b.distribution().KubectlCommand,
+	}
+}
+
+// applyManifest writes the rendered manifest to the local working directory,
+// stages it on the node and applies it.
+func (k remoteKubectl) applyManifest(filename string, manifest []byte) error {
+	err := k.fw.WriteFile(filename, manifest, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+	remotePath := "/root/" + filename
+	err = k.node.NodeClient.CopyFile(k.node, filename, remotePath)
+	if err != nil {
+		return fmt.Errorf("failed to copy %s to %s: %w", filename, k.node.GetName(), err)
+	}
+	return k.run(fmt.Sprintf("apply -f %s", remotePath))
+}
+
+// run executes kubectl with the given arguments on the node.
+func (k remoteKubectl) run(args string) error {
+	cmd := k.command + " " + args
+	err := k.node.RunSSHCommand("root", cmd)
+	if err != nil {
+		return fmt.Errorf("failed to run %q on %s: %w", cmd, k.node.GetName(), err)
+	}
+	return nil
+}
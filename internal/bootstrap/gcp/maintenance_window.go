@@ -0,0 +1,225 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/codesphere-cloud/oms/internal/util"
+)
+
+// MaintenanceWindow restricts when disruptive operations may run, so managed
+// environments follow the customer's change policy. Times are interpreted in
+// the window's timezone; an end before the start wraps past midnight.
+type MaintenanceWindow struct {
+	// Days are short weekday names ("Mon"); empty means every day. For windows
+	// wrapping past midnight the day refers to when the window opens.
+	Days  []string `json:"days,omitempty"`
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+	// Timezone is an IANA name like "Europe/Berlin"; empty means UTC.
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// timeRangePattern matches the HH:MM-HH:MM part of a window spec.
+var timeRangePattern = regexp.MustCompile(`^\d{1,2}:\d{2}-\d{1,2}:\d{2}$`)
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ParseMaintenanceWindow parses a window spec of the form
+// "[days] HH:MM-HH:MM [timezone]", e.g. "Sat,Sun 22:00-04:00 Europe/Berlin".
+// Days accept comma-separated names or a range like "Mon-Fri".
+func ParseMaintenanceWindow(spec string) (*MaintenanceWindow, error) {
+	fields := strings.Fields(spec)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("maintenance window spec is empty")
+	}
+
+	w := &MaintenanceWindow{}
+	if !timeRangePattern.MatchString(fields[0]) {
+		days, err := parseWindowDays(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		w.Days = days
+		fields = fields[1:]
+	}
+
+	if len(fields) == 0 || !timeRangePattern.MatchString(fields[0]) {
+		return nil, fmt.Errorf("maintenance window %q must contain a time range like 22:00-04:00", spec)
+	}
+	timeRange := strings.SplitN(fields[0], "-", 2)
+	w.Start = timeRange[0]
+	w.End = timeRange[1]
+	fields = fields[1:]
+
+	if len(fields) > 1 {
+		return nil, fmt.Errorf("maintenance window %q has unexpected trailing fields", spec)
+	}
+	if len(fields) == 1 {
+		w.Timezone = fields[0]
+	}
+
+	if _, err := w.location(); err != nil {
+		return nil, err
+	}
+	if _, err := parseWindowTime(w.Start); err != nil {
+		return nil, err
+	}
+	if _, err := parseWindowTime(w.End); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// parseWindowDays expands a day field like "Sat,Sun" or "Mon-Fri" into
+// normalized short weekday names.
+func parseWindowDays(field string) ([]string, error) {
+	if from, to, isRange := strings.Cut(field, "-"); isRange {
+		first, err := parseWeekday(from)
+		if err != nil {
+			return nil, err
+		}
+		last, err := parseWeekday(to)
+		if err != nil {
+			return nil, err
+		}
+		days := []string{first.String()[:3]}
+		for day := first; day != last; {
+			day = (day + 1) % 7
+			days = append(days, day.String()[:3])
+		}
+		return days, nil
+	}
+
+	days := []string{}
+	for _, name := range strings.Split(field, ",") {
+		day, err := parseWeekday(name)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, day.String()[:3])
+	}
+	return days, nil
+}
+
+func parseWeekday(name string) (time.Weekday, error) {
+	lower := strings.ToLower(name)
+	if len(lower) >= 3 {
+		if day, ok := weekdayNames[lower[:3]]; ok {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday %q in maintenance window", name)
+}
+
+// parseWindowTime converts an HH:MM string into minutes since midnight.
+func parseWindowTime(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid maintenance window time %q: %w", value, err)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func (w *MaintenanceWindow) location() (*time.Location, error) {
+	if w.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid maintenance window timezone %q: %w", w.Timezone, err)
+	}
+	return loc, nil
+}
+
+func (w *MaintenanceWindow) dayMatches(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, name := range w.Days {
+		if parsed, err := parseWeekday(name); err == nil && parsed == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains reports whether t falls inside the window, evaluated in the
+// window's timezone.
+func (w *MaintenanceWindow) Contains(t time.Time) (bool, error) {
+	loc, err := w.location()
+	if err != nil {
+		return false, err
+	}
+	start, err := parseWindowTime(w.Start)
+	if err != nil {
+		return false, err
+	}
+	end, err := parseWindowTime(w.End)
+	if err != nil {
+		return false, err
+	}
+
+	local := t.In(loc)
+	now := local.Hour()*60 + local.Minute()
+	if start <= end {
+		return now >= start && now < end && w.dayMatches(local.Weekday()), nil
+	}
+
+	// The window wraps past midnight: the early-morning part still belongs to
+	// the day the window opened on.
+	if now >= start {
+		return w.dayMatches(local.Weekday()), nil
+	}
+	if now < end {
+		return w.dayMatches((local.Weekday() + 6) % 7), nil
+	}
+	return false, nil
+}
+
+func (w *MaintenanceWindow) String() string {
+	parts := []string{}
+	if len(w.Days) > 0 {
+		parts = append(parts, strings.Join(w.Days, ","))
+	}
+	parts = append(parts, fmt.Sprintf("%s-%s", w.Start, w.End))
+	if w.Timezone != "" {
+		parts = append(parts, w.Timezone)
+	}
+	return strings.Join(parts, " ")
+}
+
+// ConfirmMaintenanceWindow refuses a disruptive operation outside the
+// environment's maintenance window. A nil window allows the operation at any
+// time; with override the operation runs anyway, with a warning.
+func ConfirmMaintenanceWindow(w *MaintenanceWindow, clock util.Time, operation string, override bool) error {
+	if w == nil {
+		return nil
+	}
+	inside, err := w.Contains(clock.Now())
+	if err != nil {
+		return fmt.Errorf("failed to evaluate maintenance window: %w", err)
+	}
+	if inside {
+		return nil
+	}
+	if override {
+		log.Printf("WARNING: running %s outside the maintenance window (%s) because --override-window is set.", operation, w)
+		return nil
+	}
+	return fmt.Errorf("%s is outside the maintenance window (%s); wait for the window or pass --override-window", operation, w)
+}
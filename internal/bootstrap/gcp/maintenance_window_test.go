@@ -0,0 +1,115 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/util"
+)
+
+var _ = Describe("MaintenanceWindow", func() {
+	Describe("ParseMaintenanceWindow", func() {
+		It("parses a full spec with days and timezone", func() {
+			w, err := gcp.ParseMaintenanceWindow("Sat,Sun 22:00-04:00 Europe/Berlin")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(w.Days).To(Equal([]string{"Sat", "Sun"}))
+			Expect(w.Start).To(Equal("22:00"))
+			Expect(w.End).To(Equal("04:00"))
+			Expect(w.Timezone).To(Equal("Europe/Berlin"))
+		})
+
+		It("expands a day range", func() {
+			w, err := gcp.ParseMaintenanceWindow("Fri-Mon 22:00-23:00")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(w.Days).To(Equal([]string{"Fri", "Sat", "Sun", "Mon"}))
+		})
+
+		It("parses a time range without days or timezone", func() {
+			w, err := gcp.ParseMaintenanceWindow("01:00-05:00")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(w.Days).To(BeEmpty())
+			Expect(w.Timezone).To(BeEmpty())
+		})
+
+		It("rejects a spec without a time range", func() {
+			_, err := gcp.ParseMaintenanceWindow("Sat,Sun")
+			Expect(err).To(MatchError(ContainSubstring("must contain a time range")))
+		})
+
+		It("rejects an unknown weekday", func() {
+			_, err := gcp.ParseMaintenanceWindow("Caturday 22:00-04:00")
+			Expect(err).To(MatchError(ContainSubstring("invalid weekday")))
+		})
+
+		It("rejects an unknown timezone", func() {
+			_, err := gcp.ParseMaintenanceWindow("22:00-04:00 Mars/Olympus")
+			Expect(err).To(MatchError(ContainSubstring("invalid maintenance window timezone")))
+		})
+	})
+
+	Describe("Contains", func() {
+		It("matches a time inside the window", func() {
+			w := &gcp.MaintenanceWindow{Start: "22:00", End: "23:00"}
+			Expect(w.Contains(time.Date(2026, 1, 1, 22, 30, 0, 0, time.UTC))).To(BeTrue())
+		})
+
+		It("does not match a time outside the window", func() {
+			w := &gcp.MaintenanceWindow{Start: "22:00", End: "23:00"}
+			Expect(w.Contains(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))).To(BeFalse())
+		})
+
+		It("evaluates the window in its timezone", func() {
+			// 12:00 UTC is 13:00 in Berlin during winter.
+			w := &gcp.MaintenanceWindow{Start: "13:00", End: "14:00", Timezone: "Europe/Berlin"}
+			Expect(w.Contains(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))).To(BeTrue())
+		})
+
+		It("restricts the window to the configured days", func() {
+			// 2026-01-01 is a Thursday.
+			w := &gcp.MaintenanceWindow{Days: []string{"Sat", "Sun"}, Start: "22:00", End: "23:00"}
+			Expect(w.Contains(time.Date(2026, 1, 1, 22, 30, 0, 0, time.UTC))).To(BeFalse())
+		})
+
+		It("wraps past midnight into the next day", func() {
+			// Saturday's window reaches into early Sunday, but not Sunday evening.
+			w := &gcp.MaintenanceWindow{Days: []string{"Sat"}, Start: "22:00", End: "04:00"}
+			Expect(w.Contains(time.Date(2026, 1, 4, 2, 0, 0, 0, time.UTC))).To(BeTrue())
+			Expect(w.Contains(time.Date(2026, 1, 4, 22, 30, 0, 0, time.UTC))).To(BeFalse())
+		})
+	})
+
+	Describe("ConfirmMaintenanceWindow", func() {
+		var clock *util.FakeTime
+
+		BeforeEach(func() {
+			clock = util.NewFakeTime()
+		})
+
+		It("allows the operation when no window is configured", func() {
+			Expect(gcp.ConfirmMaintenanceWindow(nil, clock, "teardown", false)).To(Succeed())
+		})
+
+		It("allows the operation inside the window", func() {
+			clock.CurrentTime = time.Date(2026, 1, 1, 22, 30, 0, 0, time.UTC)
+			w := &gcp.MaintenanceWindow{Start: "22:00", End: "23:00"}
+			Expect(gcp.ConfirmMaintenanceWindow(w, clock, "teardown", false)).To(Succeed())
+		})
+
+		It("refuses the operation outside the window", func() {
+			w := &gcp.MaintenanceWindow{Start: "22:00", End: "23:00"}
+			err := gcp.ConfirmMaintenanceWindow(w, clock, "teardown", false)
+			Expect(err).To(MatchError(ContainSubstring("outside the maintenance window (22:00-23:00)")))
+		})
+
+		It("allows the operation outside the window with override", func() {
+			w := &gcp.MaintenanceWindow{Start: "22:00", End: "23:00"}
+			Expect(gcp.ConfirmMaintenanceWindow(w, clock, "teardown", true)).To(Succeed())
+		})
+	})
+})
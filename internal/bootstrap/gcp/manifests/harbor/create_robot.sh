@@ -0,0 +1,31 @@
+#!/bin/sh
+# Creates the {{ .Project }} project and the OMS robot account in Harbor.
+# The admin password is read from stdin so it never appears in a process
+# listing, and the generated robot credentials are written to
+# {{ .CredentialsPath }} readable by root only.
+set -eu
+
+read -r ADMIN_PASSWORD
+
+api="https://{{ .Hostname }}/api/v2.0"
+
+for _ in $(seq 1 30); do
+	if curl -fsk -u "admin:${ADMIN_PASSWORD}" "${api}/health" >/dev/null 2>&1; then
+		break
+	fi
+	sleep 10
+done
+
+status=$(curl -sk -o /dev/null -w '%{http_code}' -u "admin:${ADMIN_PASSWORD}" \
+	-X POST -H 'Content-Type: application/json' \
+	-d '{"project_name": "{{ .Project }}", "public": false}' "${api}/projects")
+if [ "${status}" != "201" ] && [ "${status}" != "409" ]; then
+	echo "failed to create project {{ .Project }}: HTTP ${status}" >&2
+	exit 1
+fi
+
+umask 077
+curl -fsk -u "admin:${ADMIN_PASSWORD}" \
+	-X POST -H 'Content-Type: application/json' \
+	-d '{"name": "{{ .RobotName }}", "duration": -1, "level": "system", "permissions": [{"kind": "project", "namespace": "{{ .Project }}", "access": [{"resource": "repository", "action": "pull"}, {"resource": "repository", "action": "push"}]}]}' \
+	"${api}/robots" > {{ .CredentialsPath }}
@@ -0,0 +1,93 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DNS modes selectable via --dns-mode.
+const (
+	DNSModeManaged = "managed"
+	DNSModeManual  = "manual"
+)
+
+// DefaultManualDNSTimeout bounds how long manual DNS verification waits for
+// the operator-created records to resolve.
+const DefaultManualDNSTimeout = 15 * time.Minute
+
+// manualDNSPollInterval is the delay between resolver checks.
+const manualDNSPollInterval = 15 * time.Second
+
+// DNSResolver resolves host names, so tests can run without real DNS.
+//
+//mockery:generate: true
+type DNSResolver interface {
+	LookupHost(host string) ([]string, error)
+}
+
+type netResolver struct{}
+
+func (netResolver) LookupHost(host string) ([]string, error) {
+	return net.LookupHost(host)
+}
+
+// dnsResolver returns the injected resolver or the system resolver.
+func (b *GCPBootstrapper) dnsResolver() DNSResolver {
+	if b.Resolver != nil {
+		return b.Resolver
+	}
+	return netResolver{}
+}
+
+// manualDNSTimeout returns the configured verification timeout, falling back
+// to the default.
+func (b *GCPBootstrapper) manualDNSTimeout() time.Duration {
+	if b.Env.ManualDNSTimeout > 0 {
+		return b.Env.ManualDNSTimeout
+	}
+	return DefaultManualDNSTimeout
+}
+
+// ensureManualDNSRecords prints the records the operator must create in their
+// own DNS zone and polls resolvers until all of them resolve to the expected
+// IPs, or the timeout is reached.
+func (b *GCPBootstrapper) ensureManualDNSRecords() error {
+	records := b.requiredDNSRecords()
+
+	b.stlog.Logf("Manual DNS mode: create the following records in the zone for %s:", b.Env.BaseDomain)
+	for _, record := range records {
+		b.stlog.Logf("  %s %d IN %s %s", record.Name, record.TTL, record.Type, strings.Join(record.Rrdatas, " "))
+	}
+
+	deadline := b.Time.Now().Add(b.manualDNSTimeout())
+	for _, record := range records {
+		host := manualDNSProbeHost(record.Name)
+		expected := record.Rrdatas[0]
+		for {
+			ips, err := b.dnsResolver().LookupHost(host)
+			if err == nil && slices.Contains(ips, expected) {
+				b.stlog.Logf("Verified %s resolves to %s", host, expected)
+				break
+			}
+			if b.Time.Now().After(deadline) {
+				return fmt.Errorf("timed out waiting for %s to resolve to %s; create the records listed above and re-run the bootstrap", host, expected)
+			}
+			b.stlog.LogRetry()
+			b.Time.Sleep(manualDNSPollInterval)
+		}
+	}
+
+	return nil
+}
+
+// manualDNSProbeHost turns a record name into a resolvable host name,
+// substituting a probe label for wildcards and dropping the trailing dot.
+func manualDNSProbeHost(name string) string {
+	return strings.TrimSuffix(strings.Replace(name, "*", "oms-dns-check", 1), ".")
+}
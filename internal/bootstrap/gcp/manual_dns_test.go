@@ -0,0 +1,102 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/cloudflare"
+	"github.com/codesphere-cloud/oms/internal/env"
+	"github.com/codesphere-cloud/oms/internal/github"
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/codesphere-cloud/oms/internal/portal"
+	"github.com/codesphere-cloud/oms/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manual DNS mode", func() {
+	var (
+		csEnv    *gcp.CodesphereEnvironment
+		resolver *gcp.MockDNSResolver
+
+		bs *gcp.GCPBootstrapper
+	)
+
+	JustBeforeEach(func() {
+		var err error
+		bs, err = gcp.NewGCPBootstrapper(
+			context.Background(),
+			env.NewEnv(),
+			bootstrap.NewStepLogger(false),
+			csEnv,
+			installer.NewMockInstallConfigManager(GinkgoT()),
+			gcp.NewMockGCPClientManager(GinkgoT()),
+			util.NewMockFileIO(GinkgoT()),
+			node.NewMockNodeClient(GinkgoT()),
+			portal.NewMockPortal(GinkgoT()),
+			util.NewFakeTime(),
+			github.NewMockGitHubClient(GinkgoT()),
+			cloudflare.NewMockCloudflareClient(GinkgoT()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		bs.Resolver = resolver
+	})
+
+	BeforeEach(func() {
+		resolver = gcp.NewMockDNSResolver(GinkgoT())
+
+		csEnv = &gcp.CodesphereEnvironment{
+			BaseDomain:      "dev.codesphere.com",
+			DNSMode:         gcp.DNSModeManual,
+			GatewayIP:       "1.1.1.1",
+			PublicGatewayIP: "2.2.2.2",
+			SshProxyIP:      "3.3.3.3",
+		}
+	})
+
+	It("succeeds once all records resolve to the expected IPs", func() {
+		resolver.EXPECT().LookupHost("cs.dev.codesphere.com").Return([]string{"1.1.1.1"}, nil)
+		resolver.EXPECT().LookupHost("oms-dns-check.cs.dev.codesphere.com").Return([]string{"1.1.1.1"}, nil)
+		resolver.EXPECT().LookupHost("oms-dns-check.ws.dev.codesphere.com").Return([]string{"2.2.2.2"}, nil)
+		resolver.EXPECT().LookupHost("ws.dev.codesphere.com").Return([]string{"2.2.2.2"}, nil)
+		resolver.EXPECT().LookupHost("oms-dns-check.ssh.cs.dev.codesphere.com").Return([]string{"3.3.3.3"}, nil)
+
+		Expect(bs.EnsureDNSRecords()).To(Succeed())
+	})
+
+	It("retries until a record resolves", func() {
+		resolver.EXPECT().LookupHost("cs.dev.codesphere.com").Return(nil, fmt.Errorf("no such host")).Times(3)
+		resolver.EXPECT().LookupHost("cs.dev.codesphere.com").Return([]string{"1.1.1.1"}, nil)
+		resolver.EXPECT().LookupHost("oms-dns-check.cs.dev.codesphere.com").Return([]string{"1.1.1.1"}, nil)
+		resolver.EXPECT().LookupHost("oms-dns-check.ws.dev.codesphere.com").Return([]string{"2.2.2.2"}, nil)
+		resolver.EXPECT().LookupHost("ws.dev.codesphere.com").Return([]string{"2.2.2.2"}, nil)
+		resolver.EXPECT().LookupHost("oms-dns-check.ssh.cs.dev.codesphere.com").Return([]string{"3.3.3.3"}, nil)
+
+		Expect(bs.EnsureDNSRecords()).To(Succeed())
+	})
+
+	It("fails when a record resolves to the wrong IP until the timeout", func() {
+		resolver.EXPECT().LookupHost("cs.dev.codesphere.com").Return([]string{"9.9.9.9"}, nil)
+
+		err := bs.EnsureDNSRecords()
+		Expect(err).To(MatchError(ContainSubstring("timed out waiting for cs.dev.codesphere.com to resolve to 1.1.1.1")))
+	})
+
+	It("verifies the apex record when apex records are enabled", func() {
+		csEnv.ApexDomainRecords = true
+		resolver.EXPECT().LookupHost("cs.dev.codesphere.com").Return([]string{"1.1.1.1"}, nil)
+		resolver.EXPECT().LookupHost("oms-dns-check.cs.dev.codesphere.com").Return([]string{"1.1.1.1"}, nil)
+		resolver.EXPECT().LookupHost("oms-dns-check.ws.dev.codesphere.com").Return([]string{"2.2.2.2"}, nil)
+		resolver.EXPECT().LookupHost("ws.dev.codesphere.com").Return([]string{"2.2.2.2"}, nil)
+		resolver.EXPECT().LookupHost("oms-dns-check.ssh.cs.dev.codesphere.com").Return([]string{"3.3.3.3"}, nil)
+		resolver.EXPECT().LookupHost("dev.codesphere.com").Return([]string{"1.1.1.1"}, nil)
+
+		Expect(bs.EnsureDNSRecords()).To(Succeed())
+	})
+})
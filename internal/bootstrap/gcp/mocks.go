@@ -11,6 +11,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 	"google.golang.org/api/cloudbilling/v1"
 	"google.golang.org/api/dns/v1"
+	"google.golang.org/api/sqladmin/v1"
+	"time"
 )
 
 // NewMockGCPClientManager creates a new instance of MockGCPClientManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -40,6 +42,81 @@ func (_m *MockGCPClientManager) EXPECT() *MockGCPClientManager_Expecter {
 	return &MockGCPClientManager_Expecter{mock: &_m.Mock}
 }
 
+// ApplyArtifactRegistryCleanupPolicies provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) ApplyArtifactRegistryCleanupPolicies(projectID string, region string, repoName string, keepTagCount int, untaggedRetention time.Duration) error {
+	ret := _mock.Called(projectID, region, repoName, keepTagCount, untaggedRetention)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyArtifactRegistryCleanupPolicies")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, int, time.Duration) error); ok {
+		r0 = returnFunc(projectID, region, repoName, keepTagCount, untaggedRetention)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGCPClientManager_ApplyArtifactRegistryCleanupPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyArtifactRegistryCleanupPolicies'
+type MockGCPClientManager_ApplyArtifactRegistryCleanupPolicies_Call struct {
+	*mock.Call
+}
+
+// ApplyArtifactRegistryCleanupPolicies is a helper method to define mock.On call
+//   - projectID string
+//   - region string
+//   - repoName string
+//   - keepTagCount int
+//   - untaggedRetention time.Duration
+func (_e *MockGCPClientManager_Expecter) ApplyArtifactRegistryCleanupPolicies(projectID any, region any, repoName any, keepTagCount any, untaggedRetention any) *MockGCPClientManager_ApplyArtifactRegistryCleanupPolicies_Call {
+	return &MockGCPClientManager_ApplyArtifactRegistryCleanupPolicies_Call{Call: _e.mock.On("ApplyArtifactRegistryCleanupPolicies", projectID, region, repoName, keepTagCount, untaggedRetention)}
+}
+
+func (_c *MockGCPClientManager_ApplyArtifactRegistryCleanupPolicies_Call) Run(run func(projectID string, region string, repoName string, keepTagCount int, untaggedRetention time.Duration)) *MockGCPClientManager_ApplyArtifactRegistryCleanupPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 time.Duration
+		if args[4] != nil {
+			arg4 = args[4].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_ApplyArtifactRegistryCleanupPolicies_Call) Return(err error) *MockGCPClientManager_ApplyArtifactRegistryCleanupPolicies_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_ApplyArtifactRegistryCleanupPolicies_Call) RunAndReturn(run func(projectID string, region string, repoName string, keepTagCount int, untaggedRetention time.Duration) error) *MockGCPClientManager_ApplyArtifactRegistryCleanupPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AssignIAMRole provides a mock function for the type MockGCPClientManager
 func (_mock *MockGCPClientManager) AssignIAMRole(projectID string, saEmail string, saProjectID string, roles []string) error {
 	ret := _mock.Called(projectID, saEmail, saProjectID, roles)
@@ -109,6 +186,69 @@ func (_c *MockGCPClientManager_AssignIAMRole_Call) RunAndReturn(run func(project
 	return _c
 }
 
+// AssignIAMRoleToMember provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) AssignIAMRoleToMember(projectID string, member string, roles []string) error {
+	ret := _mock.Called(projectID, member, roles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignIAMRoleToMember")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, []string) error); ok {
+		r0 = returnFunc(projectID, member, roles)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGCPClientManager_AssignIAMRoleToMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignIAMRoleToMember'
+type MockGCPClientManager_AssignIAMRoleToMember_Call struct {
+	*mock.Call
+}
+
+// AssignIAMRoleToMember is a helper method to define mock.On call
+//   - projectID string
+//   - member string
+//   - roles []string
+func (_e *MockGCPClientManager_Expecter) AssignIAMRoleToMember(projectID any, member any, roles any) *MockGCPClientManager_AssignIAMRoleToMember_Call {
+	return &MockGCPClientManager_AssignIAMRoleToMember_Call{Call: _e.mock.On("AssignIAMRoleToMember", projectID, member, roles)}
+}
+
+func (_c *MockGCPClientManager_AssignIAMRoleToMember_Call) Run(run func(projectID string, member string, roles []string)) *MockGCPClientManager_AssignIAMRoleToMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_AssignIAMRoleToMember_Call) Return(err error) *MockGCPClientManager_AssignIAMRoleToMember_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_AssignIAMRoleToMember_Call) RunAndReturn(run func(projectID string, member string, roles []string) error) *MockGCPClientManager_AssignIAMRoleToMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateAddress provides a mock function for the type MockGCPClientManager
 func (_mock *MockGCPClientManager) CreateAddress(projectID string, region string, address *computepb.Address) (string, error) {
 	ret := _mock.Called(projectID, region, address)
@@ -255,6 +395,63 @@ func (_c *MockGCPClientManager_CreateArtifactRegistry_Call) RunAndReturn(run fun
 	return _c
 }
 
+// CreateCloudSQLInstance provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) CreateCloudSQLInstance(projectID string, instance *sqladmin.DatabaseInstance) error {
+	ret := _mock.Called(projectID, instance)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCloudSQLInstance")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, *sqladmin.DatabaseInstance) error); ok {
+		r0 = returnFunc(projectID, instance)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGCPClientManager_CreateCloudSQLInstance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCloudSQLInstance'
+type MockGCPClientManager_CreateCloudSQLInstance_Call struct {
+	*mock.Call
+}
+
+// CreateCloudSQLInstance is a helper method to define mock.On call
+//   - projectID string
+//   - instance *sqladmin.DatabaseInstance
+func (_e *MockGCPClientManager_Expecter) CreateCloudSQLInstance(projectID any, instance any) *MockGCPClientManager_CreateCloudSQLInstance_Call {
+	return &MockGCPClientManager_CreateCloudSQLInstance_Call{Call: _e.mock.On("CreateCloudSQLInstance", projectID, instance)}
+}
+
+func (_c *MockGCPClientManager_CreateCloudSQLInstance_Call) Run(run func(projectID string, instance *sqladmin.DatabaseInstance)) *MockGCPClientManager_CreateCloudSQLInstance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 *sqladmin.DatabaseInstance
+		if args[1] != nil {
+			arg1 = args[1].(*sqladmin.DatabaseInstance)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_CreateCloudSQLInstance_Call) Return(err error) *MockGCPClientManager_CreateCloudSQLInstance_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_CreateCloudSQLInstance_Call) RunAndReturn(run func(projectID string, instance *sqladmin.DatabaseInstance) error) *MockGCPClientManager_CreateCloudSQLInstance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateFirewallRule provides a mock function for the type MockGCPClientManager
 func (_mock *MockGCPClientManager) CreateFirewallRule(projectID string, rule *computepb.Firewall) error {
 	ret := _mock.Called(projectID, rule)
@@ -570,6 +767,63 @@ func (_c *MockGCPClientManager_CreatePublicCAExternalAccountKey_Call) RunAndRetu
 	return _c
 }
 
+// CreateSecurityPolicy provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) CreateSecurityPolicy(projectID string, policy *computepb.SecurityPolicy) error {
+	ret := _mock.Called(projectID, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSecurityPolicy")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, *computepb.SecurityPolicy) error); ok {
+		r0 = returnFunc(projectID, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGCPClientManager_CreateSecurityPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSecurityPolicy'
+type MockGCPClientManager_CreateSecurityPolicy_Call struct {
+	*mock.Call
+}
+
+// CreateSecurityPolicy is a helper method to define mock.On call
+//   - projectID string
+//   - policy *computepb.SecurityPolicy
+func (_e *MockGCPClientManager_Expecter) CreateSecurityPolicy(projectID any, policy any) *MockGCPClientManager_CreateSecurityPolicy_Call {
+	return &MockGCPClientManager_CreateSecurityPolicy_Call{Call: _e.mock.On("CreateSecurityPolicy", projectID, policy)}
+}
+
+func (_c *MockGCPClientManager_CreateSecurityPolicy_Call) Run(run func(projectID string, policy *computepb.SecurityPolicy)) *MockGCPClientManager_CreateSecurityPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 *computepb.SecurityPolicy
+		if args[1] != nil {
+			arg1 = args[1].(*computepb.SecurityPolicy)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_CreateSecurityPolicy_Call) Return(err error) *MockGCPClientManager_CreateSecurityPolicy_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_CreateSecurityPolicy_Call) RunAndReturn(run func(projectID string, policy *computepb.SecurityPolicy) error) *MockGCPClientManager_CreateSecurityPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateServiceAccount provides a mock function for the type MockGCPClientManager
 func (_mock *MockGCPClientManager) CreateServiceAccount(projectID string, name string, displayName string) (string, bool, error) {
 	ret := _mock.Called(projectID, name, displayName)
@@ -715,16 +969,16 @@ func (_c *MockGCPClientManager_CreateServiceAccountKey_Call) RunAndReturn(run fu
 }
 
 // CreateVPC provides a mock function for the type MockGCPClientManager
-func (_mock *MockGCPClientManager) CreateVPC(projectID string, region string, networkName string, subnetName string, routerName string, natName string) error {
-	ret := _mock.Called(projectID, region, networkName, subnetName, routerName, natName)
+func (_mock *MockGCPClientManager) CreateVPC(projectID string, region string, networkName string, subnetName string, routerName string, natName string, dualStack bool) error {
+	ret := _mock.Called(projectID, region, networkName, subnetName, routerName, natName, dualStack)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateVPC")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, string, string, string, string, string) error); ok {
-		r0 = returnFunc(projectID, region, networkName, subnetName, routerName, natName)
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, string, string, string, bool) error); ok {
+		r0 = returnFunc(projectID, region, networkName, subnetName, routerName, natName, dualStack)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -743,11 +997,12 @@ type MockGCPClientManager_CreateVPC_Call struct {
 //   - subnetName string
 //   - routerName string
 //   - natName string
-func (_e *MockGCPClientManager_Expecter) CreateVPC(projectID any, region any, networkName any, subnetName any, routerName any, natName any) *MockGCPClientManager_CreateVPC_Call {
-	return &MockGCPClientManager_CreateVPC_Call{Call: _e.mock.On("CreateVPC", projectID, region, networkName, subnetName, routerName, natName)}
+//   - dualStack bool
+func (_e *MockGCPClientManager_Expecter) CreateVPC(projectID any, region any, networkName any, subnetName any, routerName any, natName any, dualStack any) *MockGCPClientManager_CreateVPC_Call {
+	return &MockGCPClientManager_CreateVPC_Call{Call: _e.mock.On("CreateVPC", projectID, region, networkName, subnetName, routerName, natName, dualStack)}
 }
 
-func (_c *MockGCPClientManager_CreateVPC_Call) Run(run func(projectID string, region string, networkName string, subnetName string, routerName string, natName string)) *MockGCPClientManager_CreateVPC_Call {
+func (_c *MockGCPClientManager_CreateVPC_Call) Run(run func(projectID string, region string, networkName string, subnetName string, routerName string, natName string, dualStack bool)) *MockGCPClientManager_CreateVPC_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
@@ -773,6 +1028,10 @@ func (_c *MockGCPClientManager_CreateVPC_Call) Run(run func(projectID string, re
 		if args[5] != nil {
 			arg5 = args[5].(string)
 		}
+		var arg6 bool
+		if args[6] != nil {
+			arg6 = args[6].(bool)
+		}
 		run(
 			arg0,
 			arg1,
@@ -780,6 +1039,7 @@ func (_c *MockGCPClientManager_CreateVPC_Call) Run(run func(projectID string, re
 			arg3,
 			arg4,
 			arg5,
+			arg6,
 		)
 	})
 	return _c
@@ -790,42 +1050,42 @@ func (_c *MockGCPClientManager_CreateVPC_Call) Return(err error) *MockGCPClientM
 	return _c
 }
 
-func (_c *MockGCPClientManager_CreateVPC_Call) RunAndReturn(run func(projectID string, region string, networkName string, subnetName string, routerName string, natName string) error) *MockGCPClientManager_CreateVPC_Call {
+func (_c *MockGCPClientManager_CreateVPC_Call) RunAndReturn(run func(projectID string, region string, networkName string, subnetName string, routerName string, natName string, dualStack bool) error) *MockGCPClientManager_CreateVPC_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteDNSRecordSets provides a mock function for the type MockGCPClientManager
-func (_mock *MockGCPClientManager) DeleteDNSRecordSets(projectID string, zoneName string, baseDomain string) error {
-	ret := _mock.Called(projectID, zoneName, baseDomain)
+// DeleteAddress provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) DeleteAddress(projectID string, region string, addressName string) error {
+	ret := _mock.Called(projectID, region, addressName)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteDNSRecordSets")
+		panic("no return value specified for DeleteAddress")
 	}
 
 	var r0 error
 	if returnFunc, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = returnFunc(projectID, zoneName, baseDomain)
+		r0 = returnFunc(projectID, region, addressName)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockGCPClientManager_DeleteDNSRecordSets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDNSRecordSets'
-type MockGCPClientManager_DeleteDNSRecordSets_Call struct {
+// MockGCPClientManager_DeleteAddress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAddress'
+type MockGCPClientManager_DeleteAddress_Call struct {
 	*mock.Call
 }
 
-// DeleteDNSRecordSets is a helper method to define mock.On call
+// DeleteAddress is a helper method to define mock.On call
 //   - projectID string
-//   - zoneName string
-//   - baseDomain string
-func (_e *MockGCPClientManager_Expecter) DeleteDNSRecordSets(projectID any, zoneName any, baseDomain any) *MockGCPClientManager_DeleteDNSRecordSets_Call {
-	return &MockGCPClientManager_DeleteDNSRecordSets_Call{Call: _e.mock.On("DeleteDNSRecordSets", projectID, zoneName, baseDomain)}
+//   - region string
+//   - addressName string
+func (_e *MockGCPClientManager_Expecter) DeleteAddress(projectID any, region any, addressName any) *MockGCPClientManager_DeleteAddress_Call {
+	return &MockGCPClientManager_DeleteAddress_Call{Call: _e.mock.On("DeleteAddress", projectID, region, addressName)}
 }
 
-func (_c *MockGCPClientManager_DeleteDNSRecordSets_Call) Run(run func(projectID string, zoneName string, baseDomain string)) *MockGCPClientManager_DeleteDNSRecordSets_Call {
+func (_c *MockGCPClientManager_DeleteAddress_Call) Run(run func(projectID string, region string, addressName string)) *MockGCPClientManager_DeleteAddress_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
@@ -848,109 +1108,354 @@ func (_c *MockGCPClientManager_DeleteDNSRecordSets_Call) Run(run func(projectID
 	return _c
 }
 
-func (_c *MockGCPClientManager_DeleteDNSRecordSets_Call) Return(err error) *MockGCPClientManager_DeleteDNSRecordSets_Call {
+func (_c *MockGCPClientManager_DeleteAddress_Call) Return(err error) *MockGCPClientManager_DeleteAddress_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockGCPClientManager_DeleteDNSRecordSets_Call) RunAndReturn(run func(projectID string, zoneName string, baseDomain string) error) *MockGCPClientManager_DeleteDNSRecordSets_Call {
+func (_c *MockGCPClientManager_DeleteAddress_Call) RunAndReturn(run func(projectID string, region string, addressName string) error) *MockGCPClientManager_DeleteAddress_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteProject provides a mock function for the type MockGCPClientManager
-func (_mock *MockGCPClientManager) DeleteProject(projectID string) error {
-	ret := _mock.Called(projectID)
+// DeleteDNSManagedZone provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) DeleteDNSManagedZone(projectID string, zoneName string) error {
+	ret := _mock.Called(projectID, zoneName)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteProject")
+		panic("no return value specified for DeleteDNSManagedZone")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
-		r0 = returnFunc(projectID)
+	if returnFunc, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = returnFunc(projectID, zoneName)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockGCPClientManager_DeleteProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteProject'
-type MockGCPClientManager_DeleteProject_Call struct {
+// MockGCPClientManager_DeleteDNSManagedZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDNSManagedZone'
+type MockGCPClientManager_DeleteDNSManagedZone_Call struct {
 	*mock.Call
 }
 
-// DeleteProject is a helper method to define mock.On call
+// DeleteDNSManagedZone is a helper method to define mock.On call
 //   - projectID string
-func (_e *MockGCPClientManager_Expecter) DeleteProject(projectID any) *MockGCPClientManager_DeleteProject_Call {
-	return &MockGCPClientManager_DeleteProject_Call{Call: _e.mock.On("DeleteProject", projectID)}
+//   - zoneName string
+func (_e *MockGCPClientManager_Expecter) DeleteDNSManagedZone(projectID any, zoneName any) *MockGCPClientManager_DeleteDNSManagedZone_Call {
+	return &MockGCPClientManager_DeleteDNSManagedZone_Call{Call: _e.mock.On("DeleteDNSManagedZone", projectID, zoneName)}
 }
 
-func (_c *MockGCPClientManager_DeleteProject_Call) Run(run func(projectID string)) *MockGCPClientManager_DeleteProject_Call {
+func (_c *MockGCPClientManager_DeleteDNSManagedZone_Call) Run(run func(projectID string, zoneName string)) *MockGCPClientManager_DeleteDNSManagedZone_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
 			arg0 = args[0].(string)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockGCPClientManager_DeleteProject_Call) Return(err error) *MockGCPClientManager_DeleteProject_Call {
+func (_c *MockGCPClientManager_DeleteDNSManagedZone_Call) Return(err error) *MockGCPClientManager_DeleteDNSManagedZone_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockGCPClientManager_DeleteProject_Call) RunAndReturn(run func(projectID string) error) *MockGCPClientManager_DeleteProject_Call {
+func (_c *MockGCPClientManager_DeleteDNSManagedZone_Call) RunAndReturn(run func(projectID string, zoneName string) error) *MockGCPClientManager_DeleteDNSManagedZone_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// EnableAPIs provides a mock function for the type MockGCPClientManager
-func (_mock *MockGCPClientManager) EnableAPIs(projectID string, apis []string) error {
-	ret := _mock.Called(projectID, apis)
+// DeleteDNSRecordSets provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) DeleteDNSRecordSets(projectID string, zoneName string, baseDomain string) error {
+	ret := _mock.Called(projectID, zoneName, baseDomain)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EnableAPIs")
+		panic("no return value specified for DeleteDNSRecordSets")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, []string) error); ok {
-		r0 = returnFunc(projectID, apis)
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = returnFunc(projectID, zoneName, baseDomain)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockGCPClientManager_EnableAPIs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableAPIs'
-type MockGCPClientManager_EnableAPIs_Call struct {
+// MockGCPClientManager_DeleteDNSRecordSets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDNSRecordSets'
+type MockGCPClientManager_DeleteDNSRecordSets_Call struct {
 	*mock.Call
 }
 
-// EnableAPIs is a helper method to define mock.On call
+// DeleteDNSRecordSets is a helper method to define mock.On call
 //   - projectID string
-//   - apis []string
-func (_e *MockGCPClientManager_Expecter) EnableAPIs(projectID any, apis any) *MockGCPClientManager_EnableAPIs_Call {
-	return &MockGCPClientManager_EnableAPIs_Call{Call: _e.mock.On("EnableAPIs", projectID, apis)}
+//   - zoneName string
+//   - baseDomain string
+func (_e *MockGCPClientManager_Expecter) DeleteDNSRecordSets(projectID any, zoneName any, baseDomain any) *MockGCPClientManager_DeleteDNSRecordSets_Call {
+	return &MockGCPClientManager_DeleteDNSRecordSets_Call{Call: _e.mock.On("DeleteDNSRecordSets", projectID, zoneName, baseDomain)}
 }
 
-func (_c *MockGCPClientManager_EnableAPIs_Call) Run(run func(projectID string, apis []string)) *MockGCPClientManager_EnableAPIs_Call {
+func (_c *MockGCPClientManager_DeleteDNSRecordSets_Call) Run(run func(projectID string, zoneName string, baseDomain string)) *MockGCPClientManager_DeleteDNSRecordSets_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
 			arg0 = args[0].(string)
 		}
-		var arg1 []string
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].([]string)
+			arg1 = args[1].(string)
 		}
-		run(
-			arg0,
-			arg1,
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_DeleteDNSRecordSets_Call) Return(err error) *MockGCPClientManager_DeleteDNSRecordSets_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_DeleteDNSRecordSets_Call) RunAndReturn(run func(projectID string, zoneName string, baseDomain string) error) *MockGCPClientManager_DeleteDNSRecordSets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteInstance provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) DeleteInstance(projectID string, zone string, instanceName string) error {
+	ret := _mock.Called(projectID, zone, instanceName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteInstance")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = returnFunc(projectID, zone, instanceName)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGCPClientManager_DeleteInstance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteInstance'
+type MockGCPClientManager_DeleteInstance_Call struct {
+	*mock.Call
+}
+
+// DeleteInstance is a helper method to define mock.On call
+//   - projectID string
+//   - zone string
+//   - instanceName string
+func (_e *MockGCPClientManager_Expecter) DeleteInstance(projectID any, zone any, instanceName any) *MockGCPClientManager_DeleteInstance_Call {
+	return &MockGCPClientManager_DeleteInstance_Call{Call: _e.mock.On("DeleteInstance", projectID, zone, instanceName)}
+}
+
+func (_c *MockGCPClientManager_DeleteInstance_Call) Run(run func(projectID string, zone string, instanceName string)) *MockGCPClientManager_DeleteInstance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_DeleteInstance_Call) Return(err error) *MockGCPClientManager_DeleteInstance_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_DeleteInstance_Call) RunAndReturn(run func(projectID string, zone string, instanceName string) error) *MockGCPClientManager_DeleteInstance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteProject provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) DeleteProject(projectID string) error {
+	ret := _mock.Called(projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProject")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(projectID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGCPClientManager_DeleteProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteProject'
+type MockGCPClientManager_DeleteProject_Call struct {
+	*mock.Call
+}
+
+// DeleteProject is a helper method to define mock.On call
+//   - projectID string
+func (_e *MockGCPClientManager_Expecter) DeleteProject(projectID any) *MockGCPClientManager_DeleteProject_Call {
+	return &MockGCPClientManager_DeleteProject_Call{Call: _e.mock.On("DeleteProject", projectID)}
+}
+
+func (_c *MockGCPClientManager_DeleteProject_Call) Run(run func(projectID string)) *MockGCPClientManager_DeleteProject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_DeleteProject_Call) Return(err error) *MockGCPClientManager_DeleteProject_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_DeleteProject_Call) RunAndReturn(run func(projectID string) error) *MockGCPClientManager_DeleteProject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DestroyKMSKeyVersions provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) DestroyKMSKeyVersions(keyName string) ([]string, error) {
+	ret := _mock.Called(keyName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DestroyKMSKeyVersions")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return returnFunc(keyName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = returnFunc(keyName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(keyName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGCPClientManager_DestroyKMSKeyVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DestroyKMSKeyVersions'
+type MockGCPClientManager_DestroyKMSKeyVersions_Call struct {
+	*mock.Call
+}
+
+// DestroyKMSKeyVersions is a helper method to define mock.On call
+//   - keyName string
+func (_e *MockGCPClientManager_Expecter) DestroyKMSKeyVersions(keyName any) *MockGCPClientManager_DestroyKMSKeyVersions_Call {
+	return &MockGCPClientManager_DestroyKMSKeyVersions_Call{Call: _e.mock.On("DestroyKMSKeyVersions", keyName)}
+}
+
+func (_c *MockGCPClientManager_DestroyKMSKeyVersions_Call) Run(run func(keyName string)) *MockGCPClientManager_DestroyKMSKeyVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_DestroyKMSKeyVersions_Call) Return(strings []string, err error) *MockGCPClientManager_DestroyKMSKeyVersions_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_DestroyKMSKeyVersions_Call) RunAndReturn(run func(keyName string) ([]string, error)) *MockGCPClientManager_DestroyKMSKeyVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnableAPIs provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) EnableAPIs(projectID string, apis []string) error {
+	ret := _mock.Called(projectID, apis)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableAPIs")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = returnFunc(projectID, apis)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGCPClientManager_EnableAPIs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableAPIs'
+type MockGCPClientManager_EnableAPIs_Call struct {
+	*mock.Call
+}
+
+// EnableAPIs is a helper method to define mock.On call
+//   - projectID string
+//   - apis []string
+func (_e *MockGCPClientManager_Expecter) EnableAPIs(projectID any, apis any) *MockGCPClientManager_EnableAPIs_Call {
+	return &MockGCPClientManager_EnableAPIs_Call{Call: _e.mock.On("EnableAPIs", projectID, apis)}
+}
+
+func (_c *MockGCPClientManager_EnableAPIs_Call) Run(run func(projectID string, apis []string)) *MockGCPClientManager_EnableAPIs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -990,139 +1495,1913 @@ type MockGCPClientManager_EnableBilling_Call struct {
 
 // EnableBilling is a helper method to define mock.On call
 //   - projectID string
-//   - billingAccount string
-func (_e *MockGCPClientManager_Expecter) EnableBilling(projectID any, billingAccount any) *MockGCPClientManager_EnableBilling_Call {
-	return &MockGCPClientManager_EnableBilling_Call{Call: _e.mock.On("EnableBilling", projectID, billingAccount)}
+//   - billingAccount string
+func (_e *MockGCPClientManager_Expecter) EnableBilling(projectID any, billingAccount any) *MockGCPClientManager_EnableBilling_Call {
+	return &MockGCPClientManager_EnableBilling_Call{Call: _e.mock.On("EnableBilling", projectID, billingAccount)}
+}
+
+func (_c *MockGCPClientManager_EnableBilling_Call) Run(run func(projectID string, billingAccount string)) *MockGCPClientManager_EnableBilling_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_EnableBilling_Call) Return(err error) *MockGCPClientManager_EnableBilling_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_EnableBilling_Call) RunAndReturn(run func(projectID string, billingAccount string) error) *MockGCPClientManager_EnableBilling_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnsureCloudSQLDatabase provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) EnsureCloudSQLDatabase(projectID string, instanceName string, database string) error {
+	ret := _mock.Called(projectID, instanceName, database)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureCloudSQLDatabase")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = returnFunc(projectID, instanceName, database)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGCPClientManager_EnsureCloudSQLDatabase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureCloudSQLDatabase'
+type MockGCPClientManager_EnsureCloudSQLDatabase_Call struct {
+	*mock.Call
+}
+
+// EnsureCloudSQLDatabase is a helper method to define mock.On call
+//   - projectID string
+//   - instanceName string
+//   - database string
+func (_e *MockGCPClientManager_Expecter) EnsureCloudSQLDatabase(projectID any, instanceName any, database any) *MockGCPClientManager_EnsureCloudSQLDatabase_Call {
+	return &MockGCPClientManager_EnsureCloudSQLDatabase_Call{Call: _e.mock.On("EnsureCloudSQLDatabase", projectID, instanceName, database)}
+}
+
+func (_c *MockGCPClientManager_EnsureCloudSQLDatabase_Call) Run(run func(projectID string, instanceName string, database string)) *MockGCPClientManager_EnsureCloudSQLDatabase_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_EnsureCloudSQLDatabase_Call) Return(err error) *MockGCPClientManager_EnsureCloudSQLDatabase_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_EnsureCloudSQLDatabase_Call) RunAndReturn(run func(projectID string, instanceName string, database string) error) *MockGCPClientManager_EnsureCloudSQLDatabase_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnsureCloudSQLUser provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) EnsureCloudSQLUser(projectID string, instanceName string, name string, password string) error {
+	ret := _mock.Called(projectID, instanceName, name, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureCloudSQLUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, string) error); ok {
+		r0 = returnFunc(projectID, instanceName, name, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGCPClientManager_EnsureCloudSQLUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureCloudSQLUser'
+type MockGCPClientManager_EnsureCloudSQLUser_Call struct {
+	*mock.Call
+}
+
+// EnsureCloudSQLUser is a helper method to define mock.On call
+//   - projectID string
+//   - instanceName string
+//   - name string
+//   - password string
+func (_e *MockGCPClientManager_Expecter) EnsureCloudSQLUser(projectID any, instanceName any, name any, password any) *MockGCPClientManager_EnsureCloudSQLUser_Call {
+	return &MockGCPClientManager_EnsureCloudSQLUser_Call{Call: _e.mock.On("EnsureCloudSQLUser", projectID, instanceName, name, password)}
+}
+
+func (_c *MockGCPClientManager_EnsureCloudSQLUser_Call) Run(run func(projectID string, instanceName string, name string, password string)) *MockGCPClientManager_EnsureCloudSQLUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_EnsureCloudSQLUser_Call) Return(err error) *MockGCPClientManager_EnsureCloudSQLUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_EnsureCloudSQLUser_Call) RunAndReturn(run func(projectID string, instanceName string, name string, password string) error) *MockGCPClientManager_EnsureCloudSQLUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnsureDNSManagedZone provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) EnsureDNSManagedZone(projectID string, zoneName string, dnsName string, description string, labels map[string]string, dnssec bool) error {
+	ret := _mock.Called(projectID, zoneName, dnsName, description, labels, dnssec)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureDNSManagedZone")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, string, map[string]string, bool) error); ok {
+		r0 = returnFunc(projectID, zoneName, dnsName, description, labels, dnssec)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGCPClientManager_EnsureDNSManagedZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureDNSManagedZone'
+type MockGCPClientManager_EnsureDNSManagedZone_Call struct {
+	*mock.Call
+}
+
+// EnsureDNSManagedZone is a helper method to define mock.On call
+//   - projectID string
+//   - zoneName string
+//   - dnsName string
+//   - description string
+//   - labels map[string]string
+//   - dnssec bool
+func (_e *MockGCPClientManager_Expecter) EnsureDNSManagedZone(projectID any, zoneName any, dnsName any, description any, labels any, dnssec any) *MockGCPClientManager_EnsureDNSManagedZone_Call {
+	return &MockGCPClientManager_EnsureDNSManagedZone_Call{Call: _e.mock.On("EnsureDNSManagedZone", projectID, zoneName, dnsName, description, labels, dnssec)}
+}
+
+func (_c *MockGCPClientManager_EnsureDNSManagedZone_Call) Run(run func(projectID string, zoneName string, dnsName string, description string, labels map[string]string, dnssec bool)) *MockGCPClientManager_EnsureDNSManagedZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 map[string]string
+		if args[4] != nil {
+			arg4 = args[4].(map[string]string)
+		}
+		var arg5 bool
+		if args[5] != nil {
+			arg5 = args[5].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_EnsureDNSManagedZone_Call) Return(err error) *MockGCPClientManager_EnsureDNSManagedZone_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_EnsureDNSManagedZone_Call) RunAndReturn(run func(projectID string, zoneName string, dnsName string, description string, labels map[string]string, dnssec bool) error) *MockGCPClientManager_EnsureDNSManagedZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnsureDNSRecordSets provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) EnsureDNSRecordSets(projectID string, zoneName string, records []*dns.ResourceRecordSet) error {
+	ret := _mock.Called(projectID, zoneName, records)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureDNSRecordSets")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, []*dns.ResourceRecordSet) error); ok {
+		r0 = returnFunc(projectID, zoneName, records)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGCPClientManager_EnsureDNSRecordSets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureDNSRecordSets'
+type MockGCPClientManager_EnsureDNSRecordSets_Call struct {
+	*mock.Call
+}
+
+// EnsureDNSRecordSets is a helper method to define mock.On call
+//   - projectID string
+//   - zoneName string
+//   - records []*dns.ResourceRecordSet
+func (_e *MockGCPClientManager_Expecter) EnsureDNSRecordSets(projectID any, zoneName any, records any) *MockGCPClientManager_EnsureDNSRecordSets_Call {
+	return &MockGCPClientManager_EnsureDNSRecordSets_Call{Call: _e.mock.On("EnsureDNSRecordSets", projectID, zoneName, records)}
+}
+
+func (_c *MockGCPClientManager_EnsureDNSRecordSets_Call) Run(run func(projectID string, zoneName string, records []*dns.ResourceRecordSet)) *MockGCPClientManager_EnsureDNSRecordSets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []*dns.ResourceRecordSet
+		if args[2] != nil {
+			arg2 = args[2].([]*dns.ResourceRecordSet)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_EnsureDNSRecordSets_Call) Return(err error) *MockGCPClientManager_EnsureDNSRecordSets_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_EnsureDNSRecordSets_Call) RunAndReturn(run func(projectID string, zoneName string, records []*dns.ResourceRecordSet) error) *MockGCPClientManager_EnsureDNSRecordSets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnsureEssentialContact provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) EnsureEssentialContact(projectID string, email string, category string) error {
+	ret := _mock.Called(projectID, email, category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureEssentialContact")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = returnFunc(projectID, email, category)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGCPClientManager_EnsureEssentialContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureEssentialContact'
+type MockGCPClientManager_EnsureEssentialContact_Call struct {
+	*mock.Call
+}
+
+// EnsureEssentialContact is a helper method to define mock.On call
+//   - projectID string
+//   - email string
+//   - category string
+func (_e *MockGCPClientManager_Expecter) EnsureEssentialContact(projectID any, email any, category any) *MockGCPClientManager_EnsureEssentialContact_Call {
+	return &MockGCPClientManager_EnsureEssentialContact_Call{Call: _e.mock.On("EnsureEssentialContact", projectID, email, category)}
+}
+
+func (_c *MockGCPClientManager_EnsureEssentialContact_Call) Run(run func(projectID string, email string, category string)) *MockGCPClientManager_EnsureEssentialContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_EnsureEssentialContact_Call) Return(err error) *MockGCPClientManager_EnsureEssentialContact_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_EnsureEssentialContact_Call) RunAndReturn(run func(projectID string, email string, category string) error) *MockGCPClientManager_EnsureEssentialContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnsureGCSBucket provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) EnsureGCSBucket(projectID string, bucketName string, location string, labels map[string]string, retentionDays int64) error {
+	ret := _mock.Called(projectID, bucketName, location, labels, retentionDays)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureGCSBucket")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, map[string]string, int64) error); ok {
+		r0 = returnFunc(projectID, bucketName, location, labels, retentionDays)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGCPClientManager_EnsureGCSBucket_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureGCSBucket'
+type MockGCPClientManager_EnsureGCSBucket_Call struct {
+	*mock.Call
+}
+
+// EnsureGCSBucket is a helper method to define mock.On call
+//   - projectID string
+//   - bucketName string
+//   - location string
+//   - labels map[string]string
+//   - retentionDays int64
+func (_e *MockGCPClientManager_Expecter) EnsureGCSBucket(projectID any, bucketName any, location any, labels any, retentionDays any) *MockGCPClientManager_EnsureGCSBucket_Call {
+	return &MockGCPClientManager_EnsureGCSBucket_Call{Call: _e.mock.On("EnsureGCSBucket", projectID, bucketName, location, labels, retentionDays)}
+}
+
+func (_c *MockGCPClientManager_EnsureGCSBucket_Call) Run(run func(projectID string, bucketName string, location string, labels map[string]string, retentionDays int64)) *MockGCPClientManager_EnsureGCSBucket_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 map[string]string
+		if args[3] != nil {
+			arg3 = args[3].(map[string]string)
+		}
+		var arg4 int64
+		if args[4] != nil {
+			arg4 = args[4].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_EnsureGCSBucket_Call) Return(err error) *MockGCPClientManager_EnsureGCSBucket_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_EnsureGCSBucket_Call) RunAndReturn(run func(projectID string, bucketName string, location string, labels map[string]string, retentionDays int64) error) *MockGCPClientManager_EnsureGCSBucket_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnsureKMSKey provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) EnsureKMSKey(projectID string, location string, keyRingID string, keyID string) (string, error) {
+	ret := _mock.Called(projectID, location, keyRingID, keyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureKMSKey")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, string) (string, error)); ok {
+		return returnFunc(projectID, location, keyRingID, keyID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, string) string); ok {
+		r0 = returnFunc(projectID, location, keyRingID, keyID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, string, string) error); ok {
+		r1 = returnFunc(projectID, location, keyRingID, keyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGCPClientManager_EnsureKMSKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureKMSKey'
+type MockGCPClientManager_EnsureKMSKey_Call struct {
+	*mock.Call
+}
+
+// EnsureKMSKey is a helper method to define mock.On call
+//   - projectID string
+//   - location string
+//   - keyRingID string
+//   - keyID string
+func (_e *MockGCPClientManager_Expecter) EnsureKMSKey(projectID any, location any, keyRingID any, keyID any) *MockGCPClientManager_EnsureKMSKey_Call {
+	return &MockGCPClientManager_EnsureKMSKey_Call{Call: _e.mock.On("EnsureKMSKey", projectID, location, keyRingID, keyID)}
+}
+
+func (_c *MockGCPClientManager_EnsureKMSKey_Call) Run(run func(projectID string, location string, keyRingID string, keyID string)) *MockGCPClientManager_EnsureKMSKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_EnsureKMSKey_Call) Return(s string, err error) *MockGCPClientManager_EnsureKMSKey_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_EnsureKMSKey_Call) RunAndReturn(run func(projectID string, location string, keyRingID string, keyID string) (string, error)) *MockGCPClientManager_EnsureKMSKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnsurePrivateServiceAccess provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) EnsurePrivateServiceAccess(projectID string, networkName string) error {
+	ret := _mock.Called(projectID, networkName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsurePrivateServiceAccess")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = returnFunc(projectID, networkName)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGCPClientManager_EnsurePrivateServiceAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsurePrivateServiceAccess'
+type MockGCPClientManager_EnsurePrivateServiceAccess_Call struct {
+	*mock.Call
+}
+
+// EnsurePrivateServiceAccess is a helper method to define mock.On call
+//   - projectID string
+//   - networkName string
+func (_e *MockGCPClientManager_Expecter) EnsurePrivateServiceAccess(projectID any, networkName any) *MockGCPClientManager_EnsurePrivateServiceAccess_Call {
+	return &MockGCPClientManager_EnsurePrivateServiceAccess_Call{Call: _e.mock.On("EnsurePrivateServiceAccess", projectID, networkName)}
+}
+
+func (_c *MockGCPClientManager_EnsurePrivateServiceAccess_Call) Run(run func(projectID string, networkName string)) *MockGCPClientManager_EnsurePrivateServiceAccess_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_EnsurePrivateServiceAccess_Call) Return(err error) *MockGCPClientManager_EnsurePrivateServiceAccess_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_EnsurePrivateServiceAccess_Call) RunAndReturn(run func(projectID string, networkName string) error) *MockGCPClientManager_EnsurePrivateServiceAccess_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnsureProjectLien provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) EnsureProjectLien(projectID string, reason string) (string, error) {
+	ret := _mock.Called(projectID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureProjectLien")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) (string, error)); ok {
+		return returnFunc(projectID, reason)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = returnFunc(projectID, reason)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = returnFunc(projectID, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGCPClientManager_EnsureProjectLien_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureProjectLien'
+type MockGCPClientManager_EnsureProjectLien_Call struct {
+	*mock.Call
+}
+
+// EnsureProjectLien is a helper method to define mock.On call
+//   - projectID string
+//   - reason string
+func (_e *MockGCPClientManager_Expecter) EnsureProjectLien(projectID any, reason any) *MockGCPClientManager_EnsureProjectLien_Call {
+	return &MockGCPClientManager_EnsureProjectLien_Call{Call: _e.mock.On("EnsureProjectLien", projectID, reason)}
+}
+
+func (_c *MockGCPClientManager_EnsureProjectLien_Call) Run(run func(projectID string, reason string)) *MockGCPClientManager_EnsureProjectLien_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_EnsureProjectLien_Call) Return(s string, err error) *MockGCPClientManager_EnsureProjectLien_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_EnsureProjectLien_Call) RunAndReturn(run func(projectID string, reason string) (string, error)) *MockGCPClientManager_EnsureProjectLien_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAddress provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) GetAddress(projectID string, region string, addressName string) (*computepb.Address, error) {
+	ret := _mock.Called(projectID, region, addressName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAddress")
+	}
+
+	var r0 *computepb.Address
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) (*computepb.Address, error)); ok {
+		return returnFunc(projectID, region, addressName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) *computepb.Address); ok {
+		r0 = returnFunc(projectID, region, addressName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*computepb.Address)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = returnFunc(projectID, region, addressName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGCPClientManager_GetAddress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAddress'
+type MockGCPClientManager_GetAddress_Call struct {
+	*mock.Call
+}
+
+// GetAddress is a helper method to define mock.On call
+//   - projectID string
+//   - region string
+//   - addressName string
+func (_e *MockGCPClientManager_Expecter) GetAddress(projectID any, region any, addressName any) *MockGCPClientManager_GetAddress_Call {
+	return &MockGCPClientManager_GetAddress_Call{Call: _e.mock.On("GetAddress", projectID, region, addressName)}
+}
+
+func (_c *MockGCPClientManager_GetAddress_Call) Run(run func(projectID string, region string, addressName string)) *MockGCPClientManager_GetAddress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetAddress_Call) Return(address *computepb.Address, err error) *MockGCPClientManager_GetAddress_Call {
+	_c.Call.Return(address, err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetAddress_Call) RunAndReturn(run func(projectID string, region string, addressName string) (*computepb.Address, error)) *MockGCPClientManager_GetAddress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArtifactRegistry provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) GetArtifactRegistry(projectID string, region string, repoName string) (*artifactregistrypb.Repository, error) {
+	ret := _mock.Called(projectID, region, repoName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactRegistry")
+	}
+
+	var r0 *artifactregistrypb.Repository
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) (*artifactregistrypb.Repository, error)); ok {
+		return returnFunc(projectID, region, repoName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) *artifactregistrypb.Repository); ok {
+		r0 = returnFunc(projectID, region, repoName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*artifactregistrypb.Repository)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = returnFunc(projectID, region, repoName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGCPClientManager_GetArtifactRegistry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArtifactRegistry'
+type MockGCPClientManager_GetArtifactRegistry_Call struct {
+	*mock.Call
+}
+
+// GetArtifactRegistry is a helper method to define mock.On call
+//   - projectID string
+//   - region string
+//   - repoName string
+func (_e *MockGCPClientManager_Expecter) GetArtifactRegistry(projectID any, region any, repoName any) *MockGCPClientManager_GetArtifactRegistry_Call {
+	return &MockGCPClientManager_GetArtifactRegistry_Call{Call: _e.mock.On("GetArtifactRegistry", projectID, region, repoName)}
+}
+
+func (_c *MockGCPClientManager_GetArtifactRegistry_Call) Run(run func(projectID string, region string, repoName string)) *MockGCPClientManager_GetArtifactRegistry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetArtifactRegistry_Call) Return(repository *artifactregistrypb.Repository, err error) *MockGCPClientManager_GetArtifactRegistry_Call {
+	_c.Call.Return(repository, err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetArtifactRegistry_Call) RunAndReturn(run func(projectID string, region string, repoName string) (*artifactregistrypb.Repository, error)) *MockGCPClientManager_GetArtifactRegistry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBillingInfo provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) GetBillingInfo(projectID string) (*cloudbilling.ProjectBillingInfo, error) {
+	ret := _mock.Called(projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBillingInfo")
+	}
+
+	var r0 *cloudbilling.ProjectBillingInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*cloudbilling.ProjectBillingInfo, error)); ok {
+		return returnFunc(projectID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *cloudbilling.ProjectBillingInfo); ok {
+		r0 = returnFunc(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*cloudbilling.ProjectBillingInfo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGCPClientManager_GetBillingInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBillingInfo'
+type MockGCPClientManager_GetBillingInfo_Call struct {
+	*mock.Call
+}
+
+// GetBillingInfo is a helper method to define mock.On call
+//   - projectID string
+func (_e *MockGCPClientManager_Expecter) GetBillingInfo(projectID any) *MockGCPClientManager_GetBillingInfo_Call {
+	return &MockGCPClientManager_GetBillingInfo_Call{Call: _e.mock.On("GetBillingInfo", projectID)}
+}
+
+func (_c *MockGCPClientManager_GetBillingInfo_Call) Run(run func(projectID string)) *MockGCPClientManager_GetBillingInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetBillingInfo_Call) Return(projectBillingInfo *cloudbilling.ProjectBillingInfo, err error) *MockGCPClientManager_GetBillingInfo_Call {
+	_c.Call.Return(projectBillingInfo, err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetBillingInfo_Call) RunAndReturn(run func(projectID string) (*cloudbilling.ProjectBillingInfo, error)) *MockGCPClientManager_GetBillingInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCloudSQLInstance provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) GetCloudSQLInstance(projectID string, instanceName string) (*sqladmin.DatabaseInstance, error) {
+	ret := _mock.Called(projectID, instanceName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCloudSQLInstance")
+	}
+
+	var r0 *sqladmin.DatabaseInstance
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) (*sqladmin.DatabaseInstance, error)); ok {
+		return returnFunc(projectID, instanceName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string) *sqladmin.DatabaseInstance); ok {
+		r0 = returnFunc(projectID, instanceName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqladmin.DatabaseInstance)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = returnFunc(projectID, instanceName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGCPClientManager_GetCloudSQLInstance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCloudSQLInstance'
+type MockGCPClientManager_GetCloudSQLInstance_Call struct {
+	*mock.Call
+}
+
+// GetCloudSQLInstance is a helper method to define mock.On call
+//   - projectID string
+//   - instanceName string
+func (_e *MockGCPClientManager_Expecter) GetCloudSQLInstance(projectID any, instanceName any) *MockGCPClientManager_GetCloudSQLInstance_Call {
+	return &MockGCPClientManager_GetCloudSQLInstance_Call{Call: _e.mock.On("GetCloudSQLInstance", projectID, instanceName)}
+}
+
+func (_c *MockGCPClientManager_GetCloudSQLInstance_Call) Run(run func(projectID string, instanceName string)) *MockGCPClientManager_GetCloudSQLInstance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetCloudSQLInstance_Call) Return(databaseInstance *sqladmin.DatabaseInstance, err error) *MockGCPClientManager_GetCloudSQLInstance_Call {
+	_c.Call.Return(databaseInstance, err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetCloudSQLInstance_Call) RunAndReturn(run func(projectID string, instanceName string) (*sqladmin.DatabaseInstance, error)) *MockGCPClientManager_GetCloudSQLInstance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDNSManagedZone provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) GetDNSManagedZone(projectID string, zoneName string) (*dns.ManagedZone, error) {
+	ret := _mock.Called(projectID, zoneName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDNSManagedZone")
+	}
+
+	var r0 *dns.ManagedZone
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) (*dns.ManagedZone, error)); ok {
+		return returnFunc(projectID, zoneName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string) *dns.ManagedZone); ok {
+		r0 = returnFunc(projectID, zoneName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dns.ManagedZone)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = returnFunc(projectID, zoneName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGCPClientManager_GetDNSManagedZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDNSManagedZone'
+type MockGCPClientManager_GetDNSManagedZone_Call struct {
+	*mock.Call
+}
+
+// GetDNSManagedZone is a helper method to define mock.On call
+//   - projectID string
+//   - zoneName string
+func (_e *MockGCPClientManager_Expecter) GetDNSManagedZone(projectID any, zoneName any) *MockGCPClientManager_GetDNSManagedZone_Call {
+	return &MockGCPClientManager_GetDNSManagedZone_Call{Call: _e.mock.On("GetDNSManagedZone", projectID, zoneName)}
+}
+
+func (_c *MockGCPClientManager_GetDNSManagedZone_Call) Run(run func(projectID string, zoneName string)) *MockGCPClientManager_GetDNSManagedZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetDNSManagedZone_Call) Return(managedZone *dns.ManagedZone, err error) *MockGCPClientManager_GetDNSManagedZone_Call {
+	_c.Call.Return(managedZone, err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetDNSManagedZone_Call) RunAndReturn(run func(projectID string, zoneName string) (*dns.ManagedZone, error)) *MockGCPClientManager_GetDNSManagedZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDNSRecordSet provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) GetDNSRecordSet(projectID string, zoneName string, name string, rtype string) (*dns.ResourceRecordSet, error) {
+	ret := _mock.Called(projectID, zoneName, name, rtype)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDNSRecordSet")
+	}
+
+	var r0 *dns.ResourceRecordSet
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, string) (*dns.ResourceRecordSet, error)); ok {
+		return returnFunc(projectID, zoneName, name, rtype)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, string) *dns.ResourceRecordSet); ok {
+		r0 = returnFunc(projectID, zoneName, name, rtype)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dns.ResourceRecordSet)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, string, string) error); ok {
+		r1 = returnFunc(projectID, zoneName, name, rtype)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGCPClientManager_GetDNSRecordSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDNSRecordSet'
+type MockGCPClientManager_GetDNSRecordSet_Call struct {
+	*mock.Call
+}
+
+// GetDNSRecordSet is a helper method to define mock.On call
+//   - projectID string
+//   - zoneName string
+//   - name string
+//   - rtype string
+func (_e *MockGCPClientManager_Expecter) GetDNSRecordSet(projectID any, zoneName any, name any, rtype any) *MockGCPClientManager_GetDNSRecordSet_Call {
+	return &MockGCPClientManager_GetDNSRecordSet_Call{Call: _e.mock.On("GetDNSRecordSet", projectID, zoneName, name, rtype)}
+}
+
+func (_c *MockGCPClientManager_GetDNSRecordSet_Call) Run(run func(projectID string, zoneName string, name string, rtype string)) *MockGCPClientManager_GetDNSRecordSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetDNSRecordSet_Call) Return(resourceRecordSet *dns.ResourceRecordSet, err error) *MockGCPClientManager_GetDNSRecordSet_Call {
+	_c.Call.Return(resourceRecordSet, err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetDNSRecordSet_Call) RunAndReturn(run func(projectID string, zoneName string, name string, rtype string) (*dns.ResourceRecordSet, error)) *MockGCPClientManager_GetDNSRecordSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDNSSECDSRecord provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) GetDNSSECDSRecord(projectID string, zoneName string) (string, error) {
+	ret := _mock.Called(projectID, zoneName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDNSSECDSRecord")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) (string, error)); ok {
+		return returnFunc(projectID, zoneName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = returnFunc(projectID, zoneName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = returnFunc(projectID, zoneName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGCPClientManager_GetDNSSECDSRecord_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDNSSECDSRecord'
+type MockGCPClientManager_GetDNSSECDSRecord_Call struct {
+	*mock.Call
+}
+
+// GetDNSSECDSRecord is a helper method to define mock.On call
+//   - projectID string
+//   - zoneName string
+func (_e *MockGCPClientManager_Expecter) GetDNSSECDSRecord(projectID any, zoneName any) *MockGCPClientManager_GetDNSSECDSRecord_Call {
+	return &MockGCPClientManager_GetDNSSECDSRecord_Call{Call: _e.mock.On("GetDNSSECDSRecord", projectID, zoneName)}
+}
+
+func (_c *MockGCPClientManager_GetDNSSECDSRecord_Call) Run(run func(projectID string, zoneName string)) *MockGCPClientManager_GetDNSSECDSRecord_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetDNSSECDSRecord_Call) Return(s string, err error) *MockGCPClientManager_GetDNSSECDSRecord_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetDNSSECDSRecord_Call) RunAndReturn(run func(projectID string, zoneName string) (string, error)) *MockGCPClientManager_GetDNSSECDSRecord_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetImage provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) GetImage(projectID string, imageName string) (*computepb.Image, error) {
+	ret := _mock.Called(projectID, imageName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetImage")
+	}
+
+	var r0 *computepb.Image
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) (*computepb.Image, error)); ok {
+		return returnFunc(projectID, imageName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string) *computepb.Image); ok {
+		r0 = returnFunc(projectID, imageName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*computepb.Image)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = returnFunc(projectID, imageName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGCPClientManager_GetImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetImage'
+type MockGCPClientManager_GetImage_Call struct {
+	*mock.Call
+}
+
+// GetImage is a helper method to define mock.On call
+//   - projectID string
+//   - imageName string
+func (_e *MockGCPClientManager_Expecter) GetImage(projectID any, imageName any) *MockGCPClientManager_GetImage_Call {
+	return &MockGCPClientManager_GetImage_Call{Call: _e.mock.On("GetImage", projectID, imageName)}
+}
+
+func (_c *MockGCPClientManager_GetImage_Call) Run(run func(projectID string, imageName string)) *MockGCPClientManager_GetImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetImage_Call) Return(image *computepb.Image, err error) *MockGCPClientManager_GetImage_Call {
+	_c.Call.Return(image, err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetImage_Call) RunAndReturn(run func(projectID string, imageName string) (*computepb.Image, error)) *MockGCPClientManager_GetImage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetImageFromFamily provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) GetImageFromFamily(projectID string, family string) (*computepb.Image, error) {
+	ret := _mock.Called(projectID, family)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetImageFromFamily")
+	}
+
+	var r0 *computepb.Image
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) (*computepb.Image, error)); ok {
+		return returnFunc(projectID, family)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string) *computepb.Image); ok {
+		r0 = returnFunc(projectID, family)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*computepb.Image)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = returnFunc(projectID, family)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGCPClientManager_GetImageFromFamily_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetImageFromFamily'
+type MockGCPClientManager_GetImageFromFamily_Call struct {
+	*mock.Call
+}
+
+// GetImageFromFamily is a helper method to define mock.On call
+//   - projectID string
+//   - family string
+func (_e *MockGCPClientManager_Expecter) GetImageFromFamily(projectID any, family any) *MockGCPClientManager_GetImageFromFamily_Call {
+	return &MockGCPClientManager_GetImageFromFamily_Call{Call: _e.mock.On("GetImageFromFamily", projectID, family)}
+}
+
+func (_c *MockGCPClientManager_GetImageFromFamily_Call) Run(run func(projectID string, family string)) *MockGCPClientManager_GetImageFromFamily_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetImageFromFamily_Call) Return(image *computepb.Image, err error) *MockGCPClientManager_GetImageFromFamily_Call {
+	_c.Call.Return(image, err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetImageFromFamily_Call) RunAndReturn(run func(projectID string, family string) (*computepb.Image, error)) *MockGCPClientManager_GetImageFromFamily_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInstance provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) GetInstance(projectID string, zone string, instanceName string) (*computepb.Instance, error) {
+	ret := _mock.Called(projectID, zone, instanceName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInstance")
+	}
+
+	var r0 *computepb.Instance
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) (*computepb.Instance, error)); ok {
+		return returnFunc(projectID, zone, instanceName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) *computepb.Instance); ok {
+		r0 = returnFunc(projectID, zone, instanceName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*computepb.Instance)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = returnFunc(projectID, zone, instanceName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGCPClientManager_GetInstance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInstance'
+type MockGCPClientManager_GetInstance_Call struct {
+	*mock.Call
+}
+
+// GetInstance is a helper method to define mock.On call
+//   - projectID string
+//   - zone string
+//   - instanceName string
+func (_e *MockGCPClientManager_Expecter) GetInstance(projectID any, zone any, instanceName any) *MockGCPClientManager_GetInstance_Call {
+	return &MockGCPClientManager_GetInstance_Call{Call: _e.mock.On("GetInstance", projectID, zone, instanceName)}
+}
+
+func (_c *MockGCPClientManager_GetInstance_Call) Run(run func(projectID string, zone string, instanceName string)) *MockGCPClientManager_GetInstance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetInstance_Call) Return(instance *computepb.Instance, err error) *MockGCPClientManager_GetInstance_Call {
+	_c.Call.Return(instance, err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetInstance_Call) RunAndReturn(run func(projectID string, zone string, instanceName string) (*computepb.Instance, error)) *MockGCPClientManager_GetInstance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInstanceHostKeys provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) GetInstanceHostKeys(projectID string, zone string, instanceName string) (map[string]string, error) {
+	ret := _mock.Called(projectID, zone, instanceName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInstanceHostKeys")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) (map[string]string, error)); ok {
+		return returnFunc(projectID, zone, instanceName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) map[string]string); ok {
+		r0 = returnFunc(projectID, zone, instanceName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = returnFunc(projectID, zone, instanceName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGCPClientManager_GetInstanceHostKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInstanceHostKeys'
+type MockGCPClientManager_GetInstanceHostKeys_Call struct {
+	*mock.Call
+}
+
+// GetInstanceHostKeys is a helper method to define mock.On call
+//   - projectID string
+//   - zone string
+//   - instanceName string
+func (_e *MockGCPClientManager_Expecter) GetInstanceHostKeys(projectID any, zone any, instanceName any) *MockGCPClientManager_GetInstanceHostKeys_Call {
+	return &MockGCPClientManager_GetInstanceHostKeys_Call{Call: _e.mock.On("GetInstanceHostKeys", projectID, zone, instanceName)}
+}
+
+func (_c *MockGCPClientManager_GetInstanceHostKeys_Call) Run(run func(projectID string, zone string, instanceName string)) *MockGCPClientManager_GetInstanceHostKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetInstanceHostKeys_Call) Return(stringToString map[string]string, err error) *MockGCPClientManager_GetInstanceHostKeys_Call {
+	_c.Call.Return(stringToString, err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetInstanceHostKeys_Call) RunAndReturn(run func(projectID string, zone string, instanceName string) (map[string]string, error)) *MockGCPClientManager_GetInstanceHostKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProjectByName provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) GetProjectByName(folderID string, displayName string) (*resourcemanagerpb.Project, error) {
+	ret := _mock.Called(folderID, displayName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProjectByName")
+	}
+
+	var r0 *resourcemanagerpb.Project
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) (*resourcemanagerpb.Project, error)); ok {
+		return returnFunc(folderID, displayName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string) *resourcemanagerpb.Project); ok {
+		r0 = returnFunc(folderID, displayName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*resourcemanagerpb.Project)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = returnFunc(folderID, displayName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGCPClientManager_GetProjectByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProjectByName'
+type MockGCPClientManager_GetProjectByName_Call struct {
+	*mock.Call
+}
+
+// GetProjectByName is a helper method to define mock.On call
+//   - folderID string
+//   - displayName string
+func (_e *MockGCPClientManager_Expecter) GetProjectByName(folderID any, displayName any) *MockGCPClientManager_GetProjectByName_Call {
+	return &MockGCPClientManager_GetProjectByName_Call{Call: _e.mock.On("GetProjectByName", folderID, displayName)}
+}
+
+func (_c *MockGCPClientManager_GetProjectByName_Call) Run(run func(folderID string, displayName string)) *MockGCPClientManager_GetProjectByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetProjectByName_Call) Return(project *resourcemanagerpb.Project, err error) *MockGCPClientManager_GetProjectByName_Call {
+	_c.Call.Return(project, err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetProjectByName_Call) RunAndReturn(run func(folderID string, displayName string) (*resourcemanagerpb.Project, error)) *MockGCPClientManager_GetProjectByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProjectLabels provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) GetProjectLabels(projectID string) (map[string]string, error) {
+	ret := _mock.Called(projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProjectLabels")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (map[string]string, error)); ok {
+		return returnFunc(projectID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) map[string]string); ok {
+		r0 = returnFunc(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGCPClientManager_GetProjectLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProjectLabels'
+type MockGCPClientManager_GetProjectLabels_Call struct {
+	*mock.Call
+}
+
+// GetProjectLabels is a helper method to define mock.On call
+//   - projectID string
+func (_e *MockGCPClientManager_Expecter) GetProjectLabels(projectID any) *MockGCPClientManager_GetProjectLabels_Call {
+	return &MockGCPClientManager_GetProjectLabels_Call{Call: _e.mock.On("GetProjectLabels", projectID)}
+}
+
+func (_c *MockGCPClientManager_GetProjectLabels_Call) Run(run func(projectID string)) *MockGCPClientManager_GetProjectLabels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetProjectLabels_Call) Return(stringToString map[string]string, err error) *MockGCPClientManager_GetProjectLabels_Call {
+	_c.Call.Return(stringToString, err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetProjectLabels_Call) RunAndReturn(run func(projectID string) (map[string]string, error)) *MockGCPClientManager_GetProjectLabels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProjectNumber provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) GetProjectNumber(projectID string) (int64, error) {
+	ret := _mock.Called(projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProjectNumber")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (int64, error)); ok {
+		return returnFunc(projectID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = returnFunc(projectID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGCPClientManager_GetProjectNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProjectNumber'
+type MockGCPClientManager_GetProjectNumber_Call struct {
+	*mock.Call
+}
+
+// GetProjectNumber is a helper method to define mock.On call
+//   - projectID string
+func (_e *MockGCPClientManager_Expecter) GetProjectNumber(projectID any) *MockGCPClientManager_GetProjectNumber_Call {
+	return &MockGCPClientManager_GetProjectNumber_Call{Call: _e.mock.On("GetProjectNumber", projectID)}
+}
+
+func (_c *MockGCPClientManager_GetProjectNumber_Call) Run(run func(projectID string)) *MockGCPClientManager_GetProjectNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetProjectNumber_Call) Return(n int64, err error) *MockGCPClientManager_GetProjectNumber_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetProjectNumber_Call) RunAndReturn(run func(projectID string) (int64, error)) *MockGCPClientManager_GetProjectNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRegionQuotas provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) GetRegionQuotas(projectID string, region string) ([]*computepb.Quota, error) {
+	ret := _mock.Called(projectID, region)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRegionQuotas")
+	}
+
+	var r0 []*computepb.Quota
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) ([]*computepb.Quota, error)); ok {
+		return returnFunc(projectID, region)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string) []*computepb.Quota); ok {
+		r0 = returnFunc(projectID, region)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*computepb.Quota)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = returnFunc(projectID, region)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGCPClientManager_GetRegionQuotas_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRegionQuotas'
+type MockGCPClientManager_GetRegionQuotas_Call struct {
+	*mock.Call
+}
+
+// GetRegionQuotas is a helper method to define mock.On call
+//   - projectID string
+//   - region string
+func (_e *MockGCPClientManager_Expecter) GetRegionQuotas(projectID any, region any) *MockGCPClientManager_GetRegionQuotas_Call {
+	return &MockGCPClientManager_GetRegionQuotas_Call{Call: _e.mock.On("GetRegionQuotas", projectID, region)}
+}
+
+func (_c *MockGCPClientManager_GetRegionQuotas_Call) Run(run func(projectID string, region string)) *MockGCPClientManager_GetRegionQuotas_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetRegionQuotas_Call) Return(quotas []*computepb.Quota, err error) *MockGCPClientManager_GetRegionQuotas_Call {
+	_c.Call.Return(quotas, err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_GetRegionQuotas_Call) RunAndReturn(run func(projectID string, region string) ([]*computepb.Quota, error)) *MockGCPClientManager_GetRegionQuotas_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GrantBucketIAMRole provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) GrantBucketIAMRole(bucketName string, member string, role string) error {
+	ret := _mock.Called(bucketName, member, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GrantBucketIAMRole")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = returnFunc(bucketName, member, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGCPClientManager_GrantBucketIAMRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GrantBucketIAMRole'
+type MockGCPClientManager_GrantBucketIAMRole_Call struct {
+	*mock.Call
+}
+
+// GrantBucketIAMRole is a helper method to define mock.On call
+//   - bucketName string
+//   - member string
+//   - role string
+func (_e *MockGCPClientManager_Expecter) GrantBucketIAMRole(bucketName any, member any, role any) *MockGCPClientManager_GrantBucketIAMRole_Call {
+	return &MockGCPClientManager_GrantBucketIAMRole_Call{Call: _e.mock.On("GrantBucketIAMRole", bucketName, member, role)}
+}
+
+func (_c *MockGCPClientManager_GrantBucketIAMRole_Call) Run(run func(bucketName string, member string, role string)) *MockGCPClientManager_GrantBucketIAMRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGCPClientManager_GrantBucketIAMRole_Call) Return(err error) *MockGCPClientManager_GrantBucketIAMRole_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGCPClientManager_GrantBucketIAMRole_Call) RunAndReturn(run func(bucketName string, member string, role string) error) *MockGCPClientManager_GrantBucketIAMRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsOMSManagedProject provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) IsOMSManagedProject(projectID string) (bool, error) {
+	ret := _mock.Called(projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsOMSManagedProject")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (bool, error)); ok {
+		return returnFunc(projectID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = returnFunc(projectID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGCPClientManager_IsOMSManagedProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsOMSManagedProject'
+type MockGCPClientManager_IsOMSManagedProject_Call struct {
+	*mock.Call
+}
+
+// IsOMSManagedProject is a helper method to define mock.On call
+//   - projectID string
+func (_e *MockGCPClientManager_Expecter) IsOMSManagedProject(projectID any) *MockGCPClientManager_IsOMSManagedProject_Call {
+	return &MockGCPClientManager_IsOMSManagedProject_Call{Call: _e.mock.On("IsOMSManagedProject", projectID)}
 }
 
-func (_c *MockGCPClientManager_EnableBilling_Call) Run(run func(projectID string, billingAccount string)) *MockGCPClientManager_EnableBilling_Call {
+func (_c *MockGCPClientManager_IsOMSManagedProject_Call) Run(run func(projectID string)) *MockGCPClientManager_IsOMSManagedProject_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
 			arg0 = args[0].(string)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockGCPClientManager_EnableBilling_Call) Return(err error) *MockGCPClientManager_EnableBilling_Call {
-	_c.Call.Return(err)
+func (_c *MockGCPClientManager_IsOMSManagedProject_Call) Return(b bool, err error) *MockGCPClientManager_IsOMSManagedProject_Call {
+	_c.Call.Return(b, err)
 	return _c
 }
 
-func (_c *MockGCPClientManager_EnableBilling_Call) RunAndReturn(run func(projectID string, billingAccount string) error) *MockGCPClientManager_EnableBilling_Call {
+func (_c *MockGCPClientManager_IsOMSManagedProject_Call) RunAndReturn(run func(projectID string) (bool, error)) *MockGCPClientManager_IsOMSManagedProject_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// EnsureDNSManagedZone provides a mock function for the type MockGCPClientManager
-func (_mock *MockGCPClientManager) EnsureDNSManagedZone(projectID string, zoneName string, dnsName string, description string) error {
-	ret := _mock.Called(projectID, zoneName, dnsName, description)
+// IsProtectedProject provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) IsProtectedProject(projectID string) (bool, error) {
+	ret := _mock.Called(projectID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EnsureDNSManagedZone")
+		panic("no return value specified for IsProtectedProject")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, string, string, string) error); ok {
-		r0 = returnFunc(projectID, zoneName, dnsName, description)
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (bool, error)); ok {
+		return returnFunc(projectID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = returnFunc(projectID)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockGCPClientManager_EnsureDNSManagedZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureDNSManagedZone'
-type MockGCPClientManager_EnsureDNSManagedZone_Call struct {
+// MockGCPClientManager_IsProtectedProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsProtectedProject'
+type MockGCPClientManager_IsProtectedProject_Call struct {
 	*mock.Call
 }
 
-// EnsureDNSManagedZone is a helper method to define mock.On call
+// IsProtectedProject is a helper method to define mock.On call
 //   - projectID string
-//   - zoneName string
-//   - dnsName string
-//   - description string
-func (_e *MockGCPClientManager_Expecter) EnsureDNSManagedZone(projectID any, zoneName any, dnsName any, description any) *MockGCPClientManager_EnsureDNSManagedZone_Call {
-	return &MockGCPClientManager_EnsureDNSManagedZone_Call{Call: _e.mock.On("EnsureDNSManagedZone", projectID, zoneName, dnsName, description)}
+func (_e *MockGCPClientManager_Expecter) IsProtectedProject(projectID any) *MockGCPClientManager_IsProtectedProject_Call {
+	return &MockGCPClientManager_IsProtectedProject_Call{Call: _e.mock.On("IsProtectedProject", projectID)}
 }
 
-func (_c *MockGCPClientManager_EnsureDNSManagedZone_Call) Run(run func(projectID string, zoneName string, dnsName string, description string)) *MockGCPClientManager_EnsureDNSManagedZone_Call {
+func (_c *MockGCPClientManager_IsProtectedProject_Call) Run(run func(projectID string)) *MockGCPClientManager_IsProtectedProject_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
 			arg0 = args[0].(string)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
 		run(
 			arg0,
-			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockGCPClientManager_EnsureDNSManagedZone_Call) Return(err error) *MockGCPClientManager_EnsureDNSManagedZone_Call {
-	_c.Call.Return(err)
+func (_c *MockGCPClientManager_IsProtectedProject_Call) Return(b bool, err error) *MockGCPClientManager_IsProtectedProject_Call {
+	_c.Call.Return(b, err)
 	return _c
 }
 
-func (_c *MockGCPClientManager_EnsureDNSManagedZone_Call) RunAndReturn(run func(projectID string, zoneName string, dnsName string, description string) error) *MockGCPClientManager_EnsureDNSManagedZone_Call {
+func (_c *MockGCPClientManager_IsProtectedProject_Call) RunAndReturn(run func(projectID string) (bool, error)) *MockGCPClientManager_IsProtectedProject_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// EnsureDNSRecordSets provides a mock function for the type MockGCPClientManager
-func (_mock *MockGCPClientManager) EnsureDNSRecordSets(projectID string, zoneName string, records []*dns.ResourceRecordSet) error {
-	ret := _mock.Called(projectID, zoneName, records)
+// ListAddressesByLabels provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) ListAddressesByLabels(projectID string, region string, labels map[string]string) ([]*computepb.Address, error) {
+	ret := _mock.Called(projectID, region, labels)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EnsureDNSRecordSets")
+		panic("no return value specified for ListAddressesByLabels")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, string, []*dns.ResourceRecordSet) error); ok {
-		r0 = returnFunc(projectID, zoneName, records)
+	var r0 []*computepb.Address
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, map[string]string) ([]*computepb.Address, error)); ok {
+		return returnFunc(projectID, region, labels)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, map[string]string) []*computepb.Address); ok {
+		r0 = returnFunc(projectID, region, labels)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*computepb.Address)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(string, string, map[string]string) error); ok {
+		r1 = returnFunc(projectID, region, labels)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockGCPClientManager_EnsureDNSRecordSets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureDNSRecordSets'
-type MockGCPClientManager_EnsureDNSRecordSets_Call struct {
+// MockGCPClientManager_ListAddressesByLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAddressesByLabels'
+type MockGCPClientManager_ListAddressesByLabels_Call struct {
 	*mock.Call
 }
 
-// EnsureDNSRecordSets is a helper method to define mock.On call
+// ListAddressesByLabels is a helper method to define mock.On call
 //   - projectID string
-//   - zoneName string
-//   - records []*dns.ResourceRecordSet
-func (_e *MockGCPClientManager_Expecter) EnsureDNSRecordSets(projectID any, zoneName any, records any) *MockGCPClientManager_EnsureDNSRecordSets_Call {
-	return &MockGCPClientManager_EnsureDNSRecordSets_Call{Call: _e.mock.On("EnsureDNSRecordSets", projectID, zoneName, records)}
+//   - region string
+//   - labels map[string]string
+func (_e *MockGCPClientManager_Expecter) ListAddressesByLabels(projectID any, region any, labels any) *MockGCPClientManager_ListAddressesByLabels_Call {
+	return &MockGCPClientManager_ListAddressesByLabels_Call{Call: _e.mock.On("ListAddressesByLabels", projectID, region, labels)}
 }
 
-func (_c *MockGCPClientManager_EnsureDNSRecordSets_Call) Run(run func(projectID string, zoneName string, records []*dns.ResourceRecordSet)) *MockGCPClientManager_EnsureDNSRecordSets_Call {
+func (_c *MockGCPClientManager_ListAddressesByLabels_Call) Run(run func(projectID string, region string, labels map[string]string)) *MockGCPClientManager_ListAddressesByLabels_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
@@ -1132,9 +3411,9 @@ func (_c *MockGCPClientManager_EnsureDNSRecordSets_Call) Run(run func(projectID
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 []*dns.ResourceRecordSet
+		var arg2 map[string]string
 		if args[2] != nil {
-			arg2 = args[2].([]*dns.ResourceRecordSet)
+			arg2 = args[2].(map[string]string)
 		}
 		run(
 			arg0,
@@ -1145,132 +3424,120 @@ func (_c *MockGCPClientManager_EnsureDNSRecordSets_Call) Run(run func(projectID
 	return _c
 }
 
-func (_c *MockGCPClientManager_EnsureDNSRecordSets_Call) Return(err error) *MockGCPClientManager_EnsureDNSRecordSets_Call {
-	_c.Call.Return(err)
+func (_c *MockGCPClientManager_ListAddressesByLabels_Call) Return(addresss []*computepb.Address, err error) *MockGCPClientManager_ListAddressesByLabels_Call {
+	_c.Call.Return(addresss, err)
 	return _c
 }
 
-func (_c *MockGCPClientManager_EnsureDNSRecordSets_Call) RunAndReturn(run func(projectID string, zoneName string, records []*dns.ResourceRecordSet) error) *MockGCPClientManager_EnsureDNSRecordSets_Call {
+func (_c *MockGCPClientManager_ListAddressesByLabels_Call) RunAndReturn(run func(projectID string, region string, labels map[string]string) ([]*computepb.Address, error)) *MockGCPClientManager_ListAddressesByLabels_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAddress provides a mock function for the type MockGCPClientManager
-func (_mock *MockGCPClientManager) GetAddress(projectID string, region string, addressName string) (*computepb.Address, error) {
-	ret := _mock.Called(projectID, region, addressName)
+// ListComputeSKUs provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) ListComputeSKUs(region string) ([]*cloudbilling.Sku, error) {
+	ret := _mock.Called(region)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAddress")
+		panic("no return value specified for ListComputeSKUs")
 	}
 
-	var r0 *computepb.Address
+	var r0 []*cloudbilling.Sku
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string, string, string) (*computepb.Address, error)); ok {
-		return returnFunc(projectID, region, addressName)
+	if returnFunc, ok := ret.Get(0).(func(string) ([]*cloudbilling.Sku, error)); ok {
+		return returnFunc(region)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string, string, string) *computepb.Address); ok {
-		r0 = returnFunc(projectID, region, addressName)
+	if returnFunc, ok := ret.Get(0).(func(string) []*cloudbilling.Sku); ok {
+		r0 = returnFunc(region)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*computepb.Address)
+			r0 = ret.Get(0).([]*cloudbilling.Sku)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = returnFunc(projectID, region, addressName)
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(region)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockGCPClientManager_GetAddress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAddress'
-type MockGCPClientManager_GetAddress_Call struct {
+// MockGCPClientManager_ListComputeSKUs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListComputeSKUs'
+type MockGCPClientManager_ListComputeSKUs_Call struct {
 	*mock.Call
 }
 
-// GetAddress is a helper method to define mock.On call
-//   - projectID string
+// ListComputeSKUs is a helper method to define mock.On call
 //   - region string
-//   - addressName string
-func (_e *MockGCPClientManager_Expecter) GetAddress(projectID any, region any, addressName any) *MockGCPClientManager_GetAddress_Call {
-	return &MockGCPClientManager_GetAddress_Call{Call: _e.mock.On("GetAddress", projectID, region, addressName)}
+func (_e *MockGCPClientManager_Expecter) ListComputeSKUs(region any) *MockGCPClientManager_ListComputeSKUs_Call {
+	return &MockGCPClientManager_ListComputeSKUs_Call{Call: _e.mock.On("ListComputeSKUs", region)}
 }
 
-func (_c *MockGCPClientManager_GetAddress_Call) Run(run func(projectID string, region string, addressName string)) *MockGCPClientManager_GetAddress_Call {
+func (_c *MockGCPClientManager_ListComputeSKUs_Call) Run(run func(region string)) *MockGCPClientManager_ListComputeSKUs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
 			arg0 = args[0].(string)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
 		run(
 			arg0,
-			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockGCPClientManager_GetAddress_Call) Return(address *computepb.Address, err error) *MockGCPClientManager_GetAddress_Call {
-	_c.Call.Return(address, err)
+func (_c *MockGCPClientManager_ListComputeSKUs_Call) Return(skus []*cloudbilling.Sku, err error) *MockGCPClientManager_ListComputeSKUs_Call {
+	_c.Call.Return(skus, err)
 	return _c
 }
 
-func (_c *MockGCPClientManager_GetAddress_Call) RunAndReturn(run func(projectID string, region string, addressName string) (*computepb.Address, error)) *MockGCPClientManager_GetAddress_Call {
+func (_c *MockGCPClientManager_ListComputeSKUs_Call) RunAndReturn(run func(region string) ([]*cloudbilling.Sku, error)) *MockGCPClientManager_ListComputeSKUs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetArtifactRegistry provides a mock function for the type MockGCPClientManager
-func (_mock *MockGCPClientManager) GetArtifactRegistry(projectID string, region string, repoName string) (*artifactregistrypb.Repository, error) {
-	ret := _mock.Called(projectID, region, repoName)
+// ListInstancesByLabels provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) ListInstancesByLabels(projectID string, zone string, labels map[string]string) ([]*computepb.Instance, error) {
+	ret := _mock.Called(projectID, zone, labels)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetArtifactRegistry")
+		panic("no return value specified for ListInstancesByLabels")
 	}
 
-	var r0 *artifactregistrypb.Repository
+	var r0 []*computepb.Instance
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string, string, string) (*artifactregistrypb.Repository, error)); ok {
-		return returnFunc(projectID, region, repoName)
+	if returnFunc, ok := ret.Get(0).(func(string, string, map[string]string) ([]*computepb.Instance, error)); ok {
+		return returnFunc(projectID, zone, labels)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string, string, string) *artifactregistrypb.Repository); ok {
-		r0 = returnFunc(projectID, region, repoName)
+	if returnFunc, ok := ret.Get(0).(func(string, string, map[string]string) []*computepb.Instance); ok {
+		r0 = returnFunc(projectID, zone, labels)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*artifactregistrypb.Repository)
+			r0 = ret.Get(0).([]*computepb.Instance)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = returnFunc(projectID, region, repoName)
+	if returnFunc, ok := ret.Get(1).(func(string, string, map[string]string) error); ok {
+		r1 = returnFunc(projectID, zone, labels)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockGCPClientManager_GetArtifactRegistry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArtifactRegistry'
-type MockGCPClientManager_GetArtifactRegistry_Call struct {
+// MockGCPClientManager_ListInstancesByLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListInstancesByLabels'
+type MockGCPClientManager_ListInstancesByLabels_Call struct {
 	*mock.Call
 }
 
-// GetArtifactRegistry is a helper method to define mock.On call
+// ListInstancesByLabels is a helper method to define mock.On call
 //   - projectID string
-//   - region string
-//   - repoName string
-func (_e *MockGCPClientManager_Expecter) GetArtifactRegistry(projectID any, region any, repoName any) *MockGCPClientManager_GetArtifactRegistry_Call {
-	return &MockGCPClientManager_GetArtifactRegistry_Call{Call: _e.mock.On("GetArtifactRegistry", projectID, region, repoName)}
+//   - zone string
+//   - labels map[string]string
+func (_e *MockGCPClientManager_Expecter) ListInstancesByLabels(projectID any, zone any, labels any) *MockGCPClientManager_ListInstancesByLabels_Call {
+	return &MockGCPClientManager_ListInstancesByLabels_Call{Call: _e.mock.On("ListInstancesByLabels", projectID, zone, labels)}
 }
 
-func (_c *MockGCPClientManager_GetArtifactRegistry_Call) Run(run func(projectID string, region string, repoName string)) *MockGCPClientManager_GetArtifactRegistry_Call {
+func (_c *MockGCPClientManager_ListInstancesByLabels_Call) Run(run func(projectID string, zone string, labels map[string]string)) *MockGCPClientManager_ListInstancesByLabels_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
@@ -1280,9 +3547,9 @@ func (_c *MockGCPClientManager_GetArtifactRegistry_Call) Run(run func(projectID
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 map[string]string
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(map[string]string)
 		}
 		run(
 			arg0,
@@ -1293,193 +3560,168 @@ func (_c *MockGCPClientManager_GetArtifactRegistry_Call) Run(run func(projectID
 	return _c
 }
 
-func (_c *MockGCPClientManager_GetArtifactRegistry_Call) Return(repository *artifactregistrypb.Repository, err error) *MockGCPClientManager_GetArtifactRegistry_Call {
-	_c.Call.Return(repository, err)
+func (_c *MockGCPClientManager_ListInstancesByLabels_Call) Return(instances []*computepb.Instance, err error) *MockGCPClientManager_ListInstancesByLabels_Call {
+	_c.Call.Return(instances, err)
 	return _c
 }
 
-func (_c *MockGCPClientManager_GetArtifactRegistry_Call) RunAndReturn(run func(projectID string, region string, repoName string) (*artifactregistrypb.Repository, error)) *MockGCPClientManager_GetArtifactRegistry_Call {
+func (_c *MockGCPClientManager_ListInstancesByLabels_Call) RunAndReturn(run func(projectID string, zone string, labels map[string]string) ([]*computepb.Instance, error)) *MockGCPClientManager_ListInstancesByLabels_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBillingInfo provides a mock function for the type MockGCPClientManager
-func (_mock *MockGCPClientManager) GetBillingInfo(projectID string) (*cloudbilling.ProjectBillingInfo, error) {
-	ret := _mock.Called(projectID)
+// RemoveIAMRoleBinding provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) RemoveIAMRoleBinding(projectID string, saName string, saProjectID string, roles []string) error {
+	ret := _mock.Called(projectID, saName, saProjectID, roles)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBillingInfo")
+		panic("no return value specified for RemoveIAMRoleBinding")
 	}
 
-	var r0 *cloudbilling.ProjectBillingInfo
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (*cloudbilling.ProjectBillingInfo, error)); ok {
-		return returnFunc(projectID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(string) *cloudbilling.ProjectBillingInfo); ok {
-		r0 = returnFunc(projectID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*cloudbilling.ProjectBillingInfo)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(projectID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, []string) error); ok {
+		r0 = returnFunc(projectID, saName, saProjectID, roles)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockGCPClientManager_GetBillingInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBillingInfo'
-type MockGCPClientManager_GetBillingInfo_Call struct {
+// MockGCPClientManager_RemoveIAMRoleBinding_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveIAMRoleBinding'
+type MockGCPClientManager_RemoveIAMRoleBinding_Call struct {
 	*mock.Call
 }
 
-// GetBillingInfo is a helper method to define mock.On call
+// RemoveIAMRoleBinding is a helper method to define mock.On call
 //   - projectID string
-func (_e *MockGCPClientManager_Expecter) GetBillingInfo(projectID any) *MockGCPClientManager_GetBillingInfo_Call {
-	return &MockGCPClientManager_GetBillingInfo_Call{Call: _e.mock.On("GetBillingInfo", projectID)}
+//   - saName string
+//   - saProjectID string
+//   - roles []string
+func (_e *MockGCPClientManager_Expecter) RemoveIAMRoleBinding(projectID any, saName any, saProjectID any, roles any) *MockGCPClientManager_RemoveIAMRoleBinding_Call {
+	return &MockGCPClientManager_RemoveIAMRoleBinding_Call{Call: _e.mock.On("RemoveIAMRoleBinding", projectID, saName, saProjectID, roles)}
 }
 
-func (_c *MockGCPClientManager_GetBillingInfo_Call) Run(run func(projectID string)) *MockGCPClientManager_GetBillingInfo_Call {
+func (_c *MockGCPClientManager_RemoveIAMRoleBinding_Call) Run(run func(projectID string, saName string, saProjectID string, roles []string)) *MockGCPClientManager_RemoveIAMRoleBinding_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
 			arg0 = args[0].(string)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []string
+		if args[3] != nil {
+			arg3 = args[3].([]string)
+		}
 		run(
 			arg0,
+			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockGCPClientManager_GetBillingInfo_Call) Return(projectBillingInfo *cloudbilling.ProjectBillingInfo, err error) *MockGCPClientManager_GetBillingInfo_Call {
-	_c.Call.Return(projectBillingInfo, err)
+func (_c *MockGCPClientManager_RemoveIAMRoleBinding_Call) Return(err error) *MockGCPClientManager_RemoveIAMRoleBinding_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockGCPClientManager_GetBillingInfo_Call) RunAndReturn(run func(projectID string) (*cloudbilling.ProjectBillingInfo, error)) *MockGCPClientManager_GetBillingInfo_Call {
+func (_c *MockGCPClientManager_RemoveIAMRoleBinding_Call) RunAndReturn(run func(projectID string, saName string, saProjectID string, roles []string) error) *MockGCPClientManager_RemoveIAMRoleBinding_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetInstance provides a mock function for the type MockGCPClientManager
-func (_mock *MockGCPClientManager) GetInstance(projectID string, zone string, instanceName string) (*computepb.Instance, error) {
-	ret := _mock.Called(projectID, zone, instanceName)
+// RemoveProjectLiens provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) RemoveProjectLiens(projectID string) error {
+	ret := _mock.Called(projectID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetInstance")
+		panic("no return value specified for RemoveProjectLiens")
 	}
 
-	var r0 *computepb.Instance
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string, string, string) (*computepb.Instance, error)); ok {
-		return returnFunc(projectID, zone, instanceName)
-	}
-	if returnFunc, ok := ret.Get(0).(func(string, string, string) *computepb.Instance); ok {
-		r0 = returnFunc(projectID, zone, instanceName)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*computepb.Instance)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = returnFunc(projectID, zone, instanceName)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(projectID)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockGCPClientManager_GetInstance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInstance'
-type MockGCPClientManager_GetInstance_Call struct {
+// MockGCPClientManager_RemoveProjectLiens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveProjectLiens'
+type MockGCPClientManager_RemoveProjectLiens_Call struct {
 	*mock.Call
 }
-
-// GetInstance is a helper method to define mock.On call
-//   - projectID string
-//   - zone string
-//   - instanceName string
-func (_e *MockGCPClientManager_Expecter) GetInstance(projectID any, zone any, instanceName any) *MockGCPClientManager_GetInstance_Call {
-	return &MockGCPClientManager_GetInstance_Call{Call: _e.mock.On("GetInstance", projectID, zone, instanceName)}
+
+// RemoveProjectLiens is a helper method to define mock.On call
+//   - projectID string
+func (_e *MockGCPClientManager_Expecter) RemoveProjectLiens(projectID any) *MockGCPClientManager_RemoveProjectLiens_Call {
+	return &MockGCPClientManager_RemoveProjectLiens_Call{Call: _e.mock.On("RemoveProjectLiens", projectID)}
 }
 
-func (_c *MockGCPClientManager_GetInstance_Call) Run(run func(projectID string, zone string, instanceName string)) *MockGCPClientManager_GetInstance_Call {
+func (_c *MockGCPClientManager_RemoveProjectLiens_Call) Run(run func(projectID string)) *MockGCPClientManager_RemoveProjectLiens_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
 			arg0 = args[0].(string)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
 		run(
 			arg0,
-			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockGCPClientManager_GetInstance_Call) Return(instance *computepb.Instance, err error) *MockGCPClientManager_GetInstance_Call {
-	_c.Call.Return(instance, err)
+func (_c *MockGCPClientManager_RemoveProjectLiens_Call) Return(err error) *MockGCPClientManager_RemoveProjectLiens_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockGCPClientManager_GetInstance_Call) RunAndReturn(run func(projectID string, zone string, instanceName string) (*computepb.Instance, error)) *MockGCPClientManager_GetInstance_Call {
+func (_c *MockGCPClientManager_RemoveProjectLiens_Call) RunAndReturn(run func(projectID string) error) *MockGCPClientManager_RemoveProjectLiens_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetProjectByName provides a mock function for the type MockGCPClientManager
-func (_mock *MockGCPClientManager) GetProjectByName(folderID string, displayName string) (*resourcemanagerpb.Project, error) {
-	ret := _mock.Called(folderID, displayName)
+// ResizeDisk provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) ResizeDisk(projectID string, zone string, diskName string, sizeGb int64) error {
+	ret := _mock.Called(projectID, zone, diskName, sizeGb)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetProjectByName")
+		panic("no return value specified for ResizeDisk")
 	}
 
-	var r0 *resourcemanagerpb.Project
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string, string) (*resourcemanagerpb.Project, error)); ok {
-		return returnFunc(folderID, displayName)
-	}
-	if returnFunc, ok := ret.Get(0).(func(string, string) *resourcemanagerpb.Project); ok {
-		r0 = returnFunc(folderID, displayName)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*resourcemanagerpb.Project)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = returnFunc(folderID, displayName)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, int64) error); ok {
+		r0 = returnFunc(projectID, zone, diskName, sizeGb)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockGCPClientManager_GetProjectByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProjectByName'
-type MockGCPClientManager_GetProjectByName_Call struct {
+// MockGCPClientManager_ResizeDisk_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResizeDisk'
+type MockGCPClientManager_ResizeDisk_Call struct {
 	*mock.Call
 }
 
-// GetProjectByName is a helper method to define mock.On call
-//   - folderID string
-//   - displayName string
-func (_e *MockGCPClientManager_Expecter) GetProjectByName(folderID any, displayName any) *MockGCPClientManager_GetProjectByName_Call {
-	return &MockGCPClientManager_GetProjectByName_Call{Call: _e.mock.On("GetProjectByName", folderID, displayName)}
+// ResizeDisk is a helper method to define mock.On call
+//   - projectID string
+//   - zone string
+//   - diskName string
+//   - sizeGb int64
+func (_e *MockGCPClientManager_Expecter) ResizeDisk(projectID any, zone any, diskName any, sizeGb any) *MockGCPClientManager_ResizeDisk_Call {
+	return &MockGCPClientManager_ResizeDisk_Call{Call: _e.mock.On("ResizeDisk", projectID, zone, diskName, sizeGb)}
 }
 
-func (_c *MockGCPClientManager_GetProjectByName_Call) Run(run func(folderID string, displayName string)) *MockGCPClientManager_GetProjectByName_Call {
+func (_c *MockGCPClientManager_ResizeDisk_Call) Run(run func(projectID string, zone string, diskName string, sizeGb int64)) *MockGCPClientManager_ResizeDisk_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
@@ -1489,116 +3731,133 @@ func (_c *MockGCPClientManager_GetProjectByName_Call) Run(run func(folderID stri
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockGCPClientManager_GetProjectByName_Call) Return(project *resourcemanagerpb.Project, err error) *MockGCPClientManager_GetProjectByName_Call {
-	_c.Call.Return(project, err)
+func (_c *MockGCPClientManager_ResizeDisk_Call) Return(err error) *MockGCPClientManager_ResizeDisk_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockGCPClientManager_GetProjectByName_Call) RunAndReturn(run func(folderID string, displayName string) (*resourcemanagerpb.Project, error)) *MockGCPClientManager_GetProjectByName_Call {
+func (_c *MockGCPClientManager_ResizeDisk_Call) RunAndReturn(run func(projectID string, zone string, diskName string, sizeGb int64) error) *MockGCPClientManager_ResizeDisk_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// IsOMSManagedProject provides a mock function for the type MockGCPClientManager
-func (_mock *MockGCPClientManager) IsOMSManagedProject(projectID string) (bool, error) {
-	ret := _mock.Called(projectID)
+// SearchProjectsByLabels provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) SearchProjectsByLabels(folderID string, labels map[string]string) ([]*resourcemanagerpb.Project, error) {
+	ret := _mock.Called(folderID, labels)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IsOMSManagedProject")
+		panic("no return value specified for SearchProjectsByLabels")
 	}
 
-	var r0 bool
+	var r0 []*resourcemanagerpb.Project
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (bool, error)); ok {
-		return returnFunc(projectID)
+	if returnFunc, ok := ret.Get(0).(func(string, map[string]string) ([]*resourcemanagerpb.Project, error)); ok {
+		return returnFunc(folderID, labels)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = returnFunc(projectID)
+	if returnFunc, ok := ret.Get(0).(func(string, map[string]string) []*resourcemanagerpb.Project); ok {
+		r0 = returnFunc(folderID, labels)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*resourcemanagerpb.Project)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(projectID)
+	if returnFunc, ok := ret.Get(1).(func(string, map[string]string) error); ok {
+		r1 = returnFunc(folderID, labels)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockGCPClientManager_IsOMSManagedProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsOMSManagedProject'
-type MockGCPClientManager_IsOMSManagedProject_Call struct {
+// MockGCPClientManager_SearchProjectsByLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchProjectsByLabels'
+type MockGCPClientManager_SearchProjectsByLabels_Call struct {
 	*mock.Call
 }
 
-// IsOMSManagedProject is a helper method to define mock.On call
-//   - projectID string
-func (_e *MockGCPClientManager_Expecter) IsOMSManagedProject(projectID any) *MockGCPClientManager_IsOMSManagedProject_Call {
-	return &MockGCPClientManager_IsOMSManagedProject_Call{Call: _e.mock.On("IsOMSManagedProject", projectID)}
+// SearchProjectsByLabels is a helper method to define mock.On call
+//   - folderID string
+//   - labels map[string]string
+func (_e *MockGCPClientManager_Expecter) SearchProjectsByLabels(folderID any, labels any) *MockGCPClientManager_SearchProjectsByLabels_Call {
+	return &MockGCPClientManager_SearchProjectsByLabels_Call{Call: _e.mock.On("SearchProjectsByLabels", folderID, labels)}
 }
 
-func (_c *MockGCPClientManager_IsOMSManagedProject_Call) Run(run func(projectID string)) *MockGCPClientManager_IsOMSManagedProject_Call {
+func (_c *MockGCPClientManager_SearchProjectsByLabels_Call) Run(run func(folderID string, labels map[string]string)) *MockGCPClientManager_SearchProjectsByLabels_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
 			arg0 = args[0].(string)
 		}
+		var arg1 map[string]string
+		if args[1] != nil {
+			arg1 = args[1].(map[string]string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockGCPClientManager_IsOMSManagedProject_Call) Return(b bool, err error) *MockGCPClientManager_IsOMSManagedProject_Call {
-	_c.Call.Return(b, err)
+func (_c *MockGCPClientManager_SearchProjectsByLabels_Call) Return(projects []*resourcemanagerpb.Project, err error) *MockGCPClientManager_SearchProjectsByLabels_Call {
+	_c.Call.Return(projects, err)
 	return _c
 }
 
-func (_c *MockGCPClientManager_IsOMSManagedProject_Call) RunAndReturn(run func(projectID string) (bool, error)) *MockGCPClientManager_IsOMSManagedProject_Call {
+func (_c *MockGCPClientManager_SearchProjectsByLabels_Call) RunAndReturn(run func(folderID string, labels map[string]string) ([]*resourcemanagerpb.Project, error)) *MockGCPClientManager_SearchProjectsByLabels_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveIAMRoleBinding provides a mock function for the type MockGCPClientManager
-func (_mock *MockGCPClientManager) RemoveIAMRoleBinding(projectID string, saName string, saProjectID string, roles []string) error {
-	ret := _mock.Called(projectID, saName, saProjectID, roles)
+// StartInstance provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) StartInstance(projectID string, zone string, instanceName string) error {
+	ret := _mock.Called(projectID, zone, instanceName)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveIAMRoleBinding")
+		panic("no return value specified for StartInstance")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, string, string, []string) error); ok {
-		r0 = returnFunc(projectID, saName, saProjectID, roles)
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = returnFunc(projectID, zone, instanceName)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockGCPClientManager_RemoveIAMRoleBinding_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveIAMRoleBinding'
-type MockGCPClientManager_RemoveIAMRoleBinding_Call struct {
+// MockGCPClientManager_StartInstance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartInstance'
+type MockGCPClientManager_StartInstance_Call struct {
 	*mock.Call
 }
 
-// RemoveIAMRoleBinding is a helper method to define mock.On call
+// StartInstance is a helper method to define mock.On call
 //   - projectID string
-//   - saName string
-//   - saProjectID string
-//   - roles []string
-func (_e *MockGCPClientManager_Expecter) RemoveIAMRoleBinding(projectID any, saName any, saProjectID any, roles any) *MockGCPClientManager_RemoveIAMRoleBinding_Call {
-	return &MockGCPClientManager_RemoveIAMRoleBinding_Call{Call: _e.mock.On("RemoveIAMRoleBinding", projectID, saName, saProjectID, roles)}
+//   - zone string
+//   - instanceName string
+func (_e *MockGCPClientManager_Expecter) StartInstance(projectID any, zone any, instanceName any) *MockGCPClientManager_StartInstance_Call {
+	return &MockGCPClientManager_StartInstance_Call{Call: _e.mock.On("StartInstance", projectID, zone, instanceName)}
 }
 
-func (_c *MockGCPClientManager_RemoveIAMRoleBinding_Call) Run(run func(projectID string, saName string, saProjectID string, roles []string)) *MockGCPClientManager_RemoveIAMRoleBinding_Call {
+func (_c *MockGCPClientManager_StartInstance_Call) Run(run func(projectID string, zone string, instanceName string)) *MockGCPClientManager_StartInstance_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
@@ -1612,36 +3871,31 @@ func (_c *MockGCPClientManager_RemoveIAMRoleBinding_Call) Run(run func(projectID
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
-		var arg3 []string
-		if args[3] != nil {
-			arg3 = args[3].([]string)
-		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockGCPClientManager_RemoveIAMRoleBinding_Call) Return(err error) *MockGCPClientManager_RemoveIAMRoleBinding_Call {
+func (_c *MockGCPClientManager_StartInstance_Call) Return(err error) *MockGCPClientManager_StartInstance_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockGCPClientManager_RemoveIAMRoleBinding_Call) RunAndReturn(run func(projectID string, saName string, saProjectID string, roles []string) error) *MockGCPClientManager_RemoveIAMRoleBinding_Call {
+func (_c *MockGCPClientManager_StartInstance_Call) RunAndReturn(run func(projectID string, zone string, instanceName string) error) *MockGCPClientManager_StartInstance_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// StartInstance provides a mock function for the type MockGCPClientManager
-func (_mock *MockGCPClientManager) StartInstance(projectID string, zone string, instanceName string) error {
+// StopInstance provides a mock function for the type MockGCPClientManager
+func (_mock *MockGCPClientManager) StopInstance(projectID string, zone string, instanceName string) error {
 	ret := _mock.Called(projectID, zone, instanceName)
 
 	if len(ret) == 0 {
-		panic("no return value specified for StartInstance")
+		panic("no return value specified for StopInstance")
 	}
 
 	var r0 error
@@ -1653,20 +3907,20 @@ func (_mock *MockGCPClientManager) StartInstance(projectID string, zone string,
 	return r0
 }
 
-// MockGCPClientManager_StartInstance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartInstance'
-type MockGCPClientManager_StartInstance_Call struct {
+// MockGCPClientManager_StopInstance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StopInstance'
+type MockGCPClientManager_StopInstance_Call struct {
 	*mock.Call
 }
 
-// StartInstance is a helper method to define mock.On call
+// StopInstance is a helper method to define mock.On call
 //   - projectID string
 //   - zone string
 //   - instanceName string
-func (_e *MockGCPClientManager_Expecter) StartInstance(projectID any, zone any, instanceName any) *MockGCPClientManager_StartInstance_Call {
-	return &MockGCPClientManager_StartInstance_Call{Call: _e.mock.On("StartInstance", projectID, zone, instanceName)}
+func (_e *MockGCPClientManager_Expecter) StopInstance(projectID any, zone any, instanceName any) *MockGCPClientManager_StopInstance_Call {
+	return &MockGCPClientManager_StopInstance_Call{Call: _e.mock.On("StopInstance", projectID, zone, instanceName)}
 }
 
-func (_c *MockGCPClientManager_StartInstance_Call) Run(run func(projectID string, zone string, instanceName string)) *MockGCPClientManager_StartInstance_Call {
+func (_c *MockGCPClientManager_StopInstance_Call) Run(run func(projectID string, zone string, instanceName string)) *MockGCPClientManager_StopInstance_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
@@ -1689,12 +3943,12 @@ func (_c *MockGCPClientManager_StartInstance_Call) Run(run func(projectID string
 	return _c
 }
 
-func (_c *MockGCPClientManager_StartInstance_Call) Return(err error) *MockGCPClientManager_StartInstance_Call {
+func (_c *MockGCPClientManager_StopInstance_Call) Return(err error) *MockGCPClientManager_StopInstance_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockGCPClientManager_StartInstance_Call) RunAndReturn(run func(projectID string, zone string, instanceName string) error) *MockGCPClientManager_StartInstance_Call {
+func (_c *MockGCPClientManager_StopInstance_Call) RunAndReturn(run func(projectID string, zone string, instanceName string) error) *MockGCPClientManager_StopInstance_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1755,3 +4009,170 @@ func (_c *MockGCPClientManager_UpdateProject_Call) RunAndReturn(run func(project
 	_c.Call.Return(run)
 	return _c
 }
+
+// NewMockImageChecker creates a new instance of MockImageChecker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockImageChecker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockImageChecker {
+	mock := &MockImageChecker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockImageChecker is an autogenerated mock type for the ImageChecker type
+type MockImageChecker struct {
+	mock.Mock
+}
+
+type MockImageChecker_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockImageChecker) EXPECT() *MockImageChecker_Expecter {
+	return &MockImageChecker_Expecter{mock: &_m.Mock}
+}
+
+// ImageExists provides a mock function for the type MockImageChecker
+func (_mock *MockImageChecker) ImageExists(ref string) error {
+	ret := _mock.Called(ref)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImageExists")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(ref)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockImageChecker_ImageExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImageExists'
+type MockImageChecker_ImageExists_Call struct {
+	*mock.Call
+}
+
+// ImageExists is a helper method to define mock.On call
+//   - ref string
+func (_e *MockImageChecker_Expecter) ImageExists(ref any) *MockImageChecker_ImageExists_Call {
+	return &MockImageChecker_ImageExists_Call{Call: _e.mock.On("ImageExists", ref)}
+}
+
+func (_c *MockImageChecker_ImageExists_Call) Run(run func(ref string)) *MockImageChecker_ImageExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockImageChecker_ImageExists_Call) Return(err error) *MockImageChecker_ImageExists_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockImageChecker_ImageExists_Call) RunAndReturn(run func(ref string) error) *MockImageChecker_ImageExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDNSResolver creates a new instance of MockDNSResolver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDNSResolver(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDNSResolver {
+	mock := &MockDNSResolver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDNSResolver is an autogenerated mock type for the DNSResolver type
+type MockDNSResolver struct {
+	mock.Mock
+}
+
+type MockDNSResolver_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDNSResolver) EXPECT() *MockDNSResolver_Expecter {
+	return &MockDNSResolver_Expecter{mock: &_m.Mock}
+}
+
+// LookupHost provides a mock function for the type MockDNSResolver
+func (_mock *MockDNSResolver) LookupHost(host string) ([]string, error) {
+	ret := _mock.Called(host)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LookupHost")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return returnFunc(host)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = returnFunc(host)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(host)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDNSResolver_LookupHost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LookupHost'
+type MockDNSResolver_LookupHost_Call struct {
+	*mock.Call
+}
+
+// LookupHost is a helper method to define mock.On call
+//   - host string
+func (_e *MockDNSResolver_Expecter) LookupHost(host any) *MockDNSResolver_LookupHost_Call {
+	return &MockDNSResolver_LookupHost_Call{Call: _e.mock.On("LookupHost", host)}
+}
+
+func (_c *MockDNSResolver_LookupHost_Call) Run(run func(host string)) *MockDNSResolver_LookupHost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDNSResolver_LookupHost_Call) Return(strings []string, err error) *MockDNSResolver_LookupHost_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockDNSResolver_LookupHost_Call) RunAndReturn(run func(host string) ([]string, error)) *MockDNSResolver_LookupHost_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,224 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Auth modes selecting the credential source for the GCP clients.
+const (
+	// AuthModeADC uses Application Default Credentials, typically a
+	// GOOGLE_APPLICATION_CREDENTIALS key file or gcloud user credentials.
+	AuthModeADC = "adc"
+	// AuthModeOIDC exchanges the OIDC token of the surrounding CI job
+	// (GitHub Actions or GitLab CI) for GCP credentials via workload
+	// identity federation, without any long-lived key files.
+	AuthModeOIDC = "oidc"
+)
+
+// DefaultOIDCTokenRefreshInterval is how often the CI job's OIDC token is
+// re-fetched. GitHub Actions tokens expire after roughly five minutes, so the
+// refresher stays comfortably below that.
+const DefaultOIDCTokenRefreshInterval = 4 * time.Minute
+
+// OIDCAuthConfig describes workload identity federation auth from a CI job.
+type OIDCAuthConfig struct {
+	// Audience is the full resource name of the workload identity provider:
+	// //iam.googleapis.com/projects/<number>/locations/global/workloadIdentityPools/<pool>/providers/<provider>
+	Audience string
+	// ServiceAccount is the email of a service account to impersonate after
+	// the token exchange. Leave empty when the provider grants direct
+	// resource access to the federated identity.
+	ServiceAccount string
+	// Workdir is the directory the OIDC token and the generated credential
+	// configuration are written to.
+	Workdir string
+	// RefreshInterval overrides DefaultOIDCTokenRefreshInterval; only
+	// relevant for token sources that expire (GitHub Actions).
+	RefreshInterval time.Duration
+}
+
+// externalAccountCredentials is the ADC credential configuration for workload
+// identity federation, as documented for the google-auth libraries. The
+// libraries re-read the credential source file on every access token refresh,
+// so keeping that file current is all the refresh handling needed.
+type externalAccountCredentials struct {
+	Type                           string                   `json:"type"`
+	Audience                       string                   `json:"audience"`
+	SubjectTokenType               string                   `json:"subject_token_type"`
+	TokenURL                       string                   `json:"token_url"`
+	ServiceAccountImpersonationURL string                   `json:"service_account_impersonation_url,omitempty"`
+	CredentialSource               externalCredentialSource `json:"credential_source"`
+}
+
+type externalCredentialSource struct {
+	File string `json:"file"`
+}
+
+// SetupOIDCAuth configures workload identity federation auth for all GCP
+// clients created afterwards: it fetches the CI job's OIDC token, writes an
+// external-account ADC configuration referencing it, points
+// GOOGLE_APPLICATION_CREDENTIALS at that configuration, and keeps the token
+// fresh in the background until the context is cancelled. It returns the path
+// of the generated credential configuration.
+func SetupOIDCAuth(ctx context.Context, config OIDCAuthConfig) (string, error) {
+	if config.Audience == "" {
+		return "", fmt.Errorf("OIDC auth requires the workload identity provider audience")
+	}
+
+	fetch, refreshable, err := ciTokenSource(config.Audience)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := fetch(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch CI OIDC token: %w", err)
+	}
+
+	authDir := filepath.Join(config.Workdir, "gcp-oidc")
+	if err := os.MkdirAll(authDir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create OIDC auth directory: %w", err)
+	}
+
+	tokenPath := filepath.Join(authDir, "oidc-token")
+	if err := os.WriteFile(tokenPath, []byte(token), 0600); err != nil {
+		return "", fmt.Errorf("failed to write OIDC token: %w", err)
+	}
+
+	credentials := externalAccountCredentials{
+		Type:             "external_account",
+		Audience:         config.Audience,
+		SubjectTokenType: "urn:ietf:params:oauth:token-type:jwt",
+		TokenURL:         "https://sts.googleapis.com/v1/token",
+		CredentialSource: externalCredentialSource{File: tokenPath},
+	}
+	if config.ServiceAccount != "" {
+		credentials.ServiceAccountImpersonationURL = fmt.Sprintf(
+			"https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/%s:generateAccessToken",
+			config.ServiceAccount,
+		)
+	}
+
+	credentialsJSON, err := json.MarshalIndent(credentials, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal credential configuration: %w", err)
+	}
+	credentialsPath := filepath.Join(authDir, "credentials.json")
+	if err := os.WriteFile(credentialsPath, credentialsJSON, 0600); err != nil {
+		return "", fmt.Errorf("failed to write credential configuration: %w", err)
+	}
+
+	// The GCP client libraries pick up ADC through this variable; pointing it
+	// at the generated configuration makes every client use the federated
+	// credentials without further plumbing.
+	if err := os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", credentialsPath); err != nil {
+		return "", fmt.Errorf("failed to set GOOGLE_APPLICATION_CREDENTIALS: %w", err)
+	}
+
+	if refreshable {
+		interval := config.RefreshInterval
+		if interval <= 0 {
+			interval = DefaultOIDCTokenRefreshInterval
+		}
+		go refreshOIDCToken(ctx, fetch, tokenPath, interval)
+	}
+
+	return credentialsPath, nil
+}
+
+// refreshOIDCToken periodically re-fetches the CI OIDC token and rewrites the
+// credential source file so access token refreshes keep working for jobs that
+// outlive a single token.
+func refreshOIDCToken(ctx context.Context, fetch func(context.Context) (string, error), tokenPath string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			token, err := fetch(ctx)
+			if err != nil {
+				// Transient CI endpoint errors are retried on the next tick;
+				// the previous token stays in place until then.
+				continue
+			}
+			_ = os.WriteFile(tokenPath, []byte(token), 0600)
+		}
+	}
+}
+
+// ciTokenSource detects the surrounding CI system and returns a fetcher for
+// its OIDC token plus whether the token expires and needs refreshing.
+func ciTokenSource(audience string) (fetch func(context.Context) (string, error), refreshable bool, err error) {
+	if requestURL := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_URL"); requestURL != "" {
+		requestToken := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN")
+		if requestToken == "" {
+			return nil, false, fmt.Errorf("ACTIONS_ID_TOKEN_REQUEST_TOKEN is not set; grant the workflow id-token: write permission")
+		}
+		return func(ctx context.Context) (string, error) {
+			return fetchGitHubActionsToken(ctx, requestURL, requestToken, audience)
+		}, true, nil
+	}
+
+	// GitLab CI injects a job-scoped token via the id_tokens keyword; it is
+	// valid for the whole job and needs no refresh.
+	if token := os.Getenv("GITLAB_OIDC_TOKEN"); token != "" {
+		return func(context.Context) (string, error) { return token, nil }, false, nil
+	}
+
+	return nil, false, fmt.Errorf("no CI OIDC token source found: expected ACTIONS_ID_TOKEN_REQUEST_URL (GitHub Actions) or GITLAB_OIDC_TOKEN (GitLab CI) to be set")
+}
+
+// fetchGitHubActionsToken requests an OIDC token for the given audience from
+// the GitHub Actions token endpoint.
+func fetchGitHubActionsToken(ctx context.Context, requestURL, requestToken, audience string) (string, error) {
+	separator := "?"
+	if strings.Contains(requestURL, "?") {
+		separator = "&"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL+separator+"audience="+url.QueryEscape(audience), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+requestToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var tokenResponse struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(body, &tokenResponse); err != nil {
+		return "", fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if tokenResponse.Value == "" {
+		return "", fmt.Errorf("token endpoint returned an empty token")
+	}
+
+	return tokenResponse.Value, nil
+}
@@ -0,0 +1,134 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SetupOIDCAuth", func() {
+	var (
+		workdir  string
+		audience string
+	)
+
+	setEnv := func(key, value string) {
+		previous, hadPrevious := os.LookupEnv(key)
+		Expect(os.Setenv(key, value)).To(Succeed())
+		DeferCleanup(func() {
+			if hadPrevious {
+				Expect(os.Setenv(key, previous)).To(Succeed())
+				return
+			}
+			Expect(os.Unsetenv(key)).To(Succeed())
+		})
+	}
+
+	BeforeEach(func() {
+		workdir = GinkgoT().TempDir()
+		audience = "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/ci/providers/github"
+
+		setEnv("GOOGLE_APPLICATION_CREDENTIALS", "")
+		setEnv("ACTIONS_ID_TOKEN_REQUEST_URL", "")
+		setEnv("ACTIONS_ID_TOKEN_REQUEST_TOKEN", "")
+		setEnv("GITLAB_OIDC_TOKEN", "")
+	})
+
+	It("fails without a CI token source", func() {
+		_, err := gcp.SetupOIDCAuth(context.Background(), gcp.OIDCAuthConfig{
+			Audience: audience,
+			Workdir:  workdir,
+		})
+
+		Expect(err).To(MatchError(ContainSubstring("no CI OIDC token source found")))
+	})
+
+	It("requires the workload identity provider audience", func() {
+		_, err := gcp.SetupOIDCAuth(context.Background(), gcp.OIDCAuthConfig{Workdir: workdir})
+
+		Expect(err).To(MatchError(ContainSubstring("requires the workload identity provider audience")))
+	})
+
+	It("writes a credential configuration from the GitLab CI token", func() {
+		setEnv("GITLAB_OIDC_TOKEN", "gitlab-jwt")
+
+		credentialsPath, err := gcp.SetupOIDCAuth(context.Background(), gcp.OIDCAuthConfig{
+			Audience:       audience,
+			ServiceAccount: "bootstrap@pid.iam.gserviceaccount.com",
+			Workdir:        workdir,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")).To(Equal(credentialsPath))
+
+		data, err := os.ReadFile(credentialsPath)
+		Expect(err).NotTo(HaveOccurred())
+		var credentials map[string]any
+		Expect(json.Unmarshal(data, &credentials)).To(Succeed())
+		Expect(credentials["type"]).To(Equal("external_account"))
+		Expect(credentials["audience"]).To(Equal(audience))
+		Expect(credentials["subject_token_type"]).To(Equal("urn:ietf:params:oauth:token-type:jwt"))
+		Expect(credentials["service_account_impersonation_url"]).To(Equal(
+			"https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/bootstrap@pid.iam.gserviceaccount.com:generateAccessToken"))
+
+		tokenPath := credentials["credential_source"].(map[string]any)["file"].(string)
+		Expect(os.ReadFile(tokenPath)).To(BeEquivalentTo("gitlab-jwt"))
+	})
+
+	It("fetches and refreshes the GitHub Actions token", func() {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Header.Get("Authorization")).To(Equal("Bearer request-token"))
+			Expect(r.URL.Query().Get("audience")).To(Equal(audience))
+			fmt.Fprintf(w, `{"value":"github-jwt-%d"}`, requests.Add(1))
+		}))
+		DeferCleanup(server.Close)
+
+		setEnv("ACTIONS_ID_TOKEN_REQUEST_URL", server.URL)
+		setEnv("ACTIONS_ID_TOKEN_REQUEST_TOKEN", "request-token")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		_, err := gcp.SetupOIDCAuth(ctx, gcp.OIDCAuthConfig{
+			Audience:        audience,
+			Workdir:         workdir,
+			RefreshInterval: 10 * time.Millisecond,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		tokenPath := filepath.Join(workdir, "gcp-oidc", "oidc-token")
+		Expect(os.ReadFile(tokenPath)).To(BeEquivalentTo("github-jwt-1"))
+
+		// The refresher rewrites the credential source file with fresh tokens.
+		Eventually(func() string {
+			token, _ := os.ReadFile(tokenPath)
+			return string(token)
+		}).ShouldNot(Equal("github-jwt-1"))
+	})
+
+	It("rejects the GitHub Actions source without a request token", func() {
+		setEnv("ACTIONS_ID_TOKEN_REQUEST_URL", "https://token.actions.example.com")
+
+		_, err := gcp.SetupOIDCAuth(context.Background(), gcp.OIDCAuthConfig{
+			Audience: audience,
+			Workdir:  workdir,
+		})
+
+		Expect(err).To(MatchError(ContainSubstring("ACTIONS_ID_TOKEN_REQUEST_TOKEN is not set")))
+	})
+})
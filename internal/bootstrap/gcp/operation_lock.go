@@ -0,0 +1,160 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// OperationLockPath is the lock file on the ops node guarding against
+	// concurrent operations on the same environment.
+	OperationLockPath = "/var/lock/oms-operation.lock"
+	// OperationLockLabel mirrors the lock holder as a project label, so the
+	// running operation is visible to operators without SSH access.
+	OperationLockLabel = "oms-operation-lock"
+)
+
+// OperationLock records who is running which operation against the
+// environment. It is stored as JSON in the lock file on the ops node.
+type OperationLock struct {
+	Operation  string    `json:"operation"`
+	Holder     string    `json:"holder"`
+	AcquiredAt time.Time `json:"acquired_at"`
+}
+
+// AcquireOperationLock takes the environment-wide operation lock before a
+// mutating operation: a lock file created atomically on the ops node, plus a
+// project label recording the holder. When another operation already holds
+// the lock, the error names its holder so operators can coordinate; a stale
+// lock of a crashed operation has to be removed manually.
+func (b *GCPBootstrapper) AcquireOperationLock(operation string) error {
+	ops := b.opsNode()
+
+	lock := OperationLock{
+		Operation:  operation,
+		Holder:     lockHolder(),
+		AcquiredAt: b.Time.Now().UTC(),
+	}
+	data, err := json.Marshal(lock)
+	if err != nil {
+		return fmt.Errorf("failed to marshal operation lock: %w", err)
+	}
+
+	// noclobber (set -C) makes the redirection fail when the lock file
+	// already exists, so checking and creating the lock is a single atomic
+	// operation on the ops node.
+	command := fmt.Sprintf("sh -c 'set -C; echo %q > %s'", string(data), OperationLockPath)
+	if err := b.NodeClient.RunCommand(ops, "root", command); err != nil {
+		holder, readErr := b.readOperationLock()
+		if readErr != nil {
+			return fmt.Errorf("failed to acquire operation lock on %s: %w", ops.GetName(), err)
+		}
+		return fmt.Errorf("operation %q started by %s at %s is still running; remove %s on %s if it is not",
+			holder.Operation, holder.Holder, holder.AcquiredAt.Format(time.RFC3339), OperationLockPath, ops.GetName())
+	}
+
+	// The lock file is authoritative; the label is informational and must
+	// not fail the operation, e.g. for operators without project permissions.
+	if err := b.setOperationLockLabel(lock); err != nil {
+		b.stlog.Logf("Warning: failed to record operation lock label: %v", err)
+	}
+
+	return nil
+}
+
+// ReleaseOperationLock releases the operation lock taken by
+// AcquireOperationLock. Releasing an already released lock is a no-op so it
+// is safe to defer.
+func (b *GCPBootstrapper) ReleaseOperationLock() error {
+	ops := b.opsNode()
+
+	command := fmt.Sprintf("rm -f %s", OperationLockPath)
+	if err := b.NodeClient.RunCommand(ops, "root", command); err != nil {
+		return fmt.Errorf("failed to remove operation lock on %s: %w", ops.GetName(), err)
+	}
+
+	if err := b.removeOperationLockLabel(); err != nil {
+		b.stlog.Logf("Warning: failed to remove operation lock label: %v", err)
+	}
+
+	return nil
+}
+
+// readOperationLock downloads and parses the lock file from the ops node to
+// report the current holder.
+func (b *GCPBootstrapper) readOperationLock() (OperationLock, error) {
+	ops := b.opsNode()
+
+	tmpDir, err := os.MkdirTemp("", "oms-operation-lock-")
+	if err != nil {
+		return OperationLock{}, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	localPath := filepath.Join(tmpDir, "oms-operation.lock")
+	if err := b.NodeClient.DownloadFile(ops, OperationLockPath, localPath); err != nil {
+		return OperationLock{}, fmt.Errorf("failed to download operation lock from %s: %w", ops.GetName(), err)
+	}
+
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		return OperationLock{}, fmt.Errorf("failed to read operation lock: %w", err)
+	}
+
+	var lock OperationLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return OperationLock{}, fmt.Errorf("failed to parse operation lock: %w", err)
+	}
+	return lock, nil
+}
+
+func (b *GCPBootstrapper) setOperationLockLabel(lock OperationLock) error {
+	labels, err := b.GCPClient.GetProjectLabels(b.Env.ProjectID)
+	if err != nil {
+		return err
+	}
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	label, err := createLabel(fmt.Sprintf("%s-by-%s", lock.Operation, lock.Holder))
+	if err != nil {
+		return fmt.Errorf("failed to create lock label: %w", err)
+	}
+	labels[OperationLockLabel] = label
+
+	return b.GCPClient.UpdateProject(b.Env.ProjectID, labels)
+}
+
+func (b *GCPBootstrapper) removeOperationLockLabel() error {
+	labels, err := b.GCPClient.GetProjectLabels(b.Env.ProjectID)
+	if err != nil {
+		return err
+	}
+	if _, ok := labels[OperationLockLabel]; !ok {
+		return nil
+	}
+	delete(labels, OperationLockLabel)
+
+	return b.GCPClient.UpdateProject(b.Env.ProjectID, labels)
+}
+
+// lockHolder identifies the operator as user@host for the lock holder info.
+func lockHolder() string {
+	username := "unknown"
+	if current, err := user.Current(); err == nil {
+		username = current.Username
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s@%s", username, hostname)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/codesphere-cloud/oms/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("Operation lock", func() {
+	var (
+		nodeClient *node.MockNodeClient
+		gc         *gcp.MockGCPClientManager
+		csEnv      *gcp.CodesphereEnvironment
+
+		bs *gcp.GCPBootstrapper
+	)
+
+	BeforeEach(func() {
+		nodeClient = node.NewMockNodeClient(GinkgoT())
+		gc = gcp.NewMockGCPClientManager(GinkgoT())
+
+		csEnv = &gcp.CodesphereEnvironment{
+			ProjectID: "pid",
+			Jumpbox:   fakeNode("jumpbox", nodeClient),
+		}
+
+		var err error
+		bs, err = gcp.NewGCPBootstrapper(
+			context.Background(),
+			nil,
+			bootstrap.NewStepLogger(false),
+			csEnv,
+			nil,
+			gc,
+			nil,
+			nodeClient,
+			nil,
+			util.NewFakeTime(),
+			nil,
+			nil,
+		)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("AcquireOperationLock", func() {
+		It("creates the lock file atomically and records the holder label", func() {
+			nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", mock.MatchedBy(func(cmd string) bool {
+				return strings.Contains(cmd, "set -C") && strings.Contains(cmd, gcp.OperationLockPath)
+			})).Return(nil)
+			gc.EXPECT().GetProjectLabels("pid").Return(map[string]string{"oms-managed": "true"}, nil)
+			gc.EXPECT().UpdateProject("pid", mock.MatchedBy(func(labels map[string]string) bool {
+				return labels["oms-managed"] == "true" &&
+					strings.HasPrefix(labels[gcp.OperationLockLabel], "upgrade-by-")
+			})).Return(nil)
+
+			Expect(bs.AcquireOperationLock("upgrade")).To(Succeed())
+		})
+
+		It("reports the current holder when the lock is already taken", func() {
+			nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", mock.Anything).
+				Return(fmt.Errorf("cannot create /var/lock/oms-operation.lock: File exists"))
+			nodeClient.EXPECT().DownloadFile(mock.MatchedBy(jumpboxMatcher), gcp.OperationLockPath, mock.Anything).
+				RunAndReturn(func(_ *node.Node, _ string, dst string) error {
+					lock := `{"operation":"scale","holder":"alice@ops","acquired_at":"2024-01-01T10:00:00Z"}`
+					return os.WriteFile(dst, []byte(lock), 0o600)
+				})
+
+			err := bs.AcquireOperationLock("upgrade")
+			Expect(err).To(MatchError(ContainSubstring(`operation "scale" started by alice@ops`)))
+			Expect(err).To(MatchError(ContainSubstring(gcp.OperationLockPath)))
+		})
+
+		It("keeps the lock when only the holder label cannot be recorded", func() {
+			nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", mock.Anything).Return(nil)
+			gc.EXPECT().GetProjectLabels("pid").Return(nil, fmt.Errorf("permission denied"))
+
+			Expect(bs.AcquireOperationLock("upgrade")).To(Succeed())
+		})
+	})
+
+	Describe("ReleaseOperationLock", func() {
+		It("removes the lock file and the holder label", func() {
+			nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", fmt.Sprintf("rm -f %s", gcp.OperationLockPath)).Return(nil)
+			gc.EXPECT().GetProjectLabels("pid").Return(map[string]string{
+				"oms-managed":          "true",
+				gcp.OperationLockLabel: "upgrade-by-alice_ops",
+			}, nil)
+			gc.EXPECT().UpdateProject("pid", map[string]string{"oms-managed": "true"}).Return(nil)
+
+			Expect(bs.ReleaseOperationLock()).To(Succeed())
+		})
+
+		It("leaves the labels untouched when no lock label is set", func() {
+			nodeClient.EXPECT().RunCommand(mock.MatchedBy(jumpboxMatcher), "root", mock.Anything).Return(nil)
+			gc.EXPECT().GetProjectLabels("pid").Return(map[string]string{"oms-managed": "true"}, nil)
+
+			Expect(bs.ReleaseOperationLock()).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,57 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+)
+
+// EnsurePartialResourcesHandled detects labeled compute resources left behind
+// by a previous interrupted run and reports them. By default they are adopted
+// and the following Ensure steps reuse them; with --clean-partial-resources
+// they are deleted first so the run recreates them from scratch.
+func (b *GCPBootstrapper) EnsurePartialResourcesHandled() error {
+	labels := map[string]string{OMSManagedLabel: "true"}
+
+	instances, err := b.GCPClient.ListInstancesByLabels(b.Env.ProjectID, b.Env.Zone, labels)
+	if err != nil {
+		return fmt.Errorf("failed to list existing instances: %w", err)
+	}
+
+	addresses, err := b.GCPClient.ListAddressesByLabels(b.Env.ProjectID, b.Env.Region, labels)
+	if err != nil {
+		return fmt.Errorf("failed to list existing addresses: %w", err)
+	}
+
+	if len(instances) == 0 && len(addresses) == 0 {
+		return nil
+	}
+
+	for _, instance := range instances {
+		b.stlog.Logf("Found instance %s from a previous run", instance.GetName())
+	}
+	for _, address := range addresses {
+		b.stlog.Logf("Found address %s from a previous run", address.GetName())
+	}
+
+	if !b.Env.CleanPartialResources {
+		b.stlog.Logf("Adopting %d existing resource(s); use --clean-partial-resources to recreate them", len(instances)+len(addresses))
+		return nil
+	}
+
+	for _, instance := range instances {
+		if err := b.GCPClient.DeleteInstance(b.Env.ProjectID, b.Env.Zone, instance.GetName()); err != nil {
+			return fmt.Errorf("failed to delete instance %s: %w", instance.GetName(), err)
+		}
+		b.stlog.Logf("Deleted instance %s", instance.GetName())
+	}
+	for _, address := range addresses {
+		if err := b.GCPClient.DeleteAddress(b.Env.ProjectID, b.Env.Region, address.GetName()); err != nil {
+			return fmt.Errorf("failed to delete address %s: %w", address.GetName(), err)
+		}
+		b.stlog.Logf("Deleted address %s", address.GetName())
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+)
+
+// PGBackupJobName is the scheduled job that uploads a PostgreSQL dump to the
+// backup bucket, declared via the --pg-backups bootstrap flag.
+const PGBackupJobName = "pg-backup"
+
+// PGBackupBucketName returns the GCS bucket holding the PostgreSQL backups of
+// the environment.
+func PGBackupBucketName(projectID string) string {
+	return fmt.Sprintf("%s-pg-backups", projectID)
+}
+
+// PGBackupJob builds the scheduled job that backs up postgres to the bucket.
+func PGBackupJob(schedule, bucket string) ScheduledJob {
+	return ScheduledJob{
+		Name:     PGBackupJobName,
+		Schedule: schedule,
+		Command:  fmt.Sprintf("oms beta backup postgres --bucket %s", bucket),
+	}
+}
+
+// declarePGBackupJob adds the postgres backup job to the scheduled jobs when
+// backups are enabled. Re-running bootstrap updates an already declared job
+// instead of duplicating it.
+func (b *GCPBootstrapper) declarePGBackupJob() error {
+	if !b.Env.PGBackups {
+		return nil
+	}
+	if b.Env.PostgresMode == PostgresModeCloudSQL {
+		return fmt.Errorf("--pg-backups requires a postgres VM; Cloud SQL instances come with automated backups instead")
+	}
+
+	job := PGBackupJob(b.Env.PGBackupSchedule, PGBackupBucketName(b.Env.ProjectID))
+	for i, existing := range b.Env.ScheduledJobs {
+		if existing.Name == job.Name {
+			b.Env.ScheduledJobs[i] = job
+			return nil
+		}
+	}
+	b.Env.ScheduledJobs = append(b.Env.ScheduledJobs, job)
+	return nil
+}
+
+// EnsurePGBackups creates the GCS backup bucket with its retention lifecycle
+// policy and grants the instance service account write access, so the
+// scheduled backup job on the jumpbox can upload dumps.
+func (b *GCPBootstrapper) EnsurePGBackups() error {
+	bucket := PGBackupBucketName(b.Env.ProjectID)
+	err := b.GCPClient.EnsureGCSBucket(b.Env.ProjectID, bucket, b.Env.Region, b.resourceLabels(), b.Env.PGBackupRetentionDays)
+	if err != nil {
+		return fmt.Errorf("failed to ensure backup bucket %s: %w", bucket, err)
+	}
+
+	member := fmt.Sprintf("serviceAccount:cloud-controller@%s.iam.gserviceaccount.com", b.Env.ProjectID)
+	err = b.GCPClient.GrantBucketIAMRole(bucket, member, "roles/storage.objectAdmin")
+	if err != nil {
+		return fmt.Errorf("failed to grant backup bucket access: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/cloudflare"
+	"github.com/codesphere-cloud/oms/internal/env"
+	"github.com/codesphere-cloud/oms/internal/github"
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/codesphere-cloud/oms/internal/portal"
+	"github.com/codesphere-cloud/oms/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Postgres backups", func() {
+	var (
+		gc    *gcp.MockGCPClientManager
+		csEnv *gcp.CodesphereEnvironment
+
+		bs *gcp.GCPBootstrapper
+	)
+
+	JustBeforeEach(func() {
+		var err error
+		bs, err = gcp.NewGCPBootstrapper(
+			context.Background(),
+			env.NewEnv(),
+			bootstrap.NewStepLogger(false),
+			csEnv,
+			installer.NewMockInstallConfigManager(GinkgoT()),
+			gc,
+			util.NewMockFileIO(GinkgoT()),
+			node.NewMockNodeClient(GinkgoT()),
+			portal.NewMockPortal(GinkgoT()),
+			util.NewFakeTime(),
+			github.NewMockGitHubClient(GinkgoT()),
+			cloudflare.NewMockCloudflareClient(GinkgoT()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	BeforeEach(func() {
+		gc = gcp.NewMockGCPClientManager(GinkgoT())
+
+		csEnv = &gcp.CodesphereEnvironment{
+			ProjectID:             "fake-project",
+			Region:                "europe-west4",
+			PGBackups:             true,
+			PGBackupSchedule:      "daily",
+			PGBackupRetentionDays: 30,
+		}
+	})
+
+	Describe("PGBackupJob", func() {
+		It("uploads to the environment's backup bucket via the beta backup command", func() {
+			job := gcp.PGBackupJob("daily", gcp.PGBackupBucketName("fake-project"))
+			Expect(job.Name).To(Equal(gcp.PGBackupJobName))
+			Expect(job.Schedule).To(Equal("daily"))
+			Expect(job.Command).To(Equal("oms beta backup postgres --bucket fake-project-pg-backups"))
+		})
+	})
+
+	Describe("EnsurePGBackups", func() {
+		It("creates the bucket and grants the instance service account write access", func() {
+			gc.EXPECT().EnsureGCSBucket("fake-project", "fake-project-pg-backups", "europe-west4", map[string]string{gcp.OMSManagedLabel: "true"}, int64(30)).Return(nil)
+			gc.EXPECT().GrantBucketIAMRole("fake-project-pg-backups", "serviceAccount:cloud-controller@fake-project.iam.gserviceaccount.com", "roles/storage.objectAdmin").Return(nil)
+
+			Expect(bs.EnsurePGBackups()).To(Succeed())
+		})
+
+		It("fails when the bucket cannot be created", func() {
+			gc.EXPECT().EnsureGCSBucket("fake-project", "fake-project-pg-backups", "europe-west4", map[string]string{gcp.OMSManagedLabel: "true"}, int64(30)).Return(fmt.Errorf("denied"))
+
+			err := bs.EnsurePGBackups()
+			Expect(err).To(MatchError(ContainSubstring("failed to ensure backup bucket fake-project-pg-backups")))
+		})
+	})
+})
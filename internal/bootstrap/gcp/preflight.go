@@ -0,0 +1,225 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/env"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+)
+
+// preflightCheck is a single connectivity check executed on the jumpbox.
+type preflightCheck struct {
+	Name    string
+	Command string
+}
+
+// registryHost returns the host of the container registry the installation
+// will pull images from.
+func (b *GCPBootstrapper) registryHost() string {
+	if b.Env.RegistryType == RegistryTypeArtifactRegistry {
+		return fmt.Sprintf("%s-docker.pkg.dev", b.Env.Region)
+	}
+	return "ghcr.io"
+}
+
+// connectivityPreflightChecks builds the checks for the endpoints the remote
+// installer depends on, based on the configured registry and ACME issuer.
+func (b *GCPBootstrapper) connectivityPreflightChecks() []preflightCheck {
+	portalAPI := env.NewEnv().GetOmsPortalApi()
+
+	registryHost := b.registryHost()
+
+	acmeServer := "https://acme-v02.api.letsencrypt.org/directory"
+	if b.Env.ACMEStaging {
+		acmeServer = "https://acme-staging-v02.api.letsencrypt.org/directory"
+	}
+	if b.Env.GoogleACMEIssuer {
+		acmeServer = "https://dv.acme-v02.api.pki.goog/directory"
+	}
+
+	checks := []preflightCheck{
+		{
+			Name:    "DNS resolution",
+			Command: fmt.Sprintf("getent hosts %s >/dev/null", registryHost),
+		},
+		{
+			Name:    "NTP synchronization",
+			Command: `test "$(timedatectl show -p NTPSynchronized --value)" = yes`,
+		},
+		{
+			// The registry answers unauthenticated requests with 401, so only
+			// connection-level failures are treated as unreachable.
+			Name:    "Registry reachability",
+			Command: fmt.Sprintf("curl -sS --max-time 10 -o /dev/null https://%s/v2/", registryHost),
+		},
+		{
+			Name:    "ACME endpoint reachability",
+			Command: fmt.Sprintf("curl -fsS --max-time 10 -o /dev/null %s", acmeServer),
+		},
+	}
+
+	if portalURL, err := url.Parse(portalAPI); err == nil && portalURL.Host != "" {
+		checks = append(checks, preflightCheck{
+			Name:    "Portal reachability",
+			Command: fmt.Sprintf("curl -sS --max-time 10 -o /dev/null %s", portalAPI),
+		})
+	}
+
+	return checks
+}
+
+// EnsureConnectivityPreflight verifies from inside the VPC that the endpoints
+// the remote installer depends on (portal, registry, ACME, DNS, NTP) are
+// reachable. The checks run on the jumpbox, or on the first control plane
+// node in jumpbox-less mode. All checks run before reporting so a failure
+// lists every unreachable endpoint at once instead of failing deep inside the
+// remote installer.
+func (b *GCPBootstrapper) EnsureConnectivityPreflight() error {
+	var failures []string
+	for _, check := range b.connectivityPreflightChecks() {
+		if err := b.opsNode().RunSSHCommand("root", check.Command); err != nil {
+			failures = append(failures, fmt.Sprintf("%s (`%s`): %v", check.Name, check.Command, err))
+			continue
+		}
+		b.stlog.Logf("Preflight check passed: %s", check.Name)
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("connectivity preflight failed:\n  - %s", strings.Join(failures, "\n  - "))
+	}
+
+	return nil
+}
+
+// EnsureNodesHealthy runs the node readiness probes on every node and fails
+// with the collected hints when any node is not ready, so problems surface
+// here instead of deep inside the Codesphere install.
+func (b *GCPBootstrapper) EnsureNodesHealthy() error {
+	allNodes := b.allNodes()
+
+	checker := node.NewHealthChecker(b.registryHost())
+	reports := make([]node.HealthReport, len(allNodes))
+	tasks := make([]bootstrap.NodeTask, 0, len(allNodes))
+	for i, n := range allNodes {
+		tasks = append(tasks, bootstrap.NodeTask{Name: n.GetName(), Run: func() error {
+			reports[i] = checker.CheckNode(n)
+			return nil
+		}})
+	}
+	// The tasks only collect reports and never fail; failures are aggregated
+	// below so every unhealthy node is listed at once.
+	_ = bootstrap.RunNodeTasks(b.Env.NodeParallelism, tasks)
+
+	var failures []string
+	for _, report := range reports {
+		if report.Healthy() {
+			b.stlog.Logf("Node %s passed all health checks", report.Node)
+			continue
+		}
+		for _, failure := range report.Failures() {
+			failures = append(failures, fmt.Sprintf("%s: %s failed: %s", report.Node, failure.Name, failure.Hint))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("node health check failed:\n  - %s", strings.Join(failures, "\n  - "))
+	}
+
+	return nil
+}
+
+// ghcrPackage is a container package on ghcr.io, identified by the owning
+// organization and the package name (which may contain path segments).
+type ghcrPackage struct {
+	Org  string
+	Name string
+}
+
+// ghcrPackages collects the ghcr.io packages behind the images the
+// installation will pull: the deploy-config image set plus any configured
+// pre-pull images. Tags and digests are stripped, duplicates removed.
+func (b *GCPBootstrapper) ghcrPackages() []ghcrPackage {
+	packages := []ghcrPackage{}
+	seen := map[ghcrPackage]bool{}
+
+	images := append(b.deployImageSet(), b.Env.PrePullImages...)
+	for _, image := range images {
+		pkg, ok := parseGHCRImage(image)
+		if !ok || seen[pkg] {
+			continue
+		}
+		seen[pkg] = true
+		packages = append(packages, pkg)
+	}
+
+	return packages
+}
+
+// parseGHCRImage splits a ghcr.io image reference into organization and
+// package name. Images hosted elsewhere are reported as not ok.
+func parseGHCRImage(image string) (ghcrPackage, bool) {
+	rest, found := strings.CutPrefix(image, "ghcr.io/")
+	if !found {
+		return ghcrPackage{}, false
+	}
+	if digest := strings.Index(rest, "@"); digest >= 0 {
+		rest = rest[:digest]
+	}
+	if tag := strings.LastIndex(rest, ":"); tag > strings.LastIndex(rest, "/") {
+		rest = rest[:tag]
+	}
+	org, name, found := strings.Cut(rest, "/")
+	if !found || org == "" || name == "" {
+		return ghcrPackage{}, false
+	}
+	return ghcrPackage{Org: org, Name: name}, true
+}
+
+// EnsureGitHubRegistryPreflight verifies against the GitHub API that the
+// configured PAT can pull the required images from ghcr.io: classic tokens
+// must carry the read:packages scope, and every required package must be
+// readable. This surfaces missing grants here instead of as image pull
+// failures on the cluster an hour later.
+func (b *GCPBootstrapper) EnsureGitHubRegistryPreflight() error {
+	if b.Env.GitHubPAT == "" {
+		return fmt.Errorf("GitHub PAT is not set")
+	}
+
+	scopes, err := b.GitHubClient.GetTokenScopes(b.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query GitHub PAT scopes: %w", err)
+	}
+
+	var failures []string
+	switch {
+	case len(scopes) == 0:
+		// Fine-grained tokens report no scopes; package access is still
+		// verified per package below.
+		b.stlog.Logf("GitHub PAT reports no scopes (fine-grained token), skipping scope check")
+	case slices.Contains(scopes, "read:packages") || slices.Contains(scopes, "write:packages"):
+		b.stlog.Logf("GitHub PAT has the read:packages scope")
+	default:
+		failures = append(failures, fmt.Sprintf("PAT is missing the read:packages scope (has: %s)", strings.Join(scopes, ", ")))
+	}
+
+	for _, pkg := range b.ghcrPackages() {
+		if err := b.GitHubClient.GetOrgContainerPackage(b.ctx, pkg.Org, pkg.Name); err != nil {
+			failures = append(failures, fmt.Sprintf("package ghcr.io/%s/%s is not readable: %v", pkg.Org, pkg.Name, err))
+			continue
+		}
+		b.stlog.Logf("Preflight check passed: read access to ghcr.io/%s/%s", pkg.Org, pkg.Name)
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("GitHub registry preflight failed:\n  - %s", strings.Join(failures, "\n  - "))
+	}
+
+	return nil
+}
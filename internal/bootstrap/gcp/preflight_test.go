@@ -0,0 +1,279 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/cloudflare"
+	"github.com/codesphere-cloud/oms/internal/env"
+	"github.com/codesphere-cloud/oms/internal/github"
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/codesphere-cloud/oms/internal/portal"
+	"github.com/codesphere-cloud/oms/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("Connectivity preflight", func() {
+	var (
+		nodeClient *node.MockNodeClient
+		csEnv      *gcp.CodesphereEnvironment
+
+		bs *gcp.GCPBootstrapper
+
+		commandsMu sync.Mutex
+		commands   []string
+	)
+
+	// recordCommands captures every command run on the jumpbox.
+	recordCommands := func(ret error) {
+		nodeClient.EXPECT().RunCommand(mock.Anything, "root", mock.Anything).RunAndReturn(
+			func(n *node.Node, username, command string) error {
+				commandsMu.Lock()
+				defer commandsMu.Unlock()
+				commands = append(commands, command)
+				return ret
+			})
+	}
+
+	JustBeforeEach(func() {
+		var err error
+		bs, err = gcp.NewGCPBootstrapper(
+			context.Background(),
+			env.NewEnv(),
+			bootstrap.NewStepLogger(false),
+			csEnv,
+			installer.NewMockInstallConfigManager(GinkgoT()),
+			gcp.NewMockGCPClientManager(GinkgoT()),
+			util.NewMockFileIO(GinkgoT()),
+			nodeClient,
+			portal.NewMockPortal(GinkgoT()),
+			util.NewFakeTime(),
+			github.NewMockGitHubClient(GinkgoT()),
+			cloudflare.NewMockCloudflareClient(GinkgoT()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	BeforeEach(func() {
+		nodeClient = node.NewMockNodeClient(GinkgoT())
+		commands = nil
+
+		csEnv = &gcp.CodesphereEnvironment{
+			Region:       "europe-west3",
+			RegistryType: gcp.RegistryTypeGitHub,
+			Jumpbox:      fakeNode("jumpbox", nodeClient),
+		}
+	})
+
+	It("passes when all checks succeed", func() {
+		recordCommands(nil)
+
+		Expect(bs.EnsureConnectivityPreflight()).To(Succeed())
+
+		Expect(commands).To(ContainElement(ContainSubstring("getent hosts ghcr.io")))
+		Expect(commands).To(ContainElement(ContainSubstring("timedatectl show -p NTPSynchronized")))
+		Expect(commands).To(ContainElement(ContainSubstring("https://ghcr.io/v2/")))
+		Expect(commands).To(ContainElement(ContainSubstring("https://acme-v02.api.letsencrypt.org/directory")))
+		Expect(commands).To(ContainElement(ContainSubstring("oms-portal.codesphere.com")))
+	})
+
+	It("reports every failed check in one error", func() {
+		recordCommands(fmt.Errorf("exit status 1"))
+
+		err := bs.EnsureConnectivityPreflight()
+		Expect(err).To(MatchError(ContainSubstring("connectivity preflight failed")))
+		Expect(err).To(MatchError(ContainSubstring("DNS resolution")))
+		Expect(err).To(MatchError(ContainSubstring("NTP synchronization")))
+		Expect(err).To(MatchError(ContainSubstring("Registry reachability")))
+		Expect(err).To(MatchError(ContainSubstring("ACME endpoint reachability")))
+		Expect(err).To(MatchError(ContainSubstring("Portal reachability")))
+	})
+
+	Context("When using the artifact registry", func() {
+		BeforeEach(func() {
+			csEnv.RegistryType = gcp.RegistryTypeArtifactRegistry
+		})
+
+		It("checks the regional registry endpoint", func() {
+			recordCommands(nil)
+
+			Expect(bs.EnsureConnectivityPreflight()).To(Succeed())
+
+			Expect(commands).To(ContainElement(ContainSubstring("https://europe-west3-docker.pkg.dev/v2/")))
+		})
+	})
+
+	Context("When using the staging ACME endpoint", func() {
+		BeforeEach(func() {
+			csEnv.ACMEStaging = true
+		})
+
+		It("checks the staging directory", func() {
+			recordCommands(nil)
+
+			Expect(bs.EnsureConnectivityPreflight()).To(Succeed())
+
+			Expect(commands).To(ContainElement(ContainSubstring("https://acme-staging-v02.api.letsencrypt.org/directory")))
+		})
+	})
+
+	Describe("EnsureNodesHealthy", func() {
+		BeforeEach(func() {
+			csEnv.ControlPlaneNodes = []*node.Node{fakeNode("k0s-1", nodeClient)}
+			csEnv.PostgreSQLNode = fakeNode("postgres", nodeClient)
+			csEnv.CephNodes = []*node.Node{fakeNode("ceph-1", nodeClient)}
+		})
+
+		It("passes when all probes succeed on all nodes", func() {
+			recordCommands(nil)
+
+			Expect(bs.EnsureNodesHealthy()).To(Succeed())
+
+			Expect(commands).To(ContainElement(ContainSubstring("df --output=pcent /")))
+			Expect(commands).To(ContainElement(ContainSubstring("sysctl -n vm.max_map_count")))
+			Expect(commands).To(ContainElement(ContainSubstring("timedatectl show -p NTPSynchronized")))
+			Expect(commands).To(ContainElement(ContainSubstring("getent hosts ghcr.io")))
+			Expect(commands).To(ContainElement(ContainSubstring("https://ghcr.io/v2/")))
+			// 5 probes on each of the 4 nodes
+			Expect(commands).To(HaveLen(20))
+		})
+
+		It("reports every failing probe on every node with a hint", func() {
+			recordCommands(fmt.Errorf("exit status 1"))
+
+			err := bs.EnsureNodesHealthy()
+			Expect(err).To(MatchError(ContainSubstring("node health check failed")))
+			Expect(err).To(MatchError(ContainSubstring("jumpbox: disk capacity failed")))
+			Expect(err).To(MatchError(ContainSubstring("postgres: kernel parameters failed")))
+			Expect(err).To(MatchError(ContainSubstring("ceph-1: time synchronization failed")))
+			Expect(err).To(MatchError(ContainSubstring("k0s-1: outbound connectivity failed")))
+			Expect(err).To(MatchError(ContainSubstring("check NAT and firewall egress rules")))
+		})
+	})
+
+	Context("When using the Google ACME issuer", func() {
+		BeforeEach(func() {
+			csEnv.GoogleACMEIssuer = true
+		})
+
+		It("checks the Google ACME directory", func() {
+			recordCommands(nil)
+
+			Expect(bs.EnsureConnectivityPreflight()).To(Succeed())
+
+			acmeChecks := 0
+			for _, cmd := range commands {
+				if strings.Contains(cmd, "acme") || strings.Contains(cmd, "pki.goog") {
+					acmeChecks++
+				}
+			}
+			Expect(acmeChecks).To(Equal(1))
+			Expect(commands).To(ContainElement(ContainSubstring("https://dv.acme-v02.api.pki.goog/directory")))
+		})
+	})
+})
+
+var _ = Describe("GitHub registry preflight", func() {
+	var (
+		githubClient *github.MockGitHubClient
+		csEnv        *gcp.CodesphereEnvironment
+
+		bs *gcp.GCPBootstrapper
+	)
+
+	JustBeforeEach(func() {
+		var err error
+		bs, err = gcp.NewGCPBootstrapper(
+			context.Background(),
+			env.NewEnv(),
+			bootstrap.NewStepLogger(false),
+			csEnv,
+			installer.NewMockInstallConfigManager(GinkgoT()),
+			gcp.NewMockGCPClientManager(GinkgoT()),
+			util.NewMockFileIO(GinkgoT()),
+			node.NewMockNodeClient(GinkgoT()),
+			portal.NewMockPortal(GinkgoT()),
+			util.NewFakeTime(),
+			githubClient,
+			cloudflare.NewMockCloudflareClient(GinkgoT()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	BeforeEach(func() {
+		githubClient = github.NewMockGitHubClient(GinkgoT())
+
+		csEnv = &gcp.CodesphereEnvironment{
+			RegistryType: gcp.RegistryTypeGitHub,
+			GitHubPAT:    "ghp_token",
+			InstallConfig: &files.RootConfig{
+				Codesphere: files.CodesphereConfig{
+					DeployConfig: files.DeployConfig{
+						Images: map[string]files.ImageConfig{
+							"ubuntu-24.04": {Flavors: map[string]files.FlavorConfig{
+								"default": {Image: files.ImageRef{ImageName: "ghcr.io/codesphere-cloud/workspace-agent:24.04"}},
+								"other":   {Image: files.ImageRef{ImageName: "docker.io/library/ubuntu:24.04"}},
+							}},
+						},
+					},
+				},
+			},
+			PrePullImages: []string{
+				"ghcr.io/codesphere-cloud/codesphere/base-image@sha256:abc",
+				"ghcr.io/codesphere-cloud/workspace-agent:22.04",
+			},
+		}
+	})
+
+	It("passes with a classic token that can read every package", func() {
+		githubClient.EXPECT().GetTokenScopes(mock.Anything).Return([]string{"repo", "read:packages"}, nil).Once()
+		githubClient.EXPECT().GetOrgContainerPackage(mock.Anything, "codesphere-cloud", "workspace-agent").Return(nil).Once()
+		githubClient.EXPECT().GetOrgContainerPackage(mock.Anything, "codesphere-cloud", "codesphere/base-image").Return(nil).Once()
+
+		Expect(bs.EnsureGitHubRegistryPreflight()).To(Succeed())
+	})
+
+	It("skips the scope check for fine-grained tokens", func() {
+		githubClient.EXPECT().GetTokenScopes(mock.Anything).Return(nil, nil).Once()
+		githubClient.EXPECT().GetOrgContainerPackage(mock.Anything, "codesphere-cloud", "workspace-agent").Return(nil).Once()
+		githubClient.EXPECT().GetOrgContainerPackage(mock.Anything, "codesphere-cloud", "codesphere/base-image").Return(nil).Once()
+
+		Expect(bs.EnsureGitHubRegistryPreflight()).To(Succeed())
+	})
+
+	It("reports a missing scope and every unreadable package in one error", func() {
+		githubClient.EXPECT().GetTokenScopes(mock.Anything).Return([]string{"repo"}, nil).Once()
+		githubClient.EXPECT().GetOrgContainerPackage(mock.Anything, "codesphere-cloud", "workspace-agent").Return(fmt.Errorf("404 Not Found")).Once()
+		githubClient.EXPECT().GetOrgContainerPackage(mock.Anything, "codesphere-cloud", "codesphere/base-image").Return(nil).Once()
+
+		err := bs.EnsureGitHubRegistryPreflight()
+		Expect(err).To(MatchError(ContainSubstring("GitHub registry preflight failed")))
+		Expect(err).To(MatchError(ContainSubstring("missing the read:packages scope")))
+		Expect(err).To(MatchError(ContainSubstring("ghcr.io/codesphere-cloud/workspace-agent is not readable")))
+		Expect(err).NotTo(MatchError(ContainSubstring("base-image is not readable")))
+	})
+
+	It("fails when the PAT cannot be queried", func() {
+		githubClient.EXPECT().GetTokenScopes(mock.Anything).Return(nil, fmt.Errorf("401 Bad credentials")).Once()
+
+		err := bs.EnsureGitHubRegistryPreflight()
+		Expect(err).To(MatchError(ContainSubstring("failed to query GitHub PAT scopes")))
+	})
+
+	It("fails when no PAT is set", func() {
+		csEnv.GitHubPAT = ""
+
+		Expect(bs.EnsureGitHubRegistryPreflight()).To(MatchError(ContainSubstring("GitHub PAT is not set")))
+	})
+})
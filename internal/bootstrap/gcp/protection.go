@@ -0,0 +1,44 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+)
+
+// CheckProtectedLabel checks if the given labels map marks a project as
+// protected against teardown, i.e. the 'oms-protected' label is set to "true".
+func CheckProtectedLabel(labels map[string]string) bool {
+	if labels == nil {
+		return false
+	}
+	value, exists := labels[OMSProtectedLabel]
+	return exists && value == "true"
+}
+
+// ConfirmProtectedOperation enforces the environment lock on destructive
+// operations against a protected environment: the operation is refused unless
+// force is set, and even then the project ID has to be typed to confirm.
+func ConfirmProtectedOperation(projectID, operation string, force bool, in io.Reader) error {
+	if !force {
+		return fmt.Errorf("environment %s is protected; %s requires --force and typing the project ID", projectID, operation)
+	}
+
+	log.Printf("WARNING: environment '%s' is marked as protected.", projectID)
+	log.Printf("Type the project ID to confirm %s: ", operation)
+
+	reader := bufio.NewReader(in)
+	confirmation, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	if strings.TrimSpace(confirmation) != projectID {
+		return fmt.Errorf("confirmation did not match project ID, aborting %s", operation)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+)
+
+var _ = Describe("Environment protection", func() {
+	Describe("CheckProtectedLabel", func() {
+		It("returns true when oms-protected is set to true", func() {
+			labels := map[string]string{gcp.OMSProtectedLabel: "true"}
+			Expect(gcp.CheckProtectedLabel(labels)).To(BeTrue())
+		})
+
+		It("returns false when oms-protected is absent", func() {
+			labels := map[string]string{gcp.OMSManagedLabel: "true"}
+			Expect(gcp.CheckProtectedLabel(labels)).To(BeFalse())
+		})
+
+		It("returns false for a nil labels map", func() {
+			Expect(gcp.CheckProtectedLabel(nil)).To(BeFalse())
+		})
+	})
+
+	Describe("ConfirmProtectedOperation", func() {
+		It("refuses the operation without force", func() {
+			err := gcp.ConfirmProtectedOperation("prod-project", "deletion", false, bytes.NewBufferString(""))
+			Expect(err).To(MatchError(ContainSubstring("requires --force and typing the project ID")))
+		})
+
+		It("rejects a confirmation that does not match the project ID", func() {
+			err := gcp.ConfirmProtectedOperation("prod-project", "deletion", true, bytes.NewBufferString("other-project\n"))
+			Expect(err).To(MatchError(ContainSubstring("confirmation did not match project ID")))
+		})
+
+		It("accepts a matching confirmation with force", func() {
+			err := gcp.ConfirmProtectedOperation("prod-project", "deletion", true, bytes.NewBufferString("prod-project\n"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
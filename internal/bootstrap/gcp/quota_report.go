@@ -0,0 +1,110 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Quota metrics that bound the environment's regional footprint.
+const (
+	quotaMetricCPUs           = "CPUS"
+	quotaMetricInUseAddresses = "IN_USE_ADDRESSES"
+	quotaMetricSSDTotalGB     = "SSD_TOTAL_GB"
+)
+
+// QuotaLine is the consumption of a single regional quota.
+type QuotaLine struct {
+	Metric string
+	Usage  float64
+	Limit  float64
+}
+
+// Remaining returns the unconsumed headroom of the quota.
+func (l QuotaLine) Remaining() float64 {
+	return l.Limit - l.Usage
+}
+
+// QuotaReport summarizes how much of the relevant regional quotas the
+// environment consumes, with warnings for quotas that leave too little
+// headroom for scaling up.
+type QuotaReport struct {
+	Region   string
+	Lines    []QuotaLine
+	Warnings []string
+}
+
+// Print writes the report as a table, followed by any scaling warnings.
+func (r *QuotaReport) Print(out io.Writer) error {
+	if _, err := fmt.Fprintf(out, "Regional quota consumption in %s:\n", r.Region); err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "QUOTA\tUSED\tLIMIT\tREMAINING")
+	for _, line := range r.Lines {
+		fmt.Fprintf(w, "%s\t%.0f\t%.0f\t%.0f\n", line.Metric, line.Usage, line.Limit, line.Remaining())
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, warning := range r.Warnings {
+		if _, err := fmt.Fprintf(out, "WARNING: %s\n", warning); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReportQuotaUsage collects the regional CPU, in-use address, and SSD quota
+// consumption of the environment. Environments run in a dedicated project, so
+// the project's quota usage is the environment's footprint. A warning is added
+// for every quota that one additional worker VM would exceed, since that is
+// the unit subsequent scale operations grow by.
+func (b *GCPBootstrapper) ReportQuotaUsage() (*QuotaReport, error) {
+	quotas, err := b.GCPClient.GetRegionQuotas(b.Env.ProjectID, b.Env.Region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get region quotas: %w", err)
+	}
+
+	report := &QuotaReport{Region: b.Env.Region}
+	lines := map[string]QuotaLine{}
+	for _, quota := range quotas {
+		switch quota.GetMetric() {
+		case quotaMetricCPUs, quotaMetricInUseAddresses, quotaMetricSSDTotalGB:
+			line := QuotaLine{Metric: quota.GetMetric(), Usage: quota.GetUsage(), Limit: quota.GetLimit()}
+			report.Lines = append(report.Lines, line)
+			lines[line.Metric] = line
+		}
+	}
+
+	workerCores, _, err := machineTypeResources(workerMachineType())
+	if err != nil {
+		return nil, err
+	}
+	if line, ok := lines[quotaMetricCPUs]; ok && line.Remaining() < float64(workerCores) {
+		report.Warnings = append(report.Warnings, fmt.Sprintf(
+			"scaling up by one worker needs %d CPUs but only %.0f remain in %s; request a CPU quota increase before scaling",
+			workerCores, line.Remaining(), b.Env.Region))
+	}
+	if line, ok := lines[quotaMetricSSDTotalGB]; ok && line.Remaining() < float64(b.Env.RootDiskSize) {
+		report.Warnings = append(report.Warnings, fmt.Sprintf(
+			"scaling up by one worker needs %d GB of SSD but only %.0f GB remain in %s; request an SSD quota increase before scaling",
+			b.Env.RootDiskSize, line.Remaining(), b.Env.Region))
+	}
+
+	return report, nil
+}
+
+// workerMachineType returns the machine type additional worker VMs use.
+func workerMachineType() string {
+	for _, vm := range vmDefs {
+		if vm.Tags[0] == "k0s" {
+			return vm.MachineType
+		}
+	}
+	return ""
+}
@@ -0,0 +1,127 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/codesphere-cloud/oms/internal/util"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Defaults for RetryPolicy fields that are left unset.
+const (
+	DefaultRetryAttempts  = 5
+	DefaultInitialBackoff = 5 * time.Second
+	DefaultMaxBackoff     = 30 * time.Second
+)
+
+// RetryPolicy retries an operation with exponential backoff and jitter. It
+// replaces the ad-hoc fixed-sleep loops that were spread over the bootstrapper
+// steps with one configurable engine.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one.
+	MaxAttempts int
+	// InitialBackoff is the base delay before the first retry; it doubles
+	// after every attempt up to MaxBackoff.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts.
+	MaxBackoff time.Duration
+	// Budget caps the total time spent waiting between attempts. Zero means
+	// the budget is only bounded by MaxAttempts.
+	Budget time.Duration
+	// Retryable classifies which errors are worth another attempt. A nil
+	// classifier retries every error, which is appropriate for IAM and
+	// service account propagation delays that surface as arbitrary errors.
+	Retryable func(error) bool
+	// OnRetry is called before every retry, e.g. to log progress. May be nil.
+	OnRetry func()
+
+	Time util.Time
+}
+
+// Retry runs fn until it succeeds, returns a non-retryable error, or the
+// attempt and backoff budgets are exhausted. The last error is returned.
+func (p RetryPolicy) Retry(fn func() error) error {
+	maxAttempts := p.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = DefaultRetryAttempts
+	}
+	backoff := p.InitialBackoff
+	if backoff <= 0 {
+		backoff = DefaultInitialBackoff
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxBackoff
+	}
+
+	var waited time.Duration
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+		if p.Retryable != nil && !p.Retryable(err) {
+			return err
+		}
+		if attempt == maxAttempts-1 {
+			break
+		}
+
+		delay := withJitter(backoff)
+		if p.Budget > 0 && waited+delay > p.Budget {
+			break
+		}
+		if p.OnRetry != nil {
+			p.OnRetry()
+		}
+		p.Time.Sleep(delay)
+		waited += delay
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+
+	return err
+}
+
+// withJitter spreads a delay over [d/2, d] so concurrent retries do not hit
+// the API in lockstep.
+func withJitter(d time.Duration) time.Duration {
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// IsRetryableGCPError reports whether the error is a transient GCP API
+// condition that a retry can resolve: rate limiting, contention on concurrent
+// policy updates, and server-side failures. Idempotency conditions (not found,
+// already exists) are never retryable; callers branch on those instead.
+func IsRetryableGCPError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrAlreadyExists) {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted, codes.Internal:
+		return true
+	}
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case 429, 500, 502, 503, 504:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+var _ = Describe("Retry", func() {
+	var (
+		fakeTime *util.FakeTime
+		policy   gcp.RetryPolicy
+		calls    int
+	)
+
+	BeforeEach(func() {
+		fakeTime = util.NewFakeTime()
+		policy = gcp.RetryPolicy{Time: fakeTime}
+		calls = 0
+	})
+
+	It("returns immediately on success", func() {
+		err := policy.Retry(func() error {
+			calls++
+			return nil
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls).To(Equal(1))
+	})
+
+	It("retries until the operation succeeds", func() {
+		err := policy.Retry(func() error {
+			calls++
+			if calls < 3 {
+				return fmt.Errorf("transient")
+			}
+			return nil
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls).To(Equal(3))
+	})
+
+	It("returns the last error when the attempts are exhausted", func() {
+		policy.MaxAttempts = 3
+
+		err := policy.Retry(func() error {
+			calls++
+			return fmt.Errorf("attempt %d failed", calls)
+		})
+
+		Expect(err).To(MatchError("attempt 3 failed"))
+		Expect(calls).To(Equal(3))
+	})
+
+	It("backs off exponentially between attempts", func() {
+		policy.MaxAttempts = 3
+		policy.InitialBackoff = 8 * time.Second
+		start := fakeTime.Now()
+
+		err := policy.Retry(func() error {
+			return fmt.Errorf("transient")
+		})
+
+		Expect(err).To(HaveOccurred())
+		waited := fakeTime.Now().Sub(start)
+		// Backoffs with jitter lie in [4s, 8s] and [8s, 16s].
+		Expect(waited).To(BeNumerically(">=", 12*time.Second))
+		Expect(waited).To(BeNumerically("<=", 24*time.Second))
+	})
+
+	It("stops retrying when the budget is exhausted", func() {
+		policy.MaxAttempts = 10
+		policy.InitialBackoff = 10 * time.Second
+		policy.Budget = time.Second
+
+		err := policy.Retry(func() error {
+			calls++
+			return fmt.Errorf("transient")
+		})
+
+		Expect(err).To(HaveOccurred())
+		Expect(calls).To(Equal(1))
+	})
+
+	It("does not retry non-retryable errors", func() {
+		policy.Retryable = gcp.IsRetryableGCPError
+
+		err := policy.Retry(func() error {
+			calls++
+			return grpcstatus.Errorf(codes.PermissionDenied, "denied")
+		})
+
+		Expect(err).To(HaveOccurred())
+		Expect(calls).To(Equal(1))
+	})
+
+	It("reports retries through the OnRetry hook", func() {
+		retries := 0
+		policy.MaxAttempts = 3
+		policy.OnRetry = func() { retries++ }
+
+		err := policy.Retry(func() error {
+			return fmt.Errorf("transient")
+		})
+
+		Expect(err).To(HaveOccurred())
+		Expect(retries).To(Equal(2))
+	})
+})
+
+var _ = Describe("IsRetryableGCPError", func() {
+	It("returns false for nil", func() {
+		Expect(gcp.IsRetryableGCPError(nil)).To(BeFalse())
+	})
+
+	It("retries transient gRPC codes", func() {
+		for _, code := range []codes.Code{codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted, codes.Internal} {
+			Expect(gcp.IsRetryableGCPError(grpcstatus.Errorf(code, "transient"))).To(BeTrue())
+		}
+	})
+
+	It("retries transient HTTP status codes", func() {
+		for _, code := range []int{429, 500, 502, 503, 504} {
+			Expect(gcp.IsRetryableGCPError(&googleapi.Error{Code: code})).To(BeTrue())
+		}
+	})
+
+	It("does not retry idempotency conditions", func() {
+		Expect(gcp.IsRetryableGCPError(fmt.Errorf("wrapped: %w", gcp.ErrNotFound))).To(BeFalse())
+		Expect(gcp.IsRetryableGCPError(fmt.Errorf("wrapped: %w", gcp.ErrAlreadyExists))).To(BeFalse())
+	})
+
+	It("does not retry client errors", func() {
+		Expect(gcp.IsRetryableGCPError(grpcstatus.Errorf(codes.PermissionDenied, "denied"))).To(BeFalse())
+		Expect(gcp.IsRetryableGCPError(&googleapi.Error{Code: 403})).To(BeFalse())
+	})
+})
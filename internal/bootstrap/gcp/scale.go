@@ -0,0 +1,200 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+)
+
+// k0sBinaryPath is where the installer places the k0s binary on every node.
+const k0sBinaryPath = "/etc/codesphere/deps/kubernetes/files/k0s"
+
+// workerNodeName returns the instance name of the i-th k0s node (1-based).
+func workerNodeName(i int) string {
+	return fmt.Sprintf("k0s-%d", i)
+}
+
+// k0sVMDefs returns the base k0s node definitions.
+func k0sVMDefs() []VMDef {
+	defs := []VMDef{}
+	for _, vm := range vmDefs {
+		if vm.Tags[0] == "k0s" {
+			defs = append(defs, vm)
+		}
+	}
+	return defs
+}
+
+// workerVMDef returns the definition of an additional worker instance, sized
+// like the base k0s nodes.
+func workerVMDef(name string) VMDef {
+	base := k0sVMDefs()[0]
+	return VMDef{Name: name, MachineType: base.MachineType, Tags: []string{"k0s"}, ExternalIP: base.ExternalIP}
+}
+
+// ScaleWorkers grows or shrinks the k0s worker pool of an existing
+// environment to target nodes. New workers are provisioned and joined via a
+// token from the first control plane node; removed workers are drained and
+// deleted from the cluster before their instance is deleted. The install
+// config worker list and the infra file are refreshed afterwards.
+func (b *GCPBootstrapper) ScaleWorkers(target int) error {
+	if b.distribution().Name != K8sDistributionK0s {
+		return fmt.Errorf("scaling joins workers through k0s join tokens and is only supported for k0s environments")
+	}
+	base := len(k0sVMDefs())
+	if target < base {
+		return fmt.Errorf("cannot scale below %d workers: the first %d k0s nodes host the control plane", base, base)
+	}
+	if len(b.Env.ControlPlaneNodes) < base {
+		return fmt.Errorf("environment has %d k0s nodes, expected at least %d; bootstrap it first", len(b.Env.ControlPlaneNodes), base)
+	}
+
+	current := len(b.Env.ControlPlaneNodes)
+	switch {
+	case target == current:
+		b.stlog.Logf("Environment already has %d worker nodes, nothing to do", current)
+		return nil
+	case target > current:
+		if err := b.addWorkerNodes(current, target); err != nil {
+			return err
+		}
+	default:
+		if err := b.removeWorkerNodes(current, target); err != nil {
+			return err
+		}
+	}
+
+	return b.refreshWorkerState()
+}
+
+// addWorkerNodes provisions the worker instances current+1..target and joins
+// them to the cluster one by one.
+func (b *GCPBootstrapper) addWorkerNodes(current, target int) error {
+	sourceImages, err := b.resolveSourceImages()
+	if err != nil {
+		return fmt.Errorf("failed to resolve source images: %w", err)
+	}
+	scopes, err := b.resolveServiceAccountScopes()
+	if err != nil {
+		return fmt.Errorf("failed to resolve service account scopes: %w", err)
+	}
+
+	// The join token and the k0s binary come from the first control plane
+	// node and are staged locally, because the nodes cannot SSH each other.
+	controller := b.Env.ControlPlaneNodes[0]
+	err = controller.RunSSHCommand("root", fmt.Sprintf("%s token create --role=worker --expiry=1h > /root/k0s-join-token", k0sBinaryPath))
+	if err != nil {
+		return fmt.Errorf("failed to create worker join token on %s: %w", controller.GetName(), err)
+	}
+	if err := controller.NodeClient.DownloadFile(controller, "/root/k0s-join-token", "k0s-join-token"); err != nil {
+		return fmt.Errorf("failed to download worker join token: %w", err)
+	}
+	if err := controller.NodeClient.DownloadFile(controller, k0sBinaryPath, "k0s"); err != nil {
+		return fmt.Errorf("failed to download k0s binary from %s: %w", controller.GetName(), err)
+	}
+
+	for i := current + 1; i <= target; i++ {
+		vm := workerVMDef(workerNodeName(i))
+
+		logCh := make(chan string, 4)
+		result, err := b.ensureVM(vm, b.Env.RootDiskSize, sourceImages["k0s"], scopes["k0s"], logCh)
+		close(logCh)
+		for msg := range logCh {
+			b.stlog.Logf("%s", msg)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to ensure worker VM %s: %w", vm.Name, err)
+		}
+
+		worker := b.opsNode().CreateSubNode(result.name, result.externalIP, result.internalIP)
+		if b.Env.NoJumpbox {
+			// Without a jumpbox there is no hop to proxy through; the IAP
+			// tunnel reaches the worker directly.
+			worker.Jumpbox = nil
+		}
+		if err := worker.NodeClient.WaitReady(worker, 30*time.Second); err != nil {
+			return fmt.Errorf("worker VM %s did not become reachable: %w", vm.Name, err)
+		}
+
+		if err := b.joinWorker(worker); err != nil {
+			return fmt.Errorf("failed to join worker %s: %w", vm.Name, err)
+		}
+
+		b.Env.ControlPlaneNodes = append(b.Env.ControlPlaneNodes, worker)
+		if result.created {
+			b.Env.NewWorkerNodes = append(b.Env.NewWorkerNodes, result.name)
+		}
+		b.stlog.Logf("Worker %s joined the cluster", vm.Name)
+	}
+
+	return nil
+}
+
+// joinWorker installs k0s on the worker and starts it with the staged join token.
+func (b *GCPBootstrapper) joinWorker(worker *node.Node) error {
+	if err := worker.NodeClient.CopyFile(worker, "k0s", "/root/k0s"); err != nil {
+		return fmt.Errorf("failed to copy k0s binary: %w", err)
+	}
+	if err := worker.NodeClient.CopyFile(worker, "k0s-join-token", "/root/k0s-join-token"); err != nil {
+		return fmt.Errorf("failed to copy join token: %w", err)
+	}
+
+	commands := []string{
+		"install -m 0755 /root/k0s /usr/local/bin/k0s",
+		"test -f /etc/systemd/system/k0sworker.service || /usr/local/bin/k0s install worker --token-file /root/k0s-join-token",
+		"systemctl daemon-reload",
+		"systemctl start k0sworker",
+		"rm -f /root/k0s /root/k0s-join-token",
+	}
+	for _, command := range commands {
+		if err := worker.RunSSHCommand("root", command); err != nil {
+			return fmt.Errorf("failed to run %q: %w", command, err)
+		}
+	}
+	return nil
+}
+
+// removeWorkerNodes drains and deletes the highest-numbered worker instances
+// until target nodes remain. The base control plane nodes are never removed.
+func (b *GCPBootstrapper) removeWorkerNodes(current, target int) error {
+	controller := b.Env.ControlPlaneNodes[0]
+	for i := current; i > target; i-- {
+		name := workerNodeName(i)
+
+		drain := fmt.Sprintf("%s kubectl drain %s --ignore-daemonsets --delete-emptydir-data --timeout=300s && %s kubectl delete node %s",
+			k0sBinaryPath, name, k0sBinaryPath, name)
+		if err := controller.RunSSHCommand("root", drain); err != nil {
+			return fmt.Errorf("failed to drain worker %s: %w", name, err)
+		}
+
+		if err := b.GCPClient.DeleteInstance(b.Env.ProjectID, b.Env.Zone, name); err != nil {
+			return fmt.Errorf("failed to delete worker instance %s: %w", name, err)
+		}
+
+		b.Env.ControlPlaneNodes = slices.DeleteFunc(b.Env.ControlPlaneNodes, func(n *node.Node) bool {
+			return n.GetName() == name
+		})
+		b.Env.NewWorkerNodes = slices.DeleteFunc(b.Env.NewWorkerNodes, func(worker string) bool {
+			return worker == name
+		})
+		b.stlog.Logf("Worker %s drained and removed", name)
+	}
+	return nil
+}
+
+// refreshWorkerState writes the scaled worker list into the install config
+// and refreshes the infra file.
+func (b *GCPBootstrapper) refreshWorkerState() error {
+	_, workers := b.k8sTopology()
+	b.icg.GetInstallConfig().Kubernetes.Workers = workers
+	if err := b.icg.WriteInstallConfig(b.Env.InstallConfigPath, true); err != nil {
+		return fmt.Errorf("failed to write install config: %w", err)
+	}
+
+	return b.WriteInfraFile()
+}
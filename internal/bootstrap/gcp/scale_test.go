@@ -0,0 +1,152 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/cloudflare"
+	"github.com/codesphere-cloud/oms/internal/env"
+	"github.com/codesphere-cloud/oms/internal/github"
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/codesphere-cloud/oms/internal/portal"
+	"github.com/codesphere-cloud/oms/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("ScaleWorkers", func() {
+	var (
+		nodeClient *node.MockNodeClient
+		gc         *gcp.MockGCPClientManager
+		icg        *installer.MockInstallConfigManager
+		fw         *util.MockFileIO
+		csEnv      *gcp.CodesphereEnvironment
+
+		bs *gcp.GCPBootstrapper
+	)
+
+	nodeNamed := func(name string) interface{} {
+		return mock.MatchedBy(func(n *node.Node) bool { return n.GetName() == name })
+	}
+
+	// expectStateRefresh mocks the install config and infra file writes at
+	// the end of a successful scale operation and returns the config the
+	// worker list is written into.
+	expectStateRefresh := func() *files.RootConfig {
+		cfg := &files.RootConfig{}
+		icg.EXPECT().GetInstallConfig().Return(cfg)
+		icg.EXPECT().WriteInstallConfig("config.yaml", true).Return(nil)
+		fw.EXPECT().MkdirAll(mock.Anything, os.FileMode(0755)).Return(nil)
+		fw.EXPECT().WriteFile(mock.Anything, mock.Anything, os.FileMode(0644)).Return(nil)
+		return cfg
+	}
+
+	JustBeforeEach(func() {
+		var err error
+		bs, err = gcp.NewGCPBootstrapper(
+			context.Background(),
+			env.NewEnv(),
+			bootstrap.NewStepLogger(false),
+			csEnv,
+			icg,
+			gc,
+			fw,
+			nodeClient,
+			portal.NewMockPortal(GinkgoT()),
+			util.NewFakeTime(),
+			github.NewMockGitHubClient(GinkgoT()),
+			cloudflare.NewMockCloudflareClient(GinkgoT()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	BeforeEach(func() {
+		nodeClient = node.NewMockNodeClient(GinkgoT())
+		gc = gcp.NewMockGCPClientManager(GinkgoT())
+		icg = installer.NewMockInstallConfigManager(GinkgoT())
+		fw = util.NewMockFileIO(GinkgoT())
+
+		csEnv = &gcp.CodesphereEnvironment{
+			ProjectID:         "pid",
+			Zone:              "us-central1-a",
+			RootDiskSize:      50,
+			InstallConfigPath: "config.yaml",
+			Jumpbox:           fakeNode("jumpbox", nodeClient),
+			ControlPlaneNodes: []*node.Node{
+				fakeNode("k0s-1", nodeClient),
+				fakeNode("k0s-2", nodeClient),
+				fakeNode("k0s-3", nodeClient),
+			},
+		}
+	})
+
+	It("rejects scaling below the control plane size", func() {
+		err := bs.ScaleWorkers(2)
+		Expect(err).To(MatchError(ContainSubstring("cannot scale below 3 workers")))
+	})
+
+	It("does nothing when the pool already has the requested size", func() {
+		Expect(bs.ScaleWorkers(3)).To(Succeed())
+	})
+
+	It("provisions, joins and records an additional worker", func() {
+		nodeClient.EXPECT().RunCommand(nodeNamed("k0s-1"), "root",
+			"/etc/codesphere/deps/kubernetes/files/k0s token create --role=worker --expiry=1h > /root/k0s-join-token").Return(nil).Once()
+		nodeClient.EXPECT().DownloadFile(nodeNamed("k0s-1"), "/root/k0s-join-token", "k0s-join-token").Return(nil).Once()
+		nodeClient.EXPECT().DownloadFile(nodeNamed("k0s-1"), "/etc/codesphere/deps/kubernetes/files/k0s", "k0s").Return(nil).Once()
+
+		// The instance already exists, so it is adopted instead of created.
+		gc.EXPECT().GetInstance("pid", "us-central1-a", "k0s-4").Return(makeRunningInstance("10.0.0.4", ""), nil)
+
+		nodeClient.EXPECT().WaitReady(nodeNamed("k0s-4"), mock.Anything).Return(nil).Once()
+		nodeClient.EXPECT().CopyFile(nodeNamed("k0s-4"), "k0s", "/root/k0s").Return(nil).Once()
+		nodeClient.EXPECT().CopyFile(nodeNamed("k0s-4"), "k0s-join-token", "/root/k0s-join-token").Return(nil).Once()
+		nodeClient.EXPECT().RunCommand(nodeNamed("k0s-4"), "root", mock.Anything).Return(nil).Times(5)
+
+		cfg := expectStateRefresh()
+
+		Expect(bs.ScaleWorkers(4)).To(Succeed())
+
+		Expect(csEnv.ControlPlaneNodes).To(HaveLen(4))
+		Expect(csEnv.ControlPlaneNodes[3].GetName()).To(Equal("k0s-4"))
+		Expect(cfg.Kubernetes.Workers).To(HaveLen(4))
+		Expect(cfg.Kubernetes.Workers[3].IPAddress).To(Equal("10.0.0.4"))
+	})
+
+	Context("when the environment has an extra worker", func() {
+		BeforeEach(func() {
+			csEnv.ControlPlaneNodes = append(csEnv.ControlPlaneNodes, fakeNode("k0s-4", nodeClient))
+		})
+
+		It("drains and deletes the highest-numbered worker", func() {
+			nodeClient.EXPECT().RunCommand(nodeNamed("k0s-1"), "root", mock.MatchedBy(func(command string) bool {
+				return strings.Contains(command, "kubectl drain k0s-4") && strings.Contains(command, "kubectl delete node k0s-4")
+			})).Return(nil).Once()
+			gc.EXPECT().DeleteInstance("pid", "us-central1-a", "k0s-4").Return(nil).Once()
+
+			cfg := expectStateRefresh()
+
+			Expect(bs.ScaleWorkers(3)).To(Succeed())
+
+			Expect(csEnv.ControlPlaneNodes).To(HaveLen(3))
+			Expect(cfg.Kubernetes.Workers).To(HaveLen(3))
+		})
+
+		It("fails when the drain fails", func() {
+			nodeClient.EXPECT().RunCommand(nodeNamed("k0s-1"), "root", mock.Anything).Return(fmt.Errorf("pdb violated")).Once()
+
+			err := bs.ScaleWorkers(3)
+			Expect(err).To(MatchError(ContainSubstring("failed to drain worker k0s-4")))
+		})
+	})
+})
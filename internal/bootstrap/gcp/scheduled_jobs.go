@@ -0,0 +1,179 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+)
+
+// scheduledJobNameRegexp restricts job names to safe systemd unit names.
+var scheduledJobNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// ScheduledJob describes a recurring maintenance task installed on the
+// jumpbox as a systemd service/timer pair (e.g. backup triggers, registry
+// garbage collection, certificate checks).
+type ScheduledJob struct {
+	// Name identifies the job; units are named oms-<name>.service/.timer.
+	Name string `json:"name"`
+	// Schedule is a systemd OnCalendar expression (e.g. "daily", "*-*-* 03:00:00").
+	Schedule string `json:"schedule"`
+	// Command is the shell command executed when the timer fires.
+	Command string `json:"command"`
+}
+
+// Validate checks that the job has a safe unit name, a schedule, and a command.
+func (j ScheduledJob) Validate() error {
+	if !scheduledJobNameRegexp.MatchString(j.Name) {
+		return fmt.Errorf("invalid scheduled job name %q: must consist of lowercase letters, digits, and dashes", j.Name)
+	}
+	if j.Schedule == "" {
+		return fmt.Errorf("scheduled job %s has no schedule", j.Name)
+	}
+	if j.Command == "" {
+		return fmt.Errorf("scheduled job %s has no command", j.Name)
+	}
+	return nil
+}
+
+// scheduledJobUnit returns the systemd unit base name of a job.
+func scheduledJobUnit(name string) string {
+	return "oms-" + name
+}
+
+// renderScheduledJobUnits renders the systemd service and timer unit files for a job.
+func renderScheduledJobUnits(job ScheduledJob) (service, timer string) {
+	service = fmt.Sprintf(`[Unit]
+Description=OMS scheduled job %s
+
+[Service]
+Type=oneshot
+ExecStart=/bin/bash -c %q
+`, job.Name, job.Command)
+
+	timer = fmt.Sprintf(`[Unit]
+Description=OMS schedule for %s
+
+[Timer]
+OnCalendar=%s
+Persistent=true
+
+[Install]
+WantedBy=timers.target
+`, job.Name, job.Schedule)
+
+	return service, timer
+}
+
+// InstallScheduledJob writes the service and timer units for a job to the
+// jumpbox and enables the timer. Existing units with the same name are updated.
+func InstallScheduledJob(jumpbox *node.Node, job ScheduledJob) error {
+	if err := job.Validate(); err != nil {
+		return err
+	}
+
+	unit := scheduledJobUnit(job.Name)
+	service, timer := renderScheduledJobUnits(job)
+
+	commands := []string{
+		fmt.Sprintf("cat > /etc/systemd/system/%s.service <<'EOF'\n%sEOF", unit, service),
+		fmt.Sprintf("cat > /etc/systemd/system/%s.timer <<'EOF'\n%sEOF", unit, timer),
+		"systemctl daemon-reload",
+		fmt.Sprintf("systemctl enable --now %s.timer", unit),
+	}
+	for _, command := range commands {
+		if err := jumpbox.RunSSHCommand("root", command); err != nil {
+			return fmt.Errorf("failed to install scheduled job %s: %w", job.Name, err)
+		}
+	}
+	return nil
+}
+
+// RemoveScheduledJob disables and deletes the service and timer units of a job
+// on the jumpbox.
+func RemoveScheduledJob(jumpbox *node.Node, name string) error {
+	unit := scheduledJobUnit(name)
+
+	commands := []string{
+		fmt.Sprintf("systemctl disable --now %s.timer || true", unit),
+		fmt.Sprintf("rm -f /etc/systemd/system/%s.timer /etc/systemd/system/%s.service", unit, unit),
+		"systemctl daemon-reload",
+	}
+	for _, command := range commands {
+		if err := jumpbox.RunSSHCommand("root", command); err != nil {
+			return fmt.Errorf("failed to remove scheduled job %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// VerificationJobName is the scheduled job that runs the config verification
+// suite, declared via the --verification-schedule bootstrap flag.
+const VerificationJobName = "verify-config"
+
+// VerificationJob builds the scheduled job that verifies the install config
+// against the running cluster and optionally posts the result to a webhook.
+func VerificationJob(schedule string, webhookURL string) ScheduledJob {
+	command := fmt.Sprintf("oms verify config -c %s", remoteInstallConfigPath)
+	if webhookURL != "" {
+		command = fmt.Sprintf("%s --webhook %s", command, webhookURL)
+	}
+	return ScheduledJob{
+		Name:     VerificationJobName,
+		Schedule: schedule,
+		Command:  command,
+	}
+}
+
+// declareVerificationJob adds the verification job to the scheduled jobs when
+// a verification schedule is configured. Re-running bootstrap updates an
+// already declared job instead of duplicating it.
+func (b *GCPBootstrapper) declareVerificationJob() error {
+	if b.Env.VerificationSchedule == "" {
+		if b.Env.VerificationWebhook != "" {
+			return fmt.Errorf("--verification-webhook requires --verification-schedule")
+		}
+		return nil
+	}
+
+	job := VerificationJob(b.Env.VerificationSchedule, b.Env.VerificationWebhook)
+	for i, existing := range b.Env.ScheduledJobs {
+		if existing.Name == job.Name {
+			b.Env.ScheduledJobs[i] = job
+			return nil
+		}
+	}
+	b.Env.ScheduledJobs = append(b.Env.ScheduledJobs, job)
+	return nil
+}
+
+// validateScheduledJobs checks all declared scheduled jobs and rejects
+// duplicate names.
+func (b *GCPBootstrapper) validateScheduledJobs() error {
+	seen := map[string]bool{}
+	for _, job := range b.Env.ScheduledJobs {
+		if err := job.Validate(); err != nil {
+			return err
+		}
+		if seen[job.Name] {
+			return fmt.Errorf("duplicate scheduled job name %q", job.Name)
+		}
+		seen[job.Name] = true
+	}
+	return nil
+}
+
+// EnsureScheduledJobs installs all scheduled jobs declared in the environment
+// on the jumpbox, or on the first control plane node in jumpbox-less mode.
+func (b *GCPBootstrapper) EnsureScheduledJobs() error {
+	for _, job := range b.Env.ScheduledJobs {
+		if err := InstallScheduledJob(b.opsNode(), job); err != nil {
+			return err
+		}
+		b.stlog.Logf("Installed scheduled job %s (%s)", job.Name, job.Schedule)
+	}
+	return nil
+}
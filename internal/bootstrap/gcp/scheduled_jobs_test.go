@@ -0,0 +1,107 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"strings"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("Scheduled jobs", func() {
+	var (
+		nodeClient *node.MockNodeClient
+		jumpbox    *node.Node
+		commands   []string
+	)
+
+	BeforeEach(func() {
+		nodeClient = node.NewMockNodeClient(GinkgoT())
+		jumpbox = fakeNode("jumpbox", nodeClient)
+		commands = nil
+	})
+
+	recordCommands := func() {
+		nodeClient.EXPECT().RunCommand(mock.Anything, "root", mock.Anything).RunAndReturn(
+			func(n *node.Node, username, command string) error {
+				commands = append(commands, command)
+				return nil
+			})
+	}
+
+	Describe("Validate", func() {
+		It("accepts a complete job", func() {
+			job := gcp.ScheduledJob{Name: "registry-gc", Schedule: "weekly", Command: "oms registry gc"}
+			Expect(job.Validate()).To(Succeed())
+		})
+
+		It("rejects unsafe job names", func() {
+			job := gcp.ScheduledJob{Name: "bad name!", Schedule: "daily", Command: "true"}
+			Expect(job.Validate()).To(MatchError(ContainSubstring("invalid scheduled job name")))
+		})
+
+		It("rejects a job without a schedule", func() {
+			job := gcp.ScheduledJob{Name: "backup", Command: "oms backup"}
+			Expect(job.Validate()).To(MatchError(ContainSubstring("has no schedule")))
+		})
+
+		It("rejects a job without a command", func() {
+			job := gcp.ScheduledJob{Name: "backup", Schedule: "daily"}
+			Expect(job.Validate()).To(MatchError(ContainSubstring("has no command")))
+		})
+	})
+
+	Describe("InstallScheduledJob", func() {
+		It("writes both units, reloads systemd, and enables the timer", func() {
+			recordCommands()
+
+			job := gcp.ScheduledJob{Name: "cert-check", Schedule: "*-*-* 03:00:00", Command: "oms verify certs"}
+			Expect(gcp.InstallScheduledJob(jumpbox, job)).To(Succeed())
+
+			joined := strings.Join(commands, "\n")
+			Expect(joined).To(ContainSubstring("cat > /etc/systemd/system/oms-cert-check.service"))
+			Expect(joined).To(ContainSubstring("cat > /etc/systemd/system/oms-cert-check.timer"))
+			Expect(joined).To(ContainSubstring(`ExecStart=/bin/bash -c "oms verify certs"`))
+			Expect(joined).To(ContainSubstring("OnCalendar=*-*-* 03:00:00"))
+			Expect(joined).To(ContainSubstring("systemctl daemon-reload"))
+			Expect(joined).To(ContainSubstring("systemctl enable --now oms-cert-check.timer"))
+		})
+
+		It("rejects invalid jobs before touching the jumpbox", func() {
+			job := gcp.ScheduledJob{Name: "../etc", Schedule: "daily", Command: "true"}
+			Expect(gcp.InstallScheduledJob(jumpbox, job)).To(MatchError(ContainSubstring("invalid scheduled job name")))
+		})
+	})
+
+	Describe("VerificationJob", func() {
+		It("runs the verify suite against the remote install config", func() {
+			job := gcp.VerificationJob("hourly", "")
+			Expect(job.Name).To(Equal(gcp.VerificationJobName))
+			Expect(job.Schedule).To(Equal("hourly"))
+			Expect(job.Command).To(Equal("oms verify config -c /etc/codesphere/config.yaml"))
+		})
+
+		It("posts results to the webhook when one is configured", func() {
+			job := gcp.VerificationJob("daily", "https://hooks.example.com/oms")
+			Expect(job.Command).To(ContainSubstring("--webhook https://hooks.example.com/oms"))
+		})
+	})
+
+	Describe("RemoveScheduledJob", func() {
+		It("disables the timer and deletes both units", func() {
+			recordCommands()
+
+			Expect(gcp.RemoveScheduledJob(jumpbox, "registry-gc")).To(Succeed())
+
+			joined := strings.Join(commands, "\n")
+			Expect(joined).To(ContainSubstring("systemctl disable --now oms-registry-gc.timer"))
+			Expect(joined).To(ContainSubstring("rm -f /etc/systemd/system/oms-registry-gc.timer /etc/systemd/system/oms-registry-gc.service"))
+			Expect(joined).To(ContainSubstring("systemctl daemon-reload"))
+		})
+	})
+})
@@ -0,0 +1,51 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/codesphere-cloud/oms/internal/secretstore"
+)
+
+// PushGeneratedCredentials pushes the credentials generated during bootstrap
+// (registry login, postgres admin password) into the configured external
+// secret store, in addition to the vault file.
+func (b *GCPBootstrapper) PushGeneratedCredentials() error {
+	store, err := secretstore.New(*b.Env.SecretStore)
+	if err != nil {
+		return err
+	}
+
+	vault := b.icg.GetVault()
+	var credentials []secretstore.Credential
+
+	registryUsername := ""
+	if s := vault.GetSecret(files.SecretRegistryUsername); s != nil && s.Fields != nil {
+		registryUsername = s.Fields.Password
+	}
+	if s := vault.GetSecret(files.SecretRegistryPassword); s != nil && s.Fields != nil {
+		credentials = append(credentials, secretstore.Credential{
+			Name:     "codesphere-registry",
+			Username: registryUsername,
+			Password: s.Fields.Password,
+		})
+	}
+	if s := vault.GetSecret(files.SecretPostgresPassword); s != nil && s.Fields != nil {
+		credentials = append(credentials, secretstore.Credential{
+			Name:     "codesphere-postgres-admin",
+			Username: "postgres",
+			Password: s.Fields.Password,
+		})
+	}
+
+	for _, credential := range credentials {
+		b.stlog.Logf("Pushing %s to %s secret store", credential.Name, b.Env.SecretStore.Type)
+		if err := store.Push(credential); err != nil {
+			return fmt.Errorf("failed to push %s: %w", credential.Name, err)
+		}
+	}
+	return nil
+}
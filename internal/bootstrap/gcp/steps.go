@@ -0,0 +1,520 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+// BootstrapStep describes one step of the GCP bootstrap as a node in a
+// dependency graph: a stable ID, the name printed by the step logger, a
+// description for documentation and UI, the IDs of the steps it depends on,
+// an optional condition gating it, and the step implementation.
+type BootstrapStep struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// DependsOn lists the IDs of steps whose results this step consumes.
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Condition reports whether the step applies to the environment; a nil
+	// Condition means the step always runs.
+	Condition func(*CodesphereEnvironment) bool `json:"-"`
+	// Run executes the step against the bootstrapper.
+	Run func(*GCPBootstrapper) error `json:"-"`
+	// ErrorFormat wraps the step error with context; it must contain a
+	// single %w verb.
+	ErrorFormat string `json:"-"`
+}
+
+// BootstrapSteps returns the declared bootstrap step graph in topological
+// order. Bootstrap executes the steps in exactly this order; the DependsOn
+// edges make the ordering constraints explicit so documentation and the web
+// UI can render the graph without reading Bootstrap itself.
+func BootstrapSteps() []BootstrapStep {
+	return []BootstrapStep{
+		{
+			ID:          "validate-input",
+			Name:        "Validate input",
+			Description: "Validate flags, DNS parameters, git provider credentials and the install config against each other.",
+			Run:         (*GCPBootstrapper).ValidateInput,
+			ErrorFormat: "invalid input: %w",
+		},
+		{
+			ID:          "ensure-install-config",
+			Name:        "Ensure install config",
+			Description: "Load the install config or generate one from the minimal profile.",
+			DependsOn:   []string{"validate-input"},
+			Run:         (*GCPBootstrapper).EnsureInstallConfig,
+			ErrorFormat: "failed to ensure install config: %w",
+		},
+		{
+			ID:          "check-entitlement",
+			Name:        "Check entitlement",
+			Description: "Gate requested features and plan limits against the license from the portal.",
+			DependsOn:   []string{"ensure-install-config"},
+			Condition: func(e *CodesphereEnvironment) bool {
+				return e.InstallVersion != "" || e.InstallLocal != ""
+			},
+			Run:         (*GCPBootstrapper).CheckEntitlement,
+			ErrorFormat: "failed to check entitlement: %w",
+		},
+		{
+			ID:          "ensure-secrets",
+			Name:        "Ensure secrets",
+			Description: "Load or generate the secrets vault used by the installation.",
+			DependsOn:   []string{"ensure-install-config"},
+			Run:         (*GCPBootstrapper).EnsureSecrets,
+			ErrorFormat: "failed to ensure secrets: %w",
+		},
+		{
+			ID:          "ensure-project",
+			Name:        "Ensure project",
+			Description: "Create or adopt the GCP project hosting the environment.",
+			DependsOn:   []string{"validate-input"},
+			Run:         (*GCPBootstrapper).EnsureProject,
+			ErrorFormat: "failed to ensure GCP project: %w",
+		},
+		{
+			ID:          "write-infra-file",
+			Name:        "Write infrastructure file",
+			Description: "Persist the environment checkpoint so interrupted bootstraps can resume.",
+			DependsOn:   []string{"ensure-project"},
+			Run:         (*GCPBootstrapper).WriteInfraFile,
+			ErrorFormat: "failed to write infrastructure file: %w",
+		},
+		{
+			ID:          "ensure-billing",
+			Name:        "Ensure billing",
+			Description: "Link the project to the billing account.",
+			DependsOn:   []string{"ensure-project"},
+			Run:         (*GCPBootstrapper).EnsureBilling,
+			ErrorFormat: "failed to ensure billing is enabled: %w",
+		},
+		{
+			ID:          "ensure-apis-enabled",
+			Name:        "Ensure APIs enabled",
+			Description: "Enable the GCP APIs the bootstrap depends on.",
+			DependsOn:   []string{"ensure-billing"},
+			Run:         (*GCPBootstrapper).EnsureAPIsEnabled,
+			ErrorFormat: "failed to enable required APIs: %w",
+		},
+		{
+			ID:          "ensure-essential-contacts",
+			Name:        "Ensure essential contacts",
+			Description: "Register the governance contacts as Essential Contacts on the project.",
+			DependsOn:   []string{"ensure-apis-enabled"},
+			Condition: func(e *CodesphereEnvironment) bool {
+				return e.SecurityContact != "" || e.BillingContact != "" || e.TechnicalContact != ""
+			},
+			Run:         (*GCPBootstrapper).EnsureEssentialContacts,
+			ErrorFormat: "failed to ensure essential contacts: %w",
+		},
+		{
+			ID:          "ensure-deletion-lien",
+			Name:        "Ensure deletion lien",
+			Description: "Place a lien on the project so it cannot be deleted accidentally.",
+			DependsOn:   []string{"ensure-apis-enabled"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.Protected },
+			Run:         (*GCPBootstrapper).EnsureDeletionLien,
+			ErrorFormat: "failed to ensure deletion lien: %w",
+		},
+		{
+			ID:          "handle-partial-resources",
+			Name:        "Detect partially-created resources",
+			Description: "Detect and optionally clean up resources left behind by an aborted bootstrap.",
+			DependsOn:   []string{"ensure-apis-enabled"},
+			Run:         (*GCPBootstrapper).EnsurePartialResourcesHandled,
+			ErrorFormat: "failed to handle partially-created resources: %w",
+		},
+		{
+			ID:          "ensure-artifact-registry",
+			Name:        "Ensure artifact registry",
+			Description: "Create the artifact registry serving container images to the cluster.",
+			DependsOn:   []string{"ensure-apis-enabled"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.RegistryType == RegistryTypeArtifactRegistry },
+			Run:         (*GCPBootstrapper).EnsureArtifactRegistry,
+			ErrorFormat: "failed to ensure artifact registry: %w",
+		},
+		{
+			ID:          "ensure-registry-cleanup-policies",
+			Name:        "Ensure registry cleanup policies",
+			Description: "Apply cleanup policies to the artifact registry to bound its growth.",
+			DependsOn:   []string{"ensure-artifact-registry"},
+			Condition: func(e *CodesphereEnvironment) bool {
+				return e.RegistryType == RegistryTypeArtifactRegistry && (e.RegistryKeepTagCount > 0 || e.RegistryUntaggedRetentionDays > 0)
+			},
+			Run:         (*GCPBootstrapper).EnsureRegistryCleanupPolicies,
+			ErrorFormat: "failed to ensure registry cleanup policies: %w",
+		},
+		{
+			ID:          "ensure-service-accounts",
+			Name:        "Ensure service accounts",
+			Description: "Create the service accounts and keys used by the environment.",
+			DependsOn:   []string{"ensure-apis-enabled", "ensure-secrets"},
+			Run:         (*GCPBootstrapper).EnsureServiceAccounts,
+			ErrorFormat: "failed to ensure service accounts: %w",
+		},
+		{
+			ID:          "ensure-iam-roles",
+			Name:        "Ensure IAM roles",
+			Description: "Bind the required IAM roles to the service accounts.",
+			DependsOn:   []string{"ensure-service-accounts"},
+			Run:         (*GCPBootstrapper).EnsureIAMRoles,
+			ErrorFormat: "failed to ensure IAM roles: %w",
+		},
+		{
+			ID:          "ensure-disk-encryption-key",
+			Name:        "Ensure disk encryption key",
+			Description: "Create the KMS key encrypting VM disks and grant the compute service agent access.",
+			DependsOn:   []string{"ensure-apis-enabled"},
+			Condition: func(e *CodesphereEnvironment) bool {
+				return e.GenerateDiskEncryptionKey || e.ManagedDiskEncryptionKey
+			},
+			Run:         (*GCPBootstrapper).EnsureDiskEncryptionKey,
+			ErrorFormat: "failed to ensure disk encryption key: %w",
+		},
+		{
+			ID:          "ensure-vpc",
+			Name:        "Ensure VPC",
+			Description: "Create the VPC network and subnet hosting the environment.",
+			DependsOn:   []string{"ensure-apis-enabled"},
+			Run:         (*GCPBootstrapper).EnsureVPC,
+			ErrorFormat: "failed to ensure VPC: %w",
+		},
+		{
+			ID:          "ensure-firewall-rules",
+			Name:        "Ensure firewall rules",
+			Description: "Create the firewall rules controlling access to the nodes.",
+			DependsOn:   []string{"ensure-vpc"},
+			Run:         (*GCPBootstrapper).EnsureFirewallRules,
+			ErrorFormat: "failed to ensure firewall rules: %w",
+		},
+		{
+			ID:          "ensure-cloud-armor-policy",
+			Name:        "Ensure Cloud Armor policy",
+			Description: "Create the Cloud Armor security policy with rate limiting and geo blocking.",
+			DependsOn:   []string{"ensure-apis-enabled"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.CloudArmorPolicy },
+			Run:         (*GCPBootstrapper).EnsureCloudArmorPolicy,
+			ErrorFormat: "failed to ensure Cloud Armor policy: %w",
+		},
+		{
+			ID:          "ensure-pg-backups",
+			Name:        "Ensure postgres backups",
+			Description: "Create the GCS backup bucket with its retention policy and grant write access.",
+			DependsOn:   []string{"ensure-apis-enabled", "ensure-service-accounts"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.PGBackups },
+			Run:         (*GCPBootstrapper).EnsurePGBackups,
+			ErrorFormat: "failed to ensure postgres backups: %w",
+		},
+		{
+			ID:          "ensure-cloudsql-instance",
+			Name:        "Ensure Cloud SQL instance",
+			Description: "Create the managed Cloud SQL for PostgreSQL instance with a private IP in the VPC.",
+			DependsOn:   []string{"ensure-vpc", "ensure-apis-enabled"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.PostgresMode == PostgresModeCloudSQL },
+			Run:         (*GCPBootstrapper).EnsureCloudSQLInstance,
+			ErrorFormat: "failed to ensure Cloud SQL instance: %w",
+		},
+		{
+			ID:          "ensure-compute-instances",
+			Name:        "Ensure compute instances",
+			Description: "Create the jumpbox, control plane, ceph and postgres VMs.",
+			DependsOn:   []string{"ensure-vpc", "ensure-service-accounts", "ensure-disk-encryption-key"},
+			Run:         (*GCPBootstrapper).EnsureComputeInstances,
+			ErrorFormat: "failed to ensure compute instances: %w",
+		},
+		{
+			ID:          "ensure-gateway-ip-addresses",
+			Name:        "Ensure gateway IP addresses",
+			Description: "Reserve the static external IP addresses for the gateways.",
+			DependsOn:   []string{"ensure-apis-enabled"},
+			Run:         (*GCPBootstrapper).EnsureGatewayIPAddresses,
+			ErrorFormat: "failed to ensure external IP addresses: %w",
+		},
+		{
+			ID:          "ensure-host-keys-recorded",
+			Name:        "Ensure host keys recorded",
+			Description: "Record the SSH host keys of the freshly created VMs.",
+			DependsOn:   []string{"ensure-compute-instances"},
+			Run:         (*GCPBootstrapper).EnsureHostKeysRecorded,
+			ErrorFormat: "failed to ensure host keys are recorded: %w",
+		},
+		{
+			ID:          "ensure-root-login-enabled",
+			Name:        "Ensure root login enabled",
+			Description: "Enable root SSH login on all nodes for the installer.",
+			DependsOn:   []string{"ensure-host-keys-recorded"},
+			Run:         (*GCPBootstrapper).EnsureRootLoginEnabled,
+			ErrorFormat: "failed to ensure root login is enabled: %w",
+		},
+		{
+			ID:          "ensure-jumpbox-configured",
+			Name:        "Ensure jumpbox configured",
+			Description: "Install the OMS binary and tooling on the jumpbox, or on the first control plane node in jumpbox-less mode.",
+			DependsOn:   []string{"ensure-root-login-enabled"},
+			Run:         (*GCPBootstrapper).EnsureJumpboxConfigured,
+			ErrorFormat: "failed to ensure jumpbox is configured: %w",
+		},
+		{
+			ID:          "ensure-hosts-configured",
+			Name:        "Ensure hosts are configured",
+			Description: "Apply sysctl settings and host configuration on all nodes.",
+			DependsOn:   []string{"ensure-root-login-enabled"},
+			Run:         (*GCPBootstrapper).EnsureHostsConfigured,
+			ErrorFormat: "failed to ensure hosts are configured: %w",
+		},
+		{
+			ID:          "ensure-artifact-storage",
+			Name:        "Ensure artifact storage",
+			Description: "Mount the GCS bucket or attached disk holding the install package on the ops node.",
+			DependsOn:   []string{"ensure-hosts-configured"},
+			Condition: func(e *CodesphereEnvironment) bool {
+				return e.ArtifactsBucket != "" || e.ArtifactsDiskGB > 0
+			},
+			Run:         (*GCPBootstrapper).EnsureArtifactStorage,
+			ErrorFormat: "failed to ensure artifact storage: %w",
+		},
+		{
+			ID:          "connectivity-preflight",
+			Name:        "Run connectivity preflight",
+			Description: "Verify connectivity between the jumpbox and all nodes.",
+			DependsOn:   []string{"ensure-jumpbox-configured", "ensure-hosts-configured"},
+			Run:         (*GCPBootstrapper).EnsureConnectivityPreflight,
+			ErrorFormat: "failed connectivity preflight: %w",
+		},
+		{
+			ID:          "check-node-health",
+			Name:        "Check node health",
+			Description: "Check disk, memory and service health on all nodes.",
+			DependsOn:   []string{"connectivity-preflight"},
+			Run:         (*GCPBootstrapper).EnsureNodesHealthy,
+			ErrorFormat: "failed node health check: %w",
+		},
+		{
+			ID:          "ensure-ci-runner",
+			Name:        "Ensure CI runner",
+			Description: "Provision an in-environment GitHub Actions runner.",
+			DependsOn:   []string{"check-node-health"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.CIRunner != nil },
+			Run:         (*GCPBootstrapper).EnsureCIRunner,
+			ErrorFormat: "failed to ensure CI runner: %w",
+		},
+		{
+			ID:          "ensure-scheduled-jobs",
+			Name:        "Ensure scheduled jobs",
+			Description: "Install systemd timers for recurring maintenance tasks on the jumpbox.",
+			DependsOn:   []string{"ensure-jumpbox-configured"},
+			Condition:   func(e *CodesphereEnvironment) bool { return len(e.ScheduledJobs) > 0 },
+			Run:         (*GCPBootstrapper).EnsureScheduledJobs,
+			ErrorFormat: "failed to ensure scheduled jobs: %w",
+		},
+		{
+			ID:          "ensure-local-container-registry",
+			Name:        "Ensure local container registry",
+			Description: "Run a container registry on the jumpbox and distribute its certificate.",
+			DependsOn:   []string{"check-node-health"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.RegistryType == RegistryTypeLocalContainer },
+			Run:         (*GCPBootstrapper).EnsureLocalContainerRegistry,
+			ErrorFormat: "failed to ensure local container registry: %w",
+		},
+		{
+			ID:          "ensure-harbor-registry",
+			Name:        "Ensure Harbor registry",
+			Description: "Deploy Harbor on a dedicated VM, create a robot account and distribute the registry certificate.",
+			DependsOn:   []string{"check-node-health"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.RegistryType == RegistryTypeHarbor },
+			Run:         (*GCPBootstrapper).EnsureHarborRegistry,
+			ErrorFormat: "failed to ensure harbor registry: %w",
+		},
+		{
+			ID:          "pre-pull-images",
+			Name:        "Pre-pull workspace images",
+			Description: "Pull workspace agent and base images onto all worker nodes so the first workspace start does not wait for them.",
+			DependsOn:   []string{"check-node-health"},
+			Condition:   func(e *CodesphereEnvironment) bool { return len(e.PrePullImages) > 0 },
+			Run:         (*GCPBootstrapper).EnsurePrePulledImages,
+			ErrorFormat: "failed to pre-pull workspace images: %w",
+		},
+		{
+			ID:          "pre-warm-deploy-images",
+			Name:        "Pre-warm deploy images",
+			Description: "Pull the deploy-config image set onto newly created worker nodes before installation, so scaled-up workers start with a warm image cache.",
+			DependsOn:   []string{"check-node-health", "ensure-install-config"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.PreWarmDeployImages },
+			Run:         (*GCPBootstrapper).EnsureDeployImagesPreWarmed,
+			ErrorFormat: "failed to pre-warm deploy images: %w",
+		},
+		{
+			ID:          "github-registry-preflight",
+			Name:        "Run GitHub registry preflight",
+			Description: "Verify the GitHub PAT scopes and package read access for the required ghcr.io images.",
+			DependsOn:   []string{"ensure-install-config"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.RegistryType == RegistryTypeGitHub },
+			Run:         (*GCPBootstrapper).EnsureGitHubRegistryPreflight,
+			ErrorFormat: "failed GitHub registry preflight: %w",
+		},
+		{
+			ID:          "ensure-github-access",
+			Name:        "Ensure GitHub access configured",
+			Description: "Configure registry access via GitHub credentials in the install config.",
+			DependsOn:   []string{"ensure-install-config"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.RegistryType == RegistryTypeGitHub },
+			Run:         (*GCPBootstrapper).EnsureGitHubAccessConfigured,
+			ErrorFormat: "failed to update install config: %w",
+		},
+		{
+			ID:          "update-install-config",
+			Name:        "Update install config",
+			Description: "Write the provisioned node addresses and settings into the install config.",
+			DependsOn:   []string{"check-node-health", "ensure-gateway-ip-addresses"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.WriteConfig },
+			Run:         (*GCPBootstrapper).UpdateInstallConfig,
+			ErrorFormat: "failed to update install config: %w",
+		},
+		{
+			ID:          "byo-cluster-preflight",
+			Name:        "Run BYO cluster preflight",
+			Description: "Validate the operator-provided Kubernetes cluster: version, node health and default storage class.",
+			DependsOn:   []string{"ensure-install-config"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.KubeconfigPath != "" },
+			Run:         (*GCPBootstrapper).EnsureByoClusterPreflight,
+			ErrorFormat: "failed BYO Kubernetes preflight: %w",
+		},
+		{
+			ID:          "check-version-skew",
+			Name:        "Check version skew",
+			Description: "Record the oms-cli version and verify the Codesphere, k0s and oms-cli versions against the portal's supported matrix.",
+			DependsOn:   []string{"ensure-install-config"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.InstallVersion != "" },
+			Run:         (*GCPBootstrapper).EnsureSupportedVersionSkew,
+			ErrorFormat: "unsupported version skew: %w",
+		},
+		{
+			ID:          "ensure-cloudsql-users",
+			Name:        "Ensure Cloud SQL users",
+			Description: "Create the codesphere database and the service users on the Cloud SQL instance from the generated credentials.",
+			DependsOn:   []string{"ensure-cloudsql-instance", "update-install-config"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.PostgresMode == PostgresModeCloudSQL },
+			Run:         (*GCPBootstrapper).EnsureCloudSQLUsers,
+			ErrorFormat: "failed to ensure Cloud SQL users: %w",
+		},
+		{
+			ID:          "externalize-secrets",
+			Name:        "Externalize secrets",
+			Description: "Move generated secret values into the external secret backend and reference them by URI.",
+			DependsOn:   []string{"update-install-config"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.WriteConfig && e.SecretBackend != "" },
+			Run:         (*GCPBootstrapper).ExternalizeSecrets,
+			ErrorFormat: "failed to externalize secrets: %w",
+		},
+		{
+			ID:          "ensure-vault-kms-key",
+			Name:        "Ensure vault KMS key",
+			Description: "Create the Cloud KMS key encrypting the secrets vault and grant the jumpbox service account decrypt rights.",
+			DependsOn:   []string{"update-install-config"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.WriteConfig && e.KMSVault },
+			Run:         (*GCPBootstrapper).EnsureVaultKMSKey,
+			ErrorFormat: "failed to ensure vault KMS key: %w",
+		},
+		{
+			ID:          "ensure-age-key",
+			Name:        "Ensure age key",
+			Description: "Generate the age key encrypting the secrets vault.",
+			DependsOn:   []string{"update-install-config"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.WriteConfig && !e.KMSVault },
+			Run:         (*GCPBootstrapper).EnsureAgeKey,
+			ErrorFormat: "failed to ensure age key: %w",
+		},
+		{
+			ID:          "encrypt-vault",
+			Name:        "Encrypt vault",
+			Description: "Encrypt the secrets vault with SOPS using the age or KMS key.",
+			DependsOn:   []string{"externalize-secrets", "ensure-vault-kms-key", "ensure-age-key"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.WriteConfig },
+			Run:         (*GCPBootstrapper).EncryptVault,
+			ErrorFormat: "failed to encrypt vault: %w",
+		},
+		{
+			ID:          "ensure-dns-records",
+			Name:        "Ensure DNS records",
+			Description: "Create the DNS records pointing at the gateway IP addresses.",
+			DependsOn:   []string{"ensure-gateway-ip-addresses"},
+			Run:         (*GCPBootstrapper).EnsureDNSRecords,
+			ErrorFormat: "failed to ensure DNS records: %w",
+		},
+		{
+			ID:          "ensure-origin-certificate",
+			Name:        "Ensure Cloudflare origin certificate",
+			Description: "Issue a Cloudflare origin certificate for the environment domains.",
+			DependsOn:   []string{"ensure-dns-records"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.UseCloudflare },
+			Run:         (*GCPBootstrapper).EnsureOriginCertificate,
+			ErrorFormat: "failed to ensure Cloudflare origin certificate: %w",
+		},
+		{
+			ID:          "install-codesphere",
+			Name:        "Install Codesphere",
+			Description: "Run the Codesphere installation from the jumpbox.",
+			DependsOn:   []string{"ensure-hosts-configured", "ensure-dns-records"},
+			Condition: func(e *CodesphereEnvironment) bool {
+				return e.InstallVersion != "" || e.InstallLocal != ""
+			},
+			Run:         (*GCPBootstrapper).InstallCodesphere,
+			ErrorFormat: "failed to install Codesphere: %w",
+		},
+		{
+			ID:          "configure-cloud-controller",
+			Name:        "Configure cloud controller",
+			Description: "Deploy the GCP cloud controller manager and point the gateway services at their static IPs.",
+			DependsOn:   []string{"install-codesphere"},
+			Condition: func(e *CodesphereEnvironment) bool {
+				return (e.InstallVersion != "" || e.InstallLocal != "") && e.KubeconfigPath == ""
+			},
+			Run:         (*GCPBootstrapper).ConfigureCloudController,
+			ErrorFormat: "failed to configure cloud controller: %w",
+		},
+		{
+			ID:          "ensure-kubernetes-version",
+			Name:        "Ensure kubernetes version",
+			Description: "Upgrade the cluster to the pinned k0s release when the installer bundled a different one.",
+			DependsOn:   []string{"configure-cloud-controller"},
+			Condition: func(e *CodesphereEnvironment) bool {
+				return (e.InstallVersion != "" || e.InstallLocal != "") && e.KubernetesVersion != ""
+			},
+			Run:         (*GCPBootstrapper).EnsureKubernetesVersion,
+			ErrorFormat: "failed to ensure kubernetes version: %w",
+		},
+		{
+			ID:          "install-storage-backend",
+			Name:        "Install storage backend",
+			Description: "Install the selected non-Ceph CSI stack on the k0s nodes and mark its storage class as the default.",
+			DependsOn:   []string{"install-codesphere"},
+			Condition: func(e *CodesphereEnvironment) bool {
+				return (e.InstallVersion != "" || e.InstallLocal != "") &&
+					e.StorageBackend != "" && e.StorageBackend != StorageBackendCeph &&
+					e.KubeconfigPath == ""
+			},
+			Run:         (*GCPBootstrapper).InstallStorageBackend,
+			ErrorFormat: "failed to install storage backend: %w",
+		},
+		{
+			ID:          "push-generated-credentials",
+			Name:        "Push generated credentials",
+			Description: "Push generated credentials into the configured external secret store.",
+			DependsOn:   []string{"ensure-secrets"},
+			Condition:   func(e *CodesphereEnvironment) bool { return e.SecretStore != nil },
+			Run:         (*GCPBootstrapper).PushGeneratedCredentials,
+			ErrorFormat: "failed to push generated credentials: %w",
+		},
+		{
+			ID:          "verify-github-app-urls",
+			Name:        "Verify GitHub App URLs",
+			Description: "Check that the GitHub App points at the generated cs.<domain> endpoints and print fix instructions if not.",
+			DependsOn:   []string{"update-install-config"},
+			Condition: func(e *CodesphereEnvironment) bool {
+				return e.GitHubAppName != "" && e.GitHubAppClientID != "" && e.GitHubAppClientSecret != ""
+			},
+			Run:         (*GCPBootstrapper).VerifyGitHubAppURLs,
+			ErrorFormat: "failed to verify GitHub App URLs: %w",
+		},
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"strings"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BootstrapSteps", func() {
+	It("declares every step completely", func() {
+		for _, step := range gcp.BootstrapSteps() {
+			Expect(step.ID).NotTo(BeEmpty())
+			Expect(step.Name).NotTo(BeEmpty(), "step %s has no name", step.ID)
+			Expect(step.Description).NotTo(BeEmpty(), "step %s has no description", step.ID)
+			Expect(step.Run).NotTo(BeNil(), "step %s has no implementation", step.ID)
+			Expect(step.ErrorFormat).To(ContainSubstring("%w"), "step %s has no error wrap", step.ID)
+		}
+	})
+
+	It("declares a valid topological order", func() {
+		seen := map[string]bool{}
+		for _, step := range gcp.BootstrapSteps() {
+			Expect(seen).NotTo(HaveKey(step.ID), "duplicate step ID %s", step.ID)
+			for _, dep := range step.DependsOn {
+				Expect(seen).To(HaveKey(dep), "step %s depends on %s, which is not declared before it", step.ID, dep)
+			}
+			seen[step.ID] = true
+		}
+	})
+
+	It("uses kebab-case step IDs", func() {
+		for _, step := range gcp.BootstrapSteps() {
+			Expect(step.ID).To(Equal(strings.ToLower(step.ID)))
+			Expect(step.ID).NotTo(ContainSubstring(" "))
+		}
+	})
+})
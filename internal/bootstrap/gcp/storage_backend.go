@@ -0,0 +1,128 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+)
+
+// Versions of the non-Ceph CSI stacks installed on the k0s nodes.
+const (
+	LonghornVersion             = "v1.7.2"
+	LocalPathProvisionerVersion = "v0.0.30"
+)
+
+// validateStorageBackend ensures the storage backend is one of the supported
+// values and that backend-specific constraints hold. Non-Ceph backends keep
+// the topology small for PoC environments and cannot be combined with an
+// external Ceph cluster.
+func (b *GCPBootstrapper) validateStorageBackend() error {
+	switch b.Env.StorageBackend {
+	case "", StorageBackendCeph:
+	case StorageBackendLonghorn, StorageBackendLocalPath:
+		if b.Env.StorageMode == StorageModeExternal {
+			return fmt.Errorf("--storage-mode external requires the ceph storage backend")
+		}
+	default:
+		return fmt.Errorf("invalid storage backend %q: must be %s, %s or %s", b.Env.StorageBackend, StorageBackendCeph, StorageBackendLonghorn, StorageBackendLocalPath)
+	}
+	return nil
+}
+
+// usesCephStorage reports whether workspace storage is backed by Ceph, either
+// deployed by the installer or an external cluster.
+func (b *GCPBootstrapper) usesCephStorage() bool {
+	return b.Env.StorageBackend == "" || b.Env.StorageBackend == StorageBackendCeph
+}
+
+// storageClassName returns the storage class workspace volumes are
+// provisioned from for the selected non-Ceph backend.
+func (b *GCPBootstrapper) storageClassName() string {
+	if b.Env.StorageBackend == StorageBackendLonghorn {
+		return "longhorn"
+	}
+	return "local-path"
+}
+
+// applyStorageBackendConfig points the install config at the non-Ceph storage
+// backend. The CSI stack itself is installed on the k0s nodes by the
+// install-storage-backend step, so the installer's ceph step is skipped and
+// the ceph section stays empty.
+func (b *GCPBootstrapper) applyStorageBackendConfig() {
+	b.Env.InstallConfig.Storage = &files.StorageConfig{
+		Backend:      b.Env.StorageBackend,
+		StorageClass: b.storageClassName(),
+	}
+	b.Env.InstallConfig.Ceph = files.CephConfig{}
+	b.skipInstallerStep("ceph")
+}
+
+// skipInstallerStep adds a step to the installer's skip list unless it is
+// already present.
+func (b *GCPBootstrapper) skipInstallerStep(step string) {
+	if b.Env.InstallConfig.Operations == nil {
+		b.Env.InstallConfig.Operations = &files.OperationsConfig{}
+	}
+	for _, skipped := range b.Env.InstallConfig.Operations.Skip {
+		if skipped == step {
+			return
+		}
+	}
+	b.Env.InstallConfig.Operations.Skip = append(b.Env.InstallConfig.Operations.Skip, step)
+}
+
+// storageBackendScript returns the script installing the selected CSI stack
+// through the distribution's kubectl and marking its storage class as the
+// cluster default.
+func (b *GCPBootstrapper) storageBackendScript() string {
+	dist := b.distribution()
+	defaultClassPatch := `'{"metadata": {"annotations":{"storageclass.kubernetes.io/is-default-class":"true"}}}'`
+	if b.Env.StorageBackend == StorageBackendLonghorn {
+		return `#!/bin/bash
+set -euo pipefail
+
+KUBECTL="` + dist.KubectlCommand + `"
+$KUBECTL apply -f https://raw.githubusercontent.com/longhorn/longhorn/` + LonghornVersion + `/deploy/longhorn.yaml
+
+# Some distributions keep the kubelet data under their own directory, so the
+# CSI driver must be registered there instead of /var/lib/kubelet.
+$KUBECTL -n longhorn-system patch deployment longhorn-driver-deployer --type=json \
+  -p '[{"op":"add","path":"/spec/template/spec/containers/0/command/-","value":"--kubelet-root-dir=` + dist.KubeletDir + `"}]'
+
+$KUBECTL -n longhorn-system rollout status deployment/longhorn-driver-deployer --timeout=10m
+$KUBECTL patch storageclass longhorn -p ` + defaultClassPatch + `
+`
+	}
+	return `#!/bin/bash
+set -euo pipefail
+
+KUBECTL="` + dist.KubectlCommand + `"
+$KUBECTL apply -f https://raw.githubusercontent.com/rancher/local-path-provisioner/` + LocalPathProvisionerVersion + `/deploy/local-path-storage.yaml
+$KUBECTL -n local-path-storage rollout status deployment/local-path-provisioner --timeout=5m
+$KUBECTL patch storageclass local-path -p ` + defaultClassPatch + `
+`
+}
+
+// InstallStorageBackend installs the selected CSI stack on the k0s nodes by
+// running the storage backend script on the first control plane node, the
+// same way the k0s config script is applied.
+func (b *GCPBootstrapper) InstallStorageBackend() error {
+	script := b.storageBackendScript()
+	err := b.fw.WriteFile("install-storage-backend.sh", []byte(script), 0755)
+	if err != nil {
+		return fmt.Errorf("failed to write install-storage-backend.sh: %w", err)
+	}
+	controlPlane := b.Env.ControlPlaneNodes[0]
+	err = controlPlane.NodeClient.CopyFile(controlPlane, "install-storage-backend.sh", "/root/install-storage-backend.sh")
+	if err != nil {
+		return fmt.Errorf("failed to copy install-storage-backend.sh to control plane node: %w", err)
+	}
+	err = controlPlane.RunSSHCommand("root", "chmod +x /root/install-storage-backend.sh && /root/install-storage-backend.sh")
+	if err != nil {
+		return fmt.Errorf("failed to install the %s storage backend: %w", b.Env.StorageBackend, err)
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"cloud.google.com/go/compute/apiv1/computepb"
 	"github.com/codesphere-cloud/oms/internal/bootstrap"
 	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/cloudflare"
 	"github.com/codesphere-cloud/oms/internal/env"
 	"github.com/codesphere-cloud/oms/internal/github"
 	"github.com/codesphere-cloud/oms/internal/installer"
@@ -96,6 +97,7 @@ func newTestBootstrapperAll(csEnv *gcp.CodesphereEnvironment, gc gcp.GCPClientMa
 		portal.NewMockPortal(GinkgoT()),
 		util.NewFakeTime(),
 		ghClient,
+		cloudflare.NewMockCloudflareClient(GinkgoT()),
 	)
 	if err != nil {
 		panic("newTestBootstrapperAll: " + err.Error())
@@ -0,0 +1,162 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+)
+
+// k0sDownloadURL is the release binary fetched onto the ops node when pinning
+// or upgrading the kubernetes version.
+const k0sDownloadURL = "https://github.com/k0sproject/k0s/releases/download/%s/k0s-%s-amd64"
+
+// validateKubernetesVersion checks the pinned k0s release. Other
+// distributions and BYO clusters bring their own upgrade tooling.
+func (b *GCPBootstrapper) validateKubernetesVersion() error {
+	if b.Env.KubernetesVersion == "" {
+		return nil
+	}
+	if b.byoK8s() {
+		return fmt.Errorf("--kubernetes-version pins the provisioned k0s release and cannot be combined with --kubeconfig")
+	}
+	if b.distribution().Name != K8sDistributionK0s {
+		return fmt.Errorf("--kubernetes-version is only supported for k0s environments")
+	}
+	return validateK0sRelease(b.Env.KubernetesVersion)
+}
+
+// validateK0sRelease checks that version names a full k0s release, which is
+// needed to derive its download URL.
+func validateK0sRelease(version string) error {
+	if !strings.HasPrefix(version, "v") || !strings.Contains(version, "+k0s.") {
+		return fmt.Errorf("invalid kubernetes version %q: expected a k0s release like v1.33.2+k0s.0", version)
+	}
+	return nil
+}
+
+// EnsureKubernetesVersion upgrades the cluster to the pinned k0s release when
+// the installer bundled a different one.
+func (b *GCPBootstrapper) EnsureKubernetesVersion() error {
+	if len(b.Env.ControlPlaneNodes) == 0 {
+		return fmt.Errorf("environment has no k0s nodes; bootstrap it first")
+	}
+	controller := b.Env.ControlPlaneNodes[0]
+	check := fmt.Sprintf("test \"$(%s version)\" = %q", k0sBinaryPath, b.Env.KubernetesVersion)
+	if err := controller.RunSSHCommand("root", check); err == nil {
+		b.stlog.Logf("Cluster already runs k0s %s", b.Env.KubernetesVersion)
+		return nil
+	}
+	return b.UpgradeK8s(b.Env.KubernetesVersion)
+}
+
+// UpgradeK8s upgrades k0s on every node to version. The release binary is
+// staged through the ops node because the nodes cannot reach each other; the
+// control plane is upgraded first, then each worker is drained, upgraded and
+// uncordoned one at a time so the cluster keeps serving workloads.
+func (b *GCPBootstrapper) UpgradeK8s(version string) error {
+	if b.byoK8s() || b.distribution().Name != K8sDistributionK0s {
+		return fmt.Errorf("upgrades replace the k0s binary and are only supported for k0s environments")
+	}
+	if err := validateK0sRelease(version); err != nil {
+		return err
+	}
+	if len(b.Env.ControlPlaneNodes) == 0 {
+		return fmt.Errorf("environment has no k0s nodes; bootstrap it first")
+	}
+	if b.PortalClient != nil && b.Env.InstallVersion != "" {
+		if err := b.checkVersionSkew(version); err != nil {
+			return err
+		}
+	}
+
+	if err := b.stageK0sBinary(version); err != nil {
+		return err
+	}
+
+	controller := b.Env.ControlPlaneNodes[0]
+	b.stlog.Logf("Upgrading control plane %s to k0s %s", controller.GetName(), version)
+	if err := b.replaceK0sBinary(controller, "k0scontroller"); err != nil {
+		return fmt.Errorf("failed to upgrade control plane %s: %w", controller.GetName(), err)
+	}
+
+	for _, worker := range b.Env.ControlPlaneNodes[1:] {
+		b.stlog.Logf("Upgrading worker %s to k0s %s", worker.GetName(), version)
+		if err := b.upgradeWorker(controller, worker); err != nil {
+			return err
+		}
+	}
+
+	b.Env.KubernetesVersion = version
+	if err := b.WriteInfraFile(); err != nil {
+		return fmt.Errorf("failed to record kubernetes version: %w", err)
+	}
+	b.stlog.Logf("Cluster upgraded to k0s %s", version)
+	return nil
+}
+
+// stageK0sBinary downloads the release binary onto the ops node and stages it
+// locally for distribution to the other nodes.
+func (b *GCPBootstrapper) stageK0sBinary(version string) error {
+	ops := b.opsNode()
+	url := fmt.Sprintf(k0sDownloadURL, version, version)
+	download := fmt.Sprintf("curl -fsSL -o /root/k0s-new %s && chmod +x /root/k0s-new", url)
+	if err := ops.RunSSHCommand("root", download); err != nil {
+		return fmt.Errorf("failed to download k0s %s on %s: %w", version, ops.GetName(), err)
+	}
+	if err := ops.NodeClient.DownloadFile(ops, "/root/k0s-new", "k0s-new"); err != nil {
+		return fmt.Errorf("failed to stage k0s binary: %w", err)
+	}
+	return nil
+}
+
+// replaceK0sBinary stops the node's k0s unit, installs the staged binary over
+// the existing one and starts the unit again. Workers joined after bootstrap
+// run k0s from /usr/local/bin, so that copy is replaced too when present.
+func (b *GCPBootstrapper) replaceK0sBinary(n *node.Node, unit string) error {
+	if err := n.NodeClient.CopyFile(n, "k0s-new", "/root/k0s-new"); err != nil {
+		return fmt.Errorf("failed to copy k0s binary: %w", err)
+	}
+
+	commands := []string{
+		"systemctl stop " + unit,
+		fmt.Sprintf("install -m 0755 /root/k0s-new %s", k0sBinaryPath),
+		"[ ! -f /usr/local/bin/k0s ] || install -m 0755 /root/k0s-new /usr/local/bin/k0s",
+		"systemctl start " + unit,
+		"rm -f /root/k0s-new",
+	}
+	for _, command := range commands {
+		if err := n.RunSSHCommand("root", command); err != nil {
+			return fmt.Errorf("failed to run %q: %w", command, err)
+		}
+	}
+	return nil
+}
+
+// upgradeWorker drains the worker, replaces its binary and uncordons it once
+// it reports Ready again.
+func (b *GCPBootstrapper) upgradeWorker(controller, worker *node.Node) error {
+	name := worker.GetName()
+	drain := fmt.Sprintf("%s kubectl drain %s --ignore-daemonsets --delete-emptydir-data --timeout=300s", k0sBinaryPath, name)
+	if err := controller.RunSSHCommand("root", drain); err != nil {
+		return fmt.Errorf("failed to drain worker %s: %w", name, err)
+	}
+
+	if err := b.replaceK0sBinary(worker, "k0sworker"); err != nil {
+		return fmt.Errorf("failed to upgrade worker %s: %w", name, err)
+	}
+
+	ready := fmt.Sprintf("%s kubectl wait --for=condition=Ready node/%s --timeout=300s", k0sBinaryPath, name)
+	if err := controller.RunSSHCommand("root", ready); err != nil {
+		return fmt.Errorf("worker %s did not become Ready after the upgrade: %w", name, err)
+	}
+
+	uncordon := fmt.Sprintf("%s kubectl uncordon %s", k0sBinaryPath, name)
+	if err := controller.RunSSHCommand("root", uncordon); err != nil {
+		return fmt.Errorf("failed to uncordon worker %s: %w", name, err)
+	}
+	return nil
+}
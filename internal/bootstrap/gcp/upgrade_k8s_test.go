@@ -0,0 +1,120 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/codesphere-cloud/oms/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("UpgradeK8s", func() {
+	var (
+		nodeClient *node.MockNodeClient
+		fw         *util.MockFileIO
+		csEnv      *gcp.CodesphereEnvironment
+
+		bs *gcp.GCPBootstrapper
+	)
+
+	const version = "v1.33.2+k0s.0"
+
+	nodeNamed := func(name string) interface{} {
+		return mock.MatchedBy(func(n *node.Node) bool { return n.GetName() == name })
+	}
+
+	JustBeforeEach(func() {
+		bs = newTestBootstrapperWithFileIO(csEnv, gcp.NewMockGCPClientManager(GinkgoT()), fw)
+	})
+
+	BeforeEach(func() {
+		nodeClient = node.NewMockNodeClient(GinkgoT())
+		fw = util.NewMockFileIO(GinkgoT())
+
+		csEnv = &gcp.CodesphereEnvironment{
+			ProjectID: "pid",
+			Zone:      "us-central1-a",
+			Jumpbox:   fakeNode("jumpbox", nodeClient),
+			ControlPlaneNodes: []*node.Node{
+				fakeNode("k0s-1", nodeClient),
+				fakeNode("k0s-2", nodeClient),
+			},
+		}
+	})
+
+	expectBinaryStaged := func() {
+		nodeClient.EXPECT().RunCommand(nodeNamed("jumpbox"), "root", mock.MatchedBy(func(command string) bool {
+			return strings.Contains(command, "curl") && strings.Contains(command, version)
+		})).Return(nil).Once()
+		nodeClient.EXPECT().DownloadFile(nodeNamed("jumpbox"), "/root/k0s-new", "k0s-new").Return(nil).Once()
+	}
+
+	expectBinaryReplaced := func(name, unit string) {
+		nodeClient.EXPECT().CopyFile(nodeNamed(name), "k0s-new", "/root/k0s-new").Return(nil).Once()
+		nodeClient.EXPECT().RunCommand(nodeNamed(name), "root", "systemctl stop "+unit).Return(nil).Once()
+		nodeClient.EXPECT().RunCommand(nodeNamed(name), "root", mock.MatchedBy(func(command string) bool {
+			return strings.HasPrefix(command, "install -m 0755 /root/k0s-new")
+		})).Return(nil).Once()
+		nodeClient.EXPECT().RunCommand(nodeNamed(name), "root", mock.MatchedBy(func(command string) bool {
+			return strings.Contains(command, "|| install -m 0755 /root/k0s-new /usr/local/bin/k0s")
+		})).Return(nil).Once()
+		nodeClient.EXPECT().RunCommand(nodeNamed(name), "root", "systemctl start "+unit).Return(nil).Once()
+		nodeClient.EXPECT().RunCommand(nodeNamed(name), "root", "rm -f /root/k0s-new").Return(nil).Once()
+	}
+
+	It("upgrades the control plane, then drains and upgrades each worker", func() {
+		expectBinaryStaged()
+		expectBinaryReplaced("k0s-1", "k0scontroller")
+		expectBinaryReplaced("k0s-2", "k0sworker")
+
+		nodeClient.EXPECT().RunCommand(nodeNamed("k0s-1"), "root", mock.MatchedBy(func(command string) bool {
+			return strings.Contains(command, "kubectl drain k0s-2")
+		})).Return(nil).Once()
+		nodeClient.EXPECT().RunCommand(nodeNamed("k0s-1"), "root", mock.MatchedBy(func(command string) bool {
+			return strings.Contains(command, "kubectl wait --for=condition=Ready node/k0s-2")
+		})).Return(nil).Once()
+		nodeClient.EXPECT().RunCommand(nodeNamed("k0s-1"), "root", mock.MatchedBy(func(command string) bool {
+			return strings.Contains(command, "kubectl uncordon k0s-2")
+		})).Return(nil).Once()
+
+		// Infra file update recording the new version.
+		fw.EXPECT().MkdirAll(mock.Anything, os.FileMode(0755)).Return(nil)
+		fw.EXPECT().WriteFile(mock.Anything, mock.MatchedBy(func(content []byte) bool {
+			return strings.Contains(string(content), "kubernetes_version")
+		}), os.FileMode(0644)).Return(nil)
+
+		Expect(bs.UpgradeK8s(version)).To(Succeed())
+		Expect(csEnv.KubernetesVersion).To(Equal(version))
+	})
+
+	It("rejects a non-k0s environment", func() {
+		csEnv.K8sDistribution = gcp.K8sDistributionRKE2
+
+		err := bs.UpgradeK8s(version)
+		Expect(err).To(MatchError(ContainSubstring("only supported for k0s environments")))
+	})
+
+	It("rejects a version that is not a k0s release", func() {
+		err := bs.UpgradeK8s("1.33.2")
+		Expect(err).To(MatchError(ContainSubstring("invalid kubernetes version")))
+	})
+
+	It("fails when draining a worker fails", func() {
+		expectBinaryStaged()
+		expectBinaryReplaced("k0s-1", "k0scontroller")
+		nodeClient.EXPECT().RunCommand(nodeNamed("k0s-1"), "root", mock.MatchedBy(func(command string) bool {
+			return strings.Contains(command, "kubectl drain k0s-2")
+		})).Return(fmt.Errorf("pods not evictable")).Once()
+
+		err := bs.UpgradeK8s(version)
+		Expect(err).To(MatchError(ContainSubstring("failed to drain worker k0s-2")))
+	})
+})
@@ -0,0 +1,65 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/codesphere-cloud/oms/internal/version"
+)
+
+// devOmsVersion is the version of local developer builds, which are exempt
+// from the minimum oms-cli version check.
+const devOmsVersion = "0.0.0"
+
+// EnsureSupportedVersionSkew records the oms-cli version in the environment
+// and fails when the version combination of the environment is outside the
+// supported matrix published by the portal.
+func (b *GCPBootstrapper) EnsureSupportedVersionSkew() error {
+	b.Env.OmsVersion = (&version.Build{}).Version()
+	return b.checkVersionSkew(b.Env.KubernetesVersion)
+}
+
+// checkVersionSkew validates the Codesphere release, the given k0s version
+// and the running oms-cli against the portal's supported matrix. An
+// unreachable portal or a release missing from the matrix only warns, so
+// air-gapped and cutting-edge environments keep working.
+func (b *GCPBootstrapper) checkVersionSkew(k0sVersion string) error {
+	matrix, err := b.PortalClient.GetVersionMatrix()
+	if err != nil {
+		b.stlog.Logf("Warning: failed to fetch the supported version matrix: %v", err)
+		return nil
+	}
+	entry := matrix.Entry(b.Env.InstallVersion)
+	if entry == nil {
+		b.stlog.Logf("Warning: Codesphere %s is not listed in the supported version matrix; skipping the version skew check", b.Env.InstallVersion)
+		return nil
+	}
+
+	omsVersion := (&version.Build{}).Version()
+	if omsVersion != devOmsVersion && entry.MinOmsVersion != "" {
+		current, err := semver.NewVersion(omsVersion)
+		if err != nil {
+			return fmt.Errorf("failed to parse oms-cli version %q: %w", omsVersion, err)
+		}
+		minimum, err := semver.NewVersion(entry.MinOmsVersion)
+		if err != nil {
+			return fmt.Errorf("failed to parse minimum oms-cli version %q: %w", entry.MinOmsVersion, err)
+		}
+		if current.LessThan(minimum) {
+			return fmt.Errorf("oms-cli %s is too old for Codesphere %s: %s or newer is required", omsVersion, b.Env.InstallVersion, entry.MinOmsVersion)
+		}
+	}
+
+	if k0sVersion != "" && len(entry.K0sVersions) > 0 && !slices.Contains(entry.K0sVersions, k0sVersion) {
+		return fmt.Errorf("k0s %s is not supported with Codesphere %s; supported releases: %s",
+			k0sVersion, b.Env.InstallVersion, strings.Join(entry.K0sVersions, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/codesphere-cloud/oms/internal/bootstrap"
+	"github.com/codesphere-cloud/oms/internal/bootstrap/gcp"
+	"github.com/codesphere-cloud/oms/internal/cloudflare"
+	"github.com/codesphere-cloud/oms/internal/env"
+	"github.com/codesphere-cloud/oms/internal/github"
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/codesphere-cloud/oms/internal/portal"
+	"github.com/codesphere-cloud/oms/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EnsureSupportedVersionSkew", func() {
+	var (
+		mockPortalClient *portal.MockPortal
+		csEnv            *gcp.CodesphereEnvironment
+
+		bs *gcp.GCPBootstrapper
+	)
+
+	// matrix supports exactly one k0s release with the installed version.
+	matrix := portal.VersionMatrix{
+		Entries: []portal.VersionMatrixEntry{
+			{
+				CodesphereVersion: "v1.2.3",
+				K0sVersions:       []string{"v1.33.2+k0s.0"},
+				MinOmsVersion:     "1.0.0",
+			},
+		},
+	}
+
+	JustBeforeEach(func() {
+		var err error
+		bs, err = gcp.NewGCPBootstrapper(
+			context.Background(),
+			env.NewEnv(),
+			bootstrap.NewStepLogger(false),
+			csEnv,
+			installer.NewMockInstallConfigManager(GinkgoT()),
+			gcp.NewMockGCPClientManager(GinkgoT()),
+			util.NewMockFileIO(GinkgoT()),
+			node.NewMockNodeClient(GinkgoT()),
+			mockPortalClient,
+			util.NewFakeTime(),
+			github.NewMockGitHubClient(GinkgoT()),
+			cloudflare.NewMockCloudflareClient(GinkgoT()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	BeforeEach(func() {
+		mockPortalClient = portal.NewMockPortal(GinkgoT())
+		csEnv = &gcp.CodesphereEnvironment{
+			InstallVersion: "v1.2.3",
+		}
+	})
+
+	It("accepts a supported version combination and records the oms version", func() {
+		csEnv.KubernetesVersion = "v1.33.2+k0s.0"
+		mockPortalClient.EXPECT().GetVersionMatrix().Return(matrix, nil)
+
+		Expect(bs.EnsureSupportedVersionSkew()).To(Succeed())
+		Expect(csEnv.OmsVersion).NotTo(BeEmpty())
+	})
+
+	It("fails for a k0s release outside the matrix", func() {
+		csEnv.KubernetesVersion = "v1.28.0+k0s.0"
+		mockPortalClient.EXPECT().GetVersionMatrix().Return(matrix, nil)
+
+		err := bs.EnsureSupportedVersionSkew()
+		Expect(err).To(MatchError(ContainSubstring("k0s v1.28.0+k0s.0 is not supported with Codesphere v1.2.3")))
+	})
+
+	It("only warns when the portal is unreachable", func() {
+		mockPortalClient.EXPECT().GetVersionMatrix().Return(portal.VersionMatrix{}, fmt.Errorf("connection refused"))
+
+		Expect(bs.EnsureSupportedVersionSkew()).To(Succeed())
+	})
+
+	It("only warns when the release is not listed in the matrix", func() {
+		csEnv.InstallVersion = "v9.9.9"
+		csEnv.KubernetesVersion = "v1.28.0+k0s.0"
+		mockPortalClient.EXPECT().GetVersionMatrix().Return(matrix, nil)
+
+		Expect(bs.EnsureSupportedVersionSkew()).To(Succeed())
+	})
+})
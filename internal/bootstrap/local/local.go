@@ -94,6 +94,16 @@ type CodesphereEnvironment struct {
 	K0s                bool                `json:"-"`
 	PodCIDR            string              `json:"pod_cidr"`
 	ServiceCIDR        string              `json:"service_cidr"`
+	// MetalLB, for clusters without a cloud load-balancer integration
+	// (bare metal, Hetzner). NodeSubnet is the CIDR of the node network,
+	// used to validate the pool address ranges in L2 mode.
+	NodeSubnet            string   `json:"node_subnet,omitempty"`
+	MetalLBPool           []string `json:"metallb_pool,omitempty"`
+	MetalLBMode           string   `json:"metallb_mode,omitempty"`
+	MetalLBBGPMyASN       int      `json:"metallb_bgp_my_asn,omitempty"`
+	MetalLBBGPPeerASN     int      `json:"metallb_bgp_peer_asn,omitempty"`
+	MetalLBBGPPeerAddress string   `json:"metallb_bgp_peer_address,omitempty"`
+	MetalLBBGPBFDProfile  string   `json:"metallb_bgp_bfd_profile,omitempty"`
 	// ArgoCD integration
 	UseArgoCD         bool   `json:"-"`
 	ArgoCDRegistryURL string `json:"-"`
@@ -627,6 +637,10 @@ func (b *LocalBootstrapper) UpdateInstallConfig() (err error) {
 	b.Env.InstallConfig.Cluster.Gateway.ServiceType = "LoadBalancer"
 	b.Env.InstallConfig.Cluster.PublicGateway.ServiceType = "LoadBalancer"
 
+	if err := b.ConfigureMetalLB(); err != nil {
+		return fmt.Errorf("failed to configure MetalLB: %w", err)
+	}
+
 	// TODO: certificates
 	b.Env.InstallConfig.Codesphere.CertIssuer = files.CertIssuerConfig{
 		Type: "self-signed",
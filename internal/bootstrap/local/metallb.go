@@ -0,0 +1,142 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package local
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+)
+
+// MetalLB announcement modes selectable via --metallb-mode.
+const (
+	MetalLBModeL2  = "l2"
+	MetalLBModeBGP = "bgp"
+)
+
+// metalLBPoolName names the address pool and its advertisement in the
+// generated install config.
+const metalLBPoolName = "codesphere"
+
+// ValidateMetalLBConfig checks the MetalLB options of the environment before
+// any infrastructure is touched. Address ranges must be well-formed, and in L2
+// mode they must fall into the node subnet: L2 announcements only work when
+// the pool addresses share the segment of the nodes. BGP pools are routed and
+// may live outside the node subnet, but need the peer configuration.
+func ValidateMetalLBConfig(env *CodesphereEnvironment) error {
+	if len(env.MetalLBPool) == 0 {
+		return nil
+	}
+
+	switch env.MetalLBMode {
+	case MetalLBModeL2, "":
+		if env.NodeSubnet == "" {
+			return fmt.Errorf("--node-subnet is required to validate the MetalLB address ranges in L2 mode")
+		}
+		_, subnet, err := net.ParseCIDR(env.NodeSubnet)
+		if err != nil {
+			return fmt.Errorf("invalid node subnet %q: %w", env.NodeSubnet, err)
+		}
+		for _, pool := range env.MetalLBPool {
+			first, last, err := parseAddressRange(pool)
+			if err != nil {
+				return err
+			}
+			if !subnet.Contains(first) || !subnet.Contains(last) {
+				return fmt.Errorf("MetalLB address range %q is outside the node subnet %s; L2 announcements require pool addresses in the node segment", pool, env.NodeSubnet)
+			}
+		}
+	case MetalLBModeBGP:
+		for _, pool := range env.MetalLBPool {
+			if _, _, err := parseAddressRange(pool); err != nil {
+				return err
+			}
+		}
+		if env.MetalLBBGPMyASN == 0 || env.MetalLBBGPPeerASN == 0 || env.MetalLBBGPPeerAddress == "" {
+			return fmt.Errorf("MetalLB BGP mode requires --metallb-bgp-my-asn, --metallb-bgp-peer-asn and --metallb-bgp-peer-address")
+		}
+		if net.ParseIP(env.MetalLBBGPPeerAddress) == nil {
+			return fmt.Errorf("invalid MetalLB BGP peer address %q", env.MetalLBBGPPeerAddress)
+		}
+	default:
+		return fmt.Errorf("unsupported MetalLB mode %q (supported: %s, %s)", env.MetalLBMode, MetalLBModeL2, MetalLBModeBGP)
+	}
+
+	return nil
+}
+
+// parseAddressRange parses a MetalLB address pool entry, either a CIDR like
+// 10.0.0.64/26 or a range like 10.0.0.10-10.0.0.20, returning its first and
+// last address.
+func parseAddressRange(pool string) (first net.IP, last net.IP, err error) {
+	if from, to, isRange := strings.Cut(pool, "-"); isRange {
+		first = net.ParseIP(strings.TrimSpace(from))
+		last = net.ParseIP(strings.TrimSpace(to))
+		if first == nil || last == nil {
+			return nil, nil, fmt.Errorf("invalid MetalLB address range %q: both ends must be IP addresses", pool)
+		}
+		if (first.To4() == nil) != (last.To4() == nil) {
+			return nil, nil, fmt.Errorf("invalid MetalLB address range %q: both ends must be the same address family", pool)
+		}
+		if bytes.Compare(first.To16(), last.To16()) > 0 {
+			return nil, nil, fmt.Errorf("invalid MetalLB address range %q: start is after end", pool)
+		}
+		return first, last, nil
+	}
+
+	ip, subnet, err := net.ParseCIDR(pool)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid MetalLB address pool %q: %w", pool, err)
+	}
+	first = ip.Mask(subnet.Mask)
+	last = make(net.IP, len(first))
+	copy(last, first)
+	for i := range last {
+		last[i] |= ^subnet.Mask[i]
+	}
+	return first, last, nil
+}
+
+// ConfigureMetalLB populates the MetalLB section of the install config from
+// the environment, so clusters without a cloud load-balancer integration can
+// serve the gateway LoadBalancer services.
+func (b *LocalBootstrapper) ConfigureMetalLB() error {
+	if len(b.Env.MetalLBPool) == 0 {
+		return nil
+	}
+	if err := ValidateMetalLBConfig(b.Env); err != nil {
+		return err
+	}
+
+	cfg := &files.MetalLBConfig{
+		Enabled: true,
+		Pools: []files.MetalLBPoolDef{{
+			Name:        metalLBPoolName,
+			IPAddresses: b.Env.MetalLBPool,
+		}},
+	}
+	if b.Env.MetalLBMode == MetalLBModeBGP {
+		cfg.BGP = []files.MetalLBBGP{{
+			Name:  metalLBPoolName,
+			Pools: []string{metalLBPoolName},
+			Config: files.MetalLBBGPConfig{
+				MyASN:       b.Env.MetalLBBGPMyASN,
+				PeerASN:     b.Env.MetalLBBGPPeerASN,
+				PeerAddress: b.Env.MetalLBBGPPeerAddress,
+				BFDProfile:  b.Env.MetalLBBGPBFDProfile,
+			},
+		}}
+	} else {
+		cfg.L2 = []files.MetalLBL2{{
+			Name:  metalLBPoolName,
+			Pools: []string{metalLBPoolName},
+		}}
+	}
+
+	b.Env.InstallConfig.MetalLB = cfg
+	return nil
+}
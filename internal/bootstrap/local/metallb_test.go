@@ -0,0 +1,140 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package local
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+)
+
+var _ = Describe("MetalLB", func() {
+	var env *CodesphereEnvironment
+
+	BeforeEach(func() {
+		env = &CodesphereEnvironment{
+			NodeSubnet:  "10.0.0.0/24",
+			MetalLBPool: []string{"10.0.0.64/26"},
+		}
+	})
+
+	Describe("ValidateMetalLBConfig", func() {
+		It("accepts an environment without MetalLB pools", func() {
+			Expect(ValidateMetalLBConfig(&CodesphereEnvironment{})).To(Succeed())
+		})
+
+		It("accepts a CIDR pool inside the node subnet", func() {
+			Expect(ValidateMetalLBConfig(env)).To(Succeed())
+		})
+
+		It("accepts an address range inside the node subnet", func() {
+			env.MetalLBPool = []string{"10.0.0.10-10.0.0.20"}
+			Expect(ValidateMetalLBConfig(env)).To(Succeed())
+		})
+
+		It("requires the node subnet in L2 mode", func() {
+			env.NodeSubnet = ""
+			err := ValidateMetalLBConfig(env)
+			Expect(err).To(MatchError(ContainSubstring("--node-subnet is required")))
+		})
+
+		It("rejects a pool outside the node subnet in L2 mode", func() {
+			env.MetalLBPool = []string{"192.168.1.0/28"}
+			err := ValidateMetalLBConfig(env)
+			Expect(err).To(MatchError(ContainSubstring("outside the node subnet")))
+		})
+
+		It("rejects a CIDR pool reaching beyond the node subnet", func() {
+			env.MetalLBPool = []string{"10.0.0.0/23"}
+			err := ValidateMetalLBConfig(env)
+			Expect(err).To(MatchError(ContainSubstring("outside the node subnet")))
+		})
+
+		It("rejects a range whose start is after its end", func() {
+			env.MetalLBPool = []string{"10.0.0.20-10.0.0.10"}
+			err := ValidateMetalLBConfig(env)
+			Expect(err).To(MatchError(ContainSubstring("start is after end")))
+		})
+
+		It("allows BGP pools outside the node subnet", func() {
+			env.MetalLBMode = MetalLBModeBGP
+			env.MetalLBPool = []string{"203.0.113.0/28"}
+			env.MetalLBBGPMyASN = 64512
+			env.MetalLBBGPPeerASN = 64513
+			env.MetalLBBGPPeerAddress = "10.0.0.1"
+			Expect(ValidateMetalLBConfig(env)).To(Succeed())
+		})
+
+		It("requires the peer configuration in BGP mode", func() {
+			env.MetalLBMode = MetalLBModeBGP
+			err := ValidateMetalLBConfig(env)
+			Expect(err).To(MatchError(ContainSubstring("requires --metallb-bgp-my-asn")))
+		})
+
+		It("rejects an invalid BGP peer address", func() {
+			env.MetalLBMode = MetalLBModeBGP
+			env.MetalLBBGPMyASN = 64512
+			env.MetalLBBGPPeerASN = 64513
+			env.MetalLBBGPPeerAddress = "not-an-ip"
+			err := ValidateMetalLBConfig(env)
+			Expect(err).To(MatchError(ContainSubstring("invalid MetalLB BGP peer address")))
+		})
+
+		It("rejects an unsupported mode", func() {
+			env.MetalLBMode = "arp"
+			err := ValidateMetalLBConfig(env)
+			Expect(err).To(MatchError(ContainSubstring("unsupported MetalLB mode")))
+		})
+	})
+
+	Describe("ConfigureMetalLB", func() {
+		var b *LocalBootstrapper
+
+		BeforeEach(func() {
+			env.InstallConfig = &files.RootConfig{}
+			b = &LocalBootstrapper{Env: env}
+		})
+
+		It("leaves the install config untouched without pools", func() {
+			env.MetalLBPool = nil
+			Expect(b.ConfigureMetalLB()).To(Succeed())
+			Expect(env.InstallConfig.MetalLB).To(BeNil())
+		})
+
+		It("configures an L2 advertisement for the pool", func() {
+			Expect(b.ConfigureMetalLB()).To(Succeed())
+
+			cfg := env.InstallConfig.MetalLB
+			Expect(cfg.Enabled).To(BeTrue())
+			Expect(cfg.Pools).To(Equal([]files.MetalLBPoolDef{{Name: "codesphere", IPAddresses: []string{"10.0.0.64/26"}}}))
+			Expect(cfg.L2).To(Equal([]files.MetalLBL2{{Name: "codesphere", Pools: []string{"codesphere"}}}))
+			Expect(cfg.BGP).To(BeEmpty())
+		})
+
+		It("configures a BGP peering for the pool", func() {
+			env.MetalLBMode = MetalLBModeBGP
+			env.MetalLBBGPMyASN = 64512
+			env.MetalLBBGPPeerASN = 64513
+			env.MetalLBBGPPeerAddress = "10.0.0.1"
+			env.MetalLBBGPBFDProfile = "fast"
+
+			Expect(b.ConfigureMetalLB()).To(Succeed())
+
+			cfg := env.InstallConfig.MetalLB
+			Expect(cfg.Enabled).To(BeTrue())
+			Expect(cfg.L2).To(BeEmpty())
+			Expect(cfg.BGP).To(Equal([]files.MetalLBBGP{{
+				Name:  "codesphere",
+				Pools: []string{"codesphere"},
+				Config: files.MetalLBBGPConfig{
+					MyASN:       64512,
+					PeerASN:     64513,
+					PeerAddress: "10.0.0.1",
+					BFDProfile:  "fast",
+				},
+			}}))
+		})
+	})
+})
@@ -0,0 +1,54 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultNodeParallelism bounds how many nodes a per-node step works on
+// concurrently when no explicit limit is configured.
+const DefaultNodeParallelism = 4
+
+// NodeTask is a unit of per-node work. Name identifies the node in
+// aggregated error messages.
+type NodeTask struct {
+	Name string
+	Run  func() error
+}
+
+// RunNodeTasks executes tasks concurrently with at most parallelism tasks in
+// flight; values below 1 fall back to DefaultNodeParallelism. Every task runs
+// to completion regardless of failures elsewhere, and errors are prefixed
+// with the task name and joined into a single error.
+func RunNodeTasks(parallelism int, tasks []NodeTask) error {
+	if parallelism < 1 {
+		parallelism = DefaultNodeParallelism
+	}
+
+	sem := make(chan struct{}, parallelism)
+	errCh := make(chan error, len(tasks))
+	wg := sync.WaitGroup{}
+	for _, task := range tasks {
+		wg.Add(1)
+		go func(t NodeTask) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if err := t.Run(); err != nil {
+				errCh <- fmt.Errorf("%s: %w", t.Name, err)
+			}
+		}(task)
+	}
+	wg.Wait()
+	close(errCh)
+
+	var errs []error
+	for err := range errCh {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// reportHTMLTemplate renders the step records as a standalone HTML table.
+const reportHTMLTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<title>Bootstrap report</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 12px; text-align: left; }
+tr.failed { background: #fdd; }
+</style>
+</head>
+<body>
+<h1>Bootstrap report</h1>
+<table>
+<tr><th>Step</th><th>Duration</th><th>Retries</th><th>Result</th></tr>
+{{- range . }}
+<tr{{ if .Failed }} class="failed"{{ end }}><td>{{ .Name }}</td><td>{{ printf "%.1fs" .DurationSeconds }}</td><td>{{ .Retries }}</td><td>{{ if .Failed }}failed: {{ .Error }}{{ else }}ok{{ end }}</td></tr>
+{{- end }}
+</table>
+</body>
+</html>
+`
+
+// PrintSummary writes a table of the executed steps sorted by duration,
+// longest first, so the steps dominating the bootstrap time stand out.
+func (b *StepLogger) PrintSummary(out io.Writer) error {
+	if len(b.records) == 0 {
+		return nil
+	}
+
+	sorted := make([]StepRecord, len(b.records))
+	copy(sorted, b.records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Duration > sorted[j].Duration
+	})
+
+	var total time.Duration
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STEP\tDURATION\tRETRIES\tRESULT")
+	for _, stepRecord := range sorted {
+		total += stepRecord.Duration
+		result := "ok"
+		if stepRecord.Failed {
+			result = "failed"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", stepRecord.Name, stepRecord.Duration.Round(100*time.Millisecond), stepRecord.Retries, result)
+	}
+	fmt.Fprintf(w, "TOTAL\t%s\t\t\n", total.Round(100*time.Millisecond))
+
+	return w.Flush()
+}
+
+// WriteReport writes the step records to the given directory as
+// bootstrap-report.json and bootstrap-report.html.
+func (b *StepLogger) WriteReport(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+
+	reportJSON, err := json.MarshalIndent(b.records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal bootstrap report: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bootstrap-report.json"), reportJSON, 0644); err != nil {
+		return fmt.Errorf("failed to write bootstrap report: %w", err)
+	}
+
+	htmlTemplate, err := template.New("report").Parse(reportHTMLTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse bootstrap report template: %w", err)
+	}
+	htmlFile, err := os.Create(filepath.Join(dir, "bootstrap-report.html"))
+	if err != nil {
+		return fmt.Errorf("failed to create bootstrap report: %w", err)
+	}
+	defer func() { _ = htmlFile.Close() }()
+	if err := htmlTemplate.Execute(htmlFile, b.records); err != nil {
+		return fmt.Errorf("failed to render bootstrap report: %w", err)
+	}
+
+	return nil
+}
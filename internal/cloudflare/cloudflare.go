@@ -0,0 +1,214 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflare
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const DefaultBaseURL = "https://api.cloudflare.com/client/v4"
+
+// CloudflareClient abstracts the Cloudflare API calls used for the
+// Cloudflare-proxied exposure mode.
+//
+//mockery:generate: true
+type CloudflareClient interface {
+	// EnsureDNSRecord creates a DNS record in the zone. Records that already
+	// exist with identical content are treated as success.
+	EnsureDNSRecord(zoneID, recordType, name, content string, proxied bool) error
+	// CreateOriginCertificate issues a Cloudflare origin CA certificate for the
+	// given hostnames and returns the certificate and private key in PEM format.
+	CreateOriginCertificate(hostnames []string) (certPEM string, keyPEM string, err error)
+	// GetIPRanges returns the IPv4 and IPv6 CIDR ranges Cloudflare proxies
+	// traffic from.
+	GetIPRanges() ([]string, error)
+}
+
+// Client is the concrete Cloudflare API client.
+type Client struct {
+	APIToken   string
+	BaseURL    string
+	HttpClient *http.Client
+}
+
+// NewCloudflareClient creates a new Client authenticating with the given API token.
+func NewCloudflareClient(apiToken string) *Client {
+	return &Client{
+		APIToken:   apiToken,
+		BaseURL:    DefaultBaseURL,
+		HttpClient: http.DefaultClient,
+	}
+}
+
+// apiResponse is the common envelope of Cloudflare v4 API responses.
+type apiResponse struct {
+	Success bool            `json:"success"`
+	Errors  []apiError      `json:"errors"`
+	Result  json.RawMessage `json:"result"`
+}
+
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// request sends an authenticated request and decodes the response envelope.
+func (c *Client) request(method, path string, body any) (*apiResponse, error) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+path, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.APIToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	apiResp := &apiResponse{}
+	if err := json.Unmarshal(respBody, apiResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response (status %d): %w", resp.StatusCode, err)
+	}
+
+	return apiResp, nil
+}
+
+// errorMessages joins all error messages of a failed API response.
+func (r *apiResponse) errorMessages() string {
+	messages := []string{}
+	for _, e := range r.Errors {
+		messages = append(messages, fmt.Sprintf("%d: %s", e.Code, e.Message))
+	}
+	return strings.Join(messages, "; ")
+}
+
+// recordAlreadyExistsCode is returned by Cloudflare when an identical DNS
+// record already exists in the zone.
+const recordAlreadyExistsCode = 81058
+
+// EnsureDNSRecord creates a proxied or unproxied DNS record in the given zone.
+func (c *Client) EnsureDNSRecord(zoneID, recordType, name, content string, proxied bool) error {
+	body := map[string]any{
+		"type":    recordType,
+		"name":    name,
+		"content": content,
+		"proxied": proxied,
+		// TTL 1 means "automatic" and is required for proxied records.
+		"ttl": 1,
+	}
+
+	resp, err := c.request(http.MethodPost, fmt.Sprintf("/zones/%s/dns_records", zoneID), body)
+	if err != nil {
+		return err
+	}
+
+	if !resp.Success {
+		for _, e := range resp.Errors {
+			if e.Code == recordAlreadyExistsCode {
+				return nil
+			}
+		}
+		return fmt.Errorf("failed to create DNS record %s: %s", name, resp.errorMessages())
+	}
+
+	return nil
+}
+
+// CreateOriginCertificate generates a private key and CSR locally and requests
+// an origin CA certificate for the given hostnames.
+func (c *Client) CreateOriginCertificate(hostnames []string) (string, string, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate private key: %w", err)
+	}
+
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: hostnames[0]},
+		DNSNames: hostnames,
+	}, key)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create certificate request: %w", err)
+	}
+	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+
+	body := map[string]any{
+		"hostnames":          hostnames,
+		"request_type":       "origin-rsa",
+		"requested_validity": 5475,
+		"csr":                string(csrPEM),
+	}
+
+	resp, err := c.request(http.MethodPost, "/certificates", body)
+	if err != nil {
+		return "", "", err
+	}
+	if !resp.Success {
+		return "", "", fmt.Errorf("failed to create origin certificate: %s", resp.errorMessages())
+	}
+
+	result := struct {
+		Certificate string `json:"certificate"`
+	}{}
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		return "", "", fmt.Errorf("failed to decode certificate response: %w", err)
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	return result.Certificate, string(keyPEM), nil
+}
+
+// GetIPRanges fetches the current Cloudflare proxy IP ranges.
+func (c *Client) GetIPRanges() ([]string, error) {
+	resp, err := c.request(http.MethodGet, "/ips", nil)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("failed to fetch Cloudflare IP ranges: %s", resp.errorMessages())
+	}
+
+	result := struct {
+		IPv4CIDRs []string `json:"ipv4_cidrs"`
+		IPv6CIDRs []string `json:"ipv6_cidrs"`
+	}{}
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode IP ranges response: %w", err)
+	}
+
+	ranges := append(result.IPv4CIDRs, result.IPv6CIDRs...)
+	if len(ranges) == 0 {
+		return nil, fmt.Errorf("cloudflare returned no IP ranges")
+	}
+
+	return ranges, nil
+}
@@ -0,0 +1,16 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflare_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCloudflare(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cloudflare Suite")
+}
@@ -0,0 +1,118 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflare_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/codesphere-cloud/oms/internal/cloudflare"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		server  *httptest.Server
+		client  *cloudflare.Client
+		handler http.HandlerFunc
+	)
+
+	BeforeEach(func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler(w, r)
+		}))
+		client = cloudflare.NewCloudflareClient("test-token")
+		client.BaseURL = server.URL
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("EnsureDNSRecord", func() {
+		It("creates a proxied record", func() {
+			var gotBody map[string]any
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Method).To(Equal(http.MethodPost))
+				Expect(r.URL.Path).To(Equal("/zones/zone-1/dns_records"))
+				Expect(r.Header.Get("Authorization")).To(Equal("Bearer test-token"))
+				Expect(json.NewDecoder(r.Body).Decode(&gotBody)).To(Succeed())
+				_, _ = w.Write([]byte(`{"success": true, "errors": [], "result": {}}`))
+			}
+
+			err := client.EnsureDNSRecord("zone-1", "A", "cs.example.com", "1.2.3.4", true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gotBody["proxied"]).To(BeTrue())
+			Expect(gotBody["ttl"]).To(BeNumerically("==", 1))
+		})
+
+		It("treats an identical existing record as success", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"success": false, "errors": [{"code": 81058, "message": "record already exists"}]}`))
+			}
+
+			err := client.EnsureDNSRecord("zone-1", "A", "cs.example.com", "1.2.3.4", true)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("fails on other API errors", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"success": false, "errors": [{"code": 10000, "message": "authentication error"}]}`))
+			}
+
+			err := client.EnsureDNSRecord("zone-1", "A", "cs.example.com", "1.2.3.4", true)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("authentication error"))
+		})
+	})
+
+	Describe("CreateOriginCertificate", func() {
+		It("sends a CSR and returns the certificate with a generated key", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Path).To(Equal("/certificates"))
+				body := map[string]any{}
+				Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+				Expect(body["csr"]).To(ContainSubstring("BEGIN CERTIFICATE REQUEST"))
+				Expect(body["hostnames"]).To(HaveLen(2))
+				_, _ = w.Write([]byte(`{"success": true, "errors": [], "result": {"certificate": "fake-cert-pem"}}`))
+			}
+
+			cert, key, err := client.CreateOriginCertificate([]string{"example.com", "*.example.com"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cert).To(Equal("fake-cert-pem"))
+			Expect(key).To(ContainSubstring("BEGIN RSA PRIVATE KEY"))
+		})
+	})
+
+	Describe("GetIPRanges", func() {
+		It("returns the combined IPv4 and IPv6 ranges", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Path).To(Equal("/ips"))
+				_, _ = w.Write([]byte(`{"success": true, "errors": [], "result": {"ipv4_cidrs": ["173.245.48.0/20"], "ipv6_cidrs": ["2400:cb00::/32"]}}`))
+			}
+
+			ranges, err := client.GetIPRanges()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ranges).To(Equal([]string{"173.245.48.0/20", "2400:cb00::/32"}))
+		})
+
+		It("fails when no ranges are returned", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"success": true, "errors": [], "result": {}}`))
+			}
+
+			_, err := client.GetIPRanges()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no IP ranges"))
+		})
+	})
+})
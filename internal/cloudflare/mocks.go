@@ -0,0 +1,232 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package cloudflare
+
+import (
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockCloudflareClient creates a new instance of MockCloudflareClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCloudflareClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCloudflareClient {
+	mock := &MockCloudflareClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCloudflareClient is an autogenerated mock type for the CloudflareClient type
+type MockCloudflareClient struct {
+	mock.Mock
+}
+
+type MockCloudflareClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCloudflareClient) EXPECT() *MockCloudflareClient_Expecter {
+	return &MockCloudflareClient_Expecter{mock: &_m.Mock}
+}
+
+// CreateOriginCertificate provides a mock function for the type MockCloudflareClient
+func (_mock *MockCloudflareClient) CreateOriginCertificate(hostnames []string) (string, string, error) {
+	ret := _mock.Called(hostnames)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOriginCertificate")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func([]string) (string, string, error)); ok {
+		return returnFunc(hostnames)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]string) string); ok {
+		r0 = returnFunc(hostnames)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func([]string) string); ok {
+		r1 = returnFunc(hostnames)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func([]string) error); ok {
+		r2 = returnFunc(hostnames)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockCloudflareClient_CreateOriginCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOriginCertificate'
+type MockCloudflareClient_CreateOriginCertificate_Call struct {
+	*mock.Call
+}
+
+// CreateOriginCertificate is a helper method to define mock.On call
+//   - hostnames []string
+func (_e *MockCloudflareClient_Expecter) CreateOriginCertificate(hostnames any) *MockCloudflareClient_CreateOriginCertificate_Call {
+	return &MockCloudflareClient_CreateOriginCertificate_Call{Call: _e.mock.On("CreateOriginCertificate", hostnames)}
+}
+
+func (_c *MockCloudflareClient_CreateOriginCertificate_Call) Run(run func(hostnames []string)) *MockCloudflareClient_CreateOriginCertificate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []string
+		if args[0] != nil {
+			arg0 = args[0].([]string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCloudflareClient_CreateOriginCertificate_Call) Return(certPEM string, keyPEM string, err error) *MockCloudflareClient_CreateOriginCertificate_Call {
+	_c.Call.Return(certPEM, keyPEM, err)
+	return _c
+}
+
+func (_c *MockCloudflareClient_CreateOriginCertificate_Call) RunAndReturn(run func(hostnames []string) (string, string, error)) *MockCloudflareClient_CreateOriginCertificate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnsureDNSRecord provides a mock function for the type MockCloudflareClient
+func (_mock *MockCloudflareClient) EnsureDNSRecord(zoneID string, recordType string, name string, content string, proxied bool) error {
+	ret := _mock.Called(zoneID, recordType, name, content, proxied)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureDNSRecord")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, string, bool) error); ok {
+		r0 = returnFunc(zoneID, recordType, name, content, proxied)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCloudflareClient_EnsureDNSRecord_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureDNSRecord'
+type MockCloudflareClient_EnsureDNSRecord_Call struct {
+	*mock.Call
+}
+
+// EnsureDNSRecord is a helper method to define mock.On call
+//   - zoneID string
+//   - recordType string
+//   - name string
+//   - content string
+//   - proxied bool
+func (_e *MockCloudflareClient_Expecter) EnsureDNSRecord(zoneID any, recordType any, name any, content any, proxied any) *MockCloudflareClient_EnsureDNSRecord_Call {
+	return &MockCloudflareClient_EnsureDNSRecord_Call{Call: _e.mock.On("EnsureDNSRecord", zoneID, recordType, name, content, proxied)}
+}
+
+func (_c *MockCloudflareClient_EnsureDNSRecord_Call) Run(run func(zoneID string, recordType string, name string, content string, proxied bool)) *MockCloudflareClient_EnsureDNSRecord_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 bool
+		if args[4] != nil {
+			arg4 = args[4].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCloudflareClient_EnsureDNSRecord_Call) Return(err error) *MockCloudflareClient_EnsureDNSRecord_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCloudflareClient_EnsureDNSRecord_Call) RunAndReturn(run func(zoneID string, recordType string, name string, content string, proxied bool) error) *MockCloudflareClient_EnsureDNSRecord_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIPRanges provides a mock function for the type MockCloudflareClient
+func (_mock *MockCloudflareClient) GetIPRanges() ([]string, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIPRanges")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]string, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []string); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCloudflareClient_GetIPRanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIPRanges'
+type MockCloudflareClient_GetIPRanges_Call struct {
+	*mock.Call
+}
+
+// GetIPRanges is a helper method to define mock.On call
+func (_e *MockCloudflareClient_Expecter) GetIPRanges() *MockCloudflareClient_GetIPRanges_Call {
+	return &MockCloudflareClient_GetIPRanges_Call{Call: _e.mock.On("GetIPRanges")}
+}
+
+func (_c *MockCloudflareClient_GetIPRanges_Call) Run(run func()) *MockCloudflareClient_GetIPRanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockCloudflareClient_GetIPRanges_Call) Return(strings []string, err error) *MockCloudflareClient_GetIPRanges_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockCloudflareClient_GetIPRanges_Call) RunAndReturn(run func() ([]string, error)) *MockCloudflareClient_GetIPRanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -7,6 +7,9 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
+	"strings"
 	"text/template"
 )
 
@@ -15,10 +18,52 @@ type SecretStore interface {
 	LookupSecret(name string, selector ...string) (string, error)
 }
 
-// RenderInstallConfigTemplate renders the given config template data, resolving
-// any `secret` template calls against store, and returns the rendered bytes.
-// Referencing a missing template key or a missing secret is treated as an error.
+// envVarPattern matches `${VAR}` references and their escaped form `$${VAR}`.
+// Only the braced syntax is recognized, so plain `$` characters in config
+// values pass through untouched.
+var envVarPattern = regexp.MustCompile(`\$\$?\{[A-Za-z_][A-Za-z0-9_]*\}`)
+
+// ExpandEnvVars replaces `${VAR}` references in the given config data with
+// the value of the corresponding environment variable. Referencing an unset
+// variable is an error, so a stale template fails loudly instead of producing
+// a config with holes. `$${VAR}` escapes the substitution and renders a
+// literal `${VAR}`.
+func ExpandEnvVars(data []byte) ([]byte, error) {
+	missing := map[string]bool{}
+	expanded := envVarPattern.ReplaceAllFunc(data, func(match []byte) []byte {
+		if bytes.HasPrefix(match, []byte("$${")) {
+			return match[1:]
+		}
+		name := string(match[2 : len(match)-1])
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			missing[name] = true
+			return match
+		}
+		return []byte(value)
+	})
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("config template references unset environment variable(s): %s", strings.Join(names, ", "))
+	}
+	return expanded, nil
+}
+
+// RenderInstallConfigTemplate renders the given config template data, first
+// expanding `${ENV_VAR}` references, then resolving Go template expressions.
+// `secret` template calls are resolved against store, `env` calls against the
+// environment. Referencing a missing template key, a missing secret or an
+// unset environment variable is treated as an error.
 func RenderInstallConfigTemplate(data []byte, store SecretStore) ([]byte, error) {
+	data, err := ExpandEnvVars(data)
+	if err != nil {
+		return nil, err
+	}
+
 	tmpl, err := template.New("install-config").
 		Option("missingkey=error").
 		Funcs(template.FuncMap{
@@ -28,6 +73,13 @@ func RenderInstallConfigTemplate(data []byte, store SecretStore) ([]byte, error)
 				}
 				return store.LookupSecret(name, selector...)
 			},
+			"env": func(name string) (string, error) {
+				value, ok := os.LookupEnv(name)
+				if !ok {
+					return "", fmt.Errorf("environment variable %s is not set", name)
+				}
+				return value, nil
+			},
 		}).
 		Parse(string(data))
 	if err != nil {
@@ -0,0 +1,277 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package drift compares an install config against the state of a running
+// cluster. It reports differences introduced after the installation, e.g. by
+// manual kubectl edits, so they can be reviewed before an upgrade overwrites
+// or collides with them.
+package drift
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// SystemNamespace is the namespace the gateway controllers run in.
+	SystemNamespace = "codesphere-system"
+	// PlatformNamespace is the namespace the platform workloads run in.
+	PlatformNamespace = "codesphere"
+
+	// GatewayServiceName is the service of the internal ingress-nginx release.
+	GatewayServiceName = "gateway-ingress-nginx-controller"
+	// PublicGatewayServiceName is the service of the public ingress-nginx release.
+	PublicGatewayServiceName = "public-gateway-ingress-nginx-controller"
+
+	// AppliedConfigMapName is the config map the installer publishes the
+	// applied install config to. It is used to verify settings that are not
+	// directly observable as Kubernetes resources, such as plans.
+	AppliedConfigMapName = "codesphere-install-config"
+	// AppliedConfigMapKey is the data key holding the applied config YAML.
+	AppliedConfigMapKey = "config.yaml"
+)
+
+// Item describes a single detected difference between the install config and
+// the cluster.
+type Item struct {
+	// Area groups the finding, e.g. "gateway", "domains", "registry", "plans".
+	Area string `json:"area"`
+	// Message describes the difference in human-readable form.
+	Message string `json:"message"`
+}
+
+func (i Item) String() string {
+	return fmt.Sprintf("%s: %s", i.Area, i.Message)
+}
+
+// Checker compares an install config against a cluster.
+type Checker struct {
+	Clientset kubernetes.Interface
+	Config    *files.RootConfig
+}
+
+func NewChecker(clientset kubernetes.Interface, config *files.RootConfig) *Checker {
+	return &Checker{
+		Clientset: clientset,
+		Config:    config,
+	}
+}
+
+// Check runs all drift checks and returns the detected differences. An empty
+// result means the cluster matches the install config. Errors are only
+// returned for failed cluster queries, not for detected drift.
+func (c *Checker) Check(ctx context.Context) ([]Item, error) {
+	if c.Config == nil {
+		return nil, fmt.Errorf("install config is required")
+	}
+
+	items := []Item{}
+
+	gatewayItems, err := c.checkGateway(ctx, "gateway", GatewayServiceName, c.Config.Cluster.Gateway)
+	if err != nil {
+		return nil, err
+	}
+	items = append(items, gatewayItems...)
+
+	publicGatewayItems, err := c.checkGateway(ctx, "publicGateway", PublicGatewayServiceName, c.Config.Cluster.PublicGateway)
+	if err != nil {
+		return nil, err
+	}
+	items = append(items, publicGatewayItems...)
+
+	domainItems, err := c.checkDomains(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items = append(items, domainItems...)
+
+	registryItems, err := c.checkRegistry(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items = append(items, registryItems...)
+
+	planItems, err := c.checkPlans(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items = append(items, planItems...)
+
+	return items, nil
+}
+
+// checkGateway compares service type and external IPs of a gateway service
+// with its GatewayConfig.
+func (c *Checker) checkGateway(ctx context.Context, area string, serviceName string, cfg files.GatewayConfig) ([]Item, error) {
+	svc, err := c.Clientset.CoreV1().Services(SystemNamespace).Get(ctx, serviceName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return []Item{{Area: area, Message: fmt.Sprintf("service %s/%s not found in cluster", SystemNamespace, serviceName)}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service %s/%s: %w", SystemNamespace, serviceName, err)
+	}
+
+	items := []Item{}
+
+	if cfg.ServiceType != "" && string(svc.Spec.Type) != cfg.ServiceType {
+		items = append(items, Item{
+			Area:    area,
+			Message: fmt.Sprintf("service %s has type %s, config expects %s", serviceName, svc.Spec.Type, cfg.ServiceType),
+		})
+	}
+
+	if len(cfg.IPAddresses) > 0 {
+		actual := serviceIPs(svc)
+		for _, ip := range missingStrings(cfg.IPAddresses, actual) {
+			items = append(items, Item{
+				Area:    area,
+				Message: fmt.Sprintf("IP %s from config is not served by service %s", ip, serviceName),
+			})
+		}
+		for _, ip := range missingStrings(actual, cfg.IPAddresses) {
+			items = append(items, Item{
+				Area:    area,
+				Message: fmt.Sprintf("service %s serves IP %s which is not in the config", serviceName, ip),
+			})
+		}
+	}
+
+	return items, nil
+}
+
+// checkDomains verifies that every ingress host in the platform namespace is
+// covered by the configured Codesphere domain or workspace hosting domain.
+func (c *Checker) checkDomains(ctx context.Context) ([]Item, error) {
+	ingresses, err := c.Clientset.NetworkingV1().Ingresses(PlatformNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ingresses in %s: %w", PlatformNamespace, err)
+	}
+
+	items := []Item{}
+	for _, ingress := range ingresses.Items {
+		for _, rule := range ingress.Spec.Rules {
+			if rule.Host == "" || c.domainCovered(rule.Host) {
+				continue
+			}
+			items = append(items, Item{
+				Area:    "domains",
+				Message: fmt.Sprintf("ingress %s serves host %s which is not covered by the configured domains", ingress.Name, rule.Host),
+			})
+		}
+	}
+
+	return items, nil
+}
+
+// domainCovered reports whether host matches the configured Codesphere domain
+// or the workspace hosting base domain, directly or as a subdomain.
+func (c *Checker) domainCovered(host string) bool {
+	for _, domain := range []string{c.Config.Codesphere.Domain, c.Config.Codesphere.WorkspaceHostingBaseDomain} {
+		if domain == "" {
+			continue
+		}
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkRegistry verifies that the platform deployments pull their images from
+// the configured registry. The check only applies when the config rewrites
+// BOM images to a local registry.
+func (c *Checker) checkRegistry(ctx context.Context) ([]Item, error) {
+	registry := c.Config.Registry
+	if registry == nil || registry.Server == "" || !registry.ReplaceImagesInBom {
+		return nil, nil
+	}
+
+	deployments, err := c.Clientset.AppsV1().Deployments(PlatformNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments in %s: %w", PlatformNamespace, err)
+	}
+
+	items := []Item{}
+	for _, deployment := range deployments.Items {
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			if strings.HasPrefix(container.Image, registry.Server+"/") {
+				continue
+			}
+			items = append(items, Item{
+				Area:    "registry",
+				Message: fmt.Sprintf("deployment %s container %s uses image %s which is not from the configured registry %s", deployment.Name, container.Name, container.Image, registry.Server),
+			})
+		}
+	}
+
+	return items, nil
+}
+
+// checkPlans compares the configured hosting and workspace plans with the
+// applied install config published in the cluster. When the applied config is
+// not available, the plans cannot be verified and a single finding says so.
+func (c *Checker) checkPlans(ctx context.Context) ([]Item, error) {
+	cm, err := c.Clientset.CoreV1().ConfigMaps(SystemNamespace).Get(ctx, AppliedConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return []Item{{
+			Area:    "plans",
+			Message: fmt.Sprintf("applied install config %s/%s not found in cluster, cannot verify plans", SystemNamespace, AppliedConfigMapName),
+		}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config map %s/%s: %w", SystemNamespace, AppliedConfigMapName, err)
+	}
+
+	applied := files.RootConfig{}
+	if err := applied.Unmarshal([]byte(cm.Data[AppliedConfigMapKey])); err != nil {
+		return nil, fmt.Errorf("failed to parse applied install config from %s/%s: %w", SystemNamespace, AppliedConfigMapName, err)
+	}
+
+	items := []Item{}
+	if !reflect.DeepEqual(c.Config.Codesphere.Plans.HostingPlans, applied.Codesphere.Plans.HostingPlans) {
+		items = append(items, Item{Area: "plans", Message: "hosting plans differ between config and cluster"})
+	}
+	if !reflect.DeepEqual(c.Config.Codesphere.Plans.WorkspacePlans, applied.Codesphere.Plans.WorkspacePlans) {
+		items = append(items, Item{Area: "plans", Message: "workspace plans differ between config and cluster"})
+	}
+
+	return items, nil
+}
+
+// serviceIPs collects the externally visible IPs of a service: load balancer
+// ingress IPs plus explicitly assigned external IPs.
+func serviceIPs(svc *corev1.Service) []string {
+	ips := []string{}
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			ips = append(ips, ingress.IP)
+		}
+	}
+	ips = append(ips, svc.Spec.ExternalIPs...)
+	sort.Strings(ips)
+	return ips
+}
+
+// missingStrings returns the elements of want that are not present in have.
+func missingStrings(want []string, have []string) []string {
+	haveSet := map[string]bool{}
+	for _, s := range have {
+		haveSet[s] = true
+	}
+	missing := []string{}
+	for _, s := range want {
+		if !haveSet[s] {
+			missing = append(missing, s)
+		}
+	}
+	return missing
+}
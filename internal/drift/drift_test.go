@@ -0,0 +1,248 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package drift_test
+
+import (
+	"context"
+
+	"github.com/codesphere-cloud/oms/internal/drift"
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Checker", func() {
+	var (
+		ctx       context.Context
+		clientset *fake.Clientset
+		config    *files.RootConfig
+		checker   *drift.Checker
+	)
+
+	gatewayService := func(name string, serviceType corev1.ServiceType, ips ...string) *corev1.Service {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: drift.SystemNamespace,
+			},
+			Spec: corev1.ServiceSpec{
+				Type: serviceType,
+			},
+		}
+		for _, ip := range ips {
+			svc.Status.LoadBalancer.Ingress = append(svc.Status.LoadBalancer.Ingress, corev1.LoadBalancerIngress{IP: ip})
+		}
+		return svc
+	}
+
+	appliedConfigMap := func(applied *files.RootConfig) *corev1.ConfigMap {
+		data, err := applied.Marshal()
+		Expect(err).ToNot(HaveOccurred())
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      drift.AppliedConfigMapName,
+				Namespace: drift.SystemNamespace,
+			},
+			Data: map[string]string{drift.AppliedConfigMapKey: string(data)},
+		}
+	}
+
+	itemsForArea := func(items []drift.Item, area string) []drift.Item {
+		matching := []drift.Item{}
+		for _, item := range items {
+			if item.Area == area {
+				matching = append(matching, item)
+			}
+		}
+		return matching
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		config = files.NewTestRootConfig()
+		config.Cluster.Gateway = files.GatewayConfig{
+			ServiceType: "LoadBalancer",
+			IPAddresses: []string{"10.52.0.1"},
+		}
+		config.Cluster.PublicGateway = files.GatewayConfig{
+			ServiceType: "LoadBalancer",
+			IPAddresses: []string{"10.52.0.2"},
+		}
+
+		clientset = fake.NewClientset(
+			gatewayService(drift.GatewayServiceName, corev1.ServiceTypeLoadBalancer, "10.52.0.1"),
+			gatewayService(drift.PublicGatewayServiceName, corev1.ServiceTypeLoadBalancer, "10.52.0.2"),
+			appliedConfigMap(files.NewTestRootConfig()),
+		)
+		checker = drift.NewChecker(clientset, config)
+	})
+
+	It("reports no drift when the cluster matches the config", func() {
+		items, err := checker.Check(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(items).To(BeEmpty())
+	})
+
+	Describe("gateway checks", func() {
+		It("reports a missing gateway service", func() {
+			Expect(clientset.CoreV1().Services(drift.SystemNamespace).Delete(ctx, drift.GatewayServiceName, metav1.DeleteOptions{})).To(Succeed())
+
+			items, err := checker.Check(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			gatewayItems := itemsForArea(items, "gateway")
+			Expect(gatewayItems).To(HaveLen(1))
+			Expect(gatewayItems[0].Message).To(ContainSubstring("not found in cluster"))
+		})
+
+		It("reports a changed service type", func() {
+			svc := gatewayService(drift.GatewayServiceName, corev1.ServiceTypeClusterIP, "10.52.0.1")
+			_, err := clientset.CoreV1().Services(drift.SystemNamespace).Update(ctx, svc, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			items, err := checker.Check(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			gatewayItems := itemsForArea(items, "gateway")
+			Expect(gatewayItems).To(HaveLen(1))
+			Expect(gatewayItems[0].Message).To(ContainSubstring("has type ClusterIP, config expects LoadBalancer"))
+		})
+
+		It("reports IPs that differ between config and service", func() {
+			svc := gatewayService(drift.PublicGatewayServiceName, corev1.ServiceTypeLoadBalancer, "10.52.0.99")
+			_, err := clientset.CoreV1().Services(drift.SystemNamespace).Update(ctx, svc, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			items, err := checker.Check(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			publicItems := itemsForArea(items, "publicGateway")
+			Expect(publicItems).To(HaveLen(2))
+			Expect(publicItems[0].Message).To(ContainSubstring("IP 10.52.0.2 from config is not served"))
+			Expect(publicItems[1].Message).To(ContainSubstring("serves IP 10.52.0.99 which is not in the config"))
+		})
+	})
+
+	Describe("domain checks", func() {
+		ingressWithHost := func(name string, host string) *networkingv1.Ingress {
+			return &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: drift.PlatformNamespace,
+				},
+				Spec: networkingv1.IngressSpec{
+					Rules: []networkingv1.IngressRule{{Host: host}},
+				},
+			}
+		}
+
+		It("accepts hosts covered by the configured domains", func() {
+			_, err := clientset.NetworkingV1().Ingresses(drift.PlatformNamespace).Create(ctx, ingressWithHost("platform", "test.example.com"), metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = clientset.NetworkingV1().Ingresses(drift.PlatformNamespace).Create(ctx, ingressWithHost("auth", "auth.test.example.com"), metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			items, err := checker.Check(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(itemsForArea(items, "domains")).To(BeEmpty())
+		})
+
+		It("reports hosts not covered by the configured domains", func() {
+			_, err := clientset.NetworkingV1().Ingresses(drift.PlatformNamespace).Create(ctx, ingressWithHost("rogue", "other.example.org"), metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			items, err := checker.Check(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			domainItems := itemsForArea(items, "domains")
+			Expect(domainItems).To(HaveLen(1))
+			Expect(domainItems[0].Message).To(ContainSubstring("ingress rogue serves host other.example.org"))
+		})
+	})
+
+	Describe("registry checks", func() {
+		deployment := func(name string, image string) *appsv1.Deployment {
+			return &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: drift.PlatformNamespace,
+				},
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "main", Image: image}},
+						},
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			config.Registry = &files.RegistryConfig{
+				Server:             "registry.test.example.com",
+				ReplaceImagesInBom: true,
+			}
+		})
+
+		It("accepts images from the configured registry", func() {
+			_, err := clientset.AppsV1().Deployments(drift.PlatformNamespace).Create(ctx, deployment("platform", "registry.test.example.com/codesphere/platform:1.0"), metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			items, err := checker.Check(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(itemsForArea(items, "registry")).To(BeEmpty())
+		})
+
+		It("reports images pulled from other registries", func() {
+			_, err := clientset.AppsV1().Deployments(drift.PlatformNamespace).Create(ctx, deployment("platform", "docker.io/codesphere/platform:1.0"), metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			items, err := checker.Check(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			registryItems := itemsForArea(items, "registry")
+			Expect(registryItems).To(HaveLen(1))
+			Expect(registryItems[0].Message).To(ContainSubstring("docker.io/codesphere/platform:1.0"))
+		})
+
+		It("skips the check when no registry rewrite is configured", func() {
+			config.Registry = nil
+			_, err := clientset.AppsV1().Deployments(drift.PlatformNamespace).Create(ctx, deployment("platform", "docker.io/codesphere/platform:1.0"), metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			items, err := checker.Check(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(itemsForArea(items, "registry")).To(BeEmpty())
+		})
+	})
+
+	Describe("plan checks", func() {
+		It("reports differing hosting plans", func() {
+			config.Codesphere.Plans.HostingPlans[1] = files.HostingPlan{CPUTenth: 10, MemoryMb: 2048}
+
+			items, err := checker.Check(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			planItems := itemsForArea(items, "plans")
+			Expect(planItems).To(HaveLen(1))
+			Expect(planItems[0].Message).To(ContainSubstring("hosting plans differ"))
+		})
+
+		It("reports when the applied config is not published in the cluster", func() {
+			Expect(clientset.CoreV1().ConfigMaps(drift.SystemNamespace).Delete(ctx, drift.AppliedConfigMapName, metav1.DeleteOptions{})).To(Succeed())
+
+			items, err := checker.Check(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			planItems := itemsForArea(items, "plans")
+			Expect(planItems).To(HaveLen(1))
+			Expect(planItems[0].Message).To(ContainSubstring("cannot verify plans"))
+		})
+	})
+})
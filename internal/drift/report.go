@@ -0,0 +1,62 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// reportTimeout bounds how long posting a report to a webhook may take.
+const reportTimeout = 30 * time.Second
+
+// Report is the result of a drift check in the form posted to webhooks, so
+// scheduled verification runs can feed dashboards or alerting.
+type Report struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	Drifted   bool      `json:"drifted"`
+	Items     []Item    `json:"items"`
+}
+
+// NewReport wraps the items of a drift check into a report.
+func NewReport(items []Item, checkedAt time.Time) Report {
+	return Report{
+		CheckedAt: checkedAt,
+		Drifted:   len(items) > 0,
+		Items:     items,
+	}
+}
+
+// PostReport sends the report as JSON to the given webhook URL. A non-2xx
+// response is treated as an error.
+func PostReport(ctx context.Context, webhookURL string, report Report) error {
+	payload, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal drift report: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reportTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post drift report to %s: %w", webhookURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s returned status %s", webhookURL, resp.Status)
+	}
+	return nil
+}
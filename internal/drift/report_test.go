@@ -0,0 +1,64 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package drift_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/codesphere-cloud/oms/internal/drift"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Report", func() {
+	It("marks a report without items as not drifted", func() {
+		report := drift.NewReport(nil, time.Now())
+		Expect(report.Drifted).To(BeFalse())
+	})
+
+	It("marks a report with items as drifted", func() {
+		items := []drift.Item{{Area: "gateway", Message: "service not found"}}
+		report := drift.NewReport(items, time.Now())
+		Expect(report.Drifted).To(BeTrue())
+		Expect(report.Items).To(Equal(items))
+	})
+
+	Describe("PostReport", func() {
+		It("posts the report as JSON to the webhook", func() {
+			var body []byte
+			var contentType string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				contentType = r.Header.Get("Content-Type")
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			report := drift.NewReport([]drift.Item{{Area: "domains", Message: "host drifted"}}, time.Now())
+			Expect(drift.PostReport(context.Background(), server.URL, report)).To(Succeed())
+
+			Expect(contentType).To(Equal("application/json"))
+			received := drift.Report{}
+			Expect(json.Unmarshal(body, &received)).To(Succeed())
+			Expect(received.Drifted).To(BeTrue())
+			Expect(received.Items).To(HaveLen(1))
+			Expect(received.Items[0].Message).To(Equal("host drifted"))
+		})
+
+		It("returns an error on non-2xx responses", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			}))
+			defer server.Close()
+
+			err := drift.PostReport(context.Background(), server.URL, drift.NewReport(nil, time.Now()))
+			Expect(err).To(MatchError(ContainSubstring("returned status 500")))
+		})
+	})
+})
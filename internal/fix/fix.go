@@ -0,0 +1,102 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fix provides a curated library of remediation scripts for known
+// operational problems, runnable on environment nodes via SSH.
+package fix
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/codesphere-cloud/oms/internal/util"
+)
+
+// Script is a curated remediation script. Version is bumped whenever the
+// commands change, so logged invocations identify what actually ran.
+type Script struct {
+	Name        string
+	Description string
+	Version     int
+	Commands    []string
+}
+
+// Library returns the curated remediation scripts.
+func Library() []Script {
+	return []Script{
+		{
+			Name:        "restart-k0s",
+			Description: "Restart a stuck k0s controller or worker service",
+			Version:     1,
+			Commands: []string{
+				"systemctl restart k0scontroller 2>/dev/null || systemctl restart k0sworker",
+				"k0s status",
+			},
+		},
+		{
+			Name:        "clear-ceph-warnings",
+			Description: "Archive ceph crash reports that keep the cluster in HEALTH_WARN",
+			Version:     1,
+			Commands: []string{
+				"ceph crash archive-all",
+				"ceph health detail",
+			},
+		},
+		{
+			Name:        "vacuum-postgres",
+			Description: "Vacuum and analyze all postgres databases",
+			Version:     1,
+			Commands: []string{
+				"sudo -u postgres vacuumdb --all --analyze",
+			},
+		},
+	}
+}
+
+// Lookup returns the script with the given name from the library.
+func Lookup(name string) (Script, error) {
+	for _, script := range Library() {
+		if script.Name == name {
+			return script, nil
+		}
+	}
+	return Script{}, fmt.Errorf("no fix script named %s; run 'fix --list' to see the library", name)
+}
+
+// Run executes the script's commands on the node as root, stopping at the
+// first failure.
+func (s Script) Run(n *node.Node, logf func(format string, args ...any)) error {
+	for _, command := range s.Commands {
+		logf("Running on %s: %s", n.GetName(), command)
+		if err := n.RunSSHCommand("root", command); err != nil {
+			return fmt.Errorf("fix %s failed at command '%s': %w", s.Name, command, err)
+		}
+	}
+	return nil
+}
+
+// LogInvocation appends an invocation record to the local fix log, so past
+// remediations remain auditable.
+func LogInvocation(fw util.FileIO, logPath string, script Script, nodeName string, runErr error, now time.Time) error {
+	status := "ok"
+	if runErr != nil {
+		status = fmt.Sprintf("failed: %v", runErr)
+	}
+	entry := fmt.Sprintf("%s fix=%s version=%d node=%s status=%s\n", now.UTC().Format(time.RFC3339), script.Name, script.Version, nodeName, status)
+
+	var content []byte
+	if fw.Exists(logPath) {
+		existing, err := fw.ReadFile(logPath)
+		if err != nil {
+			return fmt.Errorf("failed to read fix log: %w", err)
+		}
+		content = existing
+	}
+	content = append(content, []byte(entry)...)
+
+	if err := fw.WriteFile(logPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write fix log: %w", err)
+	}
+	return nil
+}
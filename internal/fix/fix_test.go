@@ -0,0 +1,113 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package fix_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/codesphere-cloud/oms/internal/fix"
+	"github.com/codesphere-cloud/oms/internal/installer/node"
+	"github.com/codesphere-cloud/oms/internal/util"
+)
+
+var _ = Describe("Fix scripts", func() {
+	Describe("Library", func() {
+		It("contains the curated scripts with commands", func() {
+			names := []string{}
+			for _, script := range fix.Library() {
+				Expect(script.Commands).NotTo(BeEmpty())
+				Expect(script.Version).To(BeNumerically(">", 0))
+				names = append(names, script.Name)
+			}
+			Expect(names).To(ContainElements("restart-k0s", "clear-ceph-warnings", "vacuum-postgres"))
+		})
+	})
+
+	Describe("Lookup", func() {
+		It("finds a script by name", func() {
+			script, err := fix.Lookup("restart-k0s")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(script.Name).To(Equal("restart-k0s"))
+		})
+
+		It("fails for unknown scripts", func() {
+			_, err := fix.Lookup("defrag-floppy")
+			Expect(err).To(MatchError(ContainSubstring("no fix script named defrag-floppy")))
+		})
+	})
+
+	Describe("Run", func() {
+		var (
+			nodeClient *node.MockNodeClient
+			target     *node.Node
+			commands   []string
+		)
+
+		BeforeEach(func() {
+			nodeClient = node.NewMockNodeClient(GinkgoT())
+			target = &node.Node{Name: "control-plane-1", ExternalIP: "1.2.3.4", NodeClient: nodeClient}
+			commands = nil
+		})
+
+		It("runs all commands as root in order", func() {
+			nodeClient.EXPECT().RunCommand(mock.Anything, "root", mock.Anything).RunAndReturn(
+				func(n *node.Node, username, command string) error {
+					commands = append(commands, command)
+					return nil
+				})
+
+			script, err := fix.Lookup("restart-k0s")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(script.Run(target, func(format string, args ...any) {})).To(Succeed())
+			Expect(commands).To(Equal(script.Commands))
+		})
+
+		It("stops at the first failing command", func() {
+			nodeClient.EXPECT().RunCommand(mock.Anything, "root", mock.Anything).Return(fmt.Errorf("exit status 1")).Once()
+
+			script, err := fix.Lookup("restart-k0s")
+			Expect(err).NotTo(HaveOccurred())
+			err = script.Run(target, func(format string, args ...any) {})
+			Expect(err).To(MatchError(ContainSubstring("fix restart-k0s failed at command")))
+		})
+	})
+
+	Describe("LogInvocation", func() {
+		var fw *util.MockFileIO
+
+		BeforeEach(func() {
+			fw = util.NewMockFileIO(GinkgoT())
+		})
+
+		It("appends an entry to the existing log", func() {
+			script := fix.Script{Name: "restart-k0s", Version: 1}
+			now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+			fw.EXPECT().Exists("/tmp/fix.log").Return(true)
+			fw.EXPECT().ReadFile("/tmp/fix.log").Return([]byte("old entry\n"), nil)
+			fw.EXPECT().WriteFile("/tmp/fix.log",
+				[]byte("old entry\n2026-01-02T03:04:05Z fix=restart-k0s version=1 node=jumpbox status=ok\n"),
+				mock.Anything).Return(nil)
+
+			Expect(fix.LogInvocation(fw, "/tmp/fix.log", script, "jumpbox", nil, now)).To(Succeed())
+		})
+
+		It("records failures with the error message", func() {
+			script := fix.Script{Name: "vacuum-postgres", Version: 1}
+			now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+			fw.EXPECT().Exists("/tmp/fix.log").Return(false)
+			fw.EXPECT().WriteFile("/tmp/fix.log",
+				[]byte("2026-01-02T03:04:05Z fix=vacuum-postgres version=1 node=postgres status=failed: boom\n"),
+				mock.Anything).Return(nil)
+
+			Expect(fix.LogInvocation(fw, "/tmp/fix.log", script, "postgres", fmt.Errorf("boom"), now)).To(Succeed())
+		})
+	})
+})
@@ -5,17 +5,23 @@ package github
 
 import (
 	"context"
+	"strings"
 
 	"github.com/google/go-github/v74/github"
 	"golang.org/x/oauth2"
 )
 
-// GitHubClient abstracts the GitHub API calls used to fetch team SSH keys.
+// GitHubClient abstracts the GitHub API calls used to fetch team SSH keys
+// and to register self-hosted Actions runners.
 //
 //mockery:generate: true
 type GitHubClient interface {
 	ListTeamMembersBySlug(ctx context.Context, org, teamSlug string, opts *github.TeamListTeamMembersOptions) ([]*github.User, error)
 	ListUserKeys(ctx context.Context, username string) ([]*github.Key, error)
+	CreateOrgRunnerRegistrationToken(ctx context.Context, org string) (string, error)
+	GetTokenScopes(ctx context.Context) ([]string, error)
+	GetOrgContainerPackage(ctx context.Context, org, name string) error
+	GetAppBySlug(ctx context.Context, slug string) (*github.App, error)
 }
 
 type RealGitHubClient struct {
@@ -40,3 +46,45 @@ func (c *RealGitHubClient) ListUserKeys(ctx context.Context, username string) ([
 	keys, _, err := c.client.Users.ListKeys(ctx, username, nil)
 	return keys, err
 }
+
+// GetTokenScopes returns the OAuth scopes of the authenticated token as
+// reported by the X-OAuth-Scopes response header. Fine-grained tokens do not
+// report scopes, so an empty slice does not mean the token has no access.
+func (c *RealGitHubClient) GetTokenScopes(ctx context.Context) ([]string, error) {
+	_, resp, err := c.client.Users.Get(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	header := resp.Header.Get("X-OAuth-Scopes")
+	if header == "" {
+		return nil, nil
+	}
+	scopes := []string{}
+	for _, scope := range strings.Split(header, ",") {
+		scopes = append(scopes, strings.TrimSpace(scope))
+	}
+	return scopes, nil
+}
+
+// GetOrgContainerPackage checks that the authenticated token can read the
+// given container package of an organization.
+func (c *RealGitHubClient) GetOrgContainerPackage(ctx context.Context, org, name string) error {
+	_, _, err := c.client.Organizations.GetPackage(ctx, org, "container", name)
+	return err
+}
+
+// GetAppBySlug fetches the public information of a GitHub App by its slug.
+func (c *RealGitHubClient) GetAppBySlug(ctx context.Context, slug string) (*github.App, error) {
+	app, _, err := c.client.Apps.Get(ctx, slug)
+	return app, err
+}
+
+// CreateOrgRunnerRegistrationToken creates a short-lived token used to
+// register a self-hosted Actions runner with the organization.
+func (c *RealGitHubClient) CreateOrgRunnerRegistrationToken(ctx context.Context, org string) (string, error) {
+	token, _, err := c.client.Actions.CreateOrganizationRegistrationToken(ctx, org)
+	if err != nil {
+		return "", err
+	}
+	return token.GetToken(), nil
+}
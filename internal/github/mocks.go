@@ -37,6 +37,265 @@ func (_m *MockGitHubClient) EXPECT() *MockGitHubClient_Expecter {
 	return &MockGitHubClient_Expecter{mock: &_m.Mock}
 }
 
+// CreateOrgRunnerRegistrationToken provides a mock function for the type MockGitHubClient
+func (_mock *MockGitHubClient) CreateOrgRunnerRegistrationToken(ctx context.Context, org string) (string, error) {
+	ret := _mock.Called(ctx, org)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrgRunnerRegistrationToken")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, org)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, org)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, org)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGitHubClient_CreateOrgRunnerRegistrationToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrgRunnerRegistrationToken'
+type MockGitHubClient_CreateOrgRunnerRegistrationToken_Call struct {
+	*mock.Call
+}
+
+// CreateOrgRunnerRegistrationToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - org string
+func (_e *MockGitHubClient_Expecter) CreateOrgRunnerRegistrationToken(ctx any, org any) *MockGitHubClient_CreateOrgRunnerRegistrationToken_Call {
+	return &MockGitHubClient_CreateOrgRunnerRegistrationToken_Call{Call: _e.mock.On("CreateOrgRunnerRegistrationToken", ctx, org)}
+}
+
+func (_c *MockGitHubClient_CreateOrgRunnerRegistrationToken_Call) Run(run func(ctx context.Context, org string)) *MockGitHubClient_CreateOrgRunnerRegistrationToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGitHubClient_CreateOrgRunnerRegistrationToken_Call) Return(s string, err error) *MockGitHubClient_CreateOrgRunnerRegistrationToken_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockGitHubClient_CreateOrgRunnerRegistrationToken_Call) RunAndReturn(run func(ctx context.Context, org string) (string, error)) *MockGitHubClient_CreateOrgRunnerRegistrationToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAppBySlug provides a mock function for the type MockGitHubClient
+func (_mock *MockGitHubClient) GetAppBySlug(ctx context.Context, slug string) (*github.App, error) {
+	ret := _mock.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppBySlug")
+	}
+
+	var r0 *github.App
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*github.App, error)); ok {
+		return returnFunc(ctx, slug)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *github.App); ok {
+		r0 = returnFunc(ctx, slug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.App)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGitHubClient_GetAppBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAppBySlug'
+type MockGitHubClient_GetAppBySlug_Call struct {
+	*mock.Call
+}
+
+// GetAppBySlug is a helper method to define mock.On call
+//   - ctx context.Context
+//   - slug string
+func (_e *MockGitHubClient_Expecter) GetAppBySlug(ctx any, slug any) *MockGitHubClient_GetAppBySlug_Call {
+	return &MockGitHubClient_GetAppBySlug_Call{Call: _e.mock.On("GetAppBySlug", ctx, slug)}
+}
+
+func (_c *MockGitHubClient_GetAppBySlug_Call) Run(run func(ctx context.Context, slug string)) *MockGitHubClient_GetAppBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGitHubClient_GetAppBySlug_Call) Return(app *github.App, err error) *MockGitHubClient_GetAppBySlug_Call {
+	_c.Call.Return(app, err)
+	return _c
+}
+
+func (_c *MockGitHubClient_GetAppBySlug_Call) RunAndReturn(run func(ctx context.Context, slug string) (*github.App, error)) *MockGitHubClient_GetAppBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOrgContainerPackage provides a mock function for the type MockGitHubClient
+func (_mock *MockGitHubClient) GetOrgContainerPackage(ctx context.Context, org string, name string) error {
+	ret := _mock.Called(ctx, org, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrgContainerPackage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, org, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGitHubClient_GetOrgContainerPackage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrgContainerPackage'
+type MockGitHubClient_GetOrgContainerPackage_Call struct {
+	*mock.Call
+}
+
+// GetOrgContainerPackage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - org string
+//   - name string
+func (_e *MockGitHubClient_Expecter) GetOrgContainerPackage(ctx any, org any, name any) *MockGitHubClient_GetOrgContainerPackage_Call {
+	return &MockGitHubClient_GetOrgContainerPackage_Call{Call: _e.mock.On("GetOrgContainerPackage", ctx, org, name)}
+}
+
+func (_c *MockGitHubClient_GetOrgContainerPackage_Call) Run(run func(ctx context.Context, org string, name string)) *MockGitHubClient_GetOrgContainerPackage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGitHubClient_GetOrgContainerPackage_Call) Return(err error) *MockGitHubClient_GetOrgContainerPackage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGitHubClient_GetOrgContainerPackage_Call) RunAndReturn(run func(ctx context.Context, org string, name string) error) *MockGitHubClient_GetOrgContainerPackage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTokenScopes provides a mock function for the type MockGitHubClient
+func (_mock *MockGitHubClient) GetTokenScopes(ctx context.Context) ([]string, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokenScopes")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGitHubClient_GetTokenScopes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTokenScopes'
+type MockGitHubClient_GetTokenScopes_Call struct {
+	*mock.Call
+}
+
+// GetTokenScopes is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockGitHubClient_Expecter) GetTokenScopes(ctx any) *MockGitHubClient_GetTokenScopes_Call {
+	return &MockGitHubClient_GetTokenScopes_Call{Call: _e.mock.On("GetTokenScopes", ctx)}
+}
+
+func (_c *MockGitHubClient_GetTokenScopes_Call) Run(run func(ctx context.Context)) *MockGitHubClient_GetTokenScopes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGitHubClient_GetTokenScopes_Call) Return(strings []string, err error) *MockGitHubClient_GetTokenScopes_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockGitHubClient_GetTokenScopes_Call) RunAndReturn(run func(ctx context.Context) ([]string, error)) *MockGitHubClient_GetTokenScopes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListTeamMembersBySlug provides a mock function for the type MockGitHubClient
 func (_mock *MockGitHubClient) ListTeamMembersBySlug(ctx context.Context, org string, teamSlug string, opts *github.TeamListTeamMembersOptions) ([]*github.User, error) {
 	ret := _mock.Called(ctx, org, teamSlug, opts)
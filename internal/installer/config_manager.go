@@ -76,6 +76,17 @@ func (g *InstallConfig) LoadInstallConfigFromFile(configPath string) error {
 		return err
 	}
 
+	version, found, err := files.ConfigSchemaVersion(data)
+	if err != nil {
+		return err
+	}
+	if version > files.CurrentSchemaVersion {
+		return fmt.Errorf("%s uses config schema version %d, but this OMS release only supports version %d; update OMS", configPath, version, files.CurrentSchemaVersion)
+	}
+	if found && version < files.CurrentSchemaVersion {
+		return fmt.Errorf("%s uses outdated config schema version %d; run 'oms config migrate' to upgrade it to version %d", configPath, version, files.CurrentSchemaVersion)
+	}
+
 	config := files.NewRootConfig()
 	if err := config.Unmarshal(data); err != nil {
 		return fmt.Errorf("failed to unmarshal %s: %w", configPath, err)
@@ -85,15 +96,21 @@ func (g *InstallConfig) LoadInstallConfigFromFile(configPath string) error {
 	return nil
 }
 
-// LoadVaultFromFile loads the vault content from an encrypted file into the installConfig
+// LoadVaultFromFile loads the vault content from an encrypted file into the installConfig.
+// Secret reference URIs are resolved against their backend, so consumers always
+// see literal values.
 // Returns an error if age key file has not been set as environment variable SOPS_AGE_KEY_FILE
 func (g *InstallConfig) LoadVaultFromFile(vaultPath string) error {
-	vault, err := vault.LoadVaultData(vaultPath, "")
+	v, err := vault.LoadVaultData(vaultPath, "")
 	if err != nil {
 		return err
 	}
 
-	g.Vault = vault
+	if err := vault.ResolveSecretRefs(v, vault.FetchSecretRef); err != nil {
+		return fmt.Errorf("failed to resolve secret references: %w", err)
+	}
+
+	g.Vault = v
 	return nil
 }
 
@@ -149,13 +166,46 @@ func (g *InstallConfig) ValidateInstallConfig() []string {
 		}
 	}
 
-	if len(g.Config.Ceph.Hosts) == 0 {
-		errors = append(errors, "at least one Ceph host is required")
+	storageBackend := "ceph"
+	if g.Config.Storage != nil && g.Config.Storage.Backend != "" {
+		storageBackend = g.Config.Storage.Backend
 	}
-	for _, host := range g.Config.Ceph.Hosts {
-		if !IsValidIP(host.IPAddress) {
-			errors = append(errors, fmt.Sprintf("invalid Ceph host IP: %s", host.IPAddress))
+	switch storageBackend {
+	case "ceph":
+		switch g.Config.Ceph.Mode {
+		case "", "install":
+			if len(g.Config.Ceph.Hosts) == 0 {
+				errors = append(errors, "at least one Ceph host is required")
+			}
+			for _, host := range g.Config.Ceph.Hosts {
+				if !IsValidIP(host.IPAddress) {
+					errors = append(errors, fmt.Sprintf("invalid Ceph host IP: %s", host.IPAddress))
+				}
+			}
+			if pool := g.Config.Ceph.Pool; pool != nil {
+				if pool.ReplicaCount < 1 {
+					errors = append(errors, "ceph pool replica count must be at least 1")
+				}
+				if pool.FailureDomain != "" && pool.FailureDomain != "host" && pool.FailureDomain != "osd" {
+					errors = append(errors, fmt.Sprintf("invalid ceph pool failure domain: %s (must be 'host' or 'osd')", pool.FailureDomain))
+				}
+			}
+		case "external":
+			if len(g.Config.Ceph.MonHosts) == 0 {
+				errors = append(errors, "ceph mon hosts are required when ceph mode is 'external'")
+			}
+			if len(g.Config.Ceph.Hosts) > 0 {
+				errors = append(errors, "ceph hosts must not be set when ceph mode is 'external'")
+			}
+		default:
+			errors = append(errors, fmt.Sprintf("invalid ceph mode: %s (must be 'install' or 'external')", g.Config.Ceph.Mode))
 		}
+	case "longhorn", "local-path":
+		if len(g.Config.Ceph.Hosts) > 0 {
+			errors = append(errors, fmt.Sprintf("ceph hosts must not be set when the storage backend is '%s'", storageBackend))
+		}
+	default:
+		errors = append(errors, fmt.Sprintf("invalid storage backend: %s (must be 'ceph', 'longhorn' or 'local-path')", storageBackend))
 	}
 
 	if g.Config.Kubernetes.ManagedByCodesphere {
@@ -337,6 +387,15 @@ func (g *InstallConfig) ApplyProfile(profile string) error {
 
 	case PROFILE_MINIMAL:
 		return g.applyProfileMinimal()
+	case PROFILE_HA_PRODUCTION:
+		return g.applyProfileHaProduction()
+	case PROFILE_AIRGAPPED:
+		return g.applyProfileAirgapped()
+	case PROFILE_GPU:
+		return g.applyProfileGpu()
+	}
+	if g.fileIO != nil && g.fileIO.Exists(profile) {
+		return g.applyProfileFile(profile)
 	}
-	return fmt.Errorf("unknown profile: %s, available profiles: dev, prod, minimal", profile)
+	return fmt.Errorf("unknown profile: %s, available profiles: dev, prod, minimal, ha-production, airgapped, gpu, or a path to a profile file", profile)
 }
@@ -10,11 +10,14 @@ import (
 )
 
 const (
-	PROFILE_DEV         = "dev"
-	PROFILE_DEVELOPMENT = "development"
-	PROFILE_PROD        = "prod"
-	PROFILE_PRODUCTION  = "production"
-	PROFILE_MINIMAL     = "minimal"
+	PROFILE_DEV           = "dev"
+	PROFILE_DEVELOPMENT   = "development"
+	PROFILE_PROD          = "prod"
+	PROFILE_PRODUCTION    = "production"
+	PROFILE_MINIMAL       = "minimal"
+	PROFILE_HA_PRODUCTION = "ha-production"
+	PROFILE_AIRGAPPED     = "airgapped"
+	PROFILE_GPU           = "gpu"
 )
 
 func (g *InstallConfig) applyCommonProperties() {
@@ -283,6 +286,105 @@ func (g *InstallConfig) applyProfileMinimal() error {
 	return nil
 }
 
+// applyProfileHaProduction extends the production profile with the cluster
+// add-ons a highly available installation needs: continuous postgres
+// archiving via the barman cloud plugin, the postgres operator, and a load
+// balanced RGW endpoint.
+func (g *InstallConfig) applyProfileHaProduction() error {
+	if err := g.applyProfileProd(); err != nil {
+		return err
+	}
+	if g.Config.Cluster.BarmanCloudPlugin == nil {
+		g.Config.Cluster.BarmanCloudPlugin = &files.BarmanCloudPluginConfig{Enabled: true}
+	}
+	if g.Config.Cluster.PgOperator == nil {
+		g.Config.Cluster.PgOperator = &files.PgOperatorConfig{Enabled: true}
+	}
+	if g.Config.Cluster.RgwLoadBalancer == nil {
+		g.Config.Cluster.RgwLoadBalancer = &files.RgwLoadBalancerConfig{Enabled: true}
+	}
+	return nil
+}
+
+// applyProfileAirgapped configures an installation without internet access:
+// container images are loaded from the install package into the configured
+// registry, BOM references are rewritten to that registry, and monitoring
+// stays local with remote write disabled.
+func (g *InstallConfig) applyProfileAirgapped() error {
+	if g.Config.Datacenter.Name == "" {
+		g.Config.Datacenter.Name = "airgapped"
+	}
+	g.Config.Registry.ReplaceImagesInBom = true
+	g.Config.Registry.LoadContainerImages = true
+	if g.Config.Cluster.Monitoring == nil {
+		g.Config.Cluster.Monitoring = &files.MonitoringConfig{}
+	}
+	if g.Config.Cluster.Monitoring.Prometheus == nil {
+		g.Config.Cluster.Monitoring.Prometheus = &files.PrometheusConfig{}
+	}
+	if g.Config.Cluster.Monitoring.Prometheus.RemoteWrite == nil {
+		g.Config.Cluster.Monitoring.Prometheus.RemoteWrite = &files.RemoteWriteConfig{
+			Enabled:     false,
+			ClusterName: "airgapped",
+		}
+	}
+	if g.Config.Cluster.Monitoring.Loki == nil {
+		g.Config.Cluster.Monitoring.Loki = &files.LokiConfig{Enabled: true}
+	}
+	if g.Config.Cluster.Monitoring.Grafana == nil {
+		g.Config.Cluster.Monitoring.Grafana = &files.GrafanaConfig{Enabled: true}
+	}
+	if g.Config.Cluster.Monitoring.GrafanaAlloy == nil {
+		g.Config.Cluster.Monitoring.GrafanaAlloy = &files.GrafanaAlloyConfig{Enabled: true}
+	}
+	return nil
+}
+
+// applyProfileGpu adds a GPU hosting and workspace plan and the GPU-enabled
+// workspace agent image on top of the minimal profile.
+func (g *InstallConfig) applyProfileGpu() error {
+	if err := g.applyProfileMinimal(); err != nil {
+		return err
+	}
+	if g.Config.Codesphere.WorkspaceImages.AgentGpu == nil {
+		g.Config.Codesphere.WorkspaceImages.AgentGpu = &files.ImageRef{
+			BomRef: "workspace-agent-gpu-24.04",
+		}
+	}
+	if _, ok := g.Config.Codesphere.Plans.HostingPlans[2]; !ok {
+		g.Config.Codesphere.Plans.HostingPlans[2] = files.HostingPlan{
+			CPUTenth:      40,
+			GPUParts:      1,
+			MemoryMb:      16384,
+			StorageMb:     102400,
+			TempStorageMb: 4096,
+		}
+	}
+	if _, ok := g.Config.Codesphere.Plans.WorkspacePlans[2]; !ok {
+		g.Config.Codesphere.Plans.WorkspacePlans[2] = files.WorkspacePlan{
+			Name:          "GPU",
+			HostingPlanID: 2,
+			MaxReplicas:   1,
+			OnDemand:      true,
+		}
+	}
+	return nil
+}
+
+// applyProfileFile merges a user-defined profile file over the base config,
+// so site-specific profiles can live next to the configs that use them. Only
+// the keys present in the file override the base values.
+func (g *InstallConfig) applyProfileFile(path string) error {
+	data, err := g.fileIO.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read profile file %s: %w", path, err)
+	}
+	if err := g.Config.Unmarshal(data); err != nil {
+		return fmt.Errorf("failed to parse profile file %s: %w", path, err)
+	}
+	return nil
+}
+
 func (g *InstallConfig) applyProfileProd() error {
 	if g.Config.Datacenter.Name == "" {
 		g.Config.Datacenter.Name = "production"
@@ -59,6 +59,93 @@ var _ = Describe("ConfigManagerProfile", func() {
 			})
 		})
 
+		Context("with ha-production profile", func() {
+			It("should extend the production profile with HA cluster add-ons", func() {
+				err := manager.ApplyProfile(installer.PROFILE_HA_PRODUCTION)
+				Expect(err).ToNot(HaveOccurred())
+
+				config := manager.GetInstallConfig()
+				Expect(config.Datacenter.Name).To(Equal("production"))
+				Expect(config.Cluster.BarmanCloudPlugin).ToNot(BeNil())
+				Expect(config.Cluster.BarmanCloudPlugin.Enabled).To(BeTrue())
+				Expect(config.Cluster.PgOperator).ToNot(BeNil())
+				Expect(config.Cluster.PgOperator.Enabled).To(BeTrue())
+				Expect(config.Cluster.RgwLoadBalancer).ToNot(BeNil())
+				Expect(config.Cluster.RgwLoadBalancer.Enabled).To(BeTrue())
+			})
+		})
+
+		Context("with airgapped profile", func() {
+			It("should load images into the local registry and keep monitoring local", func() {
+				err := manager.ApplyProfile(installer.PROFILE_AIRGAPPED)
+				Expect(err).ToNot(HaveOccurred())
+
+				config := manager.GetInstallConfig()
+				Expect(config.Datacenter.Name).To(Equal("airgapped"))
+				Expect(config.Registry.ReplaceImagesInBom).To(BeTrue())
+				Expect(config.Registry.LoadContainerImages).To(BeTrue())
+				Expect(config.Cluster.Monitoring.Prometheus.RemoteWrite.Enabled).To(BeFalse())
+				Expect(config.Cluster.Monitoring.Loki.Enabled).To(BeTrue())
+				Expect(config.Cluster.Monitoring.Grafana.Enabled).To(BeTrue())
+				Expect(config.Cluster.Monitoring.GrafanaAlloy.Enabled).To(BeTrue())
+			})
+		})
+
+		Context("with gpu profile", func() {
+			It("should add a GPU plan and the GPU workspace agent image", func() {
+				err := manager.ApplyProfile(installer.PROFILE_GPU)
+				Expect(err).ToNot(HaveOccurred())
+
+				config := manager.GetInstallConfig()
+				Expect(config.Codesphere.WorkspaceImages.AgentGpu).ToNot(BeNil())
+				Expect(config.Codesphere.WorkspaceImages.AgentGpu.BomRef).To(Equal("workspace-agent-gpu-24.04"))
+
+				Expect(config.Codesphere.Plans.HostingPlans).To(HaveKey(2))
+				hostingPlan := config.Codesphere.Plans.HostingPlans[2]
+				Expect(hostingPlan.GPUParts).To(Equal(1))
+				Expect(hostingPlan.MemoryMb).To(Equal(16384))
+
+				Expect(config.Codesphere.Plans.WorkspacePlans).To(HaveKey(2))
+				workspacePlan := config.Codesphere.Plans.WorkspacePlans[2]
+				Expect(workspacePlan.Name).To(Equal("GPU"))
+				Expect(workspacePlan.HostingPlanID).To(Equal(2))
+			})
+		})
+
+		Context("with a profile file", func() {
+			It("should merge the file contents over the base config", func() {
+				mockIO := NewMockFileIO()
+				profile := []byte("dataCenter:\n  name: custom-site\ncodesphere:\n  domain: custom.example.com\n")
+				err := mockIO.WriteFile("/tmp/custom-profile.yaml", profile, 0o644)
+				Expect(err).ToNot(HaveOccurred())
+
+				configManager := &installer.InstallConfig{Config: &files.RootConfig{}}
+				configManager.SetFileIO(mockIO)
+
+				err = configManager.ApplyProfile("/tmp/custom-profile.yaml")
+				Expect(err).ToNot(HaveOccurred())
+
+				config := configManager.GetInstallConfig()
+				Expect(config.Datacenter.Name).To(Equal("custom-site"))
+				Expect(config.Codesphere.Domain).To(Equal("custom.example.com"))
+				// common defaults are still applied underneath
+				Expect(config.Datacenter.City).To(Equal("Karlsruhe"))
+			})
+
+			It("should return an error for an unparsable profile file", func() {
+				mockIO := NewMockFileIO()
+				err := mockIO.WriteFile("/tmp/broken-profile.yaml", []byte("dataCenter: [unclosed"), 0o644)
+				Expect(err).ToNot(HaveOccurred())
+
+				configManager := &installer.InstallConfig{Config: &files.RootConfig{}}
+				configManager.SetFileIO(mockIO)
+
+				err = configManager.ApplyProfile("/tmp/broken-profile.yaml")
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to parse profile file"))
+			})
+		})
+
 		Context("with unknown profile", func() {
 			It("should return error for unknown profile", func() {
 				err := manager.ApplyProfile("unknown")
@@ -217,6 +304,9 @@ var _ = Describe("ConfigManagerProfile", func() {
 			Expect(installer.PROFILE_PROD).To(Equal("prod"))
 			Expect(installer.PROFILE_PRODUCTION).To(Equal("production"))
 			Expect(installer.PROFILE_MINIMAL).To(Equal("minimal"))
+			Expect(installer.PROFILE_HA_PRODUCTION).To(Equal("ha-production"))
+			Expect(installer.PROFILE_AIRGAPPED).To(Equal("airgapped"))
+			Expect(installer.PROFILE_GPU).To(Equal("gpu"))
 		})
 	})
 })
@@ -312,6 +312,72 @@ var _ = Describe("ConfigManager", func() {
 				errors := configManager.ValidateInstallConfig()
 				Expect(errors).To(ContainElement(ContainSubstring("invalid Ceph host IP")))
 			})
+
+			It("should reject an invalid ceph mode", func() {
+				configManager.Config.Ceph.Mode = "managed"
+				errors := configManager.ValidateInstallConfig()
+				Expect(errors).To(ContainElement(ContainSubstring("invalid ceph mode")))
+			})
+
+			It("should validate the ceph pool settings", func() {
+				configManager.Config.Ceph.Pool = &files.CephPoolConfig{ReplicaCount: 0, FailureDomain: "rack"}
+				errors := configManager.ValidateInstallConfig()
+				Expect(errors).To(ContainElement(ContainSubstring("ceph pool replica count must be at least 1")))
+				Expect(errors).To(ContainElement(ContainSubstring("invalid ceph pool failure domain: rack")))
+			})
+
+			Context("external ceph", func() {
+				BeforeEach(func() {
+					configManager.Config.Ceph.Mode = "external"
+					configManager.Config.Ceph.Hosts = nil
+				})
+
+				It("should require mon hosts", func() {
+					errors := configManager.ValidateInstallConfig()
+					Expect(errors).To(ContainElement(ContainSubstring("ceph mon hosts are required")))
+				})
+
+				It("should accept mon hosts without ceph hosts", func() {
+					configManager.Config.Ceph.MonHosts = []string{"10.0.0.10:6789"}
+					errors := configManager.ValidateInstallConfig()
+					Expect(errors).NotTo(ContainElement(ContainSubstring("Ceph")))
+				})
+
+				It("should reject ceph hosts alongside an external cluster", func() {
+					configManager.Config.Ceph.MonHosts = []string{"10.0.0.10:6789"}
+					configManager.Config.Ceph.Hosts = []files.CephHost{
+						{Hostname: "ceph-0", IPAddress: "10.0.0.5", IsMaster: true},
+					}
+					errors := configManager.ValidateInstallConfig()
+					Expect(errors).To(ContainElement(ContainSubstring("ceph hosts must not be set when ceph mode is 'external'")))
+				})
+			})
+
+			Context("non-ceph storage backend", func() {
+				BeforeEach(func() {
+					configManager.Config.Storage = &files.StorageConfig{Backend: "longhorn"}
+					configManager.Config.Ceph = files.CephConfig{}
+				})
+
+				It("should not require ceph hosts", func() {
+					errors := configManager.ValidateInstallConfig()
+					Expect(errors).NotTo(ContainElement(ContainSubstring("Ceph")))
+				})
+
+				It("should reject ceph hosts alongside a non-ceph backend", func() {
+					configManager.Config.Ceph.Hosts = []files.CephHost{
+						{Hostname: "ceph-0", IPAddress: "10.0.0.5", IsMaster: true},
+					}
+					errors := configManager.ValidateInstallConfig()
+					Expect(errors).To(ContainElement(ContainSubstring("ceph hosts must not be set when the storage backend is 'longhorn'")))
+				})
+
+				It("should reject an unknown storage backend", func() {
+					configManager.Config.Storage.Backend = "nfs"
+					errors := configManager.ValidateInstallConfig()
+					Expect(errors).To(ContainElement(ContainSubstring("invalid storage backend: nfs")))
+				})
+			})
 		})
 
 		Context("kubernetes validation", func() {
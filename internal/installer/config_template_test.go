@@ -72,6 +72,70 @@ var _ = Describe("Config templating", func() {
 		Expect(string(rendered)).To(Equal(`username: "codesphere"`))
 	})
 
+	It("substitutes ${ENV_VAR} references from the environment", func() {
+		GinkgoT().Setenv("OMS_TEST_DOMAIN", "dev.example.com")
+
+		rendered, err := configtemplating.RenderInstallConfigTemplate(
+			[]byte(`domain: "${OMS_TEST_DOMAIN}"`),
+			vault.NewVaultTemplatingSecretStore(installVault),
+		)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(rendered)).To(Equal(`domain: "dev.example.com"`))
+	})
+
+	It("fails when a referenced environment variable is unset", func() {
+		_, err := configtemplating.RenderInstallConfigTemplate(
+			[]byte(`domain: "${OMS_TEST_UNSET_VAR}"`),
+			vault.NewVaultTemplatingSecretStore(installVault),
+		)
+
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unset environment variable(s): OMS_TEST_UNSET_VAR"))
+	})
+
+	It("renders escaped $${VAR} references literally", func() {
+		rendered, err := configtemplating.RenderInstallConfigTemplate(
+			[]byte(`script: "echo $${HOME}"`),
+			vault.NewVaultTemplatingSecretStore(installVault),
+		)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(rendered)).To(Equal(`script: "echo ${HOME}"`))
+	})
+
+	It("leaves plain dollar signs untouched", func() {
+		rendered, err := configtemplating.RenderInstallConfigTemplate(
+			[]byte(`price: "$5 and $digit"`),
+			vault.NewVaultTemplatingSecretStore(installVault),
+		)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(rendered)).To(Equal(`price: "$5 and $digit"`))
+	})
+
+	It("supports the env template function", func() {
+		GinkgoT().Setenv("OMS_TEST_CLUSTER", "stage")
+
+		rendered, err := configtemplating.RenderInstallConfigTemplate(
+			[]byte(`clusterName: "{{ env "OMS_TEST_CLUSTER" }}"`),
+			vault.NewVaultTemplatingSecretStore(installVault),
+		)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(rendered)).To(Equal(`clusterName: "stage"`))
+	})
+
+	It("returns an error when the env template function hits an unset variable", func() {
+		_, err := configtemplating.RenderInstallConfigTemplate(
+			[]byte(`clusterName: "{{ env "OMS_TEST_UNSET_VAR" }}"`),
+			vault.NewVaultTemplatingSecretStore(installVault),
+		)
+
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("environment variable OMS_TEST_UNSET_VAR is not set"))
+	})
+
 	It("returns an error for missing secrets", func() {
 		_, err := configtemplating.RenderInstallConfigTemplate(
 			[]byte(`password: "{{ secret "missing" }}"`),
@@ -0,0 +1,40 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package installer
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// InstallUnitName is the transient systemd unit the detached install runs in.
+const InstallUnitName = "codesphere-install"
+
+// InstallLogPath is where the detached install appends its combined output.
+const InstallLogPath = "/var/log/codesphere-install.log"
+
+// DetachedInstallCommand wraps an install command so it runs as a transient
+// systemd unit on the install host, with workdir as its working directory so
+// the package is resolved and extracted there. The unit keeps running when
+// the SSH connection that started it drops; output goes to InstallLogPath so
+// AttachInstallCommand can stream it from a fresh connection.
+func DetachedInstallCommand(installCmd, workdir string) string {
+	script := fmt.Sprintf("%s >>%s 2>&1", installCmd, InstallLogPath)
+	// A previously failed run leaves the unit in a failed state and would block
+	// systemd-run, so clear it first. --collect is deliberately not used: the
+	// failed unit state is what AttachInstallCommand inspects for the result.
+	return fmt.Sprintf("systemctl reset-failed %s 2>/dev/null; systemd-run --unit %s --working-directory=%s /bin/sh -c %s",
+		InstallUnitName, InstallUnitName, workdir, strconv.Quote(script))
+}
+
+// AttachInstallCommand streams the detached install log until the unit
+// finishes and exits non-zero if the installation failed. It is safe to run
+// repeatedly, e.g. after a dropped connection.
+func AttachInstallCommand() string {
+	return fmt.Sprintf("tail -n +1 -f %s & TAIL_PID=$!; "+
+		"while systemctl is-active --quiet %s; do sleep 5; done; "+
+		"sleep 1; kill $TAIL_PID 2>/dev/null; "+
+		"! systemctl is-failed --quiet %s",
+		InstallLogPath, InstallUnitName, InstallUnitName)
+}
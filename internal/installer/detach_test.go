@@ -0,0 +1,30 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package installer_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/codesphere-cloud/oms/internal/installer"
+)
+
+var _ = Describe("Detached install commands", func() {
+	It("runs the install as a transient systemd unit with output to the log", func() {
+		cmd := installer.DetachedInstallCommand("oms install codesphere -c config.yaml", "/root")
+
+		Expect(cmd).To(ContainSubstring("systemd-run --unit " + installer.InstallUnitName))
+		Expect(cmd).To(ContainSubstring("--working-directory=/root"))
+		Expect(cmd).To(ContainSubstring("systemctl reset-failed " + installer.InstallUnitName))
+		Expect(cmd).To(ContainSubstring("oms install codesphere -c config.yaml >>" + installer.InstallLogPath))
+	})
+
+	It("streams the log and reports the unit result on attach", func() {
+		cmd := installer.AttachInstallCommand()
+
+		Expect(cmd).To(ContainSubstring("tail -n +1 -f " + installer.InstallLogPath))
+		Expect(cmd).To(ContainSubstring("while systemctl is-active --quiet " + installer.InstallUnitName))
+		Expect(cmd).To(ContainSubstring("! systemctl is-failed --quiet " + installer.InstallUnitName))
+	})
+})
@@ -0,0 +1,159 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package files
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// CurrentSchemaVersion is the config schema version this OMS release writes
+// and expects. Bump it together with a new entry in configMigrations whenever
+// a config field is renamed or restructured.
+const CurrentSchemaVersion = 2
+
+// ConfigMigration upgrades a config from FromVersion to FromVersion+1. The
+// migrations operate on the raw YAML document so fields unknown to the
+// current structs survive the upgrade, and comments and key order are kept.
+type ConfigMigration struct {
+	FromVersion int
+	Description string
+	Apply       func(root *yaml.Node) error
+}
+
+// configMigrations lists all schema upgrades in order. Each entry upgrades
+// from its FromVersion to the next version.
+func configMigrations() []ConfigMigration {
+	return []ConfigMigration{
+		{
+			FromVersion: 1,
+			Description: "rename top-level key datacenter to dataCenter",
+			Apply: func(root *yaml.Node) error {
+				renameMappingKey(root, "datacenter", "dataCenter")
+				return nil
+			},
+		},
+	}
+}
+
+// ConfigSchemaVersion reads the schemaVersion field from raw config YAML.
+// The second return value reports whether the field is present; configs
+// predating the field report version 1.
+func ConfigSchemaVersion(data []byte) (int, bool, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return 0, false, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	root := documentRoot(&doc)
+	if root == nil {
+		return 1, false, nil
+	}
+
+	value := mappingValue(root, "schemaVersion")
+	if value == nil {
+		return 1, false, nil
+	}
+	version, err := strconv.Atoi(value.Value)
+	if err != nil {
+		return 0, true, fmt.Errorf("invalid schemaVersion %q: must be an integer", value.Value)
+	}
+	return version, true, nil
+}
+
+// MigrateConfigYAML upgrades raw config YAML to the current schema version.
+// It returns the migrated YAML plus a description of every applied
+// transformation. A config already at the current version is returned
+// unchanged; a config newer than this OMS release is rejected.
+func MigrateConfigYAML(data []byte) ([]byte, []string, error) {
+	version, _, err := ConfigSchemaVersion(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if version > CurrentSchemaVersion {
+		return nil, nil, fmt.Errorf("config schema version %d is newer than the supported version %d; update OMS instead of migrating", version, CurrentSchemaVersion)
+	}
+	if version == CurrentSchemaVersion {
+		return data, nil, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	root := documentRoot(&doc)
+	if root == nil {
+		return nil, nil, fmt.Errorf("config file is empty")
+	}
+
+	applied := []string{}
+	for _, migration := range configMigrations() {
+		if migration.FromVersion < version {
+			continue
+		}
+		if err := migration.Apply(root); err != nil {
+			return nil, nil, fmt.Errorf("failed to migrate config from schema version %d: %w", migration.FromVersion, err)
+		}
+		applied = append(applied, fmt.Sprintf("v%d -> v%d: %s", migration.FromVersion, migration.FromVersion+1, migration.Description))
+	}
+	setSchemaVersion(root, CurrentSchemaVersion)
+
+	migrated, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal migrated config: %w", err)
+	}
+	return migrated, applied, nil
+}
+
+// documentRoot returns the mapping node of a parsed YAML document, or nil for
+// an empty document.
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil
+	}
+	return root
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// renameMappingKey renames a key in a mapping node. Nothing happens when the
+// old key is absent or the new key already exists.
+func renameMappingKey(mapping *yaml.Node, oldKey string, newKey string) {
+	if mappingValue(mapping, newKey) != nil {
+		return
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == oldKey {
+			mapping.Content[i].Value = newKey
+			return
+		}
+	}
+}
+
+// setSchemaVersion sets or inserts the schemaVersion key at the top of the
+// config mapping.
+func setSchemaVersion(mapping *yaml.Node, version int) {
+	value := strconv.Itoa(version)
+	if existing := mappingValue(mapping, "schemaVersion"); existing != nil {
+		existing.Value = value
+		existing.Tag = "!!int"
+		return
+	}
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "schemaVersion"}
+	valueNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: value}
+	mapping.Content = append([]*yaml.Node{keyNode, valueNode}, mapping.Content...)
+}
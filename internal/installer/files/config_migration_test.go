@@ -0,0 +1,85 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package files_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+)
+
+var _ = Describe("Config migration", func() {
+	Describe("ConfigSchemaVersion", func() {
+		It("reads the schemaVersion field", func() {
+			version, found, err := files.ConfigSchemaVersion([]byte("schemaVersion: 2\ndataCenter:\n  id: 1\n"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(version).To(Equal(2))
+		})
+
+		It("reports version 1 for configs without the field", func() {
+			version, found, err := files.ConfigSchemaVersion([]byte("dataCenter:\n  id: 1\n"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+			Expect(version).To(Equal(1))
+		})
+
+		It("rejects non-integer versions", func() {
+			_, _, err := files.ConfigSchemaVersion([]byte("schemaVersion: two\n"))
+			Expect(err).To(MatchError(ContainSubstring("must be an integer")))
+		})
+	})
+
+	Describe("MigrateConfigYAML", func() {
+		It("upgrades a version 1 config and records the transformations", func() {
+			legacy := []byte("# my config\ndatacenter:\n  id: 1\n  name: legacy\ncodesphere:\n  domain: cs.example.com\n")
+
+			migrated, applied, err := files.MigrateConfigYAML(legacy)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(applied).To(HaveLen(1))
+			Expect(applied[0]).To(ContainSubstring("v1 -> v2"))
+			Expect(applied[0]).To(ContainSubstring("datacenter to dataCenter"))
+
+			Expect(string(migrated)).To(ContainSubstring(fmt.Sprintf("schemaVersion: %d", files.CurrentSchemaVersion)))
+			Expect(string(migrated)).To(ContainSubstring("dataCenter:"))
+			Expect(string(migrated)).ToNot(ContainSubstring("datacenter:"))
+			// comments survive the migration
+			Expect(string(migrated)).To(ContainSubstring("# my config"))
+
+			config := &files.RootConfig{}
+			Expect(config.Unmarshal(migrated)).To(Succeed())
+			Expect(config.SchemaVersion).To(Equal(files.CurrentSchemaVersion))
+			Expect(config.Datacenter.Name).To(Equal("legacy"))
+		})
+
+		It("returns a current config unchanged", func() {
+			current := []byte(fmt.Sprintf("schemaVersion: %d\ndataCenter:\n  id: 1\n", files.CurrentSchemaVersion))
+
+			migrated, applied, err := files.MigrateConfigYAML(current)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(applied).To(BeEmpty())
+			Expect(migrated).To(Equal(current))
+		})
+
+		It("rejects configs newer than the supported schema", func() {
+			future := []byte(fmt.Sprintf("schemaVersion: %d\n", files.CurrentSchemaVersion+1))
+
+			_, _, err := files.MigrateConfigYAML(future)
+			Expect(err).To(MatchError(ContainSubstring("newer than the supported version")))
+		})
+
+		It("keeps fields unknown to the current structs", func() {
+			legacy := []byte("datacenter:\n  id: 1\nfutureSection:\n  keep: me\n")
+
+			migrated, _, err := files.MigrateConfigYAML(legacy)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(migrated)).To(ContainSubstring("futureSection:"))
+			Expect(string(migrated)).To(ContainSubstring("keep: me"))
+		})
+	})
+})
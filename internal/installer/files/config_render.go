@@ -0,0 +1,36 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package files
+
+// MarshalDeterministic serializes the RootConfig to canonical YAML suitable
+// for golden-file comparisons: optional sections that only hold their zero
+// values are dropped, struct fields keep their declaration order, and map
+// keys are emitted in sorted order. Rendering the same configuration twice,
+// or a configuration that spells out defaults instead of omitting them,
+// yields byte-identical output.
+func (c *RootConfig) MarshalDeterministic() ([]byte, error) {
+	c.normalizeDefaults()
+	return c.Marshal()
+}
+
+// normalizeDefaults clears optional sections that only hold their zero values
+// so that configs omitting a section and configs spelling out its defaults
+// render identically.
+func (c *RootConfig) normalizeDefaults() {
+	if c.Registry != nil && *c.Registry == (RegistryConfig{}) {
+		c.Registry = nil
+	}
+	if c.MetalLB != nil && !c.MetalLB.Enabled && len(c.MetalLB.Pools) == 0 && len(c.MetalLB.L2) == 0 && len(c.MetalLB.BGP) == 0 {
+		c.MetalLB = nil
+	}
+	if len(c.PcApps) == 0 {
+		c.PcApps = nil
+	}
+	if c.ManagedServiceBackends != nil && *c.ManagedServiceBackends == (ManagedServiceBackendsConfig{}) {
+		c.ManagedServiceBackends = nil
+	}
+	if c.Operations != nil && len(c.Operations.Skip) == 0 {
+		c.Operations = nil
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package files_test
+
+import (
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MarshalDeterministic", func() {
+	It("renders the same config byte-identically across invocations", func() {
+		first, err := files.NewTestRootConfig().MarshalDeterministic()
+		Expect(err).NotTo(HaveOccurred())
+
+		second, err := files.NewTestRootConfig().MarshalDeterministic()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(second).To(Equal(first))
+	})
+
+	It("renders spelled-out defaults identically to omitted sections", func() {
+		minimal := files.NewTestRootConfig()
+		explicit := files.NewTestRootConfig()
+		explicit.Registry = &files.RegistryConfig{}
+		explicit.MetalLB = &files.MetalLBConfig{}
+		explicit.PcApps = files.ChartValues{}
+		explicit.ManagedServiceBackends = &files.ManagedServiceBackendsConfig{}
+		explicit.Operations = &files.OperationsConfig{}
+
+		minimalYaml, err := minimal.MarshalDeterministic()
+		Expect(err).NotTo(HaveOccurred())
+		explicitYaml, err := explicit.MarshalDeterministic()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(explicitYaml).To(Equal(minimalYaml))
+	})
+
+	It("keeps non-default optional sections", func() {
+		config := files.NewTestRootConfig(files.WithRegistry("registry.example.com"))
+
+		rendered, err := config.MarshalDeterministic()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(string(rendered)).To(ContainSubstring("server: registry.example.com"))
+	})
+
+	It("survives a round trip through a canonical render", func() {
+		rendered, err := files.NewTestRootConfig().MarshalDeterministic()
+		Expect(err).NotTo(HaveOccurred())
+
+		parsed := &files.RootConfig{}
+		Expect(parsed.Unmarshal(rendered)).To(Succeed())
+
+		reRendered, err := parsed.MarshalDeterministic()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reRendered).To(Equal(rendered))
+	})
+})
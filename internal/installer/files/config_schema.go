@@ -0,0 +1,214 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package files
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+	jsonschemav6 "github.com/santhosh-tekuri/jsonschema/v6"
+	"go.yaml.in/yaml/v3"
+)
+
+// JSONSchema returns a custom schema for ImageRef, which is parsed either
+// as a plain image string or as an object referencing a BOM entry or a
+// Dockerfile (see UnmarshalYAML).
+func (ImageRef) JSONSchema() *jsonschema.Schema {
+	props := jsonschema.NewProperties()
+	props.Set("bomRef", &jsonschema.Schema{Type: "string"})
+	props.Set("dockerfile", &jsonschema.Schema{Type: "string"})
+	return &jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{
+			{Type: "string"},
+			{
+				Type:                 "object",
+				Properties:           props,
+				AdditionalProperties: jsonschema.FalseSchema,
+			},
+		},
+	}
+}
+
+// ConfigSchemaJSON generates a JSON Schema (draft 2020-12) for RootConfig
+// from its yaml struct tags. Fields without omitempty are required and
+// unknown keys are rejected, so the schema can be used to validate a
+// config.yaml before it reaches the installer, or to get editor support
+// when writing one.
+func ConfigSchemaJSON() ([]byte, error) {
+	reflector := jsonschema.Reflector{
+		FieldNameTag:   "yaml",
+		ExpandedStruct: true,
+		Anonymous:      true,
+	}
+
+	schema := reflector.Reflect(&RootConfig{})
+	schema.Title = "Codesphere install config"
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config schema: %w", err)
+	}
+	return data, nil
+}
+
+// ConfigValidationIssue is a single schema violation in a config file.
+type ConfigValidationIssue struct {
+	// Path is a JSON pointer to the offending value, e.g. /codesphere/domain.
+	Path string
+	// Line is the line number of the offending value in the YAML file,
+	// or 0 if it could not be determined.
+	Line int
+	// Message describes the violation.
+	Message string
+}
+
+func (i ConfigValidationIssue) String() string {
+	path := i.Path
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("line %d: %s: %s", i.Line, path, i.Message)
+}
+
+// ValidateConfigYAML validates a config.yaml against the generated
+// RootConfig schema. It returns one issue per violation (unknown keys,
+// type mismatches, missing required fields), annotated with the line
+// number in the input. An error is only returned when validation itself
+// cannot run, e.g. for unparsable YAML.
+func ValidateConfigYAML(data []byte) ([]ConfigValidationIssue, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	// Round-trip through JSON so the instance has the value types the
+	// schema validator expects.
+	jsonData, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert config to JSON: %w", err)
+	}
+	instance, err := jsonschemav6.UnmarshalJSON(bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert config to JSON: %w", err)
+	}
+
+	schema, err := compileConfigSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	err = schema.Validate(instance)
+	if err == nil {
+		return nil, nil
+	}
+	validationErr, ok := err.(*jsonschemav6.ValidationError)
+	if !ok {
+		return nil, fmt.Errorf("failed to validate config: %w", err)
+	}
+
+	issues := collectIssues(validationErr.BasicOutput(), &doc)
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+		return issues[i].Path < issues[j].Path
+	})
+	return issues, nil
+}
+
+func compileConfigSchema() (*jsonschemav6.Schema, error) {
+	schemaJSON, err := ConfigSchemaJSON()
+	if err != nil {
+		return nil, err
+	}
+	schemaDoc, err := jsonschemav6.UnmarshalJSON(bytes.NewReader(schemaJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config schema: %w", err)
+	}
+
+	compiler := jsonschemav6.NewCompiler()
+	if err := compiler.AddResource("config-schema.json", schemaDoc); err != nil {
+		return nil, fmt.Errorf("failed to load config schema: %w", err)
+	}
+	schema, err := compiler.Compile("config-schema.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile config schema: %w", err)
+	}
+	return schema, nil
+}
+
+// collectIssues flattens the basic output of the validator into issues
+// annotated with line numbers from the YAML document.
+func collectIssues(unit *jsonschemav6.OutputUnit, doc *yaml.Node) []ConfigValidationIssue {
+	var issues []ConfigValidationIssue
+	if unit.Error != nil && !unit.Valid {
+		issue := ConfigValidationIssue{
+			Path:    unit.InstanceLocation,
+			Message: unit.Error.String(),
+		}
+		if node := nodeAtPointer(doc, unit.InstanceLocation); node != nil {
+			issue.Line = node.Line
+		}
+		issues = append(issues, issue)
+	}
+	for i := range unit.Errors {
+		issues = append(issues, collectIssues(&unit.Errors[i], doc)...)
+	}
+	return issues
+}
+
+// nodeAtPointer resolves a JSON pointer to a node of the YAML document,
+// returning nil if the pointer does not resolve.
+func nodeAtPointer(doc *yaml.Node, pointer string) *yaml.Node {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil
+	}
+	node := doc.Content[0]
+
+	for _, segment := range strings.Split(pointer, "/") {
+		if segment == "" {
+			continue
+		}
+		segment = strings.ReplaceAll(segment, "~1", "/")
+		segment = strings.ReplaceAll(segment, "~0", "~")
+
+		if node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+
+		switch node.Kind {
+		case yaml.MappingNode:
+			var value *yaml.Node
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == segment {
+					value = node.Content[i+1]
+					break
+				}
+			}
+			if value == nil {
+				return nil
+			}
+			node = value
+		case yaml.SequenceNode:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node.Content) {
+				return nil
+			}
+			node = node.Content[index]
+		default:
+			return nil
+		}
+	}
+	return node
+}
@@ -0,0 +1,117 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package files_test
+
+import (
+	"encoding/json"
+
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"go.yaml.in/yaml/v3"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConfigSchemaJSON", func() {
+	It("generates a schema with yaml field names and required fields", func() {
+		schemaJSON, err := files.ConfigSchemaJSON()
+		Expect(err).NotTo(HaveOccurred())
+
+		var schema map[string]interface{}
+		Expect(json.Unmarshal(schemaJSON, &schema)).To(Succeed())
+
+		Expect(schema["$schema"]).To(ContainSubstring("2020-12"))
+		Expect(schema["properties"]).To(HaveKey("dataCenter"))
+		Expect(schema["required"]).To(ContainElement("dataCenter"))
+	})
+})
+
+var _ = Describe("ValidateConfigYAML", func() {
+	validConfigYAML := func() []byte {
+		data, err := yaml.Marshal(files.NewTestRootConfig())
+		Expect(err).NotTo(HaveOccurred())
+		return data
+	}
+
+	Context("with a valid config", func() {
+		It("reports no issues for a marshalled test config", func() {
+			issues, err := files.ValidateConfigYAML(validConfigYAML())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issues).To(BeEmpty())
+		})
+
+		It("accepts workspace images in string and object form", func() {
+			config := files.NewTestRootConfig()
+			config.Codesphere.WorkspaceImages = &files.WorkspaceImagesConfig{
+				Agent:  &files.ImageRef{ImageName: "registry.example.com/agent:v1"},
+				Server: &files.ImageRef{BomRef: "server-image"},
+			}
+			data, err := yaml.Marshal(config)
+			Expect(err).NotTo(HaveOccurred())
+
+			issues, err := files.ValidateConfigYAML(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issues).To(BeEmpty())
+		})
+	})
+
+	Context("with an invalid config", func() {
+		It("reports unknown keys with their line number", func() {
+			data := append(validConfigYAML(), []byte("notAConfigKey: true\n")...)
+
+			issues, err := files.ValidateConfigYAML(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issues).NotTo(BeEmpty())
+
+			messages := []string{}
+			for _, issue := range issues {
+				messages = append(messages, issue.Message)
+				Expect(issue.Line).To(BeNumerically(">", 0))
+			}
+			Expect(messages).To(ContainElement(ContainSubstring("notAConfigKey")))
+		})
+
+		It("reports type mismatches at the offending value", func() {
+			config := map[string]interface{}{}
+			Expect(yaml.Unmarshal(validConfigYAML(), &config)).To(Succeed())
+			config["dataCenter"].(map[string]interface{})["id"] = "not-a-number"
+			data, err := yaml.Marshal(config)
+			Expect(err).NotTo(HaveOccurred())
+
+			issues, err := files.ValidateConfigYAML(data)
+			Expect(err).NotTo(HaveOccurred())
+
+			var found *files.ConfigValidationIssue
+			for i := range issues {
+				if issues[i].Path == "/dataCenter/id" {
+					found = &issues[i]
+				}
+			}
+			Expect(found).NotTo(BeNil())
+			Expect(found.Line).To(BeNumerically(">", 0))
+		})
+
+		It("reports missing required fields", func() {
+			config := map[string]interface{}{}
+			Expect(yaml.Unmarshal(validConfigYAML(), &config)).To(Succeed())
+			delete(config, "dataCenter")
+			data, err := yaml.Marshal(config)
+			Expect(err).NotTo(HaveOccurred())
+
+			issues, err := files.ValidateConfigYAML(data)
+			Expect(err).NotTo(HaveOccurred())
+
+			messages := []string{}
+			for _, issue := range issues {
+				messages = append(messages, issue.Message)
+			}
+			Expect(messages).To(ContainElement(ContainSubstring("dataCenter")))
+		})
+
+		It("fails for unparsable yaml", func() {
+			_, err := files.ValidateConfigYAML([]byte("dataCenter: [unclosed"))
+			Expect(err).To(MatchError(ContainSubstring("failed to parse config file")))
+		})
+	})
+})
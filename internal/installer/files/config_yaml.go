@@ -61,11 +61,16 @@ type SecretFields struct {
 
 // RootConfig represents the relevant parts of the configuration file
 type RootConfig struct {
+	// SchemaVersion records which config schema the file was written for.
+	// Older configs are upgraded with `oms config migrate`; a missing value
+	// means the config predates the field.
+	SchemaVersion          int                           `yaml:"schemaVersion,omitempty"`
 	Datacenter             DatacenterConfig              `yaml:"dataCenter"`
 	Secrets                SecretsConfig                 `yaml:"secrets"`
 	Registry               *RegistryConfig               `yaml:"registry,omitempty"`
 	Postgres               PostgresConfig                `yaml:"postgres"`
 	Ceph                   CephConfig                    `yaml:"ceph"`
+	Storage                *StorageConfig                `yaml:"storage,omitempty"`
 	Kubernetes             KubernetesConfig              `yaml:"kubernetes"`
 	Cluster                ClusterConfig                 `yaml:"cluster"`
 	MetalLB                *MetalLBConfig                `yaml:"metallb,omitempty"`
@@ -124,11 +129,38 @@ type SSLConfig struct {
 }
 
 type CephConfig struct {
+	// Mode selects whether the installer deploys the Ceph cluster ("install",
+	// the default) or an existing external cluster is used ("external").
+	Mode          string     `yaml:"mode,omitempty"`
 	CsiKubeletDir string     `yaml:"csiKubeletDir,omitempty"`
 	CephAdmSSHKey CephSSHKey `yaml:"cephAdmSshKey"`
 	NodesSubnet   string     `yaml:"nodesSubnet"`
-	Hosts         []CephHost `yaml:"hosts"`
-	OSDs          []CephOSD  `yaml:"osds"`
+	// MonHosts are the monitor addresses of an external cluster; the keyring
+	// authenticating against it is provided via the vault.
+	MonHosts []string   `yaml:"monHosts,omitempty"`
+	Hosts    []CephHost `yaml:"hosts"`
+	OSDs     []CephOSD  `yaml:"osds"`
+	// Pool overrides the replication settings of the RBD pool backing
+	// workspace volumes; absent means the installer defaults.
+	Pool *CephPoolConfig `yaml:"pool,omitempty"`
+}
+
+type CephPoolConfig struct {
+	// ReplicaCount is the number of data replicas in the pool.
+	ReplicaCount int `yaml:"replicaCount,omitempty"`
+	// FailureDomain spreads the replicas across "host" or "osd".
+	FailureDomain string `yaml:"failureDomain,omitempty"`
+}
+
+// StorageConfig selects the storage backend providing workspace volumes.
+// When absent, workspaces are backed by the Ceph cluster from the ceph
+// section.
+type StorageConfig struct {
+	// Backend is "ceph" (the default), "longhorn" or "local-path".
+	Backend string `yaml:"backend"`
+	// StorageClass is the storage class workspace volumes are provisioned
+	// from; empty means the backend's default class.
+	StorageClass string `yaml:"storageClass,omitempty"`
 }
 
 type CephSSHKey struct {
@@ -163,12 +195,18 @@ type CephDBDevices struct {
 }
 
 type KubernetesConfig struct {
-	ManagedByCodesphere bool      `yaml:"managedByCodesphere"`
-	APIServerHost       string    `yaml:"apiServerHost,omitempty"`
-	ControlPlanes       []K8sNode `yaml:"controlPlanes,omitempty"`
-	Workers             []K8sNode `yaml:"workers,omitempty"`
-	PodCIDR             string    `yaml:"podCidr,omitempty"`
-	ServiceCIDR         string    `yaml:"serviceCidr,omitempty"`
+	ManagedByCodesphere bool `yaml:"managedByCodesphere"`
+	// Distribution is the Kubernetes distribution deployed on the nodes:
+	// k0s, k3s or rke2. Empty defaults to k0s.
+	Distribution  string    `yaml:"distribution,omitempty"`
+	APIServerHost string    `yaml:"apiServerHost,omitempty"`
+	ControlPlanes []K8sNode `yaml:"controlPlanes,omitempty"`
+	Workers       []K8sNode `yaml:"workers,omitempty"`
+	PodCIDR       string    `yaml:"podCidr,omitempty"`
+	ServiceCIDR   string    `yaml:"serviceCidr,omitempty"`
+	// NodeLocalDNS deploys a node-local-dns cache on every node so workloads
+	// resolve against a per-node cache instead of an external resolver.
+	NodeLocalDNS bool `yaml:"nodeLocalDns,omitempty"`
 
 	// Internal flag
 	NeedsKubeConfig bool `yaml:"-"`
@@ -176,6 +214,9 @@ type KubernetesConfig struct {
 
 type K8sNode struct {
 	IPAddress string `yaml:"ipAddress"`
+	// Taints are applied to the node when it registers, in the
+	// key[=value]:effect format used by kubectl taint.
+	Taints []string `yaml:"taints,omitempty"`
 }
 
 type ClusterConfig struct {
@@ -319,6 +360,7 @@ type CodesphereConfig struct {
 	OpenBao                    *OpenBaoConfig         `yaml:"openBao,omitempty"`
 	Migration                  *MigrationConfig       `yaml:"migration,omitempty"`
 	TelemetryExport            *TelemetryExport       `yaml:"telemetryExport,omitempty"`
+	WorkspaceEgress            *WorkspaceEgressConfig `yaml:"workspaceEgress,omitempty"`
 	Override                   ChartOverride          `yaml:"override,omitempty"`
 }
 
@@ -333,6 +375,18 @@ type MigrationPostgresConfig struct {
 	AltName  string `yaml:"altName,omitempty"`
 }
 
+// WorkspaceEgressConfig restricts outbound traffic from workspaces via
+// NetworkPolicies applied before the platform install. With DefaultDeny set,
+// all workspace egress except DNS is blocked unless it matches an allowed
+// CIDR or a CIDR resolved from an allowed domain at install time.
+type WorkspaceEgressConfig struct {
+	// Namespace the workspace pods run in. Defaults to "codesphere".
+	Namespace      string   `yaml:"namespace,omitempty"`
+	DefaultDeny    bool     `yaml:"defaultDeny"`
+	AllowedCIDRs   []string `yaml:"allowedCidrs,omitempty"`
+	AllowedDomains []string `yaml:"allowedDomains,omitempty"`
+}
+
 type OpenBaoConfig struct {
 	Engine string `yaml:"engine,omitempty"`
 	URI    string `yaml:"uri,omitempty"`
@@ -639,6 +693,7 @@ func (c *RootConfig) Unmarshal(data []byte) error {
 
 func NewRootConfig() RootConfig {
 	return RootConfig{
+		SchemaVersion:          CurrentSchemaVersion,
 		Registry:               &RegistryConfig{},
 		MetalLB:                &MetalLBConfig{},
 		PcApps:                 ChartValues{},
@@ -59,6 +59,7 @@ const (
 	SecretPostgresReplicaServerKeyPem = "postgresReplicaServerKeyPem"
 
 	// Ceph
+	SecretCephKeyring       = "cephKeyring"
 	SecretCephSshPrivateKey = "cephSshPrivateKey"
 
 	// SSH workspace proxy
@@ -67,6 +68,10 @@ const (
 	// Cluster / TLS
 	SecretSelfSignedCaKeyPem = "selfSignedCaKeyPem"
 
+	// Cloudflare
+	SecretCloudflareOriginCertPem = "cloudflareOriginCertPem"
+	SecretCloudflareOriginKeyPem  = "cloudflareOriginKeyPem"
+
 	// Mounter
 	SecretMounterHmacSecret = "mounterHmacSecret"
 
@@ -0,0 +1,125 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package files
+
+// RootConfigOption customizes a test config built by NewTestRootConfig.
+type RootConfigOption func(*RootConfig)
+
+// NewTestRootConfig returns a minimal valid install config for use in tests,
+// so specs do not have to hand-assemble the deeply nested structs. The
+// baseline is a Codesphere-managed cluster with a single control plane;
+// options adjust the parts a test cares about. Exposed for downstream test
+// code as well.
+func NewTestRootConfig(opts ...RootConfigOption) *RootConfig {
+	config := &RootConfig{
+		Datacenter: DatacenterConfig{
+			ID:          1,
+			Name:        "test-dc",
+			City:        "Test City",
+			CountryCode: "US",
+		},
+		Kubernetes: KubernetesConfig{
+			ManagedByCodesphere: true,
+			ControlPlanes: []K8sNode{
+				{IPAddress: "192.168.1.100"},
+			},
+			APIServerHost: "api.test.example.com",
+		},
+		Codesphere: CodesphereConfig{
+			Domain:   "test.example.com",
+			PublicIP: "192.168.1.100",
+			DeployConfig: DeployConfig{
+				Images: map[string]ImageConfig{},
+			},
+			Plans: PlansConfig{
+				HostingPlans:   map[int]HostingPlan{},
+				WorkspacePlans: map[int]WorkspacePlan{},
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return config
+}
+
+// WithDatacenterName sets the datacenter name.
+func WithDatacenterName(name string) RootConfigOption {
+	return func(c *RootConfig) {
+		c.Datacenter.Name = name
+	}
+}
+
+// WithControlPlanes replaces the control plane nodes with the given IPs.
+func WithControlPlanes(ips ...string) RootConfigOption {
+	return func(c *RootConfig) {
+		c.Kubernetes.ControlPlanes = make([]K8sNode, 0, len(ips))
+		for _, ip := range ips {
+			c.Kubernetes.ControlPlanes = append(c.Kubernetes.ControlPlanes, K8sNode{IPAddress: ip})
+		}
+	}
+}
+
+// WithWorkers replaces the worker nodes with the given IPs.
+func WithWorkers(ips ...string) RootConfigOption {
+	return func(c *RootConfig) {
+		c.Kubernetes.Workers = make([]K8sNode, 0, len(ips))
+		for _, ip := range ips {
+			c.Kubernetes.Workers = append(c.Kubernetes.Workers, K8sNode{IPAddress: ip})
+		}
+	}
+}
+
+// WithPublicIP sets the public IP of the Codesphere installation.
+func WithPublicIP(ip string) RootConfigOption {
+	return func(c *RootConfig) {
+		c.Codesphere.PublicIP = ip
+	}
+}
+
+// WithSelfManagedKubernetes marks the cluster as not managed by Codesphere.
+func WithSelfManagedKubernetes() RootConfigOption {
+	return func(c *RootConfig) {
+		c.Kubernetes.ManagedByCodesphere = false
+	}
+}
+
+// WithRegistry configures a container registry with the given server.
+func WithRegistry(server string) RootConfigOption {
+	return func(c *RootConfig) {
+		c.Registry = &RegistryConfig{
+			Server:              server,
+			ReplaceImagesInBom:  true,
+			LoadContainerImages: true,
+		}
+	}
+}
+
+// WithPostgresMode sets the postgres mode (e.g. "install" or "external").
+func WithPostgresMode(mode string) RootConfigOption {
+	return func(c *RootConfig) {
+		c.Postgres.Mode = mode
+	}
+}
+
+// WithExternalPostgres configures an externally managed postgres server.
+func WithExternalPostgres(serverAddress string) RootConfigOption {
+	return func(c *RootConfig) {
+		c.Postgres.Mode = "external"
+		c.Postgres.ServerAddress = serverAddress
+	}
+}
+
+// WithPrimaryPostgres configures an installed postgres primary.
+func WithPrimaryPostgres(ip, hostname string) RootConfigOption {
+	return func(c *RootConfig) {
+		c.Postgres.Mode = "install"
+		c.Postgres.Primary = &PostgresPrimaryConfig{
+			IP:       ip,
+			Hostname: hostname,
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package files_test
+
+import (
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewTestRootConfig", func() {
+	It("builds a minimal managed cluster config", func() {
+		config := files.NewTestRootConfig()
+
+		Expect(config.Datacenter.Name).To(Equal("test-dc"))
+		Expect(config.Kubernetes.ManagedByCodesphere).To(BeTrue())
+		Expect(config.Kubernetes.ControlPlanes).To(HaveLen(1))
+		Expect(config.Codesphere.Domain).To(Equal("test.example.com"))
+	})
+
+	It("survives a yaml round trip", func() {
+		config := files.NewTestRootConfig()
+
+		data, err := config.Marshal()
+		Expect(err).NotTo(HaveOccurred())
+
+		parsed := &files.RootConfig{}
+		Expect(parsed.Unmarshal(data)).To(Succeed())
+		Expect(parsed.Kubernetes.APIServerHost).To(Equal("api.test.example.com"))
+	})
+
+	It("applies node options", func() {
+		config := files.NewTestRootConfig(
+			files.WithControlPlanes("10.0.0.1", "10.0.0.2"),
+			files.WithWorkers("10.0.0.3"),
+		)
+
+		Expect(config.Kubernetes.ControlPlanes).To(Equal([]files.K8sNode{{IPAddress: "10.0.0.1"}, {IPAddress: "10.0.0.2"}}))
+		Expect(config.Kubernetes.Workers).To(Equal([]files.K8sNode{{IPAddress: "10.0.0.3"}}))
+	})
+
+	It("applies registry options", func() {
+		config := files.NewTestRootConfig(files.WithRegistry("registry.example.com"))
+
+		Expect(config.Registry).NotTo(BeNil())
+		Expect(config.Registry.Server).To(Equal("registry.example.com"))
+		Expect(config.Registry.ReplaceImagesInBom).To(BeTrue())
+	})
+
+	It("applies postgres options", func() {
+		external := files.NewTestRootConfig(files.WithExternalPostgres("postgres.example.com:5432"))
+		Expect(external.Postgres.Mode).To(Equal("external"))
+		Expect(external.Postgres.ServerAddress).To(Equal("postgres.example.com:5432"))
+
+		installed := files.NewTestRootConfig(files.WithPrimaryPostgres("10.0.0.4", "postgres"))
+		Expect(installed.Postgres.Mode).To(Equal("install"))
+		Expect(installed.Postgres.Primary.IP).To(Equal("10.0.0.4"))
+		Expect(installed.Postgres.Primary.Hostname).To(Equal("postgres"))
+	})
+})
@@ -0,0 +1,14 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package node
+
+import "net"
+
+// dialAgent connects to the ssh-agent listening on the unix socket
+// referenced by SSH_AUTH_SOCK.
+func dialAgent(authSocket string) (net.Conn, error) {
+	return net.Dial("unix", authSocket)
+}
@@ -0,0 +1,18 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package node
+
+import (
+	"net"
+
+	"github.com/Microsoft/go-winio"
+)
+
+// dialAgent connects to the Windows OpenSSH agent. On Windows SSH_AUTH_SOCK
+// holds a named pipe (usually \\.\pipe\openssh-ssh-agent), not a unix socket.
+func dialAgent(authSocket string) (net.Conn, error) {
+	return winio.DialPipe(authSocket, nil)
+}
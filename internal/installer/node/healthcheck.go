@@ -0,0 +1,117 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package node
+
+import (
+	"fmt"
+)
+
+// HealthCheck is a single readiness probe executed on a node. Hint tells the
+// operator how to fix the problem when the probe fails.
+type HealthCheck struct {
+	Name    string
+	Command string
+	Hint    string
+}
+
+// HealthCheckResult is the outcome of one probe on one node.
+type HealthCheckResult struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Hint   string `json:"hint,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// HealthReport collects the probe results for a single node.
+type HealthReport struct {
+	Node    string              `json:"node"`
+	Results []HealthCheckResult `json:"results"`
+}
+
+// Healthy reports whether every probe passed.
+func (r HealthReport) Healthy() bool {
+	for _, result := range r.Results {
+		if !result.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// Failures returns the probes that failed.
+func (r HealthReport) Failures() []HealthCheckResult {
+	var failures []HealthCheckResult
+	for _, result := range r.Results {
+		if !result.Passed {
+			failures = append(failures, result)
+		}
+	}
+	return failures
+}
+
+// HealthChecker verifies that a provisioned node is ready for the Codesphere
+// install: disk capacity, kernel parameters, time synchronization, DNS
+// resolution, and outbound connectivity.
+type HealthChecker struct {
+	// RegistryHost is the container registry host used for the DNS and
+	// outbound connectivity probes.
+	RegistryHost string
+}
+
+// NewHealthChecker creates a health checker. An empty registryHost falls back
+// to ghcr.io.
+func NewHealthChecker(registryHost string) *HealthChecker {
+	if registryHost == "" {
+		registryHost = "ghcr.io"
+	}
+	return &HealthChecker{RegistryHost: registryHost}
+}
+
+func (h *HealthChecker) checks() []HealthCheck {
+	return []HealthCheck{
+		{
+			Name:    "disk capacity",
+			Command: `test "$(df --output=pcent / | tail -1 | tr -dc '0-9')" -lt 90`,
+			Hint:    "the root filesystem is over 90% full; grow the disk or free up space",
+		},
+		{
+			Name:    "kernel parameters",
+			Command: `test "$(sysctl -n vm.max_map_count)" -ge 262144 && test "$(sysctl -n fs.inotify.max_user_watches)" -ge 1048576`,
+			Hint:    "sysctl limits are below the values bootstrap configures; apply /etc/sysctl.conf with 'sysctl -p' or re-run bootstrap",
+		},
+		{
+			Name:    "time synchronization",
+			Command: `test "$(timedatectl show -p NTPSynchronized --value)" = yes`,
+			Hint:    "enable NTP with 'timedatectl set-ntp true' and wait for the clock to synchronize",
+		},
+		{
+			Name:    "DNS resolution",
+			Command: fmt.Sprintf("getent hosts %s >/dev/null", h.RegistryHost),
+			Hint:    fmt.Sprintf("the node cannot resolve %s; check /etc/resolv.conf and the VPC DNS settings", h.RegistryHost),
+		},
+		{
+			// The registry answers unauthenticated requests with 401, so only
+			// connection-level failures are treated as unreachable.
+			Name:    "outbound connectivity",
+			Command: fmt.Sprintf("curl -sS --max-time 10 -o /dev/null https://%s/v2/", h.RegistryHost),
+			Hint:    fmt.Sprintf("the node cannot reach https://%s; check NAT and firewall egress rules", h.RegistryHost),
+		},
+	}
+}
+
+// CheckNode runs all probes on the node. Probes keep running after a failure
+// so the report lists every problem at once.
+func (h *HealthChecker) CheckNode(n *Node) HealthReport {
+	report := HealthReport{Node: n.GetName()}
+	for _, check := range h.checks() {
+		result := HealthCheckResult{Name: check.Name, Passed: true}
+		if err := n.RunSSHCommand("root", check.Command); err != nil {
+			result.Passed = false
+			result.Hint = check.Hint
+			result.Error = err.Error()
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report
+}
@@ -0,0 +1,106 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package node
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/codesphere-cloud/oms/internal/env"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// Host keys are verified against a dedicated known_hosts file in the OMS
+// workdir instead of StrictHostKeyChecking=no. Keys are recorded on first
+// connection (or seeded from GCP guest attributes by the bootstrapper) and any
+// later mismatch fails the connection.
+var (
+	insecureIgnoreHostKeys bool
+	knownHostsMu           sync.Mutex
+)
+
+// SetInsecureIgnoreHostKeys disables host key verification entirely. It backs
+// the --insecure-ignore-host-keys escape hatch for environments where host
+// keys legitimately change, e.g. when instances are recreated.
+func SetInsecureIgnoreHostKeys(insecure bool) {
+	insecureIgnoreHostKeys = insecure
+}
+
+// KnownHostsPath returns the dedicated known_hosts file managed by OMS.
+func KnownHostsPath() string {
+	return filepath.Join(env.NewEnv().GetOmsWorkdir(), "known_hosts")
+}
+
+// RecordHostKey writes a host key for the given host to the managed
+// known_hosts file, replacing any previously recorded key of the same type.
+// The key is expected in authorized-keys format (e.g. "ssh-ed25519 AAAA...")
+// as published in GCP guest attributes.
+func RecordHostKey(host string, keyLine string) error {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(keyLine))
+	if err != nil {
+		return fmt.Errorf("failed to parse host key for %s: %w", host, err)
+	}
+	return appendKnownHost(host, key)
+}
+
+func appendKnownHost(host string, key ssh.PublicKey) error {
+	knownHostsMu.Lock()
+	defer knownHostsMu.Unlock()
+
+	path := KnownHostsPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create known_hosts directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open known_hosts file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	line := knownhosts.Line([]string{knownhosts.Normalize(host)}, key)
+	if _, err := fmt.Fprintln(file, line); err != nil {
+		return fmt.Errorf("failed to write known_hosts entry: %w", err)
+	}
+	return nil
+}
+
+// hostKeyCallback returns the host key verification used for every SSH
+// connection. Unknown hosts are trusted on first use and recorded; a key that
+// differs from the recorded one fails the connection.
+func hostKeyCallback() ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		if insecureIgnoreHostKeys {
+			return nil
+		}
+
+		path := KnownHostsPath()
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return appendKnownHost(hostname, key)
+		}
+
+		verify, err := knownhosts.New(path)
+		if err != nil {
+			return fmt.Errorf("failed to load known_hosts file %s: %w", path, err)
+		}
+
+		err = verify(hostname, remote, key)
+		if err == nil {
+			return nil
+		}
+
+		var keyErr *knownhosts.KeyError
+		if errors.As(err, &keyErr) && len(keyErr.Want) == 0 {
+			// First connection to this host: trust and record the key.
+			return appendKnownHost(hostname, key)
+		}
+
+		return fmt.Errorf("host key verification failed for %s (use --insecure-ignore-host-keys to bypass): %w", hostname, err)
+	}
+}
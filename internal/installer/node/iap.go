@@ -0,0 +1,91 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package node
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// IAPTunnel describes how to reach instances through Google Identity-Aware
+// Proxy TCP forwarding instead of public IPs. When set on a node, all SSH and
+// SFTP connections are established through an IAP tunnel to the instance name,
+// so no external IP or public SSH firewall rule is required.
+type IAPTunnel struct {
+	Project string
+	Zone    string
+}
+
+// Dial opens an IAP tunnel to the SSH port of the given instance and returns
+// it as a net.Conn usable as SSH transport. The tunnel is backed by a
+// `gcloud compute start-iap-tunnel` process speaking on stdio.
+func (t *IAPTunnel) Dial(instance string, port int) (net.Conn, error) {
+	cmd := exec.Command("gcloud", "compute", "start-iap-tunnel", instance, fmt.Sprintf("%d", port),
+		"--listen-on-stdin",
+		"--project", t.Project,
+		"--zone", t.Zone,
+		"--verbosity=warning")
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdin pipe for IAP tunnel: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout pipe for IAP tunnel: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start IAP tunnel process (is gcloud installed?): %w", err)
+	}
+
+	return &iapConn{
+		cmd:      cmd,
+		stdin:    stdin,
+		stdout:   stdout,
+		instance: instance,
+	}, nil
+}
+
+// iapConn adapts the stdio of the tunnel process to a net.Conn so the SSH
+// client can use it as a transport.
+type iapConn struct {
+	cmd      *exec.Cmd
+	stdin    io.WriteCloser
+	stdout   io.ReadCloser
+	instance string
+}
+
+func (c *iapConn) Read(p []byte) (int, error)  { return c.stdout.Read(p) }
+func (c *iapConn) Write(p []byte) (int, error) { return c.stdin.Write(p) }
+
+func (c *iapConn) Close() error {
+	_ = c.stdin.Close()
+	_ = c.stdout.Close()
+	if c.cmd.Process != nil {
+		_ = c.cmd.Process.Kill()
+	}
+	return c.cmd.Wait()
+}
+
+func (c *iapConn) LocalAddr() net.Addr  { return iapAddr{instance: "local"} }
+func (c *iapConn) RemoteAddr() net.Addr { return iapAddr{instance: c.instance} }
+
+// Deadlines are not supported on the process-backed transport; the SSH layer
+// applies its own connection timeout.
+func (c *iapConn) SetDeadline(_ time.Time) error      { return nil }
+func (c *iapConn) SetReadDeadline(_ time.Time) error  { return nil }
+func (c *iapConn) SetWriteDeadline(_ time.Time) error { return nil }
+
+type iapAddr struct {
+	instance string
+}
+
+func (a iapAddr) Network() string { return "iap" }
+func (a iapAddr) String() string  { return a.instance }
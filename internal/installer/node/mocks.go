@@ -282,6 +282,75 @@ func (_c *MockNodeClient_RunCommand_Call) RunAndReturn(run func(n *Node, usernam
 	return _c
 }
 
+// RunCommandWithInput provides a mock function for the type MockNodeClient
+func (_mock *MockNodeClient) RunCommandWithInput(n *Node, username string, command string, input string) error {
+	ret := _mock.Called(n, username, command, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunCommandWithInput")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*Node, string, string, string) error); ok {
+		r0 = returnFunc(n, username, command, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockNodeClient_RunCommandWithInput_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunCommandWithInput'
+type MockNodeClient_RunCommandWithInput_Call struct {
+	*mock.Call
+}
+
+// RunCommandWithInput is a helper method to define mock.On call
+//   - n *Node
+//   - username string
+//   - command string
+//   - input string
+func (_e *MockNodeClient_Expecter) RunCommandWithInput(n any, username any, command any, input any) *MockNodeClient_RunCommandWithInput_Call {
+	return &MockNodeClient_RunCommandWithInput_Call{Call: _e.mock.On("RunCommandWithInput", n, username, command, input)}
+}
+
+func (_c *MockNodeClient_RunCommandWithInput_Call) Run(run func(n *Node, username string, command string, input string)) *MockNodeClient_RunCommandWithInput_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *Node
+		if args[0] != nil {
+			arg0 = args[0].(*Node)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNodeClient_RunCommandWithInput_Call) Return(err error) *MockNodeClient_RunCommandWithInput_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockNodeClient_RunCommandWithInput_Call) RunAndReturn(run func(n *Node, username string, command string, input string) error) *MockNodeClient_RunCommandWithInput_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WaitReady provides a mock function for the type MockNodeClient
 func (_mock *MockNodeClient) WaitReady(n *Node, timeout time.Duration) error {
 	ret := _mock.Called(n, timeout)
@@ -5,17 +5,23 @@ package node
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"log"
-	"net"
 	"os"
-	"path/filepath"
+	"path"
+	"runtime"
+	"strings"
 	"sync"
-	"syscall"
 	"time"
 
+	"github.com/codesphere-cloud/oms/internal/audit"
+	"github.com/codesphere-cloud/oms/internal/redact"
+	"github.com/codesphere-cloud/oms/internal/telemetry"
 	"github.com/codesphere-cloud/oms/internal/util"
 	"github.com/pkg/sftp"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/crypto/ssh/agent"
 	"golang.org/x/term"
@@ -25,13 +31,19 @@ type Node struct {
 	FileIO util.FileIO `json:"-"`
 	// If connecting via the Jumpbox
 	Jumpbox *Node `json:"-"`
+	// If connecting through Google Identity-Aware Proxy instead of public IPs
+	IAPTunnel *IAPTunnel `json:"-"`
 	// Config
-	keyPath      string     `json:"-"`
-	Name         string     `json:"name"`
-	ExternalIP   string     `json:"external_ip"`
-	InternalIP   string     `json:"internal_ip"`
+	keyPath    string `json:"-"`
+	Name       string `json:"name"`
+	ExternalIP string `json:"external_ip"`
+	InternalIP string `json:"internal_ip"`
+	// SSHPort is the port sshd listens on; zero means DefaultSSHPort.
+	SSHPort      int        `json:"ssh_port,omitempty"`
 	cachedSigner ssh.Signer `json:"-"`
 	sshQuiet     bool       `json:"-"`
+	// Operating system of the node, defaults to Ubuntu
+	os OperatingSystem `json:"-"`
 
 	NodeClient NodeClient `json:"-"`
 	// SSH client cache: map[username]*ssh.Client
@@ -42,6 +54,7 @@ type Node struct {
 //mockery:generate: true
 type NodeClient interface {
 	RunCommand(n *Node, username string, command string) error
+	RunCommandWithInput(n *Node, username string, command string, input string) error
 	CopyFile(n *Node, src string, dst string) error
 	DownloadFile(n *Node, src string, dst string) error
 	WaitReady(n *Node, timeout time.Duration) error
@@ -59,6 +72,26 @@ func NewSSHNodeClient(quiet bool) *SSHNodeClient {
 }
 
 func (r *SSHNodeClient) RunCommand(n *Node, username string, command string) error {
+	return r.runCommand(n, username, command, nil)
+}
+
+// RunCommandWithInput runs a command with input supplied on stdin, keeping
+// secrets off the command line and out of process listings on the node.
+func (r *SSHNodeClient) RunCommandWithInput(n *Node, username string, command string, input string) error {
+	return r.runCommand(n, username, command, strings.NewReader(input))
+}
+
+func (r *SSHNodeClient) runCommand(n *Node, username string, command string, stdin io.Reader) (err error) {
+	// Command lines regularly contain secrets (htpasswd, certificates), so
+	// only the leading token is attached to the span and the audit log.
+	_, span := telemetry.StartSpan(context.Background(), "ssh.run",
+		attribute.String("ssh.node", n.Name),
+		attribute.String("ssh.user", username),
+		attribute.String("ssh.command", firstToken(command)),
+	)
+	defer func() { telemetry.EndSpan(span, err) }()
+	audit.Log("ssh.run", n.Name, username, firstToken(command))
+
 	var jumpboxIp string
 	var ip string
 	if n.Jumpbox != nil {
@@ -93,11 +126,15 @@ func (r *SSHNodeClient) RunCommand(n *Node, username string, command string) err
 	_ = session.Setenv("OMS_PORTAL_API", os.Getenv("OMS_PORTAL_API"))
 	_ = agent.RequestAgentForwarding(session) // Best effort, ignore errors
 
+	if stdin != nil {
+		session.Stdin = stdin
+	}
+
 	var stderrBuf bytes.Buffer
 	session.Stderr = &stderrBuf
 	if !r.Quiet {
-		session.Stdout = os.Stdout
-		session.Stderr = os.Stderr
+		session.Stdout = redact.NewWriter(os.Stdout)
+		session.Stderr = redact.NewWriter(os.Stderr)
 	}
 	// Start the command
 	if err := session.Start(command); err != nil {
@@ -107,13 +144,22 @@ func (r *SSHNodeClient) RunCommand(n *Node, username string, command string) err
 	if err := session.Wait(); err != nil {
 		// A non-zero exit status from the remote command is also considered an error
 		if r.Quiet && stderrBuf.Len() > 0 {
-			return fmt.Errorf("command failed: %w\n%s", err, stderrBuf.String())
+			return fmt.Errorf("command failed: %w\n%s", err, redact.Redact(stderrBuf.String()))
 		}
 		return fmt.Errorf("command failed: %w", err)
 	}
 	return nil
 }
 
+// firstToken returns the first whitespace-separated token of a command line.
+func firstToken(command string) string {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
 const jumpboxUser = "ubuntu"
 
 // NewNode creates a new Node with the given File
@@ -123,9 +169,12 @@ func (n *Node) CreateSubNode(name string, externalIP string, internalIP string)
 		// Inherited from jumpbox
 		FileIO:     n.FileIO,
 		Jumpbox:    n,
+		IAPTunnel:  n.IAPTunnel,
 		keyPath:    util.ExpandPath(n.keyPath),
 		sshQuiet:   n.sshQuiet,
+		os:         n.os,
 		NodeClient: n.NodeClient,
+		SSHPort:    n.SSHPort,
 
 		// Custom
 		Name:        name,
@@ -135,6 +184,39 @@ func (n *Node) CreateSubNode(name string, externalIP string, internalIP string)
 	}
 }
 
+// SetSSHKeyPath configures the private key file used as a fallback when the
+// key is not available from a running ssh-agent. Sub-nodes created afterwards
+// inherit the path.
+func (n *Node) SetSSHKeyPath(path string) {
+	n.keyPath = util.ExpandPath(path)
+}
+
+// DefaultSSHPort is the port SSH connections use when a node doesn't
+// configure one.
+const DefaultSSHPort = 22
+
+// GetSSHPort returns the SSH port of the node, defaulting to DefaultSSHPort.
+func (n *Node) GetSSHPort() int {
+	if n.SSHPort == 0 {
+		return DefaultSSHPort
+	}
+	return n.SSHPort
+}
+
+// SetOS configures the operating system of the node. Sub-nodes created
+// afterwards inherit it.
+func (n *Node) SetOS(os OperatingSystem) {
+	n.os = os
+}
+
+// OS returns the operating system of the node, defaulting to Ubuntu.
+func (n *Node) OS() OperatingSystem {
+	if n.os == nil {
+		return UbuntuOS{}
+	}
+	return n.os
+}
+
 // UpdateNode updates the node's name and IP addresses
 func (n *Node) UpdateNode(name string, externalIP string, internalIP string) {
 	n.Name = name
@@ -186,6 +268,12 @@ func (n *Node) RunSSHCommand(username string, command string) error {
 	return n.NodeClient.RunCommand(n, username, command)
 }
 
+// RunSSHCommandWithInput runs a command on the remote node with input
+// supplied on stdin, keeping secrets off the command line.
+func (n *Node) RunSSHCommandWithInput(username string, command string, input string) error {
+	return n.NodeClient.RunCommandWithInput(n, username, command, input)
+}
+
 // HasCommand checks if a command exists on the remote node via SSH
 func (n *Node) HasCommand(command string) bool {
 	checkCommand := fmt.Sprintf("command -v %s >/dev/null 2>&1", command)
@@ -197,11 +285,24 @@ func (n *Node) HasCommand(command string) bool {
 	return true
 }
 
-// InstallOms installs the OMS CLI on the remote node via SSH
-func (n *Node) InstallOms() error {
+// InstallOms installs the OMS CLI on the remote node via SSH. The release
+// matching version is installed so the remote CLI matches the local one;
+// development builds (empty or 0.0.0 version) fall back to the latest release.
+// The downloaded binary is verified against the release's checksum file before
+// it is moved into place. Air-gapped nodes without GitHub access are supplied
+// with a local binary instead (see RemoteOmsBinaryPath).
+func (n *Node) InstallOms(version string) error {
+	release := "latest"
+	if version != "" && version != "0.0.0" {
+		release = "tags/v" + strings.TrimPrefix(version, "v")
+	}
+	releaseURL := fmt.Sprintf("https://api.github.com/repos/codesphere-cloud/oms/releases/%s", release)
+
 	remoteCommands := []string{
-		"wget -qO- 'https://api.github.com/repos/codesphere-cloud/oms/releases/latest' | jq -r '.assets[] | select(.name | match(\"oms.*linux_amd64\")) | .browser_download_url' | xargs wget -O oms",
-		"chmod +x oms; sudo mv oms /usr/local/bin/",
+		fmt.Sprintf("wget -qO- '%s' | jq -r '.assets[] | select(.name | match(\"oms.*linux_amd64$\")) | .browser_download_url' | xargs wget -O oms", releaseURL),
+		fmt.Sprintf("wget -qO- '%s' | jq -r '.assets[] | select(.name | test(\"checksums\")) | .browser_download_url' | xargs wget -qO oms-checksums.txt", releaseURL),
+		"grep -E ' oms[^ ]*linux_amd64$' oms-checksums.txt | awk '{print $1 \"  oms\"}' | sha256sum -c -",
+		"chmod +x oms; sudo mv oms /usr/local/bin/; rm -f oms-checksums.txt",
 	}
 	for _, cmd := range remoteCommands {
 		err := n.RunSSHCommand("root", cmd)
@@ -266,6 +367,51 @@ func (n *Node) ConfigureAcceptEnv() error {
 	return nil
 }
 
+// SSHDHardeningPath is where the sshd hardening drop-in is written. Files in
+// sshd_config.d are read before the main config, so its settings win.
+const SSHDHardeningPath = "/etc/ssh/sshd_config.d/99-oms-hardening.conf"
+
+// SSHDHardeningConfig returns the content of the sshd hardening drop-in:
+// a bounded number of authentication attempts and modern AEAD ciphers only.
+// The listen port is included when it deviates from the default.
+func SSHDHardeningConfig(port int) string {
+	lines := []string{
+		"MaxAuthTries 3",
+		"Ciphers chacha20-poly1305@openssh.com,aes256-gcm@openssh.com,aes128-gcm@openssh.com",
+	}
+	if port != DefaultSSHPort {
+		lines = append([]string{fmt.Sprintf("Port %d", port)}, lines...)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// HasHardenedSSHD checks if the sshd hardening drop-in is in place
+func (n *Node) HasHardenedSSHD() bool {
+	checkCommand := fmt.Sprintf("sudo grep -qxF 'MaxAuthTries 3' %s >/dev/null 2>&1", SSHDHardeningPath)
+	err := n.RunSSHCommand("ubuntu", checkCommand)
+	if err != nil {
+		// If the command returns a NON-zero exit status, it means the drop-in is not in place
+		return false
+	}
+	return true
+}
+
+// HardenSSHD writes the sshd hardening drop-in and restarts sshd
+func (n *Node) HardenSSHD() error {
+	content := strings.ReplaceAll(SSHDHardeningConfig(n.GetSSHPort()), "\n", "\\n")
+	cmds := []string{
+		fmt.Sprintf("sudo sh -c \"mkdir -p /etc/ssh/sshd_config.d && printf '%s' > %s\"", content, SSHDHardeningPath),
+		"sudo systemctl restart sshd",
+	}
+	for _, cmd := range cmds {
+		err := n.RunSSHCommand("ubuntu", cmd)
+		if err != nil {
+			return fmt.Errorf("failed to run command '%s': %w", cmd, err)
+		}
+	}
+	return nil
+}
+
 // HasRootLoginEnabled checks if root login is enabled on the remote node via SSH
 func (n *Node) HasRootLoginEnabled() bool {
 	checkCommandPermit := "sudo grep -E '^PermitRootLogin yes' /etc/ssh/sshd_config >/dev/null 2>&1"
@@ -334,7 +480,14 @@ func (c *SSHNodeClient) HasFile(n *Node, filePath string) bool {
 }
 
 // CopyFile copies a file from the local system to the remote node via SFTP
-func (c *SSHNodeClient) CopyFile(n *Node, src string, dst string) error {
+func (c *SSHNodeClient) CopyFile(n *Node, src string, dst string) (err error) {
+	_, span := telemetry.StartSpan(context.Background(), "ssh.copy",
+		attribute.String("ssh.node", n.Name),
+		attribute.String("ssh.file", dst),
+	)
+	defer func() { telemetry.EndSpan(span, err) }()
+	audit.Log("ssh.copy", n.Name, dst)
+
 	jumpBoxIP := ""
 	nodeIP := n.ExternalIP
 	if n.Jumpbox != nil {
@@ -342,7 +495,8 @@ func (c *SSHNodeClient) CopyFile(n *Node, src string, dst string) error {
 		nodeIP = n.InternalIP
 	}
 
-	err := n.ensureDirectoryExists("root", filepath.Dir(dst))
+	// Remote paths are always POSIX, regardless of the operator's OS.
+	err = n.ensureDirectoryExists("root", path.Dir(dst))
 	if err != nil {
 		return fmt.Errorf("failed to ensure directory exists: %w", err)
 	}
@@ -351,7 +505,13 @@ func (c *SSHNodeClient) CopyFile(n *Node, src string, dst string) error {
 }
 
 // DownloadFile downloads a file from the remote node to the local system via SFTP
-func (c *SSHNodeClient) DownloadFile(n *Node, src, dst string) error {
+func (c *SSHNodeClient) DownloadFile(n *Node, src, dst string) (err error) {
+	_, span := telemetry.StartSpan(context.Background(), "ssh.download",
+		attribute.String("ssh.node", n.Name),
+		attribute.String("ssh.file", src),
+	)
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	jumpBoxIP := ""
 	nodeIP := n.ExternalIP
 	if n.Jumpbox != nil {
@@ -364,9 +524,9 @@ func (c *SSHNodeClient) DownloadFile(n *Node, src, dst string) error {
 
 // Helper functions
 
-// hasSysctlLine checks if a specific line exists in /etc/sysctl.conf on the remote node via SSH
+// hasSysctlLine checks if a specific line exists in the sysctl config file on the remote node via SSH
 func (n *Node) hasSysctlLine(line string) bool {
-	checkCommand := fmt.Sprintf("sudo grep -E '^%s' /etc/sysctl.conf >/dev/null 2>&1", line)
+	checkCommand := fmt.Sprintf("sudo grep -E '^%s' %s >/dev/null 2>&1", line, n.OS().SysctlConfigPath())
 	err := n.RunSSHCommand("root", checkCommand)
 	if err != nil {
 		// If the command returns a NON-zero exit status, it means the setting is not configured
@@ -381,20 +541,20 @@ func (n *Node) isSysctlActive(key, expected string) bool {
 	return err == nil
 }
 
-// configureSysctlLine appends a specific line to /etc/sysctl.conf and applies the settings on the remote node via SSH
+// configureSysctlLine appends a specific line to the sysctl config file and applies the settings on the remote node via SSH
 func (n *Node) configureSysctlLines(lines []string) error {
 	for _, line := range lines {
 		if !n.hasSysctlLine(line) {
-			cmd := fmt.Sprintf("echo '%s' | sudo tee -a /etc/sysctl.conf", line)
+			cmd := fmt.Sprintf("echo '%s' | sudo tee -a %s", line, n.OS().SysctlConfigPath())
 			if err := n.RunSSHCommand("root", cmd); err != nil {
-				return fmt.Errorf("failed to append to sysctl.conf: %w", err)
+				return fmt.Errorf("failed to append to sysctl config: %w", err)
 			}
 		}
 	}
 
-	err := n.RunSSHCommand("root", "sudo sysctl -p")
+	err := n.RunSSHCommand("root", n.OS().ApplySysctlCommand())
 	if err != nil {
-		return fmt.Errorf("failed to run sysctl -p: %w", err)
+		return fmt.Errorf("failed to apply sysctl settings: %w", err)
 	}
 
 	return nil
@@ -449,6 +609,33 @@ func (n *Node) createClient(jumpboxIp string, ip string, username string) (*ssh.
 		return nil, fmt.Errorf("failed to get authentication methods: %w", err)
 	}
 
+	// IAP tunnels reach every instance directly by name, so neither a public
+	// IP nor a hop through the jumpbox is needed.
+	if n.IAPTunnel != nil {
+		config := &ssh.ClientConfig{
+			User:    username,
+			Auth:    authMethods,
+			Timeout: 10 * time.Second,
+			// Host keys are trusted on first use, recorded in the managed
+			// known_hosts file, and verified on every later connection.
+			HostKeyCallback: hostKeyCallback(),
+		}
+
+		conn, err := n.IAPTunnel.Dial(n.Name, n.GetSSHPort())
+		if err != nil {
+			return nil, fmt.Errorf("failed to open IAP tunnel to %s: %w", n.Name, err)
+		}
+
+		addr := fmt.Sprintf("%s:%d", n.Name, n.GetSSHPort())
+		clientConn, channels, requests, err := ssh.NewClientConn(conn, addr, config)
+		if err != nil {
+			util.IgnoreError(conn.Close)
+			return nil, fmt.Errorf("failed to perform SSH handshake through IAP tunnel: %v", err)
+		}
+
+		return ssh.NewClient(clientConn, channels, requests), nil
+	}
+
 	if jumpboxIp != "" {
 		// Use the Jumpbox's cached client if available
 		jbClient, err := n.Jumpbox.getOrCreateClient("", jumpboxIp, jumpboxUser)
@@ -460,14 +647,12 @@ func (n *Node) createClient(jumpboxIp string, ip string, username string) (*ssh.
 			User:    username,
 			Auth:    authMethods,
 			Timeout: 10 * time.Second,
-			// WARNING: This is INSECURE for production!
-			// It tells the client to accept any host key.
-			// For production, you should implement a proper HostKeyCallback
-			// to verify the remote server's identity.
-			HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+			// Host keys are trusted on first use, recorded in the managed
+			// known_hosts file, and verified on every later connection.
+			HostKeyCallback: hostKeyCallback(),
 		}
 
-		finalAddr := fmt.Sprintf("%s:22", ip)
+		finalAddr := fmt.Sprintf("%s:%d", ip, n.GetSSHPort())
 		jbConn, err := jbClient.Dial("tcp", finalAddr)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create connection through jumpbox: %v", err)
@@ -484,14 +669,12 @@ func (n *Node) createClient(jumpboxIp string, ip string, username string) (*ssh.
 		User:    username,
 		Auth:    authMethods,
 		Timeout: 10 * time.Second,
-		// WARNING: This is INSECURE for production!
-		// It tells the client to accept any host key.
-		// For production, you should implement a proper HostKeyCallback
-		// to verify the remote server's identity.
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		// Host keys are trusted on first use, recorded in the managed
+		// known_hosts file, and verified on every later connection.
+		HostKeyCallback: hostKeyCallback(),
 	}
 
-	addr := fmt.Sprintf("%s:22", ip)
+	addr := fmt.Sprintf("%s:%d", ip, n.GetSSHPort())
 	client, err := ssh.Dial("tcp", addr, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to dial: %v", err)
@@ -583,13 +766,17 @@ func (n *Node) downloadFile(jumpboxIp, ip, username, src, dst string) error {
 func (n *Node) getAuthMethods() ([]ssh.AuthMethod, error) {
 	var signers []ssh.Signer
 
-	// 1. Get Agent Signers
+	// 1. Get Agent Signers. The agent is preferred over key files and is the
+	// only way to use hardware-backed (FIDO2/PIV) keys, which never leave the
+	// token and cannot be loaded from disk.
 	if authSocket := os.Getenv("SSH_AUTH_SOCK"); authSocket != "" {
-		if conn, err := net.Dial("unix", authSocket); err == nil {
-			agentClient := agent.NewClient(conn)
-			if s, err := agentClient.Signers(); err == nil {
-				signers = append(signers, s...)
-			}
+		conn, err := dialAgent(authSocket)
+		if err != nil {
+			log.Printf("Warning: failed to connect to ssh-agent: %v\n", err)
+		} else if s, err := agent.NewClient(conn).Signers(); err != nil {
+			log.Printf("Warning: failed to list ssh-agent keys: %v\n", err)
+		} else {
+			signers = append(signers, s...)
 		}
 	}
 
@@ -643,18 +830,32 @@ func (n *Node) loadPrivateKey() (ssh.Signer, error) {
 		return nil, fmt.Errorf("failed to read private key file %s: %v", n.keyPath, err)
 	}
 
+	// Mirror the openssh permission check, but only warn: on Windows the unix
+	// mode bits are synthetic and checking them would produce false positives.
+	if runtime.GOOS != "windows" {
+		if info, statErr := os.Stat(n.keyPath); statErr == nil && info.Mode().Perm()&0077 != 0 {
+			log.Printf("Warning: private key %s is accessible by other users (mode %04o)\n", n.keyPath, info.Mode().Perm())
+		}
+	}
+
 	signer, err := ssh.ParsePrivateKey(key)
 	if err == nil {
 		return signer, nil
 	}
 
+	// Hardware-backed keys cannot be parsed from disk; point the operator at
+	// the agent instead of surfacing a generic parse error.
+	if pub, pubErr := n.FileIO.ReadFile(n.keyPath + ".pub"); pubErr == nil && strings.HasPrefix(string(pub), "sk-") {
+		return nil, fmt.Errorf("key %s is hardware-backed and can only be used through a running ssh-agent", n.keyPath)
+	}
+
 	if _, ok := err.(*ssh.PassphraseMissingError); !ok {
 		return nil, fmt.Errorf("failed to parse private key: %v", err)
 	}
 
 	// Key is encrypted, prompt for passphrase
 	log.Printf("Enter passphrase for key '%s': ", n.keyPath)
-	passphrase, err := term.ReadPassword(int(syscall.Stdin))
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
 	log.Println()
 	if err != nil {
 		return nil, fmt.Errorf("failed to read passphrase: %v", err)
@@ -679,7 +880,7 @@ func (n *Node) setupAgentForwarding(client *ssh.Client) error {
 		return nil
 	}
 
-	conn, err := net.Dial("unix", authSocket)
+	conn, err := dialAgent(authSocket)
 	if err != nil {
 		return fmt.Errorf("failed to connect to SSH agent: %v", err)
 	}
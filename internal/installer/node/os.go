@@ -0,0 +1,130 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package node
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OperatingSystem abstracts the distro-specific commands bootstrap runs on a
+// node: package installation, CA trust handling, service restarts, and sysctl
+// persistence. All supported distros use systemd.
+type OperatingSystem interface {
+	// Name returns the distro family name.
+	Name() string
+	// RefreshPackagesCommand returns the shell command that refreshes the package index.
+	RefreshPackagesCommand() string
+	// InstallPackagesCommand returns the shell command that installs the given
+	// packages. Package names are given in their Debian form and mapped to
+	// distro-specific names where they differ.
+	InstallPackagesCommand(packages ...string) string
+	// CACertPath returns the path a CA certificate has to be copied to so
+	// UpdateCATrustCommand picks it up.
+	CACertPath(name string) string
+	// UpdateCATrustCommand returns the shell command that rebuilds the system CA trust store.
+	UpdateCATrustCommand() string
+	// RestartServiceCommand returns the shell command that restarts a systemd service.
+	RestartServiceCommand(service string) string
+	// SysctlConfigPath returns the file bootstrap persists sysctl settings in.
+	SysctlConfigPath() string
+	// ApplySysctlCommand returns the shell command that applies the persisted sysctl settings.
+	ApplySysctlCommand() string
+}
+
+// OSFromName returns the operating system abstraction for a distro family
+// name: ubuntu (Debian family), rhel (RHEL/Rocky/Alma), or suse (SLES/openSUSE).
+func OSFromName(name string) (OperatingSystem, error) {
+	switch name {
+	case "", "ubuntu":
+		return UbuntuOS{}, nil
+	case "rhel":
+		return RHELOS{}, nil
+	case "suse":
+		return SUSEOS{}, nil
+	}
+	return nil, fmt.Errorf("unsupported node operating system %q (supported: ubuntu, rhel, suse)", name)
+}
+
+// UbuntuOS covers Ubuntu and other Debian-family distros.
+type UbuntuOS struct{}
+
+func (UbuntuOS) Name() string { return "ubuntu" }
+
+func (UbuntuOS) RefreshPackagesCommand() string { return "apt-get update" }
+
+func (UbuntuOS) InstallPackagesCommand(packages ...string) string {
+	return "apt-get install -y " + strings.Join(packages, " ")
+}
+
+func (UbuntuOS) CACertPath(name string) string {
+	return "/usr/local/share/ca-certificates/" + name + ".crt"
+}
+
+func (UbuntuOS) UpdateCATrustCommand() string { return "update-ca-certificates" }
+
+func (UbuntuOS) RestartServiceCommand(service string) string { return "systemctl restart " + service }
+
+func (UbuntuOS) SysctlConfigPath() string { return "/etc/sysctl.conf" }
+
+func (UbuntuOS) ApplySysctlCommand() string { return "sudo sysctl -p" }
+
+// rhelPackageNames maps the Debian package names bootstrap uses to their RHEL
+// equivalents where they differ.
+var rhelPackageNames = map[string]string{
+	"apache2-utils": "httpd-tools",
+}
+
+// RHELOS covers RHEL and its derivatives (Rocky, Alma, CentOS Stream).
+type RHELOS struct{}
+
+func (RHELOS) Name() string { return "rhel" }
+
+func (RHELOS) RefreshPackagesCommand() string { return "dnf makecache" }
+
+func (RHELOS) InstallPackagesCommand(packages ...string) string {
+	mapped := make([]string, 0, len(packages))
+	for _, pkg := range packages {
+		if name, ok := rhelPackageNames[pkg]; ok {
+			pkg = name
+		}
+		mapped = append(mapped, pkg)
+	}
+	return "dnf install -y " + strings.Join(mapped, " ")
+}
+
+func (RHELOS) CACertPath(name string) string {
+	return "/etc/pki/ca-trust/source/anchors/" + name + ".crt"
+}
+
+func (RHELOS) UpdateCATrustCommand() string { return "update-ca-trust extract" }
+
+func (RHELOS) RestartServiceCommand(service string) string { return "systemctl restart " + service }
+
+func (RHELOS) SysctlConfigPath() string { return "/etc/sysctl.d/90-oms.conf" }
+
+func (RHELOS) ApplySysctlCommand() string { return "sudo sysctl --system" }
+
+// SUSEOS covers SLES and openSUSE.
+type SUSEOS struct{}
+
+func (SUSEOS) Name() string { return "suse" }
+
+func (SUSEOS) RefreshPackagesCommand() string { return "zypper --non-interactive refresh" }
+
+func (SUSEOS) InstallPackagesCommand(packages ...string) string {
+	return "zypper --non-interactive install " + strings.Join(packages, " ")
+}
+
+func (SUSEOS) CACertPath(name string) string {
+	return "/etc/pki/trust/anchors/" + name + ".crt"
+}
+
+func (SUSEOS) UpdateCATrustCommand() string { return "update-ca-certificates" }
+
+func (SUSEOS) RestartServiceCommand(service string) string { return "systemctl restart " + service }
+
+func (SUSEOS) SysctlConfigPath() string { return "/etc/sysctl.d/90-oms.conf" }
+
+func (SUSEOS) ApplySysctlCommand() string { return "sudo sysctl --system" }
@@ -0,0 +1,159 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package node
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log"
+	"net"
+
+	"github.com/codesphere-cloud/oms/internal/util"
+	"golang.org/x/crypto/ssh"
+)
+
+// OpenSOCKSProxy listens on localAddr and serves a SOCKS5 proxy whose
+// connections are dialed through the node's SSH connection, like ssh -D.
+// Only the CONNECT command without authentication is supported, which is what
+// browsers and common tooling use. The proxy stays open until the returned
+// listener is closed.
+func (n *Node) OpenSOCKSProxy(username string, localAddr string) (net.Listener, error) {
+	var jumpboxIp string
+	var ip string
+	if n.Jumpbox != nil {
+		jumpboxIp = n.Jumpbox.ExternalIP
+		ip = n.InternalIP
+	} else {
+		jumpboxIp = ""
+		ip = n.ExternalIP
+	}
+	client, err := n.getOrCreateClient(jumpboxIp, ip, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", localAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", localAddr, err)
+	}
+
+	go func() {
+		for {
+			local, err := listener.Accept()
+			if err != nil {
+				// The listener was closed, shutting down the proxy.
+				return
+			}
+			go serveSOCKSConn(client, local)
+		}
+	}()
+
+	return listener, nil
+}
+
+// serveSOCKSConn handles the SOCKS5 handshake on a single connection and
+// pipes it to the requested destination through the SSH client.
+func serveSOCKSConn(client *ssh.Client, local net.Conn) {
+	defer util.IgnoreError(local.Close)
+
+	addr, err := handleSOCKSHandshake(local)
+	if err != nil {
+		log.Printf("Warning: SOCKS handshake failed: %v", err)
+		return
+	}
+
+	remote, err := client.Dial("tcp", addr)
+	if err != nil {
+		// Reply "host unreachable" so the client fails fast.
+		_, _ = local.Write([]byte{0x05, 0x04, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+		log.Printf("Warning: failed to connect to %s through the proxy: %v", addr, err)
+		return
+	}
+	defer util.IgnoreError(remote.Close)
+
+	// Reply success with a zero bind address; clients ignore it for CONNECT.
+	if _, err := local.Write([]byte{0x05, 0x00, 0x00, 0x01, 0, 0, 0, 0, 0, 0}); err != nil {
+		return
+	}
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(remote, local)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(local, remote)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+// handleSOCKSHandshake performs the SOCKS5 greeting and parses the CONNECT
+// request, returning the destination address as host:port.
+func handleSOCKSHandshake(conn net.Conn) (string, error) {
+	// Greeting: version, method count, methods.
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return "", fmt.Errorf("failed to read greeting: %w", err)
+	}
+	if header[0] != 0x05 {
+		return "", fmt.Errorf("unsupported SOCKS version %d", header[0])
+	}
+	methods := make([]byte, header[1])
+	if _, err := io.ReadFull(conn, methods); err != nil {
+		return "", fmt.Errorf("failed to read auth methods: %w", err)
+	}
+	// Select "no authentication required".
+	if _, err := conn.Write([]byte{0x05, 0x00}); err != nil {
+		return "", fmt.Errorf("failed to write method selection: %w", err)
+	}
+
+	// Request: version, command, reserved, address type.
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(conn, request); err != nil {
+		return "", fmt.Errorf("failed to read request: %w", err)
+	}
+	if request[1] != 0x01 {
+		_, _ = conn.Write([]byte{0x05, 0x07, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+		return "", fmt.Errorf("unsupported SOCKS command %d (only CONNECT is supported)", request[1])
+	}
+
+	var host string
+	switch request[3] {
+	case 0x01: // IPv4
+		addr := make([]byte, net.IPv4len)
+		if _, err := io.ReadFull(conn, addr); err != nil {
+			return "", fmt.Errorf("failed to read IPv4 address: %w", err)
+		}
+		host = net.IP(addr).String()
+	case 0x03: // Domain name
+		length := make([]byte, 1)
+		if _, err := io.ReadFull(conn, length); err != nil {
+			return "", fmt.Errorf("failed to read domain length: %w", err)
+		}
+		domain := make([]byte, length[0])
+		if _, err := io.ReadFull(conn, domain); err != nil {
+			return "", fmt.Errorf("failed to read domain: %w", err)
+		}
+		host = string(domain)
+	case 0x04: // IPv6
+		addr := make([]byte, net.IPv6len)
+		if _, err := io.ReadFull(conn, addr); err != nil {
+			return "", fmt.Errorf("failed to read IPv6 address: %w", err)
+		}
+		host = net.IP(addr).String()
+	default:
+		_, _ = conn.Write([]byte{0x05, 0x08, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+		return "", fmt.Errorf("unsupported SOCKS address type %d", request[3])
+	}
+
+	portBytes := make([]byte, 2)
+	if _, err := io.ReadFull(conn, portBytes); err != nil {
+		return "", fmt.Errorf("failed to read port: %w", err)
+	}
+	port := binary.BigEndian.Uint16(portBytes)
+
+	return net.JoinHostPort(host, fmt.Sprintf("%d", port)), nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package node
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net"
+
+	"github.com/codesphere-cloud/oms/internal/util"
+	"golang.org/x/crypto/ssh"
+)
+
+// OpenTunnel listens on localAddr and forwards every accepted connection to
+// remoteAddr through the node's SSH connection, like ssh -L. The tunnel stays
+// open until the returned listener is closed.
+func (n *Node) OpenTunnel(username string, localAddr string, remoteAddr string) (net.Listener, error) {
+	var jumpboxIp string
+	var ip string
+	if n.Jumpbox != nil {
+		jumpboxIp = n.Jumpbox.ExternalIP
+		ip = n.InternalIP
+	} else {
+		jumpboxIp = ""
+		ip = n.ExternalIP
+	}
+	client, err := n.getOrCreateClient(jumpboxIp, ip, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", localAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", localAddr, err)
+	}
+
+	go func() {
+		for {
+			local, err := listener.Accept()
+			if err != nil {
+				// The listener was closed, shutting down the tunnel.
+				return
+			}
+			go forwardTunnelConn(client, local, remoteAddr)
+		}
+	}()
+
+	return listener, nil
+}
+
+// forwardTunnelConn pipes a single local connection to remoteAddr through the
+// SSH client until either side closes.
+func forwardTunnelConn(client *ssh.Client, local net.Conn, remoteAddr string) {
+	defer util.IgnoreError(local.Close)
+
+	remote, err := client.Dial("tcp", remoteAddr)
+	if err != nil {
+		log.Printf("Warning: failed to connect to %s through the tunnel: %v", remoteAddr, err)
+		return
+	}
+	defer util.IgnoreError(remote.Close)
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(remote, local)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(local, remote)
+		done <- struct{}{}
+	}()
+	<-done
+}
@@ -0,0 +1,133 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package installer
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"net"
+	"text/template"
+
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/codesphere-cloud/oms/internal/util"
+)
+
+//go:embed manifests/node-local-dns/node-local-dns.yaml
+var nodeLocalDNSTemplate []byte
+
+const (
+	// NodeLocalDNSIP is the link-local address node-local-dns binds on every
+	// node. Workspaces and node resolvers can use it as their DNS server
+	// instead of a hardcoded public resolver.
+	NodeLocalDNSIP = "169.254.20.10"
+
+	nodeLocalDNSImage = "registry.k8s.io/dns/k8s-dns-node-cache:1.23.1"
+
+	// clusterDNSHostOffset is the offset of the cluster DNS service address
+	// within the service CIDR, following the Kubernetes convention of
+	// reserving the tenth address for kube-dns/CoreDNS.
+	clusterDNSHostOffset = 10
+)
+
+type nodeLocalDNSTemplateData struct {
+	LocalDNSIP   string
+	ClusterDNSIP string
+	Image        string
+}
+
+// ClusterDNSIP derives the cluster DNS service address from the service CIDR
+// by convention (tenth address of the range).
+func ClusterDNSIP(serviceCIDR string) (string, error) {
+	_, network, err := net.ParseCIDR(serviceCIDR)
+	if err != nil {
+		return "", fmt.Errorf("invalid service CIDR %q: %w", serviceCIDR, err)
+	}
+
+	ip := network.IP.To4()
+	if ip == nil {
+		return "", fmt.Errorf("service CIDR %q is not an IPv4 range", serviceCIDR)
+	}
+
+	dnsIP := make(net.IP, len(ip))
+	copy(dnsIP, ip)
+	dnsIP[3] += clusterDNSHostOffset
+
+	if !network.Contains(dnsIP) {
+		return "", fmt.Errorf("service CIDR %q is too small to contain the cluster DNS address", serviceCIDR)
+	}
+
+	return dnsIP.String(), nil
+}
+
+// RenderNodeLocalDNS renders the node-local-dns manifests for the cluster
+// described by the install config. Cluster-internal names are forwarded to the
+// CoreDNS service derived from the service CIDR; everything else goes to the
+// node's resolvers.
+func RenderNodeLocalDNS(cfg files.RootConfig) ([]byte, error) {
+	serviceCIDR := defaultIfEmpty(cfg.Kubernetes.ServiceCIDR, "100.64.0.0/13")
+	clusterDNSIP, err := ClusterDNSIP(serviceCIDR)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New("node-local-dns").Parse(string(nodeLocalDNSTemplate))
+	if err != nil {
+		return nil, fmt.Errorf("parsing node-local-dns template: %w", err)
+	}
+
+	data := nodeLocalDNSTemplateData{
+		LocalDNSIP:   NodeLocalDNSIP,
+		ClusterDNSIP: clusterDNSIP,
+		Image:        nodeLocalDNSImage,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("rendering node-local-dns template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// EnsureNodeLocalDNS deploys the node-local-dns cache to kube-system when
+// kubernetes.nodeLocalDns is enabled in the install config. It is a no-op
+// otherwise.
+func EnsureNodeLocalDNS(ctx context.Context, vaultPath, privKey string, cfg files.RootConfig) error {
+	if !cfg.Kubernetes.NodeLocalDNS {
+		return nil
+	}
+
+	rendered, err := RenderNodeLocalDNS(cfg)
+	if err != nil {
+		return err
+	}
+
+	objects, err := util.DecodeMultiDocYAML(rendered)
+	if err != nil {
+		return fmt.Errorf("decoding node-local-dns manifests: %w", err)
+	}
+
+	_, restConfig, err := VaultAndRESTConfig(vaultPath, privKey, cfg)
+	if err != nil {
+		return err
+	}
+	_, dynClient, err := util.NewClientsFromRESTConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	for _, obj := range objects {
+		gvr, err := util.GvrForUnstructured(obj)
+		if err != nil {
+			return fmt.Errorf("resolving GVR for %s: %w", obj.GetKind(), err)
+		}
+		if err := util.ApplyUnstructured(ctx, dynClient, gvr, obj); err != nil {
+			return fmt.Errorf("applying node-local-dns manifest: %w", err)
+		}
+	}
+
+	return nil
+}
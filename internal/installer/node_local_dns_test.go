@@ -0,0 +1,80 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package installer_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/codesphere-cloud/oms/internal/util"
+)
+
+var _ = Describe("ClusterDNSIP", func() {
+	It("derives the tenth address of the service CIDR", func() {
+		ip, err := installer.ClusterDNSIP("100.64.0.0/13")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ip).To(Equal("100.64.0.10"))
+	})
+
+	It("rejects malformed CIDRs", func() {
+		_, err := installer.ClusterDNSIP("not-a-cidr")
+		Expect(err).To(MatchError(ContainSubstring(`invalid service CIDR "not-a-cidr"`)))
+	})
+
+	It("rejects ranges too small to contain the DNS address", func() {
+		_, err := installer.ClusterDNSIP("10.0.0.0/30")
+		Expect(err).To(MatchError(ContainSubstring("too small")))
+	})
+})
+
+var _ = Describe("RenderNodeLocalDNS", func() {
+	It("renders the cache manifests bound to the node-local address", func() {
+		rendered, err := installer.RenderNodeLocalDNS(files.RootConfig{
+			Kubernetes: files.KubernetesConfig{ServiceCIDR: "10.96.0.0/12"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		objects, err := util.DecodeMultiDocYAML(rendered)
+		Expect(err).ToNot(HaveOccurred())
+
+		kinds := []string{}
+		for _, obj := range objects {
+			Expect(obj.GetName()).To(Equal("node-local-dns"))
+			Expect(obj.GetNamespace()).To(Equal("kube-system"))
+			kinds = append(kinds, obj.GetKind())
+		}
+		Expect(kinds).To(Equal([]string{"ServiceAccount", "ConfigMap", "DaemonSet"}))
+
+		Expect(string(rendered)).To(ContainSubstring("bind " + installer.NodeLocalDNSIP))
+		Expect(string(rendered)).To(ContainSubstring("forward . 10.96.0.10"))
+		Expect(string(rendered)).To(ContainSubstring("forward . /etc/resolv.conf"))
+	})
+
+	It("falls back to the default service CIDR when none is configured", func() {
+		rendered, err := installer.RenderNodeLocalDNS(files.RootConfig{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(rendered)).To(ContainSubstring("forward . 100.64.0.10"))
+	})
+})
+
+var _ = Describe("EnsureNodeLocalDNS", func() {
+	It("is a no-op when node-local DNS is not enabled", func() {
+		err := installer.EnsureNodeLocalDNS(context.Background(), "", "", files.RootConfig{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("fails on an invalid service CIDR before touching the vault", func() {
+		err := installer.EnsureNodeLocalDNS(context.Background(), "", "", files.RootConfig{
+			Kubernetes: files.KubernetesConfig{
+				NodeLocalDNS: true,
+				ServiceCIDR:  "bogus",
+			},
+		})
+		Expect(err).To(MatchError(ContainSubstring(`invalid service CIDR "bogus"`)))
+	})
+})
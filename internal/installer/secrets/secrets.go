@@ -32,8 +32,12 @@ func EnsureSecrets(vault *files.InstallVault, config *files.RootConfig) error {
 	if err := EnsureIngressCA(vault, &config.Cluster); err != nil {
 		return fmt.Errorf("ensure ingress CA: %w", err)
 	}
-	if err := EnsureCephSSHKeys(vault, &config.Ceph); err != nil {
-		return fmt.Errorf("ensure ceph SSH keys: %w", err)
+	cephDeployed := config.Ceph.Mode != "external" &&
+		(config.Storage == nil || config.Storage.Backend == "" || config.Storage.Backend == "ceph")
+	if cephDeployed {
+		if err := EnsureCephSSHKeys(vault, &config.Ceph); err != nil {
+			return fmt.Errorf("ensure ceph SSH keys: %w", err)
+		}
 	}
 	if err := EnsureSshWorkspaceProxyHostKey(vault); err != nil {
 		return fmt.Errorf("ensure ssh workspace proxy host key: %w", err)
@@ -425,3 +429,39 @@ func EnsurePostgresUsers(vault *files.InstallVault) error {
 	}
 	return nil
 }
+
+// RotatePostgresUserPasswords overwrites the password of every per-service postgres
+// user with a freshly generated one. Usernames are kept, so only the credentials change.
+func RotatePostgresUserPasswords(vault *files.InstallVault) error {
+	for _, svc := range codesphere.PostgresServices {
+		svcPwd, err := GeneratePassword(32)
+		if err != nil {
+			return fmt.Errorf("generate postgres password for %s: %w", svc.Name, err)
+		}
+		setPassword(vault, fmt.Sprintf("postgresPassword%s", files.Capitalize(svc.Name)), svcPwd)
+	}
+	return nil
+}
+
+// RotateRegistryPassword overwrites the container registry password with a freshly
+// generated one. The registry username is kept.
+func RotateRegistryPassword(vault *files.InstallVault) error {
+	pwd, err := GeneratePassword(32)
+	if err != nil {
+		return fmt.Errorf("generate registry password: %w", err)
+	}
+	setPassword(vault, files.SecretRegistryPassword, pwd)
+	return nil
+}
+
+// RotateDomainAuthKeys overwrites the EC P-256 domain-auth key pair with a freshly
+// generated one. Tokens signed with the previous key become invalid.
+func RotateDomainAuthKeys(vault *files.InstallVault) error {
+	domainPriv, domainPub, err := GenerateECDSAKeyPair()
+	if err != nil {
+		return fmt.Errorf("generate domain auth key pair: %w", err)
+	}
+	vault.SetSecret(files.SecretEntry{Name: files.SecretDomainAuthPrivateKey, File: &files.SecretFile{Name: "key.pem", Content: domainPriv}})
+	vault.SetSecret(files.SecretEntry{Name: files.SecretDomainAuthPublicKey, File: &files.SecretFile{Name: "key.pub", Content: domainPub}})
+	return nil
+}
@@ -0,0 +1,149 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package installer
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/codesphere-cloud/oms/internal/util"
+)
+
+// InstallStatusPath is where the install command persists its progress on the
+// machine running the installation. The local CLI polls this file through the
+// node client, so the path must be stable across oms versions.
+const InstallStatusPath = "/var/lib/codesphere/install-status.json"
+
+// Install status states, used for the overall installation and each phase.
+const (
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// PhaseStatus records the progress of one installation phase.
+type PhaseStatus struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+	// Steps are the installer steps planned for this phase after skip filtering.
+	Steps      []string   `json:"steps,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"startedAt"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+// InstallStatus is the structured progress report written to InstallStatusPath.
+type InstallStatus struct {
+	Pid       int           `json:"pid"`
+	State     string        `json:"state"`
+	Error     string        `json:"error,omitempty"`
+	StartedAt time.Time     `json:"startedAt"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+	Phases    []PhaseStatus `json:"phases,omitempty"`
+}
+
+// ParseInstallStatus decodes a status file downloaded from the install host.
+func ParseInstallStatus(data []byte) (InstallStatus, error) {
+	var status InstallStatus
+	if err := json.Unmarshal(data, &status); err != nil {
+		return InstallStatus{}, fmt.Errorf("failed to parse install status: %w", err)
+	}
+	return status, nil
+}
+
+// StatusWriter persists install progress to a status file after every phase
+// transition. Writing is best effort: a failure to persist is logged once and
+// never fails the installation, so installs run by unprivileged users still
+// work without the status directory.
+type StatusWriter struct {
+	fw     util.FileIO
+	time   util.Time
+	path   string
+	status InstallStatus
+	warned bool
+}
+
+// NewStatusWriter creates a status writer for the current install process and
+// persists the initial running state.
+func NewStatusWriter(fw util.FileIO, time util.Time, path string) *StatusWriter {
+	w := &StatusWriter{
+		fw:   fw,
+		time: time,
+		path: path,
+	}
+	w.status = InstallStatus{
+		Pid:       os.Getpid(),
+		State:     StatusRunning,
+		StartedAt: w.time.Now(),
+	}
+	w.persist()
+	return w
+}
+
+// BeginPhase records that a phase with the given planned steps has started.
+func (w *StatusWriter) BeginPhase(name string, steps []string) {
+	w.status.Phases = append(w.status.Phases, PhaseStatus{
+		Name:      name,
+		State:     StatusRunning,
+		Steps:     steps,
+		StartedAt: w.time.Now(),
+	})
+	w.persist()
+}
+
+// EndPhase marks the most recently started phase as succeeded or failed.
+func (w *StatusWriter) EndPhase(err error) {
+	if len(w.status.Phases) == 0 {
+		return
+	}
+	phase := &w.status.Phases[len(w.status.Phases)-1]
+	finished := w.time.Now()
+	phase.FinishedAt = &finished
+	phase.State = StatusSucceeded
+	if err != nil {
+		phase.State = StatusFailed
+		phase.Error = err.Error()
+	}
+	w.persist()
+}
+
+// Finish records the overall outcome of the installation.
+func (w *StatusWriter) Finish(err error) {
+	w.status.State = StatusSucceeded
+	if err != nil {
+		w.status.State = StatusFailed
+		w.status.Error = err.Error()
+	}
+	w.persist()
+}
+
+func (w *StatusWriter) persist() {
+	w.status.UpdatedAt = w.time.Now()
+
+	data, err := json.MarshalIndent(w.status, "", "  ")
+	if err != nil {
+		w.warnOnce(err)
+		return
+	}
+
+	if err := w.fw.MkdirAll(filepath.Dir(w.path), 0755); err != nil {
+		w.warnOnce(err)
+		return
+	}
+	if err := w.fw.WriteFile(w.path, data, 0644); err != nil {
+		w.warnOnce(err)
+	}
+}
+
+func (w *StatusWriter) warnOnce(err error) {
+	if w.warned {
+		return
+	}
+	w.warned = true
+	log.Printf("Warning: failed to write install status to %s: %v", w.path, err)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package installer_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/util"
+)
+
+var _ = Describe("StatusWriter", func() {
+	var (
+		fw   *MockFileIO
+		time *util.FakeTime
+	)
+
+	const path = "/var/lib/codesphere/install-status.json"
+
+	BeforeEach(func() {
+		fw = NewMockFileIO()
+		time = util.NewFakeTime()
+	})
+
+	readStatus := func() installer.InstallStatus {
+		status, err := installer.ParseInstallStatus(fw.GetFileContent(path))
+		Expect(err).NotTo(HaveOccurred())
+		return status
+	}
+
+	It("persists the running state on creation", func() {
+		installer.NewStatusWriter(fw, time, path)
+
+		status := readStatus()
+		Expect(status.State).To(Equal(installer.StatusRunning))
+		Expect(status.StartedAt).To(Equal(time.CurrentTime))
+		Expect(status.Phases).To(BeEmpty())
+	})
+
+	It("tracks phases through begin and end", func() {
+		w := installer.NewStatusWriter(fw, time, path)
+		w.BeginPhase("infrastructure", []string{"postgres", "ceph"})
+		w.EndPhase(nil)
+		w.BeginPhase("platform", []string{"codesphere"})
+
+		status := readStatus()
+		Expect(status.Phases).To(HaveLen(2))
+		Expect(status.Phases[0].Name).To(Equal("infrastructure"))
+		Expect(status.Phases[0].State).To(Equal(installer.StatusSucceeded))
+		Expect(status.Phases[0].Steps).To(Equal([]string{"postgres", "ceph"}))
+		Expect(status.Phases[0].FinishedAt).NotTo(BeNil())
+		Expect(status.Phases[1].State).To(Equal(installer.StatusRunning))
+	})
+
+	It("records phase and overall failure", func() {
+		w := installer.NewStatusWriter(fw, time, path)
+		w.BeginPhase("infrastructure", []string{"postgres"})
+		w.EndPhase(fmt.Errorf("postgres broke"))
+		w.Finish(fmt.Errorf("postgres broke"))
+
+		status := readStatus()
+		Expect(status.State).To(Equal(installer.StatusFailed))
+		Expect(status.Error).To(Equal("postgres broke"))
+		Expect(status.Phases[0].State).To(Equal(installer.StatusFailed))
+		Expect(status.Phases[0].Error).To(Equal("postgres broke"))
+	})
+
+	It("records overall success", func() {
+		w := installer.NewStatusWriter(fw, time, path)
+		w.Finish(nil)
+
+		Expect(readStatus().State).To(Equal(installer.StatusSucceeded))
+	})
+
+	It("never fails the install when the status file cannot be written", func() {
+		fw.writeError = fmt.Errorf("read-only filesystem")
+
+		w := installer.NewStatusWriter(fw, time, path)
+		w.BeginPhase("infrastructure", nil)
+		w.EndPhase(nil)
+		w.Finish(nil)
+	})
+})
@@ -0,0 +1,252 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package vault
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	"net/http"
+)
+
+// NewMockSecretBackend creates a new instance of MockSecretBackend. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSecretBackend(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSecretBackend {
+	mock := &MockSecretBackend{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSecretBackend is an autogenerated mock type for the SecretBackend type
+type MockSecretBackend struct {
+	mock.Mock
+}
+
+type MockSecretBackend_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSecretBackend) EXPECT() *MockSecretBackend_Expecter {
+	return &MockSecretBackend_Expecter{mock: &_m.Mock}
+}
+
+// Fetch provides a mock function for the type MockSecretBackend
+func (_mock *MockSecretBackend) Fetch(uri string) (string, error) {
+	ret := _mock.Called(uri)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Fetch")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return returnFunc(uri)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
+		r0 = returnFunc(uri)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(uri)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretBackend_Fetch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Fetch'
+type MockSecretBackend_Fetch_Call struct {
+	*mock.Call
+}
+
+// Fetch is a helper method to define mock.On call
+//   - uri string
+func (_e *MockSecretBackend_Expecter) Fetch(uri any) *MockSecretBackend_Fetch_Call {
+	return &MockSecretBackend_Fetch_Call{Call: _e.mock.On("Fetch", uri)}
+}
+
+func (_c *MockSecretBackend_Fetch_Call) Run(run func(uri string)) *MockSecretBackend_Fetch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretBackend_Fetch_Call) Return(s string, err error) *MockSecretBackend_Fetch_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockSecretBackend_Fetch_Call) RunAndReturn(run func(uri string) (string, error)) *MockSecretBackend_Fetch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Store provides a mock function for the type MockSecretBackend
+func (_mock *MockSecretBackend) Store(name string, value string) (string, error) {
+	ret := _mock.Called(name, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Store")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) (string, error)); ok {
+		return returnFunc(name, value)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = returnFunc(name, value)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = returnFunc(name, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretBackend_Store_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Store'
+type MockSecretBackend_Store_Call struct {
+	*mock.Call
+}
+
+// Store is a helper method to define mock.On call
+//   - name string
+//   - value string
+func (_e *MockSecretBackend_Expecter) Store(name any, value any) *MockSecretBackend_Store_Call {
+	return &MockSecretBackend_Store_Call{Call: _e.mock.On("Store", name, value)}
+}
+
+func (_c *MockSecretBackend_Store_Call) Run(run func(name string, value string)) *MockSecretBackend_Store_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretBackend_Store_Call) Return(s string, err error) *MockSecretBackend_Store_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockSecretBackend_Store_Call) RunAndReturn(run func(name string, value string) (string, error)) *MockSecretBackend_Store_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockHttpClient creates a new instance of MockHttpClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHttpClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHttpClient {
+	mock := &MockHttpClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockHttpClient is an autogenerated mock type for the HttpClient type
+type MockHttpClient struct {
+	mock.Mock
+}
+
+type MockHttpClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHttpClient) EXPECT() *MockHttpClient_Expecter {
+	return &MockHttpClient_Expecter{mock: &_m.Mock}
+}
+
+// Do provides a mock function for the type MockHttpClient
+func (_mock *MockHttpClient) Do(request *http.Request) (*http.Response, error) {
+	ret := _mock.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Do")
+	}
+
+	var r0 *http.Response
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*http.Request) (*http.Response, error)); ok {
+		return returnFunc(request)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*http.Request) *http.Response); ok {
+		r0 = returnFunc(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*http.Response)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = returnFunc(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockHttpClient_Do_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Do'
+type MockHttpClient_Do_Call struct {
+	*mock.Call
+}
+
+// Do is a helper method to define mock.On call
+//   - request *http.Request
+func (_e *MockHttpClient_Expecter) Do(request any) *MockHttpClient_Do_Call {
+	return &MockHttpClient_Do_Call{Call: _e.mock.On("Do", request)}
+}
+
+func (_c *MockHttpClient_Do_Call) Run(run func(request *http.Request)) *MockHttpClient_Do_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *http.Request
+		if args[0] != nil {
+			arg0 = args[0].(*http.Request)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHttpClient_Do_Call) Return(response *http.Response, err error) *MockHttpClient_Do_Call {
+	_c.Call.Return(response, err)
+	return _c
+}
+
+func (_c *MockHttpClient_Do_Call) RunAndReturn(run func(request *http.Request) (*http.Response, error)) *MockHttpClient_Do_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,312 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Supported secret reference URI schemes.
+const (
+	// SecretRefSchemeGCP references a GCP Secret Manager secret version:
+	// gcpsm://projects/<project>/secrets/<secret>/versions/<version>
+	SecretRefSchemeGCP = "gcpsm://"
+	// SecretRefSchemeVault references a HashiCorp Vault KV v2 field:
+	// hcvault://<mount>/<path>#<field>
+	SecretRefSchemeVault = "hcvault://"
+)
+
+// SecretBackend stores generated secrets outside the vault file and fetches
+// them back at install time.
+//
+//mockery:generate: true
+type SecretBackend interface {
+	// Store saves the value under name and returns the reference URI to put
+	// into the vault file instead of the value.
+	Store(name, value string) (string, error)
+	// Fetch returns the value the reference URI points at.
+	Fetch(uri string) (string, error)
+}
+
+//mockery:generate: true
+type HttpClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// IsSecretRef reports whether value is a secret reference URI.
+func IsSecretRef(value string) bool {
+	return strings.HasPrefix(value, SecretRefSchemeGCP) || strings.HasPrefix(value, SecretRefSchemeVault)
+}
+
+// ExternalizeSecrets moves every literal secret value in the vault into the
+// backend and replaces it with its reference URI, so the vault file written to
+// disk contains no secret material. Usernames stay in the vault; they are not
+// secret and keeping them readable aids debugging. Values that are already
+// references are left alone, so externalizing is idempotent.
+func ExternalizeSecrets(v *files.InstallVault, backend SecretBackend) error {
+	for i := range v.Secrets {
+		entry := &v.Secrets[i]
+		if entry.Fields != nil && entry.Fields.Password != "" && !IsSecretRef(entry.Fields.Password) {
+			uri, err := backend.Store(entry.Name+"-password", entry.Fields.Password)
+			if err != nil {
+				return fmt.Errorf("failed to store secret %s: %w", entry.Name, err)
+			}
+			entry.Fields.Password = uri
+		}
+		if entry.File != nil && entry.File.Content != "" && !IsSecretRef(entry.File.Content) {
+			uri, err := backend.Store(entry.Name+"-file", entry.File.Content)
+			if err != nil {
+				return fmt.Errorf("failed to store secret %s: %w", entry.Name, err)
+			}
+			entry.File.Content = uri
+		}
+	}
+	return nil
+}
+
+// ResolveSecretRefs replaces every secret reference URI in the vault with the
+// value returned by fetch. Entries without references are left alone, so
+// vaults with literal values pass through unchanged.
+func ResolveSecretRefs(v *files.InstallVault, fetch func(uri string) (string, error)) error {
+	for i := range v.Secrets {
+		entry := &v.Secrets[i]
+		if entry.Fields != nil && IsSecretRef(entry.Fields.Password) {
+			value, err := fetch(entry.Fields.Password)
+			if err != nil {
+				return fmt.Errorf("failed to resolve secret %s: %w", entry.Name, err)
+			}
+			entry.Fields.Password = value
+		}
+		if entry.File != nil && IsSecretRef(entry.File.Content) {
+			value, err := fetch(entry.File.Content)
+			if err != nil {
+				return fmt.Errorf("failed to resolve secret %s: %w", entry.Name, err)
+			}
+			entry.File.Content = value
+		}
+	}
+	return nil
+}
+
+// FetchSecretRef fetches the value behind a secret reference URI using the
+// backend matching its scheme.
+func FetchSecretRef(uri string) (string, error) {
+	backend, err := BackendForRef(uri)
+	if err != nil {
+		return "", err
+	}
+	return backend.Fetch(uri)
+}
+
+// BackendForRef returns the backend able to fetch the given reference URI.
+// GCP Secret Manager references are self-contained; HashiCorp Vault
+// references read the server address from VAULT_ADDR.
+func BackendForRef(uri string) (SecretBackend, error) {
+	switch {
+	case strings.HasPrefix(uri, SecretRefSchemeGCP):
+		return NewGCPSecretManagerBackend(""), nil
+	case strings.HasPrefix(uri, SecretRefSchemeVault):
+		return NewHashiVaultBackendFromEnv()
+	default:
+		return nil, fmt.Errorf("unsupported secret reference %q", uri)
+	}
+}
+
+// GCPSecretManagerBackend stores secrets in GCP Secret Manager. Credentials
+// are resolved via Application Default Credentials.
+type GCPSecretManagerBackend struct {
+	// ProjectID hosts the stored secrets. Only Store needs it; Fetch reads
+	// the project from the reference URI.
+	ProjectID string
+}
+
+func NewGCPSecretManagerBackend(projectID string) *GCPSecretManagerBackend {
+	return &GCPSecretManagerBackend{ProjectID: projectID}
+}
+
+func (g *GCPSecretManagerBackend) Store(name, value string) (string, error) {
+	ctx := context.Background()
+	client, err := secretmanager.NewClient(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to create secret manager client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	secretID := secretIDFromName(name)
+	parent := "projects/" + g.ProjectID
+	_, err = client.CreateSecret(ctx, &secretmanagerpb.CreateSecretRequest{
+		Parent:   parent,
+		SecretId: secretID,
+		Secret: &secretmanagerpb.Secret{
+			Replication: &secretmanagerpb.Replication{
+				Replication: &secretmanagerpb.Replication_Automatic_{Automatic: &secretmanagerpb.Replication_Automatic{}},
+			},
+		},
+	})
+	if err != nil && status.Code(err) != codes.AlreadyExists {
+		return "", fmt.Errorf("failed to create secret %s: %w", secretID, err)
+	}
+
+	secretName := parent + "/secrets/" + secretID
+	_, err = client.AddSecretVersion(ctx, &secretmanagerpb.AddSecretVersionRequest{
+		Parent:  secretName,
+		Payload: &secretmanagerpb.SecretPayload{Data: []byte(value)},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to add version to secret %s: %w", secretID, err)
+	}
+
+	return SecretRefSchemeGCP + secretName + "/versions/latest", nil
+}
+
+func (g *GCPSecretManagerBackend) Fetch(uri string) (string, error) {
+	resource := strings.TrimPrefix(uri, SecretRefSchemeGCP)
+
+	ctx := context.Background()
+	client, err := secretmanager.NewClient(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to create secret manager client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	resp, err := client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{Name: resource})
+	if err != nil {
+		return "", fmt.Errorf("failed to access secret version %s: %w", resource, err)
+	}
+	return string(resp.GetPayload().GetData()), nil
+}
+
+// secretIDFromName maps a vault entry name to a valid Secret Manager secret
+// ID by replacing unsupported characters.
+func secretIDFromName(name string) string {
+	id := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '-'
+		}
+	}, name)
+	return "codesphere-" + id
+}
+
+// HashiVaultBackend stores secrets in a HashiCorp Vault KV v2 engine. The
+// server address and token are read from VAULT_ADDR and VAULT_TOKEN so they
+// never end up in files.
+type HashiVaultBackend struct {
+	URL        string
+	Mount      string
+	HttpClient HttpClient
+}
+
+func NewHashiVaultBackendFromEnv() (*HashiVaultBackend, error) {
+	url := os.Getenv("VAULT_ADDR")
+	if url == "" {
+		return nil, fmt.Errorf("VAULT_ADDR is not set")
+	}
+	mount := os.Getenv("VAULT_KV_MOUNT")
+	if mount == "" {
+		mount = "secret"
+	}
+	return &HashiVaultBackend{URL: url, Mount: mount, HttpClient: http.DefaultClient}, nil
+}
+
+type hashiVaultData struct {
+	Data map[string]string `json:"data"`
+}
+
+type hashiVaultReadResponse struct {
+	Data hashiVaultData `json:"data"`
+}
+
+func (h *HashiVaultBackend) Store(name, value string) (string, error) {
+	token := os.Getenv("VAULT_TOKEN")
+	if token == "" {
+		return "", fmt.Errorf("VAULT_TOKEN is not set")
+	}
+
+	body, err := json.Marshal(hashiVaultData{Data: map[string]string{"value": value}})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal secret: %w", err)
+	}
+
+	path := "codesphere/" + name
+	url := fmt.Sprintf("%s/v1/%s/data/%s", strings.TrimSuffix(h.URL, "/"), h.Mount, path)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.HttpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return "", fmt.Errorf("vault returned status %s", resp.Status)
+	}
+
+	return SecretRefSchemeVault + h.Mount + "/" + path + "#value", nil
+}
+
+func (h *HashiVaultBackend) Fetch(uri string) (string, error) {
+	token := os.Getenv("VAULT_TOKEN")
+	if token == "" {
+		return "", fmt.Errorf("VAULT_TOKEN is not set")
+	}
+
+	ref := strings.TrimPrefix(uri, SecretRefSchemeVault)
+	field := "value"
+	if idx := strings.LastIndex(ref, "#"); idx >= 0 {
+		field = ref[idx+1:]
+		ref = ref[:idx]
+	}
+	mount, path, found := strings.Cut(ref, "/")
+	if !found || mount == "" || path == "" || field == "" {
+		return "", fmt.Errorf("malformed vault secret reference %q", uri)
+	}
+
+	url := fmt.Sprintf("%s/v1/%s/data/%s", strings.TrimSuffix(h.URL, "/"), mount, path)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+
+	resp, err := h.HttpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault returned status %s", resp.Status)
+	}
+
+	readResponse := hashiVaultReadResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&readResponse); err != nil {
+		return "", fmt.Errorf("failed to decode vault response: %w", err)
+	}
+
+	value, ok := readResponse.Data.Data[field]
+	if !ok {
+		return "", fmt.Errorf("field %q not found in vault secret %s", field, path)
+	}
+	return value, nil
+}
@@ -0,0 +1,226 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package vault_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/codesphere-cloud/oms/internal/installer/vault"
+)
+
+var _ = Describe("SecretBackend", func() {
+	Describe("IsSecretRef", func() {
+		It("recognizes GCP Secret Manager references", func() {
+			Expect(vault.IsSecretRef("gcpsm://projects/p/secrets/s/versions/latest")).To(BeTrue())
+		})
+
+		It("recognizes HashiCorp Vault references", func() {
+			Expect(vault.IsSecretRef("hcvault://secret/codesphere/foo#value")).To(BeTrue())
+		})
+
+		It("rejects literal values", func() {
+			Expect(vault.IsSecretRef("hunter2")).To(BeFalse())
+			Expect(vault.IsSecretRef("")).To(BeFalse())
+		})
+	})
+
+	Describe("ExternalizeSecrets", func() {
+		var (
+			installVault *files.InstallVault
+			backend      *vault.MockSecretBackend
+		)
+
+		BeforeEach(func() {
+			installVault = &files.InstallVault{Secrets: []files.SecretEntry{
+				{Name: "postgres", Fields: &files.SecretFields{Username: "admin", Password: "hunter2"}},
+				{Name: "ca", File: &files.SecretFile{Name: "ca.key", Content: "-----BEGIN KEY-----"}},
+			}}
+			backend = vault.NewMockSecretBackend(GinkgoT())
+		})
+
+		It("replaces passwords and file contents with reference URIs", func() {
+			backend.EXPECT().Store("postgres-password", "hunter2").Return("gcpsm://ref-pw", nil).Once()
+			backend.EXPECT().Store("ca-file", "-----BEGIN KEY-----").Return("gcpsm://ref-file", nil).Once()
+
+			Expect(vault.ExternalizeSecrets(installVault, backend)).To(Succeed())
+
+			Expect(installVault.Secrets[0].Fields.Password).To(Equal("gcpsm://ref-pw"))
+			Expect(installVault.Secrets[0].Fields.Username).To(Equal("admin"))
+			Expect(installVault.Secrets[1].File.Content).To(Equal("gcpsm://ref-file"))
+		})
+
+		It("leaves values that are already references alone", func() {
+			installVault.Secrets[0].Fields.Password = "gcpsm://already-stored"
+			backend.EXPECT().Store("ca-file", "-----BEGIN KEY-----").Return("gcpsm://ref-file", nil).Once()
+
+			Expect(vault.ExternalizeSecrets(installVault, backend)).To(Succeed())
+
+			Expect(installVault.Secrets[0].Fields.Password).To(Equal("gcpsm://already-stored"))
+		})
+
+		It("fails when the backend rejects a secret", func() {
+			backend.EXPECT().Store("postgres-password", "hunter2").Return("", fmt.Errorf("boom")).Once()
+
+			err := vault.ExternalizeSecrets(installVault, backend)
+			Expect(err).To(MatchError(ContainSubstring("failed to store secret postgres")))
+		})
+	})
+
+	Describe("ResolveSecretRefs", func() {
+		It("replaces reference URIs with fetched values", func() {
+			installVault := &files.InstallVault{Secrets: []files.SecretEntry{
+				{Name: "postgres", Fields: &files.SecretFields{Username: "admin", Password: "gcpsm://ref-pw"}},
+				{Name: "ca", File: &files.SecretFile{Name: "ca.key", Content: "hcvault://secret/codesphere/ca-file#value"}},
+				{Name: "literal", Fields: &files.SecretFields{Password: "plain"}},
+			}}
+
+			fetched := map[string]string{
+				"gcpsm://ref-pw": "hunter2",
+				"hcvault://secret/codesphere/ca-file#value": "-----BEGIN KEY-----",
+			}
+			err := vault.ResolveSecretRefs(installVault, func(uri string) (string, error) {
+				return fetched[uri], nil
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(installVault.Secrets[0].Fields.Password).To(Equal("hunter2"))
+			Expect(installVault.Secrets[1].File.Content).To(Equal("-----BEGIN KEY-----"))
+			Expect(installVault.Secrets[2].Fields.Password).To(Equal("plain"))
+		})
+
+		It("fails when a reference cannot be fetched", func() {
+			installVault := &files.InstallVault{Secrets: []files.SecretEntry{
+				{Name: "postgres", Fields: &files.SecretFields{Password: "gcpsm://ref-pw"}},
+			}}
+
+			err := vault.ResolveSecretRefs(installVault, func(uri string) (string, error) {
+				return "", fmt.Errorf("boom")
+			})
+			Expect(err).To(MatchError(ContainSubstring("failed to resolve secret postgres")))
+		})
+	})
+
+	Describe("BackendForRef", func() {
+		It("fails for unknown schemes", func() {
+			_, err := vault.BackendForRef("s3://bucket/key")
+			Expect(err).To(MatchError(ContainSubstring("unsupported secret reference")))
+		})
+	})
+
+	Describe("HashiVaultBackend", func() {
+		var (
+			client  *vault.MockHttpClient
+			backend *vault.HashiVaultBackend
+		)
+
+		BeforeEach(func() {
+			GinkgoT().Setenv("VAULT_TOKEN", "test-token")
+			client = vault.NewMockHttpClient(GinkgoT())
+			backend = &vault.HashiVaultBackend{URL: "https://vault.example.com", Mount: "secret", HttpClient: client}
+		})
+
+		Describe("Store", func() {
+			It("writes the value to the KV v2 engine and returns its reference", func() {
+				client.EXPECT().Do(mock.Anything).RunAndReturn(func(req *http.Request) (*http.Response, error) {
+					Expect(req.Method).To(Equal(http.MethodPost))
+					Expect(req.URL.String()).To(Equal("https://vault.example.com/v1/secret/data/codesphere/postgres-password"))
+					Expect(req.Header.Get("X-Vault-Token")).To(Equal("test-token"))
+					body, err := io.ReadAll(req.Body)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(string(body)).To(MatchJSON(`{"data":{"value":"hunter2"}}`))
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+				}).Once()
+
+				uri, err := backend.Store("postgres-password", "hunter2")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(uri).To(Equal("hcvault://secret/codesphere/postgres-password#value"))
+			})
+
+			It("fails when vault rejects the write", func() {
+				client.EXPECT().Do(mock.Anything).Return(&http.Response{
+					StatusCode: http.StatusForbidden,
+					Status:     "403 Forbidden",
+					Body:       io.NopCloser(strings.NewReader("{}")),
+				}, nil).Once()
+
+				_, err := backend.Store("postgres-password", "hunter2")
+				Expect(err).To(MatchError(ContainSubstring("vault returned status 403")))
+			})
+
+			It("fails when no token is set", func() {
+				GinkgoT().Setenv("VAULT_TOKEN", "")
+
+				_, err := backend.Store("postgres-password", "hunter2")
+				Expect(err).To(MatchError(ContainSubstring("VAULT_TOKEN is not set")))
+			})
+		})
+
+		Describe("Fetch", func() {
+			It("reads the referenced field from the KV v2 engine", func() {
+				client.EXPECT().Do(mock.Anything).RunAndReturn(func(req *http.Request) (*http.Response, error) {
+					Expect(req.Method).To(Equal(http.MethodGet))
+					Expect(req.URL.String()).To(Equal("https://vault.example.com/v1/secret/data/codesphere/postgres-password"))
+					Expect(req.Header.Get("X-Vault-Token")).To(Equal("test-token"))
+					body := `{"data":{"data":{"value":"hunter2"}}}`
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+				}).Once()
+
+				value, err := backend.Fetch("hcvault://secret/codesphere/postgres-password#value")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(value).To(Equal("hunter2"))
+			})
+
+			It("fails when the referenced field is missing", func() {
+				client.EXPECT().Do(mock.Anything).Return(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"data":{"data":{"other":"x"}}}`)),
+				}, nil).Once()
+
+				_, err := backend.Fetch("hcvault://secret/codesphere/postgres-password#value")
+				Expect(err).To(MatchError(ContainSubstring(`field "value" not found`)))
+			})
+
+			It("fails for malformed references", func() {
+				_, err := backend.Fetch("hcvault://no-path")
+				Expect(err).To(MatchError(ContainSubstring("malformed vault secret reference")))
+			})
+		})
+	})
+})
+
+var _ = Describe("NewHashiVaultBackendFromEnv", func() {
+	It("reads address and mount from the environment", func() {
+		GinkgoT().Setenv("VAULT_ADDR", "https://vault.example.com")
+		GinkgoT().Setenv("VAULT_KV_MOUNT", "kv")
+
+		backend, err := vault.NewHashiVaultBackendFromEnv()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(backend.URL).To(Equal("https://vault.example.com"))
+		Expect(backend.Mount).To(Equal("kv"))
+	})
+
+	It("defaults the mount to secret", func() {
+		GinkgoT().Setenv("VAULT_ADDR", "https://vault.example.com")
+		GinkgoT().Setenv("VAULT_KV_MOUNT", "")
+
+		backend, err := vault.NewHashiVaultBackendFromEnv()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(backend.Mount).To(Equal("secret"))
+	})
+
+	It("fails when VAULT_ADDR is not set", func() {
+		GinkgoT().Setenv("VAULT_ADDR", "")
+
+		_, err := vault.NewHashiVaultBackendFromEnv()
+		Expect(err).To(MatchError(ContainSubstring("VAULT_ADDR is not set")))
+	})
+})
@@ -7,13 +7,22 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"filippo.io/age"
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/aes"
 	sopsage "github.com/getsops/sops/v3/age"
+	"github.com/getsops/sops/v3/cmd/sops/common"
+	"github.com/getsops/sops/v3/config"
+	"github.com/getsops/sops/v3/decrypt"
+	"github.com/getsops/sops/v3/gcpkms"
+	"github.com/getsops/sops/v3/keyservice"
+	yamlstore "github.com/getsops/sops/v3/stores/yaml"
+	"github.com/getsops/sops/v3/version"
 	"go.yaml.in/yaml/v3"
 )
 
@@ -37,7 +46,7 @@ var xdgConfigHome = "XDG_CONFIG_HOME"
 func ResolveAgeKey(explicitKeyFile, fallbackDir string) (recipient string, keyPath string, err error) {
 	// 0. Explicit key file – supplied by the caller, takes priority.
 	if explicitKeyFile != "" {
-		recipient, err = readRecipientFromFile(explicitKeyFile)
+		recipient, err = RecipientFromKeyFile(explicitKeyFile)
 		if err != nil {
 			return "", "", fmt.Errorf("failed to read age key from %s: %w", explicitKeyFile, err)
 		}
@@ -55,7 +64,7 @@ func ResolveAgeKey(explicitKeyFile, fallbackDir string) (recipient string, keyPa
 
 	// 2. SOPS_AGE_KEY_FILE env var.
 	if keyFile := os.Getenv(sopsage.SopsAgeKeyFileEnv); keyFile != "" {
-		recipient, err = readRecipientFromFile(keyFile)
+		recipient, err = RecipientFromKeyFile(keyFile)
 		if err != nil {
 			return "", "", fmt.Errorf("failed to read age key from %s: %w", keyFile, err)
 		}
@@ -66,7 +75,7 @@ func ResolveAgeKey(explicitKeyFile, fallbackDir string) (recipient string, keyPa
 	defaultPath, configErr := getUserConfigDir()
 	if configErr == nil {
 		defaultPath = filepath.Join(defaultPath, sopsage.SopsAgeKeyUserConfigPath)
-		recipient, err = readRecipientFromFile(defaultPath)
+		recipient, err = RecipientFromKeyFile(defaultPath)
 		if err == nil {
 			return recipient, defaultPath, nil
 		}
@@ -77,12 +86,12 @@ func ResolveAgeKey(explicitKeyFile, fallbackDir string) (recipient string, keyPa
 
 	// 4. Generate a new key.
 	keyPath = filepath.Join(fallbackDir, "age_key.txt")
-	recipient, err = readRecipientFromFile(keyPath)
+	recipient, err = RecipientFromKeyFile(keyPath)
 	if err != nil {
 		if !os.IsNotExist(err) {
 			return "", "", fmt.Errorf("failed to read age key from fallback location %s: %w", keyPath, err)
 		}
-		recipient, err = generateAgeKey(keyPath)
+		recipient, err = GenerateAgeKey(keyPath)
 		if err != nil {
 			return "", "", fmt.Errorf("failed to generate age key: %w", err)
 		}
@@ -114,8 +123,8 @@ func parseAgeRecipient(reader io.Reader) (string, error) {
 	}
 }
 
-// readRecipientFromFile reads an age key file and extracts the public key.
-func readRecipientFromFile(path string) (recipient string, err error) {
+// RecipientFromKeyFile reads an age key file and extracts the public key.
+func RecipientFromKeyFile(path string) (recipient string, err error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return "", err
@@ -135,49 +144,133 @@ func getUserConfigDir() (string, error) {
 	return os.UserConfigDir()
 }
 
-// generateAgeKey generates a new age keypair and writes it to the given path.
+// GenerateAgeKey generates a new age keypair in-process and writes it to the
+// given path in the same format age-keygen produces.
 // Returns the public key (recipient).
-func generateAgeKey(keyPath string) (string, error) {
+func GenerateAgeKey(keyPath string) (string, error) {
 	if err := os.MkdirAll(filepath.Dir(keyPath), 0700); err != nil {
 		return "", fmt.Errorf("failed to create directory for age key: %w", err)
 	}
 
-	cmd := exec.Command("age-keygen", "-o", keyPath)
-	out, err := cmd.CombinedOutput()
+	identity, err := age.GenerateX25519Identity()
 	if err != nil {
-		return "", fmt.Errorf("age-keygen failed: %w: %s", err, out)
+		return "", fmt.Errorf("failed to generate age identity: %w", err)
 	}
 
-	recipient, err := readRecipientFromFile(keyPath)
-	if err != nil {
-		return "", fmt.Errorf("failed to read generated age key: %w", err)
+	content := fmt.Sprintf("# created: %s\n# public key: %s\n%s\n",
+		time.Now().Format(time.RFC3339), identity.Recipient(), identity)
+	if err := os.WriteFile(keyPath, []byte(content), 0600); err != nil {
+		return "", fmt.Errorf("failed to write age key file: %w", err)
 	}
-	return recipient, nil
+
+	return identity.Recipient().String(), nil
 }
 
 // EncryptFileWithSOPS encrypts src with SOPS+age and writes ciphertext to target.
+// Encryption happens in-process via the sops library; no sops binary is needed.
 func EncryptFileWithSOPS(src, target, recipient string) error {
-	cmd := exec.Command("sops", "--encrypt", "--input-type", "yaml", "--age", recipient, "--output", target, src)
-	out, err := cmd.CombinedOutput()
+	masterKeys, err := sopsage.MasterKeysFromRecipients(recipient)
+	if err != nil {
+		return fmt.Errorf("failed to parse age recipient: %w", err)
+	}
+	keyGroup := make(sops.KeyGroup, 0, len(masterKeys))
+	for _, key := range masterKeys {
+		keyGroup = append(keyGroup, key)
+	}
+
+	return encryptFile(src, target, keyGroup)
+}
+
+// EncryptFileWithGCPKMS encrypts src with SOPS using the given Cloud KMS key
+// resource name as recipient and writes the ciphertext to target. Credentials
+// are resolved via Application Default Credentials; no key material touches
+// the local disk.
+func EncryptFileWithGCPKMS(src, target, resourceID string) error {
+	keyGroup := sops.KeyGroup{}
+	for _, key := range gcpkms.MasterKeysFromResourceIDString(resourceID) {
+		keyGroup = append(keyGroup, key)
+	}
+	if len(keyGroup) == 0 {
+		return fmt.Errorf("no Cloud KMS keys found in resource ID %q", resourceID)
+	}
+
+	return encryptFile(src, target, keyGroup)
+}
+
+// encryptFile reads the plaintext file, encrypts it for the key group and
+// writes the ciphertext to target.
+func encryptFile(src, target string, keyGroup sops.KeyGroup) error {
+	plaintext, err := os.ReadFile(src)
 	if err != nil {
-		return fmt.Errorf("sops encrypt failed: %w: %s", err, out)
+		return fmt.Errorf("failed to read plaintext file: %w", err)
+	}
+
+	ciphertext, err := encryptYAML(plaintext, keyGroup)
+	if err != nil {
+		return fmt.Errorf("sops encrypt failed: %w", err)
+	}
+
+	if err := os.WriteFile(target, ciphertext, 0600); err != nil {
+		return fmt.Errorf("failed to write encrypted file: %w", err)
 	}
 	return nil
 }
 
+// encryptYAML encrypts a YAML document for the given SOPS key group using the
+// sops library.
+func encryptYAML(plaintext []byte, keyGroup sops.KeyGroup) ([]byte, error) {
+	store := yamlstore.NewStore(&config.YAMLStoreConfig{})
+	branches, err := store.LoadPlainFile(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse plaintext YAML: %w", err)
+	}
+
+	tree := sops.Tree{
+		Branches: branches,
+		Metadata: sops.Metadata{
+			KeyGroups: []sops.KeyGroup{keyGroup},
+			Version:   version.Version,
+		},
+	}
+
+	dataKey, errs := tree.GenerateDataKeyWithKeyServices([]keyservice.KeyServiceClient{keyservice.NewLocalClient()})
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to generate data key: %v", errs)
+	}
+
+	err = common.EncryptTree(common.EncryptTreeOpts{Tree: &tree, Cipher: aes.NewCipher(), DataKey: dataKey})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt tree: %w", err)
+	}
+
+	return store.EmitEncryptedFile(tree)
+}
+
 // DecryptFileWithSOPS decrypts a SOPS-encrypted file and returns the plaintext bytes.
-// If keyPath is non-empty, SOPS_AGE_KEY_FILE is set for the sops process.
+// Decryption happens in-process via the sops library; if keyPath is non-empty,
+// SOPS_AGE_KEY_FILE is set while decrypting so the key file is picked up.
 func DecryptFileWithSOPS(src, keyPath string) ([]byte, error) {
-	cmd := exec.Command("sops", "--decrypt", "--input-type", "yaml", src)
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read encrypted file: %w", err)
+	}
+
 	if keyPath != "" {
-		cmd.Env = append(os.Environ(), "SOPS_AGE_KEY_FILE="+keyPath)
+		previous, wasSet := os.LookupEnv(sopsage.SopsAgeKeyFileEnv)
+		if err := os.Setenv(sopsage.SopsAgeKeyFileEnv, keyPath); err != nil {
+			return nil, fmt.Errorf("failed to set %s: %w", sopsage.SopsAgeKeyFileEnv, err)
+		}
+		defer func() {
+			if wasSet {
+				_ = os.Setenv(sopsage.SopsAgeKeyFileEnv, previous)
+			} else {
+				_ = os.Unsetenv(sopsage.SopsAgeKeyFileEnv)
+			}
+		}()
 	}
 
-	out, err := cmd.Output()
+	out, err := decrypt.Data(data, "yaml")
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			return nil, fmt.Errorf("sops decrypt failed: %s", string(exitErr.Stderr))
-		}
 		return nil, fmt.Errorf("sops decrypt failed: %w", err)
 	}
 
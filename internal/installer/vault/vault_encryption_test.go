@@ -221,28 +221,13 @@ var _ = Describe("VaultEncryption", func() {
 			Expect(os.RemoveAll(tmpDir)).To(Succeed())
 		})
 
-		It("parses a plain vault file without data: wrapper", func() {
+		It("rejects a plain vault file without sops metadata", func() {
 			vaultPath := filepath.Join(tmpDir, "plain.vault.yaml")
 			plainYAML := "secrets:\n    - name: test-secret\n      fields:\n        password: hunter2\n"
 			Expect(os.WriteFile(vaultPath, []byte(plainYAML), 0644)).To(Succeed())
 
-			vault, err := vault.LoadVaultData(vaultPath, "")
-			Expect(err).ToNot(HaveOccurred())
-			Expect(vault.Secrets).To(HaveLen(1))
-			Expect(vault.Secrets[0].Name).To(Equal("test-secret"))
-			Expect(vault.Secrets[0].Fields.Password).To(Equal("hunter2"))
-		})
-
-		It("unwraps a plain file with data: | wrapper (SOPS whole-file format edge case)", func() {
-			vaultPath := filepath.Join(tmpDir, "wrapped.vault.yaml")
-			wrappedYAML := "data: |\n    secrets:\n        - name: test-secret\n          fields:\n            password: hunter2\n"
-			Expect(os.WriteFile(vaultPath, []byte(wrappedYAML), 0644)).To(Succeed())
-
-			vault, err := vault.LoadVaultData(vaultPath, "")
-			Expect(err).ToNot(HaveOccurred())
-			Expect(vault.Secrets).To(HaveLen(1))
-			Expect(vault.Secrets[0].Name).To(Equal("test-secret"))
-			Expect(vault.Secrets[0].Fields.Password).To(Equal("hunter2"))
+			_, err := vault.LoadVaultData(vaultPath, "")
+			Expect(err).To(MatchError(ContainSubstring("not SOPS-encrypted")))
 		})
 
 		It("loads and decrypts a SOPS-encrypted vault end-to-end", func() {
@@ -0,0 +1,138 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wizard
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Field is a single editable value of the wizard. Its Value holds the current
+// text, pre-loaded from the config so an existing configuration can be edited
+// instead of typed in from scratch.
+type Field struct {
+	// Label shown next to the input and on the review screen.
+	Label string
+	// Value is the current value as text.
+	Value string
+	// Validate rejects invalid input before the field is committed;
+	// nil accepts any value.
+	Validate func(string) error
+	// Apply writes the committed value back to the config. Called for every
+	// field once the operator accepts the review screen.
+	Apply func(string)
+}
+
+// Section groups related fields under one heading.
+type Section struct {
+	Title  string
+	Fields []*Field
+}
+
+func validateRequired(value string) error {
+	if value == "" {
+		return fmt.Errorf("a value is required")
+	}
+	return nil
+}
+
+func validateInt(value string) error {
+	if _, err := strconv.Atoi(value); err != nil {
+		return fmt.Errorf("%q is not a number", value)
+	}
+	return nil
+}
+
+func validateIP(value string) error {
+	if net.ParseIP(value) == nil {
+		return fmt.Errorf("%q is not a valid IP address", value)
+	}
+	return nil
+}
+
+func validateCIDR(value string) error {
+	if _, _, err := net.ParseCIDR(value); err != nil {
+		return fmt.Errorf("%q is not a valid CIDR subnet", value)
+	}
+	return nil
+}
+
+func validateIPList(value string) error {
+	for _, ip := range splitList(value) {
+		if err := validateIP(ip); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateBool(value string) error {
+	switch strings.ToLower(value) {
+	case "y", "yes", "n", "no", "true", "false":
+		return nil
+	}
+	return fmt.Errorf("answer y or n")
+}
+
+func validateChoice(options ...string) func(string) error {
+	return func(value string) error {
+		for _, option := range options {
+			if value == option {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of: %s", strings.Join(options, ", "))
+	}
+}
+
+// optional wraps a validator so the empty value is also accepted.
+func optional(validate func(string) error) func(string) error {
+	return func(value string) error {
+		if value == "" {
+			return nil
+		}
+		return validate(value)
+	}
+}
+
+// all chains validators; the first failure wins.
+func all(validators ...func(string) error) func(string) error {
+	return func(value string) error {
+		for _, validate := range validators {
+			if err := validate(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func parseBool(value string) bool {
+	switch strings.ToLower(value) {
+	case "y", "yes", "true":
+		return true
+	}
+	return false
+}
+
+func formatBool(value bool) string {
+	if value {
+		return "y"
+	}
+	return "n"
+}
+
+// splitList splits a comma-separated value into trimmed, non-empty entries.
+func splitList(value string) []string {
+	result := []string{}
+	for _, part := range strings.Split(value, ",") {
+		trimmed := strings.TrimSpace(part)
+		if trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
@@ -0,0 +1,203 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wizard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	titleStyle   = lipgloss.NewStyle().Bold(true)
+	sectionStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	errorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	helpStyle    = lipgloss.NewStyle().Faint(true)
+	valueStyle   = lipgloss.NewStyle().Faint(true)
+)
+
+// Model is the Bubble Tea model driving the wizard: it walks the operator
+// through the sections field by field, validates every committed value, and
+// ends on a review screen summarizing all answers.
+type Model struct {
+	sections []*Section
+	section  int
+	field    int
+	input    textinput.Model
+	err      error
+	review   bool
+	// Accepted reports whether the operator confirmed the review screen.
+	Accepted bool
+	aborted  bool
+}
+
+// NewModel creates a wizard model over the given sections, starting at the
+// first field of the first section.
+func NewModel(sections []*Section) Model {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Focus()
+
+	m := Model{
+		sections: sections,
+		input:    input,
+	}
+	m.input.SetValue(m.currentField().Value)
+	return m
+}
+
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+
+	if keyMsg.String() == "ctrl+c" {
+		m.aborted = true
+		return m, tea.Quit
+	}
+
+	if m.review {
+		return m.updateReview(keyMsg)
+	}
+	return m.updateField(keyMsg)
+}
+
+func (m Model) updateReview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "y":
+		m.Accepted = true
+		return m, tea.Quit
+	case "e":
+		m.review = false
+		m.section = 0
+		m.field = 0
+		m.input.SetValue(m.currentField().Value)
+		return m, nil
+	case "q", "esc":
+		m.aborted = true
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m Model) updateField(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		value := strings.TrimSpace(m.input.Value())
+		field := m.currentField()
+		if field.Validate != nil {
+			if err := field.Validate(value); err != nil {
+				m.err = err
+				return m, nil
+			}
+		}
+		field.Value = value
+		m.err = nil
+		return m.nextField(), nil
+	case "up", "shift+tab":
+		m.err = nil
+		return m.previousField(), nil
+	case "esc":
+		m.aborted = true
+		return m, tea.Quit
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m Model) currentField() *Field {
+	return m.sections[m.section].Fields[m.field]
+}
+
+// nextField advances to the next field, crossing section boundaries; past the
+// last field the wizard switches to the review screen.
+func (m Model) nextField() Model {
+	m.field++
+	if m.field >= len(m.sections[m.section].Fields) {
+		m.field = 0
+		m.section++
+	}
+	if m.section >= len(m.sections) {
+		m.section = 0
+		m.review = true
+		return m
+	}
+	m.input.SetValue(m.currentField().Value)
+	return m
+}
+
+// previousField steps back one field, crossing section boundaries; at the
+// very first field it stays put.
+func (m Model) previousField() Model {
+	if m.section == 0 && m.field == 0 {
+		return m
+	}
+	m.field--
+	if m.field < 0 {
+		m.section--
+		m.field = len(m.sections[m.section].Fields) - 1
+	}
+	m.input.SetValue(m.currentField().Value)
+	return m
+}
+
+func (m Model) View() string {
+	if m.review {
+		return m.reviewView()
+	}
+	return m.fieldView()
+}
+
+func (m Model) fieldView() string {
+	var sb strings.Builder
+	section := m.sections[m.section]
+
+	sb.WriteString(titleStyle.Render("Codesphere install configuration"))
+	sb.WriteString(helpStyle.Render(fmt.Sprintf("  (section %d/%d)", m.section+1, len(m.sections))))
+	sb.WriteString("\n\n")
+	sb.WriteString(sectionStyle.Render(section.Title) + "\n")
+
+	for i, field := range section.Fields {
+		switch {
+		case i == m.field:
+			sb.WriteString(fmt.Sprintf("  %s\n  %s\n", field.Label, m.input.View()))
+		default:
+			sb.WriteString(valueStyle.Render(fmt.Sprintf("  %s: %s", field.Label, field.Value)) + "\n")
+		}
+	}
+
+	if m.err != nil {
+		sb.WriteString("\n" + errorStyle.Render("✗ "+m.err.Error()) + "\n")
+	}
+
+	sb.WriteString("\n" + helpStyle.Render("enter: confirm field • up: previous field • esc: abort") + "\n")
+	return sb.String()
+}
+
+func (m Model) reviewView() string {
+	var sb strings.Builder
+
+	sb.WriteString(titleStyle.Render("Review configuration") + "\n")
+	for _, section := range m.sections {
+		sb.WriteString("\n" + sectionStyle.Render(section.Title) + "\n")
+		for _, field := range section.Fields {
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", field.Label, field.Value))
+		}
+	}
+
+	sb.WriteString("\n" + helpStyle.Render("enter: write configuration • e: edit again • q: abort") + "\n")
+	return sb.String()
+}
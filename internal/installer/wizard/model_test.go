@@ -0,0 +1,101 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wizard
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Model", func() {
+	var model Model
+
+	pressEnter := func() {
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		model = updated.(Model)
+	}
+
+	typeValue := func(value string) {
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(value)})
+		model = updated.(Model)
+	}
+
+	BeforeEach(func() {
+		model = NewModel([]*Section{
+			{
+				Title: "First",
+				Fields: []*Field{
+					{Label: "Name", Validate: validateRequired},
+					{Label: "City"},
+				},
+			},
+			{
+				Title: "Second",
+				Fields: []*Field{
+					{Label: "IP", Validate: validateIP},
+				},
+			},
+		})
+	})
+
+	It("stays on an invalid field and shows the error", func() {
+		pressEnter()
+
+		Expect(model.err).To(HaveOccurred())
+		Expect(model.section).To(Equal(0))
+		Expect(model.field).To(Equal(0))
+	})
+
+	It("commits valid values and advances across sections to review", func() {
+		typeValue("dc-1")
+		pressEnter()
+		pressEnter()
+		typeValue("10.0.0.1")
+		pressEnter()
+
+		Expect(model.review).To(BeTrue())
+		Expect(model.sections[0].Fields[0].Value).To(Equal("dc-1"))
+		Expect(model.sections[1].Fields[0].Value).To(Equal("10.0.0.1"))
+	})
+
+	It("steps back to the previous field across section boundaries", func() {
+		typeValue("dc-1")
+		pressEnter()
+		pressEnter()
+
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyUp})
+		model = updated.(Model)
+
+		Expect(model.section).To(Equal(0))
+		Expect(model.field).To(Equal(1))
+	})
+
+	It("accepts the configuration on the review screen", func() {
+		model.review = true
+
+		pressEnter()
+
+		Expect(model.Accepted).To(BeTrue())
+	})
+
+	It("returns to the first field when editing again from review", func() {
+		model.review = true
+
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+		model = updated.(Model)
+
+		Expect(model.review).To(BeFalse())
+		Expect(model.section).To(Equal(0))
+		Expect(model.field).To(Equal(0))
+	})
+
+	It("aborts without accepting on ctrl+c", func() {
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+		model = updated.(Model)
+
+		Expect(model.aborted).To(BeTrue())
+		Expect(model.Accepted).To(BeFalse())
+	})
+})
@@ -0,0 +1,316 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wizard
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+)
+
+// SectionsFromConfig builds the wizard sections for the given config. Every
+// field is pre-loaded with the current config value, so both a fresh profile
+// and an existing config file can be edited. The Apply closures write the
+// committed values back into the config once the review screen is accepted.
+func SectionsFromConfig(config *files.RootConfig) []*Section {
+	if config.Registry == nil {
+		config.Registry = &files.RegistryConfig{}
+	}
+	if config.Postgres.Primary == nil {
+		config.Postgres.Primary = &files.PostgresPrimaryConfig{}
+	}
+
+	return []*Section{
+		datacenterSection(config),
+		registrySection(config),
+		postgresSection(config),
+		cephSection(config),
+		kubernetesSection(config),
+		codesphereSection(config),
+	}
+}
+
+func datacenterSection(config *files.RootConfig) *Section {
+	return &Section{
+		Title: "Datacenter",
+		Fields: []*Field{
+			{
+				Label:    "Datacenter ID",
+				Value:    strconv.Itoa(config.Datacenter.ID),
+				Validate: all(validateRequired, validateInt),
+				Apply:    func(v string) { config.Datacenter.ID, _ = strconv.Atoi(v) },
+			},
+			{
+				Label:    "Datacenter name",
+				Value:    config.Datacenter.Name,
+				Validate: validateRequired,
+				Apply:    func(v string) { config.Datacenter.Name = v },
+			},
+			{
+				Label: "Datacenter city",
+				Value: config.Datacenter.City,
+				Apply: func(v string) { config.Datacenter.City = v },
+			},
+			{
+				Label: "Country code",
+				Value: config.Datacenter.CountryCode,
+				Apply: func(v string) { config.Datacenter.CountryCode = v },
+			},
+			{
+				Label:    "Secrets base directory",
+				Value:    config.Secrets.BaseDir,
+				Validate: validateRequired,
+				Apply:    func(v string) { config.Secrets.BaseDir = v },
+			},
+		},
+	}
+}
+
+func registrySection(config *files.RootConfig) *Section {
+	return &Section{
+		Title: "Container registry",
+		Fields: []*Field{
+			{
+				Label: "Registry server (empty to skip)",
+				Value: config.Registry.Server,
+				Apply: func(v string) { config.Registry.Server = v },
+			},
+			{
+				Label:    "Replace images in BOM (y/n)",
+				Value:    formatBool(config.Registry.ReplaceImagesInBom),
+				Validate: validateBool,
+				Apply:    func(v string) { config.Registry.ReplaceImagesInBom = parseBool(v) },
+			},
+			{
+				Label:    "Load container images from installer (y/n)",
+				Value:    formatBool(config.Registry.LoadContainerImages),
+				Validate: validateBool,
+				Apply:    func(v string) { config.Registry.LoadContainerImages = parseBool(v) },
+			},
+		},
+	}
+}
+
+func postgresSection(config *files.RootConfig) *Section {
+	mode := &Field{
+		Label:    "Mode (install/external)",
+		Value:    config.Postgres.Mode,
+		Validate: validateChoice("install", "external"),
+		Apply:    func(v string) { config.Postgres.Mode = v },
+	}
+
+	return &Section{
+		Title: "PostgreSQL",
+		Fields: []*Field{
+			mode,
+			{
+				Label: "Primary server IP",
+				Value: config.Postgres.Primary.IP,
+				Validate: func(v string) error {
+					if mode.Value == "external" && v == "" {
+						return nil
+					}
+					return validateIP(v)
+				},
+				Apply: func(v string) { config.Postgres.Primary.IP = v },
+			},
+			{
+				Label: "Primary hostname",
+				Value: config.Postgres.Primary.Hostname,
+				Validate: func(v string) error {
+					if mode.Value == "external" && v == "" {
+						return nil
+					}
+					return validateRequired(v)
+				},
+				Apply: func(v string) { config.Postgres.Primary.Hostname = v },
+			},
+			{
+				Label: "External server address",
+				Value: config.Postgres.ServerAddress,
+				Validate: func(v string) error {
+					if mode.Value == "external" {
+						return validateRequired(v)
+					}
+					if v != "" {
+						return fmt.Errorf("must be empty when mode is 'install'")
+					}
+					return nil
+				},
+				Apply: func(v string) { config.Postgres.ServerAddress = v },
+			},
+		},
+	}
+}
+
+func cephSection(config *files.RootConfig) *Section {
+	initialHosts := joinCephHosts(config.Ceph.Hosts)
+
+	return &Section{
+		Title: "Ceph",
+		Fields: []*Field{
+			{
+				Label:    "Nodes subnet (CIDR)",
+				Value:    config.Ceph.NodesSubnet,
+				Validate: validateCIDR,
+				Apply:    func(v string) { config.Ceph.NodesSubnet = v },
+			},
+			{
+				Label:    "Host IPs (comma-separated)",
+				Value:    initialHosts,
+				Validate: all(validateRequired, validateIPList),
+				Apply: func(v string) {
+					// Editing the IPs rebuilds the host list with generated
+					// hostnames; an unchanged value keeps the existing hosts.
+					if v == initialHosts {
+						return
+					}
+					hosts := []files.CephHost{}
+					for i, ip := range splitList(v) {
+						hosts = append(hosts, files.CephHost{
+							Hostname:  fmt.Sprintf("ceph-%d", i+1),
+							IPAddress: ip,
+							IsMaster:  i == 0,
+						})
+					}
+					config.Ceph.Hosts = hosts
+				},
+			},
+		},
+	}
+}
+
+func kubernetesSection(config *files.RootConfig) *Section {
+	managed := &Field{
+		Label:    "Managed by Codesphere (y/n)",
+		Value:    formatBool(config.Kubernetes.ManagedByCodesphere),
+		Validate: validateBool,
+		Apply:    func(v string) { config.Kubernetes.ManagedByCodesphere = parseBool(v) },
+	}
+	initialControlPlanes := joinK8sNodes(config.Kubernetes.ControlPlanes)
+	initialWorkers := joinK8sNodes(config.Kubernetes.Workers)
+
+	return &Section{
+		Title: "Kubernetes",
+		Fields: []*Field{
+			managed,
+			{
+				Label: "Control plane IPs (comma-separated)",
+				Value: initialControlPlanes,
+				Validate: func(v string) error {
+					if parseBool(managed.Value) && v == "" {
+						return fmt.Errorf("at least one control plane is required")
+					}
+					return validateIPList(v)
+				},
+				Apply: func(v string) {
+					if v == initialControlPlanes {
+						return
+					}
+					config.Kubernetes.ControlPlanes = ipsToK8sNodes(splitList(v))
+				},
+			},
+			{
+				Label:    "Worker IPs (comma-separated)",
+				Value:    initialWorkers,
+				Validate: validateIPList,
+				Apply: func(v string) {
+					if v == initialWorkers {
+						return
+					}
+					config.Kubernetes.Workers = ipsToK8sNodes(splitList(v))
+				},
+			},
+			{
+				Label: "API server host",
+				Value: config.Kubernetes.APIServerHost,
+				Apply: func(v string) { config.Kubernetes.APIServerHost = v },
+			},
+			{
+				Label: "Pod CIDR",
+				Value: config.Kubernetes.PodCIDR,
+				Validate: func(v string) error {
+					if !parseBool(managed.Value) {
+						return validateCIDR(v)
+					}
+					return optional(validateCIDR)(v)
+				},
+				Apply: func(v string) { config.Kubernetes.PodCIDR = v },
+			},
+			{
+				Label: "Service CIDR",
+				Value: config.Kubernetes.ServiceCIDR,
+				Validate: func(v string) error {
+					if !parseBool(managed.Value) {
+						return validateCIDR(v)
+					}
+					return optional(validateCIDR)(v)
+				},
+				Apply: func(v string) { config.Kubernetes.ServiceCIDR = v },
+			},
+		},
+	}
+}
+
+func codesphereSection(config *files.RootConfig) *Section {
+	return &Section{
+		Title: "Codesphere",
+		Fields: []*Field{
+			{
+				Label:    "Domain",
+				Value:    config.Codesphere.Domain,
+				Validate: validateRequired,
+				Apply:    func(v string) { config.Codesphere.Domain = v },
+			},
+			{
+				Label: "Workspace hosting base domain",
+				Value: config.Codesphere.WorkspaceHostingBaseDomain,
+				Apply: func(v string) { config.Codesphere.WorkspaceHostingBaseDomain = v },
+			},
+			{
+				Label: "Custom domains CNAME base domain",
+				Value: config.Codesphere.CustomDomains.CNameBaseDomain,
+				Apply: func(v string) { config.Codesphere.CustomDomains.CNameBaseDomain = v },
+			},
+			{
+				Label:    "Public IP",
+				Value:    config.Codesphere.PublicIP,
+				Validate: optional(validateIP),
+				Apply:    func(v string) { config.Codesphere.PublicIP = v },
+			},
+			{
+				Label:    "DNS servers (comma-separated)",
+				Value:    strings.Join(config.Codesphere.DNSServers, ", "),
+				Validate: validateIPList,
+				Apply:    func(v string) { config.Codesphere.DNSServers = splitList(v) },
+			},
+		},
+	}
+}
+
+func joinCephHosts(hosts []files.CephHost) string {
+	ips := make([]string, len(hosts))
+	for i, host := range hosts {
+		ips[i] = host.IPAddress
+	}
+	return strings.Join(ips, ", ")
+}
+
+func joinK8sNodes(nodes []files.K8sNode) string {
+	ips := make([]string, len(nodes))
+	for i, node := range nodes {
+		ips[i] = node.IPAddress
+	}
+	return strings.Join(ips, ", ")
+}
+
+func ipsToK8sNodes(ips []string) []files.K8sNode {
+	nodes := make([]files.K8sNode, len(ips))
+	for i, ip := range ips {
+		nodes[i] = files.K8sNode{IPAddress: ip}
+	}
+	return nodes
+}
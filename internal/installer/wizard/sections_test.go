@@ -0,0 +1,114 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wizard_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/codesphere-cloud/oms/internal/installer/wizard"
+)
+
+var _ = Describe("SectionsFromConfig", func() {
+	var config *files.RootConfig
+
+	BeforeEach(func() {
+		config = &files.RootConfig{}
+		config.Datacenter.ID = 42
+		config.Datacenter.Name = "dc"
+		config.Postgres.Mode = "install"
+		config.Ceph.Hosts = []files.CephHost{
+			{Hostname: "storage-a", IPAddress: "10.0.0.1", IsMaster: true},
+			{Hostname: "storage-b", IPAddress: "10.0.0.2"},
+		}
+	})
+
+	findField := func(sections []*wizard.Section, label string) *wizard.Field {
+		for _, section := range sections {
+			for _, field := range section.Fields {
+				if field.Label == label {
+					return field
+				}
+			}
+		}
+		return nil
+	}
+
+	It("pre-loads field values from the config", func() {
+		sections := wizard.SectionsFromConfig(config)
+
+		Expect(findField(sections, "Datacenter ID").Value).To(Equal("42"))
+		Expect(findField(sections, "Datacenter name").Value).To(Equal("dc"))
+		Expect(findField(sections, "Host IPs (comma-separated)").Value).To(Equal("10.0.0.1, 10.0.0.2"))
+	})
+
+	It("writes committed values back into the config on apply", func() {
+		sections := wizard.SectionsFromConfig(config)
+
+		field := findField(sections, "Datacenter name")
+		field.Value = "frankfurt"
+		field.Apply(field.Value)
+
+		Expect(config.Datacenter.Name).To(Equal("frankfurt"))
+	})
+
+	It("keeps existing ceph hosts when the IP list is unchanged", func() {
+		sections := wizard.SectionsFromConfig(config)
+
+		field := findField(sections, "Host IPs (comma-separated)")
+		field.Apply(field.Value)
+
+		Expect(config.Ceph.Hosts[0].Hostname).To(Equal("storage-a"))
+		Expect(config.Ceph.Hosts[1].Hostname).To(Equal("storage-b"))
+	})
+
+	It("rebuilds the ceph host list when the IPs are edited", func() {
+		sections := wizard.SectionsFromConfig(config)
+
+		field := findField(sections, "Host IPs (comma-separated)")
+		field.Apply("10.0.0.5, 10.0.0.6, 10.0.0.7")
+
+		Expect(config.Ceph.Hosts).To(HaveLen(3))
+		Expect(config.Ceph.Hosts[0]).To(Equal(files.CephHost{Hostname: "ceph-1", IPAddress: "10.0.0.5", IsMaster: true}))
+		Expect(config.Ceph.Hosts[2]).To(Equal(files.CephHost{Hostname: "ceph-3", IPAddress: "10.0.0.7"}))
+	})
+
+	It("requires the postgres primary only in install mode", func() {
+		sections := wizard.SectionsFromConfig(config)
+		mode := findField(sections, "Mode (install/external)")
+		primaryIP := findField(sections, "Primary server IP")
+
+		mode.Value = "install"
+		Expect(primaryIP.Validate("")).To(HaveOccurred())
+
+		mode.Value = "external"
+		Expect(primaryIP.Validate("")).ToNot(HaveOccurred())
+	})
+
+	It("rejects an external server address in install mode", func() {
+		sections := wizard.SectionsFromConfig(config)
+		mode := findField(sections, "Mode (install/external)")
+		address := findField(sections, "External server address")
+
+		mode.Value = "install"
+		Expect(address.Validate("db.example.com:5432")).To(HaveOccurred())
+
+		mode.Value = "external"
+		Expect(address.Validate("db.example.com:5432")).ToNot(HaveOccurred())
+		Expect(address.Validate("")).To(HaveOccurred())
+	})
+
+	It("requires control planes only for managed kubernetes", func() {
+		sections := wizard.SectionsFromConfig(config)
+		managed := findField(sections, "Managed by Codesphere (y/n)")
+		controlPlanes := findField(sections, "Control plane IPs (comma-separated)")
+
+		managed.Value = "y"
+		Expect(controlPlanes.Validate("")).To(HaveOccurred())
+
+		managed.Value = "n"
+		Expect(controlPlanes.Validate("")).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,51 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package wizard implements the full-screen interactive configuration wizard
+// for 'oms init install-config'. Unlike the linear prompter it is organized
+// in sections, validates every field as it is entered, lets the operator
+// navigate back to earlier answers, and only writes to the config after a
+// final review screen is accepted.
+package wizard
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"golang.org/x/term"
+)
+
+// IsTerminal reports whether the wizard can run, i.e. stdin and stdout are
+// attached to a terminal.
+func IsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// Run starts the wizard on the terminal, editing the given config in place,
+// and reports whether the operator accepted the reviewed configuration. An
+// aborted wizard leaves the config untouched.
+func Run(config *files.RootConfig) (bool, error) {
+	model := NewModel(SectionsFromConfig(config))
+
+	finished, err := tea.NewProgram(model).Run()
+	if err != nil {
+		return false, fmt.Errorf("wizard failed: %w", err)
+	}
+
+	result, ok := finished.(Model)
+	if !ok || !result.Accepted {
+		return false, nil
+	}
+
+	for _, section := range result.sections {
+		for _, field := range section.Fields {
+			if field.Apply != nil {
+				field.Apply(field.Value)
+			}
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package installer
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"net"
+	"strings"
+	"text/template"
+
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/codesphere-cloud/oms/internal/util"
+)
+
+//go:embed manifests/workspace-egress/networkpolicies.yaml
+var workspaceEgressTemplate []byte
+
+// DefaultWorkspaceNamespace is the namespace workspace pods run in unless
+// overridden via codesphere.workspaceEgress.namespace.
+const DefaultWorkspaceNamespace = "codesphere"
+
+type workspaceEgressTemplateData struct {
+	Namespace    string
+	DefaultDeny  bool
+	AllowedCIDRs []string
+}
+
+// ResolveWorkspaceEgressCIDRs validates the configured CIDRs and resolves the
+// allowed domains to host CIDRs using lookupIP. Domains are resolved once at
+// install time; re-running the platform install picks up DNS changes.
+func ResolveWorkspaceEgressCIDRs(egress *files.WorkspaceEgressConfig, lookupIP func(host string) ([]net.IP, error)) ([]string, error) {
+	var cidrs []string
+	for _, cidr := range egress.AllowedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, fmt.Errorf("invalid allowed CIDR %q: %w", cidr, err)
+		}
+		cidrs = append(cidrs, cidr)
+	}
+
+	for _, domain := range egress.AllowedDomains {
+		ips, err := lookupIP(domain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve allowed domain %q: %w", domain, err)
+		}
+		for _, ip := range ips {
+			if ip.To4() != nil {
+				cidrs = append(cidrs, ip.String()+"/32")
+			} else {
+				cidrs = append(cidrs, ip.String()+"/128")
+			}
+		}
+	}
+
+	return cidrs, nil
+}
+
+// RenderWorkspaceEgressPolicies renders the NetworkPolicy manifests for the
+// given egress config and resolved allow-list CIDRs. The result is empty when
+// neither default deny nor any CIDRs are configured.
+func RenderWorkspaceEgressPolicies(egress *files.WorkspaceEgressConfig, cidrs []string) ([]byte, error) {
+	tmpl, err := template.New("workspace-egress").Parse(string(workspaceEgressTemplate))
+	if err != nil {
+		return nil, fmt.Errorf("parsing workspace egress template: %w", err)
+	}
+
+	namespace := egress.Namespace
+	if strings.TrimSpace(namespace) == "" {
+		namespace = DefaultWorkspaceNamespace
+	}
+
+	data := workspaceEgressTemplateData{
+		Namespace:    namespace,
+		DefaultDeny:  egress.DefaultDeny,
+		AllowedCIDRs: cidrs,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("rendering workspace egress template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// EnsureWorkspaceEgressPolicies applies the egress NetworkPolicies configured
+// via codesphere.workspaceEgress to the workspace namespace before the
+// platform is installed. It is a no-op when the config does not restrict
+// workspace egress.
+func EnsureWorkspaceEgressPolicies(ctx context.Context, vaultPath, privKey string, cfg files.RootConfig) error {
+	egress := cfg.Codesphere.WorkspaceEgress
+	if egress == nil {
+		return nil
+	}
+
+	cidrs, err := ResolveWorkspaceEgressCIDRs(egress, net.LookupIP)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := RenderWorkspaceEgressPolicies(egress, cidrs)
+	if err != nil {
+		return err
+	}
+
+	objects, err := util.DecodeMultiDocYAML(rendered)
+	if err != nil {
+		return fmt.Errorf("decoding workspace egress policies: %w", err)
+	}
+	if len(objects) == 0 {
+		return nil
+	}
+
+	_, restConfig, err := VaultAndRESTConfig(vaultPath, privKey, cfg)
+	if err != nil {
+		return err
+	}
+	_, dynClient, err := util.NewClientsFromRESTConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	for _, obj := range objects {
+		gvr, err := util.GvrForUnstructured(obj)
+		if err != nil {
+			return fmt.Errorf("resolving GVR for %s: %w", obj.GetKind(), err)
+		}
+		if err := util.ApplyUnstructured(ctx, dynClient, gvr, obj); err != nil {
+			return fmt.Errorf("applying workspace egress policy: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package installer_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/codesphere-cloud/oms/internal/installer"
+	"github.com/codesphere-cloud/oms/internal/installer/files"
+	"github.com/codesphere-cloud/oms/internal/util"
+)
+
+var _ = Describe("ResolveWorkspaceEgressCIDRs", func() {
+	noLookup := func(host string) ([]net.IP, error) {
+		return nil, fmt.Errorf("unexpected lookup of %s", host)
+	}
+
+	It("passes through valid CIDRs", func() {
+		cidrs, err := installer.ResolveWorkspaceEgressCIDRs(&files.WorkspaceEgressConfig{
+			AllowedCIDRs: []string{"10.0.0.0/8", "192.168.1.0/24"},
+		}, noLookup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cidrs).To(Equal([]string{"10.0.0.0/8", "192.168.1.0/24"}))
+	})
+
+	It("rejects malformed CIDRs", func() {
+		_, err := installer.ResolveWorkspaceEgressCIDRs(&files.WorkspaceEgressConfig{
+			AllowedCIDRs: []string{"not-a-cidr"},
+		}, noLookup)
+		Expect(err).To(MatchError(ContainSubstring(`invalid allowed CIDR "not-a-cidr"`)))
+	})
+
+	It("resolves allowed domains to host CIDRs", func() {
+		lookup := func(host string) ([]net.IP, error) {
+			Expect(host).To(Equal("registry.example.com"))
+			return []net.IP{net.ParseIP("203.0.113.10"), net.ParseIP("2001:db8::1")}, nil
+		}
+
+		cidrs, err := installer.ResolveWorkspaceEgressCIDRs(&files.WorkspaceEgressConfig{
+			AllowedDomains: []string{"registry.example.com"},
+		}, lookup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cidrs).To(Equal([]string{"203.0.113.10/32", "2001:db8::1/128"}))
+	})
+
+	It("fails when a domain cannot be resolved", func() {
+		lookup := func(host string) ([]net.IP, error) {
+			return nil, fmt.Errorf("no such host")
+		}
+
+		_, err := installer.ResolveWorkspaceEgressCIDRs(&files.WorkspaceEgressConfig{
+			AllowedDomains: []string{"gone.example.com"},
+		}, lookup)
+		Expect(err).To(MatchError(ContainSubstring(`failed to resolve allowed domain "gone.example.com"`)))
+	})
+})
+
+var _ = Describe("RenderWorkspaceEgressPolicies", func() {
+	It("renders a default-deny policy that keeps DNS reachable", func() {
+		rendered, err := installer.RenderWorkspaceEgressPolicies(&files.WorkspaceEgressConfig{
+			DefaultDeny: true,
+		}, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		objects, err := util.DecodeMultiDocYAML(rendered)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(objects).To(HaveLen(1))
+		Expect(objects[0].GetKind()).To(Equal("NetworkPolicy"))
+		Expect(objects[0].GetName()).To(Equal("workspace-egress-default-deny"))
+		Expect(objects[0].GetNamespace()).To(Equal(installer.DefaultWorkspaceNamespace))
+		Expect(string(rendered)).To(ContainSubstring("port: 53"))
+	})
+
+	It("renders an allow-list policy with the resolved CIDRs", func() {
+		rendered, err := installer.RenderWorkspaceEgressPolicies(&files.WorkspaceEgressConfig{
+			DefaultDeny: true,
+			Namespace:   "workspaces",
+		}, []string{"10.0.0.0/8", "203.0.113.10/32"})
+		Expect(err).ToNot(HaveOccurred())
+
+		objects, err := util.DecodeMultiDocYAML(rendered)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(objects).To(HaveLen(2))
+		Expect(objects[1].GetName()).To(Equal("workspace-egress-allowlist"))
+		Expect(objects[1].GetNamespace()).To(Equal("workspaces"))
+		Expect(string(rendered)).To(ContainSubstring("cidr: 10.0.0.0/8"))
+		Expect(string(rendered)).To(ContainSubstring("cidr: 203.0.113.10/32"))
+	})
+
+	It("renders nothing when egress is not restricted", func() {
+		rendered, err := installer.RenderWorkspaceEgressPolicies(&files.WorkspaceEgressConfig{}, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		objects, err := util.DecodeMultiDocYAML(rendered)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(objects).To(BeEmpty())
+	})
+})
+
+var _ = Describe("EnsureWorkspaceEgressPolicies", func() {
+	It("is a no-op when workspace egress is not configured", func() {
+		err := installer.EnsureWorkspaceEgressPolicies(context.Background(), "", "", files.RootConfig{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("fails on invalid CIDRs before touching the vault", func() {
+		err := installer.EnsureWorkspaceEgressPolicies(context.Background(), "", "", files.RootConfig{
+			Codesphere: files.CodesphereConfig{
+				WorkspaceEgress: &files.WorkspaceEgressConfig{
+					AllowedCIDRs: []string{"bogus"},
+				},
+			},
+		})
+		Expect(err).To(MatchError(ContainSubstring(`invalid allowed CIDR "bogus"`)))
+	})
+})
@@ -471,6 +471,172 @@ func (_c *MockPortal_GetBuild_Call) RunAndReturn(run func(product Product, versi
 	return _c
 }
 
+// GetDefaultFlags provides a mock function for the type MockPortal
+func (_mock *MockPortal) GetDefaultFlags(version string) (DefaultFlags, error) {
+	ret := _mock.Called(version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDefaultFlags")
+	}
+
+	var r0 DefaultFlags
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (DefaultFlags, error)); ok {
+		return returnFunc(version)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) DefaultFlags); ok {
+		r0 = returnFunc(version)
+	} else {
+		r0 = ret.Get(0).(DefaultFlags)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(version)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPortal_GetDefaultFlags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDefaultFlags'
+type MockPortal_GetDefaultFlags_Call struct {
+	*mock.Call
+}
+
+// GetDefaultFlags is a helper method to define mock.On call
+//   - version string
+func (_e *MockPortal_Expecter) GetDefaultFlags(version any) *MockPortal_GetDefaultFlags_Call {
+	return &MockPortal_GetDefaultFlags_Call{Call: _e.mock.On("GetDefaultFlags", version)}
+}
+
+func (_c *MockPortal_GetDefaultFlags_Call) Run(run func(version string)) *MockPortal_GetDefaultFlags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPortal_GetDefaultFlags_Call) Return(defaultFlags DefaultFlags, err error) *MockPortal_GetDefaultFlags_Call {
+	_c.Call.Return(defaultFlags, err)
+	return _c
+}
+
+func (_c *MockPortal_GetDefaultFlags_Call) RunAndReturn(run func(version string) (DefaultFlags, error)) *MockPortal_GetDefaultFlags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEntitlement provides a mock function for the type MockPortal
+func (_mock *MockPortal) GetEntitlement() (Entitlement, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEntitlement")
+	}
+
+	var r0 Entitlement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() (Entitlement, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() Entitlement); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(Entitlement)
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPortal_GetEntitlement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEntitlement'
+type MockPortal_GetEntitlement_Call struct {
+	*mock.Call
+}
+
+// GetEntitlement is a helper method to define mock.On call
+func (_e *MockPortal_Expecter) GetEntitlement() *MockPortal_GetEntitlement_Call {
+	return &MockPortal_GetEntitlement_Call{Call: _e.mock.On("GetEntitlement")}
+}
+
+func (_c *MockPortal_GetEntitlement_Call) Run(run func()) *MockPortal_GetEntitlement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPortal_GetEntitlement_Call) Return(entitlement Entitlement, err error) *MockPortal_GetEntitlement_Call {
+	_c.Call.Return(entitlement, err)
+	return _c
+}
+
+func (_c *MockPortal_GetEntitlement_Call) RunAndReturn(run func() (Entitlement, error)) *MockPortal_GetEntitlement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetVersionMatrix provides a mock function for the type MockPortal
+func (_mock *MockPortal) GetVersionMatrix() (VersionMatrix, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVersionMatrix")
+	}
+
+	var r0 VersionMatrix
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() (VersionMatrix, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() VersionMatrix); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(VersionMatrix)
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPortal_GetVersionMatrix_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVersionMatrix'
+type MockPortal_GetVersionMatrix_Call struct {
+	*mock.Call
+}
+
+// GetVersionMatrix is a helper method to define mock.On call
+func (_e *MockPortal_Expecter) GetVersionMatrix() *MockPortal_GetVersionMatrix_Call {
+	return &MockPortal_GetVersionMatrix_Call{Call: _e.mock.On("GetVersionMatrix")}
+}
+
+func (_c *MockPortal_GetVersionMatrix_Call) Run(run func()) *MockPortal_GetVersionMatrix_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPortal_GetVersionMatrix_Call) Return(versionMatrix VersionMatrix, err error) *MockPortal_GetVersionMatrix_Call {
+	_c.Call.Return(versionMatrix, err)
+	return _c
+}
+
+func (_c *MockPortal_GetVersionMatrix_Call) RunAndReturn(run func() (VersionMatrix, error)) *MockPortal_GetVersionMatrix_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListAPIKeys provides a mock function for the type MockPortal
 func (_mock *MockPortal) ListAPIKeys() ([]ApiKey, error) {
 	ret := _mock.Called()
@@ -31,6 +31,18 @@ type Portal interface {
 	UpdateAPIKey(key string, expiresAt time.Time) error
 	ListAPIKeys() ([]ApiKey, error)
 	GetApiKeyId(oldKey string) (string, error)
+	GetEntitlement() (Entitlement, error)
+	GetDefaultFlags(version string) (DefaultFlags, error)
+	GetVersionMatrix() (VersionMatrix, error)
+}
+
+// Entitlement describes the customer's license as known by the portal.
+// A zero ExpiresAt means the license does not expire.
+type Entitlement struct {
+	Seats       int       `json:"seats"`
+	Features    []string  `json:"features"`
+	MaxReplicas int       `json:"maxReplicas"`
+	ExpiresAt   time.Time `json:"expiresAt"`
 }
 
 type PortalClient struct {
@@ -447,6 +459,89 @@ func (c *PortalClient) GetApiKeyId(oldKey string) (string, error) {
 	return result.KeyID, nil
 }
 
+// GetEntitlement retrieves the customer's entitlement (seats, licensed
+// features and plan limits) from the portal.
+func (c *PortalClient) GetEntitlement() (Entitlement, error) {
+	res, _, err := c.GetBody("/entitlement")
+	if err != nil {
+		return Entitlement{}, fmt.Errorf("failed to get entitlement: %w", err)
+	}
+
+	var entitlement Entitlement
+	if err := json.Unmarshal(res, &entitlement); err != nil {
+		return Entitlement{}, fmt.Errorf("failed to parse entitlement response: %w", err)
+	}
+
+	return entitlement, nil
+}
+
+// DefaultFlags are the internal/preview/feature flag defaults the portal
+// publishes as metadata of a Codesphere release.
+type DefaultFlags struct {
+	Internal []string `json:"internal"`
+	Preview  []string `json:"preview"`
+	Feature  []string `json:"feature"`
+}
+
+// GetDefaultFlags retrieves the flag defaults published for a Codesphere
+// release, so new experiments ship as release metadata instead of an OMS
+// code change.
+func (c *PortalClient) GetDefaultFlags(version string) (DefaultFlags, error) {
+	res, _, err := c.GetBody(fmt.Sprintf("/packages/%s/%s/default-flags", CodesphereProduct, version))
+	if err != nil {
+		return DefaultFlags{}, fmt.Errorf("failed to get default flags: %w", err)
+	}
+
+	var flags DefaultFlags
+	if err := json.Unmarshal(res, &flags); err != nil {
+		return DefaultFlags{}, fmt.Errorf("failed to parse default flags response: %w", err)
+	}
+
+	return flags, nil
+}
+
+// VersionMatrixEntry lists what is supported alongside one Codesphere
+// release: the k0s releases it was verified with and the oldest oms-cli
+// version that can operate it.
+type VersionMatrixEntry struct {
+	CodesphereVersion string   `json:"codesphereVersion"`
+	K0sVersions       []string `json:"k0sVersions"`
+	MinOmsVersion     string   `json:"minOmsVersion"`
+}
+
+// VersionMatrix is the supported-version matrix published by the portal.
+type VersionMatrix struct {
+	Entries []VersionMatrixEntry `json:"entries"`
+}
+
+// Entry returns the matrix entry of the given Codesphere release, or nil when
+// the release is not listed.
+func (m VersionMatrix) Entry(codesphereVersion string) *VersionMatrixEntry {
+	for i := range m.Entries {
+		if m.Entries[i].CodesphereVersion == codesphereVersion {
+			return &m.Entries[i]
+		}
+	}
+	return nil
+}
+
+// GetVersionMatrix retrieves the supported-version matrix, so version skew
+// between Codesphere, k0s and the oms-cli is caught before an operation
+// rather than debugged afterwards.
+func (c *PortalClient) GetVersionMatrix() (VersionMatrix, error) {
+	res, _, err := c.GetBody("/version-matrix")
+	if err != nil {
+		return VersionMatrix{}, fmt.Errorf("failed to get version matrix: %w", err)
+	}
+
+	var matrix VersionMatrix
+	if err := json.Unmarshal(res, &matrix); err != nil {
+		return VersionMatrix{}, fmt.Errorf("failed to parse version matrix response: %w", err)
+	}
+
+	return matrix, nil
+}
+
 // GetHealth checks the response of the OMS-Portal health endpoint
 // returns an error if portal is unhealthy
 func (c *PortalClient) GetHealth() error {
@@ -633,6 +633,69 @@ var _ = Describe("PortalClient", func() {
 		})
 	})
 
+	Describe("GetEntitlement", func() {
+		Context("when the portal returns an entitlement", func() {
+			BeforeEach(func() {
+				responseBody, _ := json.Marshal(portal.Entitlement{
+					Seats:       50,
+					Features:    []string{"agents", "managed-services"},
+					MaxReplicas: 5,
+				})
+
+				mockHttpClient.EXPECT().Do(mock.Anything).Return(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader(responseBody)),
+				}, nil)
+
+				mockEnv.EXPECT().GetOmsPortalApi().Return(apiUrl)
+				mockEnv.EXPECT().GetOmsPortalApiKey().Return(apiKey, apiKeyErr)
+			})
+
+			It("returns the entitlement", func() {
+				entitlement, err := client.GetEntitlement()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(entitlement.Seats).To(Equal(50))
+				Expect(entitlement.Features).To(ContainElement("agents"))
+				Expect(entitlement.MaxReplicas).To(Equal(5))
+			})
+		})
+	})
+
+	Describe("GetVersionMatrix", func() {
+		Context("when the portal returns a matrix", func() {
+			BeforeEach(func() {
+				responseBody, _ := json.Marshal(portal.VersionMatrix{
+					Entries: []portal.VersionMatrixEntry{
+						{
+							CodesphereVersion: "v1.2.3",
+							K0sVersions:       []string{"v1.33.2+k0s.0"},
+							MinOmsVersion:     "1.5.0",
+						},
+					},
+				})
+
+				mockHttpClient.EXPECT().Do(mock.Anything).Return(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader(responseBody)),
+				}, nil)
+
+				mockEnv.EXPECT().GetOmsPortalApi().Return(apiUrl)
+				mockEnv.EXPECT().GetOmsPortalApiKey().Return(apiKey, apiKeyErr)
+			})
+
+			It("returns the matrix with its entries resolvable by release", func() {
+				matrix, err := client.GetVersionMatrix()
+				Expect(err).NotTo(HaveOccurred())
+
+				entry := matrix.Entry("v1.2.3")
+				Expect(entry).NotTo(BeNil())
+				Expect(entry.K0sVersions).To(ContainElement("v1.33.2+k0s.0"))
+				Expect(entry.MinOmsVersion).To(Equal("1.5.0"))
+				Expect(matrix.Entry("v9.9.9")).To(BeNil())
+			})
+		})
+	})
+
 	Describe("GetHealth", func() {
 		Context("portal reports healthy status", func() {
 			BeforeEach(func() {
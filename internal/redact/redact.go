@@ -0,0 +1,67 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redact masks known secret values (registry passwords, access
+// tokens, service account keys) in log output and error messages so they
+// never leak into logs or reports.
+package redact
+
+import (
+	"io"
+	"strings"
+	"sync"
+)
+
+// Mask replaces registered secret values in redacted output.
+const Mask = "[REDACTED]"
+
+// minSecretLength guards against registering trivial values that would
+// mangle unrelated output.
+const minSecretLength = 4
+
+var (
+	mu      sync.RWMutex
+	secrets []string
+)
+
+// Add registers secret values to mask in all subsequently redacted output.
+// Empty and very short values are ignored.
+func Add(values ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, value := range values {
+		if len(value) < minSecretLength {
+			continue
+		}
+		secrets = append(secrets, value)
+	}
+}
+
+// Redact replaces every registered secret value in s with the mask.
+func Redact(s string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, Mask)
+	}
+	return s
+}
+
+// NewWriter wraps w so every chunk written through it is redacted. Used for
+// streamed remote command output.
+func NewWriter(w io.Writer) io.Writer {
+	return &writer{out: w}
+}
+
+type writer struct {
+	out io.Writer
+}
+
+// Write redacts the chunk and reports the original length so callers see a
+// complete write even when the mask changes the size.
+func (w *writer) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(w.out, Redact(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package redact_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/codesphere-cloud/oms/internal/redact"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestRedact(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Redact Suite")
+}
+
+var _ = Describe("Redact", func() {
+	It("masks registered secret values", func() {
+		redact.Add("s3cret-registry-password")
+		Expect(redact.Redact("login with s3cret-registry-password now")).
+			To(Equal("login with " + redact.Mask + " now"))
+	})
+
+	It("masks multiple occurrences and multiple secrets", func() {
+		redact.Add("token-one", "token-two")
+		Expect(redact.Redact("token-one token-two token-one")).
+			To(Equal(redact.Mask + " " + redact.Mask + " " + redact.Mask))
+	})
+
+	It("ignores empty and very short values", func() {
+		redact.Add("", "ab")
+		Expect(redact.Redact("ab cd")).To(Equal("ab cd"))
+	})
+
+	It("leaves output without secrets untouched", func() {
+		Expect(redact.Redact("nothing to hide")).To(Equal("nothing to hide"))
+	})
+})
+
+var _ = Describe("NewWriter", func() {
+	It("redacts streamed output", func() {
+		redact.Add("stream-secret-value")
+
+		var out bytes.Buffer
+		w := redact.NewWriter(&out)
+		n, err := w.Write([]byte("before stream-secret-value after"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(len("before stream-secret-value after")))
+		Expect(out.String()).To(Equal("before " + redact.Mask + " after"))
+	})
+})
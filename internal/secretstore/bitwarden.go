@@ -0,0 +1,77 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secretstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// BitwardenStore pushes credentials as login items via the REST API of a
+// locally running `bw serve` (https://bitwarden.com/help/cli/#serve). The
+// operator unlocks the vault in the bw CLI, so no token handling is needed
+// here.
+type BitwardenStore struct {
+	Config     Config
+	HttpClient HttpClient
+}
+
+func NewBitwardenStore(config Config) *BitwardenStore {
+	return &BitwardenStore{
+		Config:     config,
+		HttpClient: http.DefaultClient,
+	}
+}
+
+type bitwardenItem struct {
+	Type     int            `json:"type"`
+	Name     string         `json:"name"`
+	FolderID string         `json:"folderId,omitempty"`
+	Login    bitwardenLogin `json:"login"`
+}
+
+type bitwardenLogin struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// bitwardenItemTypeLogin is the item type for login entries in the bw API.
+const bitwardenItemTypeLogin = 1
+
+func (s *BitwardenStore) Push(credential Credential) error {
+	item := bitwardenItem{
+		Type:     bitwardenItemTypeLogin,
+		Name:     credential.Name,
+		FolderID: s.Config.Vault,
+		Login: bitwardenLogin{
+			Username: credential.Username,
+			Password: credential.Password,
+		},
+	}
+	body, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("failed to marshal item: %w", err)
+	}
+
+	url := strings.TrimSuffix(s.Config.URL, "/") + "/object/item"
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.HttpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("bitwarden serve returned status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secretstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// VaultStore pushes credentials into a HashiCorp Vault KV v2 engine. The
+// Vault token is read from VAULT_TOKEN.
+type VaultStore struct {
+	Config     Config
+	HttpClient HttpClient
+}
+
+func NewVaultStore(config Config) *VaultStore {
+	return &VaultStore{
+		Config:     config,
+		HttpClient: http.DefaultClient,
+	}
+}
+
+type vaultWriteRequest struct {
+	Data map[string]string `json:"data"`
+}
+
+func (s *VaultStore) Push(credential Credential) error {
+	token := os.Getenv("VAULT_TOKEN")
+	if token == "" {
+		return fmt.Errorf("VAULT_TOKEN is not set")
+	}
+
+	mount := s.Config.Vault
+	if mount == "" {
+		mount = "secret"
+	}
+
+	body, err := json.Marshal(vaultWriteRequest{Data: map[string]string{
+		"username": credential.Username,
+		"password": credential.Password,
+	}})
+	if err != nil {
+		return fmt.Errorf("failed to marshal secret: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/v1/%s/data/%s", strings.TrimSuffix(s.Config.URL, "/"), mount, credential.Name)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.HttpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("vault returned status %s", resp.Status)
+	}
+	return nil
+}
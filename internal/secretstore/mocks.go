@@ -0,0 +1,177 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package secretstore
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	"net/http"
+)
+
+// NewMockSecretStore creates a new instance of MockSecretStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSecretStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSecretStore {
+	mock := &MockSecretStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSecretStore is an autogenerated mock type for the SecretStore type
+type MockSecretStore struct {
+	mock.Mock
+}
+
+type MockSecretStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSecretStore) EXPECT() *MockSecretStore_Expecter {
+	return &MockSecretStore_Expecter{mock: &_m.Mock}
+}
+
+// Push provides a mock function for the type MockSecretStore
+func (_mock *MockSecretStore) Push(credential Credential) error {
+	ret := _mock.Called(credential)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Push")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(Credential) error); ok {
+		r0 = returnFunc(credential)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSecretStore_Push_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Push'
+type MockSecretStore_Push_Call struct {
+	*mock.Call
+}
+
+// Push is a helper method to define mock.On call
+//   - credential Credential
+func (_e *MockSecretStore_Expecter) Push(credential any) *MockSecretStore_Push_Call {
+	return &MockSecretStore_Push_Call{Call: _e.mock.On("Push", credential)}
+}
+
+func (_c *MockSecretStore_Push_Call) Run(run func(credential Credential)) *MockSecretStore_Push_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 Credential
+		if args[0] != nil {
+			arg0 = args[0].(Credential)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretStore_Push_Call) Return(err error) *MockSecretStore_Push_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSecretStore_Push_Call) RunAndReturn(run func(credential Credential) error) *MockSecretStore_Push_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockHttpClient creates a new instance of MockHttpClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHttpClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHttpClient {
+	mock := &MockHttpClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockHttpClient is an autogenerated mock type for the HttpClient type
+type MockHttpClient struct {
+	mock.Mock
+}
+
+type MockHttpClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHttpClient) EXPECT() *MockHttpClient_Expecter {
+	return &MockHttpClient_Expecter{mock: &_m.Mock}
+}
+
+// Do provides a mock function for the type MockHttpClient
+func (_mock *MockHttpClient) Do(request *http.Request) (*http.Response, error) {
+	ret := _mock.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Do")
+	}
+
+	var r0 *http.Response
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*http.Request) (*http.Response, error)); ok {
+		return returnFunc(request)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*http.Request) *http.Response); ok {
+		r0 = returnFunc(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*http.Response)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = returnFunc(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockHttpClient_Do_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Do'
+type MockHttpClient_Do_Call struct {
+	*mock.Call
+}
+
+// Do is a helper method to define mock.On call
+//   - request *http.Request
+func (_e *MockHttpClient_Expecter) Do(request any) *MockHttpClient_Do_Call {
+	return &MockHttpClient_Do_Call{Call: _e.mock.On("Do", request)}
+}
+
+func (_c *MockHttpClient_Do_Call) Run(run func(request *http.Request)) *MockHttpClient_Do_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *http.Request
+		if args[0] != nil {
+			arg0 = args[0].(*http.Request)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHttpClient_Do_Call) Return(response *http.Response, err error) *MockHttpClient_Do_Call {
+	_c.Call.Return(response, err)
+	return _c
+}
+
+func (_c *MockHttpClient_Do_Call) RunAndReturn(run func(request *http.Request) (*http.Response, error)) *MockHttpClient_Do_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,88 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secretstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// OnePasswordStore pushes credentials as login items via a 1Password Connect
+// server (https://developer.1password.com/docs/connect). The Connect access
+// token is read from OP_CONNECT_TOKEN.
+type OnePasswordStore struct {
+	Config     Config
+	HttpClient HttpClient
+}
+
+func NewOnePasswordStore(config Config) *OnePasswordStore {
+	return &OnePasswordStore{
+		Config:     config,
+		HttpClient: http.DefaultClient,
+	}
+}
+
+type onePasswordItem struct {
+	Title    string              `json:"title"`
+	Category string              `json:"category"`
+	Vault    onePasswordVaultRef `json:"vault"`
+	Fields   []onePasswordField  `json:"fields"`
+}
+
+type onePasswordVaultRef struct {
+	ID string `json:"id"`
+}
+
+type onePasswordField struct {
+	ID      string `json:"id"`
+	Purpose string `json:"purpose"`
+	Value   string `json:"value"`
+}
+
+func (s *OnePasswordStore) Push(credential Credential) error {
+	token := os.Getenv("OP_CONNECT_TOKEN")
+	if token == "" {
+		return fmt.Errorf("OP_CONNECT_TOKEN is not set")
+	}
+	if s.Config.Vault == "" {
+		return fmt.Errorf("1password requires the vault ID to push into")
+	}
+
+	item := onePasswordItem{
+		Title:    credential.Name,
+		Category: "LOGIN",
+		Vault:    onePasswordVaultRef{ID: s.Config.Vault},
+		Fields: []onePasswordField{
+			{ID: "username", Purpose: "USERNAME", Value: credential.Username},
+			{ID: "password", Purpose: "PASSWORD", Value: credential.Password},
+		},
+	}
+	body, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("failed to marshal item: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/v1/vaults/%s/items", strings.TrimSuffix(s.Config.URL, "/"), s.Config.Vault)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.HttpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("1password connect returned status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package secretstore pushes credentials generated during bootstrap into an
+// external password manager, so operators can hand them to their security
+// team without copying them out of the vault file by hand.
+package secretstore
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Supported store types.
+const (
+	Type1Password = "1password"
+	TypeBitwarden = "bitwarden"
+	TypeVault     = "vault"
+)
+
+// Credential is one generated secret to push, e.g. the registry login or the
+// postgres admin password.
+type Credential struct {
+	Name     string
+	Username string
+	Password string
+}
+
+// Config selects and locates the secret store for one environment. Access
+// tokens are not part of the config; each store reads its own token from the
+// environment (OP_CONNECT_TOKEN, VAULT_TOKEN) so they never end up in files.
+type Config struct {
+	// Type is one of 1password, bitwarden or vault.
+	Type string
+	// URL of the store API: the 1Password Connect server, the local
+	// `bw serve` REST API, or the Vault server address.
+	URL string
+	// Vault scopes pushed credentials inside the store: the 1Password vault
+	// ID, the Bitwarden folder ID, or the Vault KV v2 mount (default: secret).
+	Vault string
+}
+
+//mockery:generate: true
+type SecretStore interface {
+	Push(credential Credential) error
+}
+
+//mockery:generate: true
+type HttpClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// New creates the secret store selected by the config.
+func New(config Config) (SecretStore, error) {
+	switch config.Type {
+	case Type1Password:
+		return NewOnePasswordStore(config), nil
+	case TypeBitwarden:
+		return NewBitwardenStore(config), nil
+	case TypeVault:
+		return NewVaultStore(config), nil
+	default:
+		return nil, fmt.Errorf("unsupported secret store type %q (supported: %s, %s, %s)",
+			config.Type, Type1Password, TypeBitwarden, TypeVault)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secretstore_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/codesphere-cloud/oms/internal/secretstore"
+)
+
+// setEnv sets an environment variable for the duration of the spec.
+func setEnv(key, value string) {
+	previous, hadPrevious := os.LookupEnv(key)
+	Expect(os.Setenv(key, value)).To(Succeed())
+	DeferCleanup(func() {
+		if hadPrevious {
+			Expect(os.Setenv(key, previous)).To(Succeed())
+		} else {
+			Expect(os.Unsetenv(key)).To(Succeed())
+		}
+	})
+}
+
+var _ = Describe("New", func() {
+	It("creates a store for every supported type", func() {
+		for _, storeType := range []string{"1password", "bitwarden", "vault"} {
+			store, err := secretstore.New(secretstore.Config{Type: storeType})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(store).NotTo(BeNil())
+		}
+	})
+
+	It("rejects unsupported types", func() {
+		_, err := secretstore.New(secretstore.Config{Type: "keepass"})
+		Expect(err).To(MatchError(ContainSubstring("unsupported secret store type")))
+	})
+})
+
+var _ = Describe("OnePasswordStore", func() {
+	credential := secretstore.Credential{Name: "codesphere-registry", Username: "custom-registry", Password: "hunter2"}
+
+	It("creates a login item via the Connect API", func() {
+		var request *http.Request
+		var body map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			request = r
+			data, err := io.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(json.Unmarshal(data, &body)).To(Succeed())
+			w.WriteHeader(http.StatusOK)
+		}))
+		DeferCleanup(server.Close)
+		setEnv("OP_CONNECT_TOKEN", "connect-token")
+
+		store := secretstore.NewOnePasswordStore(secretstore.Config{URL: server.URL, Vault: "vault-id"})
+		Expect(store.Push(credential)).To(Succeed())
+
+		Expect(request.URL.Path).To(Equal("/v1/vaults/vault-id/items"))
+		Expect(request.Header.Get("Authorization")).To(Equal("Bearer connect-token"))
+		Expect(body["title"]).To(Equal("codesphere-registry"))
+		Expect(body["category"]).To(Equal("LOGIN"))
+	})
+
+	It("fails without OP_CONNECT_TOKEN", func() {
+		setEnv("OP_CONNECT_TOKEN", "")
+		store := secretstore.NewOnePasswordStore(secretstore.Config{URL: "http://localhost", Vault: "vault-id"})
+		Expect(store.Push(credential)).To(MatchError(ContainSubstring("OP_CONNECT_TOKEN is not set")))
+	})
+
+	It("fails without a vault ID", func() {
+		setEnv("OP_CONNECT_TOKEN", "connect-token")
+		store := secretstore.NewOnePasswordStore(secretstore.Config{URL: "http://localhost"})
+		Expect(store.Push(credential)).To(MatchError(ContainSubstring("vault ID")))
+	})
+})
+
+var _ = Describe("BitwardenStore", func() {
+	credential := secretstore.Credential{Name: "codesphere-postgres-admin", Username: "postgres", Password: "hunter2"}
+
+	It("creates a login item via the bw serve API", func() {
+		var request *http.Request
+		var body map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			request = r
+			data, err := io.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(json.Unmarshal(data, &body)).To(Succeed())
+			w.WriteHeader(http.StatusOK)
+		}))
+		DeferCleanup(server.Close)
+
+		store := secretstore.NewBitwardenStore(secretstore.Config{URL: server.URL, Vault: "folder-id"})
+		Expect(store.Push(credential)).To(Succeed())
+
+		Expect(request.URL.Path).To(Equal("/object/item"))
+		Expect(body["name"]).To(Equal("codesphere-postgres-admin"))
+		Expect(body["folderId"]).To(Equal("folder-id"))
+		Expect(body["login"].(map[string]interface{})["password"]).To(Equal("hunter2"))
+	})
+
+	It("surfaces API errors", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		DeferCleanup(server.Close)
+
+		store := secretstore.NewBitwardenStore(secretstore.Config{URL: server.URL})
+		Expect(store.Push(credential)).To(MatchError(ContainSubstring("bitwarden serve returned status")))
+	})
+})
+
+var _ = Describe("VaultStore", func() {
+	credential := secretstore.Credential{Name: "codesphere-registry", Username: "custom-registry", Password: "hunter2"}
+
+	It("writes to the KV v2 engine", func() {
+		var request *http.Request
+		var body map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			request = r
+			data, err := io.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(json.Unmarshal(data, &body)).To(Succeed())
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		DeferCleanup(server.Close)
+		setEnv("VAULT_TOKEN", "vault-token")
+
+		store := secretstore.NewVaultStore(secretstore.Config{URL: server.URL})
+		Expect(store.Push(credential)).To(Succeed())
+
+		Expect(request.URL.Path).To(Equal("/v1/secret/data/codesphere-registry"))
+		Expect(request.Header.Get("X-Vault-Token")).To(Equal("vault-token"))
+		Expect(body["data"].(map[string]interface{})["username"]).To(Equal("custom-registry"))
+	})
+
+	It("uses the configured mount", func() {
+		var request *http.Request
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			request = r
+			w.WriteHeader(http.StatusOK)
+		}))
+		DeferCleanup(server.Close)
+		setEnv("VAULT_TOKEN", "vault-token")
+
+		store := secretstore.NewVaultStore(secretstore.Config{URL: server.URL, Vault: "codesphere"})
+		Expect(store.Push(credential)).To(Succeed())
+		Expect(request.URL.Path).To(Equal("/v1/codesphere/data/codesphere-registry"))
+	})
+
+	It("fails without VAULT_TOKEN", func() {
+		setEnv("VAULT_TOKEN", "")
+		store := secretstore.NewVaultStore(secretstore.Config{URL: "http://localhost"})
+		Expect(store.Push(credential)).To(MatchError(ContainSubstring("VAULT_TOKEN is not set")))
+	})
+})
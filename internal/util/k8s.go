@@ -70,6 +70,9 @@ var gvrMappings = map[string]string{
 	"ServiceAccount": "serviceaccounts",
 	"Role":           "roles",
 	"RoleBinding":    "rolebindings",
+	"NetworkPolicy":  "networkpolicies",
+	"ConfigMap":      "configmaps",
+	"DaemonSet":      "daemonsets",
 }
 
 // GvrForUnstructured maps an unstructured object's GVK to the appropriate GVR.
@@ -183,8 +183,8 @@ var _ = Describe("GvrForUnstructured", func() {
 
 	It("returns an error for an unknown kind", func() {
 		obj := &unstructured.Unstructured{}
-		obj.SetAPIVersion("v1")
-		obj.SetKind("ConfigMap")
+		obj.SetAPIVersion("stable.example.com/v1")
+		obj.SetKind("CronTab")
 
 		_, err := util.GvrForUnstructured(obj)
 		Expect(err).To(HaveOccurred())
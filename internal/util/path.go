@@ -9,11 +9,13 @@ import (
 	"strings"
 )
 
-// ExpandPath expands ~ to the user's home directory
+// ExpandPath expands a leading ~ to the user's home directory.
+// Both separator styles are accepted so paths like ~\.ssh\id_rsa
+// work for operators on Windows.
 func ExpandPath(path string) string {
-	if strings.HasPrefix(path, "~/") {
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, `~\`) {
 		if home, err := os.UserHomeDir(); err == nil {
-			return filepath.Join(home, path[2:])
+			return filepath.Join(home, path[1:])
 		}
 	}
 	return path
@@ -0,0 +1,48 @@
+// Copyright (c) Codesphere Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/codesphere-cloud/oms/internal/util"
+)
+
+var _ = Describe("ExpandPath", func() {
+	var home string
+
+	BeforeEach(func() {
+		var err error
+		home, err = os.UserHomeDir()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("expands a bare ~ to the home directory", func() {
+		Expect(util.ExpandPath("~")).To(Equal(home))
+	})
+
+	It("expands ~/ paths", func() {
+		Expect(util.ExpandPath("~/.ssh/id_rsa")).To(Equal(filepath.Join(home, ".ssh", "id_rsa")))
+	})
+
+	It(`expands ~\ paths written with Windows separators`, func() {
+		Expect(util.ExpandPath(`~\.ssh\id_rsa`)).To(HavePrefix(home))
+	})
+
+	It("leaves absolute paths untouched", func() {
+		Expect(util.ExpandPath("/etc/hosts")).To(Equal("/etc/hosts"))
+	})
+
+	It("leaves relative paths without ~ untouched", func() {
+		Expect(util.ExpandPath("keys/id_rsa")).To(Equal("keys/id_rsa"))
+	})
+
+	It("does not expand ~ in the middle of a path", func() {
+		Expect(util.ExpandPath("/data/~/file")).To(Equal("/data/~/file"))
+	})
+})